@@ -0,0 +1,146 @@
+package main
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+const (
+	defaultClientCacheSize = 1000
+	defaultClientCacheTTL  = 5 * time.Minute
+)
+
+// clientCache reuses godo clients across HTTP requests that carry the same
+// bearer token, so that the oauth2 client and retry wrapper are not rebuilt on
+// every tool call. Entries are keyed by a fingerprint of the token, expire
+// after ttl, and the least recently used entry is evicted once size clients
+// are cached. Expired entries are swept whenever a new client is cached, so
+// tokens that are never seen again do not linger until LRU eviction. A size of
+// zero or less disables caching.
+type clientCache struct {
+	size      int
+	ttl       time.Duration
+	newClient func(token string) (*godo.Client, error)
+	now       func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+type clientCacheEntry struct {
+	key     string
+	client  *godo.Client
+	expires time.Time
+}
+
+// newClientCache creates a client cache that builds missing clients with newClient.
+func newClientCache(size int, ttl time.Duration, newClient func(token string) (*godo.Client, error)) *clientCache {
+	return &clientCache{
+		size:      size,
+		ttl:       ttl,
+		newClient: newClient,
+		now:       time.Now,
+		entries:   make(map[string]*list.Element),
+		lru:       list.New(),
+	}
+}
+
+// tokenFingerprint returns the cache key for a token so raw tokens are never
+// used as map keys.
+func tokenFingerprint(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// get returns the cached client for token, creating and caching one if there
+// is no live entry.
+func (c *clientCache) get(token string) (*godo.Client, error) {
+	if c.size <= 0 {
+		return c.newClient(token)
+	}
+
+	key := tokenFingerprint(token)
+	if client, ok := c.lookup(key); ok {
+		return client, nil
+	}
+
+	// build the client outside the lock so a slow construction does not
+	// serialize requests for other tokens.
+	client, err := c.newClient(token)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// another request for the same token may have raced us; keep its client.
+	if el, ok := c.entries[key]; ok {
+		entry := el.Value.(*clientCacheEntry)
+		if c.now().Before(entry.expires) {
+			c.lru.MoveToFront(el)
+			return entry.client, nil
+		}
+		c.remove(el)
+	}
+
+	c.pruneExpired()
+	c.entries[key] = c.lru.PushFront(&clientCacheEntry{key: key, client: client, expires: c.now().Add(c.ttl)})
+	for c.lru.Len() > c.size {
+		c.remove(c.lru.Back())
+	}
+
+	return client, nil
+}
+
+// lookup returns a live cached client for key, dropping it if it has expired.
+func (c *clientCache) lookup(key string) (*godo.Client, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*clientCacheEntry)
+	if !c.now().Before(entry.expires) {
+		c.remove(el)
+		return nil, false
+	}
+	c.lru.MoveToFront(el)
+	return entry.client, true
+}
+
+// pruneExpired drops every expired entry. Recently used entries can be older
+// than less recently used ones, so the whole list is walked rather than just
+// its back. This only runs on a cache miss, which already pays for building a
+// client. The caller must hold c.mu.
+func (c *clientCache) pruneExpired() {
+	now := c.now()
+	for el := c.lru.Back(); el != nil; {
+		prev := el.Prev()
+		if !now.Before(el.Value.(*clientCacheEntry).expires) {
+			c.remove(el)
+		}
+		el = prev
+	}
+}
+
+// len returns the number of cached clients.
+func (c *clientCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// remove drops el from the cache. The caller must hold c.mu.
+func (c *clientCache) remove(el *list.Element) {
+	c.lru.Remove(el)
+	delete(c.entries, el.Value.(*clientCacheEntry).key)
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	middleware "mcp-digitalocean/internal"
+
+	"github.com/digitalocean/godo"
+)
+
+// countingFactory returns a client constructor that records how many clients
+// were built per token.
+func countingFactory() (func(token string) (*godo.Client, error), map[string]int) {
+	built := make(map[string]int)
+	return func(token string) (*godo.Client, error) {
+		built[token]++
+		return godo.NewFromToken(token), nil
+	}, built
+}
+
+func TestClientCache_ReusesClientForSameToken(t *testing.T) {
+	factory, built := countingFactory()
+	c := newClientCache(10, time.Minute, factory)
+
+	first, err := c.get("token-a")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	second, err := c.get("token-a")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+
+	if first != second {
+		t.Fatalf("expected the same client for repeated token")
+	}
+	if built["token-a"] != 1 {
+		t.Fatalf("built %d clients, want 1", built["token-a"])
+	}
+}
+
+func TestClientCache_DifferentTokensNeverShare(t *testing.T) {
+	factory, _ := countingFactory()
+	c := newClientCache(10, time.Minute, factory)
+
+	a, _ := c.get("token-a")
+	b, _ := c.get("token-b")
+	if a == b {
+		t.Fatalf("different tokens must not share a client")
+	}
+	if c.len() != 2 {
+		t.Fatalf("len = %d, want 2", c.len())
+	}
+}
+
+func TestClientCache_ExpiresAfterTTL(t *testing.T) {
+	factory, built := countingFactory()
+	c := newClientCache(10, 5*time.Minute, factory)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+
+	first, _ := c.get("token-a")
+	now = now.Add(4 * time.Minute)
+	if got, _ := c.get("token-a"); got != first {
+		t.Fatalf("expected cached client before TTL")
+	}
+
+	now = now.Add(2 * time.Minute)
+	second, _ := c.get("token-a")
+	if second == first {
+		t.Fatalf("expected a fresh client after TTL")
+	}
+	if built["token-a"] != 2 {
+		t.Fatalf("built %d clients, want 2", built["token-a"])
+	}
+}
+
+func TestClientCache_PrunesExpiredEntriesOnInsert(t *testing.T) {
+	factory, _ := countingFactory()
+	c := newClientCache(10, 5*time.Minute, factory)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+
+	_, _ = c.get("token-a")
+	_, _ = c.get("token-b")
+	now = now.Add(3 * time.Minute)
+	_, _ = c.get("token-c")
+	// touch a so the expired entry is not at the back of the list.
+	_, _ = c.get("token-a")
+
+	now = now.Add(3 * time.Minute)
+	_, _ = c.get("token-d")
+
+	// a and b expired and are dropped even though they were never looked up
+	// again; c is still live.
+	if c.len() != 2 {
+		t.Fatalf("len = %d, want 2", c.len())
+	}
+	if _, ok := c.lookup(tokenFingerprint("token-c")); !ok {
+		t.Fatalf("token-c should still be cached")
+	}
+}
+
+func TestClientCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	factory, built := countingFactory()
+	c := newClientCache(2, time.Minute, factory)
+
+	_, _ = c.get("token-a")
+	_, _ = c.get("token-b")
+	// touch a so b becomes the least recently used entry.
+	_, _ = c.get("token-a")
+	_, _ = c.get("token-c")
+
+	if c.len() != 2 {
+		t.Fatalf("len = %d, want 2", c.len())
+	}
+	_, _ = c.get("token-a")
+	if built["token-a"] != 1 {
+		t.Fatalf("token-a was evicted, want it kept")
+	}
+	_, _ = c.get("token-b")
+	if built["token-b"] != 2 {
+		t.Fatalf("token-b was not evicted")
+	}
+}
+
+func TestClientCache_DisabledWhenSizeIsZero(t *testing.T) {
+	factory, built := countingFactory()
+	c := newClientCache(0, time.Minute, factory)
+
+	_, _ = c.get("token-a")
+	_, _ = c.get("token-a")
+	if built["token-a"] != 2 {
+		t.Fatalf("built %d clients, want 2 with caching disabled", built["token-a"])
+	}
+	if c.len() != 0 {
+		t.Fatalf("len = %d, want 0", c.len())
+	}
+}
+
+func TestClientCache_DoesNotCacheErrors(t *testing.T) {
+	calls := 0
+	c := newClientCache(10, time.Minute, func(string) (*godo.Client, error) {
+		calls++
+		return nil, errors.New("boom")
+	})
+
+	if _, err := c.get("token-a"); err == nil {
+		t.Fatalf("expected error")
+	}
+	if _, err := c.get("token-a"); err == nil {
+		t.Fatalf("expected error")
+	}
+	if calls != 2 || c.len() != 0 {
+		t.Fatalf("calls = %d, len = %d; errors must not be cached", calls, c.len())
+	}
+}
+
+func TestClientFromContext_UsesBearerToken(t *testing.T) {
+	factory, built := countingFactory()
+	c := newClientCache(10, time.Minute, factory)
+
+	ctx := middleware.WithAuthKey(context.Background(), "Bearer token-a")
+	if _, err := clientFromContext(ctx, c); err != nil {
+		t.Fatalf("clientFromContext: %v", err)
+	}
+	if built["token-a"] != 1 {
+		t.Fatalf("expected client built for the bearer token, got %v", built)
+	}
+
+	for _, variant := range []string{"Bearer 'token-a'", "Bearer  token-a ", "Bearer token-a\n"} {
+		if _, err := clientFromContext(middleware.WithAuthKey(context.Background(), variant), c); err != nil {
+			t.Fatalf("clientFromContext(%q): %v", variant, err)
+		}
+	}
+	if built["token-a"] != 1 || c.len() != 1 {
+		t.Fatalf("token variants built %v clients, want one shared client", built)
+	}
+
+	if _, err := clientFromContext(context.Background(), c); err == nil {
+		t.Fatalf("expected error without auth header")
+	}
+	for _, empty := range []string{"Bearer", "Bearer ", "Bearer ''"} {
+		if _, err := clientFromContext(middleware.WithAuthKey(context.Background(), empty), c); err == nil {
+			t.Fatalf("clientFromContext(%q): expected error for an empty bearer token", empty)
+		}
+	}
+}
+
+func BenchmarkClientFromContext_Cached(b *testing.B) {
+	c := newClientCache(defaultClientCacheSize, defaultClientCacheTTL, func(token string) (*godo.Client, error) {
+		return newGodoClientWithTokenAndEndpoint(context.Background(), token, "https://api.digitalocean.com", buildUserAgent("", ""))
+	})
+	ctx := middleware.WithAuthKey(context.Background(), "Bearer token-a")
+
+	for b.Loop() {
+		if _, err := clientFromContext(ctx, c); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkClientFromContext_Uncached(b *testing.B) {
+	c := newClientCache(0, defaultClientCacheTTL, func(token string) (*godo.Client, error) {
+		return newGodoClientWithTokenAndEndpoint(context.Background(), token, "https://api.digitalocean.com", buildUserAgent("", ""))
+	})
+	ctx := middleware.WithAuthKey(context.Background(), "Bearer token-a")
+
+	for b.Loop() {
+		if _, err := clientFromContext(ctx, c); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
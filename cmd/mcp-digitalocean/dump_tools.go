@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"mcp-digitalocean/pkg/registry"
+	"mcp-digitalocean/pkg/registry/common"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// toolDump describes one registered tool in the --dump-tools output.
+type toolDump struct {
+	Name        string `json:"name"`
+	Destructive bool   `json:"destructive"`
+	// SchemaSHA256 is the digest of the tool's input schema as sent to
+	// clients, so schema changes between releases show up in a diff.
+	SchemaSHA256 string `json:"schema_sha256"`
+	// AliasOf names the canonical tool of an alias, with --include-aliases.
+	AliasOf string `json:"alias_of,omitempty"`
+}
+
+// dumpTools writes the tools registered on svr as a JSON array sorted by name.
+// Alias tools, which repeat their canonical tool, are left out unless
+// includeAliases is set.
+func dumpTools(w io.Writer, svr *server.MCPServer, includeAliases bool) error {
+	var tools []toolDump
+	for _, t := range svr.ListTools() {
+		aliasOf := registry.AliasOf(t.Tool)
+		if aliasOf != "" && !includeAliases {
+			continue
+		}
+		schema := []byte(t.Tool.RawInputSchema)
+		if schema == nil {
+			var err error
+			if schema, err = json.Marshal(t.Tool.InputSchema); err != nil {
+				return fmt.Errorf("marshal input schema of %s: %w", t.Tool.Name, err)
+			}
+		}
+		hint := t.Tool.Annotations.DestructiveHint
+		tools = append(tools, toolDump{
+			Name:         t.Tool.Name,
+			Destructive:  hint != nil && *hint,
+			SchemaSHA256: common.SchemaDigest(schema),
+			AliasOf:      aliasOf,
+		})
+	}
+	slices.SortFunc(tools, func(a, b toolDump) int { return strings.Compare(a.Name, b.Name) })
+
+	out, err := json.MarshalIndent(tools, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", out)
+	return err
+}
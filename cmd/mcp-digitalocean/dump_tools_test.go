@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+
+	"mcp-digitalocean/pkg/registry"
+	"mcp-digitalocean/pkg/registry/common"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func TestDumpTools(t *testing.T) {
+	svr := server.NewMCPServer(mcpName, mcpVersion)
+	_, err := registry.Register(slog.New(slog.NewTextHandler(io.Discard, nil)), svr, func(ctx context.Context) (*godo.Client, error) {
+		return godo.NewFromToken("token"), nil
+	}, registry.Options{}, "doks")
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := dumpTools(&out, svr, false); err != nil {
+		t.Fatalf("dumpTools: %v", err)
+	}
+	var tools []toolDump
+	if err := json.Unmarshal(out.Bytes(), &tools); err != nil {
+		t.Fatalf("unmarshal dump: %v", err)
+	}
+	if len(tools) != len(svr.ListTools()) {
+		t.Fatalf("dumped %d tools, want %d", len(tools), len(svr.ListTools()))
+	}
+	if !slices.IsSortedFunc(tools, func(a, b toolDump) int { return strings.Compare(a.Name, b.Name) }) {
+		t.Fatal("tools are not sorted by name")
+	}
+
+	byName := map[string]toolDump{}
+	for _, tool := range tools {
+		byName[tool.Name] = tool
+	}
+	create := byName["doks-create-cluster"]
+	if want := common.SchemaDigest(svr.GetTool("doks-create-cluster").Tool.RawInputSchema); create.SchemaSHA256 != want {
+		t.Errorf("doks-create-cluster schema_sha256 = %q, want %q", create.SchemaSHA256, want)
+	}
+	if create.Destructive {
+		t.Error("doks-create-cluster should not be destructive")
+	}
+	if !byName["doks-delete-cluster"].Destructive {
+		t.Error("doks-delete-cluster should be destructive")
+	}
+	if len(byName["doks-get-cluster"].SchemaSHA256) != 64 {
+		t.Errorf("doks-get-cluster schema_sha256 = %q, want a SHA-256 hex digest", byName["doks-get-cluster"].SchemaSHA256)
+	}
+}
+
+func TestDumpTools_aliases(t *testing.T) {
+	svr := server.NewMCPServer(mcpName, mcpVersion)
+	_, err := registry.Register(slog.New(slog.NewTextHandler(io.Discard, nil)), svr, func(ctx context.Context) (*godo.Client, error) {
+		return godo.NewFromToken("token"), nil
+	}, registry.Options{}, "networking")
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	dump := func(includeAliases bool) map[string]toolDump {
+		var out bytes.Buffer
+		if err := dumpTools(&out, svr, includeAliases); err != nil {
+			t.Fatalf("dumpTools: %v", err)
+		}
+		var tools []toolDump
+		if err := json.Unmarshal(out.Bytes(), &tools); err != nil {
+			t.Fatalf("unmarshal dump: %v", err)
+		}
+		byName := map[string]toolDump{}
+		for _, tool := range tools {
+			byName[tool.Name] = tool
+		}
+		return byName
+	}
+
+	tools := dump(false)
+	if _, ok := tools["floating-ip-list"]; ok {
+		t.Error("floating-ip-list dumped without --include-aliases")
+	}
+	if _, ok := tools["reserved-ip-list"]; !ok {
+		t.Error("reserved-ip-list missing from the dump")
+	}
+
+	tools = dump(true)
+	alias, ok := tools["floating-ip-list"]
+	if !ok {
+		t.Fatal("floating-ip-list missing with --include-aliases")
+	}
+	if alias.AliasOf != "reserved-ip-list" {
+		t.Errorf("floating-ip-list alias_of = %q, want reserved-ip-list", alias.AliasOf)
+	}
+	if alias.SchemaSHA256 != tools["reserved-ip-list"].SchemaSHA256 {
+		t.Error("alias schema digest differs from its canonical tool")
+	}
+	if tools["reserved-ip-list"].AliasOf != "" {
+		t.Error("reserved-ip-list should not have alias_of")
+	}
+}
@@ -9,17 +9,22 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
 
 	middleware "mcp-digitalocean/internal"
+	"mcp-digitalocean/internal/metrics"
 	"mcp-digitalocean/internal/oauthmeta"
 	"mcp-digitalocean/internal/openaichallenge"
 	"mcp-digitalocean/internal/wslogging"
 	"mcp-digitalocean/pkg/registry"
+	"mcp-digitalocean/pkg/registry/common"
 
 	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"golang.org/x/oauth2"
 )
@@ -42,6 +47,41 @@ func getEnv(key, fallback string) string {
 	return fallback
 }
 
+// splitList splits a comma-separated flag value, dropping blank entries.
+func splitList(value string) []string {
+	var items []string
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// getEnvInt is like getEnv for integer values. Unparsable values fall back too.
+func getEnvInt(key string, fallback int) int {
+	if v, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return v
+	}
+	return fallback
+}
+
+// getEnvDuration is like getEnv for time.Duration values. Unparsable values fall back too.
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	if v, err := time.ParseDuration(os.Getenv(key)); err == nil {
+		return v
+	}
+	return fallback
+}
+
+// getEnvFloat is like getEnv for float values. Unparsable values fall back too.
+func getEnvFloat(key string, fallback float64) float64 {
+	if v, err := strconv.ParseFloat(os.Getenv(key), 64); err == nil {
+		return v
+	}
+	return fallback
+}
+
 func main() {
 	logLevelFlag := flag.String("log-level", getEnv("LOG_LEVEL", "info"), "Log level: debug, info, warn, error")
 	serviceFlag := flag.String("services", getEnv("SERVICES", ""), "Comma-separated list of services to activate (e.g., apps,networking,droplets)")
@@ -49,14 +89,57 @@ func main() {
 	endpointFlag := flag.String("digitalocean-api-endpoint", getEnv("DIGITALOCEAN_API_ENDPOINT", "https://api.digitalocean.com"), "DigitalOcean API endpoint")
 	transport := flag.String("transport", getEnv("TRANSPORT", "stdio"), "The transport protocol to use (http or stdio). Default is stdio.")
 	bindAddr := flag.String("bind-addr", getEnv("BIND_ADDR", "127.0.0.1:8080"), "Bind address to bind to. Only used for http transport.")
+	metricsAddr := flag.String("metrics-addr", getEnv("METRICS_ADDR", ""), "Address to serve Prometheus metrics at /metrics on. When empty, the http transport serves them on --bind-addr, and stdio serves none (optional)")
 	wsLoggingURL := flag.String("ws-logging-url", getEnv("WS_LOGGING_URL", ""), "WebSocket URL for WebSocket logging (optional)")
 	wsLoggingToken := flag.String("ws-logging-token", getEnv("WS_LOGGING_TOKEN", ""), "Authentication token for WebSocket logging (optional)")
+	wsLoggingTokenFile := flag.String("ws-logging-token-file", getEnv("WS_LOGGING_TOKEN_FILE", ""), "File containing the authentication token for WebSocket logging, instead of --ws-logging-token (optional)")
+	wsLoggingBatchFrame := flag.String("ws-logging-batch-frame", getEnv("WS_LOGGING_BATCH_FRAME", string(wslogging.BatchFramePerMessage)), "How WebSocket logging frames each batch: per-message for one frame per log entry, or ndjson for one frame per batch with an entry per line")
 	enableToolErrorLogging := flag.Bool("enable-tool-error-logging", getEnv("ENABLE_TOOL_ERROR_LOGGING", "false") == "true", "Enable logging of tool errors")
 	serverURLFlag := flag.String("mcp-resource-url", getEnv("MCP_RESOURCE_URL", ""), "This server's public base URL advertised in the OAuth protected resource metadata. When empty, it is derived from each request (remote transport only, optional)")
 	openaiAppsVerificationTokenFlag := flag.String("openai-apps-verification-token", getEnv("OPENAI_APPS_VERIFICATION_TOKEN", ""), "Plain-text token served at /.well-known/openai-apps-challenge for OpenAI ChatGPT app domain verification (remote transport only, optional)")
 	userAgent := flag.String("user-agent", getEnv("USER_AGENT", ""), "Indicate this server is running as a remote MCP ")
+	userAgentSuffix := flag.String("user-agent-suffix", getEnv("USER_AGENT_SUFFIX", ""), "Identifies the platform running this server in DigitalOcean API logs, appended to the user agent as (+suffix). Printable ASCII only")
+	versionFlag := flag.Bool("version", false, "Print the server version and user agent, then exit")
+	dumpToolsFlag := flag.Bool("dump-tools", false, "Print the registered tools with a SHA-256 digest of each input schema as JSON, then exit")
+	includeAliases := flag.Bool("include-aliases", false, "Include alias tool names, such as floating-ip-list for reserved-ip-list, in the --dump-tools output")
+	clientCacheSize := flag.Int("client-cache-size", getEnvInt("CLIENT_CACHE_SIZE", defaultClientCacheSize), "Maximum number of per-token DigitalOcean clients kept for reuse. 0 disables the cache (http transport only)")
+	clientCacheTTL := flag.Duration("client-cache-ttl", getEnvDuration("CLIENT_CACHE_TTL", defaultClientCacheTTL), "How long a cached per-token DigitalOcean client is reused (http transport only)")
+	spendLimitUSD := flag.Float64("spend-limit-usd", getEnvFloat("SPEND_LIMIT_USD", 0), "Refuse resource-creating tools once the account's month-to-date usage reaches this many USD, unless the call passes OverrideSpendLimit: true. 0 disables the limit")
+	preferredRegions := flag.String("preferred-regions", getEnv("PREFERRED_REGIONS", ""), "Comma-separated region slugs (e.g., nyc3,ams3) that placement-options lists first, in this order")
+	toolTimeout := flag.Duration("tool-timeout", getEnvDuration("TOOL_TIMEOUT", 0), "How long a tool call may run before it fails with a timeout error, e.g. 60s. 0 means no timeout")
+	toolTimeoutsFlag := flag.String("tool-timeouts", getEnv("TOOL_TIMEOUTS", ""), "Per-tool timeouts overriding --tool-timeout, as comma-separated tool=duration pairs, e.g. doks-create-cluster=300s,droplet-create=120s (optional)")
+	maxConcurrentPerTool := flag.Int("max-concurrent-per-tool", getEnvInt("MAX_CONCURRENT_PER_TOOL", 0), "Maximum number of calls of the same tool that run at once; a few more may queue, the rest are refused. 0 means unlimited")
+	warnUnknownArgs := flag.Bool("warn-unknown-args", getEnv("WARN_UNKNOWN_ARGS", "false") == "true", "Append a warnings field to tool results listing arguments the tool does not declare")
+	toolsConfigFile := flag.String("tools-config-file", getEnv("TOOLS_CONFIG_FILE", ""), "File of services, allow and deny tool patterns and creation quotas, one key=value per line, reloadable with server-reload-tools (optional)")
+	includeRequestEcho := flag.Bool("include-request-echo", getEnv("INCLUDE_REQUEST_ECHO", "false") == "true", "Add the sanitized request to the error of a resource-creating tool the API rejects with a 4xx status, for debugging. Off by default since it grows responses")
+	enableAdminTools := flag.Bool("enable-admin-tools", getEnv("ENABLE_ADMIN_TOOLS", "false") == "true", "Register server administration tools such as server-recent-errors")
+	scanSecretArgs := flag.Bool("scan-secret-args", getEnv("SCAN_SECRET_ARGS", "true") == "true", "Refuse tool calls that put a token, private key or access key in a name, tag or description argument, and log a warning for secrets in other arguments")
+	secretPatternsFile := flag.String("secret-patterns-file", getEnv("SECRET_PATTERNS_FILE", ""), "File of extra secret patterns, one name=regexp per line, redacted from logs, recent errors and request echoes and refused by --scan-secret-args (optional)")
+	structuredOutput := flag.Bool("structured-output", getEnv("STRUCTURED_OUTPUT", "false") == "true", "Declare output schemas and return structured content from tools that support it. Leave off for clients that do not support structured tool output")
+	readOnly := flag.Bool("read-only", getEnv("READ_ONLY", "false") == "true", "Register only the tools that read, such as get and list tools, leaving out every tool that creates, changes or deletes something")
+	fieldNamingFlag := flag.String("field-naming", getEnv("FIELD_NAMING", string(common.FieldNamingGodo)), "Field names of droplet, load balancer and image output: godo for the API's snake_case names, or doctl for doctl's column names such as PublicIPv4")
 	flag.Parse()
 
+	if err := validateUserAgentSuffix(*userAgentSuffix); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid --user-agent-suffix: %v\n", err)
+		os.Exit(1)
+	}
+	fieldNaming, err := common.ParseFieldNaming(*fieldNamingFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid --field-naming: %v\n", err)
+		os.Exit(1)
+	}
+	toolTimeouts, err := middleware.ParseToolTimeouts(*toolTimeoutsFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid --tool-timeouts: %v\n", err)
+		os.Exit(1)
+	}
+	apiUserAgent := buildUserAgent(*userAgent, *userAgentSuffix)
+	if *versionFlag {
+		fmt.Printf("%s %s\nuser-agent: %s\n", mcpName, mcpVersion, apiUserAgent)
+		os.Exit(0)
+	}
+
 	var level slog.Level
 	switch strings.ToLower(*logLevelFlag) {
 	case "debug":
@@ -81,10 +164,19 @@ func main() {
 	wsLoggingHandler := wslogging.NewHandler(os.Stderr, &slog.HandlerOptions{Level: level})
 	// configure WebSocket logging if URL is provided
 	if *wsLoggingURL != "" {
-		if err := wsLoggingHandler.ConfigureWebSocket(*wsLoggingURL, *wsLoggingToken); err != nil {
+		token, err := resolveWSLoggingToken(*wsLoggingToken, *wsLoggingTokenFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid WebSocket logging token: %v\n", err)
+			os.Exit(1)
+		}
+		if err := wsLoggingHandler.ConfigureWebSocket(*wsLoggingURL, token); err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to configure WebSocket logging: %v\n", err)
 			os.Exit(1)
 		}
+		if err := wsLoggingHandler.SetBatchFrame(wslogging.BatchFrame(*wsLoggingBatchFrame)); err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid --ws-logging-batch-frame: %v\n", err)
+			os.Exit(1)
+		}
 
 		// start WebSocket logging with signal context for graceful shutdown
 		// The context passed here controls when the background goroutines should stop.
@@ -125,19 +217,67 @@ func main() {
 	// create logger after adding service attributes
 	logger := slog.New(wsLoggingHandler)
 	token := *tokenFlag
-	if token == "" && *transport == "stdio" {
+	// --dump-tools only lists the tools, so it needs no token
+	if token == "" && *transport == "stdio" && !*dumpToolsFlag {
 		logger.Error("DigitalOcean API token not provided. Use --digitalocean-api-token flag or set DIGITALOCEAN_API_TOKEN environment variable")
 		os.Exit(1)
 	}
 
+	// forward WARN+ logs from a tool call to its MCP client once the client
+	// has asked for logs with logging/setLevel.
+	hooks := &server.Hooks{}
+	hooks.AddAfterSetLevel(func(ctx context.Context, id any, message *mcp.SetLevelRequest, result *mcp.EmptyResult) {
+		if session := server.ClientSessionFromContext(ctx); session != nil {
+			wsLoggingHandler.EnableMCPSession(session.SessionID())
+		}
+	})
+	hooks.AddOnUnregisterSession(func(ctx context.Context, session server.ClientSession) {
+		wsLoggingHandler.DisableMCPSession(session.SessionID())
+	})
+
+	// the secrets redacted from logs, recent errors and request echoes, and
+	// refused by --scan-secret-args.
+	secretPatterns := middleware.DefaultSecretPatterns
+	if *secretPatternsFile != "" {
+		extra, err := middleware.LoadSecretPatterns(*secretPatternsFile)
+		if err != nil {
+			logger.Error("Failed to load secret patterns: " + err.Error())
+			os.Exit(1)
+		}
+		secretPatterns = append(slices.Clip(secretPatterns), extra...)
+	}
+
 	var opts []server.ServerOption
-	opts = append(opts, server.WithPromptCapabilities(true))
+	opts = append(opts, server.WithPromptCapabilities(true), server.WithLogging(), server.WithHooks(hooks))
+	// give each tool call a logger with its tool name and request ID. Added
+	// first so every later middleware and the handler see it.
+	opts = append(opts, server.WithToolHandlerMiddleware(middleware.NewRequestLogger(logger).ToolMiddleware))
+	// count and time tool calls for /metrics. Added before PanicRecovery so
+	// recovered panics are counted as error results.
+	var toolMetrics *metrics.Metrics
+	if *transport != "stdio" || *metricsAddr != "" {
+		toolMetrics = metrics.New()
+		opts = append(opts, server.WithToolHandlerMiddleware(toolMetrics.ToolMiddleware))
+	}
 	if *enableToolErrorLogging {
-		toolLoggingMiddleware := middleware.ToolLoggingMiddleware{Logger: logger}
+		toolLoggingMiddleware := middleware.ToolLoggingMiddleware{Logger: logger, SecretPatterns: secretPatterns}
 		opts = append(opts, server.WithToolHandlerMiddleware(toolLoggingMiddleware.ToolMiddleware))
 	}
+	// fail tool calls that run past their timeout. Added after the logging
+	// and metrics middleware so timeouts are logged and counted, and before
+	// PanicRecovery so the handler's goroutine recovers its own panics.
+	if *toolTimeout > 0 || len(toolTimeouts) > 0 {
+		opts = append(opts, server.WithToolHandlerMiddleware(middleware.NewToolTimeout(*toolTimeout, toolTimeouts).ToolMiddleware))
+	}
+	// recover panicking tool handlers. Added after the logging middleware so
+	// the recovered panic is logged, and before the others so their panics
+	// are recovered too.
+	panicRecovery := middleware.NewPanicRecovery(logger)
+	panicRecovery.SecretPatterns = secretPatterns
+	opts = append(opts, server.WithToolHandlerMiddleware(panicRecovery.ToolMiddleware))
 
 	svr := server.NewMCPServer(mcpName, mcpVersion, opts...)
+	wsLoggingHandler.ConfigureMCP(svr)
 
 	// For remote (non-stdio) transports, serve the OAuth protected resource
 	// metadata document and challenge unauthenticated requests. The resource is
@@ -174,14 +314,19 @@ func main() {
 		}
 	}
 
-	// by default, we create a new client per request.
+	// by default, we look up a client per request keyed by the caller's bearer
+	// token. Clients are built with a background context because they outlive
+	// the request that created them.
+	clients := newClientCache(*clientCacheSize, *clientCacheTTL, func(token string) (*godo.Client, error) {
+		return newGodoClientWithTokenAndEndpoint(context.Background(), token, *endpointFlag, apiUserAgent)
+	})
 	getClientFn := func(ctx context.Context) (*godo.Client, error) {
-		return clientFromContext(ctx, *endpointFlag, *userAgent)
+		return clientFromContext(ctx, clients)
 	}
 
 	// if using stdio, we can re-use the client.
 	if *transport == "stdio" {
-		godoClient, err := newGodoClientWithTokenAndEndpoint(context.Background(), token, *endpointFlag, *userAgent)
+		godoClient, err := newGodoClientWithTokenAndEndpoint(context.Background(), token, *endpointFlag, apiUserAgent)
 		if err != nil {
 			logger.Error("Failed to create DigitalOcean client: " + err.Error())
 			os.Exit(1)
@@ -191,20 +336,163 @@ func main() {
 		}
 	}
 
-	// register the tools.
-	err := registry.Register(
+	// the tool configuration file sets the tools to register and the quotas
+	// of resource-creating tools.
+	var toolConfig registry.ToolConfig
+	if *toolsConfigFile != "" {
+		toolConfig, err = registry.LoadToolConfig(*toolsConfigFile)
+		if err != nil {
+			logger.Error("Failed to load tool configuration: " + err.Error())
+			os.Exit(1)
+		}
+	}
+
+	// keep the last tool errors for server-recent-errors. Added first so
+	// refusals from the limiter and spend guard are recorded too.
+	if *enableAdminTools {
+		recentErrors := middleware.NewRecentErrors(middleware.DefaultRecentErrors)
+		recentErrors.SecretPatterns = secretPatterns
+		svr.Use(recentErrors.ToolMiddleware)
+		svr.AddTools(recentErrors.Tools()...)
+	}
+
+	// name the token's team in 401 and 403 errors. Added after the recent
+	// errors buffer so it records the explained message.
+	svr.Use(middleware.NewAccessErrorExplainer(getClientFn).ToolMiddleware)
+
+	// refuse secrets pasted into names, tags and descriptions before they
+	// reach the API. Added before the limiter so refused calls take no slot.
+	if *scanSecretArgs {
+		svr.Use(middleware.NewSecretScanner(secretPatterns, logger).ToolMiddleware)
+	}
+
+	// cap concurrent calls per tool so an agent looping on one tool cannot
+	// flood the API. Added before the spend guard so its usage lookups are
+	// limited too.
+	if *maxConcurrentPerTool > 0 {
+		svr.Use(middleware.NewToolLimiter(*maxConcurrentPerTool).ToolMiddleware)
+		logger.Info("per-tool concurrency limit enabled", "max_concurrent", *maxConcurrentPerTool, "backlog", middleware.DefaultToolBacklog)
+	}
+
+	// tell callers about arguments the tool ignores.
+	if *warnUnknownArgs {
+		svr.Use(middleware.NewUnknownArgsWarner(func(name string) *mcp.Tool {
+			if tool := svr.GetTool(name); tool != nil {
+				return &tool.Tool
+			}
+			return nil
+		}).ToolMiddleware)
+	}
+
+	// echo the request of a create call the API rejected.
+	if *includeRequestEcho {
+		requestEcho := middleware.NewRequestEcho(func(toolName string) bool {
+			tool := svr.GetTool(toolName)
+			return tool != nil && common.CreatesResource(tool.Tool)
+		})
+		requestEcho.SecretPatterns = secretPatterns
+		svr.Use(requestEcho.ToolMiddleware)
+	}
+
+	// refuse resource-creating tools once the token has used its hourly quota.
+	// Added before the spend guard so refused calls skip its usage lookup.
+	// With server-reload-tools, quotas may be added to the file later, so the
+	// middleware is added even when it has none yet.
+	var createQuota *middleware.CreateQuota
+	if len(toolConfig.Quotas) > 0 || *enableAdminTools && *toolsConfigFile != "" {
+		createQuota = middleware.NewCreateQuota(toolConfig.Quotas, func(toolName string) bool {
+			tool := svr.GetTool(toolName)
+			return tool != nil && common.CreatesResource(tool.Tool)
+		})
+		createQuota.Tool = func(name string) *mcp.Tool {
+			if tool := svr.GetTool(name); tool != nil {
+				return &tool.Tool
+			}
+			return nil
+		}
+		svr.Use(createQuota.ToolMiddleware)
+		logger.Info("resource creation quotas enabled", "tokens", len(toolConfig.Quotas))
+	}
+
+	// refuse resource-creating tools once the account is over its spend limit.
+	// Added after the logging middleware so refusals are logged too.
+	if *spendLimitUSD > 0 {
+		spendGuard := middleware.NewSpendGuard(*spendLimitUSD, common.OverrideSpendLimitArg, func(toolName string) bool {
+			tool := svr.GetTool(toolName)
+			return tool != nil && common.CreatesResource(tool.Tool)
+		}, getClientFn)
+		svr.Use(spendGuard.ToolMiddleware)
+		logger.Info("spend limit enabled", "limit_usd", *spendLimitUSD)
+	}
+
+	// register the tools. Services in the tool configuration file replace
+	// --services.
+	registryOpts := registry.Options{
+		PreferredRegions:        splitList(*preferredRegions),
+		DisableStructuredOutput: !*structuredOutput,
+		FieldNaming:             fieldNaming,
+		ReadOnly:                *readOnly,
+	}
+	registryServices := services
+	if len(toolConfig.Services) > 0 {
+		registryServices = toolConfig.Services
+	}
+	registryOpts.Filter = toolConfig.Filter
+	registration, err := registry.Register(
 		logger,
 		svr,
 		getClientFn,
-		services...,
+		registryOpts,
+		registryServices...,
 	)
 	if err != nil {
 		logger.Error("Failed to register tools: " + err.Error())
 		os.Exit(1)
 	}
+	if *enableAdminTools {
+		reloader := registry.NewReloader(logger, svr, getClientFn, registryOpts, *toolsConfigFile, services, registration)
+		reloader.Quotas = createQuota
+		svr.AddTools(reloader.Tools()...)
+	}
+
+	// output schemas are opt-in: a client that does not understand them may
+	// reject results it cannot validate.
+	if !*structuredOutput {
+		middleware.DisableStructuredOutput(svr)
+	}
+
+	if *dumpToolsFlag {
+		if err := dumpTools(os.Stdout, svr, *includeAliases); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to dump tools: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	logStartupSummary(logger, startupSummary{
+		Version:          mcpVersion,
+		Transport:        *transport,
+		BindAddr:         *bindAddr,
+		Registration:     registration,
+		WSLoggingURL:     *wsLoggingURL,
+		StructuredOutput: *structuredOutput,
+		SpendLimitUSD:    *spendLimitUSD,
+		UserAgent:        apiUserAgent,
+	})
+
+	// serve the metrics on their own address, or else alongside the MCP
+	// endpoint.
+	var metricsHandler http.Handler
+	if toolMetrics != nil {
+		if *metricsAddr != "" {
+			go serveMetrics(ctx, logger, *metricsAddr, toolMetrics.Handler())
+		} else {
+			metricsHandler = toolMetrics.Handler()
+		}
+	}
 
 	// start our server.
-	err = runServer(ctx, svr, logger, *bindAddr, transport, wellKnownHandler, openaiChallengeHandler, requireAuth)
+	err = runServer(ctx, svr, logger, *bindAddr, transport, wellKnownHandler, openaiChallengeHandler, requireAuth, metricsHandler)
 	if err != nil {
 		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
 			logger.Info("shutting down mcp server")
@@ -216,16 +504,19 @@ func main() {
 	}
 }
 
-func clientFromContext(ctx context.Context, endpoint string, userAgent string) (*godo.Client, error) {
+func clientFromContext(ctx context.Context, clients *clientCache) (*godo.Client, error) {
 	auth, ok := ctx.Value(middleware.AuthKey{}).(string)
 	if !ok || strings.TrimSpace(auth) == "" {
 		return nil, errors.New("no auth header found")
 	}
-	token := strings.TrimPrefix(auth, "Bearer ")
+	// normalize before caching so quoting or padding variants of the same
+	// token share one client. HTTP trims the trailing space of an empty
+	// "Bearer " header, which must not be taken for the token "Bearer".
+	token := normalizeToken(strings.TrimPrefix(strings.TrimSpace(auth)+" ", "Bearer "))
 	if token == "" {
 		return nil, errors.New("no bearer token found")
 	}
-	client, err := newGodoClientWithTokenAndEndpoint(ctx, token, endpoint, userAgent)
+	client, err := clients.get(token)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create godo client: %w", err)
 	}
@@ -233,10 +524,61 @@ func clientFromContext(ctx context.Context, endpoint string, userAgent string) (
 	return client, nil
 }
 
+// normalizeToken strips surrounding whitespace and single quotes from a token.
+func normalizeToken(token string) string {
+	return strings.Trim(strings.TrimSpace(token), "'")
+}
+
+// buildUserAgent returns the user agent sent to the DigitalOcean API:
+// mcp-digitalocean/<version>, with the product name replaced by userAgent when
+// set and suffix appended as " (+suffix)".
+func buildUserAgent(userAgent, suffix string) string {
+	name := mcpName
+	if userAgent != "" {
+		name = userAgent
+	}
+	ua := fmt.Sprintf("%s/%s", name, mcpVersion)
+	if suffix != "" {
+		ua = fmt.Sprintf("%s (+%s)", ua, suffix)
+	}
+	return ua
+}
+
+// validateUserAgentSuffix rejects suffixes that are not printable ASCII, so a
+// suffix cannot inject header content or break user agent parsing.
+func validateUserAgentSuffix(suffix string) error {
+	for i := 0; i < len(suffix); i++ {
+		if c := suffix[i]; c < 0x20 || c > 0x7e {
+			return fmt.Errorf("character %q at position %d is not printable ASCII", rune(c), i)
+		}
+	}
+	return nil
+}
+
+// resolveWSLoggingToken returns the WebSocket logging token, read from
+// tokenFile when one is given. Reading it from a file keeps it out of the
+// process arguments and environment.
+func resolveWSLoggingToken(token, tokenFile string) (string, error) {
+	if tokenFile == "" {
+		return token, nil
+	}
+	if token != "" {
+		return "", errors.New("set only one of --ws-logging-token and --ws-logging-token-file")
+	}
+	data, err := os.ReadFile(tokenFile)
+	if err != nil {
+		return "", fmt.Errorf("read --ws-logging-token-file: %w", err)
+	}
+	token = strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("--ws-logging-token-file %s is empty", tokenFile)
+	}
+	return token, nil
+}
+
 // newGodoClientWithTokenAndEndpoint initializes a new godo client with a custom user agent and endpoint.
 func newGodoClientWithTokenAndEndpoint(ctx context.Context, token string, endpoint string, userAgent string) (*godo.Client, error) {
-	cleanToken := strings.Trim(strings.TrimSpace(token), "'")
-	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: cleanToken})
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: normalizeToken(token)})
 	oauthClient := oauth2.NewClient(ctx, ts)
 
 	retry := godo.RetryConfig{
@@ -245,18 +587,42 @@ func newGodoClientWithTokenAndEndpoint(ctx context.Context, token string, endpoi
 		RetryWaitMax: godo.PtrTo(float64(30)),
 	}
 
-	mcpUserAgent := fmt.Sprintf("%s/%s", mcpName, mcpVersion)
-	if userAgent != "" {
-		mcpUserAgent = fmt.Sprintf("%s/%s", userAgent, mcpVersion)
-	}
-
 	return godo.New(oauthClient,
 		godo.WithRetryAndBackoffs(retry),
 		godo.SetBaseURL(endpoint),
-		godo.SetUserAgent(mcpUserAgent))
+		godo.SetUserAgent(userAgent))
+}
+
+// streamableHTTPOptions returns the options of the streamable HTTP server.
+// Each request carries its caller's bearer token in its context, and no
+// session state is kept between requests.
+func streamableHTTPOptions() []server.StreamableHTTPOption {
+	return []server.StreamableHTTPOption{
+		server.WithHTTPContextFunc(middleware.AuthFromRequest),
+		server.WithStateLess(true),
+	}
 }
 
-func runServer(ctx context.Context, s *server.MCPServer, logger *slog.Logger, bindAddr string, transport *string, wellKnownHandler http.HandlerFunc, openaiChallengeHandler http.HandlerFunc, requireAuth func(http.Handler) http.Handler) error {
+// serveMetrics serves handler at metrics.Path on addr until ctx is done.
+func serveMetrics(ctx context.Context, logger *slog.Logger, addr string, handler http.Handler) {
+	mux := http.NewServeMux()
+	mux.Handle(metrics.Path, handler)
+	metricsServer := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		<-ctx.Done()
+		timeoutCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = metricsServer.Shutdown(timeoutCtx)
+	}()
+
+	logger.Info("metrics server started", "metrics_addr", addr, "path", metrics.Path)
+	if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logger.Error("metrics server error", "error", err)
+	}
+}
+
+func runServer(ctx context.Context, s *server.MCPServer, logger *slog.Logger, bindAddr string, transport *string, wellKnownHandler http.HandlerFunc, openaiChallengeHandler http.HandlerFunc, requireAuth func(http.Handler) http.Handler, metricsHandler http.Handler) error {
 	logger.Info("starting MCP server", "name", mcpName, "version", mcpVersion, "transport", *transport)
 	switch *transport {
 	case "stdio":
@@ -274,14 +640,11 @@ func runServer(ctx context.Context, s *server.MCPServer, logger *slog.Logger, bi
 		// bearer-token guard on the MCP endpoint), we own the mux and hand it to the
 		// streamable server via WithStreamableHTTPServer. The MCP protocol endpoint
 		// is registered explicitly so its behavior is unchanged; the well-known
-		// route is served alongside it and is intentionally left unauthenticated.
-		var streamableOpts []server.StreamableHTTPOption
-		streamableOpts = append(streamableOpts,
-			server.WithHTTPContextFunc(middleware.AuthFromRequest),
-			server.WithStateLess(true),
-		)
-
-		useCustomMux := wellKnownHandler != nil || openaiChallengeHandler != nil || requireAuth != nil
+		// route, and /metrics when enabled, are served alongside it and are
+		// intentionally left unauthenticated.
+		streamableOpts := streamableHTTPOptions()
+
+		useCustomMux := wellKnownHandler != nil || openaiChallengeHandler != nil || requireAuth != nil || metricsHandler != nil
 		var mux *http.ServeMux
 		if useCustomMux {
 			mux = http.NewServeMux()
@@ -303,6 +666,9 @@ func runServer(ctx context.Context, s *server.MCPServer, logger *slog.Logger, bi
 			if openaiChallengeHandler != nil {
 				mux.HandleFunc(openaichallenge.WellKnownPath, openaiChallengeHandler)
 			}
+			if metricsHandler != nil {
+				mux.Handle(metrics.Path, metricsHandler)
+			}
 		}
 
 		go func() {
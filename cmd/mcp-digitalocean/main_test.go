@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewGodoClient_SendsUserAgent(t *testing.T) {
+	tests := []struct {
+		name      string
+		userAgent string
+		suffix    string
+		want      string
+	}{
+		{name: "default", want: mcpName + "/" + mcpVersion + " godo/"},
+		{name: "suffix", suffix: "platform-a", want: mcpName + "/" + mcpVersion + " (+platform-a) godo/"},
+		{name: "custom user agent with suffix", userAgent: "remote-mcp", suffix: "platform b", want: "remote-mcp/" + mcpVersion + " (+platform b) godo/"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var got string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Get("User-Agent")
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"account":{"uuid":"abc"}}`))
+			}))
+			defer srv.Close()
+
+			client, err := newGodoClientWithTokenAndEndpoint(context.Background(), "token-a", srv.URL, buildUserAgent(tc.userAgent, tc.suffix))
+			if err != nil {
+				t.Fatalf("newGodoClientWithTokenAndEndpoint: %v", err)
+			}
+			if _, _, err := client.Account.Get(context.Background()); err != nil {
+				t.Fatalf("Account.Get: %v", err)
+			}
+			if !strings.HasPrefix(got, tc.want) {
+				t.Fatalf("User-Agent = %q, want prefix %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateUserAgentSuffix(t *testing.T) {
+	for _, valid := range []string{"", "platform-a", "ci/runner 42 (eu)"} {
+		if err := validateUserAgentSuffix(valid); err != nil {
+			t.Errorf("validateUserAgentSuffix(%q) = %v, want nil", valid, err)
+		}
+	}
+	for _, invalid := range []string{"line\nbreak", "tab\there", "del\x7f", "plätform"} {
+		if err := validateUserAgentSuffix(invalid); err == nil {
+			t.Errorf("validateUserAgentSuffix(%q) = nil, want error", invalid)
+		}
+	}
+}
+
+func TestResolveWSLoggingToken(t *testing.T) {
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token")
+	if err := os.WriteFile(tokenFile, []byte("  file-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	emptyFile := filepath.Join(dir, "empty")
+	if err := os.WriteFile(emptyFile, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		token     string
+		tokenFile string
+		want      string
+		wantErr   string
+	}{
+		{name: "flag only", token: "flag-token", want: "flag-token"},
+		{name: "neither", want: ""},
+		{name: "file only", tokenFile: tokenFile, want: "file-token"},
+		{name: "both", token: "flag-token", tokenFile: tokenFile, wantErr: "set only one of"},
+		{name: "missing file", tokenFile: filepath.Join(dir, "missing"), wantErr: "read --ws-logging-token-file"},
+		{name: "empty file", tokenFile: emptyFile, wantErr: "is empty"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := resolveWSLoggingToken(tc.token, tc.tokenFile)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("resolveWSLoggingToken() error = %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveWSLoggingToken() error = %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("resolveWSLoggingToken() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	middleware "mcp-digitalocean/internal"
+	"mcp-digitalocean/internal/testhelpers"
+	"mcp-digitalocean/pkg/registry"
+	"mcp-digitalocean/pkg/registry/common"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/require"
+)
+
+// testAccount is the account behind a token of the fake API.
+type testAccount struct {
+	email string
+	team  string
+	// usage is the month-to-date usage in USD.
+	usage string
+}
+
+var testAccounts = map[string]testAccount{
+	"Bearer token-a": {email: "a@example.com", team: "team-a", usage: "5.00"},
+	"Bearer token-b": {email: "b@example.com", team: "team-b", usage: "500.00"},
+}
+
+// newTestAPI returns a fake DigitalOcean API answering for testAccounts. SSH
+// key lookups are always denied and droplet creation is always rejected.
+func newTestAPI(t *testing.T) *testhelpers.FakeAPI {
+	api := testhelpers.NewFakeAPI(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, ok := testAccounts[r.Header.Get("Authorization")]
+		if !ok {
+			testhelpers.WriteJSON(w, http.StatusUnauthorized, map[string]string{"id": "unauthorized", "message": "Unable to authenticate you"})
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/account":
+			testhelpers.WriteJSON(w, http.StatusOK, map[string]any{"account": map[string]any{
+				"email": account.email,
+				"team":  map[string]string{"name": account.team},
+			}})
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/customers/my/balance":
+			testhelpers.WriteJSON(w, http.StatusOK, map[string]string{"month_to_date_usage": account.usage})
+		case strings.HasPrefix(r.URL.Path, "/v2/account/keys/"):
+			testhelpers.WriteJSON(w, http.StatusForbidden, map[string]string{"id": "forbidden", "message": "You are not authorized to perform this operation"})
+		case r.Method == http.MethodPost && r.URL.Path == "/v2/droplets":
+			testhelpers.WriteJSON(w, http.StatusUnprocessableEntity, map[string]string{"id": "unprocessable_entity", "message": "invalid size"})
+		default:
+			testhelpers.WriteJSON(w, http.StatusNotFound, map[string]string{"id": "not_found", "message": "The resource you were accessing could not be found."})
+		}
+	}))
+	t.Cleanup(api.Close)
+	return api
+}
+
+// newTestHTTPServer serves the accounts and droplets tools over the
+// streamable HTTP transport, with per-token clients and the per-token caches
+// of the access error explainer and spend guard, against apiURL.
+func newTestHTTPServer(t *testing.T, apiURL string) *httptest.Server {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	clients := newClientCache(defaultClientCacheSize, defaultClientCacheTTL, func(token string) (*godo.Client, error) {
+		return newGodoClientWithTokenAndEndpoint(context.Background(), token, apiURL, buildUserAgent("", ""))
+	})
+	getClientFn := func(ctx context.Context) (*godo.Client, error) {
+		return clientFromContext(ctx, clients)
+	}
+
+	svr := server.NewMCPServer(mcpName, mcpVersion)
+	svr.Use(middleware.NewAccessErrorExplainer(getClientFn).ToolMiddleware)
+	svr.Use(middleware.NewSpendGuard(100, common.OverrideSpendLimitArg, func(toolName string) bool {
+		tool := svr.GetTool(toolName)
+		return tool != nil && common.CreatesResource(tool.Tool)
+	}, getClientFn).ToolMiddleware)
+	_, err := registry.Register(logger, svr, getClientFn, registry.Options{}, "accounts", "droplets")
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(server.NewStreamableHTTPServer(svr, streamableHTTPOptions()...))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newTestMCPClient(t *testing.T, url, token string) *client.Client {
+	c, err := client.NewStreamableHttpClient(url, transport.WithHTTPHeaders(map[string]string{"Authorization": "Bearer " + token}))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+	require.NoError(t, c.Start(context.Background()))
+	initReq := mcp.InitializeRequest{}
+	initReq.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	_, err = c.Initialize(context.Background(), initReq)
+	require.NoError(t, err)
+	return c
+}
+
+func callTestTool(t *testing.T, c *client.Client, name string, args map[string]any) (*mcp.CallToolResult, string) {
+	t.Helper()
+	req := mcp.CallToolRequest{}
+	req.Params.Name = name
+	req.Params.Arguments = args
+	result, err := c.CallTool(context.Background(), req)
+	require.NoError(t, err)
+	require.NotEmpty(t, result.Content)
+	return result, result.Content[0].(mcp.TextContent).Text
+}
+
+// requireAPIAuth checks that every request the fake API received for path
+// carried auth.
+func requireAPIAuth(t *testing.T, api *testhelpers.FakeAPI, path, auth string, want int) {
+	t.Helper()
+	var got int
+	for _, r := range api.Requests() {
+		if r.Path != path {
+			continue
+		}
+		require.Equal(t, auth, r.Authorization, "%s %s", r.Method, r.Path)
+		got++
+	}
+	require.Equal(t, want, got, path)
+}
+
+func TestHTTPTransport_TokensAreIsolated(t *testing.T) {
+	api := newTestAPI(t)
+	srv := newTestHTTPServer(t, api.URL)
+	clientA := newTestMCPClient(t, srv.URL, "token-a")
+	clientB := newTestMCPClient(t, srv.URL, "token-b")
+
+	t.Run("concurrent calls use the caller's token", func(t *testing.T) {
+		api.Reset()
+		callers := []struct {
+			client *client.Client
+			email  string
+		}{{clientA, "a@example.com"}, {clientB, "b@example.com"}}
+
+		// require must not be called off the test goroutine, so results are
+		// collected and checked after the calls.
+		type outcome struct {
+			want string
+			text string
+			err  error
+		}
+		outcomes := make([]outcome, 20)
+		var wg sync.WaitGroup
+		for i := range outcomes {
+			caller := callers[i%len(callers)]
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				result, err := caller.client.CallTool(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "account-get-information"}})
+				outcomes[i] = outcome{want: caller.email, err: err}
+				if err == nil && len(result.Content) > 0 {
+					outcomes[i].text = result.Content[0].(mcp.TextContent).Text
+				}
+			}()
+		}
+		wg.Wait()
+
+		for _, o := range outcomes {
+			require.NoError(t, o.err)
+			var account godo.Account
+			require.NoError(t, json.Unmarshal([]byte(o.text), &account), o.text)
+			require.Equal(t, o.want, account.Email)
+		}
+
+		requests := api.Requests()
+		require.Len(t, requests, 20)
+		counts := map[string]int{}
+		for _, r := range requests {
+			counts[r.Authorization]++
+		}
+		require.Equal(t, map[string]int{"Bearer token-a": 10, "Bearer token-b": 10}, counts)
+	})
+
+	t.Run("access errors name the caller's team", func(t *testing.T) {
+		// token-a's account is looked up and cached first; token-b must not
+		// be explained with it.
+		for _, c := range []struct {
+			client *client.Client
+			team   string
+			other  string
+		}{{clientA, "team-a", "team-b"}, {clientB, "team-b", "team-a"}, {clientA, "team-a", "team-b"}} {
+			result, text := callTestTool(t, c.client, "key-get", map[string]any{"ID": float64(1)})
+			require.True(t, result.IsError)
+			require.Contains(t, text, "team "+c.team)
+			require.NotContains(t, text, c.other)
+		}
+	})
+
+	t.Run("spend limit readings are per token", func(t *testing.T) {
+		api.Reset()
+		args := map[string]any{"Name": "web-1", "Size": "s-1vcpu-1gb", "Region": "nyc3", "ImageSlug": "ubuntu-24-04-x64"}
+
+		// token-b is over the limit and is refused before the API is called.
+		result, text := callTestTool(t, clientB, "droplet-create", args)
+		require.True(t, result.IsError)
+		require.Contains(t, text, "has reached the spend limit")
+
+		// token-a is under it, so its create reaches the API, twice, with
+		// its usage read once.
+		for range 2 {
+			result, text = callTestTool(t, clientA, "droplet-create", args)
+			require.True(t, result.IsError)
+			require.Contains(t, text, "invalid size")
+		}
+
+		result, text = callTestTool(t, clientB, "droplet-create", args)
+		require.True(t, result.IsError)
+		require.Contains(t, text, "has reached the spend limit")
+
+		requireAPIAuth(t, api, "/v2/droplets", "Bearer token-a", 2)
+		var balanceReads []string
+		for _, r := range api.Requests() {
+			if r.Path == "/v2/customers/my/balance" {
+				balanceReads = append(balanceReads, r.Authorization)
+			}
+		}
+		require.ElementsMatch(t, []string{"Bearer token-b", "Bearer token-a"}, balanceReads)
+	})
+
+	t.Run("requests without a token reach no client", func(t *testing.T) {
+		api.Reset()
+		c := newTestMCPClient(t, srv.URL, "")
+		_, err := c.CallTool(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "account-get-information"}})
+		require.ErrorContains(t, err, "no bearer token found")
+		require.Empty(t, api.Requests())
+	})
+}
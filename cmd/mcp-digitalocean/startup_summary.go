@@ -0,0 +1,57 @@
+package main
+
+import (
+	"log/slog"
+	"net/url"
+	"strings"
+
+	"mcp-digitalocean/pkg/registry"
+)
+
+// startupSummary is the configuration logged once the tools are registered,
+// so a misconfigured deployment can be diagnosed from a single record.
+type startupSummary struct {
+	Version          string
+	Transport        string
+	BindAddr         string
+	Registration     *registry.Registration
+	WSLoggingURL     string
+	StructuredOutput bool
+	SpendLimitUSD    float64
+	UserAgent        string
+}
+
+// attrs returns the summary as log attributes. Only the host of the WebSocket
+// logging URL is included: its path, query and user info may carry tokens.
+func (s startupSummary) attrs() []any {
+	bindAddr := s.BindAddr
+	if s.Transport == "stdio" {
+		bindAddr = ""
+	}
+	return []any{
+		slog.String("version", s.Version),
+		slog.String("transport", s.Transport),
+		slog.String("bind_addr", bindAddr),
+		slog.String("services", strings.Join(s.Registration.Services, ",")),
+		slog.Int("tools", s.Registration.Tools),
+		slog.Int("destructive_tools", s.Registration.DestructiveTools),
+		slog.Bool("ws_logging_enabled", s.WSLoggingURL != ""),
+		slog.String("ws_logging_host", urlHost(s.WSLoggingURL)),
+		slog.Bool("structured_output", s.StructuredOutput),
+		slog.Float64("spend_limit_usd", s.SpendLimitUSD),
+		slog.String("user_agent", s.UserAgent),
+	}
+}
+
+// urlHost returns the host (and port) of rawURL, or "" if it does not parse.
+func urlHost(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
+func logStartupSummary(logger *slog.Logger, s startupSummary) {
+	logger.Info("startup summary", s.attrs()...)
+}
@@ -0,0 +1,145 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// DefaultAccountTTL is how long an account lookup is reused.
+	DefaultAccountTTL = 15 * time.Minute
+
+	// accountFailureTTL is how long a failed account lookup is remembered, so
+	// a rejected token does not cost an extra API call on every error.
+	accountFailureTTL = time.Minute
+)
+
+// accessDeniedPattern matches the status of a godo API error with a 401 or
+// 403 response, e.g. "GET https://api.digitalocean.com/v2/droplets/1: 403".
+var accessDeniedPattern = regexp.MustCompile(`\b(?:GET|POST|PUT|PATCH|DELETE|HEAD) \S+: (401|403)\b`)
+
+// AccessErrorExplainer is a middleware that explains 401 and 403 API errors.
+// The API only says that access was denied; the explanation names the team
+// the token belongs to, since a token created in another team is the usual
+// cause.
+type AccessErrorExplainer struct {
+	// Client returns the DigitalOcean client for the request.
+	Client func(ctx context.Context) (*godo.Client, error)
+	// TTL is how long an account lookup is cached per token.
+	TTL time.Duration
+
+	now      func() time.Time
+	mu       sync.Mutex
+	accounts map[string]cachedAccount
+}
+
+// cachedAccount is an account lookup; account is nil when it failed.
+type cachedAccount struct {
+	account *godo.Account
+	expires time.Time
+}
+
+// NewAccessErrorExplainer creates an explainer that caches accounts for
+// DefaultAccountTTL.
+func NewAccessErrorExplainer(client func(ctx context.Context) (*godo.Client, error)) *AccessErrorExplainer {
+	return &AccessErrorExplainer{
+		Client:   client,
+		TTL:      DefaultAccountTTL,
+		now:      time.Now,
+		accounts: make(map[string]cachedAccount),
+	}
+}
+
+// ToolMiddleware wraps a tool handler to explain its access denied errors.
+func (e *AccessErrorExplainer) ToolMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := next(ctx, req)
+		if err != nil || result == nil || !result.IsError || len(result.Content) == 0 {
+			return result, err
+		}
+		text, ok := result.Content[0].(mcp.TextContent)
+		if !ok {
+			return result, err
+		}
+		match := accessDeniedPattern.FindStringSubmatch(text.Text)
+		if match == nil {
+			return result, err
+		}
+
+		text.Text = withHint(text.Text, e.explain(ctx, match[1]))
+		result.Content[0] = text
+		return result, err
+	}
+}
+
+// withHint adds hint to the text of an error result. A JSON object, such as
+// the payload built by errutil, gets a "hint" field so that it stays valid
+// JSON; any other text gets the hint on a new line.
+func withHint(text, hint string) string {
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(text), &payload); err != nil || len(payload) == 0 {
+		return text + "\n" + hint
+	}
+	quoted, _ := json.Marshal(hint)
+	body := strings.TrimRight(strings.TrimSuffix(strings.TrimSpace(text), "}"), " \n")
+	return body + ",\n  \"hint\": " + string(quoted) + "\n}"
+}
+
+// explain returns the hint for a 401 or 403 status.
+func (e *AccessErrorExplainer) explain(ctx context.Context, status string) string {
+	account := e.account(ctx)
+	if account == nil {
+		if status == "401" {
+			return "The token was rejected. Check that it is valid and has not expired or been revoked."
+		}
+		return "The token cannot access this resource. Check that it has the required scopes and was created in the team that owns the resource."
+	}
+	owner := fmt.Sprintf("account %s", account.Email)
+	if account.Team != nil && account.Team.Name != "" {
+		owner = fmt.Sprintf("team %s (%s)", account.Team.Name, account.Email)
+	}
+	return fmt.Sprintf("The token belongs to %s, which cannot access this resource. Check that the token was created in the team that owns the resource and has the required scopes.", owner)
+}
+
+// account returns the cached account for the caller's token, looking it up
+// when missing or expired. It returns nil when the lookup fails.
+func (e *AccessErrorExplainer) account(ctx context.Context) *godo.Account {
+	key := AuthFingerprint(ctx)
+
+	e.mu.Lock()
+	cached, ok := e.accounts[key]
+	e.mu.Unlock()
+	if ok && e.now().Before(cached.expires) {
+		return cached.account
+	}
+
+	var account *godo.Account
+	ttl := accountFailureTTL
+	if client, err := e.Client(ctx); err == nil {
+		if a, _, err := client.Account.Get(ctx); err == nil {
+			account, ttl = a, e.TTL
+		}
+	}
+
+	now := e.now()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	// drop lookups for tokens that have not been seen within their TTL.
+	for k, a := range e.accounts {
+		if !now.Before(a.expires) {
+			delete(e.accounts, k)
+		}
+	}
+	e.accounts[key] = cachedAccount{account: account, expires: now.Add(ttl)}
+
+	return account
+}
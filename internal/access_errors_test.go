@@ -0,0 +1,175 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"mcp-digitalocean/internal/errutil"
+)
+
+type fakeAccountService struct {
+	account *godo.Account
+	err     error
+	calls   int
+}
+
+func (f *fakeAccountService) Get(ctx context.Context) (*godo.Account, *godo.Response, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, nil, f.err
+	}
+	return f.account, nil, nil
+}
+
+func apiError(status int) error {
+	u, _ := url.Parse("https://api.digitalocean.com/v2/droplets/123")
+	return &godo.ErrorResponse{
+		Response:  &http.Response{StatusCode: status, Request: &http.Request{Method: http.MethodGet, URL: u}},
+		Message:   "You are not authorized to perform this operation",
+		RequestID: "req-1",
+	}
+}
+
+func callExplained(t *testing.T, e *AccessErrorExplainer, ctx context.Context, result *mcp.CallToolResult) string {
+	t.Helper()
+	handler := e.ToolMiddleware(func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return result, nil
+	})
+	out, err := handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "droplet-get"}})
+	require.NoError(t, err)
+	return out.Content[0].(mcp.TextContent).Text
+}
+
+func TestAccessErrorExplainer_ToolMiddleware(t *testing.T) {
+	teamAccount := &godo.Account{Email: "ops@example.com", Team: &godo.TeamInfo{Name: "Staging", UUID: "team-1"}}
+
+	tests := []struct {
+		name       string
+		account    *godo.Account
+		accountErr error
+		result     *mcp.CallToolResult
+		wantText   string
+		wantCalls  int
+	}{
+		{
+			name:      "403 names the token's team",
+			account:   teamAccount,
+			result:    mcp.NewToolResultErrorFromErr("api error", apiError(http.StatusForbidden)),
+			wantText:  "The token belongs to team Staging (ops@example.com), which cannot access this resource.",
+			wantCalls: 1,
+		},
+		{
+			name:      "401 names the token's team",
+			account:   teamAccount,
+			result:    mcp.NewToolResultErrorFromErr("api error", apiError(http.StatusUnauthorized)),
+			wantText:  "The token belongs to team Staging (ops@example.com)",
+			wantCalls: 1,
+		},
+		{
+			name:      "account without a team",
+			account:   &godo.Account{Email: "me@example.com"},
+			result:    mcp.NewToolResultErrorFromErr("api error", apiError(http.StatusForbidden)),
+			wantText:  "The token belongs to account me@example.com",
+			wantCalls: 1,
+		},
+		{
+			name:       "401 when the account lookup is also rejected",
+			accountErr: apiError(http.StatusUnauthorized),
+			result:     mcp.NewToolResultErrorFromErr("api error", apiError(http.StatusUnauthorized)),
+			wantText:   "The token was rejected. Check that it is valid and has not expired or been revoked.",
+			wantCalls:  1,
+		},
+		{
+			name:       "403 when the account lookup fails",
+			accountErr: errors.New("connection reset"),
+			result:     mcp.NewToolResultErrorFromErr("api error", apiError(http.StatusForbidden)),
+			wantText:   "The token cannot access this resource. Check that it has the required scopes",
+			wantCalls:  1,
+		},
+		{
+			name:     "other API errors are unchanged",
+			account:  teamAccount,
+			result:   mcp.NewToolResultErrorFromErr("api error", apiError(http.StatusNotFound)),
+			wantText: "api error: GET https://api.digitalocean.com/v2/droplets/123: 404 (request \"req-1\") You are not authorized to perform this operation",
+		},
+		{
+			name:     "successful results are unchanged",
+			account:  teamAccount,
+			result:   mcp.NewToolResultText("GET https://api.digitalocean.com/v2/droplets: 403 in a log line"),
+			wantText: "GET https://api.digitalocean.com/v2/droplets: 403 in a log line",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			accounts := &fakeAccountService{account: tc.account, err: tc.accountErr}
+			e := NewAccessErrorExplainer(func(ctx context.Context) (*godo.Client, error) {
+				return &godo.Client{Account: accounts}, nil
+			})
+
+			text := callExplained(t, e, context.Background(), tc.result)
+			require.Contains(t, text, tc.wantText)
+			require.Equal(t, tc.wantCalls, accounts.calls)
+			if tc.wantCalls == 0 {
+				require.Equal(t, tc.wantText, text)
+			}
+		})
+	}
+}
+
+func TestAccessErrorExplainer_jsonPayload(t *testing.T) {
+	accounts := &fakeAccountService{account: &godo.Account{Email: "me@example.com"}}
+	e := NewAccessErrorExplainer(func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{Account: accounts}, nil
+	})
+
+	text := callExplained(t, e, context.Background(), errutil.NewAPIErrorResult(nil, apiError(http.StatusForbidden)))
+	var out map[string]any
+	require.NoError(t, json.Unmarshal([]byte(text), &out))
+	require.Equal(t, "req-1", out["request_id"])
+	require.Contains(t, out["hint"], "The token belongs to account me@example.com")
+}
+
+func TestAccessErrorExplainer_cachesAccounts(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	accounts := &fakeAccountService{account: &godo.Account{Email: "ops@example.com"}}
+	e := NewAccessErrorExplainer(func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{Account: accounts}, nil
+	})
+	e.now = func() time.Time { return now }
+	denied := func() *mcp.CallToolResult {
+		return mcp.NewToolResultErrorFromErr("api error", apiError(http.StatusForbidden))
+	}
+
+	ctxA := WithAuthKey(context.Background(), "Bearer a")
+	ctxB := WithAuthKey(context.Background(), "Bearer b")
+	callExplained(t, e, ctxA, denied())
+	callExplained(t, e, ctxA, denied())
+	require.Equal(t, 1, accounts.calls)
+
+	// each token has its own account.
+	callExplained(t, e, ctxB, denied())
+	require.Equal(t, 2, accounts.calls)
+
+	now = now.Add(DefaultAccountTTL)
+	callExplained(t, e, ctxA, denied())
+	require.Equal(t, 3, accounts.calls)
+
+	// a failed lookup is retried sooner than a successful one is refreshed.
+	accounts.err = errors.New("connection reset")
+	now = now.Add(DefaultAccountTTL)
+	callExplained(t, e, ctxA, denied())
+	callExplained(t, e, ctxA, denied())
+	require.Equal(t, 4, accounts.calls)
+	now = now.Add(accountFailureTTL)
+	callExplained(t, e, ctxA, denied())
+	require.Equal(t, 5, accounts.calls)
+}
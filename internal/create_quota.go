@@ -0,0 +1,218 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// QuotaDefault is the token of the quota that applies to tokens without one
+// of their own.
+const QuotaDefault = "default"
+
+// quotaWindow is how long a quota counts calls for. Windows start on the hour.
+const quotaWindow = time.Hour
+
+// quotaDryRunArg is the argument with which create tools, such as
+// doks-create-cluster, only validate a request. Dry runs create nothing and are
+// not counted, but only for tools that declare the argument: the others ignore
+// it and create the resource anyway.
+const quotaDryRunArg = "DryRun"
+
+var fingerprintPattern = regexp.MustCompile(`^[0-9a-f]{` + strconv.Itoa(tokenFingerprintLen) + `}$`)
+
+// QuotaLimit caps the calls of the resource-creating tools matching Tools, a
+// path.Match pattern such as droplet-create or *, at Limit per hour.
+type QuotaLimit struct {
+	Tools string
+	Limit int
+}
+
+// Quotas maps a token fingerprint, as shown by server-recent-errors, or
+// QuotaDefault to the token's limits. Every token gets its own counts; tokens
+// without an entry are limited by the QuotaDefault limits.
+type Quotas map[string][]QuotaLimit
+
+// ParseQuota parses a quota: a token fingerprint or QuotaDefault, a colon and
+// comma-separated pattern=limit pairs, e.g. "default: droplet-create=5, *=20".
+func ParseQuota(s string) (string, []QuotaLimit, error) {
+	token, pairs, ok := strings.Cut(s, ":")
+	if !ok {
+		return "", nil, fmt.Errorf("expected <token fingerprint or %s>: <tool pattern>=<limit>, got %q", QuotaDefault, s)
+	}
+	token = strings.TrimSpace(token)
+	if token != QuotaDefault && !fingerprintPattern.MatchString(token) {
+		return "", nil, fmt.Errorf("invalid token %q: expected %s or the %d character fingerprint shown by server-recent-errors", token, QuotaDefault, tokenFingerprintLen)
+	}
+
+	var limits []QuotaLimit
+	for _, pair := range strings.Split(pairs, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		tools, limit, ok := strings.Cut(pair, "=")
+		if !ok {
+			return "", nil, fmt.Errorf("invalid limit %q: expected <tool pattern>=<limit>", pair)
+		}
+		tools = strings.TrimSpace(tools)
+		if _, err := path.Match(tools, ""); err != nil || tools == "" {
+			return "", nil, fmt.Errorf("invalid tool pattern %q", tools)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(limit))
+		if err != nil || n < 0 {
+			return "", nil, fmt.Errorf("invalid limit %q for %s: expected a number of calls per hour", strings.TrimSpace(limit), tools)
+		}
+		limits = append(limits, QuotaLimit{Tools: tools, Limit: n})
+	}
+	if len(limits) == 0 {
+		return "", nil, fmt.Errorf("no limits for %s", token)
+	}
+	return token, limits, nil
+}
+
+// CreateQuota is a middleware that caps how many times each token may call
+// resource-creating tools per hour, so one team sharing an HTTP deployment
+// cannot create resources without bound. Calls that fail and dry runs do not
+// count.
+type CreateQuota struct {
+	// Quotas are the limits per token. Use SetQuotas to change them once the
+	// middleware is in use.
+	Quotas Quotas
+	// Guarded reports whether the named tool creates resources.
+	Guarded func(toolName string) bool
+	// Tool returns the registered tool with the given name, or nil. Dry runs
+	// are exempt only for tools whose input schema declares DryRun; with a nil
+	// Tool, every call counts.
+	Tool func(name string) *mcp.Tool
+
+	now    func() time.Time
+	mu     sync.Mutex
+	counts map[quotaKey]quotaCount
+}
+
+// quotaKey identifies the count of one token against one limit.
+type quotaKey struct {
+	token string
+	tools string
+}
+
+type quotaCount struct {
+	window time.Time
+	calls  int
+}
+
+// NewCreateQuota creates a quota middleware with empty counts.
+func NewCreateQuota(quotas Quotas, guarded func(toolName string) bool) *CreateQuota {
+	return &CreateQuota{
+		Quotas:  quotas,
+		Guarded: guarded,
+		now:     time.Now,
+		counts:  make(map[quotaKey]quotaCount),
+	}
+}
+
+// ToolMiddleware wraps a tool handler to enforce the quota.
+func (q *CreateQuota) ToolMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name := req.Params.Name
+		if !q.Guarded(name) || q.isDryRun(req) {
+			return next(ctx, req)
+		}
+		token := AuthFingerprint(ctx)[:tokenFingerprintLen]
+		taken, refusal := q.take(token, name)
+		if refusal != "" {
+			return mcp.NewToolResultError(refusal), nil
+		}
+		result, err := next(ctx, req)
+		if err != nil || (result != nil && result.IsError) {
+			q.release(taken)
+		}
+		return result, err
+	}
+}
+
+// isDryRun reports whether req is a dry run of a tool that declares DryRun.
+func (q *CreateQuota) isDryRun(req mcp.CallToolRequest) bool {
+	if dryRun, _ := req.GetArguments()[quotaDryRunArg].(bool); !dryRun || q.Tool == nil {
+		return false
+	}
+	tool := q.Tool(req.Params.Name)
+	if tool == nil {
+		return false
+	}
+	declared, ok := declaredArgs(*tool)
+	if !ok {
+		return false
+	}
+	_, ok = declared[quotaDryRunArg]
+	return ok
+}
+
+// SetQuotas replaces the limits, as when the tool configuration file is
+// reloaded. Calls counted earlier in the hour still count against the new
+// limits with the same token and tool pattern.
+func (q *CreateQuota) SetQuotas(quotas Quotas) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.Quotas = quotas
+}
+
+// take counts a call of name against every limit of token matching it, or
+// counts nothing and returns why the call is refused when one of them is used
+// up. It returns the counts it took, for release.
+func (q *CreateQuota) take(token, name string) (map[quotaKey]time.Time, string) {
+	now := q.now()
+	window := now.Truncate(quotaWindow)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	limits, ok := q.Quotas[token]
+	if !ok {
+		limits = q.Quotas[QuotaDefault]
+	}
+	// drop counts from earlier windows, which no longer limit anything.
+	for k, c := range q.counts {
+		if c.window.Before(window) {
+			delete(q.counts, k)
+		}
+	}
+
+	taken := make(map[quotaKey]time.Time)
+	for _, l := range limits {
+		if ok, _ := path.Match(l.Tools, name); !ok {
+			continue
+		}
+		key := quotaKey{token: token, tools: l.Tools}
+		if c := q.counts[key]; c.calls >= l.Limit {
+			reset := window.Add(quotaWindow)
+			return nil, fmt.Sprintf("refusing to run %s: this token has used its quota of %d %s calls per hour. The quota resets at %s (in %s)",
+				name, l.Limit, l.Tools, reset.UTC().Format(time.RFC3339), reset.Sub(now).Round(time.Second))
+		}
+		taken[key] = window
+	}
+	for key := range taken {
+		q.counts[key] = quotaCount{window: window, calls: q.counts[key].calls + 1}
+	}
+	return taken, ""
+}
+
+// release gives back the counts take took, unless their window has passed.
+func (q *CreateQuota) release(taken map[quotaKey]time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for key, window := range taken {
+		if c, ok := q.counts[key]; ok && c.window.Equal(window) && c.calls > 0 {
+			c.calls--
+			q.counts[key] = c
+		}
+	}
+}
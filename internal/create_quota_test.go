@@ -0,0 +1,199 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+func setupCreateQuota(quotas Quotas, now *time.Time) *CreateQuota {
+	q := NewCreateQuota(quotas, func(toolName string) bool {
+		return toolName == "droplet-create" || toolName == "volume-create"
+	})
+	q.now = func() time.Time { return *now }
+	return q
+}
+
+// callQuota calls tool through q as the token auth, with a handler returning
+// an error result when fail is set, and returns the result text and whether
+// the handler ran.
+func callQuota(t *testing.T, q *CreateQuota, auth, tool string, fail bool) (string, bool) {
+	t.Helper()
+	called := false
+	handler := q.ToolMiddleware(func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		called = true
+		if fail {
+			return mcp.NewToolResultError("droplet create: 422"), nil
+		}
+		return mcp.NewToolResultText("created"), nil
+	})
+	result, err := handler(WithAuthKey(context.Background(), auth), mcp.CallToolRequest{Params: mcp.CallToolParams{Name: tool}})
+	require.NoError(t, err)
+	return result.Content[0].(mcp.TextContent).Text, called
+}
+
+func TestCreateQuota_ToolMiddleware(t *testing.T) {
+	now := time.Date(2026, 10, 16, 14, 50, 0, 0, time.UTC)
+	q := setupCreateQuota(Quotas{QuotaDefault: {{Tools: "droplet-create", Limit: 2}}}, &now)
+
+	for range 2 {
+		_, called := callQuota(t, q, "Bearer team-a", "droplet-create", false)
+		require.True(t, called)
+	}
+	text, called := callQuota(t, q, "Bearer team-a", "droplet-create", false)
+	require.False(t, called)
+	require.Equal(t, "refusing to run droplet-create: this token has used its quota of 2 droplet-create calls per hour. The quota resets at 2026-10-16T15:00:00Z (in 10m0s)", text)
+
+	// tools without a matching limit and tools that create nothing are not counted.
+	_, called = callQuota(t, q, "Bearer team-a", "volume-create", false)
+	require.True(t, called)
+	_, called = callQuota(t, q, "Bearer team-a", "droplet-list", false)
+	require.True(t, called)
+
+	t.Run("per-token isolation", func(t *testing.T) {
+		_, called := callQuota(t, q, "Bearer team-b", "droplet-create", false)
+		require.True(t, called)
+	})
+
+	t.Run("window rollover", func(t *testing.T) {
+		now = now.Add(10 * time.Minute)
+		_, called := callQuota(t, q, "Bearer team-a", "droplet-create", false)
+		require.True(t, called)
+	})
+}
+
+func TestCreateQuota_tokenQuotas(t *testing.T) {
+	now := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+	teamA := AuthFingerprint(WithAuthKey(context.Background(), "Bearer team-a"))[:tokenFingerprintLen]
+	q := setupCreateQuota(Quotas{
+		QuotaDefault: {{Tools: "*", Limit: 1}},
+		teamA:        {{Tools: "droplet-create", Limit: 3}, {Tools: "*", Limit: 4}},
+	}, &now)
+
+	t.Run("default bucket", func(t *testing.T) {
+		_, called := callQuota(t, q, "Bearer team-b", "volume-create", false)
+		require.True(t, called)
+		text, called := callQuota(t, q, "Bearer team-b", "droplet-create", false)
+		require.False(t, called)
+		require.Contains(t, text, "quota of 1 * calls per hour")
+	})
+
+	t.Run("token quota replaces the default", func(t *testing.T) {
+		for range 3 {
+			_, called := callQuota(t, q, "Bearer team-a", "droplet-create", false)
+			require.True(t, called)
+		}
+		text, called := callQuota(t, q, "Bearer team-a", "droplet-create", false)
+		require.False(t, called)
+		require.Contains(t, text, "quota of 3 droplet-create calls per hour")
+
+		// a refused call takes nothing from the other limits.
+		_, called = callQuota(t, q, "Bearer team-a", "volume-create", false)
+		require.True(t, called)
+		text, called = callQuota(t, q, "Bearer team-a", "volume-create", false)
+		require.False(t, called)
+		require.Contains(t, text, "quota of 4 * calls per hour")
+	})
+}
+
+func TestCreateQuota_failedCallsDoNotCount(t *testing.T) {
+	now := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+	q := setupCreateQuota(Quotas{QuotaDefault: {{Tools: "droplet-create", Limit: 1}}}, &now)
+
+	for range 3 {
+		_, called := callQuota(t, q, "", "droplet-create", true)
+		require.True(t, called)
+	}
+	_, called := callQuota(t, q, "", "droplet-create", false)
+	require.True(t, called)
+	_, called = callQuota(t, q, "", "droplet-create", false)
+	require.False(t, called)
+}
+
+func TestCreateQuota_dryRunsDoNotCount(t *testing.T) {
+	now := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+	tools := map[string]mcp.Tool{
+		"doks-create-cluster": mcp.NewTool("doks-create-cluster", mcp.WithBoolean("DryRun")),
+		"droplet-create":      mcp.NewTool("droplet-create", mcp.WithString("Name")),
+	}
+	q := NewCreateQuota(Quotas{QuotaDefault: {{Tools: "*", Limit: 1}}}, func(toolName string) bool {
+		_, ok := tools[toolName]
+		return ok
+	})
+	q.Tool = func(name string) *mcp.Tool {
+		if tool, ok := tools[name]; ok {
+			return &tool
+		}
+		return nil
+	}
+	q.now = func() time.Time { return now }
+
+	calls := 0
+	handler := q.ToolMiddleware(func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		calls++
+		return mcp.NewToolResultText("ok"), nil
+	})
+	call := func(tool string, args map[string]any) *mcp.CallToolResult {
+		result, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Name: tool, Arguments: args}})
+		require.NoError(t, err)
+		return result
+	}
+
+	for range 3 {
+		require.False(t, call("doks-create-cluster", map[string]any{"DryRun": true}).IsError)
+	}
+	require.Equal(t, 3, calls)
+
+	// droplet-create ignores DryRun and creates the droplet, so it counts.
+	require.False(t, call("droplet-create", map[string]any{"DryRun": true}).IsError)
+	require.True(t, call("droplet-create", map[string]any{"DryRun": true}).IsError)
+	require.True(t, call("doks-create-cluster", map[string]any{"DryRun": false}).IsError)
+	require.Equal(t, 4, calls)
+}
+
+func TestCreateQuota_setQuotas(t *testing.T) {
+	now := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+	q := setupCreateQuota(Quotas{QuotaDefault: {{Tools: "droplet-create", Limit: 1}}}, &now)
+
+	_, called := callQuota(t, q, "", "droplet-create", false)
+	require.True(t, called)
+	_, called = callQuota(t, q, "", "droplet-create", false)
+	require.False(t, called)
+
+	// the call counted before keeps counting against the raised limit.
+	q.SetQuotas(Quotas{QuotaDefault: {{Tools: "droplet-create", Limit: 2}}})
+	_, called = callQuota(t, q, "", "droplet-create", false)
+	require.True(t, called)
+	_, called = callQuota(t, q, "", "droplet-create", false)
+	require.False(t, called)
+
+	q.SetQuotas(nil)
+	_, called = callQuota(t, q, "", "droplet-create", false)
+	require.True(t, called)
+}
+
+func TestParseQuota(t *testing.T) {
+	token, limits, err := ParseQuota(" default: droplet-create=5, *=20 ")
+	require.NoError(t, err)
+	require.Equal(t, QuotaDefault, token)
+	require.Equal(t, []QuotaLimit{{Tools: "droplet-create", Limit: 5}, {Tools: "*", Limit: 20}}, limits)
+
+	token, _, err = ParseQuota("3f9a1c0e7b2d: *=1")
+	require.NoError(t, err)
+	require.Equal(t, "3f9a1c0e7b2d", token)
+
+	for _, s := range []string{
+		"droplet-create=5",
+		"team-a: droplet-create=5",
+		"default: droplet-create",
+		"default: droplet-create=-1",
+		"default: droplet-[=1",
+		"default:",
+	} {
+		_, _, err := ParseQuota(s)
+		require.Error(t, err, s)
+	}
+}
@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/digitalocean/godo"
+)
+
+// Error classes logged as error_class by ToolLoggingMiddleware. The set is
+// small and fixed so alerts can group on it.
+const (
+	// ErrorClassAuth is a missing, invalid or insufficiently scoped token.
+	ErrorClassAuth = "auth"
+	// ErrorClassNotFound is a 404 from the API.
+	ErrorClassNotFound = "not_found"
+	// ErrorClassValidation is input the tool or the API rejected.
+	ErrorClassValidation = "validation"
+	// ErrorClassRateLimit is a 429 from the API.
+	ErrorClassRateLimit = "rate_limit"
+	// ErrorClassDOServer is a 5xx from the API.
+	ErrorClassDOServer = "do_server"
+	// ErrorClassNetwork is a failure to reach the API.
+	ErrorClassNetwork = "network"
+	// ErrorClassHandlerPanic is a tool handler that panicked and was
+	// recovered by PanicRecovery.
+	ErrorClassHandlerPanic = "handler_panic"
+	// ErrorClassInternal is a handler error that fits no other class, such as
+	// a failure to marshal the result.
+	ErrorClassInternal = "internal"
+)
+
+// authErrorMarkers are parts of the errors returned when no client can be
+// built for the caller's token.
+var authErrorMarkers = []string{
+	"no credentials provided",
+	"failed to get DigitalOcean client",
+}
+
+// networkErrorMarkers are parts of the errors returned when the API cannot
+// be reached.
+var networkErrorMarkers = []string{
+	"dial tcp",
+	"connection refused",
+	"connection reset",
+	"no such host",
+	"i/o timeout",
+	"TLS handshake timeout",
+	"context deadline exceeded",
+	"unexpected EOF",
+}
+
+// classifyStatus returns the class of an API response status, or "" for a
+// status that is not an error.
+func classifyStatus(status int) string {
+	switch {
+	case status == http.StatusUnauthorized, status == http.StatusForbidden:
+		return ErrorClassAuth
+	case status == http.StatusNotFound:
+		return ErrorClassNotFound
+	case status == http.StatusTooManyRequests:
+		return ErrorClassRateLimit
+	case status >= 500:
+		return ErrorClassDOServer
+	case status >= 400:
+		return ErrorClassValidation
+	}
+	return ""
+}
+
+// classifyText returns the class of an error message, or fallback when the
+// message has no API status or known marker.
+func classifyText(text, fallback string) string {
+	if match := apiStatusPattern.FindStringSubmatch(text); match != nil {
+		status, _ := strconv.Atoi(match[1])
+		if class := classifyStatus(status); class != "" {
+			return class
+		}
+	}
+	for _, marker := range authErrorMarkers {
+		if strings.Contains(text, marker) {
+			return ErrorClassAuth
+		}
+	}
+	for _, marker := range networkErrorMarkers {
+		if strings.Contains(text, marker) {
+			return ErrorClassNetwork
+		}
+	}
+	return fallback
+}
+
+// classifyError returns the class of an error returned by a tool handler.
+// Handlers return errors for failures outside the caller's control, so an
+// unrecognised one is internal.
+func classifyError(err error) string {
+	var apiErr *godo.ErrorResponse
+	if errors.As(err, &apiErr) && apiErr.Response != nil {
+		if class := classifyStatus(apiErr.Response.StatusCode); class != "" {
+			return class
+		}
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded) {
+		return ErrorClassNetwork
+	}
+	return classifyText(err.Error(), ErrorClassInternal)
+}
+
+// classifyResultError returns the class of an error result. Tools return
+// error results for rejected input, so an unrecognised one is validation.
+func classifyResultError(text string) string {
+	if panicResultPattern.MatchString(text) {
+		return ErrorClassHandlerPanic
+	}
+	return classifyText(text, ErrorClassValidation)
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "unauthorized", err: apiError(http.StatusUnauthorized), want: ErrorClassAuth},
+		{name: "forbidden", err: fmt.Errorf("failed to get droplet: %w", apiError(http.StatusForbidden)), want: ErrorClassAuth},
+		{name: "no client", err: fmt.Errorf("failed to get DigitalOcean client: %w", errors.New("no token")), want: ErrorClassAuth},
+		{name: "not found", err: apiError(http.StatusNotFound), want: ErrorClassNotFound},
+		{name: "unprocessable", err: apiError(http.StatusUnprocessableEntity), want: ErrorClassValidation},
+		{name: "rate limited", err: apiError(http.StatusTooManyRequests), want: ErrorClassRateLimit},
+		{name: "server error", err: apiError(http.StatusServiceUnavailable), want: ErrorClassDOServer},
+		{name: "dial error", err: &url.Error{Op: "Get", URL: "https://api.digitalocean.com", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}, want: ErrorClassNetwork},
+		{name: "deadline", err: fmt.Errorf("waiting for action: %w", context.DeadlineExceeded), want: ErrorClassNetwork},
+		{name: "marshal error", err: errors.New("marshal error: json: unsupported value"), want: ErrorClassInternal},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, classifyError(tc.err))
+		})
+	}
+}
+
+func TestClassifyResultError(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "api forbidden", text: "api error: " + apiError(http.StatusForbidden).Error(), want: ErrorClassAuth},
+		{name: "no credentials", text: "no credentials provided: no token", want: ErrorClassAuth},
+		{name: "api not found", text: "api error: " + apiError(http.StatusNotFound).Error(), want: ErrorClassNotFound},
+		{name: "api rejected input", text: "api error: " + apiError(http.StatusBadRequest).Error(), want: ErrorClassValidation},
+		{name: "missing argument", text: "Droplet ID is required", want: ErrorClassValidation},
+		{name: "api rate limited", text: "api error: " + apiError(http.StatusTooManyRequests).Error(), want: ErrorClassRateLimit},
+		{name: "api server error", text: "api error: " + apiError(http.StatusInternalServerError).Error(), want: ErrorClassDOServer},
+		{name: "unreachable", text: `api error: Get "https://api.digitalocean.com/v2/droplets": dial tcp: lookup api.digitalocean.com: no such host`, want: ErrorClassNetwork},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, classifyResultError(tc.text))
+		})
+	}
+}
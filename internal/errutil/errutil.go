@@ -0,0 +1,109 @@
+// Package errutil turns failed DigitalOcean API calls into tool error results
+// that keep what support and callers need from the response: the status, the
+// request ID and the rate limit.
+package errutil
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Headers of an API response that godo does not keep on an ErrorResponse.
+const (
+	headerRequestID     = "X-Request-Id"
+	headerRateLimit     = "RateLimit-Limit"
+	headerRateRemaining = "RateLimit-Remaining"
+	headerRateReset     = "RateLimit-Reset"
+)
+
+// RateLimit is the caller's API rate limit as of the failed call.
+type RateLimit struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"reset"`
+}
+
+// APIError is the payload of the error result for a failed API call. Message
+// is the whole godo error, including the method, URL and status.
+type APIError struct {
+	Error      string     `json:"error"`
+	StatusCode int        `json:"status_code"`
+	RequestID  string     `json:"request_id,omitempty"`
+	RateLimit  *RateLimit `json:"rate_limit,omitempty"`
+	Message    string     `json:"message"`
+}
+
+// NewAPIError describes err, returned by an API call with resp, under text.
+// resp may be nil, in which case the response godo attached to err is used.
+// It returns false when err did not come with an API response, such as when
+// the API could not be reached.
+func NewAPIError(text string, resp *godo.Response, err error) (APIError, bool) {
+	var apiErr *godo.ErrorResponse
+	errors.As(err, &apiErr)
+
+	var httpResp *http.Response
+	switch {
+	case resp != nil && resp.Response != nil:
+		httpResp = resp.Response
+	case apiErr != nil && apiErr.Response != nil:
+		httpResp = apiErr.Response
+	default:
+		return APIError{}, false
+	}
+
+	out := APIError{
+		Error:      text,
+		StatusCode: httpResp.StatusCode,
+		RequestID:  httpResp.Header.Get(headerRequestID),
+		RateLimit:  rateLimit(httpResp.Header),
+		Message:    err.Error(),
+	}
+	if apiErr != nil && apiErr.RequestID != "" {
+		out.RequestID = apiErr.RequestID
+	}
+	return out, true
+}
+
+// rateLimit reads the rate limit headers, returning nil when the response has
+// none.
+func rateLimit(header http.Header) *RateLimit {
+	limit := header.Get(headerRateLimit)
+	remaining := header.Get(headerRateRemaining)
+	if limit == "" && remaining == "" {
+		return nil
+	}
+	rate := &RateLimit{}
+	rate.Limit, _ = strconv.Atoi(limit)
+	rate.Remaining, _ = strconv.Atoi(remaining)
+	if reset, _ := strconv.ParseInt(header.Get(headerRateReset), 10, 64); reset != 0 {
+		rate.Reset = time.Unix(reset, 0).UTC()
+	}
+	return rate
+}
+
+// NewAPIErrorResult returns the error result for err, returned by an API call
+// with resp, as a JSON APIError. Errors without an API response are returned
+// like mcp.NewToolResultErrorFromErr("api error", err).
+func NewAPIErrorResult(resp *godo.Response, err error) *mcp.CallToolResult {
+	return NewAPIErrorResultWithText("api error", resp, err)
+}
+
+// NewAPIErrorResultWithText is NewAPIErrorResult with text in place of
+// "api error", for handlers that say which call failed.
+func NewAPIErrorResultWithText(text string, resp *godo.Response, err error) *mcp.CallToolResult {
+	apiErr, ok := NewAPIError(text, resp, err)
+	if !ok {
+		return mcp.NewToolResultErrorFromErr(text, err)
+	}
+	data, marshalErr := json.MarshalIndent(apiErr, "", "  ")
+	if marshalErr != nil {
+		return mcp.NewToolResultErrorFromErr(text, err)
+	}
+	return mcp.NewToolResultError(string(data))
+}
@@ -0,0 +1,88 @@
+package errutil
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+// apiError returns the error and response godo returns for a failed call.
+func apiError(status int, header http.Header) (*godo.Response, error) {
+	u, _ := url.Parse("https://api.digitalocean.com/v2/load_balancers/lb-1")
+	httpResp := &http.Response{StatusCode: status, Header: header, Request: &http.Request{Method: http.MethodGet, URL: u}}
+	return &godo.Response{Response: httpResp}, &godo.ErrorResponse{
+		Response:  httpResp,
+		Message:   "Too many requests",
+		RequestID: header.Get("X-Request-Id"),
+	}
+}
+
+func decode(t *testing.T, result *mcp.CallToolResult) APIError {
+	t.Helper()
+	require.True(t, result.IsError)
+	var out APIError
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &out))
+	return out
+}
+
+func TestNewAPIErrorResult(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-Request-Id", "req-123")
+	header.Set("RateLimit-Limit", "5000")
+	header.Set("RateLimit-Remaining", "0")
+	header.Set("RateLimit-Reset", "1790000000")
+	resp, err := apiError(http.StatusTooManyRequests, header)
+
+	tests := []struct {
+		name string
+		resp *godo.Response
+		err  error
+	}{
+		{name: "with response", resp: resp, err: err},
+		{name: "response taken from the error", err: err},
+		{name: "wrapped error", resp: resp, err: fmt.Errorf("failed to get load balancer: %w", err)},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out := decode(t, NewAPIErrorResult(tc.resp, tc.err))
+			require.Equal(t, "api error", out.Error)
+			require.Equal(t, http.StatusTooManyRequests, out.StatusCode)
+			require.Equal(t, "req-123", out.RequestID)
+			require.Equal(t, &RateLimit{Limit: 5000, Remaining: 0, Reset: time.Unix(1790000000, 0).UTC()}, out.RateLimit)
+			require.Contains(t, out.Message, "GET https://api.digitalocean.com/v2/load_balancers/lb-1: 429")
+			require.Contains(t, out.Message, "Too many requests")
+		})
+	}
+}
+
+func TestNewAPIErrorResult_withoutHeaders(t *testing.T) {
+	resp, err := apiError(http.StatusUnprocessableEntity, http.Header{})
+	out := decode(t, NewAPIErrorResultWithText("failed to create cluster", resp, err))
+	require.Equal(t, "failed to create cluster", out.Error)
+	require.Equal(t, http.StatusUnprocessableEntity, out.StatusCode)
+	require.Empty(t, out.RequestID)
+	require.Nil(t, out.RateLimit)
+}
+
+func TestNewAPIErrorResult_requestIDFromHeader(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-Request-Id", "req-from-header")
+	resp, _ := apiError(http.StatusInternalServerError, header)
+	out := decode(t, NewAPIErrorResult(resp, errors.New("server error")))
+	require.Equal(t, http.StatusInternalServerError, out.StatusCode)
+	require.Equal(t, "req-from-header", out.RequestID)
+}
+
+func TestNewAPIErrorResult_noResponse(t *testing.T) {
+	err := errors.New("dial tcp: connection refused")
+	require.Equal(t, mcp.NewToolResultErrorFromErr("api error", err), NewAPIErrorResult(nil, err))
+	require.Equal(t, mcp.NewToolResultErrorFromErr("api error", err), NewAPIErrorResult(&godo.Response{}, err))
+}
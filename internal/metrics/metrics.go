@@ -0,0 +1,145 @@
+// Package metrics records tool call volume, latency and API errors for
+// Prometheus, served by the HTTP transport at /metrics.
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Path is where the HTTP transport serves the metrics.
+const Path = "/metrics"
+
+// Values of the status label of tool_calls_total.
+const (
+	// StatusSuccess is a call that returned a result that is not an error.
+	StatusSuccess = "success"
+	// StatusResultError is a call that returned an error result, such as
+	// rejected input or a failed API call.
+	StatusResultError = "result_error"
+	// StatusError is a call whose handler returned an error.
+	StatusError = "error"
+)
+
+// apiStatusPattern matches the method, URL and status godo puts in the
+// message of an API error, e.g. "GET https://api.digitalocean.com/v2/droplets/1: 404".
+var apiStatusPattern = regexp.MustCompile(`\b(?:GET|POST|PUT|PATCH|DELETE|HEAD) \S+: ([1-5][0-9]{2})\b`)
+
+// Metrics holds the tool call metrics in a registry of its own, so tests and
+// several servers in one process do not share counters.
+type Metrics struct {
+	registry         *prometheus.Registry
+	toolCalls        *prometheus.CounterVec
+	toolCallDuration *prometheus.HistogramVec
+	apiErrors        *prometheus.CounterVec
+}
+
+// New creates the tool call metrics, registered with the Go runtime and
+// process collectors.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		toolCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "tool_calls_total",
+			Help: "Tool calls by tool and status: success, result_error or error.",
+		}, []string{"tool", "status"}),
+		toolCallDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name: "tool_call_duration_seconds",
+			Help: "Duration of tool calls.",
+			// tools range from a single API call to waits of several minutes.
+			Buckets: []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 300, 900},
+		}, []string{"tool"}),
+		apiErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "godo_api_errors_total",
+			Help: "Tool calls that failed with a DigitalOcean API error, by the API's status code.",
+		}, []string{"status_code"}),
+	}
+	m.registry.MustRegister(
+		m.toolCalls,
+		m.toolCallDuration,
+		m.apiErrors,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return m
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// ToolMiddleware wraps a tool handler to count its calls and API errors and
+// time them. Add it before PanicRecovery so recovered panics are counted.
+func (m *Metrics) ToolMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start := time.Now()
+		result, err := next(ctx, req)
+		m.toolCallDuration.WithLabelValues(req.Params.Name).Observe(time.Since(start).Seconds())
+
+		status, apiStatus := StatusSuccess, 0
+		switch {
+		case err != nil:
+			status, apiStatus = StatusError, errorStatus(err)
+		case result != nil && result.IsError:
+			status, apiStatus = StatusResultError, resultStatus(result)
+		}
+		m.toolCalls.WithLabelValues(req.Params.Name, status).Inc()
+		if apiStatus != 0 {
+			m.apiErrors.WithLabelValues(strconv.Itoa(apiStatus)).Inc()
+		}
+		return result, err
+	}
+}
+
+// errorStatus returns the API status of an error returned by a handler, or 0
+// when it is not an API error.
+func errorStatus(err error) int {
+	var apiErr *godo.ErrorResponse
+	if errors.As(err, &apiErr) && apiErr.Response != nil {
+		return apiErr.Response.StatusCode
+	}
+	return textStatus(err.Error())
+}
+
+// resultStatus returns the API status of an error result, or 0 when it is not
+// for an API error. The status is read from the status_code of an errutil
+// payload, or else from the godo error message in the text.
+func resultStatus(result *mcp.CallToolResult) int {
+	if len(result.Content) == 0 {
+		return 0
+	}
+	text, ok := result.Content[0].(mcp.TextContent)
+	if !ok {
+		return 0
+	}
+	var payload struct {
+		StatusCode int `json:"status_code"`
+	}
+	if err := json.Unmarshal([]byte(text.Text), &payload); err == nil && payload.StatusCode != 0 {
+		return payload.StatusCode
+	}
+	return textStatus(text.Text)
+}
+
+// textStatus returns the status in a godo error message, or 0.
+func textStatus(text string) int {
+	match := apiStatusPattern.FindStringSubmatch(text)
+	if match == nil {
+		return 0
+	}
+	status, _ := strconv.Atoi(match[1])
+	return status
+}
@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"mcp-digitalocean/internal/errutil"
+)
+
+// scrape returns the metrics as served at /metrics.
+func scrape(t *testing.T, m *Metrics) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, Path, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func apiError(status int) error {
+	u, _ := url.Parse("https://api.digitalocean.com/v2/droplets/1")
+	return &godo.ErrorResponse{
+		Response: &http.Response{StatusCode: status, Request: &http.Request{Method: http.MethodGet, URL: u}},
+		Message:  "failed",
+	}
+}
+
+func TestMetrics_ToolMiddleware(t *testing.T) {
+	m := New()
+	results := map[string]func() (*mcp.CallToolResult, error){
+		"ok":      func() (*mcp.CallToolResult, error) { return mcp.NewToolResultText("ok"), nil },
+		"invalid": func() (*mcp.CallToolResult, error) { return mcp.NewToolResultError("Name is required"), nil },
+		"not-found": func() (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultErrorFromErr("api error", apiError(404)), nil
+		},
+		"rate-limited":  func() (*mcp.CallToolResult, error) { return errutil.NewAPIErrorResult(nil, apiError(429)), nil },
+		"handler-error": func() (*mcp.CallToolResult, error) { return nil, apiError(500) },
+		"no-client":     func() (*mcp.CallToolResult, error) { return nil, errors.New("no auth header found") },
+	}
+	handler := m.ToolMiddleware(func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return results[req.Params.Name]()
+	})
+	call := func(name string) {
+		_, _ = handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Name: name}})
+	}
+
+	call("ok")
+	call("ok")
+	for name := range results {
+		if name != "ok" {
+			call(name)
+		}
+	}
+
+	out := scrape(t, m)
+	require.Contains(t, out, `tool_calls_total{status="success",tool="ok"} 2`)
+	require.Contains(t, out, `tool_calls_total{status="result_error",tool="invalid"} 1`)
+	require.Contains(t, out, `tool_calls_total{status="result_error",tool="not-found"} 1`)
+	require.Contains(t, out, `tool_calls_total{status="result_error",tool="rate-limited"} 1`)
+	require.Contains(t, out, `tool_calls_total{status="error",tool="handler-error"} 1`)
+	require.Contains(t, out, `tool_calls_total{status="error",tool="no-client"} 1`)
+	require.Contains(t, out, `tool_call_duration_seconds_count{tool="ok"} 2`)
+	require.Contains(t, out, `tool_call_duration_seconds_count{tool="invalid"} 1`)
+
+	require.Contains(t, out, `godo_api_errors_total{status_code="404"} 1`)
+	require.Contains(t, out, `godo_api_errors_total{status_code="429"} 1`)
+	require.Contains(t, out, `godo_api_errors_total{status_code="500"} 1`)
+	require.NotContains(t, out, `godo_api_errors_total{status_code="0"}`)
+	require.Contains(t, out, "go_goroutines")
+}
+
+func TestMetrics_separateRegistries(t *testing.T) {
+	a, b := New(), New()
+	handler := a.ToolMiddleware(func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	})
+	_, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "droplet-list"}})
+	require.NoError(t, err)
+
+	require.Contains(t, scrape(t, a), `tool_calls_total{status="success",tool="droplet-list"} 1`)
+	require.NotContains(t, scrape(t, b), "droplet-list")
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"log/slog"
 	"net/http"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -22,9 +24,13 @@ func WithAuthKey(ctx context.Context, auth string) context.Context {
 	return context.WithValue(ctx, AuthKey{}, auth)
 }
 
-// ToolLoggingMiddleware is a middleware that logs tool errors.
+// ToolLoggingMiddleware is a middleware that logs tool errors. Errors are
+// logged with a low-cardinality error_class, one of the ErrorClass constants.
 type ToolLoggingMiddleware struct {
 	Logger *slog.Logger
+	// SecretPatterns are redacted from logged error payloads;
+	// DefaultSecretPatterns when nil.
+	SecretPatterns []SecretPattern
 }
 
 const (
@@ -38,39 +44,69 @@ const (
 	ToolCallSuccess = "tool_call_success"
 )
 
-// ToolMiddleware wraps a tool handler to log duration and success/error status.
+// secretFieldNames are the parts of JSON key names that mark a secret value,
+// such as basic_auth_password in database metrics credentials or the
+// client_key_data and token returned by doks-get-credentials.
+var secretFieldNames = []string{
+	"password",
+	"secret",
+	"token",
+	"client_key_data",
+	"client_certificate_data",
+	"certificate_authority_data",
+}
+
+// secretFieldPattern matches JSON string fields whose key contains one of
+// secretFieldNames.
+var secretFieldPattern = regexp.MustCompile(`(?i)("[^"]*(?:` + strings.Join(secretFieldNames, "|") + `)[^"]*"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// redactSecrets replaces the values of secret JSON fields, and anything
+// matching patterns, in a logged payload.
+func redactSecrets(payload string, patterns []SecretPattern) string {
+	payload = secretFieldPattern.ReplaceAllString(payload, `${1}"[REDACTED]"`)
+	return redactSecretPatterns(payload, patterns)
+}
+
+// ToolMiddleware wraps a tool handler to log duration and success/error
+// status, with the error_class of errors. Add it before PanicRecovery so
+// recovered panics are logged as handler_panic errors.
 func (m *ToolLoggingMiddleware) ToolMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		start := time.Now()
 		result, err := next(ctx, req)
 		if err != nil {
-			m.Logger.Error("tool call result",
+			m.Logger.ErrorContext(ctx, "tool call result",
 				"tool", req.Params.Name,
 				"duration_seconds", time.Since(start).Seconds(),
 				"error", err,
+				"error_class", classifyError(err),
 				"tool_call_outcome", ToolCallError,
 			)
 			return result, err
 		}
 
 		if result.IsError {
-			var payload string
+			var text string
 			if len(result.Content) > 0 {
-				textContent, ok := result.Content[0].(mcp.TextContent)
-				if ok {
-					payload = textContent.Text
+				if textContent, ok := result.Content[0].(mcp.TextContent); ok {
+					text = textContent.Text
 				}
 			}
-			m.Logger.Error("tool call result",
+			class, outcome := classifyResultError(text), ToolCallResultError
+			if class == ErrorClassHandlerPanic {
+				outcome = ToolCallError
+			}
+			m.Logger.ErrorContext(ctx, "tool call result",
 				"tool", req.Params.Name,
 				"duration_seconds", time.Since(start).Seconds(),
-				"content", payload,
-				"tool_call_outcome", ToolCallResultError,
+				"content", redactSecrets(text, m.SecretPatterns),
+				"error_class", class,
+				"tool_call_outcome", outcome,
 			)
 			return result, err
 		}
 
-		m.Logger.Info("tool call result",
+		m.Logger.InfoContext(ctx, "tool call result",
 			"tool", req.Params.Name,
 			"duration_seconds", time.Since(start).Seconds(),
 			"tool_call_outcome", ToolCallSuccess,
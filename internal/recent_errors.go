@@ -0,0 +1,139 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"mcp-digitalocean/pkg/registry/common"
+)
+
+// DefaultRecentErrors is how many tool errors RecentErrors keeps.
+const DefaultRecentErrors = 50
+
+// tokenFingerprintLen is how many hex characters of the auth fingerprint are
+// recorded; enough to tell tokens apart without identifying them.
+const tokenFingerprintLen = 12
+
+// ToolErrorRecord describes one failed tool call.
+type ToolErrorRecord struct {
+	Tool             string    `json:"tool"`
+	Time             time.Time `json:"time"`
+	TokenFingerprint string    `json:"token_fingerprint,omitempty"`
+	// Code is ToolCallResultError for error results and ToolCallError for
+	// errors returned by the handler.
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// RecentErrors is a middleware that keeps the most recent tool errors in a
+// fixed-size ring buffer, so failures can be inspected after stderr has been
+// rotated away. Messages are redacted like logged error payloads.
+type RecentErrors struct {
+	// SecretPatterns are redacted from recorded messages;
+	// DefaultSecretPatterns when nil.
+	SecretPatterns []SecretPattern
+
+	now func() time.Time
+
+	mu      sync.Mutex
+	records []ToolErrorRecord
+	// next is the index the next record is written to.
+	next int
+	// full reports whether the buffer has wrapped around.
+	full bool
+}
+
+// NewRecentErrors creates a buffer holding the last size tool errors.
+func NewRecentErrors(size int) *RecentErrors {
+	return &RecentErrors{
+		now:     time.Now,
+		records: make([]ToolErrorRecord, size),
+	}
+}
+
+// ToolMiddleware wraps a tool handler to record its errors.
+func (r *RecentErrors) ToolMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := next(ctx, req)
+		switch {
+		case err != nil:
+			r.record(ctx, req.Params.Name, ToolCallError, err.Error())
+		case result != nil && result.IsError:
+			var message string
+			if len(result.Content) > 0 {
+				if text, ok := result.Content[0].(mcp.TextContent); ok {
+					message = text.Text
+				}
+			}
+			r.record(ctx, req.Params.Name, ToolCallResultError, message)
+		}
+		return result, err
+	}
+}
+
+func (r *RecentErrors) record(ctx context.Context, tool, code, message string) {
+	rec := ToolErrorRecord{
+		Tool:    tool,
+		Time:    r.now().UTC(),
+		Code:    code,
+		Message: redactSecrets(message, r.SecretPatterns),
+	}
+	if auth, _ := ctx.Value(AuthKey{}).(string); auth != "" {
+		rec.TokenFingerprint = AuthFingerprint(ctx)[:tokenFingerprintLen]
+	}
+	r.add(rec)
+}
+
+func (r *RecentErrors) add(rec ToolErrorRecord) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.records) == 0 {
+		return
+	}
+	r.records[r.next] = rec
+	r.next = (r.next + 1) % len(r.records)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// Records returns the recorded errors, newest first.
+func (r *RecentErrors) Records() []ToolErrorRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := r.next
+	if r.full {
+		n = len(r.records)
+	}
+	out := make([]ToolErrorRecord, 0, n)
+	for i := 1; i <= n; i++ {
+		out = append(out, r.records[(r.next-i+len(r.records))%len(r.records)])
+	}
+	return out
+}
+
+func (r *RecentErrors) recentErrors(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	jsonErrors, err := json.MarshalIndent(map[string][]ToolErrorRecord{"errors": r.Records()}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(string(jsonErrors)), nil
+}
+
+// Tools returns the server-recent-errors admin tool.
+func (r *RecentErrors) Tools() []server.ServerTool {
+	return []server.ServerTool{
+		{
+			Handler: r.recentErrors,
+			Tool: mcp.NewTool("server-recent-errors",
+				mcp.WithDescription(fmt.Sprintf("List the last %d tool errors seen by this server, newest first, with the tool, time, token fingerprint, error code and message. Use it to diagnose a failure reported after the fact.", len(r.records))),
+				common.WithHints(common.HintsRead),
+			),
+		},
+	}
+}
@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecentErrors_Wraparound(t *testing.T) {
+	r := NewRecentErrors(3)
+	require.Empty(t, r.Records())
+
+	r.add(ToolErrorRecord{Tool: "a"})
+	r.add(ToolErrorRecord{Tool: "b"})
+	require.Equal(t, []string{"b", "a"}, recordTools(r.Records()))
+
+	r.add(ToolErrorRecord{Tool: "c"})
+	require.Equal(t, []string{"c", "b", "a"}, recordTools(r.Records()))
+
+	r.add(ToolErrorRecord{Tool: "d"})
+	r.add(ToolErrorRecord{Tool: "e"})
+	require.Equal(t, []string{"e", "d", "c"}, recordTools(r.Records()))
+}
+
+func TestRecentErrors_ConcurrentWrites(t *testing.T) {
+	r := NewRecentErrors(DefaultRecentErrors)
+	var wg sync.WaitGroup
+	for i := range 200 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.add(ToolErrorRecord{Tool: fmt.Sprintf("tool-%d", i)})
+			r.Records()
+		}()
+	}
+	wg.Wait()
+
+	records := r.Records()
+	require.Len(t, records, DefaultRecentErrors)
+	seen := make(map[string]bool)
+	for _, rec := range records {
+		require.False(t, seen[rec.Tool], "duplicate record %s", rec.Tool)
+		seen[rec.Tool] = true
+	}
+}
+
+func TestRecentErrors_ToolMiddleware(t *testing.T) {
+	r := NewRecentErrors(DefaultRecentErrors)
+	now := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+	r.now = func() time.Time { return now }
+
+	handler := r.ToolMiddleware(func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		switch req.Params.Name {
+		case "db-cluster-get-metrics-credentials":
+			return mcp.NewToolResultError(`unexpected response {"basic_auth_password":"hunter2"}`), nil
+		case "droplet-get":
+			return nil, errors.New("failed to get DigitalOcean client: boom")
+		default:
+			return mcp.NewToolResultText("ok"), nil
+		}
+	})
+
+	call := func(ctx context.Context, name string) {
+		_, _ = handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Name: name}})
+	}
+	call(WithAuthKey(context.Background(), "Bearer dop_v1_token"), "db-cluster-get-metrics-credentials")
+	call(context.Background(), "droplet-get")
+	call(context.Background(), "account-get")
+
+	records := r.Records()
+	require.Len(t, records, 2)
+
+	require.Equal(t, ToolErrorRecord{
+		Tool:    "droplet-get",
+		Time:    now,
+		Code:    ToolCallError,
+		Message: "failed to get DigitalOcean client: boom",
+	}, records[0])
+
+	require.Equal(t, "db-cluster-get-metrics-credentials", records[1].Tool)
+	require.Equal(t, ToolCallResultError, records[1].Code)
+	require.Equal(t, `unexpected response {"basic_auth_password":"[REDACTED]"}`, records[1].Message)
+	require.Len(t, records[1].TokenFingerprint, tokenFingerprintLen)
+	require.NotContains(t, records[1].TokenFingerprint, "dop_v1")
+}
+
+func TestRecentErrors_Tool(t *testing.T) {
+	r := NewRecentErrors(DefaultRecentErrors)
+	r.add(ToolErrorRecord{Tool: "droplet-get", Code: ToolCallResultError, Message: "api error: not found"})
+
+	tools := r.Tools()
+	require.Len(t, tools, 1)
+	require.Equal(t, "server-recent-errors", tools[0].Tool.Name)
+
+	result, err := tools[0].Handler(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var out struct {
+		Errors []ToolErrorRecord `json:"errors"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &out))
+	require.Equal(t, []string{"droplet-get"}, recordTools(out.Errors))
+	require.Equal(t, "api error: not found", out.Errors[0].Message)
+}
+
+func recordTools(records []ToolErrorRecord) []string {
+	tools := make([]string, len(records))
+	for i, rec := range records {
+		tools[i] = rec.Tool
+	}
+	return tools
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"runtime/debug"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// randomIDLen is how many random bytes are in a panic or request ID.
+const randomIDLen = 4
+
+// panicResultPattern matches the error result returned for a recovered panic.
+var panicResultPattern = regexp.MustCompile(`^internal error: [0-9a-f]{8}$`)
+
+// PanicRecovery is a middleware that turns a panicking tool handler into an
+// "internal error: <id>" error result, so the panic ends neither the process
+// nor a stdio session. The panic and its stack are logged with the same ID,
+// since the value may carry internal details.
+type PanicRecovery struct {
+	Logger *slog.Logger
+	// SecretPatterns are redacted from logged panic values;
+	// DefaultSecretPatterns when nil.
+	SecretPatterns []SecretPattern
+}
+
+// NewPanicRecovery creates a PanicRecovery logging to logger.
+func NewPanicRecovery(logger *slog.Logger) *PanicRecovery {
+	return &PanicRecovery{Logger: logger}
+}
+
+// ToolMiddleware wraps a tool handler to recover its panics.
+func (p *PanicRecovery) ToolMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (result *mcp.CallToolResult, err error) {
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+			id := randomID()
+			p.Logger.ErrorContext(ctx, "tool handler panicked",
+				"tool", req.Params.Name,
+				"panic_id", id,
+				"panic", redactSecrets(fmt.Sprint(r), p.SecretPatterns),
+				"stack", string(debug.Stack()),
+				"error_class", ErrorClassHandlerPanic,
+			)
+			result, err = mcp.NewToolResultError("internal error: "+id), nil
+		}()
+		return next(ctx, req)
+	}
+}
+
+// randomID returns a short random ID to correlate a panic's error result, or
+// a request's handler logs, with its log entries.
+func randomID() string {
+	b := make([]byte, randomIDLen)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
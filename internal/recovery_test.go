@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPanicRecovery(t *testing.T) {
+	var logs bytes.Buffer
+	p := NewPanicRecovery(slog.New(slog.NewJSONHandler(&logs, nil)))
+
+	var droplet *godo.Droplet
+	handler := p.ToolMiddleware(func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if req.GetArguments()["Panic"] == true {
+			// an unchecked dereference, like an unchecked type assertion.
+			return mcp.NewToolResultText(droplet.Name), nil
+		}
+		return mcp.NewToolResultText("ok"), nil
+	})
+	call := func(args map[string]any) (*mcp.CallToolResult, error) {
+		return handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "droplet-get", Arguments: args}})
+	}
+
+	result, err := call(map[string]any{"Panic": true})
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	text := result.Content[0].(mcp.TextContent).Text
+	require.Regexp(t, panicResultPattern, text)
+	require.NotContains(t, text, "nil pointer")
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(logs.Bytes(), &entry))
+	require.Equal(t, "ERROR", entry["level"])
+	require.Equal(t, "droplet-get", entry["tool"])
+	require.Equal(t, strings.TrimPrefix(text, "internal error: "), entry["panic_id"])
+	require.Equal(t, ErrorClassHandlerPanic, entry["error_class"])
+	require.Contains(t, entry["panic"], "nil pointer dereference")
+	require.Contains(t, entry["stack"], "TestPanicRecovery")
+
+	// later calls are unaffected, and each panic gets its own ID.
+	result, err = call(nil)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	require.Equal(t, "ok", result.Content[0].(mcp.TextContent).Text)
+
+	again, err := call(map[string]any{"Panic": true})
+	require.NoError(t, err)
+	require.True(t, again.IsError)
+	require.NotEqual(t, text, again.Content[0].(mcp.TextContent).Text)
+}
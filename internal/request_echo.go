@@ -0,0 +1,180 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// echoUserDataLimit is how many bytes of a user_data argument are echoed.
+const echoUserDataLimit = 256
+
+// apiStatusPattern matches the status of a godo API error, e.g.
+// "POST https://api.digitalocean.com/v2/kubernetes/clusters: 422".
+var apiStatusPattern = regexp.MustCompile(`\b(?:GET|POST|PUT|PATCH|DELETE|HEAD) \S+: ([1-5][0-9]{2})\b`)
+
+// RequestEcho is a middleware that returns the request of a failed create
+// call with its error, so a rejected spec can be debugged without re-running
+// it. When the API rejects the call with a 4xx status, the error result gets
+// an extra content item {"request_echo": {...}} holding the sanitized tool
+// arguments: secret fields and secret-shaped values are redacted and user_data
+// is truncated. If the API message names some of the arguments, only those
+// are echoed. 5xx errors are not the request's fault and get no echo.
+type RequestEcho struct {
+	// Echoed reports whether the named tool's failures are echoed.
+	Echoed func(toolName string) bool
+	// SecretPatterns are the secret-shaped values redacted from the echo;
+	// DefaultSecretPatterns when nil.
+	SecretPatterns []SecretPattern
+}
+
+// NewRequestEcho creates a middleware that echoes the requests of the tools
+// for which echoed returns true.
+func NewRequestEcho(echoed func(toolName string) bool) *RequestEcho {
+	return &RequestEcho{Echoed: echoed}
+}
+
+// ToolMiddleware wraps a tool handler to echo the request with 4xx errors.
+func (e *RequestEcho) ToolMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := next(ctx, req)
+		if err != nil || result == nil || !result.IsError || len(result.Content) == 0 || !e.Echoed(req.Params.Name) {
+			return result, err
+		}
+		text, ok := result.Content[0].(mcp.TextContent)
+		if !ok {
+			return result, err
+		}
+		match := apiStatusPattern.FindStringSubmatch(text.Text)
+		if match == nil {
+			return result, err
+		}
+		if status, _ := strconv.Atoi(match[1]); status < 400 || status >= 500 {
+			return result, err
+		}
+
+		data, merr := json.Marshal(map[string]any{"request_echo": echoArgs(req.GetArguments(), text.Text, e.SecretPatterns)})
+		if merr != nil {
+			return result, err
+		}
+		result.Content = append(result.Content, mcp.NewTextContent(string(data)))
+		return result, err
+	}
+}
+
+// echoArgs returns the arguments sanitized with patterns, narrowed to those
+// named in the error message when there are any.
+func echoArgs(args map[string]any, message string, patterns []SecretPattern) map[string]any {
+	var named []string
+	for key := range args {
+		if argNamed(message, key) {
+			named = append(named, key)
+		}
+	}
+	echo := make(map[string]any, len(args))
+	for key, value := range args {
+		if len(named) > 0 && !slices.Contains(named, key) {
+			continue
+		}
+		echo[key] = sanitizeArg(key, value, patterns)
+	}
+	return echo
+}
+
+// argNamed reports whether message mentions key as a word, ignoring case, e.g.
+// node_pools in "node_pools[0].size is invalid".
+func argNamed(message, key string) bool {
+	if key == "" {
+		return false
+	}
+	message, key = strings.ToLower(message), strings.ToLower(key)
+	for i := 0; ; {
+		j := strings.Index(message[i:], key)
+		if j < 0 {
+			return false
+		}
+		start, end := i+j, i+j+len(key)
+		if (start == 0 || !isWordByte(message[start-1])) && (end == len(message) || !isWordByte(message[end])) {
+			return true
+		}
+		i = start + 1
+	}
+}
+
+// isWordByte reports whether b is a letter, digit or underscore.
+func isWordByte(b byte) bool {
+	return b == '_' || '0' <= b && b <= '9' || 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z'
+}
+
+// sanitizeArg redacts secrets in value, an argument or a field of one named
+// key, and truncates user data. A string matching one of patterns, or of
+// DefaultSecretPatterns when patterns is nil, is redacted whole, since the
+// pattern may only match part of it.
+func sanitizeArg(key string, value any, patterns []SecretPattern) any {
+	if patterns == nil {
+		patterns = DefaultSecretPatterns
+	}
+	if isSecretField(key) {
+		return "[REDACTED]"
+	}
+	switch v := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, field := range v {
+			out[k] = sanitizeArg(k, field, patterns)
+		}
+		if isSecretEnv(v) {
+			if _, ok := out["value"]; ok {
+				out["value"] = "[REDACTED]"
+			}
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = sanitizeArg(key, item, patterns)
+		}
+		return out
+	case string:
+		if slices.ContainsFunc(patterns, func(p SecretPattern) bool { return p.Pattern.MatchString(v) }) {
+			return "[REDACTED]"
+		}
+		if strings.EqualFold(strings.ReplaceAll(key, "_", ""), "userdata") && len(v) > echoUserDataLimit {
+			// cut at a rune boundary, so a multi-byte character is not split.
+			n := echoUserDataLimit
+			for n > 0 && !utf8.RuneStart(v[n]) {
+				n--
+			}
+			v = fmt.Sprintf("%s... (%d more bytes)", v[:n], len(v)-n)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+// isSecretEnv reports whether m is an environment variable, as in an app spec,
+// whose value is secret: its type is SECRET or its key names a secret, like
+// {"key": "DB_PASSWORD", "value": "..."}.
+func isSecretEnv(m map[string]any) bool {
+	envType, _ := m["type"].(string)
+	envKey, _ := m["key"].(string)
+	return strings.EqualFold(envType, "SECRET") || envKey != "" && isSecretField(envKey)
+}
+
+// isSecretField reports whether key names a secret, like the JSON fields
+// redactSecrets hides in logged payloads.
+func isSecretField(key string) bool {
+	key = strings.ToLower(key)
+	return slices.ContainsFunc(secretFieldNames, func(name string) bool {
+		return strings.Contains(key, name)
+	})
+}
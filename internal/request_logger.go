@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+
+	"mcp-digitalocean/pkg/registry/common"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RequestLogger is a middleware that gives each tool call a logger with the
+// tool name and a random request_id, so a handler's log lines for one call
+// can be found together. Handlers get it with common.LoggerFromContext.
+type RequestLogger struct {
+	Logger *slog.Logger
+}
+
+// NewRequestLogger creates a RequestLogger deriving request loggers from
+// logger.
+func NewRequestLogger(logger *slog.Logger) *RequestLogger {
+	return &RequestLogger{Logger: logger}
+}
+
+// ToolMiddleware wraps a tool handler to put the request's logger in its
+// context.
+func (m *RequestLogger) ToolMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		logger := m.Logger.With("tool", req.Params.Name, "request_id", randomID())
+		return next(common.ContextWithLogger(ctx, logger), req)
+	}
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"mcp-digitalocean/pkg/registry/common"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestLogger(t *testing.T) {
+	var logs bytes.Buffer
+	m := NewRequestLogger(slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	handler := m.ToolMiddleware(func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return common.ErrorResult(ctx, nil, "api error", errors.New("GET https://api.digitalocean.com/v2/kubernetes/clusters/abc: 404")), nil
+	})
+
+	var ids []string
+	for range 2 {
+		result, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "doks-get-cluster"}})
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+	}
+	for line := range strings.Lines(logs.String()) {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		require.Equal(t, "WARN", entry["level"])
+		require.Equal(t, "api error", entry["msg"])
+		require.Equal(t, "doks-get-cluster", entry["tool"])
+		require.Contains(t, entry["error"], "404")
+		require.Regexp(t, `^[0-9a-f]{8}$`, entry["request_id"])
+		ids = append(ids, entry["request_id"].(string))
+	}
+	require.Len(t, ids, 2)
+	require.NotEqual(t, ids[0], ids[1])
+}
@@ -0,0 +1,175 @@
+package middleware
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// SecretPattern is a kind of secret the scanner recognises.
+type SecretPattern struct {
+	// Name describes the secret in errors and logs, e.g. "DigitalOcean API token".
+	Name    string
+	Pattern *regexp.Regexp
+}
+
+// DefaultSecretPatterns are the secrets that are obvious from their shape.
+var DefaultSecretPatterns = []SecretPattern{
+	{Name: "DigitalOcean token", Pattern: regexp.MustCompile(`\bdo[opr]_v1_[A-Za-z0-9]{16,}`)},
+	{Name: "private key", Pattern: regexp.MustCompile(`(?s)-----BEGIN [A-Z ]*PRIVATE KEY-----.*?-----END [A-Z ]*PRIVATE KEY-----`)},
+	{Name: "AWS access key", Pattern: regexp.MustCompile(`\b(?:AKIA|ASIA)[A-Z0-9]{16}\b`)},
+}
+
+// DefaultSecretFreeArgs are the arguments secrets never belong in. Their values
+// end up in resource names, tags and descriptions that anyone with access to
+// the account can read.
+var DefaultSecretFreeArgs = []string{"Name", "Tag", "Tags", "Description"}
+
+// SecretScanner is a middleware that looks for secrets in string arguments.
+// Calls with a secret in a secret-free argument, such as a token pasted into a
+// droplet name, are refused before they reach the API. Secrets in other
+// arguments, such as user data, are allowed but logged as a warning. The
+// secret itself is never logged.
+type SecretScanner struct {
+	Patterns []SecretPattern
+	// SecretFreeArgs are matched against argument names case-insensitively,
+	// at any depth.
+	SecretFreeArgs []string
+	Logger         *slog.Logger
+}
+
+// NewSecretScanner creates a scanner for patterns that refuses secrets in
+// DefaultSecretFreeArgs.
+func NewSecretScanner(patterns []SecretPattern, logger *slog.Logger) *SecretScanner {
+	return &SecretScanner{
+		Patterns:       patterns,
+		SecretFreeArgs: DefaultSecretFreeArgs,
+		Logger:         logger,
+	}
+}
+
+// secretFinding is a secret found in the argument at path.
+type secretFinding struct {
+	path       string
+	kind       string
+	secretFree bool
+}
+
+// ToolMiddleware wraps a tool handler to scan its arguments.
+func (s *SecretScanner) ToolMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		findings := s.scan(req.GetArguments())
+		for _, f := range findings {
+			if f.secretFree {
+				return mcp.NewToolResultError(fmt.Sprintf("argument %s looks like it contains a %s. Secrets must not be put in names, tags or descriptions, which anyone with access to the account can read; remove it and retry", f.path, f.kind)), nil
+			}
+		}
+		for _, f := range findings {
+			s.Logger.WarnContext(ctx, "secret in tool argument",
+				"tool", req.Params.Name,
+				"argument", f.path,
+				"secret_kind", f.kind,
+			)
+		}
+		return next(ctx, req)
+	}
+}
+
+// scan returns the secrets in args, in argument order.
+func (s *SecretScanner) scan(args map[string]any) []secretFinding {
+	var findings []secretFinding
+	keys := make([]string, 0, len(args))
+	for k := range args {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		findings = s.scanValue(findings, k, k, args[k])
+	}
+	return findings
+}
+
+func (s *SecretScanner) scanValue(findings []secretFinding, path, key string, value any) []secretFinding {
+	switch v := value.(type) {
+	case string:
+		for _, p := range s.Patterns {
+			if p.Pattern.MatchString(v) {
+				findings = append(findings, secretFinding{path: path, kind: p.Name, secretFree: s.isSecretFree(key)})
+				break
+			}
+		}
+	case []any:
+		for i, item := range v {
+			findings = s.scanValue(findings, fmt.Sprintf("%s[%d]", path, i), key, item)
+		}
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			findings = s.scanValue(findings, path+"."+k, k, v[k])
+		}
+	}
+	return findings
+}
+
+func (s *SecretScanner) isSecretFree(key string) bool {
+	return slices.ContainsFunc(s.SecretFreeArgs, func(arg string) bool {
+		return strings.EqualFold(arg, key)
+	})
+}
+
+// redactSecretPatterns replaces every match of patterns, or of
+// DefaultSecretPatterns when patterns is nil, in s.
+func redactSecretPatterns(s string, patterns []SecretPattern) string {
+	if patterns == nil {
+		patterns = DefaultSecretPatterns
+	}
+	for _, p := range patterns {
+		s = p.Pattern.ReplaceAllString(s, "[REDACTED]")
+	}
+	return s
+}
+
+// LoadSecretPatterns reads extra patterns from a file with one "name=regexp"
+// per line. Blank lines and lines starting with # are skipped.
+func LoadSecretPatterns(path string) ([]SecretPattern, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var patterns []SecretPattern
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		name, expr, ok := strings.Cut(text, "=")
+		if !ok || strings.TrimSpace(name) == "" || expr == "" {
+			return nil, fmt.Errorf("%s:%d: expected name=regexp", path, line)
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		patterns = append(patterns, SecretPattern{Name: strings.TrimSpace(name), Pattern: re})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return patterns, nil
+}
@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DefaultSpendUsageTTL is how long a month-to-date usage reading is reused.
+const DefaultSpendUsageTTL = 15 * time.Minute
+
+// SpendGuard is a middleware that refuses resource-creating tools once the
+// account's month-to-date usage reaches Limit (USD). A single call can bypass
+// it by passing OverrideArg: true.
+type SpendGuard struct {
+	// Limit is the month-to-date usage, in USD, at which creation is refused.
+	Limit float64
+	// OverrideArg is the boolean tool argument that bypasses the guard.
+	OverrideArg string
+	// Guarded reports whether the named tool creates resources.
+	Guarded func(toolName string) bool
+	// Client returns the DigitalOcean client for the request.
+	Client func(ctx context.Context) (*godo.Client, error)
+	// TTL is how long a usage reading is cached per token.
+	TTL time.Duration
+
+	now   func() time.Time
+	mu    sync.Mutex
+	usage map[string]spendUsage
+}
+
+type spendUsage struct {
+	usd     float64
+	expires time.Time
+}
+
+// NewSpendGuard creates a spend guard that caches usage for DefaultSpendUsageTTL.
+func NewSpendGuard(limit float64, overrideArg string, guarded func(toolName string) bool, client func(ctx context.Context) (*godo.Client, error)) *SpendGuard {
+	return &SpendGuard{
+		Limit:       limit,
+		OverrideArg: overrideArg,
+		Guarded:     guarded,
+		Client:      client,
+		TTL:         DefaultSpendUsageTTL,
+		now:         time.Now,
+		usage:       make(map[string]spendUsage),
+	}
+}
+
+// ToolMiddleware wraps a tool handler to enforce the spend limit.
+func (g *SpendGuard) ToolMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if !g.Guarded(req.Params.Name) {
+			return next(ctx, req)
+		}
+		if override, _ := req.GetArguments()[g.OverrideArg].(bool); override {
+			return next(ctx, req)
+		}
+
+		usage, err := g.monthToDateUsage(ctx)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr(fmt.Sprintf("failed to check month-to-date usage against the spend limit; retry with %s: true to create the resource anyway", g.OverrideArg), err), nil
+		}
+		if usage >= g.Limit {
+			return mcp.NewToolResultError(fmt.Sprintf("refusing to run %s: month-to-date usage of $%.2f has reached the spend limit of $%.2f. Retry with %s: true to create the resource anyway", req.Params.Name, usage, g.Limit, g.OverrideArg)), nil
+		}
+
+		return next(ctx, req)
+	}
+}
+
+// monthToDateUsage returns the cached month-to-date usage for the caller's
+// token, fetching it from the balance API when missing or expired.
+func (g *SpendGuard) monthToDateUsage(ctx context.Context) (float64, error) {
+	key := AuthFingerprint(ctx)
+
+	g.mu.Lock()
+	cached, ok := g.usage[key]
+	g.mu.Unlock()
+	if ok && g.now().Before(cached.expires) {
+		return cached.usd, nil
+	}
+
+	client, err := g.Client(ctx)
+	if err != nil {
+		return 0, err
+	}
+	balance, _, err := client.Balance.Get(ctx)
+	if err != nil {
+		return 0, err
+	}
+	usd, err := strconv.ParseFloat(balance.MonthToDateUsage, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid month_to_date_usage %q: %w", balance.MonthToDateUsage, err)
+	}
+
+	now := g.now()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	// drop readings for tokens that have not been seen within the TTL.
+	for k, u := range g.usage {
+		if !now.Before(u.expires) {
+			delete(g.usage, k)
+		}
+	}
+	g.usage[key] = spendUsage{usd: usd, expires: now.Add(g.TTL)}
+
+	return usd, nil
+}
+
+// AuthFingerprint identifies the account a request acts on by a fingerprint of
+// its auth header, so raw tokens are never stored. Stdio requests carry none
+// and share a single fingerprint.
+func AuthFingerprint(ctx context.Context) string {
+	auth, _ := ctx.Value(AuthKey{}).(string)
+	sum := sha256.Sum256([]byte(auth))
+	return hex.EncodeToString(sum[:])
+}
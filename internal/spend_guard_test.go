@@ -0,0 +1,169 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeBalanceService struct {
+	usage string
+	err   error
+	calls int
+}
+
+func (f *fakeBalanceService) Get(ctx context.Context) (*godo.Balance, *godo.Response, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, nil, f.err
+	}
+	return &godo.Balance{MonthToDateUsage: f.usage}, nil, nil
+}
+
+func setupSpendGuard(balance *fakeBalanceService, now *time.Time) *SpendGuard {
+	g := NewSpendGuard(100, "OverrideSpendLimit", func(toolName string) bool {
+		return toolName == "droplet-create"
+	}, func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{Balance: balance}, nil
+	})
+	g.now = func() time.Time { return *now }
+	return g
+}
+
+func callGuarded(t *testing.T, g *SpendGuard, ctx context.Context, name string, args map[string]any) (*mcp.CallToolResult, bool) {
+	t.Helper()
+	called := false
+	handler := g.ToolMiddleware(func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		called = true
+		return mcp.NewToolResultText("created"), nil
+	})
+	result, err := handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Name: name, Arguments: args}})
+	require.NoError(t, err)
+	return result, called
+}
+
+func TestSpendGuard_ToolMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		usage      string
+		balanceErr error
+		tool       string
+		args       map[string]any
+		wantCalled bool
+		wantCalls  int
+		wantText   string
+	}{
+		{
+			name:       "below limit",
+			usage:      "42.50",
+			tool:       "droplet-create",
+			wantCalled: true,
+			wantCalls:  1,
+		},
+		{
+			name:      "at limit",
+			usage:     "100.00",
+			tool:      "droplet-create",
+			wantCalls: 1,
+			wantText:  "month-to-date usage of $100.00 has reached the spend limit of $100.00",
+		},
+		{
+			name:      "above limit",
+			usage:     "123.45",
+			tool:      "droplet-create",
+			wantCalls: 1,
+			wantText:  "Retry with OverrideSpendLimit: true",
+		},
+		{
+			name:       "above limit with override",
+			usage:      "123.45",
+			tool:       "droplet-create",
+			args:       map[string]any{"OverrideSpendLimit": true},
+			wantCalled: true,
+		},
+		{
+			name:       "unguarded tool",
+			usage:      "123.45",
+			tool:       "droplet-list",
+			wantCalled: true,
+		},
+		{
+			name:       "balance error",
+			balanceErr: errors.New("boom"),
+			tool:       "droplet-create",
+			wantCalls:  1,
+			wantText:   "failed to check month-to-date usage",
+		},
+		{
+			name:      "invalid usage",
+			usage:     "n/a",
+			tool:      "droplet-create",
+			wantCalls: 1,
+			wantText:  "invalid month_to_date_usage",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+			balance := &fakeBalanceService{usage: tc.usage, err: tc.balanceErr}
+			g := setupSpendGuard(balance, &now)
+
+			result, called := callGuarded(t, g, context.Background(), tc.tool, tc.args)
+			require.Equal(t, tc.wantCalled, called)
+			require.Equal(t, tc.wantCalls, balance.calls)
+			if tc.wantText != "" {
+				require.True(t, result.IsError)
+				require.Contains(t, result.Content[0].(mcp.TextContent).Text, tc.wantText)
+			}
+		})
+	}
+}
+
+func TestSpendGuard_CachesUsage(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	balance := &fakeBalanceService{usage: "10.00"}
+	g := setupSpendGuard(balance, &now)
+
+	_, called := callGuarded(t, g, context.Background(), "droplet-create", nil)
+	require.True(t, called)
+
+	// usage crossing the limit is not seen until the cached reading expires.
+	balance.usage = "150.00"
+	now = now.Add(DefaultSpendUsageTTL - time.Second)
+	_, called = callGuarded(t, g, context.Background(), "droplet-create", nil)
+	require.True(t, called)
+	require.Equal(t, 1, balance.calls)
+
+	now = now.Add(time.Second)
+	result, called := callGuarded(t, g, context.Background(), "droplet-create", nil)
+	require.False(t, called)
+	require.True(t, result.IsError)
+	require.Equal(t, 2, balance.calls)
+
+	// each token is cached separately.
+	other := context.WithValue(context.Background(), AuthKey{}, "Bearer other-token")
+	_, _ = callGuarded(t, g, other, "droplet-create", nil)
+	require.Equal(t, 3, balance.calls)
+}
+
+func TestSpendGuard_DoesNotCacheErrors(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	balance := &fakeBalanceService{err: errors.New("boom")}
+	g := setupSpendGuard(balance, &now)
+
+	result, called := callGuarded(t, g, context.Background(), "droplet-create", nil)
+	require.False(t, called)
+	require.True(t, result.IsError)
+
+	balance.err = nil
+	balance.usage = "1.00"
+	_, called = callGuarded(t, g, context.Background(), "droplet-create", nil)
+	require.True(t, called)
+	require.Equal(t, 2, balance.calls)
+}
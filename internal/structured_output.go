@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DisableStructuredOutput removes the output schema from every tool
+// registered on s and strips structured content from tool results, for
+// clients that predate structured tool output. Call it after the tools are
+// registered.
+func DisableStructuredOutput(s *server.MCPServer) {
+	var tools []server.ServerTool
+	for _, st := range s.ListTools() {
+		if st.Tool.RawOutputSchema == nil && st.Tool.OutputSchema.Type == "" {
+			continue
+		}
+		tools = append(tools, server.ServerTool{Tool: WithoutOutputSchema(st.Tool), Handler: st.Handler})
+	}
+	if len(tools) > 0 {
+		s.AddTools(tools...)
+	}
+	s.Use(StripStructuredContent)
+}
+
+// WithoutOutputSchema returns a copy of tool with its output schema removed.
+func WithoutOutputSchema(tool mcp.Tool) mcp.Tool {
+	tool.RawOutputSchema = nil
+	tool.OutputSchema = mcp.ToolOutputSchema{}
+	return tool
+}
+
+// StripStructuredContent is a tool middleware that drops structured content
+// from results, leaving only the text content.
+func StripStructuredContent(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := next(ctx, req)
+		if result != nil {
+			result.StructuredContent = nil
+		}
+		return result, err
+	}
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/require"
+)
+
+func newStructuredServer() *server.MCPServer {
+	s := server.NewMCPServer("test", "1.0.0", server.WithToolCapabilities(true))
+	s.AddTool(mcp.NewTool("thing-get", mcp.WithRawOutputSchema(json.RawMessage(`{"type":"object","properties":{"id":{"type":"string"}}}`))),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultStructured(map[string]any{"id": "t-1"}, `{"id":"t-1"}`), nil
+		})
+	s.AddTool(mcp.NewTool("thing-list"), func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("[]"), nil
+	})
+	return s
+}
+
+func callTool(t *testing.T, s *server.MCPServer, name string) mcp.CallToolResult {
+	t.Helper()
+	msg := s.HandleMessage(context.Background(), json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"`+name+`"}}`))
+	resp, ok := msg.(mcp.JSONRPCResponse)
+	require.True(t, ok, "unexpected response %#v", msg)
+	result, ok := resp.Result.(*mcp.CallToolResult)
+	require.True(t, ok)
+	return *result
+}
+
+func TestDisableStructuredOutput(t *testing.T) {
+	enabled := newStructuredServer()
+	require.NotNil(t, enabled.GetTool("thing-get").Tool.RawOutputSchema)
+	result := callTool(t, enabled, "thing-get")
+	require.NotNil(t, result.StructuredContent)
+	require.Equal(t, `{"id":"t-1"}`, result.Content[0].(mcp.TextContent).Text)
+
+	disabled := newStructuredServer()
+	DisableStructuredOutput(disabled)
+	require.Nil(t, disabled.GetTool("thing-get").Tool.RawOutputSchema)
+	require.NotNil(t, disabled.GetTool("thing-list"))
+	result = callTool(t, disabled, "thing-get")
+	require.Nil(t, result.StructuredContent)
+	require.Equal(t, `{"id":"t-1"}`, result.Content[0].(mcp.TextContent).Text)
+}
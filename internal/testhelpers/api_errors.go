@@ -0,0 +1,17 @@
+package testhelpers
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/digitalocean/godo"
+)
+
+// NotFoundError returns the error godo returns when a GET of path, such as
+// "/v2/droplets/123", answers 404 Not Found.
+func NotFoundError(path string) error {
+	return &godo.ErrorResponse{
+		Response: &http.Response{StatusCode: http.StatusNotFound, Request: &http.Request{Method: http.MethodGet, URL: &url.URL{Scheme: "https", Host: "api.digitalocean.com", Path: path}}},
+		Message:  "The resource you were accessing could not be found.",
+	}
+}
@@ -0,0 +1,63 @@
+package testhelpers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+)
+
+// FakeAPIRequest is a request received by a FakeAPI.
+type FakeAPIRequest struct {
+	Method        string
+	Path          string
+	Authorization string
+}
+
+// FakeAPI is an in-process stand-in for the DigitalOcean API that records
+// every request it receives, so tests can check which token each call to the
+// API was made with. Responses come from the handler it was created with.
+type FakeAPI struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []FakeAPIRequest
+}
+
+// NewFakeAPI starts a FakeAPI answering with handler. Close it when done.
+func NewFakeAPI(handler http.Handler) *FakeAPI {
+	f := &FakeAPI{}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		f.requests = append(f.requests, FakeAPIRequest{
+			Method:        r.Method,
+			Path:          r.URL.Path,
+			Authorization: r.Header.Get("Authorization"),
+		})
+		f.mu.Unlock()
+		handler.ServeHTTP(w, r)
+	}))
+	return f
+}
+
+// Requests returns the requests received so far, oldest first.
+func (f *FakeAPI) Requests() []FakeAPIRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]FakeAPIRequest(nil), f.requests...)
+}
+
+// Reset forgets the requests received so far.
+func (f *FakeAPI) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = nil
+}
+
+// WriteJSON writes v as a JSON response with the given status, for use in
+// FakeAPI handlers.
+func WriteJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/digitalocean/godo"
 	"golang.org/x/oauth2"
+	"mcp-digitalocean/internal/waiter"
 )
 
 // Constants for configuration and magic values
@@ -159,108 +160,63 @@ func MustGodoClient(ctx context.Context, testName string) (*godo.Client, error)
 
 // waitForActionGeneric handles the common logic for polling Action status (Completed/Errored).
 func waitForActionGeneric(ctx context.Context, interval, timeout time.Duration, fetch func() (*godo.Action, *godo.Response, error)) (*godo.Action, error) {
-	var action *godo.Action
-	err := poll(ctx, interval, timeout, func() (bool, error) {
+	return poll(ctx, interval, timeout, func() (*godo.Action, bool, error) {
 		a, resp, err := fetch()
 
 		// Resiliency: If the request timed out locally (client-side), just retry
 		if err != nil && os.IsTimeout(err) {
-			return false, nil
+			return nil, false, waiter.Transient(err)
 		}
 
 		if resp != nil && resp.StatusCode == http.StatusNotFound {
-			return false, errors.New("action not found")
+			return nil, false, errors.New("action not found")
 		}
 		if err != nil {
-			return false, err
+			return nil, false, err
 		}
-		action = a
 		switch a.Status {
 		case actionStatusCompleted:
-			return true, nil
+			return a, true, nil
 		case actionStatusErrored:
-			return false, errors.New("action errored")
+			return a, false, errors.New("action errored")
 		default:
-			return false, nil // in-progress
+			return a, false, nil // in-progress
 		}
 	})
-	return action, err
 }
 
 // waitForResource is a generic helper to poll for a resource [T] until it satisfies a predicate.
 // If predicate is nil, it waits for the resource to NOT be found (404), used for deletion checks.
 func waitForResource[T any](ctx context.Context, interval, timeout time.Duration, fetch func() (*T, *godo.Response, error), predicate func(*T) bool) (*T, error) {
-	var last *T
-	err := poll(ctx, interval, timeout, func() (bool, error) {
+	return poll(ctx, interval, timeout, func() (*T, bool, error) {
 		resource, resp, err := fetch()
 		if err != nil {
 			if os.IsTimeout(err) {
-				return false, nil
+				return nil, false, waiter.Transient(err)
 			}
 
 			if resp != nil && resp.StatusCode == http.StatusNotFound {
 				if predicate == nil {
-					last = nil
-					return true, nil // Deletion confirmed
+					return nil, true, nil // Deletion confirmed
 				}
-				return false, fmt.Errorf("resource not found")
+				return nil, false, fmt.Errorf("resource not found")
 			}
-			return false, err
+			return nil, false, err
 		}
-		last = resource
-		if predicate != nil && predicate(resource) {
-			return true, nil
-		}
-		return false, nil
+		return resource, predicate != nil && predicate(resource), nil
 	})
-	return last, err
 }
 
-// poll is a generic loop that runs 'check' every 'interval' until 'timeout'.
-// check() returns (done, error). If done=true, poll returns nil. If error!=nil, poll returns error.
-func poll(ctx context.Context, interval, timeout time.Duration, check func() (bool, error)) error {
+// poll runs check every interval until it reports done or fails, applying
+// the package defaults when interval or timeout is zero.
+func poll[T any](ctx context.Context, interval, timeout time.Duration, check func() (T, bool, error)) (T, error) {
 	if interval == 0 {
 		interval = defaultInterval
 	}
 	if timeout == 0 {
 		timeout = defaultTimeout
 	}
-
-	ticker := time.NewTicker(interval)
-	defer ticker.Stop()
-	timer := time.NewTimer(timeout)
-	defer timer.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-timer.C:
-			return fmt.Errorf("timed out after %s", timeout)
-		case <-ticker.C:
-			// fallthrough to check
-		default:
-			// Check immediately on first run
-		}
-
-		done, err := check()
-		if err != nil {
-			return err
-		}
-		if done {
-			return nil
-		}
-
-		// Ensure we wait for the ticker if we just ran a check to avoid hot looping
-		if interval > 0 {
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			case <-timer.C:
-				return fmt.Errorf("timed out after %s", timeout)
-			case <-ticker.C:
-				continue
-			}
-		}
-	}
+	return waiter.Poll(ctx, interval, timeout, func(context.Context) (T, bool, error) {
+		return check()
+	})
 }
@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DefaultToolBacklog is how many calls of a tool may queue for a free slot
+// before further calls are refused.
+const DefaultToolBacklog = 4
+
+// ToolLimiter is a middleware that caps how many calls of the same tool run at
+// once. Calls over the cap wait in a short queue; once the queue is full they
+// fail with a tool error asking the agent to slow down. This protects the API
+// from agents that fan out the same call in a loop.
+type ToolLimiter struct {
+	// MaxConcurrent is how many calls of one tool may run at once.
+	MaxConcurrent int
+	// Backlog is how many calls of one tool may wait for a free slot.
+	Backlog int
+
+	mu    sync.Mutex
+	tools map[string]*toolSlots
+}
+
+type toolSlots struct {
+	running chan struct{}
+	queued  int
+}
+
+// NewToolLimiter creates a limiter allowing maxConcurrent calls per tool and a
+// queue of DefaultToolBacklog.
+func NewToolLimiter(maxConcurrent int) *ToolLimiter {
+	return &ToolLimiter{
+		MaxConcurrent: maxConcurrent,
+		Backlog:       DefaultToolBacklog,
+		tools:         make(map[string]*toolSlots),
+	}
+}
+
+// ToolMiddleware wraps a tool handler to enforce the per-tool limit. A queued
+// call gives up when its context ends, so a caller's deadline covers the time
+// spent waiting as well as the call itself.
+func (l *ToolLimiter) ToolMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name := req.Params.Name
+		slots := l.slots(name)
+
+		select {
+		case slots.running <- struct{}{}:
+		default:
+			l.mu.Lock()
+			if slots.queued >= l.Backlog {
+				l.mu.Unlock()
+				return mcp.NewToolResultError(fmt.Sprintf("too many concurrent %s calls: %d running and %d queued. Wait for earlier calls to finish before calling %s again", name, l.MaxConcurrent, l.Backlog, name)), nil
+			}
+			slots.queued++
+			l.mu.Unlock()
+
+			select {
+			case slots.running <- struct{}{}:
+				l.dequeue(slots)
+			case <-ctx.Done():
+				l.dequeue(slots)
+				return mcp.NewToolResultErrorFromErr(fmt.Sprintf("gave up waiting for a free %s slot", name), ctx.Err()), nil
+			}
+		}
+		defer func() { <-slots.running }()
+
+		return next(ctx, req)
+	}
+}
+
+func (l *ToolLimiter) slots(name string) *toolSlots {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	slots, ok := l.tools[name]
+	if !ok {
+		slots = &toolSlots{running: make(chan struct{}, l.MaxConcurrent)}
+		l.tools[name] = slots
+	}
+	return slots
+}
+
+func (l *ToolLimiter) dequeue(slots *toolSlots) {
+	l.mu.Lock()
+	slots.queued--
+	l.mu.Unlock()
+}
@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+// slowHandler blocks every call until release is closed, recording how many
+// calls ran at once.
+type slowHandler struct {
+	release chan struct{}
+	started chan struct{}
+	running atomic.Int32
+	peak    atomic.Int32
+}
+
+func newSlowHandler() *slowHandler {
+	return &slowHandler{release: make(chan struct{}), started: make(chan struct{}, 100)}
+}
+
+func (h *slowHandler) handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	n := h.running.Add(1)
+	defer h.running.Add(-1)
+	for {
+		p := h.peak.Load()
+		if n <= p || h.peak.CompareAndSwap(p, n) {
+			break
+		}
+	}
+	h.started <- struct{}{}
+	<-h.release
+	return mcp.NewToolResultText("listed"), nil
+}
+
+func callLimited(ctx context.Context, handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), name string) *mcp.CallToolResult {
+	result, _ := handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Name: name}})
+	return result
+}
+
+func queued(l *ToolLimiter, name string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.tools[name].queued
+}
+
+func TestToolLimiter_ToolMiddleware(t *testing.T) {
+	limiter := NewToolLimiter(2)
+	limiter.Backlog = 2
+	slow := newSlowHandler()
+	handler := limiter.ToolMiddleware(slow.handle)
+
+	var wg sync.WaitGroup
+	results := make(chan *mcp.CallToolResult, 4)
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results <- callLimited(context.Background(), handler, "image-list")
+		}()
+	}
+
+	// two calls run, two wait in the backlog.
+	<-slow.started
+	<-slow.started
+	require.Eventually(t, func() bool { return queued(limiter, "image-list") == 2 }, time.Second, time.Millisecond)
+
+	overflow := callLimited(context.Background(), handler, "image-list")
+	require.True(t, overflow.IsError)
+	require.Contains(t, overflow.Content[0].(mcp.TextContent).Text, "too many concurrent image-list calls: 2 running and 2 queued")
+
+	// other tools have their own slots.
+	other := make(chan *mcp.CallToolResult, 1)
+	go func() { other <- callLimited(context.Background(), handler, "size-list") }()
+	<-slow.started
+
+	close(slow.release)
+	wg.Wait()
+	close(results)
+	for result := range results {
+		require.False(t, result.IsError)
+	}
+	require.False(t, (<-other).IsError)
+	require.Equal(t, int32(3), slow.peak.Load(), "two image-list calls plus one size-list call")
+	require.Equal(t, 0, queued(limiter, "image-list"))
+}
+
+func TestToolLimiter_ToolMiddlewareQueuedCallTimesOut(t *testing.T) {
+	limiter := NewToolLimiter(1)
+	slow := newSlowHandler()
+	defer close(slow.release)
+	handler := limiter.ToolMiddleware(slow.handle)
+
+	go callLimited(context.Background(), handler, "image-list")
+	<-slow.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	result := callLimited(ctx, handler, "image-list")
+	require.True(t, result.IsError)
+	require.Contains(t, result.Content[0].(mcp.TextContent).Text, "gave up waiting for a free image-list slot")
+	require.Equal(t, 0, queued(limiter, "image-list"))
+	require.Equal(t, int32(1), slow.running.Load())
+}
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ToolTimeout is a middleware that bounds how long a tool call may run. The
+// handler's context is cancelled at the deadline, which aborts its API calls,
+// and the caller gets an error result naming the tool instead of waiting on a
+// handler stuck in a call such as GetKubeConfig on a broken cluster.
+type ToolTimeout struct {
+	// Default is the timeout of tools not in PerTool. 0 means no timeout.
+	Default time.Duration
+	// PerTool overrides Default by tool name. 0 means no timeout.
+	PerTool map[string]time.Duration
+}
+
+// NewToolTimeout creates a ToolTimeout with the given default and per-tool
+// overrides.
+func NewToolTimeout(defaultTimeout time.Duration, perTool map[string]time.Duration) *ToolTimeout {
+	return &ToolTimeout{Default: defaultTimeout, PerTool: perTool}
+}
+
+// timeout returns the timeout of the named tool.
+func (t *ToolTimeout) timeout(name string) time.Duration {
+	if timeout, ok := t.PerTool[name]; ok {
+		return timeout
+	}
+	return t.Default
+}
+
+type toolResult struct {
+	result *mcp.CallToolResult
+	err    error
+}
+
+// ToolMiddleware wraps a tool handler to enforce its timeout. The handler runs
+// in its own goroutine so the call can return at the deadline even if the
+// handler ignores its context; its late result is discarded. Add it before
+// PanicRecovery, so that recovery runs in the handler's goroutine.
+func (t *ToolTimeout) ToolMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name := req.Params.Name
+		timeout := t.timeout(name)
+		if timeout <= 0 {
+			return next(ctx, req)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		// buffered so a handler that finishes after the deadline does not
+		// block forever.
+		done := make(chan toolResult, 1)
+		go func() {
+			result, err := next(ctx, req)
+			done <- toolResult{result: result, err: err}
+		}()
+
+		select {
+		case r := <-done:
+			return r.result, r.err
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return mcp.NewToolResultError(fmt.Sprintf("%s timed out after %s. The operation may still complete; check the resource's state before retrying", name, timeout)), nil
+			}
+			return mcp.NewToolResultErrorFromErr(fmt.Sprintf("%s was cancelled", name), ctx.Err()), nil
+		}
+	}
+}
+
+// ParseToolTimeouts parses per-tool timeouts given as comma-separated
+// name=duration pairs, such as "doks-create-cluster=300s,droplet-create=2m".
+func ParseToolTimeouts(s string) (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%q is not a tool=duration pair", pair)
+		}
+		timeout, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("timeout of %s: %w", name, err)
+		}
+		if timeout < 0 {
+			return nil, fmt.Errorf("timeout of %s is negative", name)
+		}
+		timeouts[name] = timeout
+	}
+	return timeouts, nil
+}
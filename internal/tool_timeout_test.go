@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+// delayedHandler returns after delay, or when its context ends, reporting which
+// happened on cancelled.
+func delayedHandler(delay time.Duration, cancelled chan<- bool) func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		select {
+		case <-time.After(delay):
+			cancelled <- false
+			return mcp.NewToolResultText("done"), nil
+		case <-ctx.Done():
+			cancelled <- true
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func TestToolTimeout_ToolMiddleware(t *testing.T) {
+	timeouts := NewToolTimeout(20*time.Millisecond, map[string]time.Duration{
+		"doks-create-cluster": time.Second,
+		"droplet-action-wait": 0,
+	})
+
+	tests := []struct {
+		name          string
+		tool          string
+		delay         time.Duration
+		expectTimeout bool
+	}{
+		{name: "fast call", tool: "droplet-get", delay: time.Millisecond},
+		{name: "default timeout", tool: "doks-get-kubeconfig", delay: time.Second, expectTimeout: true},
+		{name: "longer per-tool timeout", tool: "doks-create-cluster", delay: 100 * time.Millisecond},
+		{name: "per-tool timeout disabled", tool: "droplet-action-wait", delay: 100 * time.Millisecond},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cancelled := make(chan bool, 1)
+			handler := timeouts.ToolMiddleware(delayedHandler(tc.delay, cancelled))
+
+			start := time.Now()
+			result, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Name: tc.tool}})
+			require.NoError(t, err)
+			if !tc.expectTimeout {
+				require.False(t, result.IsError)
+				require.Equal(t, "done", result.Content[0].(mcp.TextContent).Text)
+				require.False(t, <-cancelled)
+				return
+			}
+
+			require.Less(t, time.Since(start), tc.delay)
+			require.True(t, result.IsError)
+			require.Contains(t, result.Content[0].(mcp.TextContent).Text, tc.tool+" timed out after 20ms")
+			// the handler's context is cancelled so its API calls stop too.
+			require.True(t, <-cancelled)
+		})
+	}
+}
+
+func TestToolTimeout_handlerIgnoringContext(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	handler := NewToolTimeout(20*time.Millisecond, nil).ToolMiddleware(func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		<-release
+		return mcp.NewToolResultText("late"), nil
+	})
+
+	result, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "doks-get-kubeconfig"}})
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	require.Contains(t, result.Content[0].(mcp.TextContent).Text, "doks-get-kubeconfig timed out")
+}
+
+func TestToolTimeout_callerCancelled(t *testing.T) {
+	handler := NewToolTimeout(time.Minute, nil).ToolMiddleware(delayedHandler(time.Minute, make(chan bool, 1)))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "droplet-get"}})
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	require.Contains(t, result.Content[0].(mcp.TextContent).Text, "droplet-get was cancelled")
+}
+
+func TestParseToolTimeouts(t *testing.T) {
+	timeouts, err := ParseToolTimeouts(" doks-create-cluster=300s, droplet-create=2m,,droplet-action-wait=0s")
+	require.NoError(t, err)
+	require.Equal(t, map[string]time.Duration{
+		"doks-create-cluster": 300 * time.Second,
+		"droplet-create":      2 * time.Minute,
+		"droplet-action-wait": 0,
+	}, timeouts)
+
+	timeouts, err = ParseToolTimeouts("")
+	require.NoError(t, err)
+	require.Empty(t, timeouts)
+
+	for _, invalid := range []string{"droplet-create", "=60s", "droplet-create=soon", "droplet-create=-1s"} {
+		_, err := ParseToolTimeouts(invalid)
+		require.Error(t, err, invalid)
+	}
+}
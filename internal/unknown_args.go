@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// UnknownArgsWarner is a middleware that reports arguments a tool does not
+// declare in its input schema. Tools ignore such arguments, so an agent
+// passing Tags to a tool without them would otherwise never learn that they
+// were dropped. Successful results get an extra content item
+// {"warnings": ["unknown argument: Tags"]}.
+type UnknownArgsWarner struct {
+	// Tool returns the registered tool with the given name, or nil.
+	Tool func(name string) *mcp.Tool
+}
+
+// NewUnknownArgsWarner creates a warner that looks tools up with tool,
+// typically (*server.MCPServer).GetTool.
+func NewUnknownArgsWarner(tool func(name string) *mcp.Tool) *UnknownArgsWarner {
+	return &UnknownArgsWarner{Tool: tool}
+}
+
+// ToolMiddleware wraps a tool handler to append unknown-argument warnings.
+func (w *UnknownArgsWarner) ToolMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := next(ctx, req)
+		if err != nil || result == nil || result.IsError {
+			return result, err
+		}
+
+		tool := w.Tool(req.Params.Name)
+		if tool == nil {
+			return result, nil
+		}
+		warnings := unknownArgWarnings(*tool, req.GetArguments())
+		if len(warnings) == 0 {
+			return result, nil
+		}
+		data, err := json.Marshal(map[string][]string{"warnings": warnings})
+		if err != nil {
+			return result, nil
+		}
+		result.Content = append(result.Content, mcp.NewTextContent(string(data)))
+		return result, nil
+	}
+}
+
+// unknownArgWarnings returns a sorted warning per argument missing from the
+// tool's declared properties. Tools whose schema allows additional properties,
+// and raw schemas that declare none, accept anything and get no warnings.
+func unknownArgWarnings(tool mcp.Tool, args map[string]any) []string {
+	declared, ok := declaredArgs(tool)
+	if !ok {
+		return nil
+	}
+	var warnings []string
+	for name := range args {
+		if _, ok := declared[name]; !ok {
+			warnings = append(warnings, "unknown argument: "+name)
+		}
+	}
+	slices.Sort(warnings)
+	return warnings
+}
+
+// declaredArgs returns the argument names the tool's input schema declares,
+// reading the raw schema for tools built with mcp.NewToolWithRawSchema.
+func declaredArgs(tool mcp.Tool) (map[string]any, bool) {
+	if tool.RawInputSchema == nil {
+		if tool.InputSchema.AdditionalProperties == true {
+			return nil, false
+		}
+		return tool.InputSchema.Properties, true
+	}
+
+	var schema struct {
+		Properties           map[string]any `json:"properties"`
+		AdditionalProperties any            `json:"additionalProperties"`
+	}
+	if err := json.Unmarshal(tool.RawInputSchema, &schema); err != nil || len(schema.Properties) == 0 || schema.AdditionalProperties == true {
+		return nil, false
+	}
+	return schema.Properties, true
+}
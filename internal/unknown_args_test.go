@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"mcp-digitalocean/pkg/registry/doks"
+)
+
+func TestUnknownArgsWarner_ToolMiddleware(t *testing.T) {
+	tools := map[string]mcp.Tool{
+		"droplet-get": mcp.NewTool("droplet-get",
+			mcp.WithNumber("ID", mcp.Required()),
+		),
+		"account-get": mcp.NewTool("account-get"),
+		"free-form":   mcp.NewToolWithRawSchema("free-form", "", []byte(`{"type": "object"}`)),
+	}
+	for _, st := range doks.NewDoksTool(nil).Tools() {
+		tools[st.Tool.Name] = st.Tool
+	}
+	warner := NewUnknownArgsWarner(func(name string) *mcp.Tool {
+		tool, ok := tools[name]
+		if !ok {
+			return nil
+		}
+		return &tool
+	})
+
+	tests := []struct {
+		name         string
+		tool         string
+		args         map[string]any
+		handlerErr   bool
+		wantWarnings string
+	}{
+		{
+			name:         "builder tool with unknown arguments",
+			tool:         "droplet-get",
+			args:         map[string]any{"ID": float64(1), "Tags": []any{"web"}, "Region": "nyc3"},
+			wantWarnings: `{"warnings":["unknown argument: Region","unknown argument: Tags"]}`,
+		},
+		{
+			name: "builder tool with known arguments",
+			tool: "droplet-get",
+			args: map[string]any{"ID": float64(1)},
+		},
+		{
+			name:         "builder tool without arguments",
+			tool:         "account-get",
+			args:         map[string]any{"Verbose": true},
+			wantWarnings: `{"warnings":["unknown argument: Verbose"]}`,
+		},
+		{
+			name:         "raw schema tool with unknown arguments",
+			tool:         "doks-create-cluster",
+			args:         map[string]any{"name": "k8s", "region": "nyc3", "OverrideSpendLimit": true, "Size": "s-2vcpu-4gb"},
+			wantWarnings: `{"warnings":["unknown argument: Size"]}`,
+		},
+		{
+			name: "raw schema tool with known arguments",
+			tool: "doks-create-cluster",
+			args: map[string]any{"name": "k8s", "region": "nyc3", "node_pools": []any{}},
+		},
+		{
+			name: "raw schema without properties",
+			tool: "free-form",
+			args: map[string]any{"anything": 1},
+		},
+		{
+			name: "unregistered tool",
+			tool: "nope",
+			args: map[string]any{"anything": 1},
+		},
+		{
+			name:       "error results are left alone",
+			tool:       "droplet-get",
+			args:       map[string]any{"Tags": "web"},
+			handlerErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := warner.ToolMiddleware(func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				if tc.handlerErr {
+					return mcp.NewToolResultErrorFromErr("api error", errors.New("boom")), nil
+				}
+				return mcp.NewToolResultText(`{"id": 1}`), nil
+			})
+
+			result, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Name: tc.tool, Arguments: tc.args}})
+			require.NoError(t, err)
+			if tc.wantWarnings == "" {
+				require.Len(t, result.Content, 1)
+				return
+			}
+			require.Len(t, result.Content, 2)
+			require.JSONEq(t, tc.wantWarnings, result.Content[1].(mcp.TextContent).Text)
+		})
+	}
+}
@@ -0,0 +1,148 @@
+// Package waiter polls a resource until it reaches a wanted state, shared by the
+// tool handlers that wait on DigitalOcean operations and by the test helpers.
+package waiter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// ErrTimeout is returned, wrapped, when the timeout elapses before fetch
+// reports done.
+var ErrTimeout = errors.New("timed out")
+
+// Clock is the time source Poll measures the timeout with and sleeps on.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// Progress describes a finished poll. Err is set when the poll failed with a
+// transient error.
+type Progress struct {
+	Attempt int
+	Elapsed time.Duration
+	Err     error
+}
+
+type options struct {
+	clock    Clock
+	jitter   float64
+	rand     func() float64
+	progress func(Progress)
+}
+
+// Option configures Poll.
+type Option func(*options)
+
+// WithClock replaces the wall clock, for tests.
+func WithClock(c Clock) Option {
+	return func(o *options) { o.clock = c }
+}
+
+// WithJitter spreads each wait uniformly over interval ± fraction*interval so
+// that many waiters started together do not poll in lockstep.
+func WithJitter(fraction float64) Option {
+	return func(o *options) { o.jitter = fraction }
+}
+
+// WithProgress calls fn after every poll that did not fail fatally.
+func WithProgress(fn func(Progress)) Option {
+	return func(o *options) { o.progress = fn }
+}
+
+type transientError struct{ err error }
+
+func (e *transientError) Error() string { return e.err.Error() }
+func (e *transientError) Unwrap() error { return e.err }
+
+// Transient marks err as retryable: Poll keeps polling instead of returning it.
+// Any other error returned by fetch ends the wait.
+func Transient(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &transientError{err: err}
+}
+
+// Poll calls fetch straight away and then every interval until it reports
+// done, returns a non-transient error, the timeout elapses or ctx is
+// cancelled. The ctx passed to fetch carries the timeout.
+//
+// On success, and when fetch fails with a non-transient error, Poll returns
+// what the final fetch returned. On timeout or cancellation it returns the
+// value of the last fetch that succeeded together with ctx.Err() or an error
+// wrapping ErrTimeout and the last transient error, if any.
+func Poll[T any](ctx context.Context, interval, timeout time.Duration, fetch func(ctx context.Context) (T, bool, error), opts ...Option) (T, error) {
+	o := options{clock: realClock{}, rand: rand.Float64}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	parent := ctx
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := o.clock.Now()
+	deadline := start.Add(timeout)
+	var last T
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		value, done, err := fetch(ctx)
+		if err != nil {
+			if parent.Err() == nil && ctx.Err() != nil {
+				return last, timeoutError(timeout, err)
+			}
+			var transient *transientError
+			if !errors.As(err, &transient) {
+				return value, err
+			}
+			lastErr = transient.err
+		} else {
+			last, lastErr = value, nil
+		}
+		if o.progress != nil {
+			o.progress(Progress{Attempt: attempt, Elapsed: o.clock.Now().Sub(start), Err: lastErr})
+		}
+		if err == nil && done {
+			return value, nil
+		}
+
+		remaining := deadline.Sub(o.clock.Now())
+		if remaining <= 0 {
+			return last, timeoutError(timeout, lastErr)
+		}
+		select {
+		case <-ctx.Done():
+			if parent.Err() != nil {
+				return last, parent.Err()
+			}
+			return last, timeoutError(timeout, lastErr)
+		case <-o.clock.After(min(o.wait(interval), remaining)):
+		}
+	}
+}
+
+// wait returns interval with jitter applied.
+func (o *options) wait(interval time.Duration) time.Duration {
+	if o.jitter <= 0 {
+		return interval
+	}
+	delta := (o.rand()*2 - 1) * o.jitter * float64(interval)
+	return max(time.Duration(float64(interval)+delta), 0)
+}
+
+func timeoutError(timeout time.Duration, lastErr error) error {
+	if lastErr != nil {
+		return fmt.Errorf("%w after %s, last error: %w", ErrTimeout, timeout, lastErr)
+	}
+	return fmt.Errorf("%w after %s", ErrTimeout, timeout)
+}
@@ -0,0 +1,196 @@
+package waiter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock advances by the requested duration whenever Poll sleeps, so tests
+// run instantly and can assert exactly how long Poll waited.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	c.sleeps = append(c.sleeps, d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+// sequence returns a fetch that replays results in order, repeating the last.
+func sequence(results ...result) (func(context.Context) (string, bool, error), *int) {
+	calls := 0
+	return func(ctx context.Context) (string, bool, error) {
+		r := results[min(calls, len(results)-1)]
+		calls++
+		return r.value, r.done, r.err
+	}, &calls
+}
+
+type result struct {
+	value string
+	done  bool
+	err   error
+}
+
+func TestPoll(t *testing.T) {
+	fatal := errors.New("forbidden")
+	flaky := errors.New("connection reset")
+
+	tests := []struct {
+		name       string
+		results    []result
+		timeout    time.Duration
+		want       string
+		wantErr    string
+		wantErrIs  error
+		wantCalls  int
+		wantSleeps []time.Duration
+	}{
+		{
+			name:      "immediate success",
+			results:   []result{{value: "active", done: true}},
+			timeout:   time.Minute,
+			want:      "active",
+			wantCalls: 1,
+		},
+		{
+			name:       "eventual success",
+			results:    []result{{value: "new"}, {value: "new"}, {value: "active", done: true}},
+			timeout:    time.Minute,
+			want:       "active",
+			wantCalls:  3,
+			wantSleeps: []time.Duration{10 * time.Second, 10 * time.Second},
+		},
+		{
+			name:       "transient errors are retried",
+			results:    []result{{value: "new"}, {err: Transient(flaky)}, {value: "active", done: true}},
+			timeout:    time.Minute,
+			want:       "active",
+			wantCalls:  3,
+			wantSleeps: []time.Duration{10 * time.Second, 10 * time.Second},
+		},
+		{
+			name:       "fatal errors end the wait",
+			results:    []result{{value: "new"}, {value: "failed", err: fatal}},
+			timeout:    time.Minute,
+			want:       "failed",
+			wantErrIs:  fatal,
+			wantErr:    "forbidden",
+			wantCalls:  2,
+			wantSleeps: []time.Duration{10 * time.Second},
+		},
+		{
+			name:       "timeout",
+			results:    []result{{value: "new"}},
+			timeout:    25 * time.Second,
+			want:       "new",
+			wantErrIs:  ErrTimeout,
+			wantErr:    "timed out after 25s",
+			wantCalls:  4,
+			wantSleeps: []time.Duration{10 * time.Second, 10 * time.Second, 5 * time.Second},
+		},
+		{
+			name:       "timeout reports the last transient error",
+			results:    []result{{value: "new"}, {err: Transient(flaky)}},
+			timeout:    15 * time.Second,
+			want:       "new",
+			wantErrIs:  flaky,
+			wantErr:    "timed out after 15s, last error: connection reset",
+			wantCalls:  3,
+			wantSleeps: []time.Duration{10 * time.Second, 5 * time.Second},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := newFakeClock()
+			fetch, calls := sequence(tc.results...)
+
+			got, err := Poll(context.Background(), 10*time.Second, tc.timeout, fetch, WithClock(clock))
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				require.ErrorIs(t, err, tc.wantErrIs)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.want, got)
+			require.Equal(t, tc.wantCalls, *calls)
+			require.Equal(t, tc.wantSleeps, clock.sleeps)
+		})
+	}
+}
+
+func TestPollCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	fetch := func(context.Context) (string, bool, error) {
+		cancel()
+		return "new", false, nil
+	}
+
+	got, err := Poll(ctx, time.Hour, time.Hour, fetch)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, "new", got)
+}
+
+func TestPollProgress(t *testing.T) {
+	clock := newFakeClock()
+	flaky := errors.New("connection reset")
+	fetch, _ := sequence(result{value: "new"}, result{err: Transient(flaky)}, result{value: "active", done: true})
+
+	var progress []Progress
+	_, err := Poll(context.Background(), 10*time.Second, time.Minute, fetch, WithClock(clock), WithProgress(func(p Progress) {
+		progress = append(progress, p)
+	}))
+	require.NoError(t, err)
+	require.Equal(t, []Progress{
+		{Attempt: 1},
+		{Attempt: 2, Elapsed: 10 * time.Second, Err: flaky},
+		{Attempt: 3, Elapsed: 20 * time.Second},
+	}, progress)
+}
+
+func TestPollJitter(t *testing.T) {
+	tests := []struct {
+		name string
+		rand float64
+		want time.Duration
+	}{
+		{name: "low end", rand: 0, want: 8 * time.Second},
+		{name: "middle", rand: 0.5, want: 10 * time.Second},
+		{name: "high end", rand: 1, want: 12 * time.Second},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := newFakeClock()
+			fetch, _ := sequence(result{value: "new"}, result{value: "active", done: true})
+			opts := []Option{WithClock(clock), WithJitter(0.2), func(o *options) { o.rand = func() float64 { return tc.rand } }}
+
+			_, err := Poll(context.Background(), 10*time.Second, time.Minute, fetch, opts...)
+			require.NoError(t, err)
+			require.Equal(t, []time.Duration{tc.want}, clock.sleeps)
+		})
+	}
+}
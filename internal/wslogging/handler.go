@@ -1,9 +1,11 @@
 // Package wslogging provides a slog.Handler that can optionally send logs to a WebSocket endpoint.
 // It is a drop-in replacement for slog.JSONHandler that maintains stderr logging by default,
-// but can be configured to send logs to a WebSocket server for centralized log aggregation.
+// but can be configured to send logs to a WebSocket server for centralized log aggregation,
+// and to connected MCP clients as log notifications.
 package wslogging
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -29,6 +31,33 @@ const (
 	maxBatchSize = 50
 )
 
+// BatchFrame is how a flushed batch is framed on the WebSocket.
+type BatchFrame string
+
+const (
+	// BatchFramePerMessage sends each log entry in a frame of its own.
+	BatchFramePerMessage BatchFrame = "per-message"
+	// BatchFrameNDJSON sends the whole batch in one frame, one JSON entry per
+	// line.
+	BatchFrameNDJSON BatchFrame = "ndjson"
+)
+
+// ParseBatchFrame returns the BatchFrame named s.
+func ParseBatchFrame(s string) (BatchFrame, error) {
+	switch frame := BatchFrame(s); frame {
+	case BatchFramePerMessage, BatchFrameNDJSON:
+		return frame, nil
+	}
+	return "", fmt.Errorf("unknown batch frame %q (must be %s or %s)", s, BatchFramePerMessage, BatchFrameNDJSON)
+}
+
+// diagnosticStdout and diagnosticStderr receive the handler's own diagnostics.
+// They are variables so tests can capture what would reach the process output.
+var (
+	diagnosticStdout io.Writer = os.Stdout
+	diagnosticStderr io.Writer = os.Stderr
+)
+
 // Handler implements slog.Handler interface with optional WebSocket logging support.
 // By default, it logs to the provided io.Writer (typically stderr).
 // When configured with a WebSocket URL, it sends logs to both stderr and the WebSocket endpoint.
@@ -42,6 +71,8 @@ type Handler struct {
 	wsToken   string
 	wsBuffer  chan []byte
 	wsMu      *sync.Mutex
+	// batchFrame is how flushBatch frames a batch, protected by wsMu
+	batchFrame BatchFrame
 
 	// batch stores accumulated log messages for batched WebSocket transmission
 	// protected by flushMu
@@ -50,6 +81,9 @@ type Handler struct {
 	// flushMu protects batch modifications
 	flushMu *sync.Mutex
 
+	// MCP client logging, shared across derived handlers
+	mcp *mcpSink
+
 	// handler state for WithAttrs/WithGroup
 	attrs  []slog.Attr
 	groups []string
@@ -68,9 +102,11 @@ func NewHandler(out io.Writer, opts *slog.HandlerOptions) *Handler {
 	h := &Handler{
 		stderrHandler: slog.NewJSONHandler(out, opts),
 		wsMu:          &sync.Mutex{},
+		batchFrame:    BatchFramePerMessage,
 		batch:         make([][]byte, 0, maxBatchSize),
 		flushMu:       &sync.Mutex{},
 		closeOnce:     &sync.Once{},
+		mcp:           newMCPSink(),
 	}
 
 	return h
@@ -85,7 +121,9 @@ func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
 // Handle processes a log record.
 // Logs are always written to stderr (primary destination).
 // If WebSocket logging is enabled, logs are also sent to the WebSocket endpoint asynchronously (complementary destination).
-// Both destinations are independent - failure in one does not affect the other.
+// If MCP logging is configured, WARN and above records logged with a tool call's context are also sent
+// to that call's MCP client (complementary destination).
+// All destinations are independent - failure in one does not affect the others.
 func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
 	// Check if handler is closed and capture wsEnabled state (thread-safe)
 	h.wsMu.Lock()
@@ -105,6 +143,9 @@ func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
 		go h.sendToWebSocket(r)
 	}
 
+	// if the record belongs to an MCP session that opted in, forward it to the client
+	h.sendToMCP(ctx, r)
+
 	// return stderr error if it failed (primary logging destination)
 	// we ignore WebSocket errors as it's a complementary logging destination
 	return stderrErr
@@ -116,7 +157,7 @@ func (h *Handler) sendToWebSocket(r slog.Record) {
 	entry := h.buildLogEntry(r)
 	data, err := json.Marshal(entry)
 	if err != nil {
-		logDiagnostic(slog.LevelError, os.Stderr, "failed to marshal log entry: %v\n", err)
+		logDiagnostic(slog.LevelError, diagnosticStderr, "failed to marshal log entry: %v\n", err)
 		return
 	}
 
@@ -125,7 +166,7 @@ func (h *Handler) sendToWebSocket(r slog.Record) {
 	defer h.wsMu.Unlock()
 
 	if h.closed {
-		logDiagnostic(slog.LevelError, os.Stderr, "dropping log message: handler is closed\n")
+		logDiagnostic(slog.LevelError, diagnosticStderr, "dropping log message: handler is closed\n")
 		return
 	}
 
@@ -134,7 +175,7 @@ func (h *Handler) sendToWebSocket(r slog.Record) {
 		// successfully queued for WebSocket transmission
 	default:
 		// buffer is full, drop the WebSocket message
-		logDiagnostic(slog.LevelError, os.Stderr, "dropping log message: buffer is full (%d messages)\n", bufferSize)
+		logDiagnostic(slog.LevelError, diagnosticStderr, "dropping log message: buffer is full (%d messages)\n", bufferSize)
 	}
 }
 
@@ -160,11 +201,14 @@ func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
 		wsEnabled: h.wsEnabled,
 		wsURL:     h.wsURL,
 		wsToken:   h.wsToken,
+		// batchFrame is copied with the rest of the WebSocket configuration
+		batchFrame: h.batchFrame,
 		// these are shared across all derived handlers for efficiency
 		wsBuffer: h.wsBuffer, // shared buffer
 		wsMu:     h.wsMu,     // shared mutex
 		batch:    h.batch,    // shared batch (managed by single logWriter goroutine)
 		flushMu:  h.flushMu,  // shared flush mutex
+		mcp:      h.mcp,      // shared MCP sink
 		// each derived handler has its own attributes and groups
 		attrs:     newAttrs,
 		groups:    h.groups,
@@ -192,11 +236,14 @@ func (h *Handler) WithGroup(name string) slog.Handler {
 		wsEnabled: h.wsEnabled,
 		wsURL:     h.wsURL,
 		wsToken:   h.wsToken,
+		// batchFrame is copied with the rest of the WebSocket configuration
+		batchFrame: h.batchFrame,
 		// these are shared across all derived handlers for efficiency
 		wsBuffer: h.wsBuffer, // shared buffer
 		wsMu:     h.wsMu,     // shared mutex
 		batch:    h.batch,    // shared batch (managed by single logWriter goroutine)
 		flushMu:  h.flushMu,  // shared flush mutex
+		mcp:      h.mcp,      // shared MCP sink
 		// each derived handler has its own attributes and groups
 		attrs:     h.attrs,
 		groups:    newGroups,
@@ -227,7 +274,7 @@ func (h *Handler) ConfigureWebSocket(wsURL, token string) error {
 
 	// warn if no token provided (security risk)
 	if token == "" {
-		logDiagnostic(slog.LevelError, os.Stderr, "WARNING: no authentication token provided - this is a security risk\n")
+		logDiagnostic(slog.LevelError, diagnosticStderr, "WARNING: no authentication token provided - this is a security risk\n")
 	}
 
 	h.wsMu.Lock()
@@ -240,8 +287,20 @@ func (h *Handler) ConfigureWebSocket(wsURL, token string) error {
 	h.wsEnabled = true
 	h.wsBuffer = make(chan []byte, bufferSize)
 
-	// log startup diagnostic to stdout
-	logDiagnostic(slog.LevelError, os.Stdout, "configuring WebSocket logging to %s\n", wsURL)
+	// log startup diagnostic to stdout, without any credentials carried in the URL
+	logDiagnostic(slog.LevelError, diagnosticStdout, "configuring WebSocket logging to %s\n", redactURL(parsedURL))
+	return nil
+}
+
+// SetBatchFrame sets how each flushed batch is framed: BatchFramePerMessage,
+// the default, or BatchFrameNDJSON. Call it before Start.
+func (h *Handler) SetBatchFrame(frame BatchFrame) error {
+	if _, err := ParseBatchFrame(string(frame)); err != nil {
+		return err
+	}
+	h.wsMu.Lock()
+	defer h.wsMu.Unlock()
+	h.batchFrame = frame
 	return nil
 }
 
@@ -384,6 +443,7 @@ func (h *Handler) flushBatch() {
 	closed := h.closed
 	wsURL := h.wsURL
 	wsToken := h.wsToken
+	batchFrame := h.batchFrame
 	h.wsMu.Unlock()
 
 	if closed {
@@ -393,7 +453,7 @@ func (h *Handler) flushBatch() {
 	// establish connection (no locks held during network I/O)
 	conn, err := h.connect(wsURL, wsToken)
 	if err != nil {
-		logDiagnostic(slog.LevelError, os.Stderr, "failed to connect to WebSocket: %v\n", err)
+		logDiagnostic(slog.LevelError, diagnosticStderr, "failed to connect to WebSocket: %s\n", redactToken(err.Error(), wsToken))
 		// don't clear batch - we'll retry on next flush
 		return
 	}
@@ -405,19 +465,28 @@ func (h *Handler) flushBatch() {
 			time.Now().Add(time.Second),
 		)
 		if err != nil {
-			logDiagnostic(slog.LevelError, os.Stderr, "failed to send close message to WebSocket: %v\n", err)
+			logDiagnostic(slog.LevelError, diagnosticStderr, "failed to send close message to WebSocket: %v\n", err)
 		}
 		defer conn.Close()
 	}()
 
 	// send all messages in the local batch copy (no locks held during network I/O)
 	sentCount := 0
-	for _, data := range localBatch {
-		if err = conn.WriteMessage(websocket.TextMessage, data); err != nil {
-			logDiagnostic(slog.LevelError, os.Stderr, "failed to write message to WebSocket: %v\n", err)
-			break
+	if batchFrame == BatchFrameNDJSON {
+		// one frame for the whole batch, so it is sent or kept as a unit
+		if err = conn.WriteMessage(websocket.TextMessage, bytes.Join(localBatch, []byte("\n"))); err != nil {
+			logDiagnostic(slog.LevelError, diagnosticStderr, "failed to write batch to WebSocket: %v\n", err)
+		} else {
+			sentCount = len(localBatch)
+		}
+	} else {
+		for _, data := range localBatch {
+			if err = conn.WriteMessage(websocket.TextMessage, data); err != nil {
+				logDiagnostic(slog.LevelError, diagnosticStderr, "failed to write message to WebSocket: %v\n", err)
+				break
+			}
+			sentCount++
 		}
-		sentCount++
 	}
 
 	// atomic write-back - update h.batch based on what was sent
@@ -438,8 +507,11 @@ func (h *Handler) flushBatch() {
 
 // connect establishes a WebSocket connection to the configured endpoint.
 func (h *Handler) connect(wsURL, token string) (*websocket.Conn, error) {
+	// compression is negotiated: frames are deflated only if the server
+	// accepts permessage-deflate.
 	dialer := &websocket.Dialer{
-		HandshakeTimeout: handshakeTimeout,
+		HandshakeTimeout:  handshakeTimeout,
+		EnableCompression: true,
 	}
 
 	// set up headers for authentication
@@ -457,6 +529,26 @@ func (h *Handler) connect(wsURL, token string) (*websocket.Conn, error) {
 	return conn, nil
 }
 
+// redactURL returns u without its userinfo, query and fragment, any of which
+// may carry a credential.
+func redactURL(u *url.URL) string {
+	redacted := *u
+	redacted.User = nil
+	redacted.RawQuery = ""
+	redacted.ForceQuery = false
+	redacted.Fragment = ""
+	redacted.RawFragment = ""
+	return redacted.String()
+}
+
+// redactToken replaces every occurrence of token in s.
+func redactToken(s, token string) string {
+	if token == "" {
+		return s
+	}
+	return strings.ReplaceAll(s, token, "[REDACTED]")
+}
+
 // logDiagnostic writes a diagnostic message to the specified writer.
 // This is used for logging infrastructure issues (not application logs).
 // Messages are written as JSON to maintain consistency with application logs.
@@ -723,3 +723,179 @@ func TestHandler_ThreadSafeBatch(t *testing.T) {
 }
 
 // TestHandler_WebSocket_PingPong tests that the handler sends pings and handles pongs
+
+// captureDiagnostics redirects the handler's diagnostics into a buffer for the
+// rest of the test.
+func captureDiagnostics(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	stdout, stderr := diagnosticStdout, diagnosticStderr
+	diagnosticStdout, diagnosticStderr = &buf, &buf
+	t.Cleanup(func() { diagnosticStdout, diagnosticStderr = stdout, stderr })
+	return &buf
+}
+
+func TestConfigureWebSocket_DiagnosticsOmitToken(t *testing.T) {
+	const token = "s3cret-token"
+	tests := []struct {
+		name     string
+		url      string
+		expected string
+	}{
+		{
+			name:     "plain URL",
+			url:      "wss://logs.example.com/ingest",
+			expected: "wss://logs.example.com/ingest",
+		},
+		{
+			name:     "token in userinfo",
+			url:      "wss://user:" + token + "@logs.example.com/ingest",
+			expected: "wss://logs.example.com/ingest",
+		},
+		{
+			name:     "token in query and fragment",
+			url:      "wss://logs.example.com:9000/ingest?token=" + token + "&tenant=a#" + token,
+			expected: "wss://logs.example.com:9000/ingest",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			diagnostics := captureDiagnostics(t)
+			handler := NewHandler(&bytes.Buffer{}, &slog.HandlerOptions{Level: slog.LevelInfo})
+
+			require.NoError(t, handler.ConfigureWebSocket(tc.url, token))
+			require.Contains(t, diagnostics.String(), tc.expected)
+			require.NotContains(t, diagnostics.String(), token)
+			require.NotContains(t, diagnostics.String(), "tenant")
+		})
+	}
+}
+
+func TestHandler_ConnectFailureOmitsToken(t *testing.T) {
+	const token = "s3cret-token"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	diagnostics := captureDiagnostics(t)
+	handler := NewHandler(&bytes.Buffer{}, &slog.HandlerOptions{Level: slog.LevelInfo})
+	require.NoError(t, handler.ConfigureWebSocket(httpToWebSocketURL(server.URL)+"/?token="+token, token))
+
+	handler.batch = [][]byte{[]byte(`{"msg":"hello"}`)}
+	handler.flushBatch()
+
+	require.Contains(t, diagnostics.String(), "failed to connect to WebSocket")
+	require.NotContains(t, diagnostics.String(), token)
+	require.Len(t, handler.batch, 1, "unsent messages should be kept for the next flush")
+}
+
+func TestRedactToken(t *testing.T) {
+	require.Equal(t, "dial ws://host/?t=[REDACTED]: refused", redactToken("dial ws://host/?t=abc123: refused", "abc123"))
+	require.Equal(t, "unchanged", redactToken("unchanged", ""))
+}
+
+// mockFrameServer creates a test WebSocket server that accepts compression
+// and records each frame it receives, and the extensions each connection
+// negotiated.
+func mockFrameServer(t *testing.T) (*httptest.Server, chan []byte, chan string) {
+	t.Helper()
+
+	frames := make(chan []byte, 100)
+	extensions := make(chan string, 10)
+	upgrader := websocket.Upgrader{EnableCompression: true}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Logf("upgrade error: %v", err)
+			return
+		}
+		defer conn.Close()
+		extensions <- r.Header.Get("Sec-WebSocket-Extensions")
+
+		for {
+			_, frame, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			frames <- frame
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, frames, extensions
+}
+
+// TestHandler_BatchFrame tests that a full batch is sent as one frame per
+// entry, or as a single NDJSON frame.
+func TestHandler_BatchFrame(t *testing.T) {
+	tests := []struct {
+		name           string
+		frame          BatchFrame
+		expectedFrames int
+	}{
+		{name: "per-message", frame: BatchFramePerMessage, expectedFrames: maxBatchSize},
+		{name: "ndjson", frame: BatchFrameNDJSON, expectedFrames: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, frames, extensions := mockFrameServer(t)
+
+			handler := NewHandler(&bytes.Buffer{}, &slog.HandlerOptions{Level: slog.LevelInfo})
+			require.NoError(t, handler.ConfigureWebSocket(httpToWebSocketURL(server.URL), "test-token"))
+			require.NoError(t, handler.SetBatchFrame(tt.frame))
+			handler.Start(context.Background())
+			defer handler.Close(context.Background())
+
+			// a full batch is flushed at once, without waiting for the interval
+			logger := slog.New(handler)
+			for i := range maxBatchSize {
+				logger.Info("batch test", "n", i)
+			}
+
+			var received [][]byte
+			for len(received) < tt.expectedFrames {
+				select {
+				case frame := <-frames:
+					received = append(received, frame)
+				case <-time.After(3 * time.Second):
+					t.Fatalf("timeout: received %d/%d frames", len(received), tt.expectedFrames)
+				}
+			}
+			select {
+			case frame := <-frames:
+				t.Fatalf("unexpected extra frame: %s", frame)
+			case <-time.After(100 * time.Millisecond):
+			}
+
+			seen := make(map[float64]bool)
+			for _, frame := range received {
+				for _, line := range strings.Split(string(frame), "\n") {
+					var entry map[string]any
+					require.NoError(t, json.Unmarshal([]byte(line), &entry))
+					require.Equal(t, "batch test", entry["message"])
+					seen[entry["n"].(float64)] = true
+				}
+			}
+			require.Len(t, seen, maxBatchSize)
+
+			require.Contains(t, <-extensions, "permessage-deflate")
+		})
+	}
+}
+
+func TestParseBatchFrame(t *testing.T) {
+	frame, err := ParseBatchFrame("ndjson")
+	require.NoError(t, err)
+	require.Equal(t, BatchFrameNDJSON, frame)
+
+	frame, err = ParseBatchFrame("per-message")
+	require.NoError(t, err)
+	require.Equal(t, BatchFramePerMessage, frame)
+
+	_, err = ParseBatchFrame("lines")
+	require.Error(t, err)
+	require.Error(t, NewHandler(&bytes.Buffer{}, nil).SetBatchFrame("lines"))
+}
@@ -0,0 +1,98 @@
+package wslogging
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// mcpLoggerName is the logger name attached to forwarded MCP log notifications.
+const mcpLoggerName = "mcp-digitalocean"
+
+// ClientLogSender sends a log notification to the MCP client whose session is
+// in ctx. *server.MCPServer implements it.
+type ClientLogSender interface {
+	SendLogMessageToClient(ctx context.Context, notification mcp.LoggingMessageNotification) error
+}
+
+// mcpSink forwards WARN and above records to connected MCP clients as
+// notifications/message. It is shared by all handlers derived from the same
+// root handler, so logger.With() calls made before ConfigureMCP still forward.
+type mcpSink struct {
+	mu     sync.RWMutex
+	sender ClientLogSender
+	// sessions holds the IDs of sessions whose client has set a logging
+	// level. Only those sessions receive notifications.
+	sessions map[string]struct{}
+}
+
+func newMCPSink() *mcpSink {
+	return &mcpSink{sessions: make(map[string]struct{})}
+}
+
+// ConfigureMCP enables forwarding of WARN and above records to MCP clients
+// through sender. Records are only forwarded to sessions registered with
+// EnableMCPSession, and only when logged with the tool call's context.
+func (h *Handler) ConfigureMCP(sender ClientLogSender) {
+	h.mcp.mu.Lock()
+	defer h.mcp.mu.Unlock()
+	h.mcp.sender = sender
+}
+
+// EnableMCPSession starts forwarding records to the session with the given
+// ID. Call it once the client has set a logging level.
+func (h *Handler) EnableMCPSession(sessionID string) {
+	h.mcp.mu.Lock()
+	defer h.mcp.mu.Unlock()
+	h.mcp.sessions[sessionID] = struct{}{}
+}
+
+// DisableMCPSession stops forwarding records to the session with the given
+// ID, typically when the session ends.
+func (h *Handler) DisableMCPSession(sessionID string) {
+	h.mcp.mu.Lock()
+	defer h.mcp.mu.Unlock()
+	delete(h.mcp.sessions, sessionID)
+}
+
+// sendToMCP forwards r to the MCP session in ctx, if it has opted in. The
+// session's own logging level still applies on top of the WARN floor.
+func (h *Handler) sendToMCP(ctx context.Context, r slog.Record) {
+	if r.Level < slog.LevelWarn {
+		return
+	}
+	session := server.ClientSessionFromContext(ctx)
+	if session == nil {
+		return
+	}
+
+	h.mcp.mu.RLock()
+	sender := h.mcp.sender
+	_, enabled := h.mcp.sessions[session.SessionID()]
+	h.mcp.mu.RUnlock()
+	if sender == nil || !enabled {
+		return
+	}
+
+	notification := mcp.NewLoggingMessageNotification(mcpLevel(r.Level), mcpLoggerName, h.buildLogEntry(r))
+	// the client is a complementary destination; errors such as a blocked
+	// notification channel are ignored like WebSocket errors are.
+	_ = sender.SendLogMessageToClient(ctx, notification)
+}
+
+// mcpLevel maps a slog level to the closest MCP logging level.
+func mcpLevel(level slog.Level) mcp.LoggingLevel {
+	switch {
+	case level >= slog.LevelError:
+		return mcp.LoggingLevelError
+	case level >= slog.LevelWarn:
+		return mcp.LoggingLevelWarning
+	case level >= slog.LevelInfo:
+		return mcp.LoggingLevelInfo
+	default:
+		return mcp.LoggingLevelDebug
+	}
+}
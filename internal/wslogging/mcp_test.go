@@ -0,0 +1,100 @@
+package wslogging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSession is a minimal MCP session that collects notifications.
+type fakeSession struct {
+	id            string
+	level         mcp.LoggingLevel
+	notifications chan mcp.JSONRPCNotification
+}
+
+func newFakeSession(id string) *fakeSession {
+	return &fakeSession{id: id, level: mcp.LoggingLevelError, notifications: make(chan mcp.JSONRPCNotification, 10)}
+}
+
+func (s *fakeSession) Initialize()                                         {}
+func (s *fakeSession) Initialized() bool                                   { return true }
+func (s *fakeSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return s.notifications }
+func (s *fakeSession) SessionID() string                                   { return s.id }
+func (s *fakeSession) SetLogLevel(level mcp.LoggingLevel)                  { s.level = level }
+func (s *fakeSession) GetLogLevel() mcp.LoggingLevel                       { return s.level }
+
+// TestHandler_MCP_ForwardsToEnabledSessions tests that WARN+ records reach only opted-in sessions
+func TestHandler_MCP_ForwardsToEnabledSessions(t *testing.T) {
+	var buf bytes.Buffer
+	handler := NewHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+	// derived before ConfigureMCP, like the enabled_services handler in main
+	derived := handler.WithAttrs([]slog.Attr{slog.String("enabled_services", "all")}).(*Handler)
+
+	svr := server.NewMCPServer("test", "1.0.0", server.WithLogging())
+	handler.ConfigureMCP(svr)
+
+	optedIn := newFakeSession("session-1")
+	optedIn.SetLogLevel(mcp.LoggingLevelWarning)
+	handler.EnableMCPSession(optedIn.SessionID())
+	other := newFakeSession("session-2")
+
+	logger := slog.New(derived)
+	optedInCtx := svr.WithContext(context.Background(), optedIn)
+	logger.InfoContext(optedInCtx, "too quiet")
+	logger.WarnContext(optedInCtx, "tool call result", "tool", "droplet-get")
+	logger.ErrorContext(svr.WithContext(context.Background(), other), "not opted in")
+	logger.Error("no session")
+
+	require.Len(t, optedIn.notifications, 1)
+	require.Empty(t, other.notifications)
+
+	notification := <-optedIn.notifications
+	require.Equal(t, "notifications/message", notification.Method)
+	require.Equal(t, mcp.LoggingLevelWarning, notification.Params.AdditionalFields["level"])
+	require.Equal(t, mcpLoggerName, notification.Params.AdditionalFields["logger"])
+	data := notification.Params.AdditionalFields["data"].(map[string]any)
+	require.Equal(t, "tool call result", data["message"])
+	require.Equal(t, "droplet-get", data["tool"])
+	require.Equal(t, "all", data["enabled_services"])
+
+	// stderr still receives every record.
+	require.Equal(t, 4, bytes.Count(buf.Bytes(), []byte("\n")))
+
+	handler.DisableMCPSession(optedIn.SessionID())
+	logger.ErrorContext(optedInCtx, "after disable")
+	require.Empty(t, optedIn.notifications)
+}
+
+// TestHandler_MCP_SessionLevel tests that the session's own logging level still applies
+func TestHandler_MCP_SessionLevel(t *testing.T) {
+	handler := NewHandler(&bytes.Buffer{}, nil)
+	svr := server.NewMCPServer("test", "1.0.0", server.WithLogging())
+	handler.ConfigureMCP(svr)
+
+	session := newFakeSession("session-1")
+	session.SetLogLevel(mcp.LoggingLevelError)
+	handler.EnableMCPSession(session.SessionID())
+
+	logger := slog.New(handler)
+	ctx := svr.WithContext(context.Background(), session)
+	logger.WarnContext(ctx, "below the session level")
+	logger.ErrorContext(ctx, "at the session level")
+
+	require.Len(t, session.notifications, 1)
+	notification := <-session.notifications
+	require.Equal(t, mcp.LoggingLevelError, notification.Params.AdditionalFields["level"])
+}
+
+func TestMCPLevel(t *testing.T) {
+	require.Equal(t, mcp.LoggingLevelDebug, mcpLevel(slog.LevelDebug))
+	require.Equal(t, mcp.LoggingLevelInfo, mcpLevel(slog.LevelInfo))
+	require.Equal(t, mcp.LoggingLevelWarning, mcpLevel(slog.LevelWarn))
+	require.Equal(t, mcp.LoggingLevelError, mcpLevel(slog.LevelError))
+	require.Equal(t, mcp.LoggingLevelError, mcpLevel(slog.LevelError+4))
+}
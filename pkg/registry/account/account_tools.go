@@ -46,7 +46,7 @@ func (a *AccountTools) Tools() []server.ServerTool {
 			Handler: a.getAccountInformation,
 			Tool: mcp.NewTool("account-get-information",
 				common.WithHints(common.HintsRead),
-				mcp.WithDescription("Retrieves account information for the current user"),
+				mcp.WithDescription("Retrieves account information for the current user: email, team, status and droplet limit. Call it to confirm which account a token operates on, e.g. after switching tokens over the HTTP transport"),
 			),
 		},
 	}
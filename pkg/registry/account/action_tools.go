@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"time"
 
 	"mcp-digitalocean/pkg/registry/common"
 
@@ -15,6 +17,15 @@ import (
 const (
 	defaultActionsPageSize = 30
 	defaultActionsPage     = 1
+
+	// maxInProgressPages bounds how much of the account's action history
+	// action-list-in-progress reads. Actions are listed newest first, so
+	// anything still running is near the start.
+	maxInProgressPages = 10
+
+	// maxStreamedActionPages bounds how much of the account's action history
+	// a streamed action-list returns, newest first.
+	maxStreamedActionPages = 50
 )
 
 // ActionTools provides tool-based handlers for DigitalOcean Actions.
@@ -52,13 +63,13 @@ func (a *ActionTools) getAction(ctx context.Context, req mcp.CallToolRequest) (*
 
 // listActions lists actions with pagination support.
 func (a *ActionTools) listActions(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	page, ok := req.GetArguments()["Page"].(float64)
-	if !ok {
-		page = defaultActionsPage
+	if common.IsStream(req.GetArguments()) {
+		return a.streamActions(ctx)
 	}
-	perPage, ok := req.GetArguments()["PerPage"].(float64)
-	if !ok {
-		perPage = defaultActionsPageSize
+
+	opt, pageMeta, err := common.ListOptionsFromCursor(req.GetArguments(), defaultActionsPageSize, "action-list")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	client, err := a.client(ctx)
@@ -66,7 +77,7 @@ func (a *ActionTools) listActions(ctx context.Context, req mcp.CallToolRequest)
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	actions, _, err := client.Actions.List(ctx, &godo.ListOptions{Page: int(page), PerPage: int(perPage)})
+	actions, resp, err := client.Actions.List(ctx, opt)
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("api error", err), nil
 	}
@@ -74,6 +85,116 @@ func (a *ActionTools) listActions(ctx context.Context, req mcp.CallToolRequest)
 	if err != nil {
 		return nil, fmt.Errorf("marshal error: %w", err)
 	}
+	return common.WithPageMeta(mcp.NewToolResultText(string(jsonData)), common.WithNextCursor(pageMeta, resp))
+}
+
+// streamActions returns the account's actions, newest first, as JSON Lines
+// chunks. The history grows without bound, so at most
+// maxStreamedActionPages pages of it are returned.
+func (a *ActionTools) streamActions(ctx context.Context) (*mcp.CallToolResult, error) {
+	client, err := a.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	actions, truncated, err := common.FetchPages(ctx, common.MaxPerPage, maxStreamedActionPages, client.Actions.List)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+	meta := common.StreamMeta{Truncated: truncated}
+	if truncated {
+		meta.Note = fmt.Sprintf("only the newest %d actions are included; without Stream, older ones start at Page %d with PerPage %d", len(actions), maxStreamedActionPages+1, common.MaxPerPage)
+	}
+	return common.NewStreamResult(actions, meta)
+}
+
+// InProgressAction is a running action in the in-progress summary.
+type InProgressAction struct {
+	ID         int        `json:"id"`
+	Type       string     `json:"type"`
+	ResourceID int        `json:"resource_id"`
+	Region     string     `json:"region,omitempty"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+}
+
+// InProgressGroup counts the running actions on one resource type.
+type InProgressGroup struct {
+	ResourceType    string             `json:"resource_type"`
+	Count           int                `json:"count"`
+	OldestStartedAt *time.Time         `json:"oldest_started_at,omitempty"`
+	Actions         []InProgressAction `json:"actions"`
+}
+
+// InProgressSummary is the result of action-list-in-progress.
+type InProgressSummary struct {
+	InProgress     int               `json:"in_progress"`
+	Scanned        int               `json:"scanned"`
+	Truncated      bool              `json:"truncated"`
+	ByResourceType []InProgressGroup `json:"by_resource_type"`
+}
+
+// summarizeInProgress groups the in-progress actions by resource type, oldest
+// group first.
+func summarizeInProgress(actions []godo.Action) InProgressSummary {
+	summary := InProgressSummary{Scanned: len(actions), ByResourceType: []InProgressGroup{}}
+	groups := make(map[string]*InProgressGroup)
+	var order []string
+	for _, action := range actions {
+		if action.Status != godo.ActionInProgress {
+			continue
+		}
+		summary.InProgress++
+		group, ok := groups[action.ResourceType]
+		if !ok {
+			group = &InProgressGroup{ResourceType: action.ResourceType}
+			groups[action.ResourceType] = group
+			order = append(order, action.ResourceType)
+		}
+		running := InProgressAction{
+			ID:         action.ID,
+			Type:       action.Type,
+			ResourceID: action.ResourceID,
+			Region:     action.RegionSlug,
+		}
+		if action.StartedAt != nil {
+			started := action.StartedAt.Time
+			running.StartedAt = &started
+			if group.OldestStartedAt == nil || started.Before(*group.OldestStartedAt) {
+				group.OldestStartedAt = &started
+			}
+		}
+		group.Count++
+		group.Actions = append(group.Actions, running)
+	}
+	for _, resourceType := range order {
+		summary.ByResourceType = append(summary.ByResourceType, *groups[resourceType])
+	}
+	sort.SliceStable(summary.ByResourceType, func(i, j int) bool {
+		a, b := summary.ByResourceType[i].OldestStartedAt, summary.ByResourceType[j].OldestStartedAt
+		return a != nil && (b == nil || a.Before(*b))
+	})
+	return summary
+}
+
+// listInProgressActions summarizes the actions DigitalOcean is running for the
+// account right now.
+func (a *ActionTools) listInProgressActions(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	client, err := a.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	actions, truncated, err := common.FetchPages(ctx, common.MaxPerPage, maxInProgressPages, client.Actions.List)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+	summary := summarizeInProgress(actions)
+	summary.Truncated = truncated
+
+	jsonData, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
 	return mcp.NewToolResultText(string(jsonData)), nil
 }
 
@@ -92,9 +213,18 @@ func (a *ActionTools) Tools() []server.ServerTool {
 			Handler: a.listActions,
 			Tool: mcp.NewTool("action-list",
 				common.WithHints(common.HintsRead),
-				mcp.WithDescription("List actions with pagination"),
+				mcp.WithDescription(fmt.Sprintf("List actions with pagination. When there are more pages, the result's meta has a next_cursor to pass back as Cursor. Set Stream to get the newest %d actions at once as JSON Lines.", maxStreamedActionPages*common.MaxPerPage)),
 				mcp.WithNumber("Page", mcp.DefaultNumber(defaultActionsPage), mcp.Description("Page number")),
 				mcp.WithNumber("PerPage", mcp.DefaultNumber(defaultActionsPageSize), mcp.Description("Items per page")),
+				mcp.WithString(common.CursorArg, mcp.Description("next_cursor from a previous result, to fetch the following page. Overrides Page and PerPage")),
+				common.WithStreamArg(),
+			),
+		},
+		{
+			Handler: a.listInProgressActions,
+			Tool: mcp.NewTool("action-list-in-progress",
+				common.WithHints(common.HintsRead),
+				mcp.WithDescription(fmt.Sprintf("List the actions DigitalOcean is running for the account right now, grouped by resource type with counts and the oldest start time. Use it to explain why other calls are slow or a resource is locked. Reads the newest %d actions; truncated is true if older ones were not checked.", maxInProgressPages*common.MaxPerPage)),
 			),
 		},
 	}
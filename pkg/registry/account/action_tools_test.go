@@ -2,13 +2,17 @@ package account
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"mcp-digitalocean/pkg/registry/common"
 )
 
 func setupActionToolsWithMock(mockActions *MockActionsService) *ActionTools {
@@ -174,3 +178,166 @@ func TestActionTools_listActions(t *testing.T) {
 		})
 	}
 }
+
+func TestActionTools_listInProgressActions(t *testing.T) {
+	at := func(minute int) *godo.Timestamp {
+		return &godo.Timestamp{Time: time.Date(2026, 10, 16, 9, minute, 0, 0, time.UTC)}
+	}
+	more := &godo.Response{Links: &godo.Links{Pages: &godo.Pages{Next: "https://api/v2/actions?page=2", Last: "https://api/v2/actions?page=2"}}}
+	last := &godo.Response{Links: &godo.Links{}}
+
+	t.Run("groups in-progress actions across pages", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockActions := NewMockActionsService(ctrl)
+		mockActions.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: 200}).Return([]godo.Action{
+			{ID: 1, Status: "in-progress", Type: "snapshot", ResourceType: "droplet", ResourceID: 10, RegionSlug: "nyc3", StartedAt: at(20)},
+			{ID: 2, Status: "completed", Type: "reboot", ResourceType: "droplet", ResourceID: 11, StartedAt: at(5)},
+			{ID: 3, Status: "in-progress", Type: "attach", ResourceType: "volume", ResourceID: 12, StartedAt: at(15)},
+		}, more, nil)
+		mockActions.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 2, PerPage: 200}).Return([]godo.Action{
+			{ID: 4, Status: "in-progress", Type: "resize", ResourceType: "droplet", ResourceID: 13, StartedAt: at(10)},
+			{ID: 5, Status: "errored", Type: "create", ResourceType: "volume", ResourceID: 14, StartedAt: at(1)},
+		}, last, nil)
+		tool := setupActionToolsWithMock(mockActions)
+
+		resp, err := tool.listInProgressActions(context.Background(), mcp.CallToolRequest{})
+		require.NoError(t, err)
+		require.False(t, resp.IsError)
+
+		var out InProgressSummary
+		require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+		require.Equal(t, 3, out.InProgress)
+		require.Equal(t, 5, out.Scanned)
+		require.False(t, out.Truncated)
+		require.Len(t, out.ByResourceType, 2)
+
+		droplets := out.ByResourceType[0]
+		require.Equal(t, "droplet", droplets.ResourceType)
+		require.Equal(t, 2, droplets.Count)
+		require.Equal(t, at(10).Time, *droplets.OldestStartedAt)
+		require.Equal(t, []int{1, 4}, []int{droplets.Actions[0].ID, droplets.Actions[1].ID})
+		require.Equal(t, "nyc3", droplets.Actions[0].Region)
+
+		volumes := out.ByResourceType[1]
+		require.Equal(t, "volume", volumes.ResourceType)
+		require.Equal(t, 1, volumes.Count)
+		require.Equal(t, at(15).Time, *volumes.OldestStartedAt)
+	})
+
+	t.Run("stops after the page limit", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockActions := NewMockActionsService(ctrl)
+		mockActions.EXPECT().List(gomock.Any(), gomock.Any()).Return([]godo.Action{{ID: 1, Status: "completed"}}, more, nil).Times(maxInProgressPages)
+		tool := setupActionToolsWithMock(mockActions)
+
+		resp, err := tool.listInProgressActions(context.Background(), mcp.CallToolRequest{})
+		require.NoError(t, err)
+		var out InProgressSummary
+		require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+		require.True(t, out.Truncated)
+		require.Zero(t, out.InProgress)
+		require.Empty(t, out.ByResourceType)
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockActions := NewMockActionsService(ctrl)
+		mockActions.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil, errors.New("api down"))
+		tool := setupActionToolsWithMock(mockActions)
+
+		resp, err := tool.listInProgressActions(context.Background(), mcp.CallToolRequest{})
+		require.NoError(t, err)
+		require.True(t, resp.IsError)
+	})
+}
+
+func TestActionTools_listActionsCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockActions := NewMockActionsService(ctrl)
+	more := &godo.Response{Links: &godo.Links{Pages: &godo.Pages{Next: "https://api/v2/actions?page=2", Last: "https://api/v2/actions?page=3"}}}
+	gomock.InOrder(
+		mockActions.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: 2}).Return([]godo.Action{{ID: 1}, {ID: 2}}, more, nil),
+		mockActions.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 2, PerPage: 2}).Return([]godo.Action{{ID: 3}}, &godo.Response{Links: &godo.Links{}}, nil),
+	)
+	tool := setupActionToolsWithMock(mockActions)
+
+	resp, err := tool.listActions(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"PerPage": float64(2)}}})
+	require.NoError(t, err)
+	require.False(t, resp.IsError)
+	require.Len(t, resp.Content, 2)
+	var meta struct {
+		Meta common.PageMeta `json:"meta"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[1].(mcp.TextContent).Text), &meta))
+	require.NotEmpty(t, meta.Meta.NextCursor)
+
+	resp, err = tool.listActions(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"Cursor": meta.Meta.NextCursor}}})
+	require.NoError(t, err)
+	require.False(t, resp.IsError)
+	// the last page has no next cursor.
+	require.Len(t, resp.Content, 1)
+
+	resp, err = tool.listActions(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"Cursor": "not-a-cursor"}}})
+	require.NoError(t, err)
+	require.True(t, resp.IsError)
+}
+
+func TestActionTools_listActionsStream(t *testing.T) {
+	streamMeta := func(t *testing.T, resp *mcp.CallToolResult) common.StreamMeta {
+		t.Helper()
+		var meta struct {
+			Meta common.StreamMeta `json:"meta"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(resp.Content[len(resp.Content)-1].(mcp.TextContent).Text), &meta))
+		return meta.Meta
+	}
+	more := &godo.Response{Links: &godo.Links{Pages: &godo.Pages{Next: "https://api/v2/actions?page=2", Last: "https://api/v2/actions?page=9999"}}}
+
+	t.Run("all pages", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockActions := NewMockActionsService(ctrl)
+		gomock.InOrder(
+			mockActions.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: common.MaxPerPage}).Return([]godo.Action{{ID: 1}, {ID: 2}}, more, nil),
+			mockActions.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 2, PerPage: common.MaxPerPage}).Return([]godo.Action{{ID: 3}}, &godo.Response{Links: &godo.Links{}}, nil),
+		)
+		tool := setupActionToolsWithMock(mockActions)
+
+		resp, err := tool.listActions(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"Stream": true}}})
+		require.NoError(t, err)
+		require.False(t, resp.IsError)
+		require.Len(t, resp.Content, 2)
+		lines := strings.Split(strings.TrimSuffix(resp.Content[0].(mcp.TextContent).Text, "\n"), "\n")
+		require.Len(t, lines, 3)
+		var action godo.Action
+		require.NoError(t, json.Unmarshal([]byte(lines[2]), &action))
+		require.Equal(t, 3, action.ID)
+		require.Equal(t, common.StreamMeta{Records: 3, Chunks: 1, ChunkSize: common.StreamChunkSize}, streamMeta(t, resp))
+	})
+
+	t.Run("stops after the page limit", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockActions := NewMockActionsService(ctrl)
+		mockActions.EXPECT().List(gomock.Any(), gomock.Any()).Return([]godo.Action{{ID: 1}, {ID: 2}}, more, nil).Times(maxStreamedActionPages)
+		tool := setupActionToolsWithMock(mockActions)
+
+		resp, err := tool.listActions(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"Stream": true}}})
+		require.NoError(t, err)
+		require.False(t, resp.IsError)
+		meta := streamMeta(t, resp)
+		require.Equal(t, 2*maxStreamedActionPages, meta.Records)
+		require.Equal(t, 1, meta.Chunks)
+		require.True(t, meta.Truncated)
+		require.Contains(t, meta.Note, "older ones start at Page 51")
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockActions := NewMockActionsService(ctrl)
+		mockActions.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil, errors.New("api down"))
+		tool := setupActionToolsWithMock(mockActions)
+
+		resp, err := tool.listActions(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"Stream": true}}})
+		require.NoError(t, err)
+		require.True(t, resp.IsError)
+	})
+}
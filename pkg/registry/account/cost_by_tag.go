@@ -0,0 +1,195 @@
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"mcp-digitalocean/pkg/registry/common"
+)
+
+// costTypeDatabase is the type of database clusters in a cost-by-tag report;
+// the other resources use the sandbox cleanup plan's types.
+const costTypeDatabase = "database_cluster"
+
+// Cost values of a TaggedResourceCost.
+const (
+	costEstimated = "estimated"
+	costUnknown   = "unknown"
+)
+
+// TaggedResourceCost is one resource in a cost-by-tag report.
+type TaggedResourceCost struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Cost is "estimated" when EstimatedMonthlyCostUSD is set and "unknown"
+	// when no price is available for the resource.
+	Cost                    string   `json:"cost"`
+	EstimatedMonthlyCostUSD *float64 `json:"estimated_monthly_cost_usd"`
+	Note                    string   `json:"note,omitempty"`
+}
+
+// TagCostReport is returned by cost-by-tag.
+type TagCostReport struct {
+	Tag       string               `json:"tag"`
+	Resources []TaggedResourceCost `json:"resources"`
+	// TotalEstimatedMonthlyCostUSD sums the resources with a known cost;
+	// UnknownCost counts those left out.
+	TotalEstimatedMonthlyCostUSD float64  `json:"total_estimated_monthly_cost_usd"`
+	UnknownCost                  int      `json:"unknown_cost"`
+	Notes                        []string `json:"notes"`
+}
+
+// CostTool attributes estimated monthly costs to tags.
+type CostTool struct {
+	client func(ctx context.Context) (*godo.Client, error)
+}
+
+// NewCostTool creates a new CostTool
+func NewCostTool(client func(ctx context.Context) (*godo.Client, error)) *CostTool {
+	return &CostTool{client: client}
+}
+
+// estimated returns a resource line with a known monthly cost.
+func estimated(resourceType, id, name string, usd float64) TaggedResourceCost {
+	return TaggedResourceCost{Type: resourceType, ID: id, Name: name, Cost: costEstimated, EstimatedMonthlyCostUSD: &usd}
+}
+
+// unknownCost returns a resource line whose monthly cost is not known.
+func unknownCost(resourceType, id, name, note string) TaggedResourceCost {
+	return TaggedResourceCost{Type: resourceType, ID: id, Name: name, Cost: costUnknown, Note: note}
+}
+
+func (c *CostTool) costByTag(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	tag, _ := req.GetArguments()["Tag"].(string)
+	if tag == "" {
+		return mcp.NewToolResultError("Tag is required"), nil
+	}
+
+	client, err := c.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	report := TagCostReport{
+		Tag:       tag,
+		Resources: []TaggedResourceCost{},
+		Notes: []string{
+			"Costs are estimates from list prices for a full month. Bandwidth overage, backups, snapshots and taxes are not included.",
+		},
+	}
+	for _, list := range []func(context.Context, *godo.Client, string) ([]TaggedResourceCost, error){
+		taggedDropletCosts,
+		taggedVolumeCosts,
+		taggedLoadBalancerCosts,
+		taggedDatabaseCosts,
+	} {
+		lines, err := list(ctx, client, tag)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("api error", err), nil
+		}
+		report.Resources = append(report.Resources, lines...)
+	}
+	for _, line := range report.Resources {
+		if line.EstimatedMonthlyCostUSD == nil {
+			report.UnknownCost++
+			continue
+		}
+		report.TotalEstimatedMonthlyCostUSD += *line.EstimatedMonthlyCostUSD
+	}
+	if report.UnknownCost > 0 {
+		report.Notes = append(report.Notes, fmt.Sprintf("%d resources have no pricing data and are not in the total.", report.UnknownCost))
+	}
+
+	jsonData, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+func taggedDropletCosts(ctx context.Context, client *godo.Client, tag string) ([]TaggedResourceCost, error) {
+	droplets, err := common.FetchAll(ctx, sandboxListPageSize, func(ctx context.Context, opt *godo.ListOptions) ([]godo.Droplet, *godo.Response, error) {
+		return client.Droplets.ListByTag(ctx, tag, opt)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list droplets: %w", err)
+	}
+	var lines []TaggedResourceCost
+	for _, d := range droplets {
+		if d.Size == nil {
+			lines = append(lines, unknownCost(sandboxTypeDroplet, strconv.Itoa(d.ID), d.Name, "the droplet's size was not returned"))
+			continue
+		}
+		lines = append(lines, estimated(sandboxTypeDroplet, strconv.Itoa(d.ID), d.Name, d.Size.PriceMonthly))
+	}
+	return lines, nil
+}
+
+func taggedVolumeCosts(ctx context.Context, client *godo.Client, tag string) ([]TaggedResourceCost, error) {
+	volumes, err := common.FetchAll(ctx, sandboxListPageSize, func(ctx context.Context, opt *godo.ListOptions) ([]godo.Volume, *godo.Response, error) {
+		return client.Storage.ListVolumes(ctx, &godo.ListVolumeParams{ListOptions: opt})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list volumes: %w", err)
+	}
+	var lines []TaggedResourceCost
+	for _, v := range volumes {
+		if slices.Contains(v.Tags, tag) {
+			lines = append(lines, estimated(sandboxTypeVolume, v.ID, v.Name, float64(v.SizeGigaBytes)*volumePricePerGiBMonth))
+		}
+	}
+	return lines, nil
+}
+
+func taggedLoadBalancerCosts(ctx context.Context, client *godo.Client, tag string) ([]TaggedResourceCost, error) {
+	lbs, err := common.FetchAll(ctx, sandboxListPageSize, client.LoadBalancers.List)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list load balancers: %w", err)
+	}
+	var lines []TaggedResourceCost
+	for _, lb := range lbs {
+		if slices.Contains(lb.Tags, tag) {
+			nodes := max(lb.SizeUnit, 1)
+			lines = append(lines, estimated(sandboxTypeLoadBalancer, lb.ID, lb.Name, float64(nodes)*loadBalancerPricePerNode))
+		}
+	}
+	return lines, nil
+}
+
+// taggedDatabaseCosts lists tagged database clusters. The API returns no
+// price for them, so their cost is always unknown.
+func taggedDatabaseCosts(ctx context.Context, client *godo.Client, tag string) ([]TaggedResourceCost, error) {
+	databases, err := common.FetchAll(ctx, sandboxListPageSize, client.Databases.List)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list database clusters: %w", err)
+	}
+	var lines []TaggedResourceCost
+	for _, db := range databases {
+		if slices.Contains(db.Tags, tag) {
+			note := fmt.Sprintf("no pricing data for database clusters (%s, %d x %s)", db.EngineSlug, db.NumNodes, db.SizeSlug)
+			lines = append(lines, unknownCost(costTypeDatabase, db.ID, db.Name, note))
+		}
+	}
+	return lines, nil
+}
+
+// Tools returns the cost attribution tools
+func (c *CostTool) Tools() []server.ServerTool {
+	return []server.ServerTool{
+		{
+			Handler: c.costByTag,
+			Tool: mcp.NewTool("cost-by-tag",
+				common.WithHints(common.HintsRead),
+				mcp.WithDescription("List the droplets, volumes, load balancers and database clusters carrying a tag with the estimated monthly cost of each and their total. Resources without pricing data, such as database clusters, are listed with cost \"unknown\" and left out of the total."),
+				mcp.WithString("Tag", mcp.Required(), mcp.Description("Tag to attribute costs to")),
+			),
+		},
+	}
+}
@@ -0,0 +1,159 @@
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type costMocks struct {
+	droplets      *MockDropletsService
+	storage       *MockStorageService
+	loadBalancers *MockLoadBalancersService
+	databases     *MockDatabasesService
+}
+
+func setupCostToolWithMocks(ctrl *gomock.Controller) (*CostTool, costMocks) {
+	m := costMocks{
+		droplets:      NewMockDropletsService(ctrl),
+		storage:       NewMockStorageService(ctrl),
+		loadBalancers: NewMockLoadBalancersService(ctrl),
+		databases:     NewMockDatabasesService(ctrl),
+	}
+	return NewCostTool(func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{
+			Droplets:      m.droplets,
+			Storage:       m.storage,
+			LoadBalancers: m.loadBalancers,
+			Databases:     m.databases,
+		}, nil
+	}), m
+}
+
+// expectCostAccount sets up an account with resources tagged team:web and
+// team:data, some untagged, and one droplet whose size is missing.
+func expectCostAccount(m costMocks) {
+	m.droplets.EXPECT().ListByTag(gomock.Any(), "team:web", gomock.Any()).Return([]godo.Droplet{
+		{ID: 1, Name: "web-1", Tags: []string{"team:web"}, Size: &godo.Size{Slug: "s-1vcpu-1gb", PriceMonthly: 6}},
+		{ID: 2, Name: "web-2", Tags: []string{"team:web"}, Size: &godo.Size{Slug: "s-2vcpu-4gb", PriceMonthly: 24}},
+		{ID: 3, Name: "web-odd", Tags: []string{"team:web"}},
+	}, nil, nil)
+	m.storage.EXPECT().ListVolumes(gomock.Any(), gomock.Any()).Return([]godo.Volume{
+		{ID: "vol-1", Name: "web-assets", Tags: []string{"team:web"}, SizeGigaBytes: 50},
+		{ID: "vol-2", Name: "warehouse", Tags: []string{"team:data"}, SizeGigaBytes: 500},
+		{ID: "vol-3", Name: "scratch", SizeGigaBytes: 10},
+	}, nil, nil)
+	m.loadBalancers.EXPECT().List(gomock.Any(), gomock.Any()).Return([]godo.LoadBalancer{
+		{ID: "lb-1", Name: "web-lb", Tags: []string{"team:web", "prod"}, SizeUnit: 2},
+		{ID: "lb-2", Name: "data-lb", Tags: []string{"team:data"}},
+	}, nil, nil)
+	m.databases.EXPECT().List(gomock.Any(), gomock.Any()).Return([]godo.Database{
+		{ID: "db-1", Name: "web-pg", Tags: []string{"team:web"}, EngineSlug: "pg", NumNodes: 2, SizeSlug: "db-s-1vcpu-2gb"},
+		{ID: "db-2", Name: "data-pg", Tags: []string{"team:data"}},
+	}, nil, nil)
+}
+
+func TestCostTool_costByTag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tool, m := setupCostToolWithMocks(ctrl)
+	expectCostAccount(m)
+
+	resp, err := tool.costByTag(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"Tag": "team:web"}}})
+	require.NoError(t, err)
+	require.False(t, resp.IsError)
+
+	var report TagCostReport
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &report))
+	require.Equal(t, "team:web", report.Tag)
+
+	costs := map[string]TaggedResourceCost{}
+	for _, line := range report.Resources {
+		costs[line.Type+"/"+line.ID] = line
+	}
+	require.Len(t, costs, 6)
+	for key, usd := range map[string]float64{
+		"droplet/1":          6,
+		"droplet/2":          24,
+		"volume/vol-1":       5,
+		"load_balancer/lb-1": 24,
+	} {
+		require.Equal(t, costEstimated, costs[key].Cost, key)
+		require.NotNil(t, costs[key].EstimatedMonthlyCostUSD, key)
+		require.InDelta(t, usd, *costs[key].EstimatedMonthlyCostUSD, 0.001, key)
+	}
+	for _, key := range []string{"droplet/3", "database_cluster/db-1"} {
+		require.Equal(t, costUnknown, costs[key].Cost, key)
+		require.Nil(t, costs[key].EstimatedMonthlyCostUSD, key)
+		require.NotEmpty(t, costs[key].Note, key)
+	}
+	require.Contains(t, costs["database_cluster/db-1"].Note, "pg, 2 x db-s-1vcpu-2gb")
+
+	require.InDelta(t, 59, report.TotalEstimatedMonthlyCostUSD, 0.001)
+	require.Equal(t, 2, report.UnknownCost)
+	require.Contains(t, report.Notes[len(report.Notes)-1], "2 resources have no pricing data")
+}
+
+func TestCostTool_costByTagNoResources(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tool, m := setupCostToolWithMocks(ctrl)
+	m.droplets.EXPECT().ListByTag(gomock.Any(), "unused", gomock.Any()).Return(nil, nil, nil)
+	m.storage.EXPECT().ListVolumes(gomock.Any(), gomock.Any()).Return(nil, nil, nil)
+	m.loadBalancers.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil, nil)
+	m.databases.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil, nil)
+
+	resp, err := tool.costByTag(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"Tag": "unused"}}})
+	require.NoError(t, err)
+	require.False(t, resp.IsError)
+
+	var report TagCostReport
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &report))
+	require.Empty(t, report.Resources)
+	require.Zero(t, report.TotalEstimatedMonthlyCostUSD)
+	require.Zero(t, report.UnknownCost)
+}
+
+func TestCostTool_costByTagErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      map[string]any
+		mockSetup func(m costMocks)
+		wantText  string
+	}{
+		{
+			name:     "missing tag",
+			args:     map[string]any{},
+			wantText: "Tag is required",
+		},
+		{
+			name: "database list error",
+			args: map[string]any{"Tag": "team:web"},
+			mockSetup: func(m costMocks) {
+				m.droplets.EXPECT().ListByTag(gomock.Any(), "team:web", gomock.Any()).Return(nil, nil, nil)
+				m.storage.EXPECT().ListVolumes(gomock.Any(), gomock.Any()).Return(nil, nil, nil)
+				m.loadBalancers.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil, nil)
+				m.databases.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil, errors.New("forbidden"))
+			},
+			wantText: "failed to list database clusters: forbidden",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			tool, m := setupCostToolWithMocks(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(m)
+			}
+			resp, err := tool.costByTag(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			require.True(t, resp.IsError)
+			require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.wantText)
+		})
+	}
+}
@@ -1,3 +1,3 @@
 package account
 
-//go:generate mockgen -destination=./mocks.go -package account github.com/digitalocean/godo  AccountService,ActionsService,BalanceService,BillingHistoryService,InvoicesService,KeysService
+//go:generate mockgen -destination=./mocks.go -package account github.com/digitalocean/godo  AccountService,ActionsService,BalanceService,BillingHistoryService,DatabasesService,DropletsService,InvoicesService,KeysService,KubernetesService,LoadBalancersService,SizesService,SnapshotsService,StorageService
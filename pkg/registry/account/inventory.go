@@ -0,0 +1,299 @@
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"mcp-digitalocean/pkg/registry/common"
+)
+
+const (
+	// inventoryTimeout is the budget for a whole account-inventory call.
+	inventoryTimeout = 10 * time.Second
+	// inventoryConcurrency bounds how many resource types are listed at once.
+	inventoryConcurrency = 4
+	// inventoryRecentItems is how many of the newest items each type shows.
+	inventoryRecentItems = 3
+)
+
+// Statuses of a resource type in an account inventory.
+const (
+	inventoryStatusOK      = "ok"
+	inventoryStatusUnknown = "unknown"
+)
+
+// InventoryItem is one of the newest resources of a type.
+type InventoryItem struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+// InventoryEntry summarises one resource type. Count is nil, and Error says
+// why, when the type could not be listed within the call's budget.
+type InventoryEntry struct {
+	Type   string          `json:"type"`
+	Status string          `json:"status"`
+	Count  *int            `json:"count"`
+	Recent []InventoryItem `json:"recent"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// Inventory is the result of account-inventory.
+type Inventory struct {
+	Resources []InventoryEntry `json:"resources"`
+	Unknown   []string         `json:"unknown"`
+}
+
+// inventorySource lists every resource of one type.
+type inventorySource struct {
+	Type string
+	List func(ctx context.Context, client *godo.Client) ([]InventoryItem, error)
+}
+
+// InventoryTool gives a one-shot overview of what an account holds.
+type InventoryTool struct {
+	client      func(ctx context.Context) (*godo.Client, error)
+	sources     []inventorySource
+	timeout     time.Duration
+	concurrency int
+}
+
+// NewInventoryTool creates a new InventoryTool
+func NewInventoryTool(client func(ctx context.Context) (*godo.Client, error)) *InventoryTool {
+	return &InventoryTool{
+		client: client,
+		sources: []inventorySource{
+			{Type: "droplets", List: inventoryDroplets},
+			{Type: "volumes", List: inventoryVolumes},
+			{Type: "load_balancers", List: inventoryLoadBalancers},
+			{Type: "kubernetes_clusters", List: inventoryKubernetesClusters},
+			{Type: "databases", List: inventoryDatabases},
+			{Type: "apps", List: inventoryApps},
+			{Type: "domains", List: inventoryDomains},
+		},
+		timeout:     inventoryTimeout,
+		concurrency: inventoryConcurrency,
+	}
+}
+
+func (i *InventoryTool) inventory(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	client, err := i.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	inventory := Inventory{Resources: i.collect(ctx, client), Unknown: []string{}}
+	for _, entry := range inventory.Resources {
+		if entry.Status == inventoryStatusUnknown {
+			inventory.Unknown = append(inventory.Unknown, entry.Type)
+		}
+	}
+
+	jsonData, err := json.MarshalIndent(inventory, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+// collect lists every source, at most i.concurrency at a time, and returns an
+// entry per source in the order of i.sources. A source that fails or has not
+// finished when the budget runs out is reported as unknown rather than
+// failing the whole call; collect itself never waits past the budget.
+func (i *InventoryTool) collect(ctx context.Context, client *godo.Client) []InventoryEntry {
+	ctx, cancel := context.WithTimeout(ctx, i.timeout)
+	defer cancel()
+
+	type result struct {
+		index int
+		entry InventoryEntry
+	}
+	// buffered so that sources finishing after the budget do not block.
+	results := make(chan result, len(i.sources))
+	sem := make(chan struct{}, max(i.concurrency, 1))
+	for index, source := range i.sources {
+		go func() {
+			select {
+			case <-ctx.Done():
+				return
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			}
+			results <- result{index: index, entry: inventoryEntry(ctx, client, source)}
+		}()
+	}
+
+	entries := make([]InventoryEntry, len(i.sources))
+	done := make([]bool, len(i.sources))
+wait:
+	for range i.sources {
+		select {
+		case r := <-results:
+			entries[r.index], done[r.index] = r.entry, true
+		case <-ctx.Done():
+			break wait
+		}
+	}
+	// keep whatever finished while the budget ran out.
+	for len(results) > 0 {
+		r := <-results
+		entries[r.index], done[r.index] = r.entry, true
+	}
+	for index, source := range i.sources {
+		if !done[index] {
+			entries[index] = unknownEntry(source.Type, fmt.Sprintf("timed out after %s", i.timeout))
+		}
+	}
+	return entries
+}
+
+func inventoryEntry(ctx context.Context, client *godo.Client, source inventorySource) InventoryEntry {
+	items, err := source.List(ctx, client)
+	if err != nil {
+		if ctx.Err() != nil {
+			return unknownEntry(source.Type, "timed out")
+		}
+		return unknownEntry(source.Type, err.Error())
+	}
+
+	// newest first; items without a creation time keep their API order last.
+	slices.SortStableFunc(items, func(a, b InventoryItem) int {
+		return strings.Compare(b.CreatedAt, a.CreatedAt)
+	})
+	count := len(items)
+	return InventoryEntry{
+		Type:   source.Type,
+		Status: inventoryStatusOK,
+		Count:  &count,
+		Recent: items[:min(count, inventoryRecentItems)],
+	}
+}
+
+func unknownEntry(resourceType, reason string) InventoryEntry {
+	return InventoryEntry{Type: resourceType, Status: inventoryStatusUnknown, Recent: []InventoryItem{}, Error: reason}
+}
+
+// formatCreated formats an API creation time, returning "" when it is unset.
+func formatCreated(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func inventoryDroplets(ctx context.Context, client *godo.Client) ([]InventoryItem, error) {
+	droplets, err := common.FetchAll(ctx, common.MaxPerPage, client.Droplets.List)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list droplets: %w", err)
+	}
+	items := make([]InventoryItem, 0, len(droplets))
+	for _, d := range droplets {
+		items = append(items, InventoryItem{ID: strconv.Itoa(d.ID), Name: d.Name, CreatedAt: formatCreated(parseCreated(d.Created))})
+	}
+	return items, nil
+}
+
+func inventoryVolumes(ctx context.Context, client *godo.Client) ([]InventoryItem, error) {
+	volumes, err := common.FetchAll(ctx, common.MaxPerPage, func(ctx context.Context, opt *godo.ListOptions) ([]godo.Volume, *godo.Response, error) {
+		return client.Storage.ListVolumes(ctx, &godo.ListVolumeParams{ListOptions: opt})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list volumes: %w", err)
+	}
+	items := make([]InventoryItem, 0, len(volumes))
+	for _, v := range volumes {
+		items = append(items, InventoryItem{ID: v.ID, Name: v.Name, CreatedAt: formatCreated(v.CreatedAt)})
+	}
+	return items, nil
+}
+
+func inventoryLoadBalancers(ctx context.Context, client *godo.Client) ([]InventoryItem, error) {
+	lbs, err := common.FetchAll(ctx, common.MaxPerPage, client.LoadBalancers.List)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list load balancers: %w", err)
+	}
+	items := make([]InventoryItem, 0, len(lbs))
+	for _, lb := range lbs {
+		items = append(items, InventoryItem{ID: lb.ID, Name: lb.Name, CreatedAt: formatCreated(parseCreated(lb.Created))})
+	}
+	return items, nil
+}
+
+func inventoryKubernetesClusters(ctx context.Context, client *godo.Client) ([]InventoryItem, error) {
+	clusters, err := common.FetchAll(ctx, common.MaxPerPage, client.Kubernetes.List)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list kubernetes clusters: %w", err)
+	}
+	items := make([]InventoryItem, 0, len(clusters))
+	for _, c := range clusters {
+		items = append(items, InventoryItem{ID: c.ID, Name: c.Name, CreatedAt: formatCreated(c.CreatedAt)})
+	}
+	return items, nil
+}
+
+func inventoryDatabases(ctx context.Context, client *godo.Client) ([]InventoryItem, error) {
+	databases, err := common.FetchAll(ctx, common.MaxPerPage, client.Databases.List)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list databases: %w", err)
+	}
+	items := make([]InventoryItem, 0, len(databases))
+	for _, db := range databases {
+		items = append(items, InventoryItem{ID: db.ID, Name: db.Name, CreatedAt: formatCreated(db.CreatedAt)})
+	}
+	return items, nil
+}
+
+func inventoryApps(ctx context.Context, client *godo.Client) ([]InventoryItem, error) {
+	apps, err := common.FetchAll(ctx, common.MaxPerPage, client.Apps.List)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list apps: %w", err)
+	}
+	items := make([]InventoryItem, 0, len(apps))
+	for _, app := range apps {
+		item := InventoryItem{ID: app.ID, CreatedAt: formatCreated(app.CreatedAt)}
+		if app.Spec != nil {
+			item.Name = app.Spec.Name
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func inventoryDomains(ctx context.Context, client *godo.Client) ([]InventoryItem, error) {
+	domains, err := common.FetchAll(ctx, common.MaxPerPage, client.Domains.List)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list domains: %w", err)
+	}
+	// domains carry no creation time, so their recent items are the first
+	// ones the API returns.
+	items := make([]InventoryItem, 0, len(domains))
+	for _, d := range domains {
+		items = append(items, InventoryItem{ID: d.Name, Name: d.Name})
+	}
+	return items, nil
+}
+
+// Tools returns the account inventory tools
+func (i *InventoryTool) Tools() []server.ServerTool {
+	return []server.ServerTool{
+		{
+			Handler: i.inventory,
+			Tool: mcp.NewTool("account-inventory",
+				common.WithHints(common.HintsRead),
+				mcp.WithDescription("Overview of the account for the start of a session: for droplets, volumes, load balancers, "+
+					"DOKS clusters, databases, apps and domains, the number of resources and the 3 newest. Types are listed "+
+					"concurrently within a 10 second budget; a type that fails or does not finish in time has status \"unknown\", "+
+					"a null count and an error, and is named in the top-level unknown list, while the other types are still returned."),
+			),
+		},
+	}
+}
@@ -0,0 +1,171 @@
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func staticSource(resourceType string, items ...InventoryItem) inventorySource {
+	return inventorySource{Type: resourceType, List: func(context.Context, *godo.Client) ([]InventoryItem, error) {
+		return items, nil
+	}}
+}
+
+func newTestInventoryTool(sources ...inventorySource) *InventoryTool {
+	tool := NewInventoryTool(func(ctx context.Context) (*godo.Client, error) { return &godo.Client{}, nil })
+	tool.sources = sources
+	return tool
+}
+
+func callInventory(t *testing.T, tool *InventoryTool) Inventory {
+	t.Helper()
+	resp, err := tool.inventory(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	require.False(t, resp.IsError)
+	var inventory Inventory
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &inventory))
+	return inventory
+}
+
+func TestInventoryTool_outputShape(t *testing.T) {
+	tool := newTestInventoryTool(
+		staticSource("droplets",
+			InventoryItem{ID: "1", Name: "old", CreatedAt: "2026-01-01T00:00:00Z"},
+			InventoryItem{ID: "2", Name: "newest", CreatedAt: "2026-04-01T00:00:00Z"},
+			InventoryItem{ID: "3", Name: "middle", CreatedAt: "2026-02-01T00:00:00Z"},
+			InventoryItem{ID: "4", Name: "newer", CreatedAt: "2026-03-01T00:00:00Z"},
+		),
+		staticSource("domains"),
+		inventorySource{Type: "apps", List: func(context.Context, *godo.Client) ([]InventoryItem, error) {
+			return nil, errors.New("failed to list apps: boom")
+		}},
+	)
+
+	inventory := callInventory(t, tool)
+	require.Len(t, inventory.Resources, 3)
+
+	droplets := inventory.Resources[0]
+	require.Equal(t, "droplets", droplets.Type)
+	require.Equal(t, inventoryStatusOK, droplets.Status)
+	require.Equal(t, 4, *droplets.Count)
+	require.Len(t, droplets.Recent, inventoryRecentItems)
+	require.Equal(t, []string{"newest", "newer", "middle"}, []string{droplets.Recent[0].Name, droplets.Recent[1].Name, droplets.Recent[2].Name})
+
+	domains := inventory.Resources[1]
+	require.Equal(t, inventoryStatusOK, domains.Status)
+	require.Equal(t, 0, *domains.Count)
+	require.Empty(t, domains.Recent)
+
+	apps := inventory.Resources[2]
+	require.Equal(t, inventoryStatusUnknown, apps.Status)
+	require.Nil(t, apps.Count)
+	require.Contains(t, apps.Error, "boom")
+	require.Equal(t, []string{"apps"}, inventory.Unknown)
+}
+
+func TestInventoryTool_boundedFanOut(t *testing.T) {
+	var running, peak atomic.Int32
+	var sources []inventorySource
+	for _, resourceType := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		sources = append(sources, inventorySource{Type: resourceType, List: func(context.Context, *godo.Client) ([]InventoryItem, error) {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			return []InventoryItem{{ID: resourceType, Name: resourceType}}, nil
+		}})
+	}
+	tool := newTestInventoryTool(sources...)
+	tool.concurrency = 2
+
+	inventory := callInventory(t, tool)
+	require.Len(t, inventory.Resources, len(sources))
+	for i, entry := range inventory.Resources {
+		// entries keep the order of the sources whatever order they finish in.
+		require.Equal(t, sources[i].Type, entry.Type)
+		require.Equal(t, inventoryStatusOK, entry.Status)
+	}
+	require.Empty(t, inventory.Unknown)
+	require.Equal(t, int32(2), peak.Load())
+}
+
+func TestInventoryTool_partialTimeout(t *testing.T) {
+	stuck := make(chan struct{})
+	defer close(stuck)
+	tool := newTestInventoryTool(
+		staticSource("droplets", InventoryItem{ID: "1", Name: "web"}),
+		// honours the context, as godo does.
+		inventorySource{Type: "databases", List: func(ctx context.Context, _ *godo.Client) ([]InventoryItem, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}},
+		// ignores the context entirely; the call must not wait for it.
+		inventorySource{Type: "apps", List: func(context.Context, *godo.Client) ([]InventoryItem, error) {
+			<-stuck
+			return nil, nil
+		}},
+	)
+	tool.timeout = 50 * time.Millisecond
+
+	start := time.Now()
+	inventory := callInventory(t, tool)
+	require.Less(t, time.Since(start), 2*time.Second)
+
+	require.Equal(t, inventoryStatusOK, inventory.Resources[0].Status)
+	require.Equal(t, 1, *inventory.Resources[0].Count)
+	for _, entry := range inventory.Resources[1:] {
+		require.Equal(t, inventoryStatusUnknown, entry.Status)
+		require.Nil(t, entry.Count)
+		require.Contains(t, entry.Error, "timed out")
+	}
+	require.Equal(t, []string{"databases", "apps"}, inventory.Unknown)
+}
+
+func TestInventoryTool_sources(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	droplets := NewMockDropletsService(ctrl)
+	storage := NewMockStorageService(ctrl)
+	droplets.EXPECT().List(gomock.Any(), gomock.Any()).Return([]godo.Droplet{
+		{ID: 7, Name: "web", Created: "2026-03-01T10:00:00Z"},
+	}, &godo.Response{}, nil)
+	storage.EXPECT().ListVolumes(gomock.Any(), gomock.Any()).Return([]godo.Volume{
+		{ID: "vol-1", Name: "data", CreatedAt: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)},
+	}, &godo.Response{}, nil)
+
+	tool := NewInventoryTool(func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{Droplets: droplets, Storage: storage}, nil
+	})
+	tool.sources = tool.sources[:2]
+
+	inventory := callInventory(t, tool)
+	require.Equal(t, []InventoryEntry{
+		{Type: "droplets", Status: inventoryStatusOK, Count: inventory.Resources[0].Count,
+			Recent: []InventoryItem{{ID: "7", Name: "web", CreatedAt: "2026-03-01T10:00:00Z"}}},
+		{Type: "volumes", Status: inventoryStatusOK, Count: inventory.Resources[1].Count,
+			Recent: []InventoryItem{{ID: "vol-1", Name: "data", CreatedAt: "2026-03-02T00:00:00Z"}}},
+	}, inventory.Resources)
+	require.Equal(t, 1, *inventory.Resources[0].Count)
+	require.Equal(t, 1, *inventory.Resources[1].Count)
+}
+
+func TestInventoryTool_clientError(t *testing.T) {
+	tool := NewInventoryTool(func(ctx context.Context) (*godo.Client, error) {
+		return nil, errors.New("no token")
+	})
+	_, err := tool.inventory(context.Background(), mcp.CallToolRequest{})
+	require.ErrorContains(t, err, "failed to get DigitalOcean client")
+}
@@ -0,0 +1,90 @@
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"golang.org/x/crypto/ssh"
+	"mcp-digitalocean/pkg/registry/common"
+)
+
+// KeyVerification is the result of key-verify. Fingerprints are computed
+// locally from the given public key. Registered is true when a key on the
+// account has both the same MD5 and the same SHA256 fingerprint; Key is that
+// key.
+type KeyVerification struct {
+	Type              string       `json:"type"`
+	Comment           string       `json:"comment,omitempty"`
+	FingerprintMD5    string       `json:"fingerprint_md5"`
+	FingerprintSHA256 string       `json:"fingerprint_sha256"`
+	Registered        bool         `json:"registered"`
+	Key               *VerifiedKey `json:"key,omitempty"`
+}
+
+// VerifiedKey is the registered key that matched.
+type VerifiedKey struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// verifyKey checks whether a public key is registered on the account. The
+// key's MD5 fingerprint is compared with the fingerprint the API reports, and
+// its SHA256 fingerprint with one computed from each registered public key,
+// so a key is only reported as registered when both agree.
+func (k *KeysTool) verifyKey(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	publicKey, _ := req.GetArguments()["PublicKey"].(string)
+	if strings.TrimSpace(publicKey) == "" {
+		return mcp.NewToolResultError("PublicKey is required"), nil
+	}
+	pub, comment, _, _, err := ssh.ParseAuthorizedKey([]byte(publicKey))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("PublicKey is not a valid SSH public key in authorized_keys format: %v", err)), nil
+	}
+
+	client, err := k.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	keys, err := common.FetchAll(ctx, common.MaxPerPage, client.Keys.List)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+
+	result := KeyVerification{
+		Type:              pub.Type(),
+		Comment:           comment,
+		FingerprintMD5:    ssh.FingerprintLegacyMD5(pub),
+		FingerprintSHA256: ssh.FingerprintSHA256(pub),
+	}
+	if key := findKey(keys, result.FingerprintMD5, result.FingerprintSHA256); key != nil {
+		result.Registered = true
+		result.Key = &VerifiedKey{ID: key.ID, Name: key.Name, Fingerprint: key.Fingerprint}
+	}
+
+	jsonResult, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(string(jsonResult)), nil
+}
+
+// findKey returns the key with both fingerprints, or nil. A registered key
+// whose public key cannot be parsed never matches.
+func findKey(keys []godo.Key, md5, sha256 string) *godo.Key {
+	for i, key := range keys {
+		if key.Fingerprint != md5 {
+			continue
+		}
+		pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(key.PublicKey))
+		if err == nil && ssh.FingerprintSHA256(pub) == sha256 {
+			return &keys[i]
+		}
+	}
+	return nil
+}
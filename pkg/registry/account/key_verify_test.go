@@ -0,0 +1,102 @@
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// Test keys with fingerprints from ssh-keygen -l -E md5 and -E sha256.
+const (
+	alicePublicKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIKkKngJeZ7tcOpJVOLCA/DTZNb7YPzqdiuI/usytsnl5 alice@laptop"
+	aliceMD5       = "bd:58:67:9e:c4:f5:95:d3:88:48:73:ad:a3:6f:cb:8a"
+	aliceSHA256    = "SHA256:/BeenzJ7bfpFwHCO00xlb+N0HZEej0m4ClIIfXRF6Wg"
+	bobPublicKey   = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAINLmHBiGGFmFA4kBys075+35JvSaGshAY4C8f5yPufkf bob@laptop"
+	bobMD5         = "c6:bf:60:bb:97:94:c9:b4:91:38:6c:6f:04:30:7c:c2"
+)
+
+func TestKeysTool_verifyKey(t *testing.T) {
+	tests := []struct {
+		name          string
+		publicKey     string
+		keys          []godo.Key
+		listErr       error
+		expected      *KeyVerification
+		expectedError string
+	}{
+		{
+			name:      "registered",
+			publicKey: alicePublicKey,
+			keys: []godo.Key{
+				{ID: 1, Name: "bob", Fingerprint: bobMD5, PublicKey: bobPublicKey},
+				{ID: 2, Name: "alice", Fingerprint: aliceMD5, PublicKey: alicePublicKey},
+			},
+			expected: &KeyVerification{
+				Type: "ssh-ed25519", Comment: "alice@laptop", FingerprintMD5: aliceMD5, FingerprintSHA256: aliceSHA256,
+				Registered: true, Key: &VerifiedKey{ID: 2, Name: "alice", Fingerprint: aliceMD5},
+			},
+		},
+		{
+			name:      "not registered",
+			publicKey: alicePublicKey,
+			keys:      []godo.Key{{ID: 1, Name: "bob", Fingerprint: bobMD5, PublicKey: bobPublicKey}},
+			expected: &KeyVerification{
+				Type: "ssh-ed25519", Comment: "alice@laptop", FingerprintMD5: aliceMD5, FingerprintSHA256: aliceSHA256,
+			},
+		},
+		{
+			name:      "MD5 matches but the public key does not",
+			publicKey: alicePublicKey,
+			keys:      []godo.Key{{ID: 1, Name: "bob", Fingerprint: aliceMD5, PublicKey: bobPublicKey}},
+			expected: &KeyVerification{
+				Type: "ssh-ed25519", Comment: "alice@laptop", FingerprintMD5: aliceMD5, FingerprintSHA256: aliceSHA256,
+			},
+		},
+		{
+			name:          "missing key",
+			expectedError: "PublicKey is required",
+		},
+		{
+			name:          "invalid key",
+			publicKey:     "ssh-rsa BADKEY",
+			expectedError: "PublicKey is not a valid SSH public key",
+		},
+		{
+			name:          "API error",
+			publicKey:     alicePublicKey,
+			listErr:       errors.New("unauthorized"),
+			expectedError: "unauthorized",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockKeys := NewMockKeysService(ctrl)
+			if tc.keys != nil || tc.listErr != nil {
+				mockKeys.EXPECT().List(gomock.Any(), gomock.Any()).Return(tc.keys, nil, tc.listErr)
+			}
+			tool := setupKeysToolWithMock(mockKeys)
+
+			resp, err := tool.verifyKey(context.Background(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Arguments: map[string]any{"PublicKey": tc.publicKey}},
+			})
+			require.NoError(t, err)
+			if tc.expectedError != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.expectedError)
+				return
+			}
+			require.False(t, resp.IsError)
+			var result KeyVerification
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &result))
+			require.Equal(t, *tc.expected, result)
+		})
+	}
+}
@@ -157,5 +157,13 @@ func (k *KeysTool) Tools() []server.ServerTool {
 				mcp.WithNumber("PerPage", mcp.DefaultNumber(defaultKeysPageSize), mcp.Description("Items per page")),
 			),
 		},
+		{
+			Handler: k.verifyKey,
+			Tool: mcp.NewTool("key-verify",
+				common.WithHints(common.HintsRead),
+				mcp.WithDescription("Check whether a public SSH key is registered on the account. Computes the key's MD5 and SHA256 fingerprints locally and compares both with the account's keys. Returns the fingerprints, whether the key is registered, and the matching key's ID and name"),
+				mcp.WithString("PublicKey", mcp.Required(), mcp.Description("Public key in authorized_keys format, e.g. the contents of ~/.ssh/id_ed25519.pub")),
+			),
+		},
 	}
 }
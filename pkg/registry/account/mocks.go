@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/digitalocean/godo (interfaces: AccountService,ActionsService,BalanceService,BillingHistoryService,InvoicesService,KeysService)
+// Source: github.com/digitalocean/godo (interfaces: AccountService,ActionsService,BalanceService,BillingHistoryService,DatabasesService,DropletsService,InvoicesService,KeysService,KubernetesService,LoadBalancersService,SizesService,SnapshotsService,StorageService)
 //
 // Generated by this command:
 //
-//	mockgen -destination=./mocks.go -package account github.com/digitalocean/godo AccountService,ActionsService,BalanceService,BillingHistoryService,InvoicesService,KeysService
+//	mockgen -destination=./mocks.go -package account github.com/digitalocean/godo AccountService,ActionsService,BalanceService,BillingHistoryService,DatabasesService,DropletsService,InvoicesService,KeysService,KubernetesService,LoadBalancersService,SizesService,SnapshotsService,StorageService
 //
 
 // Package account is a generated GoMock package.
@@ -193,256 +193,2877 @@ func (mr *MockBillingHistoryServiceMockRecorder) List(arg0, arg1 any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockBillingHistoryService)(nil).List), arg0, arg1)
 }
 
+// MockDatabasesService is a mock of DatabasesService interface.
+type MockDatabasesService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDatabasesServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockDatabasesServiceMockRecorder is the mock recorder for MockDatabasesService.
+type MockDatabasesServiceMockRecorder struct {
+	mock *MockDatabasesService
+}
+
+// NewMockDatabasesService creates a new mock instance.
+func NewMockDatabasesService(ctrl *gomock.Controller) *MockDatabasesService {
+	mock := &MockDatabasesService{ctrl: ctrl}
+	mock.recorder = &MockDatabasesServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDatabasesService) EXPECT() *MockDatabasesServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockDatabasesService) Create(arg0 context.Context, arg1 *godo.DatabaseCreateRequest) (*godo.Database, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*godo.Database)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDatabasesServiceMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDatabasesService)(nil).Create), arg0, arg1)
+}
+
+// CreateDB mocks base method.
+func (m *MockDatabasesService) CreateDB(arg0 context.Context, arg1 string, arg2 *godo.DatabaseCreateDBRequest) (*godo.DatabaseDB, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDB", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.DatabaseDB)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateDB indicates an expected call of CreateDB.
+func (mr *MockDatabasesServiceMockRecorder) CreateDB(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDB", reflect.TypeOf((*MockDatabasesService)(nil).CreateDB), arg0, arg1, arg2)
+}
+
+// CreateKafkaSchemaRegistry mocks base method.
+func (m *MockDatabasesService) CreateKafkaSchemaRegistry(ctx context.Context, databaseID string, createKafkaSchemaRegistry *godo.DatabaseKafkaSchemaRegistryRequest) (*godo.DatabaseKafkaSchemaRegistrySubject, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateKafkaSchemaRegistry", ctx, databaseID, createKafkaSchemaRegistry)
+	ret0, _ := ret[0].(*godo.DatabaseKafkaSchemaRegistrySubject)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateKafkaSchemaRegistry indicates an expected call of CreateKafkaSchemaRegistry.
+func (mr *MockDatabasesServiceMockRecorder) CreateKafkaSchemaRegistry(ctx, databaseID, createKafkaSchemaRegistry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKafkaSchemaRegistry", reflect.TypeOf((*MockDatabasesService)(nil).CreateKafkaSchemaRegistry), ctx, databaseID, createKafkaSchemaRegistry)
+}
+
+// CreateLogsink mocks base method.
+func (m *MockDatabasesService) CreateLogsink(ctx context.Context, databaseID string, createLogsink *godo.DatabaseCreateLogsinkRequest) (*godo.DatabaseLogsink, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLogsink", ctx, databaseID, createLogsink)
+	ret0, _ := ret[0].(*godo.DatabaseLogsink)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateLogsink indicates an expected call of CreateLogsink.
+func (mr *MockDatabasesServiceMockRecorder) CreateLogsink(ctx, databaseID, createLogsink any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLogsink", reflect.TypeOf((*MockDatabasesService)(nil).CreateLogsink), ctx, databaseID, createLogsink)
+}
+
+// CreatePool mocks base method.
+func (m *MockDatabasesService) CreatePool(arg0 context.Context, arg1 string, arg2 *godo.DatabaseCreatePoolRequest) (*godo.DatabasePool, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePool", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.DatabasePool)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreatePool indicates an expected call of CreatePool.
+func (mr *MockDatabasesServiceMockRecorder) CreatePool(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePool", reflect.TypeOf((*MockDatabasesService)(nil).CreatePool), arg0, arg1, arg2)
+}
+
+// CreateReplica mocks base method.
+func (m *MockDatabasesService) CreateReplica(arg0 context.Context, arg1 string, arg2 *godo.DatabaseCreateReplicaRequest) (*godo.DatabaseReplica, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReplica", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.DatabaseReplica)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateReplica indicates an expected call of CreateReplica.
+func (mr *MockDatabasesServiceMockRecorder) CreateReplica(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReplica", reflect.TypeOf((*MockDatabasesService)(nil).CreateReplica), arg0, arg1, arg2)
+}
+
+// CreateTopic mocks base method.
+func (m *MockDatabasesService) CreateTopic(arg0 context.Context, arg1 string, arg2 *godo.DatabaseCreateTopicRequest) (*godo.DatabaseTopic, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTopic", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.DatabaseTopic)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateTopic indicates an expected call of CreateTopic.
+func (mr *MockDatabasesServiceMockRecorder) CreateTopic(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTopic", reflect.TypeOf((*MockDatabasesService)(nil).CreateTopic), arg0, arg1, arg2)
+}
+
+// CreateUser mocks base method.
+func (m *MockDatabasesService) CreateUser(arg0 context.Context, arg1 string, arg2 *godo.DatabaseCreateUserRequest) (*godo.DatabaseUser, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.DatabaseUser)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockDatabasesServiceMockRecorder) CreateUser(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockDatabasesService)(nil).CreateUser), arg0, arg1, arg2)
+}
+
+// Delete mocks base method.
+func (m *MockDatabasesService) Delete(arg0 context.Context, arg1 string) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDatabasesServiceMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDatabasesService)(nil).Delete), arg0, arg1)
+}
+
+// DeleteDB mocks base method.
+func (m *MockDatabasesService) DeleteDB(arg0 context.Context, arg1, arg2 string) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDB", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteDB indicates an expected call of DeleteDB.
+func (mr *MockDatabasesServiceMockRecorder) DeleteDB(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDB", reflect.TypeOf((*MockDatabasesService)(nil).DeleteDB), arg0, arg1, arg2)
+}
+
+// DeleteIndex mocks base method.
+func (m *MockDatabasesService) DeleteIndex(arg0 context.Context, arg1, arg2 string) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIndex", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteIndex indicates an expected call of DeleteIndex.
+func (mr *MockDatabasesServiceMockRecorder) DeleteIndex(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIndex", reflect.TypeOf((*MockDatabasesService)(nil).DeleteIndex), arg0, arg1, arg2)
+}
+
+// DeleteKafkaSchemaRegistry mocks base method.
+func (m *MockDatabasesService) DeleteKafkaSchemaRegistry(ctx context.Context, databaseID, subject string) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteKafkaSchemaRegistry", ctx, databaseID, subject)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteKafkaSchemaRegistry indicates an expected call of DeleteKafkaSchemaRegistry.
+func (mr *MockDatabasesServiceMockRecorder) DeleteKafkaSchemaRegistry(ctx, databaseID, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKafkaSchemaRegistry", reflect.TypeOf((*MockDatabasesService)(nil).DeleteKafkaSchemaRegistry), ctx, databaseID, subject)
+}
+
+// DeleteLogsink mocks base method.
+func (m *MockDatabasesService) DeleteLogsink(ctx context.Context, databaseID, logsinkID string) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLogsink", ctx, databaseID, logsinkID)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteLogsink indicates an expected call of DeleteLogsink.
+func (mr *MockDatabasesServiceMockRecorder) DeleteLogsink(ctx, databaseID, logsinkID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLogsink", reflect.TypeOf((*MockDatabasesService)(nil).DeleteLogsink), ctx, databaseID, logsinkID)
+}
+
+// DeletePool mocks base method.
+func (m *MockDatabasesService) DeletePool(arg0 context.Context, arg1, arg2 string) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePool", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePool indicates an expected call of DeletePool.
+func (mr *MockDatabasesServiceMockRecorder) DeletePool(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePool", reflect.TypeOf((*MockDatabasesService)(nil).DeletePool), arg0, arg1, arg2)
+}
+
+// DeleteReplica mocks base method.
+func (m *MockDatabasesService) DeleteReplica(arg0 context.Context, arg1, arg2 string) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReplica", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteReplica indicates an expected call of DeleteReplica.
+func (mr *MockDatabasesServiceMockRecorder) DeleteReplica(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReplica", reflect.TypeOf((*MockDatabasesService)(nil).DeleteReplica), arg0, arg1, arg2)
+}
+
+// DeleteTopic mocks base method.
+func (m *MockDatabasesService) DeleteTopic(arg0 context.Context, arg1, arg2 string) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTopic", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTopic indicates an expected call of DeleteTopic.
+func (mr *MockDatabasesServiceMockRecorder) DeleteTopic(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTopic", reflect.TypeOf((*MockDatabasesService)(nil).DeleteTopic), arg0, arg1, arg2)
+}
+
+// DeleteUser mocks base method.
+func (m *MockDatabasesService) DeleteUser(arg0 context.Context, arg1, arg2 string) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockDatabasesServiceMockRecorder) DeleteUser(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockDatabasesService)(nil).DeleteUser), arg0, arg1, arg2)
+}
+
+// Get mocks base method.
+func (m *MockDatabasesService) Get(arg0 context.Context, arg1 string) (*godo.Database, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*godo.Database)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockDatabasesServiceMockRecorder) Get(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDatabasesService)(nil).Get), arg0, arg1)
+}
+
+// GetAdvancedPostgresSQLConfig mocks base method.
+func (m *MockDatabasesService) GetAdvancedPostgresSQLConfig(arg0 context.Context, arg1 string) (*godo.AdvancedPostgresConfig, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdvancedPostgresSQLConfig", arg0, arg1)
+	ret0, _ := ret[0].(*godo.AdvancedPostgresConfig)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAdvancedPostgresSQLConfig indicates an expected call of GetAdvancedPostgresSQLConfig.
+func (mr *MockDatabasesServiceMockRecorder) GetAdvancedPostgresSQLConfig(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdvancedPostgresSQLConfig", reflect.TypeOf((*MockDatabasesService)(nil).GetAdvancedPostgresSQLConfig), arg0, arg1)
+}
+
+// GetCA mocks base method.
+func (m *MockDatabasesService) GetCA(arg0 context.Context, arg1 string) (*godo.DatabaseCA, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCA", arg0, arg1)
+	ret0, _ := ret[0].(*godo.DatabaseCA)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetCA indicates an expected call of GetCA.
+func (mr *MockDatabasesServiceMockRecorder) GetCA(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCA", reflect.TypeOf((*MockDatabasesService)(nil).GetCA), arg0, arg1)
+}
+
+// GetDB mocks base method.
+func (m *MockDatabasesService) GetDB(arg0 context.Context, arg1, arg2 string) (*godo.DatabaseDB, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDB", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.DatabaseDB)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDB indicates an expected call of GetDB.
+func (mr *MockDatabasesServiceMockRecorder) GetDB(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDB", reflect.TypeOf((*MockDatabasesService)(nil).GetDB), arg0, arg1, arg2)
+}
+
+// GetEvictionPolicy mocks base method.
+func (m *MockDatabasesService) GetEvictionPolicy(arg0 context.Context, arg1 string) (string, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEvictionPolicy", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEvictionPolicy indicates an expected call of GetEvictionPolicy.
+func (mr *MockDatabasesServiceMockRecorder) GetEvictionPolicy(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvictionPolicy", reflect.TypeOf((*MockDatabasesService)(nil).GetEvictionPolicy), arg0, arg1)
+}
+
+// GetFirewallRules mocks base method.
+func (m *MockDatabasesService) GetFirewallRules(arg0 context.Context, arg1 string) ([]godo.DatabaseFirewallRule, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFirewallRules", arg0, arg1)
+	ret0, _ := ret[0].([]godo.DatabaseFirewallRule)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetFirewallRules indicates an expected call of GetFirewallRules.
+func (mr *MockDatabasesServiceMockRecorder) GetFirewallRules(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFirewallRules", reflect.TypeOf((*MockDatabasesService)(nil).GetFirewallRules), arg0, arg1)
+}
+
+// GetKafkaConfig mocks base method.
+func (m *MockDatabasesService) GetKafkaConfig(arg0 context.Context, arg1 string) (*godo.KafkaConfig, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKafkaConfig", arg0, arg1)
+	ret0, _ := ret[0].(*godo.KafkaConfig)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetKafkaConfig indicates an expected call of GetKafkaConfig.
+func (mr *MockDatabasesServiceMockRecorder) GetKafkaConfig(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKafkaConfig", reflect.TypeOf((*MockDatabasesService)(nil).GetKafkaConfig), arg0, arg1)
+}
+
+// GetKafkaSchemaRegistry mocks base method.
+func (m *MockDatabasesService) GetKafkaSchemaRegistry(ctx context.Context, databaseID, subject string) (*godo.DatabaseKafkaSchemaRegistrySubject, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKafkaSchemaRegistry", ctx, databaseID, subject)
+	ret0, _ := ret[0].(*godo.DatabaseKafkaSchemaRegistrySubject)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetKafkaSchemaRegistry indicates an expected call of GetKafkaSchemaRegistry.
+func (mr *MockDatabasesServiceMockRecorder) GetKafkaSchemaRegistry(ctx, databaseID, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKafkaSchemaRegistry", reflect.TypeOf((*MockDatabasesService)(nil).GetKafkaSchemaRegistry), ctx, databaseID, subject)
+}
+
+// GetKafkaSchemaRegistryConfig mocks base method.
+func (m *MockDatabasesService) GetKafkaSchemaRegistryConfig(ctx context.Context, databaseID string) (*godo.DatabaseKafkaSchemaRegistryConfig, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKafkaSchemaRegistryConfig", ctx, databaseID)
+	ret0, _ := ret[0].(*godo.DatabaseKafkaSchemaRegistryConfig)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetKafkaSchemaRegistryConfig indicates an expected call of GetKafkaSchemaRegistryConfig.
+func (mr *MockDatabasesServiceMockRecorder) GetKafkaSchemaRegistryConfig(ctx, databaseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKafkaSchemaRegistryConfig", reflect.TypeOf((*MockDatabasesService)(nil).GetKafkaSchemaRegistryConfig), ctx, databaseID)
+}
+
+// GetKafkaSchemaRegistrySubjectConfig mocks base method.
+func (m *MockDatabasesService) GetKafkaSchemaRegistrySubjectConfig(ctx context.Context, databaseID, subject string) (*godo.DatabaseKafkaSchemaRegistrySubjectConfigResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKafkaSchemaRegistrySubjectConfig", ctx, databaseID, subject)
+	ret0, _ := ret[0].(*godo.DatabaseKafkaSchemaRegistrySubjectConfigResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetKafkaSchemaRegistrySubjectConfig indicates an expected call of GetKafkaSchemaRegistrySubjectConfig.
+func (mr *MockDatabasesServiceMockRecorder) GetKafkaSchemaRegistrySubjectConfig(ctx, databaseID, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKafkaSchemaRegistrySubjectConfig", reflect.TypeOf((*MockDatabasesService)(nil).GetKafkaSchemaRegistrySubjectConfig), ctx, databaseID, subject)
+}
+
+// GetLogsink mocks base method.
+func (m *MockDatabasesService) GetLogsink(ctx context.Context, databaseID, logsinkID string) (*godo.DatabaseLogsink, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLogsink", ctx, databaseID, logsinkID)
+	ret0, _ := ret[0].(*godo.DatabaseLogsink)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLogsink indicates an expected call of GetLogsink.
+func (mr *MockDatabasesServiceMockRecorder) GetLogsink(ctx, databaseID, logsinkID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogsink", reflect.TypeOf((*MockDatabasesService)(nil).GetLogsink), ctx, databaseID, logsinkID)
+}
+
+// GetMetricsCredentials mocks base method.
+func (m *MockDatabasesService) GetMetricsCredentials(arg0 context.Context) (*godo.DatabaseMetricsCredentials, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetricsCredentials", arg0)
+	ret0, _ := ret[0].(*godo.DatabaseMetricsCredentials)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetMetricsCredentials indicates an expected call of GetMetricsCredentials.
+func (mr *MockDatabasesServiceMockRecorder) GetMetricsCredentials(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetricsCredentials", reflect.TypeOf((*MockDatabasesService)(nil).GetMetricsCredentials), arg0)
+}
+
+// GetMongoDBConfig mocks base method.
+func (m *MockDatabasesService) GetMongoDBConfig(arg0 context.Context, arg1 string) (*godo.MongoDBConfig, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMongoDBConfig", arg0, arg1)
+	ret0, _ := ret[0].(*godo.MongoDBConfig)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetMongoDBConfig indicates an expected call of GetMongoDBConfig.
+func (mr *MockDatabasesServiceMockRecorder) GetMongoDBConfig(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMongoDBConfig", reflect.TypeOf((*MockDatabasesService)(nil).GetMongoDBConfig), arg0, arg1)
+}
+
+// GetMySQLConfig mocks base method.
+func (m *MockDatabasesService) GetMySQLConfig(arg0 context.Context, arg1 string) (*godo.MySQLConfig, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMySQLConfig", arg0, arg1)
+	ret0, _ := ret[0].(*godo.MySQLConfig)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetMySQLConfig indicates an expected call of GetMySQLConfig.
+func (mr *MockDatabasesServiceMockRecorder) GetMySQLConfig(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMySQLConfig", reflect.TypeOf((*MockDatabasesService)(nil).GetMySQLConfig), arg0, arg1)
+}
+
+// GetOnlineMigrationStatus mocks base method.
+func (m *MockDatabasesService) GetOnlineMigrationStatus(ctx context.Context, databaseID string) (*godo.DatabaseOnlineMigrationStatus, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOnlineMigrationStatus", ctx, databaseID)
+	ret0, _ := ret[0].(*godo.DatabaseOnlineMigrationStatus)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetOnlineMigrationStatus indicates an expected call of GetOnlineMigrationStatus.
+func (mr *MockDatabasesServiceMockRecorder) GetOnlineMigrationStatus(ctx, databaseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOnlineMigrationStatus", reflect.TypeOf((*MockDatabasesService)(nil).GetOnlineMigrationStatus), ctx, databaseID)
+}
+
+// GetOpensearchConfig mocks base method.
+func (m *MockDatabasesService) GetOpensearchConfig(arg0 context.Context, arg1 string) (*godo.OpensearchConfig, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpensearchConfig", arg0, arg1)
+	ret0, _ := ret[0].(*godo.OpensearchConfig)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetOpensearchConfig indicates an expected call of GetOpensearchConfig.
+func (mr *MockDatabasesServiceMockRecorder) GetOpensearchConfig(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpensearchConfig", reflect.TypeOf((*MockDatabasesService)(nil).GetOpensearchConfig), arg0, arg1)
+}
+
+// GetPool mocks base method.
+func (m *MockDatabasesService) GetPool(arg0 context.Context, arg1, arg2 string) (*godo.DatabasePool, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPool", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.DatabasePool)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPool indicates an expected call of GetPool.
+func (mr *MockDatabasesServiceMockRecorder) GetPool(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPool", reflect.TypeOf((*MockDatabasesService)(nil).GetPool), arg0, arg1, arg2)
+}
+
+// GetPostgreSQLConfig mocks base method.
+func (m *MockDatabasesService) GetPostgreSQLConfig(arg0 context.Context, arg1 string) (*godo.PostgreSQLConfig, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPostgreSQLConfig", arg0, arg1)
+	ret0, _ := ret[0].(*godo.PostgreSQLConfig)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPostgreSQLConfig indicates an expected call of GetPostgreSQLConfig.
+func (mr *MockDatabasesServiceMockRecorder) GetPostgreSQLConfig(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostgreSQLConfig", reflect.TypeOf((*MockDatabasesService)(nil).GetPostgreSQLConfig), arg0, arg1)
+}
+
+// GetRedisConfig mocks base method.
+func (m *MockDatabasesService) GetRedisConfig(arg0 context.Context, arg1 string) (*godo.RedisConfig, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRedisConfig", arg0, arg1)
+	ret0, _ := ret[0].(*godo.RedisConfig)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetRedisConfig indicates an expected call of GetRedisConfig.
+func (mr *MockDatabasesServiceMockRecorder) GetRedisConfig(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRedisConfig", reflect.TypeOf((*MockDatabasesService)(nil).GetRedisConfig), arg0, arg1)
+}
+
+// GetReplica mocks base method.
+func (m *MockDatabasesService) GetReplica(arg0 context.Context, arg1, arg2 string) (*godo.DatabaseReplica, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReplica", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.DatabaseReplica)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetReplica indicates an expected call of GetReplica.
+func (mr *MockDatabasesServiceMockRecorder) GetReplica(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReplica", reflect.TypeOf((*MockDatabasesService)(nil).GetReplica), arg0, arg1, arg2)
+}
+
+// GetSQLMode mocks base method.
+func (m *MockDatabasesService) GetSQLMode(arg0 context.Context, arg1 string) (string, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSQLMode", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSQLMode indicates an expected call of GetSQLMode.
+func (mr *MockDatabasesServiceMockRecorder) GetSQLMode(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSQLMode", reflect.TypeOf((*MockDatabasesService)(nil).GetSQLMode), arg0, arg1)
+}
+
+// GetStorageAutoscale mocks base method.
+func (m *MockDatabasesService) GetStorageAutoscale(arg0 context.Context, arg1 string) (*godo.DatabaseStorageAutoscale, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStorageAutoscale", arg0, arg1)
+	ret0, _ := ret[0].(*godo.DatabaseStorageAutoscale)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetStorageAutoscale indicates an expected call of GetStorageAutoscale.
+func (mr *MockDatabasesServiceMockRecorder) GetStorageAutoscale(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStorageAutoscale", reflect.TypeOf((*MockDatabasesService)(nil).GetStorageAutoscale), arg0, arg1)
+}
+
+// GetTopic mocks base method.
+func (m *MockDatabasesService) GetTopic(arg0 context.Context, arg1, arg2 string) (*godo.DatabaseTopic, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTopic", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.DatabaseTopic)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTopic indicates an expected call of GetTopic.
+func (mr *MockDatabasesServiceMockRecorder) GetTopic(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopic", reflect.TypeOf((*MockDatabasesService)(nil).GetTopic), arg0, arg1, arg2)
+}
+
+// GetUser mocks base method.
+func (m *MockDatabasesService) GetUser(arg0 context.Context, arg1, arg2 string) (*godo.DatabaseUser, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.DatabaseUser)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockDatabasesServiceMockRecorder) GetUser(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockDatabasesService)(nil).GetUser), arg0, arg1, arg2)
+}
+
+// GetValkeyConfig mocks base method.
+func (m *MockDatabasesService) GetValkeyConfig(arg0 context.Context, arg1 string) (*godo.ValkeyConfig, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValkeyConfig", arg0, arg1)
+	ret0, _ := ret[0].(*godo.ValkeyConfig)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetValkeyConfig indicates an expected call of GetValkeyConfig.
+func (mr *MockDatabasesServiceMockRecorder) GetValkeyConfig(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValkeyConfig", reflect.TypeOf((*MockDatabasesService)(nil).GetValkeyConfig), arg0, arg1)
+}
+
+// InstallUpdate mocks base method.
+func (m *MockDatabasesService) InstallUpdate(arg0 context.Context, arg1 string) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallUpdate", arg0, arg1)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InstallUpdate indicates an expected call of InstallUpdate.
+func (mr *MockDatabasesServiceMockRecorder) InstallUpdate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallUpdate", reflect.TypeOf((*MockDatabasesService)(nil).InstallUpdate), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockDatabasesService) List(arg0 context.Context, arg1 *godo.ListOptions) ([]godo.Database, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].([]godo.Database)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockDatabasesServiceMockRecorder) List(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDatabasesService)(nil).List), arg0, arg1)
+}
+
+// ListBackups mocks base method.
+func (m *MockDatabasesService) ListBackups(arg0 context.Context, arg1 string, arg2 *godo.ListOptions) ([]godo.DatabaseBackup, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBackups", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]godo.DatabaseBackup)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListBackups indicates an expected call of ListBackups.
+func (mr *MockDatabasesServiceMockRecorder) ListBackups(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBackups", reflect.TypeOf((*MockDatabasesService)(nil).ListBackups), arg0, arg1, arg2)
+}
+
+// ListDBs mocks base method.
+func (m *MockDatabasesService) ListDBs(arg0 context.Context, arg1 string, arg2 *godo.ListOptions) ([]godo.DatabaseDB, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDBs", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]godo.DatabaseDB)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListDBs indicates an expected call of ListDBs.
+func (mr *MockDatabasesServiceMockRecorder) ListDBs(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDBs", reflect.TypeOf((*MockDatabasesService)(nil).ListDBs), arg0, arg1, arg2)
+}
+
+// ListDatabaseEvents mocks base method.
+func (m *MockDatabasesService) ListDatabaseEvents(arg0 context.Context, arg1 string, arg2 *godo.ListOptions) ([]godo.DatabaseEvent, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDatabaseEvents", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]godo.DatabaseEvent)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListDatabaseEvents indicates an expected call of ListDatabaseEvents.
+func (mr *MockDatabasesServiceMockRecorder) ListDatabaseEvents(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDatabaseEvents", reflect.TypeOf((*MockDatabasesService)(nil).ListDatabaseEvents), arg0, arg1, arg2)
+}
+
+// ListIndexes mocks base method.
+func (m *MockDatabasesService) ListIndexes(arg0 context.Context, arg1 string, arg2 *godo.ListOptions) ([]godo.DatabaseIndex, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIndexes", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]godo.DatabaseIndex)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListIndexes indicates an expected call of ListIndexes.
+func (mr *MockDatabasesServiceMockRecorder) ListIndexes(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIndexes", reflect.TypeOf((*MockDatabasesService)(nil).ListIndexes), arg0, arg1, arg2)
+}
+
+// ListKafkaSchemaRegistry mocks base method.
+func (m *MockDatabasesService) ListKafkaSchemaRegistry(ctx context.Context, databaseID string, opts *godo.ListOptions) ([]godo.DatabaseKafkaSchemaRegistrySubject, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListKafkaSchemaRegistry", ctx, databaseID, opts)
+	ret0, _ := ret[0].([]godo.DatabaseKafkaSchemaRegistrySubject)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListKafkaSchemaRegistry indicates an expected call of ListKafkaSchemaRegistry.
+func (mr *MockDatabasesServiceMockRecorder) ListKafkaSchemaRegistry(ctx, databaseID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListKafkaSchemaRegistry", reflect.TypeOf((*MockDatabasesService)(nil).ListKafkaSchemaRegistry), ctx, databaseID, opts)
+}
+
+// ListLogsinks mocks base method.
+func (m *MockDatabasesService) ListLogsinks(ctx context.Context, databaseID string, opts *godo.ListOptions) ([]godo.DatabaseLogsink, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLogsinks", ctx, databaseID, opts)
+	ret0, _ := ret[0].([]godo.DatabaseLogsink)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListLogsinks indicates an expected call of ListLogsinks.
+func (mr *MockDatabasesServiceMockRecorder) ListLogsinks(ctx, databaseID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLogsinks", reflect.TypeOf((*MockDatabasesService)(nil).ListLogsinks), ctx, databaseID, opts)
+}
+
+// ListOptions mocks base method.
+func (m *MockDatabasesService) ListOptions(todo context.Context) (*godo.DatabaseOptions, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOptions", todo)
+	ret0, _ := ret[0].(*godo.DatabaseOptions)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListOptions indicates an expected call of ListOptions.
+func (mr *MockDatabasesServiceMockRecorder) ListOptions(todo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOptions", reflect.TypeOf((*MockDatabasesService)(nil).ListOptions), todo)
+}
+
+// ListPools mocks base method.
+func (m *MockDatabasesService) ListPools(arg0 context.Context, arg1 string, arg2 *godo.ListOptions) ([]godo.DatabasePool, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPools", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]godo.DatabasePool)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListPools indicates an expected call of ListPools.
+func (mr *MockDatabasesServiceMockRecorder) ListPools(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPools", reflect.TypeOf((*MockDatabasesService)(nil).ListPools), arg0, arg1, arg2)
+}
+
+// ListReplicas mocks base method.
+func (m *MockDatabasesService) ListReplicas(arg0 context.Context, arg1 string, arg2 *godo.ListOptions) ([]godo.DatabaseReplica, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReplicas", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]godo.DatabaseReplica)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListReplicas indicates an expected call of ListReplicas.
+func (mr *MockDatabasesServiceMockRecorder) ListReplicas(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReplicas", reflect.TypeOf((*MockDatabasesService)(nil).ListReplicas), arg0, arg1, arg2)
+}
+
+// ListTopics mocks base method.
+func (m *MockDatabasesService) ListTopics(arg0 context.Context, arg1 string, arg2 *godo.ListOptions) ([]godo.DatabaseTopic, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTopics", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]godo.DatabaseTopic)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListTopics indicates an expected call of ListTopics.
+func (mr *MockDatabasesServiceMockRecorder) ListTopics(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTopics", reflect.TypeOf((*MockDatabasesService)(nil).ListTopics), arg0, arg1, arg2)
+}
+
+// ListUsers mocks base method.
+func (m *MockDatabasesService) ListUsers(arg0 context.Context, arg1 string, arg2 *godo.ListOptions) ([]godo.DatabaseUser, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]godo.DatabaseUser)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockDatabasesServiceMockRecorder) ListUsers(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockDatabasesService)(nil).ListUsers), arg0, arg1, arg2)
+}
+
+// Migrate mocks base method.
+func (m *MockDatabasesService) Migrate(arg0 context.Context, arg1 string, arg2 *godo.DatabaseMigrateRequest) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Migrate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Migrate indicates an expected call of Migrate.
+func (mr *MockDatabasesServiceMockRecorder) Migrate(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Migrate", reflect.TypeOf((*MockDatabasesService)(nil).Migrate), arg0, arg1, arg2)
+}
+
+// PromoteReplicaToPrimary mocks base method.
+func (m *MockDatabasesService) PromoteReplicaToPrimary(arg0 context.Context, arg1, arg2 string) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PromoteReplicaToPrimary", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PromoteReplicaToPrimary indicates an expected call of PromoteReplicaToPrimary.
+func (mr *MockDatabasesServiceMockRecorder) PromoteReplicaToPrimary(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PromoteReplicaToPrimary", reflect.TypeOf((*MockDatabasesService)(nil).PromoteReplicaToPrimary), arg0, arg1, arg2)
+}
+
+// ResetUserAuth mocks base method.
+func (m *MockDatabasesService) ResetUserAuth(arg0 context.Context, arg1, arg2 string, arg3 *godo.DatabaseResetUserAuthRequest) (*godo.DatabaseUser, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetUserAuth", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*godo.DatabaseUser)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ResetUserAuth indicates an expected call of ResetUserAuth.
+func (mr *MockDatabasesServiceMockRecorder) ResetUserAuth(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetUserAuth", reflect.TypeOf((*MockDatabasesService)(nil).ResetUserAuth), arg0, arg1, arg2, arg3)
+}
+
+// Resize mocks base method.
+func (m *MockDatabasesService) Resize(arg0 context.Context, arg1 string, arg2 *godo.DatabaseResizeRequest) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resize", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resize indicates an expected call of Resize.
+func (mr *MockDatabasesServiceMockRecorder) Resize(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resize", reflect.TypeOf((*MockDatabasesService)(nil).Resize), arg0, arg1, arg2)
+}
+
+// SetEvictionPolicy mocks base method.
+func (m *MockDatabasesService) SetEvictionPolicy(arg0 context.Context, arg1, arg2 string) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEvictionPolicy", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetEvictionPolicy indicates an expected call of SetEvictionPolicy.
+func (mr *MockDatabasesServiceMockRecorder) SetEvictionPolicy(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEvictionPolicy", reflect.TypeOf((*MockDatabasesService)(nil).SetEvictionPolicy), arg0, arg1, arg2)
+}
+
+// SetSQLMode mocks base method.
+func (m *MockDatabasesService) SetSQLMode(arg0 context.Context, arg1 string, arg2 ...string) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetSQLMode", varargs...)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetSQLMode indicates an expected call of SetSQLMode.
+func (mr *MockDatabasesServiceMockRecorder) SetSQLMode(arg0, arg1 any, arg2 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSQLMode", reflect.TypeOf((*MockDatabasesService)(nil).SetSQLMode), varargs...)
+}
+
+// StartOnlineMigration mocks base method.
+func (m *MockDatabasesService) StartOnlineMigration(ctx context.Context, databaseID string, onlineMigrationRequest *godo.DatabaseStartOnlineMigrationRequest) (*godo.DatabaseOnlineMigrationStatus, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartOnlineMigration", ctx, databaseID, onlineMigrationRequest)
+	ret0, _ := ret[0].(*godo.DatabaseOnlineMigrationStatus)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// StartOnlineMigration indicates an expected call of StartOnlineMigration.
+func (mr *MockDatabasesServiceMockRecorder) StartOnlineMigration(ctx, databaseID, onlineMigrationRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartOnlineMigration", reflect.TypeOf((*MockDatabasesService)(nil).StartOnlineMigration), ctx, databaseID, onlineMigrationRequest)
+}
+
+// StopOnlineMigration mocks base method.
+func (m *MockDatabasesService) StopOnlineMigration(ctx context.Context, databaseID, migrationID string) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopOnlineMigration", ctx, databaseID, migrationID)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopOnlineMigration indicates an expected call of StopOnlineMigration.
+func (mr *MockDatabasesServiceMockRecorder) StopOnlineMigration(ctx, databaseID, migrationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopOnlineMigration", reflect.TypeOf((*MockDatabasesService)(nil).StopOnlineMigration), ctx, databaseID, migrationID)
+}
+
+// UpdateAdvancedPostgresSQLConfig mocks base method.
+func (m *MockDatabasesService) UpdateAdvancedPostgresSQLConfig(arg0 context.Context, arg1 string, arg2 *godo.AdvancedPostgresConfigUpdate) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAdvancedPostgresSQLConfig", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAdvancedPostgresSQLConfig indicates an expected call of UpdateAdvancedPostgresSQLConfig.
+func (mr *MockDatabasesServiceMockRecorder) UpdateAdvancedPostgresSQLConfig(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAdvancedPostgresSQLConfig", reflect.TypeOf((*MockDatabasesService)(nil).UpdateAdvancedPostgresSQLConfig), arg0, arg1, arg2)
+}
+
+// UpdateFirewallRules mocks base method.
+func (m *MockDatabasesService) UpdateFirewallRules(arg0 context.Context, arg1 string, arg2 *godo.DatabaseUpdateFirewallRulesRequest) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFirewallRules", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFirewallRules indicates an expected call of UpdateFirewallRules.
+func (mr *MockDatabasesServiceMockRecorder) UpdateFirewallRules(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFirewallRules", reflect.TypeOf((*MockDatabasesService)(nil).UpdateFirewallRules), arg0, arg1, arg2)
+}
+
+// UpdateKafkaConfig mocks base method.
+func (m *MockDatabasesService) UpdateKafkaConfig(arg0 context.Context, arg1 string, arg2 *godo.KafkaConfig) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateKafkaConfig", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateKafkaConfig indicates an expected call of UpdateKafkaConfig.
+func (mr *MockDatabasesServiceMockRecorder) UpdateKafkaConfig(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateKafkaConfig", reflect.TypeOf((*MockDatabasesService)(nil).UpdateKafkaConfig), arg0, arg1, arg2)
+}
+
+// UpdateKafkaSchemaRegistryConfig mocks base method.
+func (m *MockDatabasesService) UpdateKafkaSchemaRegistryConfig(ctx context.Context, databaseID string, updateKafkaSchemaRegistryConfig *godo.DatabaseKafkaSchemaRegistryConfig) (*godo.DatabaseKafkaSchemaRegistryConfig, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateKafkaSchemaRegistryConfig", ctx, databaseID, updateKafkaSchemaRegistryConfig)
+	ret0, _ := ret[0].(*godo.DatabaseKafkaSchemaRegistryConfig)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateKafkaSchemaRegistryConfig indicates an expected call of UpdateKafkaSchemaRegistryConfig.
+func (mr *MockDatabasesServiceMockRecorder) UpdateKafkaSchemaRegistryConfig(ctx, databaseID, updateKafkaSchemaRegistryConfig any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateKafkaSchemaRegistryConfig", reflect.TypeOf((*MockDatabasesService)(nil).UpdateKafkaSchemaRegistryConfig), ctx, databaseID, updateKafkaSchemaRegistryConfig)
+}
+
+// UpdateKafkaSchemaRegistrySubjectConfig mocks base method.
+func (m *MockDatabasesService) UpdateKafkaSchemaRegistrySubjectConfig(ctx context.Context, databaseID, subject string, updateKafkaSchemaRegistrySubjectConfig *godo.DatabaseKafkaSchemaRegistryConfig) (*godo.DatabaseKafkaSchemaRegistrySubjectConfigResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateKafkaSchemaRegistrySubjectConfig", ctx, databaseID, subject, updateKafkaSchemaRegistrySubjectConfig)
+	ret0, _ := ret[0].(*godo.DatabaseKafkaSchemaRegistrySubjectConfigResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateKafkaSchemaRegistrySubjectConfig indicates an expected call of UpdateKafkaSchemaRegistrySubjectConfig.
+func (mr *MockDatabasesServiceMockRecorder) UpdateKafkaSchemaRegistrySubjectConfig(ctx, databaseID, subject, updateKafkaSchemaRegistrySubjectConfig any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateKafkaSchemaRegistrySubjectConfig", reflect.TypeOf((*MockDatabasesService)(nil).UpdateKafkaSchemaRegistrySubjectConfig), ctx, databaseID, subject, updateKafkaSchemaRegistrySubjectConfig)
+}
+
+// UpdateLogsink mocks base method.
+func (m *MockDatabasesService) UpdateLogsink(ctx context.Context, databaseID, logsinkID string, updateLogsink *godo.DatabaseUpdateLogsinkRequest) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLogsink", ctx, databaseID, logsinkID, updateLogsink)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLogsink indicates an expected call of UpdateLogsink.
+func (mr *MockDatabasesServiceMockRecorder) UpdateLogsink(ctx, databaseID, logsinkID, updateLogsink any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLogsink", reflect.TypeOf((*MockDatabasesService)(nil).UpdateLogsink), ctx, databaseID, logsinkID, updateLogsink)
+}
+
+// UpdateMaintenance mocks base method.
+func (m *MockDatabasesService) UpdateMaintenance(arg0 context.Context, arg1 string, arg2 *godo.DatabaseUpdateMaintenanceRequest) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMaintenance", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMaintenance indicates an expected call of UpdateMaintenance.
+func (mr *MockDatabasesServiceMockRecorder) UpdateMaintenance(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMaintenance", reflect.TypeOf((*MockDatabasesService)(nil).UpdateMaintenance), arg0, arg1, arg2)
+}
+
+// UpdateMetricsCredentials mocks base method.
+func (m *MockDatabasesService) UpdateMetricsCredentials(arg0 context.Context, arg1 *godo.DatabaseUpdateMetricsCredentialsRequest) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMetricsCredentials", arg0, arg1)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMetricsCredentials indicates an expected call of UpdateMetricsCredentials.
+func (mr *MockDatabasesServiceMockRecorder) UpdateMetricsCredentials(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetricsCredentials", reflect.TypeOf((*MockDatabasesService)(nil).UpdateMetricsCredentials), arg0, arg1)
+}
+
+// UpdateMongoDBConfig mocks base method.
+func (m *MockDatabasesService) UpdateMongoDBConfig(arg0 context.Context, arg1 string, arg2 *godo.MongoDBConfig) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMongoDBConfig", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMongoDBConfig indicates an expected call of UpdateMongoDBConfig.
+func (mr *MockDatabasesServiceMockRecorder) UpdateMongoDBConfig(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMongoDBConfig", reflect.TypeOf((*MockDatabasesService)(nil).UpdateMongoDBConfig), arg0, arg1, arg2)
+}
+
+// UpdateMySQLConfig mocks base method.
+func (m *MockDatabasesService) UpdateMySQLConfig(arg0 context.Context, arg1 string, arg2 *godo.MySQLConfig) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMySQLConfig", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMySQLConfig indicates an expected call of UpdateMySQLConfig.
+func (mr *MockDatabasesServiceMockRecorder) UpdateMySQLConfig(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMySQLConfig", reflect.TypeOf((*MockDatabasesService)(nil).UpdateMySQLConfig), arg0, arg1, arg2)
+}
+
+// UpdateOpensearchConfig mocks base method.
+func (m *MockDatabasesService) UpdateOpensearchConfig(arg0 context.Context, arg1 string, arg2 *godo.OpensearchConfig) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOpensearchConfig", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOpensearchConfig indicates an expected call of UpdateOpensearchConfig.
+func (mr *MockDatabasesServiceMockRecorder) UpdateOpensearchConfig(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOpensearchConfig", reflect.TypeOf((*MockDatabasesService)(nil).UpdateOpensearchConfig), arg0, arg1, arg2)
+}
+
+// UpdatePool mocks base method.
+func (m *MockDatabasesService) UpdatePool(arg0 context.Context, arg1, arg2 string, arg3 *godo.DatabaseUpdatePoolRequest) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePool", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePool indicates an expected call of UpdatePool.
+func (mr *MockDatabasesServiceMockRecorder) UpdatePool(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePool", reflect.TypeOf((*MockDatabasesService)(nil).UpdatePool), arg0, arg1, arg2, arg3)
+}
+
+// UpdatePostgreSQLConfig mocks base method.
+func (m *MockDatabasesService) UpdatePostgreSQLConfig(arg0 context.Context, arg1 string, arg2 *godo.PostgreSQLConfig) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePostgreSQLConfig", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePostgreSQLConfig indicates an expected call of UpdatePostgreSQLConfig.
+func (mr *MockDatabasesServiceMockRecorder) UpdatePostgreSQLConfig(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePostgreSQLConfig", reflect.TypeOf((*MockDatabasesService)(nil).UpdatePostgreSQLConfig), arg0, arg1, arg2)
+}
+
+// UpdateRedisConfig mocks base method.
+func (m *MockDatabasesService) UpdateRedisConfig(arg0 context.Context, arg1 string, arg2 *godo.RedisConfig) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRedisConfig", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRedisConfig indicates an expected call of UpdateRedisConfig.
+func (mr *MockDatabasesServiceMockRecorder) UpdateRedisConfig(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRedisConfig", reflect.TypeOf((*MockDatabasesService)(nil).UpdateRedisConfig), arg0, arg1, arg2)
+}
+
+// UpdateStorageAutoscale mocks base method.
+func (m *MockDatabasesService) UpdateStorageAutoscale(arg0 context.Context, arg1 string, arg2 *godo.DatabaseStorageAutoscale) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStorageAutoscale", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStorageAutoscale indicates an expected call of UpdateStorageAutoscale.
+func (mr *MockDatabasesServiceMockRecorder) UpdateStorageAutoscale(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStorageAutoscale", reflect.TypeOf((*MockDatabasesService)(nil).UpdateStorageAutoscale), arg0, arg1, arg2)
+}
+
+// UpdateTopic mocks base method.
+func (m *MockDatabasesService) UpdateTopic(arg0 context.Context, arg1, arg2 string, arg3 *godo.DatabaseUpdateTopicRequest) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTopic", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTopic indicates an expected call of UpdateTopic.
+func (mr *MockDatabasesServiceMockRecorder) UpdateTopic(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTopic", reflect.TypeOf((*MockDatabasesService)(nil).UpdateTopic), arg0, arg1, arg2, arg3)
+}
+
+// UpdateUser mocks base method.
+func (m *MockDatabasesService) UpdateUser(arg0 context.Context, arg1, arg2 string, arg3 *godo.DatabaseUpdateUserRequest) (*godo.DatabaseUser, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUser", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*godo.DatabaseUser)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockDatabasesServiceMockRecorder) UpdateUser(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockDatabasesService)(nil).UpdateUser), arg0, arg1, arg2, arg3)
+}
+
+// UpdateValkeyConfig mocks base method.
+func (m *MockDatabasesService) UpdateValkeyConfig(arg0 context.Context, arg1 string, arg2 *godo.ValkeyConfig) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateValkeyConfig", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateValkeyConfig indicates an expected call of UpdateValkeyConfig.
+func (mr *MockDatabasesServiceMockRecorder) UpdateValkeyConfig(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateValkeyConfig", reflect.TypeOf((*MockDatabasesService)(nil).UpdateValkeyConfig), arg0, arg1, arg2)
+}
+
+// UpgradeMajorVersion mocks base method.
+func (m *MockDatabasesService) UpgradeMajorVersion(arg0 context.Context, arg1 string, arg2 *godo.UpgradeVersionRequest) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpgradeMajorVersion", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpgradeMajorVersion indicates an expected call of UpgradeMajorVersion.
+func (mr *MockDatabasesServiceMockRecorder) UpgradeMajorVersion(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeMajorVersion", reflect.TypeOf((*MockDatabasesService)(nil).UpgradeMajorVersion), arg0, arg1, arg2)
+}
+
+// MockDropletsService is a mock of DropletsService interface.
+type MockDropletsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDropletsServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockDropletsServiceMockRecorder is the mock recorder for MockDropletsService.
+type MockDropletsServiceMockRecorder struct {
+	mock *MockDropletsService
+}
+
+// NewMockDropletsService creates a new mock instance.
+func NewMockDropletsService(ctrl *gomock.Controller) *MockDropletsService {
+	mock := &MockDropletsService{ctrl: ctrl}
+	mock.recorder = &MockDropletsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDropletsService) EXPECT() *MockDropletsServiceMockRecorder {
+	return m.recorder
+}
+
+// Actions mocks base method.
+func (m *MockDropletsService) Actions(arg0 context.Context, arg1 int, arg2 *godo.ListOptions) ([]godo.Action, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Actions", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]godo.Action)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Actions indicates an expected call of Actions.
+func (mr *MockDropletsServiceMockRecorder) Actions(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Actions", reflect.TypeOf((*MockDropletsService)(nil).Actions), arg0, arg1, arg2)
+}
+
+// Backups mocks base method.
+func (m *MockDropletsService) Backups(arg0 context.Context, arg1 int, arg2 *godo.ListOptions) ([]godo.Image, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Backups", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]godo.Image)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Backups indicates an expected call of Backups.
+func (mr *MockDropletsServiceMockRecorder) Backups(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Backups", reflect.TypeOf((*MockDropletsService)(nil).Backups), arg0, arg1, arg2)
+}
+
+// Create mocks base method.
+func (m *MockDropletsService) Create(arg0 context.Context, arg1 *godo.DropletCreateRequest) (*godo.Droplet, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*godo.Droplet)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDropletsServiceMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDropletsService)(nil).Create), arg0, arg1)
+}
+
+// CreateMultiple mocks base method.
+func (m *MockDropletsService) CreateMultiple(arg0 context.Context, arg1 *godo.DropletMultiCreateRequest) ([]godo.Droplet, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMultiple", arg0, arg1)
+	ret0, _ := ret[0].([]godo.Droplet)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateMultiple indicates an expected call of CreateMultiple.
+func (mr *MockDropletsServiceMockRecorder) CreateMultiple(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMultiple", reflect.TypeOf((*MockDropletsService)(nil).CreateMultiple), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockDropletsService) Delete(arg0 context.Context, arg1 int) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDropletsServiceMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDropletsService)(nil).Delete), arg0, arg1)
+}
+
+// DeleteByTag mocks base method.
+func (m *MockDropletsService) DeleteByTag(arg0 context.Context, arg1 string) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByTag", arg0, arg1)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByTag indicates an expected call of DeleteByTag.
+func (mr *MockDropletsServiceMockRecorder) DeleteByTag(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByTag", reflect.TypeOf((*MockDropletsService)(nil).DeleteByTag), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockDropletsService) Get(arg0 context.Context, arg1 int) (*godo.Droplet, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*godo.Droplet)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockDropletsServiceMockRecorder) Get(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDropletsService)(nil).Get), arg0, arg1)
+}
+
+// GetBackupPolicy mocks base method.
+func (m *MockDropletsService) GetBackupPolicy(arg0 context.Context, arg1 int) (*godo.DropletBackupPolicy, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBackupPolicy", arg0, arg1)
+	ret0, _ := ret[0].(*godo.DropletBackupPolicy)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetBackupPolicy indicates an expected call of GetBackupPolicy.
+func (mr *MockDropletsServiceMockRecorder) GetBackupPolicy(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBackupPolicy", reflect.TypeOf((*MockDropletsService)(nil).GetBackupPolicy), arg0, arg1)
+}
+
+// Kernels mocks base method.
+func (m *MockDropletsService) Kernels(arg0 context.Context, arg1 int, arg2 *godo.ListOptions) ([]godo.Kernel, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Kernels", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]godo.Kernel)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Kernels indicates an expected call of Kernels.
+func (mr *MockDropletsServiceMockRecorder) Kernels(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Kernels", reflect.TypeOf((*MockDropletsService)(nil).Kernels), arg0, arg1, arg2)
+}
+
+// List mocks base method.
+func (m *MockDropletsService) List(arg0 context.Context, arg1 *godo.ListOptions) ([]godo.Droplet, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].([]godo.Droplet)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockDropletsServiceMockRecorder) List(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDropletsService)(nil).List), arg0, arg1)
+}
+
+// ListAssociatedResourcesForDeletion mocks base method.
+func (m *MockDropletsService) ListAssociatedResourcesForDeletion(arg0 context.Context, arg1 int) (*godo.DropletAssociatedResources, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAssociatedResourcesForDeletion", arg0, arg1)
+	ret0, _ := ret[0].(*godo.DropletAssociatedResources)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListAssociatedResourcesForDeletion indicates an expected call of ListAssociatedResourcesForDeletion.
+func (mr *MockDropletsServiceMockRecorder) ListAssociatedResourcesForDeletion(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAssociatedResourcesForDeletion", reflect.TypeOf((*MockDropletsService)(nil).ListAssociatedResourcesForDeletion), arg0, arg1)
+}
+
+// ListBackupPolicies mocks base method.
+func (m *MockDropletsService) ListBackupPolicies(arg0 context.Context, arg1 *godo.ListOptions) (map[int]*godo.DropletBackupPolicy, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBackupPolicies", arg0, arg1)
+	ret0, _ := ret[0].(map[int]*godo.DropletBackupPolicy)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListBackupPolicies indicates an expected call of ListBackupPolicies.
+func (mr *MockDropletsServiceMockRecorder) ListBackupPolicies(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBackupPolicies", reflect.TypeOf((*MockDropletsService)(nil).ListBackupPolicies), arg0, arg1)
+}
+
+// ListByName mocks base method.
+func (m *MockDropletsService) ListByName(arg0 context.Context, arg1 string, arg2 *godo.ListOptions) ([]godo.Droplet, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByName", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]godo.Droplet)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListByName indicates an expected call of ListByName.
+func (mr *MockDropletsServiceMockRecorder) ListByName(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByName", reflect.TypeOf((*MockDropletsService)(nil).ListByName), arg0, arg1, arg2)
+}
+
+// ListByTag mocks base method.
+func (m *MockDropletsService) ListByTag(arg0 context.Context, arg1 string, arg2 *godo.ListOptions) ([]godo.Droplet, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByTag", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]godo.Droplet)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListByTag indicates an expected call of ListByTag.
+func (mr *MockDropletsServiceMockRecorder) ListByTag(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByTag", reflect.TypeOf((*MockDropletsService)(nil).ListByTag), arg0, arg1, arg2)
+}
+
+// ListSupportedBackupPolicies mocks base method.
+func (m *MockDropletsService) ListSupportedBackupPolicies(arg0 context.Context) ([]*godo.SupportedBackupPolicy, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSupportedBackupPolicies", arg0)
+	ret0, _ := ret[0].([]*godo.SupportedBackupPolicy)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListSupportedBackupPolicies indicates an expected call of ListSupportedBackupPolicies.
+func (mr *MockDropletsServiceMockRecorder) ListSupportedBackupPolicies(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSupportedBackupPolicies", reflect.TypeOf((*MockDropletsService)(nil).ListSupportedBackupPolicies), arg0)
+}
+
+// ListWithGPUs mocks base method.
+func (m *MockDropletsService) ListWithGPUs(arg0 context.Context, arg1 *godo.ListOptions) ([]godo.Droplet, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWithGPUs", arg0, arg1)
+	ret0, _ := ret[0].([]godo.Droplet)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListWithGPUs indicates an expected call of ListWithGPUs.
+func (mr *MockDropletsServiceMockRecorder) ListWithGPUs(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWithGPUs", reflect.TypeOf((*MockDropletsService)(nil).ListWithGPUs), arg0, arg1)
+}
+
+// Neighbors mocks base method.
+func (m *MockDropletsService) Neighbors(arg0 context.Context, arg1 int) ([]godo.Droplet, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Neighbors", arg0, arg1)
+	ret0, _ := ret[0].([]godo.Droplet)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Neighbors indicates an expected call of Neighbors.
+func (mr *MockDropletsServiceMockRecorder) Neighbors(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Neighbors", reflect.TypeOf((*MockDropletsService)(nil).Neighbors), arg0, arg1)
+}
+
+// Snapshots mocks base method.
+func (m *MockDropletsService) Snapshots(arg0 context.Context, arg1 int, arg2 *godo.ListOptions) ([]godo.Image, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Snapshots", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]godo.Image)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Snapshots indicates an expected call of Snapshots.
+func (mr *MockDropletsServiceMockRecorder) Snapshots(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshots", reflect.TypeOf((*MockDropletsService)(nil).Snapshots), arg0, arg1, arg2)
+}
+
 // MockInvoicesService is a mock of InvoicesService interface.
 type MockInvoicesService struct {
 	ctrl     *gomock.Controller
-	recorder *MockInvoicesServiceMockRecorder
+	recorder *MockInvoicesServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockInvoicesServiceMockRecorder is the mock recorder for MockInvoicesService.
+type MockInvoicesServiceMockRecorder struct {
+	mock *MockInvoicesService
+}
+
+// NewMockInvoicesService creates a new mock instance.
+func NewMockInvoicesService(ctrl *gomock.Controller) *MockInvoicesService {
+	mock := &MockInvoicesService{ctrl: ctrl}
+	mock.recorder = &MockInvoicesServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvoicesService) EXPECT() *MockInvoicesServiceMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockInvoicesService) Get(arg0 context.Context, arg1 string, arg2 *godo.ListOptions) (*godo.Invoice, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.Invoice)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockInvoicesServiceMockRecorder) Get(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockInvoicesService)(nil).Get), arg0, arg1, arg2)
+}
+
+// GetCSV mocks base method.
+func (m *MockInvoicesService) GetCSV(arg0 context.Context, arg1 string) ([]byte, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCSV", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetCSV indicates an expected call of GetCSV.
+func (mr *MockInvoicesServiceMockRecorder) GetCSV(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCSV", reflect.TypeOf((*MockInvoicesService)(nil).GetCSV), arg0, arg1)
+}
+
+// GetPDF mocks base method.
+func (m *MockInvoicesService) GetPDF(arg0 context.Context, arg1 string) ([]byte, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPDF", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPDF indicates an expected call of GetPDF.
+func (mr *MockInvoicesServiceMockRecorder) GetPDF(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPDF", reflect.TypeOf((*MockInvoicesService)(nil).GetPDF), arg0, arg1)
+}
+
+// GetSummary mocks base method.
+func (m *MockInvoicesService) GetSummary(arg0 context.Context, arg1 string) (*godo.InvoiceSummary, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSummary", arg0, arg1)
+	ret0, _ := ret[0].(*godo.InvoiceSummary)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSummary indicates an expected call of GetSummary.
+func (mr *MockInvoicesServiceMockRecorder) GetSummary(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSummary", reflect.TypeOf((*MockInvoicesService)(nil).GetSummary), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockInvoicesService) List(arg0 context.Context, arg1 *godo.ListOptions) (*godo.InvoiceList, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].(*godo.InvoiceList)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockInvoicesServiceMockRecorder) List(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockInvoicesService)(nil).List), arg0, arg1)
+}
+
+// MockKeysService is a mock of KeysService interface.
+type MockKeysService struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeysServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockKeysServiceMockRecorder is the mock recorder for MockKeysService.
+type MockKeysServiceMockRecorder struct {
+	mock *MockKeysService
+}
+
+// NewMockKeysService creates a new mock instance.
+func NewMockKeysService(ctrl *gomock.Controller) *MockKeysService {
+	mock := &MockKeysService{ctrl: ctrl}
+	mock.recorder = &MockKeysServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeysService) EXPECT() *MockKeysServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockKeysService) Create(arg0 context.Context, arg1 *godo.KeyCreateRequest) (*godo.Key, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*godo.Key)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockKeysServiceMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockKeysService)(nil).Create), arg0, arg1)
+}
+
+// DeleteByFingerprint mocks base method.
+func (m *MockKeysService) DeleteByFingerprint(arg0 context.Context, arg1 string) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByFingerprint", arg0, arg1)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByFingerprint indicates an expected call of DeleteByFingerprint.
+func (mr *MockKeysServiceMockRecorder) DeleteByFingerprint(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByFingerprint", reflect.TypeOf((*MockKeysService)(nil).DeleteByFingerprint), arg0, arg1)
+}
+
+// DeleteByID mocks base method.
+func (m *MockKeysService) DeleteByID(arg0 context.Context, arg1 int) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", arg0, arg1)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockKeysServiceMockRecorder) DeleteByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockKeysService)(nil).DeleteByID), arg0, arg1)
+}
+
+// GetByFingerprint mocks base method.
+func (m *MockKeysService) GetByFingerprint(arg0 context.Context, arg1 string) (*godo.Key, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByFingerprint", arg0, arg1)
+	ret0, _ := ret[0].(*godo.Key)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByFingerprint indicates an expected call of GetByFingerprint.
+func (mr *MockKeysServiceMockRecorder) GetByFingerprint(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByFingerprint", reflect.TypeOf((*MockKeysService)(nil).GetByFingerprint), arg0, arg1)
+}
+
+// GetByID mocks base method.
+func (m *MockKeysService) GetByID(arg0 context.Context, arg1 int) (*godo.Key, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", arg0, arg1)
+	ret0, _ := ret[0].(*godo.Key)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockKeysServiceMockRecorder) GetByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockKeysService)(nil).GetByID), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockKeysService) List(arg0 context.Context, arg1 *godo.ListOptions) ([]godo.Key, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].([]godo.Key)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockKeysServiceMockRecorder) List(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockKeysService)(nil).List), arg0, arg1)
+}
+
+// UpdateByFingerprint mocks base method.
+func (m *MockKeysService) UpdateByFingerprint(arg0 context.Context, arg1 string, arg2 *godo.KeyUpdateRequest) (*godo.Key, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateByFingerprint", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.Key)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateByFingerprint indicates an expected call of UpdateByFingerprint.
+func (mr *MockKeysServiceMockRecorder) UpdateByFingerprint(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateByFingerprint", reflect.TypeOf((*MockKeysService)(nil).UpdateByFingerprint), arg0, arg1, arg2)
+}
+
+// UpdateByID mocks base method.
+func (m *MockKeysService) UpdateByID(arg0 context.Context, arg1 int, arg2 *godo.KeyUpdateRequest) (*godo.Key, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateByID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.Key)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateByID indicates an expected call of UpdateByID.
+func (mr *MockKeysServiceMockRecorder) UpdateByID(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateByID", reflect.TypeOf((*MockKeysService)(nil).UpdateByID), arg0, arg1, arg2)
+}
+
+// MockKubernetesService is a mock of KubernetesService interface.
+type MockKubernetesService struct {
+	ctrl     *gomock.Controller
+	recorder *MockKubernetesServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockKubernetesServiceMockRecorder is the mock recorder for MockKubernetesService.
+type MockKubernetesServiceMockRecorder struct {
+	mock *MockKubernetesService
+}
+
+// NewMockKubernetesService creates a new mock instance.
+func NewMockKubernetesService(ctrl *gomock.Controller) *MockKubernetesService {
+	mock := &MockKubernetesService{ctrl: ctrl}
+	mock.recorder = &MockKubernetesServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKubernetesService) EXPECT() *MockKubernetesServiceMockRecorder {
+	return m.recorder
+}
+
+// AddRegistry mocks base method.
+func (m *MockKubernetesService) AddRegistry(ctx context.Context, req *godo.KubernetesClusterRegistryRequest) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRegistry", ctx, req)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddRegistry indicates an expected call of AddRegistry.
+func (mr *MockKubernetesServiceMockRecorder) AddRegistry(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRegistry", reflect.TypeOf((*MockKubernetesService)(nil).AddRegistry), ctx, req)
+}
+
+// Create mocks base method.
+func (m *MockKubernetesService) Create(arg0 context.Context, arg1 *godo.KubernetesClusterCreateRequest) (*godo.KubernetesCluster, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*godo.KubernetesCluster)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockKubernetesServiceMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockKubernetesService)(nil).Create), arg0, arg1)
+}
+
+// CreateNodePool mocks base method.
+func (m *MockKubernetesService) CreateNodePool(ctx context.Context, clusterID string, req *godo.KubernetesNodePoolCreateRequest) (*godo.KubernetesNodePool, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNodePool", ctx, clusterID, req)
+	ret0, _ := ret[0].(*godo.KubernetesNodePool)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateNodePool indicates an expected call of CreateNodePool.
+func (mr *MockKubernetesServiceMockRecorder) CreateNodePool(ctx, clusterID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNodePool", reflect.TypeOf((*MockKubernetesService)(nil).CreateNodePool), ctx, clusterID, req)
+}
+
+// Delete mocks base method.
+func (m *MockKubernetesService) Delete(arg0 context.Context, arg1 string) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockKubernetesServiceMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockKubernetesService)(nil).Delete), arg0, arg1)
+}
+
+// DeleteDangerous mocks base method.
+func (m *MockKubernetesService) DeleteDangerous(arg0 context.Context, arg1 string) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDangerous", arg0, arg1)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteDangerous indicates an expected call of DeleteDangerous.
+func (mr *MockKubernetesServiceMockRecorder) DeleteDangerous(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDangerous", reflect.TypeOf((*MockKubernetesService)(nil).DeleteDangerous), arg0, arg1)
+}
+
+// DeleteNode mocks base method.
+func (m *MockKubernetesService) DeleteNode(ctx context.Context, clusterID, poolID, nodeID string, req *godo.KubernetesNodeDeleteRequest) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNode", ctx, clusterID, poolID, nodeID, req)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteNode indicates an expected call of DeleteNode.
+func (mr *MockKubernetesServiceMockRecorder) DeleteNode(ctx, clusterID, poolID, nodeID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNode", reflect.TypeOf((*MockKubernetesService)(nil).DeleteNode), ctx, clusterID, poolID, nodeID, req)
+}
+
+// DeleteNodePool mocks base method.
+func (m *MockKubernetesService) DeleteNodePool(ctx context.Context, clusterID, poolID string) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNodePool", ctx, clusterID, poolID)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteNodePool indicates an expected call of DeleteNodePool.
+func (mr *MockKubernetesServiceMockRecorder) DeleteNodePool(ctx, clusterID, poolID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNodePool", reflect.TypeOf((*MockKubernetesService)(nil).DeleteNodePool), ctx, clusterID, poolID)
+}
+
+// DeleteSelective mocks base method.
+func (m *MockKubernetesService) DeleteSelective(arg0 context.Context, arg1 string, arg2 *godo.KubernetesClusterDeleteSelectiveRequest) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSelective", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSelective indicates an expected call of DeleteSelective.
+func (mr *MockKubernetesServiceMockRecorder) DeleteSelective(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSelective", reflect.TypeOf((*MockKubernetesService)(nil).DeleteSelective), arg0, arg1, arg2)
+}
+
+// Get mocks base method.
+func (m *MockKubernetesService) Get(arg0 context.Context, arg1 string) (*godo.KubernetesCluster, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*godo.KubernetesCluster)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockKubernetesServiceMockRecorder) Get(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockKubernetesService)(nil).Get), arg0, arg1)
+}
+
+// GetClusterStatusMessages mocks base method.
+func (m *MockKubernetesService) GetClusterStatusMessages(ctx context.Context, clusterID string, req *godo.KubernetesGetClusterStatusMessagesRequest) ([]*godo.KubernetesClusterStatusMessage, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClusterStatusMessages", ctx, clusterID, req)
+	ret0, _ := ret[0].([]*godo.KubernetesClusterStatusMessage)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetClusterStatusMessages indicates an expected call of GetClusterStatusMessages.
+func (mr *MockKubernetesServiceMockRecorder) GetClusterStatusMessages(ctx, clusterID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClusterStatusMessages", reflect.TypeOf((*MockKubernetesService)(nil).GetClusterStatusMessages), ctx, clusterID, req)
+}
+
+// GetClusterlintResults mocks base method.
+func (m *MockKubernetesService) GetClusterlintResults(ctx context.Context, clusterID string, req *godo.KubernetesGetClusterlintRequest) ([]*godo.ClusterlintDiagnostic, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClusterlintResults", ctx, clusterID, req)
+	ret0, _ := ret[0].([]*godo.ClusterlintDiagnostic)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetClusterlintResults indicates an expected call of GetClusterlintResults.
+func (mr *MockKubernetesServiceMockRecorder) GetClusterlintResults(ctx, clusterID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClusterlintResults", reflect.TypeOf((*MockKubernetesService)(nil).GetClusterlintResults), ctx, clusterID, req)
+}
+
+// GetCredentials mocks base method.
+func (m *MockKubernetesService) GetCredentials(arg0 context.Context, arg1 string, arg2 *godo.KubernetesClusterCredentialsGetRequest) (*godo.KubernetesClusterCredentials, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCredentials", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.KubernetesClusterCredentials)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetCredentials indicates an expected call of GetCredentials.
+func (mr *MockKubernetesServiceMockRecorder) GetCredentials(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCredentials", reflect.TypeOf((*MockKubernetesService)(nil).GetCredentials), arg0, arg1, arg2)
+}
+
+// GetKubeConfig mocks base method.
+func (m *MockKubernetesService) GetKubeConfig(arg0 context.Context, arg1 string, arg2 *godo.KubernetesClusterKubeconfigGetRequest) (*godo.KubernetesClusterConfig, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKubeConfig", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.KubernetesClusterConfig)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetKubeConfig indicates an expected call of GetKubeConfig.
+func (mr *MockKubernetesServiceMockRecorder) GetKubeConfig(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKubeConfig", reflect.TypeOf((*MockKubernetesService)(nil).GetKubeConfig), arg0, arg1, arg2)
+}
+
+// GetKubeConfigWithExpiry mocks base method.
+func (m *MockKubernetesService) GetKubeConfigWithExpiry(arg0 context.Context, arg1 string, arg2 int64) (*godo.KubernetesClusterConfig, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKubeConfigWithExpiry", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.KubernetesClusterConfig)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetKubeConfigWithExpiry indicates an expected call of GetKubeConfigWithExpiry.
+func (mr *MockKubernetesServiceMockRecorder) GetKubeConfigWithExpiry(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKubeConfigWithExpiry", reflect.TypeOf((*MockKubernetesService)(nil).GetKubeConfigWithExpiry), arg0, arg1, arg2)
+}
+
+// GetNodePool mocks base method.
+func (m *MockKubernetesService) GetNodePool(ctx context.Context, clusterID, poolID string) (*godo.KubernetesNodePool, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNodePool", ctx, clusterID, poolID)
+	ret0, _ := ret[0].(*godo.KubernetesNodePool)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetNodePool indicates an expected call of GetNodePool.
+func (mr *MockKubernetesServiceMockRecorder) GetNodePool(ctx, clusterID, poolID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodePool", reflect.TypeOf((*MockKubernetesService)(nil).GetNodePool), ctx, clusterID, poolID)
+}
+
+// GetNodePoolTemplate mocks base method.
+func (m *MockKubernetesService) GetNodePoolTemplate(ctx context.Context, clusterID, nodePoolName string) (*godo.KubernetesNodePoolTemplate, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNodePoolTemplate", ctx, clusterID, nodePoolName)
+	ret0, _ := ret[0].(*godo.KubernetesNodePoolTemplate)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetNodePoolTemplate indicates an expected call of GetNodePoolTemplate.
+func (mr *MockKubernetesServiceMockRecorder) GetNodePoolTemplate(ctx, clusterID, nodePoolName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodePoolTemplate", reflect.TypeOf((*MockKubernetesService)(nil).GetNodePoolTemplate), ctx, clusterID, nodePoolName)
+}
+
+// GetOptions mocks base method.
+func (m *MockKubernetesService) GetOptions(arg0 context.Context) (*godo.KubernetesOptions, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOptions", arg0)
+	ret0, _ := ret[0].(*godo.KubernetesOptions)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetOptions indicates an expected call of GetOptions.
+func (mr *MockKubernetesServiceMockRecorder) GetOptions(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOptions", reflect.TypeOf((*MockKubernetesService)(nil).GetOptions), arg0)
+}
+
+// GetUpgrades mocks base method.
+func (m *MockKubernetesService) GetUpgrades(arg0 context.Context, arg1 string) ([]*godo.KubernetesVersion, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpgrades", arg0, arg1)
+	ret0, _ := ret[0].([]*godo.KubernetesVersion)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUpgrades indicates an expected call of GetUpgrades.
+func (mr *MockKubernetesServiceMockRecorder) GetUpgrades(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpgrades", reflect.TypeOf((*MockKubernetesService)(nil).GetUpgrades), arg0, arg1)
+}
+
+// GetUser mocks base method.
+func (m *MockKubernetesService) GetUser(arg0 context.Context, arg1 string) (*godo.KubernetesClusterUser, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
+	ret0, _ := ret[0].(*godo.KubernetesClusterUser)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockKubernetesServiceMockRecorder) GetUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockKubernetesService)(nil).GetUser), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockKubernetesService) List(arg0 context.Context, arg1 *godo.ListOptions) ([]*godo.KubernetesCluster, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].([]*godo.KubernetesCluster)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockKubernetesServiceMockRecorder) List(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockKubernetesService)(nil).List), arg0, arg1)
+}
+
+// ListAssociatedResourcesForDeletion mocks base method.
+func (m *MockKubernetesService) ListAssociatedResourcesForDeletion(arg0 context.Context, arg1 string) (*godo.KubernetesAssociatedResources, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAssociatedResourcesForDeletion", arg0, arg1)
+	ret0, _ := ret[0].(*godo.KubernetesAssociatedResources)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListAssociatedResourcesForDeletion indicates an expected call of ListAssociatedResourcesForDeletion.
+func (mr *MockKubernetesServiceMockRecorder) ListAssociatedResourcesForDeletion(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAssociatedResourcesForDeletion", reflect.TypeOf((*MockKubernetesService)(nil).ListAssociatedResourcesForDeletion), arg0, arg1)
+}
+
+// ListNodePools mocks base method.
+func (m *MockKubernetesService) ListNodePools(ctx context.Context, clusterID string, opts *godo.ListOptions) ([]*godo.KubernetesNodePool, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNodePools", ctx, clusterID, opts)
+	ret0, _ := ret[0].([]*godo.KubernetesNodePool)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListNodePools indicates an expected call of ListNodePools.
+func (mr *MockKubernetesServiceMockRecorder) ListNodePools(ctx, clusterID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNodePools", reflect.TypeOf((*MockKubernetesService)(nil).ListNodePools), ctx, clusterID, opts)
+}
+
+// RecycleNodePoolNodes mocks base method.
+func (m *MockKubernetesService) RecycleNodePoolNodes(ctx context.Context, clusterID, poolID string, req *godo.KubernetesNodePoolRecycleNodesRequest) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecycleNodePoolNodes", ctx, clusterID, poolID, req)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecycleNodePoolNodes indicates an expected call of RecycleNodePoolNodes.
+func (mr *MockKubernetesServiceMockRecorder) RecycleNodePoolNodes(ctx, clusterID, poolID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecycleNodePoolNodes", reflect.TypeOf((*MockKubernetesService)(nil).RecycleNodePoolNodes), ctx, clusterID, poolID, req)
+}
+
+// RemoveRegistry mocks base method.
+func (m *MockKubernetesService) RemoveRegistry(ctx context.Context, req *godo.KubernetesClusterRegistryRequest) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveRegistry", ctx, req)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveRegistry indicates an expected call of RemoveRegistry.
+func (mr *MockKubernetesServiceMockRecorder) RemoveRegistry(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveRegistry", reflect.TypeOf((*MockKubernetesService)(nil).RemoveRegistry), ctx, req)
+}
+
+// RunClusterlint mocks base method.
+func (m *MockKubernetesService) RunClusterlint(ctx context.Context, clusterID string, req *godo.KubernetesRunClusterlintRequest) (string, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunClusterlint", ctx, clusterID, req)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RunClusterlint indicates an expected call of RunClusterlint.
+func (mr *MockKubernetesServiceMockRecorder) RunClusterlint(ctx, clusterID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunClusterlint", reflect.TypeOf((*MockKubernetesService)(nil).RunClusterlint), ctx, clusterID, req)
+}
+
+// Update mocks base method.
+func (m *MockKubernetesService) Update(arg0 context.Context, arg1 string, arg2 *godo.KubernetesClusterUpdateRequest) (*godo.KubernetesCluster, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.KubernetesCluster)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockKubernetesServiceMockRecorder) Update(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockKubernetesService)(nil).Update), arg0, arg1, arg2)
+}
+
+// UpdateNodePool mocks base method.
+func (m *MockKubernetesService) UpdateNodePool(ctx context.Context, clusterID, poolID string, req *godo.KubernetesNodePoolUpdateRequest) (*godo.KubernetesNodePool, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNodePool", ctx, clusterID, poolID, req)
+	ret0, _ := ret[0].(*godo.KubernetesNodePool)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateNodePool indicates an expected call of UpdateNodePool.
+func (mr *MockKubernetesServiceMockRecorder) UpdateNodePool(ctx, clusterID, poolID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNodePool", reflect.TypeOf((*MockKubernetesService)(nil).UpdateNodePool), ctx, clusterID, poolID, req)
+}
+
+// Upgrade mocks base method.
+func (m *MockKubernetesService) Upgrade(arg0 context.Context, arg1 string, arg2 *godo.KubernetesClusterUpgradeRequest) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upgrade", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upgrade indicates an expected call of Upgrade.
+func (mr *MockKubernetesServiceMockRecorder) Upgrade(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upgrade", reflect.TypeOf((*MockKubernetesService)(nil).Upgrade), arg0, arg1, arg2)
+}
+
+// MockLoadBalancersService is a mock of LoadBalancersService interface.
+type MockLoadBalancersService struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoadBalancersServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockLoadBalancersServiceMockRecorder is the mock recorder for MockLoadBalancersService.
+type MockLoadBalancersServiceMockRecorder struct {
+	mock *MockLoadBalancersService
+}
+
+// NewMockLoadBalancersService creates a new mock instance.
+func NewMockLoadBalancersService(ctrl *gomock.Controller) *MockLoadBalancersService {
+	mock := &MockLoadBalancersService{ctrl: ctrl}
+	mock.recorder = &MockLoadBalancersServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoadBalancersService) EXPECT() *MockLoadBalancersServiceMockRecorder {
+	return m.recorder
+}
+
+// AddDroplets mocks base method.
+func (m *MockLoadBalancersService) AddDroplets(ctx context.Context, lbID string, dropletIDs ...int) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, lbID}
+	for _, a := range dropletIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddDroplets", varargs...)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddDroplets indicates an expected call of AddDroplets.
+func (mr *MockLoadBalancersServiceMockRecorder) AddDroplets(ctx, lbID any, dropletIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, lbID}, dropletIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDroplets", reflect.TypeOf((*MockLoadBalancersService)(nil).AddDroplets), varargs...)
+}
+
+// AddForwardingRules mocks base method.
+func (m *MockLoadBalancersService) AddForwardingRules(ctx context.Context, lbID string, rules ...godo.ForwardingRule) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, lbID}
+	for _, a := range rules {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddForwardingRules", varargs...)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddForwardingRules indicates an expected call of AddForwardingRules.
+func (mr *MockLoadBalancersServiceMockRecorder) AddForwardingRules(ctx, lbID any, rules ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, lbID}, rules...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddForwardingRules", reflect.TypeOf((*MockLoadBalancersService)(nil).AddForwardingRules), varargs...)
+}
+
+// Create mocks base method.
+func (m *MockLoadBalancersService) Create(arg0 context.Context, arg1 *godo.LoadBalancerRequest) (*godo.LoadBalancer, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*godo.LoadBalancer)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLoadBalancersServiceMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLoadBalancersService)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockLoadBalancersService) Delete(ctx context.Context, lbID string) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, lbID)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockLoadBalancersServiceMockRecorder) Delete(ctx, lbID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockLoadBalancersService)(nil).Delete), ctx, lbID)
+}
+
+// Get mocks base method.
+func (m *MockLoadBalancersService) Get(arg0 context.Context, arg1 string) (*godo.LoadBalancer, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*godo.LoadBalancer)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockLoadBalancersServiceMockRecorder) Get(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockLoadBalancersService)(nil).Get), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockLoadBalancersService) List(arg0 context.Context, arg1 *godo.ListOptions) ([]godo.LoadBalancer, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].([]godo.LoadBalancer)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockLoadBalancersServiceMockRecorder) List(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockLoadBalancersService)(nil).List), arg0, arg1)
+}
+
+// ListByNames mocks base method.
+func (m *MockLoadBalancersService) ListByNames(arg0 context.Context, arg1 []string, arg2 *godo.ListOptions) ([]godo.LoadBalancer, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByNames", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]godo.LoadBalancer)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListByNames indicates an expected call of ListByNames.
+func (mr *MockLoadBalancersServiceMockRecorder) ListByNames(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByNames", reflect.TypeOf((*MockLoadBalancersService)(nil).ListByNames), arg0, arg1, arg2)
+}
+
+// ListByUUIDs mocks base method.
+func (m *MockLoadBalancersService) ListByUUIDs(arg0 context.Context, arg1 []string, arg2 *godo.ListOptions) ([]godo.LoadBalancer, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUUIDs", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]godo.LoadBalancer)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListByUUIDs indicates an expected call of ListByUUIDs.
+func (mr *MockLoadBalancersServiceMockRecorder) ListByUUIDs(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUUIDs", reflect.TypeOf((*MockLoadBalancersService)(nil).ListByUUIDs), arg0, arg1, arg2)
+}
+
+// PurgeCache mocks base method.
+func (m *MockLoadBalancersService) PurgeCache(ctx context.Context, lbID string) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeCache", ctx, lbID)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeCache indicates an expected call of PurgeCache.
+func (mr *MockLoadBalancersServiceMockRecorder) PurgeCache(ctx, lbID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeCache", reflect.TypeOf((*MockLoadBalancersService)(nil).PurgeCache), ctx, lbID)
+}
+
+// RemoveDroplets mocks base method.
+func (m *MockLoadBalancersService) RemoveDroplets(ctx context.Context, lbID string, dropletIDs ...int) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, lbID}
+	for _, a := range dropletIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveDroplets", varargs...)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveDroplets indicates an expected call of RemoveDroplets.
+func (mr *MockLoadBalancersServiceMockRecorder) RemoveDroplets(ctx, lbID any, dropletIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, lbID}, dropletIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDroplets", reflect.TypeOf((*MockLoadBalancersService)(nil).RemoveDroplets), varargs...)
+}
+
+// RemoveForwardingRules mocks base method.
+func (m *MockLoadBalancersService) RemoveForwardingRules(ctx context.Context, lbID string, rules ...godo.ForwardingRule) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, lbID}
+	for _, a := range rules {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveForwardingRules", varargs...)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveForwardingRules indicates an expected call of RemoveForwardingRules.
+func (mr *MockLoadBalancersServiceMockRecorder) RemoveForwardingRules(ctx, lbID any, rules ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, lbID}, rules...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveForwardingRules", reflect.TypeOf((*MockLoadBalancersService)(nil).RemoveForwardingRules), varargs...)
+}
+
+// Update mocks base method.
+func (m *MockLoadBalancersService) Update(ctx context.Context, lbID string, lbr *godo.LoadBalancerRequest) (*godo.LoadBalancer, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, lbID, lbr)
+	ret0, _ := ret[0].(*godo.LoadBalancer)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockLoadBalancersServiceMockRecorder) Update(ctx, lbID, lbr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockLoadBalancersService)(nil).Update), ctx, lbID, lbr)
+}
+
+// MockSizesService is a mock of SizesService interface.
+type MockSizesService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSizesServiceMockRecorder
 	isgomock struct{}
 }
 
-// MockInvoicesServiceMockRecorder is the mock recorder for MockInvoicesService.
-type MockInvoicesServiceMockRecorder struct {
-	mock *MockInvoicesService
+// MockSizesServiceMockRecorder is the mock recorder for MockSizesService.
+type MockSizesServiceMockRecorder struct {
+	mock *MockSizesService
 }
 
-// NewMockInvoicesService creates a new mock instance.
-func NewMockInvoicesService(ctrl *gomock.Controller) *MockInvoicesService {
-	mock := &MockInvoicesService{ctrl: ctrl}
-	mock.recorder = &MockInvoicesServiceMockRecorder{mock}
+// NewMockSizesService creates a new mock instance.
+func NewMockSizesService(ctrl *gomock.Controller) *MockSizesService {
+	mock := &MockSizesService{ctrl: ctrl}
+	mock.recorder = &MockSizesServiceMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockInvoicesService) EXPECT() *MockInvoicesServiceMockRecorder {
+func (m *MockSizesService) EXPECT() *MockSizesServiceMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockSizesService) List(arg0 context.Context, arg1 *godo.ListOptions) ([]godo.Size, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].([]godo.Size)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockSizesServiceMockRecorder) List(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSizesService)(nil).List), arg0, arg1)
+}
+
+// MockSnapshotsService is a mock of SnapshotsService interface.
+type MockSnapshotsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSnapshotsServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockSnapshotsServiceMockRecorder is the mock recorder for MockSnapshotsService.
+type MockSnapshotsServiceMockRecorder struct {
+	mock *MockSnapshotsService
+}
+
+// NewMockSnapshotsService creates a new mock instance.
+func NewMockSnapshotsService(ctrl *gomock.Controller) *MockSnapshotsService {
+	mock := &MockSnapshotsService{ctrl: ctrl}
+	mock.recorder = &MockSnapshotsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSnapshotsService) EXPECT() *MockSnapshotsServiceMockRecorder {
 	return m.recorder
 }
 
+// Delete mocks base method.
+func (m *MockSnapshotsService) Delete(arg0 context.Context, arg1 string) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSnapshotsServiceMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSnapshotsService)(nil).Delete), arg0, arg1)
+}
+
 // Get mocks base method.
-func (m *MockInvoicesService) Get(arg0 context.Context, arg1 string, arg2 *godo.ListOptions) (*godo.Invoice, *godo.Response, error) {
+func (m *MockSnapshotsService) Get(arg0 context.Context, arg1 string) (*godo.Snapshot, *godo.Response, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2)
-	ret0, _ := ret[0].(*godo.Invoice)
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*godo.Snapshot)
 	ret1, _ := ret[1].(*godo.Response)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockInvoicesServiceMockRecorder) Get(arg0, arg1, arg2 any) *gomock.Call {
+func (mr *MockSnapshotsServiceMockRecorder) Get(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockInvoicesService)(nil).Get), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSnapshotsService)(nil).Get), arg0, arg1)
 }
 
-// GetCSV mocks base method.
-func (m *MockInvoicesService) GetCSV(arg0 context.Context, arg1 string) ([]byte, *godo.Response, error) {
+// List mocks base method.
+func (m *MockSnapshotsService) List(arg0 context.Context, arg1 *godo.ListOptions) ([]godo.Snapshot, *godo.Response, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCSV", arg0, arg1)
-	ret0, _ := ret[0].([]byte)
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].([]godo.Snapshot)
 	ret1, _ := ret[1].(*godo.Response)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
-// GetCSV indicates an expected call of GetCSV.
-func (mr *MockInvoicesServiceMockRecorder) GetCSV(arg0, arg1 any) *gomock.Call {
+// List indicates an expected call of List.
+func (mr *MockSnapshotsServiceMockRecorder) List(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCSV", reflect.TypeOf((*MockInvoicesService)(nil).GetCSV), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSnapshotsService)(nil).List), arg0, arg1)
 }
 
-// GetPDF mocks base method.
-func (m *MockInvoicesService) GetPDF(arg0 context.Context, arg1 string) ([]byte, *godo.Response, error) {
+// ListDroplet mocks base method.
+func (m *MockSnapshotsService) ListDroplet(arg0 context.Context, arg1 *godo.ListOptions) ([]godo.Snapshot, *godo.Response, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPDF", arg0, arg1)
-	ret0, _ := ret[0].([]byte)
+	ret := m.ctrl.Call(m, "ListDroplet", arg0, arg1)
+	ret0, _ := ret[0].([]godo.Snapshot)
 	ret1, _ := ret[1].(*godo.Response)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
-// GetPDF indicates an expected call of GetPDF.
-func (mr *MockInvoicesServiceMockRecorder) GetPDF(arg0, arg1 any) *gomock.Call {
+// ListDroplet indicates an expected call of ListDroplet.
+func (mr *MockSnapshotsServiceMockRecorder) ListDroplet(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPDF", reflect.TypeOf((*MockInvoicesService)(nil).GetPDF), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDroplet", reflect.TypeOf((*MockSnapshotsService)(nil).ListDroplet), arg0, arg1)
 }
 
-// GetSummary mocks base method.
-func (m *MockInvoicesService) GetSummary(arg0 context.Context, arg1 string) (*godo.InvoiceSummary, *godo.Response, error) {
+// ListVolume mocks base method.
+func (m *MockSnapshotsService) ListVolume(arg0 context.Context, arg1 *godo.ListOptions) ([]godo.Snapshot, *godo.Response, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSummary", arg0, arg1)
-	ret0, _ := ret[0].(*godo.InvoiceSummary)
+	ret := m.ctrl.Call(m, "ListVolume", arg0, arg1)
+	ret0, _ := ret[0].([]godo.Snapshot)
 	ret1, _ := ret[1].(*godo.Response)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
-// GetSummary indicates an expected call of GetSummary.
-func (mr *MockInvoicesServiceMockRecorder) GetSummary(arg0, arg1 any) *gomock.Call {
+// ListVolume indicates an expected call of ListVolume.
+func (mr *MockSnapshotsServiceMockRecorder) ListVolume(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSummary", reflect.TypeOf((*MockInvoicesService)(nil).GetSummary), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVolume", reflect.TypeOf((*MockSnapshotsService)(nil).ListVolume), arg0, arg1)
 }
 
-// List mocks base method.
-func (m *MockInvoicesService) List(arg0 context.Context, arg1 *godo.ListOptions) (*godo.InvoiceList, *godo.Response, error) {
+// ListVolumeSnapshotByRegion mocks base method.
+func (m *MockSnapshotsService) ListVolumeSnapshotByRegion(arg0 context.Context, arg1 string, arg2 *godo.ListOptions) ([]godo.Snapshot, *godo.Response, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", arg0, arg1)
-	ret0, _ := ret[0].(*godo.InvoiceList)
+	ret := m.ctrl.Call(m, "ListVolumeSnapshotByRegion", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]godo.Snapshot)
 	ret1, _ := ret[1].(*godo.Response)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
-// List indicates an expected call of List.
-func (mr *MockInvoicesServiceMockRecorder) List(arg0, arg1 any) *gomock.Call {
+// ListVolumeSnapshotByRegion indicates an expected call of ListVolumeSnapshotByRegion.
+func (mr *MockSnapshotsServiceMockRecorder) ListVolumeSnapshotByRegion(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockInvoicesService)(nil).List), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVolumeSnapshotByRegion", reflect.TypeOf((*MockSnapshotsService)(nil).ListVolumeSnapshotByRegion), arg0, arg1, arg2)
 }
 
-// MockKeysService is a mock of KeysService interface.
-type MockKeysService struct {
+// MockStorageService is a mock of StorageService interface.
+type MockStorageService struct {
 	ctrl     *gomock.Controller
-	recorder *MockKeysServiceMockRecorder
+	recorder *MockStorageServiceMockRecorder
 	isgomock struct{}
 }
 
-// MockKeysServiceMockRecorder is the mock recorder for MockKeysService.
-type MockKeysServiceMockRecorder struct {
-	mock *MockKeysService
+// MockStorageServiceMockRecorder is the mock recorder for MockStorageService.
+type MockStorageServiceMockRecorder struct {
+	mock *MockStorageService
 }
 
-// NewMockKeysService creates a new mock instance.
-func NewMockKeysService(ctrl *gomock.Controller) *MockKeysService {
-	mock := &MockKeysService{ctrl: ctrl}
-	mock.recorder = &MockKeysServiceMockRecorder{mock}
+// NewMockStorageService creates a new mock instance.
+func NewMockStorageService(ctrl *gomock.Controller) *MockStorageService {
+	mock := &MockStorageService{ctrl: ctrl}
+	mock.recorder = &MockStorageServiceMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockKeysService) EXPECT() *MockKeysServiceMockRecorder {
+func (m *MockStorageService) EXPECT() *MockStorageServiceMockRecorder {
 	return m.recorder
 }
 
-// Create mocks base method.
-func (m *MockKeysService) Create(arg0 context.Context, arg1 *godo.KeyCreateRequest) (*godo.Key, *godo.Response, error) {
+// CreateSnapshot mocks base method.
+func (m *MockStorageService) CreateSnapshot(arg0 context.Context, arg1 *godo.SnapshotCreateRequest) (*godo.Snapshot, *godo.Response, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", arg0, arg1)
-	ret0, _ := ret[0].(*godo.Key)
+	ret := m.ctrl.Call(m, "CreateSnapshot", arg0, arg1)
+	ret0, _ := ret[0].(*godo.Snapshot)
 	ret1, _ := ret[1].(*godo.Response)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
-// Create indicates an expected call of Create.
-func (mr *MockKeysServiceMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+// CreateSnapshot indicates an expected call of CreateSnapshot.
+func (mr *MockStorageServiceMockRecorder) CreateSnapshot(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockKeysService)(nil).Create), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSnapshot", reflect.TypeOf((*MockStorageService)(nil).CreateSnapshot), arg0, arg1)
 }
 
-// DeleteByFingerprint mocks base method.
-func (m *MockKeysService) DeleteByFingerprint(arg0 context.Context, arg1 string) (*godo.Response, error) {
+// CreateVolume mocks base method.
+func (m *MockStorageService) CreateVolume(arg0 context.Context, arg1 *godo.VolumeCreateRequest) (*godo.Volume, *godo.Response, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteByFingerprint", arg0, arg1)
-	ret0, _ := ret[0].(*godo.Response)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "CreateVolume", arg0, arg1)
+	ret0, _ := ret[0].(*godo.Volume)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// DeleteByFingerprint indicates an expected call of DeleteByFingerprint.
-func (mr *MockKeysServiceMockRecorder) DeleteByFingerprint(arg0, arg1 any) *gomock.Call {
+// CreateVolume indicates an expected call of CreateVolume.
+func (mr *MockStorageServiceMockRecorder) CreateVolume(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByFingerprint", reflect.TypeOf((*MockKeysService)(nil).DeleteByFingerprint), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVolume", reflect.TypeOf((*MockStorageService)(nil).CreateVolume), arg0, arg1)
 }
 
-// DeleteByID mocks base method.
-func (m *MockKeysService) DeleteByID(arg0 context.Context, arg1 int) (*godo.Response, error) {
+// DeleteSnapshot mocks base method.
+func (m *MockStorageService) DeleteSnapshot(arg0 context.Context, arg1 string) (*godo.Response, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteByID", arg0, arg1)
+	ret := m.ctrl.Call(m, "DeleteSnapshot", arg0, arg1)
 	ret0, _ := ret[0].(*godo.Response)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DeleteByID indicates an expected call of DeleteByID.
-func (mr *MockKeysServiceMockRecorder) DeleteByID(arg0, arg1 any) *gomock.Call {
+// DeleteSnapshot indicates an expected call of DeleteSnapshot.
+func (mr *MockStorageServiceMockRecorder) DeleteSnapshot(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockKeysService)(nil).DeleteByID), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSnapshot", reflect.TypeOf((*MockStorageService)(nil).DeleteSnapshot), arg0, arg1)
 }
 
-// GetByFingerprint mocks base method.
-func (m *MockKeysService) GetByFingerprint(arg0 context.Context, arg1 string) (*godo.Key, *godo.Response, error) {
+// DeleteVolume mocks base method.
+func (m *MockStorageService) DeleteVolume(arg0 context.Context, arg1 string) (*godo.Response, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByFingerprint", arg0, arg1)
-	ret0, _ := ret[0].(*godo.Key)
-	ret1, _ := ret[1].(*godo.Response)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "DeleteVolume", arg0, arg1)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// GetByFingerprint indicates an expected call of GetByFingerprint.
-func (mr *MockKeysServiceMockRecorder) GetByFingerprint(arg0, arg1 any) *gomock.Call {
+// DeleteVolume indicates an expected call of DeleteVolume.
+func (mr *MockStorageServiceMockRecorder) DeleteVolume(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByFingerprint", reflect.TypeOf((*MockKeysService)(nil).GetByFingerprint), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVolume", reflect.TypeOf((*MockStorageService)(nil).DeleteVolume), arg0, arg1)
 }
 
-// GetByID mocks base method.
-func (m *MockKeysService) GetByID(arg0 context.Context, arg1 int) (*godo.Key, *godo.Response, error) {
+// GetSnapshot mocks base method.
+func (m *MockStorageService) GetSnapshot(arg0 context.Context, arg1 string) (*godo.Snapshot, *godo.Response, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByID", arg0, arg1)
-	ret0, _ := ret[0].(*godo.Key)
+	ret := m.ctrl.Call(m, "GetSnapshot", arg0, arg1)
+	ret0, _ := ret[0].(*godo.Snapshot)
 	ret1, _ := ret[1].(*godo.Response)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
-// GetByID indicates an expected call of GetByID.
-func (mr *MockKeysServiceMockRecorder) GetByID(arg0, arg1 any) *gomock.Call {
+// GetSnapshot indicates an expected call of GetSnapshot.
+func (mr *MockStorageServiceMockRecorder) GetSnapshot(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockKeysService)(nil).GetByID), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSnapshot", reflect.TypeOf((*MockStorageService)(nil).GetSnapshot), arg0, arg1)
 }
 
-// List mocks base method.
-func (m *MockKeysService) List(arg0 context.Context, arg1 *godo.ListOptions) ([]godo.Key, *godo.Response, error) {
+// GetVolume mocks base method.
+func (m *MockStorageService) GetVolume(arg0 context.Context, arg1 string) (*godo.Volume, *godo.Response, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", arg0, arg1)
-	ret0, _ := ret[0].([]godo.Key)
+	ret := m.ctrl.Call(m, "GetVolume", arg0, arg1)
+	ret0, _ := ret[0].(*godo.Volume)
 	ret1, _ := ret[1].(*godo.Response)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
-// List indicates an expected call of List.
-func (mr *MockKeysServiceMockRecorder) List(arg0, arg1 any) *gomock.Call {
+// GetVolume indicates an expected call of GetVolume.
+func (mr *MockStorageServiceMockRecorder) GetVolume(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockKeysService)(nil).List), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVolume", reflect.TypeOf((*MockStorageService)(nil).GetVolume), arg0, arg1)
 }
 
-// UpdateByFingerprint mocks base method.
-func (m *MockKeysService) UpdateByFingerprint(arg0 context.Context, arg1 string, arg2 *godo.KeyUpdateRequest) (*godo.Key, *godo.Response, error) {
+// ListSnapshots mocks base method.
+func (m *MockStorageService) ListSnapshots(ctx context.Context, volumeID string, opts *godo.ListOptions) ([]godo.Snapshot, *godo.Response, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateByFingerprint", arg0, arg1, arg2)
-	ret0, _ := ret[0].(*godo.Key)
+	ret := m.ctrl.Call(m, "ListSnapshots", ctx, volumeID, opts)
+	ret0, _ := ret[0].([]godo.Snapshot)
 	ret1, _ := ret[1].(*godo.Response)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
-// UpdateByFingerprint indicates an expected call of UpdateByFingerprint.
-func (mr *MockKeysServiceMockRecorder) UpdateByFingerprint(arg0, arg1, arg2 any) *gomock.Call {
+// ListSnapshots indicates an expected call of ListSnapshots.
+func (mr *MockStorageServiceMockRecorder) ListSnapshots(ctx, volumeID, opts any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateByFingerprint", reflect.TypeOf((*MockKeysService)(nil).UpdateByFingerprint), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSnapshots", reflect.TypeOf((*MockStorageService)(nil).ListSnapshots), ctx, volumeID, opts)
 }
 
-// UpdateByID mocks base method.
-func (m *MockKeysService) UpdateByID(arg0 context.Context, arg1 int, arg2 *godo.KeyUpdateRequest) (*godo.Key, *godo.Response, error) {
+// ListVolumes mocks base method.
+func (m *MockStorageService) ListVolumes(arg0 context.Context, arg1 *godo.ListVolumeParams) ([]godo.Volume, *godo.Response, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateByID", arg0, arg1, arg2)
-	ret0, _ := ret[0].(*godo.Key)
+	ret := m.ctrl.Call(m, "ListVolumes", arg0, arg1)
+	ret0, _ := ret[0].([]godo.Volume)
 	ret1, _ := ret[1].(*godo.Response)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
-// UpdateByID indicates an expected call of UpdateByID.
-func (mr *MockKeysServiceMockRecorder) UpdateByID(arg0, arg1, arg2 any) *gomock.Call {
+// ListVolumes indicates an expected call of ListVolumes.
+func (mr *MockStorageServiceMockRecorder) ListVolumes(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateByID", reflect.TypeOf((*MockKeysService)(nil).UpdateByID), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVolumes", reflect.TypeOf((*MockStorageService)(nil).ListVolumes), arg0, arg1)
 }
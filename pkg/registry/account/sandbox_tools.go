@@ -0,0 +1,550 @@
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"mcp-digitalocean/internal/waiter"
+	"mcp-digitalocean/pkg/registry/common"
+)
+
+// Resource types a sandbox cleanup plan can contain.
+const (
+	sandboxTypeDroplet      = "droplet"
+	sandboxTypeVolume       = "volume"
+	sandboxTypeLoadBalancer = "load_balancer"
+	sandboxTypeKubernetes   = "kubernetes_cluster"
+	sandboxTypeSnapshot     = "snapshot"
+)
+
+// List prices used to estimate the monthly cost of resources whose API
+// objects carry no price. Droplet and DOKS node prices come from the sizes API.
+const (
+	volumePricePerGiBMonth      = 0.10
+	snapshotPricePerGiBMonth    = 0.06
+	loadBalancerPricePerNode    = 12.0
+	kubernetesHAControlPlaneUSD = 40.0
+)
+
+const sandboxListPageSize = 200
+
+const (
+	// defaultDetachPollInterval is how often a volume is polled while it
+	// detaches from a deleted droplet.
+	defaultDetachPollInterval = 5 * time.Second
+	// defaultDetachTimeout bounds how long a volume may take to detach.
+	defaultDetachTimeout = 2 * time.Minute
+)
+
+// SandboxCleanupItem is a single resource in a sandbox cleanup plan.
+type SandboxCleanupItem struct {
+	Type                    string  `json:"type"`
+	ID                      string  `json:"id"`
+	Name                    string  `json:"name"`
+	CreatedAt               string  `json:"created_at"`
+	EstimatedMonthlyCostUSD float64 `json:"estimated_monthly_cost_usd"`
+}
+
+// SandboxCleanupPlan is returned by sandbox-cleanup-plan and accepted back by
+// sandbox-cleanup-execute.
+type SandboxCleanupPlan struct {
+	Tag                          string               `json:"tag,omitempty"`
+	NamePrefix                   string               `json:"name_prefix,omitempty"`
+	OlderThanHours               float64              `json:"older_than_hours,omitempty"`
+	Items                        []SandboxCleanupItem `json:"items"`
+	TotalEstimatedMonthlyCostUSD float64              `json:"total_estimated_monthly_cost_usd"`
+}
+
+// SandboxCleanupResult reports the outcome of deleting one plan item.
+type SandboxCleanupResult struct {
+	Type   string `json:"type"`
+	ID     string `json:"id"`
+	Name   string `json:"name,omitempty"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	// Reason says why a skipped item was not deleted.
+	Reason string `json:"reason,omitempty"`
+}
+
+// SandboxCleanupReport is returned by sandbox-cleanup-execute.
+type SandboxCleanupReport struct {
+	Deleted int                    `json:"deleted"`
+	Failed  int                    `json:"failed"`
+	Skipped int                    `json:"skipped"`
+	Results []SandboxCleanupResult `json:"results"`
+}
+
+// SandboxTool finds and deletes leftover resources in test and sandbox accounts.
+type SandboxTool struct {
+	client        func(ctx context.Context) (*godo.Client, error)
+	now           func() time.Time
+	pollInterval  time.Duration
+	detachTimeout time.Duration
+}
+
+// NewSandboxTool creates a new SandboxTool
+func NewSandboxTool(client func(ctx context.Context) (*godo.Client, error)) *SandboxTool {
+	return &SandboxTool{
+		client:        client,
+		now:           time.Now,
+		pollInterval:  defaultDetachPollInterval,
+		detachTimeout: defaultDetachTimeout,
+	}
+}
+
+// sandboxFilter selects the resources a cleanup plan contains.
+type sandboxFilter struct {
+	tag        string
+	namePrefix string
+	cutoff     time.Time
+}
+
+func (f sandboxFilter) matches(name string, tags []string, created time.Time) bool {
+	if f.tag != "" && !slices.Contains(tags, f.tag) {
+		return false
+	}
+	if f.namePrefix != "" && !strings.HasPrefix(name, f.namePrefix) {
+		return false
+	}
+	// resources without a known creation time are never planned for deletion.
+	return !created.IsZero() && created.Before(f.cutoff)
+}
+
+// parseCreated parses an API creation timestamp, returning the zero time when
+// it is missing or malformed.
+func parseCreated(created string) time.Time {
+	t, _ := time.Parse(time.RFC3339, created)
+	return t
+}
+
+func (s *SandboxTool) plan(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	tag, _ := args["Tag"].(string)
+	namePrefix, _ := args["NamePrefix"].(string)
+	olderThanHours, _ := args["OlderThanHours"].(float64)
+	if tag == "" && namePrefix == "" {
+		return mcp.NewToolResultError("Tag or NamePrefix is required"), nil
+	}
+	if olderThanHours < 0 {
+		return mcp.NewToolResultError("OlderThanHours must not be negative"), nil
+	}
+
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	filter := sandboxFilter{
+		tag:        tag,
+		namePrefix: namePrefix,
+		cutoff:     s.now().Add(-time.Duration(olderThanHours * float64(time.Hour))),
+	}
+
+	plan := SandboxCleanupPlan{Tag: tag, NamePrefix: namePrefix, OlderThanHours: olderThanHours, Items: []SandboxCleanupItem{}}
+	for _, list := range []func(context.Context, *godo.Client, sandboxFilter) ([]SandboxCleanupItem, error){
+		planDroplets,
+		planVolumes,
+		planLoadBalancers,
+		planKubernetesClusters,
+		planSnapshots,
+	} {
+		items, err := list(ctx, client, filter)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("api error", err), nil
+		}
+		plan.Items = append(plan.Items, items...)
+	}
+	for _, item := range plan.Items {
+		plan.TotalEstimatedMonthlyCostUSD += item.EstimatedMonthlyCostUSD
+	}
+
+	jsonData, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+func planDroplets(ctx context.Context, client *godo.Client, filter sandboxFilter) ([]SandboxCleanupItem, error) {
+	droplets, err := common.FetchAll(ctx, sandboxListPageSize, client.Droplets.List)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list droplets: %w", err)
+	}
+	var items []SandboxCleanupItem
+	for _, d := range droplets {
+		if !filter.matches(d.Name, d.Tags, parseCreated(d.Created)) {
+			continue
+		}
+		item := SandboxCleanupItem{Type: sandboxTypeDroplet, ID: strconv.Itoa(d.ID), Name: d.Name, CreatedAt: d.Created}
+		if d.Size != nil {
+			item.EstimatedMonthlyCostUSD = d.Size.PriceMonthly
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func planVolumes(ctx context.Context, client *godo.Client, filter sandboxFilter) ([]SandboxCleanupItem, error) {
+	volumes, err := common.FetchAll(ctx, sandboxListPageSize, func(ctx context.Context, opt *godo.ListOptions) ([]godo.Volume, *godo.Response, error) {
+		return client.Storage.ListVolumes(ctx, &godo.ListVolumeParams{ListOptions: opt})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list volumes: %w", err)
+	}
+	var items []SandboxCleanupItem
+	for _, v := range volumes {
+		if !filter.matches(v.Name, v.Tags, v.CreatedAt) {
+			continue
+		}
+		items = append(items, SandboxCleanupItem{
+			Type:                    sandboxTypeVolume,
+			ID:                      v.ID,
+			Name:                    v.Name,
+			CreatedAt:               v.CreatedAt.Format(time.RFC3339),
+			EstimatedMonthlyCostUSD: float64(v.SizeGigaBytes) * volumePricePerGiBMonth,
+		})
+	}
+	return items, nil
+}
+
+func planLoadBalancers(ctx context.Context, client *godo.Client, filter sandboxFilter) ([]SandboxCleanupItem, error) {
+	lbs, err := common.FetchAll(ctx, sandboxListPageSize, client.LoadBalancers.List)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list load balancers: %w", err)
+	}
+	var items []SandboxCleanupItem
+	for _, lb := range lbs {
+		if !filter.matches(lb.Name, lb.Tags, parseCreated(lb.Created)) {
+			continue
+		}
+		nodes := max(lb.SizeUnit, 1)
+		items = append(items, SandboxCleanupItem{
+			Type:                    sandboxTypeLoadBalancer,
+			ID:                      lb.ID,
+			Name:                    lb.Name,
+			CreatedAt:               lb.Created,
+			EstimatedMonthlyCostUSD: float64(nodes) * loadBalancerPricePerNode,
+		})
+	}
+	return items, nil
+}
+
+func planKubernetesClusters(ctx context.Context, client *godo.Client, filter sandboxFilter) ([]SandboxCleanupItem, error) {
+	clusters, err := common.FetchAll(ctx, sandboxListPageSize, client.Kubernetes.List)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list kubernetes clusters: %w", err)
+	}
+	var items []SandboxCleanupItem
+	var prices map[string]float64
+	for _, c := range clusters {
+		if !filter.matches(c.Name, c.Tags, c.CreatedAt) {
+			continue
+		}
+		// node prices are only needed once a cluster matches.
+		if prices == nil {
+			if prices, err = sizePrices(ctx, client); err != nil {
+				return nil, err
+			}
+		}
+		item := SandboxCleanupItem{Type: sandboxTypeKubernetes, ID: c.ID, Name: c.Name, CreatedAt: c.CreatedAt.Format(time.RFC3339)}
+		for _, pool := range c.NodePools {
+			item.EstimatedMonthlyCostUSD += float64(pool.Count) * prices[pool.Size]
+		}
+		if c.HA {
+			item.EstimatedMonthlyCostUSD += kubernetesHAControlPlaneUSD
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func planSnapshots(ctx context.Context, client *godo.Client, filter sandboxFilter) ([]SandboxCleanupItem, error) {
+	snapshots, err := common.FetchAll(ctx, sandboxListPageSize, client.Snapshots.List)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+	var items []SandboxCleanupItem
+	for _, snap := range snapshots {
+		if !filter.matches(snap.Name, snap.Tags, parseCreated(snap.Created)) {
+			continue
+		}
+		items = append(items, SandboxCleanupItem{
+			Type:                    sandboxTypeSnapshot,
+			ID:                      snap.ID,
+			Name:                    snap.Name,
+			CreatedAt:               snap.Created,
+			EstimatedMonthlyCostUSD: snap.SizeGigaBytes * snapshotPricePerGiBMonth,
+		})
+	}
+	return items, nil
+}
+
+// sizePrices returns the monthly price of every droplet size by slug.
+func sizePrices(ctx context.Context, client *godo.Client) (map[string]float64, error) {
+	sizes, err := common.FetchAll(ctx, sandboxListPageSize, client.Sizes.List)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sizes: %w", err)
+	}
+	prices := make(map[string]float64, len(sizes))
+	for _, size := range sizes {
+		prices[size.Slug] = size.PriceMonthly
+	}
+	return prices, nil
+}
+
+func (s *SandboxTool) execute(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	if confirm, _ := args["Confirm"].(bool); !confirm {
+		return mcp.NewToolResultError("Confirm must be true to delete the resources in the plan"), nil
+	}
+	rawPlan, ok := args["Plan"]
+	if !ok {
+		return mcp.NewToolResultError("Plan is required"), nil
+	}
+	data, err := json.Marshal(rawPlan)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("invalid Plan", err), nil
+	}
+	var plan SandboxCleanupPlan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return mcp.NewToolResultErrorFromErr("invalid Plan", err), nil
+	}
+	if len(plan.Items) == 0 {
+		return mcp.NewToolResultError("Plan has no items"), nil
+	}
+	if plan.Tag == "" && plan.NamePrefix == "" {
+		return mcp.NewToolResultError("Plan has no tag or name_prefix; pass the plan returned by sandbox-cleanup-plan"), nil
+	}
+	if plan.OlderThanHours < 0 {
+		return mcp.NewToolResultError("Plan older_than_hours must not be negative"), nil
+	}
+
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	// every item is checked against the plan's own filter before it is
+	// deleted, so an item added to or changed in the plan after it was
+	// returned cannot delete anything the filter would not have planned.
+	filter := sandboxFilter{
+		tag:        plan.Tag,
+		namePrefix: plan.NamePrefix,
+		cutoff:     s.now().Add(-time.Duration(plan.OlderThanHours * float64(time.Hour))),
+	}
+	items := slices.Clone(plan.Items)
+	slices.SortStableFunc(items, func(a, b SandboxCleanupItem) int {
+		return sandboxDeleteRank(a.Type) - sandboxDeleteRank(b.Type)
+	})
+
+	// keep going after a failed delete so one stuck resource does not block
+	// the rest of the cleanup; every item is reported individually.
+	report := SandboxCleanupReport{Results: make([]SandboxCleanupResult, 0, len(items))}
+	deletedDroplets := map[int]bool{}
+	for _, item := range items {
+		result := SandboxCleanupResult{Type: item.Type, ID: item.ID, Name: item.Name, Status: "deleted"}
+		resource, err := getSandboxItem(ctx, client, item)
+		switch {
+		case err != nil && common.IsNotFound(err):
+			result.Status = "skipped"
+			result.Reason = "no longer exists"
+			report.Skipped++
+		case err != nil:
+			result.Status = "failed"
+			result.Error = err.Error()
+			report.Failed++
+		case !filter.matches(resource.name, resource.tags, resource.created):
+			result.Status = "skipped"
+			result.Reason = "no longer matches the plan's tag, name prefix and age filter"
+			report.Skipped++
+		default:
+			err := s.waitForDetach(ctx, client, item, resource, deletedDroplets)
+			if err == nil {
+				err = deleteSandboxItem(ctx, client, item)
+			}
+			if err != nil {
+				result.Status = "failed"
+				result.Error = err.Error()
+				report.Failed++
+				break
+			}
+			report.Deleted++
+			if item.Type == sandboxTypeDroplet {
+				id, _ := strconv.Atoi(item.ID)
+				deletedDroplets[id] = true
+			}
+		}
+		report.Results = append(report.Results, result)
+	}
+
+	jsonData, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+// sandboxDeleteOrder is the order plan items are deleted in. Droplets go
+// first, so that their volumes are detached and their snapshots no longer in
+// use by the time those are deleted.
+var sandboxDeleteOrder = []string{
+	sandboxTypeDroplet,
+	sandboxTypeLoadBalancer,
+	sandboxTypeKubernetes,
+	sandboxTypeVolume,
+	sandboxTypeSnapshot,
+}
+
+// sandboxDeleteRank returns the position of typ in sandboxDeleteOrder; unknown
+// types come last.
+func sandboxDeleteRank(typ string) int {
+	if i := slices.Index(sandboxDeleteOrder, typ); i >= 0 {
+		return i
+	}
+	return len(sandboxDeleteOrder)
+}
+
+// sandboxResource is the current state of a plan item, which the plan's
+// filter is checked against before the item is deleted.
+type sandboxResource struct {
+	name    string
+	tags    []string
+	created time.Time
+	// dropletIDs are the droplets a volume is attached to.
+	dropletIDs []int
+}
+
+// getSandboxItem fetches the resource a plan item names.
+func getSandboxItem(ctx context.Context, client *godo.Client, item SandboxCleanupItem) (sandboxResource, error) {
+	if item.ID == "" {
+		return sandboxResource{}, fmt.Errorf("missing id")
+	}
+
+	switch item.Type {
+	case sandboxTypeDroplet:
+		id, err := strconv.Atoi(item.ID)
+		if err != nil {
+			return sandboxResource{}, fmt.Errorf("invalid droplet id %q", item.ID)
+		}
+		d, _, err := client.Droplets.Get(ctx, id)
+		if err != nil {
+			return sandboxResource{}, err
+		}
+		return sandboxResource{name: d.Name, tags: d.Tags, created: parseCreated(d.Created)}, nil
+	case sandboxTypeVolume:
+		v, _, err := client.Storage.GetVolume(ctx, item.ID)
+		if err != nil {
+			return sandboxResource{}, err
+		}
+		return sandboxResource{name: v.Name, tags: v.Tags, created: v.CreatedAt, dropletIDs: v.DropletIDs}, nil
+	case sandboxTypeLoadBalancer:
+		lb, _, err := client.LoadBalancers.Get(ctx, item.ID)
+		if err != nil {
+			return sandboxResource{}, err
+		}
+		return sandboxResource{name: lb.Name, tags: lb.Tags, created: parseCreated(lb.Created)}, nil
+	case sandboxTypeKubernetes:
+		c, _, err := client.Kubernetes.Get(ctx, item.ID)
+		if err != nil {
+			return sandboxResource{}, err
+		}
+		return sandboxResource{name: c.Name, tags: c.Tags, created: c.CreatedAt}, nil
+	case sandboxTypeSnapshot:
+		snap, _, err := client.Snapshots.Get(ctx, item.ID)
+		if err != nil {
+			return sandboxResource{}, err
+		}
+		return sandboxResource{name: snap.Name, tags: snap.Tags, created: parseCreated(snap.Created)}, nil
+	default:
+		return sandboxResource{}, fmt.Errorf("unsupported type %q", item.Type)
+	}
+}
+
+// waitForDetach waits until a volume item is detached from the droplets this
+// cleanup deleted. A volume still attached to a droplet the cleanup did not
+// delete cannot be deleted and fails straight away.
+func (s *SandboxTool) waitForDetach(ctx context.Context, client *godo.Client, item SandboxCleanupItem, resource sandboxResource, deletedDroplets map[int]bool) error {
+	if item.Type != sandboxTypeVolume || len(resource.dropletIDs) == 0 {
+		return nil
+	}
+	for _, id := range resource.dropletIDs {
+		if !deletedDroplets[id] {
+			return fmt.Errorf("attached to droplet %d, which this cleanup did not delete", id)
+		}
+	}
+	_, err := waiter.Poll(ctx, s.pollInterval, s.detachTimeout, func(ctx context.Context) (*godo.Volume, bool, error) {
+		v, _, err := client.Storage.GetVolume(ctx, item.ID)
+		if err != nil {
+			return nil, false, err
+		}
+		return v, len(v.DropletIDs) == 0, nil
+	})
+	if errors.Is(err, waiter.ErrTimeout) {
+		return fmt.Errorf("timed out after %s waiting for the volume to detach from its deleted droplets", s.detachTimeout)
+	}
+	return err
+}
+
+func deleteSandboxItem(ctx context.Context, client *godo.Client, item SandboxCleanupItem) error {
+	var err error
+	switch item.Type {
+	case sandboxTypeDroplet:
+		id, convErr := strconv.Atoi(item.ID)
+		if convErr != nil {
+			return fmt.Errorf("invalid droplet id %q", item.ID)
+		}
+		_, err = client.Droplets.Delete(ctx, id)
+	case sandboxTypeVolume:
+		_, err = client.Storage.DeleteVolume(ctx, item.ID)
+	case sandboxTypeLoadBalancer:
+		_, err = client.LoadBalancers.Delete(ctx, item.ID)
+	case sandboxTypeKubernetes:
+		_, err = client.Kubernetes.Delete(ctx, item.ID)
+	case sandboxTypeSnapshot:
+		_, err = client.Snapshots.Delete(ctx, item.ID)
+	default:
+		return fmt.Errorf("unsupported type %q", item.Type)
+	}
+	return err
+}
+
+// Tools returns the sandbox cleanup tools
+func (s *SandboxTool) Tools() []server.ServerTool {
+	return []server.ServerTool{
+		{
+			Handler: s.plan,
+			Tool: mcp.NewTool("sandbox-cleanup-plan",
+				common.WithHints(common.HintsRead),
+				mcp.WithDescription("List droplets, volumes, load balancers, DOKS clusters and snapshots matching a tag or name prefix, "+
+					"optionally only those older than a number of hours, and return a cleanup plan with each resource's type, id, name, "+
+					"creation time and estimated monthly cost. Nothing is deleted; pass the plan to sandbox-cleanup-execute to delete it."),
+				mcp.WithString("Tag", mcp.Description("Only include resources carrying this tag")),
+				mcp.WithString("NamePrefix", mcp.Description("Only include resources whose name starts with this prefix")),
+				mcp.WithNumber("OlderThanHours", mcp.Description("Only include resources created more than this many hours ago")),
+			),
+		},
+		{
+			Handler: s.execute,
+			Tool: mcp.NewTool("sandbox-cleanup-execute",
+				common.WithHints(common.HintsDelete),
+				mcp.WithDescription("Delete every resource in a plan returned by sandbox-cleanup-plan and report the result for each item. "+
+					"Each resource is fetched again first and skipped if it no longer matches the plan's tag, name prefix and age filter. "+
+					"Droplets are deleted before volumes and snapshots, and volumes attached to deleted droplets are waited on to detach. "+
+					"A failed delete does not stop the remaining items."),
+				mcp.WithObject("Plan", mcp.Required(), mcp.Description("The plan returned by sandbox-cleanup-plan; its items are deleted if they still match its tag, name_prefix and older_than_hours")),
+				mcp.WithBoolean("Confirm", mcp.Required(), mcp.Description("Must be true only after the user has reviewed the plan and confirmed the deletion")),
+			),
+		},
+	}
+}
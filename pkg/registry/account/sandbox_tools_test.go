@@ -0,0 +1,325 @@
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"mcp-digitalocean/internal/testhelpers"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var sandboxNow = time.Date(2026, 5, 10, 12, 0, 0, 0, time.UTC)
+
+type sandboxMocks struct {
+	droplets      *MockDropletsService
+	storage       *MockStorageService
+	loadBalancers *MockLoadBalancersService
+	kubernetes    *MockKubernetesService
+	snapshots     *MockSnapshotsService
+	sizes         *MockSizesService
+}
+
+func setupSandboxToolWithMocks(ctrl *gomock.Controller) (*SandboxTool, sandboxMocks) {
+	m := sandboxMocks{
+		droplets:      NewMockDropletsService(ctrl),
+		storage:       NewMockStorageService(ctrl),
+		loadBalancers: NewMockLoadBalancersService(ctrl),
+		kubernetes:    NewMockKubernetesService(ctrl),
+		snapshots:     NewMockSnapshotsService(ctrl),
+		sizes:         NewMockSizesService(ctrl),
+	}
+	client := func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{
+			Droplets:      m.droplets,
+			Storage:       m.storage,
+			LoadBalancers: m.loadBalancers,
+			Kubernetes:    m.kubernetes,
+			Snapshots:     m.snapshots,
+			Sizes:         m.sizes,
+		}, nil
+	}
+
+	tool := NewSandboxTool(client)
+	tool.now = func() time.Time { return sandboxNow }
+	return tool, m
+}
+
+func hoursAgo(h int) time.Time {
+	return sandboxNow.Add(-time.Duration(h) * time.Hour)
+}
+
+// expectSandboxAccount sets up an account holding old and new resources, some
+// tagged agent-test and some not.
+func expectSandboxAccount(m sandboxMocks) {
+	m.droplets.EXPECT().List(gomock.Any(), gomock.Any()).Return([]godo.Droplet{
+		{ID: 1, Name: "agent-old", Tags: []string{"agent-test"}, Created: hoursAgo(48).Format(time.RFC3339), Size: &godo.Size{PriceMonthly: 6}},
+		{ID: 2, Name: "agent-new", Tags: []string{"agent-test"}, Created: hoursAgo(1).Format(time.RFC3339), Size: &godo.Size{PriceMonthly: 6}},
+		{ID: 3, Name: "prod-web", Tags: []string{"prod"}, Created: hoursAgo(500).Format(time.RFC3339)},
+		{ID: 4, Name: "agent-unknown-age", Tags: []string{"agent-test"}},
+	}, nil, nil)
+	m.storage.EXPECT().ListVolumes(gomock.Any(), gomock.Any()).Return([]godo.Volume{
+		{ID: "vol-1", Name: "agent-data", Tags: []string{"agent-test"}, CreatedAt: hoursAgo(30), SizeGigaBytes: 100},
+		{ID: "vol-2", Name: "prod-data", CreatedAt: hoursAgo(30), SizeGigaBytes: 100},
+	}, nil, nil)
+	m.loadBalancers.EXPECT().List(gomock.Any(), gomock.Any()).Return([]godo.LoadBalancer{
+		{ID: "lb-1", Name: "agent-lb", Tags: []string{"agent-test"}, Created: hoursAgo(72).Format(time.RFC3339), SizeUnit: 2},
+	}, nil, nil)
+	m.kubernetes.EXPECT().List(gomock.Any(), gomock.Any()).Return([]*godo.KubernetesCluster{
+		{ID: "k8s-1", Name: "agent-k8s", Tags: []string{"agent-test"}, CreatedAt: hoursAgo(25), HA: true, NodePools: []*godo.KubernetesNodePool{
+			{Size: "s-2vcpu-4gb", Count: 3},
+		}},
+	}, nil, nil)
+	m.sizes.EXPECT().List(gomock.Any(), gomock.Any()).Return([]godo.Size{
+		{Slug: "s-2vcpu-4gb", PriceMonthly: 24},
+	}, nil, nil)
+	m.snapshots.EXPECT().List(gomock.Any(), gomock.Any()).Return([]godo.Snapshot{
+		{ID: "snap-1", Name: "agent-snap", Tags: []string{"agent-test"}, Created: hoursAgo(100).Format(time.RFC3339), SizeGigaBytes: 10},
+		{ID: "snap-2", Name: "agent-snap-new", Tags: []string{"agent-test"}, Created: hoursAgo(2).Format(time.RFC3339), SizeGigaBytes: 10},
+	}, nil, nil)
+}
+
+func TestSandboxTool_plan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tool, m := setupSandboxToolWithMocks(ctrl)
+	expectSandboxAccount(m)
+
+	resp, err := tool.plan(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"Tag":            "agent-test",
+		"OlderThanHours": float64(24),
+	}}})
+	require.NoError(t, err)
+	require.False(t, resp.IsError)
+
+	var plan SandboxCleanupPlan
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &plan))
+
+	var ids []string
+	costs := map[string]float64{}
+	for _, item := range plan.Items {
+		ids = append(ids, item.Type+"/"+item.ID)
+		costs[item.ID] = item.EstimatedMonthlyCostUSD
+	}
+	require.Equal(t, []string{"droplet/1", "volume/vol-1", "load_balancer/lb-1", "kubernetes_cluster/k8s-1", "snapshot/snap-1"}, ids)
+	require.InDelta(t, 6, costs["1"], 0.001)
+	require.InDelta(t, 10, costs["vol-1"], 0.001)
+	require.InDelta(t, 24, costs["lb-1"], 0.001)
+	require.InDelta(t, 3*24+40, costs["k8s-1"], 0.001)
+	require.InDelta(t, 0.6, costs["snap-1"], 0.001)
+	require.InDelta(t, 6+10+24+112+0.6, plan.TotalEstimatedMonthlyCostUSD, 0.001)
+}
+
+func TestSandboxTool_planNamePrefixPaginates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tool, m := setupSandboxToolWithMocks(ctrl)
+
+	firstPage := &godo.Response{Links: &godo.Links{Pages: &godo.Pages{Next: "https://api/v2/droplets?page=2", Last: "https://api/v2/droplets?page=2"}}}
+	gomock.InOrder(
+		m.droplets.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: sandboxListPageSize}).
+			Return([]godo.Droplet{{ID: 1, Name: "tmp-a", Created: hoursAgo(1).Format(time.RFC3339)}}, firstPage, nil),
+		m.droplets.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 2, PerPage: sandboxListPageSize}).
+			Return([]godo.Droplet{{ID: 2, Name: "tmp-b", Created: hoursAgo(1).Format(time.RFC3339)}, {ID: 3, Name: "keep"}}, nil, nil),
+	)
+	m.storage.EXPECT().ListVolumes(gomock.Any(), gomock.Any()).Return(nil, nil, nil)
+	m.loadBalancers.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil, nil)
+	// no cluster matches, so sizes are never fetched.
+	m.kubernetes.EXPECT().List(gomock.Any(), gomock.Any()).Return([]*godo.KubernetesCluster{{ID: "k8s-1", Name: "prod", CreatedAt: hoursAgo(1)}}, nil, nil)
+	m.snapshots.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil, nil)
+
+	resp, err := tool.plan(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"NamePrefix": "tmp-",
+	}}})
+	require.NoError(t, err)
+	require.False(t, resp.IsError)
+
+	var plan SandboxCleanupPlan
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &plan))
+	require.Len(t, plan.Items, 2)
+	require.Equal(t, "tmp-a", plan.Items[0].Name)
+	require.Equal(t, "tmp-b", plan.Items[1].Name)
+}
+
+func TestSandboxTool_planErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(sandboxMocks)
+		wantMessage string
+	}{
+		{
+			name:        "no filter",
+			args:        map[string]any{"OlderThanHours": float64(1)},
+			wantMessage: "Tag or NamePrefix is required",
+		},
+		{
+			name:        "negative age",
+			args:        map[string]any{"Tag": "agent-test", "OlderThanHours": float64(-1)},
+			wantMessage: "OlderThanHours must not be negative",
+		},
+		{
+			name: "api error",
+			args: map[string]any{"Tag": "agent-test"},
+			mockSetup: func(m sandboxMocks) {
+				m.droplets.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil, nil)
+				m.storage.EXPECT().ListVolumes(gomock.Any(), gomock.Any()).Return(nil, nil, errors.New("boom"))
+			},
+			wantMessage: "failed to list volumes: boom",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			tool, m := setupSandboxToolWithMocks(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(m)
+			}
+
+			resp, err := tool.plan(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			require.True(t, resp.IsError)
+			require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.wantMessage)
+		})
+	}
+}
+
+func TestSandboxTool_execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tool, m := setupSandboxToolWithMocks(ctrl)
+	tool.pollInterval = time.Millisecond
+
+	old := hoursAgo(48)
+	tagged := []string{"agent-test"}
+	m.droplets.EXPECT().Get(gomock.Any(), 1).Return(&godo.Droplet{ID: 1, Name: "agent-old", Tags: tagged, Created: old.Format(time.RFC3339)}, nil, nil)
+	m.droplets.EXPECT().Get(gomock.Any(), 3).Return(&godo.Droplet{ID: 3, Name: "prod-web", Tags: []string{"prod"}, Created: old.Format(time.RFC3339)}, nil, nil)
+	m.loadBalancers.EXPECT().Get(gomock.Any(), "lb-1").Return(&godo.LoadBalancer{ID: "lb-1", Tags: tagged, Created: old.Format(time.RFC3339)}, nil, nil)
+	m.kubernetes.EXPECT().Get(gomock.Any(), "k8s-1").Return(&godo.KubernetesCluster{ID: "k8s-1", Tags: tagged, CreatedAt: old}, nil, nil)
+	m.snapshots.EXPECT().Get(gomock.Any(), "snap-1").Return(&godo.Snapshot{ID: "snap-1", Tags: tagged, Created: old.Format(time.RFC3339)}, nil, nil)
+	m.snapshots.EXPECT().Get(gomock.Any(), "snap-2").Return(&godo.Snapshot{ID: "snap-2", Tags: tagged, Created: hoursAgo(1).Format(time.RFC3339)}, nil, nil)
+	m.snapshots.EXPECT().Get(gomock.Any(), "snap-gone").Return(nil, nil, testhelpers.NotFoundError("/v2/snapshots/snap-gone"))
+	m.storage.EXPECT().GetVolume(gomock.Any(), "vol-2").Return(&godo.Volume{ID: "vol-2", Tags: tagged, CreatedAt: old, DropletIDs: []int{3}}, nil, nil)
+
+	// the droplet is deleted before its volume, which is deleted once it has
+	// detached.
+	gomock.InOrder(
+		m.droplets.EXPECT().Delete(gomock.Any(), 1).Return(nil, nil),
+		m.storage.EXPECT().GetVolume(gomock.Any(), "vol-1").Return(&godo.Volume{ID: "vol-1", Name: "agent-data", Tags: tagged, CreatedAt: old, DropletIDs: []int{1}}, nil, nil),
+		m.storage.EXPECT().GetVolume(gomock.Any(), "vol-1").Return(&godo.Volume{ID: "vol-1", DropletIDs: []int{1}}, nil, nil),
+		m.storage.EXPECT().GetVolume(gomock.Any(), "vol-1").Return(&godo.Volume{ID: "vol-1"}, nil, nil),
+		m.storage.EXPECT().DeleteVolume(gomock.Any(), "vol-1").Return(nil, nil),
+	)
+	m.loadBalancers.EXPECT().Delete(gomock.Any(), "lb-1").Return(nil, nil)
+	m.kubernetes.EXPECT().Delete(gomock.Any(), "k8s-1").Return(nil, errors.New("cluster is busy"))
+	m.snapshots.EXPECT().Delete(gomock.Any(), "snap-1").Return(nil, nil)
+
+	// the plan round-trips through JSON as it would from a client.
+	var plan map[string]any
+	require.NoError(t, json.Unmarshal([]byte(`{"tag":"agent-test","older_than_hours":24,"items":[
+		{"type":"snapshot","id":"snap-1"},
+		{"type":"volume","id":"vol-1","name":"agent-data"},
+		{"type":"droplet","id":"1","name":"agent-old"},
+		{"type":"load_balancer","id":"lb-1"},
+		{"type":"kubernetes_cluster","id":"k8s-1"},
+		{"type":"droplet","id":"3","name":"prod-web"},
+		{"type":"volume","id":"vol-2"},
+		{"type":"snapshot","id":"snap-2"},
+		{"type":"snapshot","id":"snap-gone"},
+		{"type":"droplet","id":"not-a-number"},
+		{"type":"database","id":"db-1"}
+	]}`), &plan))
+
+	resp, err := tool.execute(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"Plan":    plan,
+		"Confirm": true,
+	}}})
+	require.NoError(t, err)
+	require.False(t, resp.IsError)
+
+	var report SandboxCleanupReport
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &report))
+	require.Equal(t, 4, report.Deleted)
+	require.Equal(t, 4, report.Failed)
+	require.Equal(t, 3, report.Skipped)
+
+	var order []string
+	results := map[string]SandboxCleanupResult{}
+	for _, r := range report.Results {
+		order = append(order, r.Type+"/"+r.ID)
+		results[r.ID] = r
+	}
+	require.Equal(t, []string{
+		"droplet/1", "droplet/3", "droplet/not-a-number",
+		"load_balancer/lb-1", "kubernetes_cluster/k8s-1",
+		"volume/vol-1", "volume/vol-2",
+		"snapshot/snap-1", "snapshot/snap-2", "snapshot/snap-gone",
+		"database/db-1",
+	}, order)
+	require.Equal(t, SandboxCleanupResult{Type: "droplet", ID: "1", Name: "agent-old", Status: "deleted"}, results["1"])
+	require.Equal(t, SandboxCleanupResult{Type: "volume", ID: "vol-1", Name: "agent-data", Status: "deleted"}, results["vol-1"])
+	require.Equal(t, "skipped", results["3"].Status)
+	require.Contains(t, results["3"].Reason, "no longer matches")
+	require.Equal(t, "skipped", results["snap-2"].Status)
+	require.Equal(t, SandboxCleanupResult{Type: "snapshot", ID: "snap-gone", Status: "skipped", Reason: "no longer exists"}, results["snap-gone"])
+	require.Equal(t, SandboxCleanupResult{Type: "kubernetes_cluster", ID: "k8s-1", Status: "failed", Error: "cluster is busy"}, results["k8s-1"])
+	require.Contains(t, results["vol-2"].Error, "attached to droplet 3")
+	require.Contains(t, results["not-a-number"].Error, "invalid droplet id")
+	require.Contains(t, results["db-1"].Error, `unsupported type "database"`)
+}
+
+func TestSandboxTool_executeErrors(t *testing.T) {
+	plan := map[string]any{"tag": "agent-test", "items": []any{map[string]any{"type": "droplet", "id": "1"}}}
+	tests := []struct {
+		name        string
+		args        map[string]any
+		wantMessage string
+	}{
+		{
+			name:        "not confirmed",
+			args:        map[string]any{"Plan": plan},
+			wantMessage: "Confirm must be true",
+		},
+		{
+			name:        "confirm false",
+			args:        map[string]any{"Plan": plan, "Confirm": false},
+			wantMessage: "Confirm must be true",
+		},
+		{
+			name:        "missing plan",
+			args:        map[string]any{"Confirm": true},
+			wantMessage: "Plan is required",
+		},
+		{
+			name:        "empty plan",
+			args:        map[string]any{"Plan": map[string]any{"items": []any{}}, "Confirm": true},
+			wantMessage: "Plan has no items",
+		},
+		{
+			name:        "plan without a filter",
+			args:        map[string]any{"Plan": map[string]any{"items": plan["items"]}, "Confirm": true},
+			wantMessage: "Plan has no tag or name_prefix",
+		},
+		{
+			name:        "malformed plan",
+			args:        map[string]any{"Plan": map[string]any{"items": "droplet/1"}, "Confirm": true},
+			wantMessage: "invalid Plan",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			tool, _ := setupSandboxToolWithMocks(ctrl)
+
+			resp, err := tool.execute(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			require.True(t, resp.IsError)
+			require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.wantMessage)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package registry
+
+import (
+	"maps"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// AliasOfMetaKey marks, in a tool's _meta, a tool that is another name for a
+// canonical tool. Its value is the canonical tool's name.
+const AliasOfMetaKey = "com.digitalocean/alias-of"
+
+// toolAlias is another name for a tool, for a term the API has renamed or a
+// name agents commonly look for, so they find the tool under either name.
+type toolAlias struct {
+	alias     string
+	canonical string
+	reason    string
+}
+
+// floatingIPReason explains the floating-ip-* aliases.
+const floatingIPReason = "DigitalOcean renamed floating IPs to reserved IPs"
+
+// accountGetReason explains the account-get alias.
+const accountGetReason = "the short name matches balance-get and the API's GET /v2/account"
+
+// toolAliases are the alias tool names. An alias is registered only when its
+// canonical tool is.
+var toolAliases = []toolAlias{
+	{alias: "floating-ip-get", canonical: "reserved-ip-get", reason: floatingIPReason},
+	{alias: "floating-ip-list", canonical: "reserved-ip-list", reason: floatingIPReason},
+	{alias: "floating-ip-reserve", canonical: "reserved-ip-reserve", reason: floatingIPReason},
+	{alias: "floating-ip-release", canonical: "reserved-ip-release", reason: floatingIPReason},
+	{alias: "floating-ip-assign", canonical: "reserved-ip-assign", reason: floatingIPReason},
+	{alias: "floating-ip-unassign", canonical: "reserved-ip-unassign", reason: floatingIPReason},
+	{alias: "account-get", canonical: "account-get-information", reason: accountGetReason},
+}
+
+// AliasDescription describes an alias in describe-services.
+type AliasDescription struct {
+	Alias     string `json:"alias"`
+	Canonical string `json:"canonical"`
+}
+
+// AliasOf returns the canonical tool name t is an alias of, or "" if t is not
+// an alias.
+func AliasOf(t mcp.Tool) string {
+	if t.Meta == nil {
+		return ""
+	}
+	canonical, _ := t.Meta.AdditionalFields[AliasOfMetaKey].(string)
+	return canonical
+}
+
+// aliasTool returns canonical registered under a's name. It shares the
+// canonical tool's handler and schema, and its description names the
+// canonical tool.
+func aliasTool(canonical server.ServerTool, a toolAlias) server.ServerTool {
+	tool := canonical.Tool
+	tool.Name = a.alias
+	tool.Description = "Alias of " + a.canonical + " (" + a.reason + "). " + canonical.Tool.Description
+	meta := &mcp.Meta{AdditionalFields: map[string]any{}}
+	if tool.Meta != nil {
+		meta.ProgressToken = tool.Meta.ProgressToken
+		maps.Copy(meta.AdditionalFields, tool.Meta.AdditionalFields)
+	}
+	meta.AdditionalFields[AliasOfMetaKey] = a.canonical
+	tool.Meta = meta
+	return server.ServerTool{Tool: tool, Handler: canonical.Handler}
+}
+
+// registerAliases adds the aliases whose canonical tool is registered on s and
+// returns them.
+func registerAliases(s *server.MCPServer) []AliasDescription {
+	var registered []AliasDescription
+	for _, a := range toolAliases {
+		canonical := s.GetTool(a.canonical)
+		if canonical == nil {
+			continue
+		}
+		s.AddTools(aliasTool(*canonical, a))
+		registered = append(registered, AliasDescription{Alias: a.alias, Canonical: a.canonical})
+	}
+	return registered
+}
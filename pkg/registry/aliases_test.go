@@ -0,0 +1,89 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/require"
+)
+
+func describeServicesResult(t *testing.T, s *server.MCPServer) ServicesDescription {
+	t.Helper()
+	resp, err := s.GetTool("describe-services").Handler(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	var description ServicesDescription
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &description))
+	return description
+}
+
+func TestRegister_aliases(t *testing.T) {
+	var calls []string
+	getClient := func(ctx context.Context) (*godo.Client, error) {
+		calls = append(calls, "client")
+		return nil, errors.New("no client")
+	}
+	s := server.NewMCPServer("test", "0.0.0")
+	_, err := Register(slog.New(slog.NewTextHandler(io.Discard, nil)), s, getClient, Options{}, "networking", "accounts")
+	require.NoError(t, err)
+
+	for _, a := range toolAliases {
+		alias, canonical := s.GetTool(a.alias), s.GetTool(a.canonical)
+		require.NotNil(t, alias, a.alias)
+		require.NotNil(t, canonical, a.canonical)
+		require.Equal(t, a.canonical, AliasOf(alias.Tool))
+		require.Empty(t, AliasOf(canonical.Tool))
+		require.True(t, strings.HasPrefix(alias.Tool.Description, "Alias of "+a.canonical+" ("), alias.Tool.Description)
+		require.Equal(t, canonical.Tool.InputSchema, alias.Tool.InputSchema)
+		require.Equal(t, canonical.Tool.Annotations, alias.Tool.Annotations)
+	}
+
+	// both names dispatch to the same handler
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"IP": "10.0.0.1"}}}
+	calls = nil
+	aliasResp, aliasErr := s.GetTool("floating-ip-get").Handler(context.Background(), req)
+	canonicalResp, canonicalErr := s.GetTool("reserved-ip-get").Handler(context.Background(), req)
+	require.Error(t, aliasErr)
+	require.Equal(t, canonicalErr, aliasErr)
+	require.Equal(t, canonicalResp, aliasResp)
+	require.Len(t, calls, 2)
+
+	description := describeServicesResult(t, s)
+	require.Len(t, description.Aliases, len(toolAliases))
+	require.Contains(t, description.Aliases, AliasDescription{Alias: "floating-ip-list", Canonical: "reserved-ip-list"})
+}
+
+func TestRegister_aliasesFollowCanonical(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("service not enabled", func(t *testing.T) {
+		s := server.NewMCPServer("test", "0.0.0")
+		_, err := Register(logger, s, testClient, Options{}, "accounts")
+		require.NoError(t, err)
+		require.Nil(t, s.GetTool("floating-ip-list"))
+		require.Equal(t, []AliasDescription{{Alias: "account-get", Canonical: "account-get-information"}}, describeServicesResult(t, s).Aliases)
+	})
+
+	t.Run("canonical denied", func(t *testing.T) {
+		s := server.NewMCPServer("test", "0.0.0")
+		_, err := Register(logger, s, testClient, Options{Filter: ToolFilter{Deny: []string{"reserved-ip-list"}}}, "networking")
+		require.NoError(t, err)
+		require.Nil(t, s.GetTool("floating-ip-list"))
+		require.NotNil(t, s.GetTool("floating-ip-get"))
+	})
+
+	t.Run("alias denied", func(t *testing.T) {
+		s := server.NewMCPServer("test", "0.0.0")
+		_, err := Register(logger, s, testClient, Options{Filter: ToolFilter{Deny: []string{"floating-ip-*"}}}, "networking")
+		require.NoError(t, err)
+		require.Nil(t, s.GetTool("floating-ip-list"))
+		require.NotNil(t, s.GetTool("reserved-ip-list"))
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"mcp-digitalocean/pkg/registry/common"
 
 	_ "embed"
 )
@@ -19,18 +20,60 @@ var appCreateSchemaJSON []byte
 //go:embed spec/app-update-schema.json
 var appUpdateSchemaJSON []byte
 
+// appUpsertSchemaJSON is the create schema plus the ValidateSpec flag.
+var appUpsertSchemaJSON = withBooleanProperty(appCreateSchemaJSON, "ValidateSpec",
+	"Validate the spec with the App Platform propose API before creating or updating the app")
+
 const (
 	defaultPageSize = 20 // Default page size for listing apps
 	defaultPage     = 1
+
+	upsertListPageSize = 100 // Page size used when looking up an app by name
 )
 
+// withBooleanProperty returns schema with an extra top-level boolean property.
+// The schema is returned unchanged if it is not a JSON object.
+func withBooleanProperty(schema []byte, name, description string) []byte {
+	var doc map[string]any
+	if err := json.Unmarshal(schema, &doc); err != nil || doc == nil {
+		return schema
+	}
+	properties, _ := doc["properties"].(map[string]any)
+	if properties == nil {
+		properties = map[string]any{}
+		doc["properties"] = properties
+	}
+	properties[name] = map[string]any{"type": "boolean", "description": description}
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return schema
+	}
+	return out
+}
+
 type AppPlatformTool struct {
+	common.ToolLogger
+
 	client func(ctx context.Context) (*godo.Client, error)
+	locks  *common.ResourceLocks
 }
 
 // NewAppPlatformTool creates a new AppsTool instance
 func NewAppPlatformTool(client func(ctx context.Context) (*godo.Client, error)) (*AppPlatformTool, error) {
-	return &AppPlatformTool{client: client}, nil
+	return &AppPlatformTool{client: client, locks: common.SharedResourceLocks}, nil
+}
+
+// appURN keys app spec mutations for the resource locks.
+var appURN = common.URNFromArg("app", "AppID")
+
+// appUpdateURN keys apps-update, whose app ID is nested in its update object.
+func appUpdateURN(req mcp.CallToolRequest) string {
+	update, _ := req.GetArguments()["update"].(map[string]any)
+	id, _ := update["app_id"].(string)
+	if id == "" {
+		return ""
+	}
+	return "do:app:" + id
 }
 
 func (a *AppPlatformTool) createAppFromAppSpec(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -41,7 +84,7 @@ func (a *AppPlatformTool) createAppFromAppSpec(ctx context.Context, req mcp.Call
 
 	var create godo.AppCreateRequest
 	if err := json.Unmarshal(jsonBytes, &create); err != nil {
-		return mcp.NewToolResultErrorFromErr("failed to parse app creation request", err), nil
+		return common.ErrorResult(ctx, a.Logger(ctx), "failed to parse app creation request", err), nil
 	}
 
 	if create.Spec == nil {
@@ -55,7 +98,7 @@ func (a *AppPlatformTool) createAppFromAppSpec(ctx context.Context, req mcp.Call
 
 	app, _, err := client.Apps.Create(ctx, &create)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("failed to create app", err), nil
+		return common.ErrorResult(ctx, a.Logger(ctx), "failed to create app", err), nil
 	}
 
 	appJSON, err := json.MarshalIndent(app, "", "  ")
@@ -66,6 +109,97 @@ func (a *AppPlatformTool) createAppFromAppSpec(ctx context.Context, req mcp.Call
 	return mcp.NewToolResultText(string(appJSON)), nil
 }
 
+// AppUpsertResult is returned by apps-upsert.
+type AppUpsertResult struct {
+	App     *godo.App `json:"app"`
+	Created bool      `json:"created"`
+}
+
+// upsertApp creates the app described by the spec, or updates the existing app
+// with the same name, so provisioning scripts can be re-run safely.
+func (a *AppPlatformTool) upsertApp(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	jsonBytes, err := json.Marshal(req.GetArguments())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request arguments for app upsert: %w", err)
+	}
+
+	var create godo.AppCreateRequest
+	if err := json.Unmarshal(jsonBytes, &create); err != nil {
+		return common.ErrorResult(ctx, a.Logger(ctx), "failed to parse app upsert request", err), nil
+	}
+
+	if create.Spec == nil || create.Spec.Name == "" {
+		return mcp.NewToolResultError("App spec with a name is required"), nil
+	}
+	validate, _ := req.GetArguments()["ValidateSpec"].(bool)
+
+	client, err := a.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	existing, err := findAppByName(ctx, client, create.Spec.Name)
+	if err != nil {
+		return common.ErrorResult(ctx, a.Logger(ctx), "failed to look up app by name", err), nil
+	}
+
+	if validate {
+		propose := &godo.AppProposeRequest{Spec: create.Spec}
+		if existing != nil {
+			propose.AppID = existing.ID
+		}
+		if _, _, err := client.Apps.Propose(ctx, propose); err != nil {
+			return common.ErrorResult(ctx, a.Logger(ctx), "app spec validation failed", err), nil
+		}
+	}
+
+	result := AppUpsertResult{Created: existing == nil}
+	if existing != nil {
+		unlock, err := a.locks.Lock(ctx, "do:app:"+existing.ID)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		defer unlock()
+		result.App, _, err = client.Apps.Update(ctx, existing.ID, &godo.AppUpdateRequest{Spec: create.Spec})
+		if err != nil {
+			return common.ErrorResult(ctx, a.Logger(ctx), fmt.Sprintf("failed to update app %s", existing.ID), err), nil
+		}
+	} else {
+		result.App, _, err = client.Apps.Create(ctx, &create)
+		if err != nil {
+			return common.ErrorResult(ctx, a.Logger(ctx), "failed to create app", err), nil
+		}
+	}
+
+	resultJSON, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to format upserted app response: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// findAppByName walks every page of apps and returns the one whose spec name
+// matches exactly, or nil if there is none.
+func findAppByName(ctx context.Context, client *godo.Client, name string) (*godo.App, error) {
+	opt := &godo.ListOptions{Page: 1, PerPage: upsertListPageSize}
+	for {
+		apps, resp, err := client.Apps.List(ctx, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, app := range apps {
+			if app.GetSpec().GetName() == name {
+				return app, nil
+			}
+		}
+		if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+			return nil, nil
+		}
+		opt.Page++
+	}
+}
+
 type AppSummary struct {
 	ID        string          `json:"id"`
 	Name      string          `json:"name"`
@@ -109,7 +243,7 @@ func (a *AppPlatformTool) listApps(ctx context.Context, req mcp.CallToolRequest)
 
 	apps, _, err := client.Apps.List(ctx, &godo.ListOptions{Page: int(page), PerPage: int(perPage)})
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("failed to retrieve apps list", err), nil
+		return common.ErrorResult(ctx, a.Logger(ctx), "failed to retrieve apps list", err), nil
 	}
 
 	// create a slice of app summaries
@@ -141,7 +275,7 @@ func (a *AppPlatformTool) deleteApp(ctx context.Context, req mcp.CallToolRequest
 
 	_, err = client.Apps.Delete(ctx, appID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr(fmt.Sprintf("failed to delete app %s", appID), err), nil
+		return common.ErrorResult(ctx, a.Logger(ctx), fmt.Sprintf("failed to delete app %s", appID), err), nil
 	}
 
 	return mcp.NewToolResultText("App deleted successfully"), nil
@@ -167,7 +301,7 @@ func (a *AppPlatformTool) getDeploymentStatus(ctx context.Context, req mcp.CallT
 
 	deployments, _, err := client.Apps.ListDeployments(ctx, appID, &godo.ListOptions{Page: 1, PerPage: defaultPageSize})
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr(fmt.Sprintf("failed to list deployments for app %s", appID), err), nil
+		return common.GetErrorResult(ctx, a.Logger(ctx), fmt.Sprintf("failed to list deployments for app %s", appID), "app", appID, err), nil
 	}
 
 	if len(deployments) == 0 {
@@ -182,7 +316,7 @@ func (a *AppPlatformTool) getDeploymentStatus(ctx context.Context, req mcp.CallT
 	// Get the health status of the deployment
 	health, _, err := client.Apps.GetAppHealth(ctx, appID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get health status for app %s: %w", appID, err)
+		return common.GetErrorResult(ctx, a.Logger(ctx), fmt.Sprintf("failed to get health status for app %s", appID), "app", appID, err), nil
 	}
 
 	// Combine these two into a single response.
@@ -213,7 +347,7 @@ func (a *AppPlatformTool) getAppInfo(ctx context.Context, req mcp.CallToolReques
 
 	app, _, err := client.Apps.Get(ctx, appID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr(fmt.Sprintf("failed to get app %s", appID), err), nil
+		return common.GetErrorResult(ctx, a.Logger(ctx), fmt.Sprintf("failed to get app %s", appID), "app", appID, err), nil
 	}
 
 	appJSON, err := json.MarshalIndent(app.Spec, "", "  ")
@@ -245,7 +379,7 @@ func (a *AppPlatformTool) updateApp(ctx context.Context, req mcp.CallToolRequest
 
 	var update AppUpdate
 	if err := json.Unmarshal(jsonBytes, &update); err != nil {
-		return mcp.NewToolResultErrorFromErr("failed to parse app update request", err), nil
+		return common.ErrorResult(ctx, a.Logger(ctx), "failed to parse app update request", err), nil
 	}
 
 	client, err := a.client(ctx)
@@ -258,7 +392,7 @@ func (a *AppPlatformTool) updateApp(ctx context.Context, req mcp.CallToolRequest
 			ForceBuild: true,
 		})
 		if err != nil {
-			return mcp.NewToolResultErrorFromErr(fmt.Sprintf("failed to create deployment for app %s", update.Update.AppID), err), nil
+			return common.ErrorResult(ctx, a.Logger(ctx), fmt.Sprintf("failed to create deployment for app %s", update.Update.AppID), err), nil
 		}
 
 		deploymentJSON, err := json.MarshalIndent(deployment, "", "  ")
@@ -271,7 +405,7 @@ func (a *AppPlatformTool) updateApp(ctx context.Context, req mcp.CallToolRequest
 
 	app, _, err := client.Apps.Update(ctx, update.Update.AppID, update.Update.Request)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr(fmt.Sprintf("failed to update app %s", update.Update.AppID), err), nil
+		return common.ErrorResult(ctx, a.Logger(ctx), fmt.Sprintf("failed to update app %s", update.Update.AppID), err), nil
 	}
 
 	appJSON, err := json.MarshalIndent(app, "", "  ")
@@ -323,7 +457,7 @@ func (a *AppPlatformTool) getAppLogs(ctx context.Context, req mcp.CallToolReques
 	//Call Godo.getLogs function
 	logs, _, err := client.Apps.GetLogs(ctx, appID, deploymentID, component, logType, follow, tailLines)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr(fmt.Sprintf("failed to get logs for app %s, deployment %s, component %s", appID, deploymentID, component), err), nil
+		return common.GetErrorResult(ctx, a.Logger(ctx), fmt.Sprintf("failed to get logs for app %s, deployment %s, component %s", appID, deploymentID, component), "deployment", appID+"/"+deploymentID, err), nil
 	}
 
 	logsJSON, err := json.MarshalIndent(logs, "", "  ")
@@ -334,6 +468,78 @@ func (a *AppPlatformTool) getAppLogs(ctx context.Context, req mcp.CallToolReques
 	return mcp.NewToolResultText(string(logsJSON)), nil
 }
 
+// DeploymentDiff is the structured difference between the specs of two deployments.
+type DeploymentDiff struct {
+	AppID        string          `json:"app_id"`
+	FromID       string          `json:"from_deployment_id"`
+	ToID         string          `json:"to_deployment_id"`
+	Changes      []common.Change `json:"changes"`
+	ChangesCount int             `json:"changes_count"`
+}
+
+// diffDeployments compares the app specs of two deployments. DeploymentID2
+// defaults to the app's active deployment and DeploymentID1 to the deployment
+// that preceded DeploymentID2.
+func (a *AppPlatformTool) diffDeployments(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appID, ok := req.GetArguments()["AppID"].(string)
+	if !ok || appID == "" {
+		return mcp.NewToolResultError("App ID is required"), nil
+	}
+	fromID, _ := req.GetArguments()["DeploymentID1"].(string)
+	toID, _ := req.GetArguments()["DeploymentID2"].(string)
+
+	client, err := a.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	if toID == "" {
+		app, _, err := client.Apps.Get(ctx, appID)
+		if err != nil {
+			return common.GetErrorResult(ctx, a.Logger(ctx), fmt.Sprintf("failed to get app %s", appID), "app", appID, err), nil
+		}
+		if app.ActiveDeployment == nil || app.ActiveDeployment.ID == "" {
+			return mcp.NewToolResultError(fmt.Sprintf("app %s has no active deployment; pass DeploymentID2 explicitly", appID)), nil
+		}
+		toID = app.ActiveDeployment.ID
+	}
+
+	to, _, err := client.Apps.GetDeployment(ctx, appID, toID)
+	if err != nil {
+		return common.GetErrorResult(ctx, a.Logger(ctx), fmt.Sprintf("failed to get deployment %s", toID), "deployment", appID+"/"+toID, err), nil
+	}
+
+	if fromID == "" {
+		if to.PreviousDeploymentID == "" {
+			return mcp.NewToolResultError(fmt.Sprintf("deployment %s has no previous deployment; pass DeploymentID1 explicitly", toID)), nil
+		}
+		fromID = to.PreviousDeploymentID
+	}
+
+	from, _, err := client.Apps.GetDeployment(ctx, appID, fromID)
+	if err != nil {
+		return common.GetErrorResult(ctx, a.Logger(ctx), fmt.Sprintf("failed to get deployment %s", fromID), "deployment", appID+"/"+fromID, err), nil
+	}
+
+	changes, err := common.DiffJSON(from.Spec, to.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff deployment specs: %w", err)
+	}
+
+	diffJSON, err := json.MarshalIndent(DeploymentDiff{
+		AppID:        appID,
+		FromID:       fromID,
+		ToID:         toID,
+		Changes:      changes,
+		ChangesCount: len(changes),
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal deployment diff: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(diffJSON)), nil
+}
+
 func (a *AppPlatformTool) Tools() []server.ServerTool {
 	tools := []server.ServerTool{
 		{
@@ -351,7 +557,7 @@ func (a *AppPlatformTool) Tools() []server.ServerTool {
 			),
 		},
 		{
-			Handler: a.deleteApp,
+			Handler: a.locks.Serialize(appURN, a.deleteApp),
 			Tool: mcp.NewTool("apps-delete",
 				mcp.WithDescription("Delete an existing app on DigitalOcean App Platform. This is a destructive operation and cannot be undone."),
 				mcp.WithDestructiveHintAnnotation(true),
@@ -367,14 +573,22 @@ func (a *AppPlatformTool) Tools() []server.ServerTool {
 		},
 		{
 			Handler: a.createAppFromAppSpec,
-			Tool: mcp.NewToolWithRawSchema(
+			Tool: common.RawSchemaCreatesResource(mcp.NewToolWithRawSchema(
 				"apps-create-app-from-spec",
 				"Creates an application from a given app spec. Within the app spec, a source has to be provided. The source can be a Git repository, a Dockerfile, or a container image.",
 				appCreateSchemaJSON,
-			),
+			)),
+		},
+		{
+			Handler: a.upsertApp,
+			Tool: common.RawSchemaCreatesResource(mcp.NewToolWithRawSchema(
+				"apps-upsert",
+				"Creates an application from a given app spec, or updates the existing application with the same name. Use this instead of apps-create-app-from-spec when the app may already exist, for example when re-running a provisioning script. The response contains the app and a created flag that is true when a new app was created. project_id only applies when the app is created.",
+				appUpsertSchemaJSON,
+			)),
 		},
 		{
-			Handler: a.updateApp,
+			Handler: a.locks.Serialize(appUpdateURN, a.updateApp),
 			Tool: mcp.NewToolWithRawSchema(
 				"apps-update",
 				"Updates an existing application on DigitalOcean App Platform. The app ID and the AppSpec must be provided in the request.",
@@ -393,6 +607,62 @@ func (a *AppPlatformTool) Tools() []server.ServerTool {
 				mcp.WithNumber("TailLines", mcp.DefaultNumber(100), mcp.Description("Number of lines to retrieve from the end of logs (default: 100)")),
 			),
 		},
+		{
+			Handler: a.diffDeployments,
+			Tool: mcp.NewTool("apps-diff-deployments",
+				mcp.WithDescription("Shows what changed between the app specs of two deployments on DigitalOcean App Platform, as a list of added, removed and changed paths with their old and new values. By default compares the active deployment with the one before it, which is useful when a deployment breaks."),
+				mcp.WithReadOnlyHintAnnotation(true),
+				mcp.WithString("AppID", mcp.Required(), mcp.Description("The application ID")),
+				mcp.WithString("DeploymentID1", mcp.Description("The deployment to compare from. Defaults to the deployment before DeploymentID2")),
+				mcp.WithString("DeploymentID2", mcp.Description("The deployment to compare to. Defaults to the app's active deployment")),
+			),
+		},
+		{
+			Handler: a.locks.Serialize(appURN, a.scaleComponent),
+			Tool: mcp.NewTool("apps-scale-component",
+				mcp.WithDescription("Changes the instance count and/or instance size of one service or worker in an app on DigitalOcean App Platform, leaving the rest of the app spec untouched. Prefer this over apps-update for scaling. The size slug is checked against the available App Platform instance sizes. The change triggers a new deployment."),
+				mcp.WithString("AppID", mcp.Required(), mcp.Description("The application ID")),
+				mcp.WithString("ComponentName", mcp.Required(), mcp.Description("Name of the service or worker to scale")),
+				mcp.WithNumber("InstanceCount", mcp.Description("New number of instances, at least 1. Not allowed for components that use autoscaling")),
+				mcp.WithString("InstanceSizeSlug", mcp.Description("New instance size slug (e.g., apps-s-1vcpu-1gb)")),
+			),
+		},
+		{
+			Handler: a.locks.Serialize(appURN, a.addDomain),
+			Tool: mcp.NewTool("apps-add-domain",
+				mcp.WithDescription("Adds a custom domain to an app on DigitalOcean App Platform, leaving the rest of the app spec untouched. When the domain belongs to a domain in this account, App Platform manages its DNS records; otherwise the result has a warning to add a CNAME record at the DNS provider. App Platform provisions and renews the TLS certificate. The change triggers a new deployment."),
+				mcp.WithString("AppID", mcp.Required(), mcp.Description("The application ID")),
+				mcp.WithString("Domain", mcp.Required(), mcp.Description("Fully qualified domain name to add (e.g., app.example.com)")),
+				mcp.WithString("Zone", mcp.Description("Domain in this account whose DNS App Platform should manage (e.g., example.com). Detected from the account's domains when omitted")),
+				mcp.WithString("Type", mcp.Enum("PRIMARY", "ALIAS"), mcp.DefaultString("ALIAS"), mcp.Description("PRIMARY makes this the app's primary domain, turning the current primary into an alias")),
+				mcp.WithBoolean("Wildcard", mcp.DefaultBool(false), mcp.Description("Also serve all subdomains of Domain (*.Domain)")),
+				mcp.WithString("MinimumTLSVersion", mcp.Enum("1.2", "1.3"), mcp.Description("Minimum TLS version clients must use for this domain")),
+			),
+		},
+		{
+			Handler: a.locks.Serialize(appURN, a.removeDomain),
+			Tool: mcp.NewTool("apps-remove-domain",
+				mcp.WithDescription("Removes a custom domain from an app on DigitalOcean App Platform, leaving the rest of the app spec untouched. The change triggers a new deployment."),
+				mcp.WithDestructiveHintAnnotation(true),
+				mcp.WithString("AppID", mcp.Required(), mcp.Description("The application ID")),
+				mcp.WithString("Domain", mcp.Required(), mcp.Description("Domain to remove")),
+			),
+		},
+		{
+			Handler: a.locks.Serialize(appURN, a.updateCORS),
+			Tool: mcp.NewTool("apps-update-cors",
+				mcp.WithDescription("Updates the CORS policy of one ingress rule of an app on DigitalOcean App Platform, leaving the rest of the app spec untouched. Settings that are not given keep their current value. The result has the previous and new policy. The change triggers a new deployment."),
+				mcp.WithString("AppID", mcp.Required(), mcp.Description("The application ID")),
+				mcp.WithString("PathPrefix", mcp.DefaultString("/"), mcp.Description("Path prefix of the ingress rule to update")),
+				mcp.WithArray("AllowOrigins", mcp.Items(map[string]any{"type": "string"}), mcp.Description("Allowed origins, matched exactly (e.g., https://example.com). Prefix a value with regex: to match a regular expression")),
+				mcp.WithArray("AllowMethods", mcp.Items(map[string]any{"type": "string"}), mcp.Description("Allowed HTTP methods (e.g., GET, POST)")),
+				mcp.WithArray("AllowHeaders", mcp.Items(map[string]any{"type": "string"}), mcp.Description("Allowed request headers")),
+				mcp.WithArray("ExposeHeaders", mcp.Items(map[string]any{"type": "string"}), mcp.Description("Response headers browsers may expose to scripts")),
+				mcp.WithString("MaxAge", mcp.Description("How long browsers may cache a preflight response, as a duration (e.g., 5h30m)")),
+				mcp.WithBoolean("AllowCredentials", mcp.Description("Whether browsers expose responses to requests made with credentials")),
+				mcp.WithBoolean("Remove", mcp.DefaultBool(false), mcp.Description("Remove the rule's CORS policy instead of updating it")),
+			),
+		},
 	}
 
 	return tools
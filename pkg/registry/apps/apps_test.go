@@ -10,6 +10,7 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"mcp-digitalocean/pkg/registry/common"
 )
 
 type getClientFn func(ctx context.Context) (*godo.Client, error)
@@ -646,3 +647,278 @@ func TestGetAppLogs(t *testing.T) {
 	}
 
 }
+
+func TestDiffDeployments(t *testing.T) {
+	baseSpec := func() *godo.AppSpec {
+		return &godo.AppSpec{
+			Name: "my-app",
+			Services: []*godo.AppServiceSpec{
+				{
+					Name: "web",
+					Image: &godo.ImageSourceSpec{
+						RegistryType: godo.ImageSourceSpecRegistryType_DOCR,
+						Repository:   "web",
+						Tag:          "v1",
+					},
+					Envs: []*godo.AppVariableDefinition{
+						{Key: "PORT", Value: "8080"},
+						{Key: "LOG_LEVEL", Value: "info"},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mock        func(app *MockAppsService)
+		expected    DeploymentDiff
+		expectMcp   string
+		expectError bool
+	}{
+		{
+			name: "Env var changes between previous and active deployment",
+			args: map[string]any{"AppID": "app-123"},
+			mock: func(app *MockAppsService) {
+				to := baseSpec()
+				to.Services[0].Envs = []*godo.AppVariableDefinition{
+					{Key: "LOG_LEVEL", Value: "debug"},
+					{Key: "PORT", Value: "8080"},
+					{Key: "FEATURE_X", Value: "on"},
+				}
+				app.EXPECT().Get(gomock.Any(), "app-123").Return(&godo.App{ActiveDeployment: &godo.Deployment{ID: "dep-2"}}, nil, nil)
+				app.EXPECT().GetDeployment(gomock.Any(), "app-123", "dep-2").Return(&godo.Deployment{ID: "dep-2", PreviousDeploymentID: "dep-1", Spec: to}, nil, nil)
+				app.EXPECT().GetDeployment(gomock.Any(), "app-123", "dep-1").Return(&godo.Deployment{ID: "dep-1", Spec: baseSpec()}, nil, nil)
+			},
+			expected: DeploymentDiff{
+				AppID:  "app-123",
+				FromID: "dep-1",
+				ToID:   "dep-2",
+				Changes: []common.Change{
+					{Path: "services[name=web].envs[key=FEATURE_X]", Op: common.ChangeAdded, New: map[string]any{"key": "FEATURE_X", "value": "on"}},
+					{Path: "services[name=web].envs[key=LOG_LEVEL].value", Op: common.ChangeChanged, Old: "info", New: "debug"},
+				},
+				ChangesCount: 2,
+			},
+		},
+		{
+			name: "Component addition",
+			args: map[string]any{"AppID": "app-123", "DeploymentID1": "dep-1", "DeploymentID2": "dep-3"},
+			mock: func(app *MockAppsService) {
+				to := baseSpec()
+				to.Workers = []*godo.AppWorkerSpec{{Name: "queue", RunCommand: "./worker"}}
+				app.EXPECT().GetDeployment(gomock.Any(), "app-123", "dep-3").Return(&godo.Deployment{ID: "dep-3", Spec: to}, nil, nil)
+				app.EXPECT().GetDeployment(gomock.Any(), "app-123", "dep-1").Return(&godo.Deployment{ID: "dep-1", Spec: baseSpec()}, nil, nil)
+			},
+			expected: DeploymentDiff{
+				AppID:  "app-123",
+				FromID: "dep-1",
+				ToID:   "dep-3",
+				Changes: []common.Change{
+					{Path: "workers", Op: common.ChangeAdded, New: []any{map[string]any{"name": "queue", "run_command": "./worker"}}},
+				},
+				ChangesCount: 1,
+			},
+		},
+		{
+			name: "Image tag change",
+			args: map[string]any{"AppID": "app-123", "DeploymentID2": "dep-2"},
+			mock: func(app *MockAppsService) {
+				to := baseSpec()
+				to.Services[0].Image.Tag = "v2"
+				app.EXPECT().GetDeployment(gomock.Any(), "app-123", "dep-2").Return(&godo.Deployment{ID: "dep-2", PreviousDeploymentID: "dep-1", Spec: to}, nil, nil)
+				app.EXPECT().GetDeployment(gomock.Any(), "app-123", "dep-1").Return(&godo.Deployment{ID: "dep-1", Spec: baseSpec()}, nil, nil)
+			},
+			expected: DeploymentDiff{
+				AppID:  "app-123",
+				FromID: "dep-1",
+				ToID:   "dep-2",
+				Changes: []common.Change{
+					{Path: "services[name=web].image.tag", Op: common.ChangeChanged, Old: "v1", New: "v2"},
+				},
+				ChangesCount: 1,
+			},
+		},
+		{
+			name: "Identical specs",
+			args: map[string]any{"AppID": "app-123", "DeploymentID1": "dep-1", "DeploymentID2": "dep-2"},
+			mock: func(app *MockAppsService) {
+				app.EXPECT().GetDeployment(gomock.Any(), "app-123", "dep-2").Return(&godo.Deployment{ID: "dep-2", Spec: baseSpec()}, nil, nil)
+				app.EXPECT().GetDeployment(gomock.Any(), "app-123", "dep-1").Return(&godo.Deployment{ID: "dep-1", Spec: baseSpec()}, nil, nil)
+			},
+			expected: DeploymentDiff{AppID: "app-123", FromID: "dep-1", ToID: "dep-2", Changes: []common.Change{}},
+		},
+		{
+			name: "No previous deployment",
+			args: map[string]any{"AppID": "app-123", "DeploymentID2": "dep-1"},
+			mock: func(app *MockAppsService) {
+				app.EXPECT().GetDeployment(gomock.Any(), "app-123", "dep-1").Return(&godo.Deployment{ID: "dep-1", Spec: baseSpec()}, nil, nil)
+			},
+			expectMcp: "deployment dep-1 has no previous deployment; pass DeploymentID1 explicitly",
+		},
+		{
+			name: "No active deployment",
+			args: map[string]any{"AppID": "app-123"},
+			mock: func(app *MockAppsService) {
+				app.EXPECT().Get(gomock.Any(), "app-123").Return(&godo.App{}, nil, nil)
+			},
+			expectMcp: "app app-123 has no active deployment; pass DeploymentID2 explicitly",
+		},
+		{
+			name: "Error getting deployment",
+			args: map[string]any{"AppID": "app-123", "DeploymentID1": "dep-1", "DeploymentID2": "dep-2"},
+			mock: func(app *MockAppsService) {
+				app.EXPECT().GetDeployment(gomock.Any(), "app-123", "dep-2").Return(nil, nil, fmt.Errorf("api error"))
+			},
+			expectError: true,
+		},
+		{
+			name:      "Missing AppID",
+			args:      map[string]any{},
+			expectMcp: "App ID is required",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client, appService := setupMock(t)
+			tool := &AppPlatformTool{client: client}
+			if tc.mock != nil {
+				tc.mock(appService)
+			}
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.diffDeployments(context.Background(), req)
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			if tc.expectError {
+				require.True(t, resp.IsError)
+				return
+			}
+			if tc.expectMcp != "" {
+				require.True(t, resp.IsError)
+				require.Equal(t, tc.expectMcp, resp.Content[0].(mcp.TextContent).Text)
+				return
+			}
+
+			require.False(t, resp.IsError)
+			equalsToolResult(t, tc.expected, resp)
+		})
+	}
+}
+
+func TestUpsertApp(t *testing.T) {
+	spec := &godo.AppSpec{
+		Name:     "my-app",
+		Services: []*godo.AppServiceSpec{{Name: "web", Image: &godo.ImageSourceSpec{RegistryType: godo.ImageSourceSpecRegistryType_DOCR, Repository: "web", Tag: "v2"}}},
+	}
+	lastPage := &godo.Response{Links: &godo.Links{Pages: &godo.Pages{Prev: "https://api/v2/apps?page=1"}}}
+	morePages := &godo.Response{Links: &godo.Links{Pages: &godo.Pages{Next: "https://api/v2/apps?page=2", Last: "https://api/v2/apps?page=2"}}}
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mock        func(app *MockAppsService)
+		expected    AppUpsertResult
+		expectMcp   string
+		expectError bool
+	}{
+		{
+			name: "Creates when no app has the name",
+			args: map[string]any{"spec": spec, "project_id": "proj-1"},
+			mock: func(app *MockAppsService) {
+				app.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: upsertListPageSize}).Return([]*godo.App{
+					{ID: "app-other", Spec: &godo.AppSpec{Name: "my-app-staging"}},
+				}, nil, nil)
+				app.EXPECT().Create(gomock.Any(), &godo.AppCreateRequest{Spec: spec, ProjectID: "proj-1"}).Return(&godo.App{ID: "app-new", Spec: spec}, nil, nil)
+			},
+			expected: AppUpsertResult{App: &godo.App{ID: "app-new", Spec: spec}, Created: true},
+		},
+		{
+			name: "Updates the app found on a later page",
+			args: map[string]any{"spec": spec},
+			mock: func(app *MockAppsService) {
+				gomock.InOrder(
+					app.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: upsertListPageSize}).Return([]*godo.App{
+						{ID: "app-other", Spec: &godo.AppSpec{Name: "other"}},
+					}, morePages, nil),
+					app.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 2, PerPage: upsertListPageSize}).Return([]*godo.App{
+						{ID: "app-123", Spec: &godo.AppSpec{Name: "my-app"}},
+					}, lastPage, nil),
+				)
+				app.EXPECT().Update(gomock.Any(), "app-123", &godo.AppUpdateRequest{Spec: spec}).Return(&godo.App{ID: "app-123", Spec: spec}, nil, nil)
+			},
+			expected: AppUpsertResult{App: &godo.App{ID: "app-123", Spec: spec}},
+		},
+		{
+			name: "Validates the spec against the existing app",
+			args: map[string]any{"spec": spec, "ValidateSpec": true},
+			mock: func(app *MockAppsService) {
+				app.EXPECT().List(gomock.Any(), gomock.Any()).Return([]*godo.App{{ID: "app-123", Spec: &godo.AppSpec{Name: "my-app"}}}, nil, nil)
+				app.EXPECT().Propose(gomock.Any(), &godo.AppProposeRequest{Spec: spec, AppID: "app-123"}).Return(&godo.AppProposeResponse{}, nil, nil)
+				app.EXPECT().Update(gomock.Any(), "app-123", &godo.AppUpdateRequest{Spec: spec}).Return(&godo.App{ID: "app-123", Spec: spec}, nil, nil)
+			},
+			expected: AppUpsertResult{App: &godo.App{ID: "app-123", Spec: spec}},
+		},
+		{
+			name: "Invalid spec is not created",
+			args: map[string]any{"spec": spec, "ValidateSpec": true},
+			mock: func(app *MockAppsService) {
+				app.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil, nil)
+				app.EXPECT().Propose(gomock.Any(), &godo.AppProposeRequest{Spec: spec}).Return(nil, nil, fmt.Errorf("invalid service web"))
+			},
+			expectError: true,
+		},
+		{
+			name:      "Missing spec name",
+			args:      map[string]any{"spec": &godo.AppSpec{}},
+			expectMcp: "App spec with a name is required",
+		},
+		{
+			name: "List error",
+			args: map[string]any{"spec": spec},
+			mock: func(app *MockAppsService) {
+				app.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil, fmt.Errorf("api error"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client, appService := setupMock(t)
+			tool := &AppPlatformTool{client: client, locks: common.SharedResourceLocks}
+			if tc.mock != nil {
+				tc.mock(appService)
+			}
+
+			// arguments arrive as decoded JSON.
+			var args map[string]any
+			require.NoError(t, json.Unmarshal([]byte(toJSONString(tc.args)), &args))
+
+			resp, err := tool.upsertApp(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+
+			switch {
+			case tc.expectMcp != "":
+				require.True(t, resp.IsError)
+				require.Equal(t, tc.expectMcp, resp.Content[0].(mcp.TextContent).Text)
+			case tc.expectError:
+				require.True(t, resp.IsError)
+			default:
+				require.False(t, resp.IsError)
+				equalsToolResult(t, tc.expected, resp)
+			}
+		})
+	}
+}
+
+func TestUpsertAppSchema(t *testing.T) {
+	var schema struct {
+		Properties map[string]map[string]any `json:"properties"`
+	}
+	require.NoError(t, json.Unmarshal(appUpsertSchemaJSON, &schema))
+	require.Equal(t, "boolean", schema.Properties["ValidateSpec"]["type"])
+	require.Contains(t, schema.Properties, "spec")
+}
@@ -0,0 +1,416 @@
+package apps
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"mcp-digitalocean/pkg/registry/common"
+)
+
+// DomainResult is the result of apps-add-domain and apps-remove-domain.
+type DomainResult struct {
+	AppID  string `json:"app_id"`
+	Domain string `json:"domain"`
+	// Zone is the DigitalOcean DNS zone App Platform manages the domain's
+	// records in. It is empty when the domain's DNS is managed elsewhere.
+	Zone string `json:"zone,omitempty"`
+	// Domains are the app's domains after the change.
+	Domains             []string `json:"domains"`
+	PendingDeploymentID string   `json:"pending_deployment_id,omitempty"`
+	Warning             string   `json:"warning,omitempty"`
+}
+
+// CORSResult is the result of apps-update-cors.
+type CORSResult struct {
+	AppID               string              `json:"app_id"`
+	PathPrefix          string              `json:"path_prefix"`
+	Previous            *godo.AppCORSPolicy `json:"previous"`
+	Current             *godo.AppCORSPolicy `json:"current"`
+	PendingDeploymentID string              `json:"pending_deployment_id,omitempty"`
+}
+
+// corsMethods are the HTTP methods a CORS policy can allow.
+var corsMethods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS", "CONNECT", "TRACE"}
+
+// appDomainNames returns the domain names in spec, in spec order.
+func appDomainNames(spec *godo.AppSpec) []string {
+	names := make([]string, 0, len(spec.Domains))
+	for _, d := range spec.Domains {
+		names = append(names, d.Domain)
+	}
+	return names
+}
+
+// findZone returns the account's DNS zone that domain belongs to, trying the
+// domain itself and then each parent with at least two labels. It returns ""
+// when none of them is a domain in the account.
+func findZone(ctx context.Context, client *godo.Client, domain string) (string, error) {
+	labels := strings.Split(domain, ".")
+	for i := 0; i < len(labels)-1; i++ {
+		candidate := strings.Join(labels[i:], ".")
+		_, resp, err := client.Domains.Get(ctx, candidate)
+		if err == nil {
+			return candidate, nil
+		}
+		if resp == nil || resp.Response == nil || resp.StatusCode != http.StatusNotFound {
+			return "", err
+		}
+	}
+	return "", nil
+}
+
+// updateAppSpec sends the changed spec and returns the ID of the deployment it
+// started, if any.
+func updateAppSpec(ctx context.Context, client *godo.Client, appID string, spec *godo.AppSpec) (string, error) {
+	updated, _, err := client.Apps.Update(ctx, appID, &godo.AppUpdateRequest{Spec: spec})
+	if err != nil {
+		return "", err
+	}
+	if updated != nil && updated.PendingDeployment != nil {
+		return updated.PendingDeployment.ID, nil
+	}
+	return "", nil
+}
+
+// getAppSpec fetches the app and returns its spec, or a tool error result.
+func getAppSpec(ctx context.Context, client *godo.Client, appID string) (*godo.AppSpec, *mcp.CallToolResult) {
+	app, _, err := client.Apps.Get(ctx, appID)
+	if err != nil {
+		return nil, mcp.NewToolResultErrorFromErr(fmt.Sprintf("failed to get app %s", appID), err)
+	}
+	if app.Spec == nil {
+		return nil, mcp.NewToolResultError(fmt.Sprintf("app %s has no spec", appID))
+	}
+	return app.Spec, nil
+}
+
+// addDomain adds a custom domain to an app, detecting the DigitalOcean DNS
+// zone it belongs to so App Platform can manage its records.
+func (a *AppPlatformTool) addDomain(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	appID, ok := args["AppID"].(string)
+	if !ok || appID == "" {
+		return mcp.NewToolResultError("App ID is required"), nil
+	}
+	domain, _ := args["Domain"].(string)
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	if !common.ValidHostname(domain) || !strings.Contains(domain, ".") {
+		return mcp.NewToolResultError("Domain must be a fully qualified domain name, e.g. app.example.com; use Wildcard for *.example.com"), nil
+	}
+	zone, _ := args["Zone"].(string)
+	zone = strings.ToLower(strings.TrimSuffix(zone, "."))
+	if zone != "" && zone != domain && !strings.HasSuffix(domain, "."+zone) {
+		return mcp.NewToolResultError(fmt.Sprintf("Zone %s does not contain %s", zone, domain)), nil
+	}
+	domainType := godo.AppDomainSpecType_Alias
+	if t, _ := args["Type"].(string); t != "" {
+		domainType = godo.AppDomainSpecType(strings.ToUpper(t))
+		if domainType != godo.AppDomainSpecType_Primary && domainType != godo.AppDomainSpecType_Alias {
+			return mcp.NewToolResultError("Type must be PRIMARY or ALIAS"), nil
+		}
+	}
+	wildcard, _ := args["Wildcard"].(bool)
+	minTLS, _ := args["MinimumTLSVersion"].(string)
+	if minTLS != "" && minTLS != "1.2" && minTLS != "1.3" {
+		return mcp.NewToolResultError("MinimumTLSVersion must be 1.2 or 1.3"), nil
+	}
+
+	client, err := a.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	spec, errResult := getAppSpec(ctx, client, appID)
+	if errResult != nil {
+		return errResult, nil
+	}
+	if slices.Contains(appDomainNames(spec), domain) {
+		return mcp.NewToolResultError(fmt.Sprintf("app %s already has domain %s", appID, domain)), nil
+	}
+
+	// A zone that is not in the account would fail the update, so the domain
+	// is added without one and its DNS is left to the user.
+	var warning string
+	if zone != "" {
+		_, resp, err := client.Domains.Get(ctx, zone)
+		if err != nil {
+			if resp == nil || resp.Response == nil || resp.StatusCode != http.StatusNotFound {
+				return common.ErrorResult(ctx, a.Logger(ctx), fmt.Sprintf("failed to look up zone %s", zone), err), nil
+			}
+			warning = fmt.Sprintf("zone %s is not a domain in this account, so its DNS is managed elsewhere; the domain was added without a zone. Point %s at the app's default ingress with a CNAME record at your DNS provider", zone, domain)
+			zone = ""
+		}
+	} else {
+		zone, err = findZone(ctx, client, domain)
+		if err != nil {
+			return common.ErrorResult(ctx, a.Logger(ctx), fmt.Sprintf("failed to look up the DNS zone of %s", domain), err), nil
+		}
+		if zone == "" {
+			warning = fmt.Sprintf("no domain in this account contains %s, so its DNS is managed elsewhere. Point it at the app's default ingress with a CNAME record at your DNS provider", domain)
+		}
+	}
+
+	// An app has at most one primary domain.
+	if domainType == godo.AppDomainSpecType_Primary {
+		for _, d := range spec.Domains {
+			if d.Type == godo.AppDomainSpecType_Primary {
+				d.Type = godo.AppDomainSpecType_Alias
+			}
+		}
+	}
+	spec.Domains = append(spec.Domains, &godo.AppDomainSpec{
+		Domain:            domain,
+		Type:              domainType,
+		Wildcard:          wildcard,
+		Zone:              zone,
+		MinimumTLSVersion: minTLS,
+	})
+
+	deploymentID, err := updateAppSpec(ctx, client, appID, spec)
+	if err != nil {
+		return common.ErrorResult(ctx, a.Logger(ctx), fmt.Sprintf("failed to update app %s", appID), err), nil
+	}
+
+	return marshalResult(DomainResult{
+		AppID:               appID,
+		Domain:              domain,
+		Zone:                zone,
+		Domains:             appDomainNames(spec),
+		PendingDeploymentID: deploymentID,
+		Warning:             warning,
+	})
+}
+
+// removeDomain removes a custom domain from an app.
+func (a *AppPlatformTool) removeDomain(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	appID, ok := args["AppID"].(string)
+	if !ok || appID == "" {
+		return mcp.NewToolResultError("App ID is required"), nil
+	}
+	domain, _ := args["Domain"].(string)
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	if domain == "" {
+		return mcp.NewToolResultError("Domain is required"), nil
+	}
+
+	client, err := a.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	spec, errResult := getAppSpec(ctx, client, appID)
+	if errResult != nil {
+		return errResult, nil
+	}
+	i := slices.IndexFunc(spec.Domains, func(d *godo.AppDomainSpec) bool { return strings.EqualFold(d.Domain, domain) })
+	if i < 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("app %s has no domain %s; its domains are %v", appID, domain, appDomainNames(spec))), nil
+	}
+	removed := spec.Domains[i]
+	spec.Domains = slices.Delete(spec.Domains, i, i+1)
+
+	deploymentID, err := updateAppSpec(ctx, client, appID, spec)
+	if err != nil {
+		return common.ErrorResult(ctx, a.Logger(ctx), fmt.Sprintf("failed to update app %s", appID), err), nil
+	}
+
+	return marshalResult(DomainResult{
+		AppID:               appID,
+		Domain:              removed.Domain,
+		Zone:                removed.Zone,
+		Domains:             appDomainNames(spec),
+		PendingDeploymentID: deploymentID,
+	})
+}
+
+// updateCORS merges the given CORS settings into the policy of the ingress
+// rule matching PathPrefix. Settings that are not given keep their value.
+func (a *AppPlatformTool) updateCORS(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	appID, ok := args["AppID"].(string)
+	if !ok || appID == "" {
+		return mcp.NewToolResultError("App ID is required"), nil
+	}
+	pathPrefix, _ := args["PathPrefix"].(string)
+	if pathPrefix == "" {
+		pathPrefix = "/"
+	}
+	remove, _ := args["Remove"].(bool)
+	update, message := parseCORSUpdate(args)
+	if message != "" {
+		return mcp.NewToolResultError(message), nil
+	}
+	switch {
+	case remove && update != (corsUpdate{}):
+		return mcp.NewToolResultError("Remove cannot be combined with CORS settings"), nil
+	case !remove && update == (corsUpdate{}):
+		return mcp.NewToolResultError("at least one CORS setting, or Remove, is required"), nil
+	}
+
+	client, err := a.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	spec, errResult := getAppSpec(ctx, client, appID)
+	if errResult != nil {
+		return errResult, nil
+	}
+	rule, message := findIngressRule(spec, pathPrefix)
+	if message != "" {
+		return mcp.NewToolResultError(message), nil
+	}
+
+	previous := rule.CORS
+	if remove {
+		rule.CORS = nil
+	} else {
+		rule.CORS = update.apply(previous)
+	}
+
+	deploymentID, err := updateAppSpec(ctx, client, appID, spec)
+	if err != nil {
+		return common.ErrorResult(ctx, a.Logger(ctx), fmt.Sprintf("failed to update app %s", appID), err), nil
+	}
+
+	return marshalResult(CORSResult{
+		AppID:               appID,
+		PathPrefix:          pathPrefix,
+		Previous:            previous,
+		Current:             rule.CORS,
+		PendingDeploymentID: deploymentID,
+	})
+}
+
+// findIngressRule returns the ingress rule whose path prefix is pathPrefix. The
+// message is non-empty when there is none.
+func findIngressRule(spec *godo.AppSpec, pathPrefix string) (*godo.AppIngressSpecRule, string) {
+	if spec.Ingress == nil || len(spec.Ingress.Rules) == 0 {
+		return nil, "app has no ingress rules; CORS policies are set on ingress rules, so add routes to the app spec's ingress with apps-update first"
+	}
+	var prefixes []string
+	for _, rule := range spec.Ingress.Rules {
+		if rule.Match == nil || rule.Match.Path == nil || rule.Match.Path.Prefix == nil {
+			continue
+		}
+		if *rule.Match.Path.Prefix == pathPrefix {
+			return rule, ""
+		}
+		prefixes = append(prefixes, *rule.Match.Path.Prefix)
+	}
+	return nil, fmt.Sprintf("app has no ingress rule for path prefix %s; its rules match %v", pathPrefix, prefixes)
+}
+
+// corsUpdate holds the CORS settings given to apps-update-cors. A nil field was
+// not given and keeps its current value.
+type corsUpdate struct {
+	allowOrigins     *[]*godo.AppStringMatch
+	allowMethods     *[]string
+	allowHeaders     *[]string
+	exposeHeaders    *[]string
+	maxAge           *string
+	allowCredentials *bool
+}
+
+// parseCORSUpdate reads the CORS settings from args. The message is non-empty
+// when a setting is invalid.
+func parseCORSUpdate(args map[string]any) (corsUpdate, string) {
+	var u corsUpdate
+	if raw, ok := args["AllowOrigins"].([]any); ok {
+		origins := make([]*godo.AppStringMatch, 0, len(raw))
+		for _, o := range raw {
+			s, _ := o.(string)
+			switch {
+			case s == "":
+				return u, "AllowOrigins must be non-empty strings"
+			case strings.HasPrefix(s, "regex:"):
+				origins = append(origins, &godo.AppStringMatch{Regex: strings.TrimPrefix(s, "regex:")})
+			default:
+				origins = append(origins, &godo.AppStringMatch{Exact: s})
+			}
+		}
+		u.allowOrigins = &origins
+	}
+	if raw, ok := args["AllowMethods"].([]any); ok {
+		methods := make([]string, 0, len(raw))
+		for _, m := range raw {
+			s, _ := m.(string)
+			s = strings.ToUpper(s)
+			if !slices.Contains(corsMethods, s) {
+				return u, fmt.Sprintf("AllowMethods must be HTTP methods (%s), got %q", strings.Join(corsMethods, ", "), m)
+			}
+			methods = append(methods, s)
+		}
+		u.allowMethods = &methods
+	}
+	u.allowHeaders = stringList(args, "AllowHeaders")
+	u.exposeHeaders = stringList(args, "ExposeHeaders")
+	if s, ok := args["MaxAge"].(string); ok {
+		if _, err := time.ParseDuration(s); err != nil {
+			return u, fmt.Sprintf("MaxAge must be a duration such as 5h30m, got %q", s)
+		}
+		u.maxAge = &s
+	}
+	if b, ok := args["AllowCredentials"].(bool); ok {
+		u.allowCredentials = &b
+	}
+	return u, ""
+}
+
+// stringList returns the strings in args[key], or nil when key is not given.
+func stringList(args map[string]any, key string) *[]string {
+	raw, ok := args[key].([]any)
+	if !ok {
+		return nil
+	}
+	values := make([]string, 0, len(raw))
+	for _, v := range raw {
+		if s, ok := v.(string); ok && s != "" {
+			values = append(values, s)
+		}
+	}
+	return &values
+}
+
+// apply returns a copy of current with the given settings replaced.
+func (u corsUpdate) apply(current *godo.AppCORSPolicy) *godo.AppCORSPolicy {
+	merged := &godo.AppCORSPolicy{}
+	if current != nil {
+		*merged = *current
+	}
+	if u.allowOrigins != nil {
+		merged.AllowOrigins = *u.allowOrigins
+	}
+	if u.allowMethods != nil {
+		merged.AllowMethods = *u.allowMethods
+	}
+	if u.allowHeaders != nil {
+		merged.AllowHeaders = *u.allowHeaders
+	}
+	if u.exposeHeaders != nil {
+		merged.ExposeHeaders = *u.exposeHeaders
+	}
+	if u.maxAge != nil {
+		merged.MaxAge = *u.maxAge
+	}
+	if u.allowCredentials != nil {
+		merged.AllowCredentials = *u.allowCredentials
+	}
+	return merged
+}
+
+func marshalResult(v any) (*mcp.CallToolResult, error) {
+	resultJSON, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
@@ -0,0 +1,362 @@
+package apps
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func setupDomainsMock(t *testing.T) (*AppPlatformTool, *MockAppsService, *MockDomainsService) {
+	ctrl := gomock.NewController(t)
+	apps := NewMockAppsService(ctrl)
+	domains := NewMockDomainsService(ctrl)
+	tool, err := NewAppPlatformTool(func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{Apps: apps, Domains: domains}, nil
+	})
+	require.NoError(t, err)
+	return tool, apps, domains
+}
+
+// notFound is the response the API sends for a domain that is not in the account.
+var notFound = &godo.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}
+
+func TestAddDomain(t *testing.T) {
+	appSpec := func() *godo.AppSpec {
+		return &godo.AppSpec{
+			Name:     "my-app",
+			Services: []*godo.AppServiceSpec{{Name: "web"}},
+			Domains:  []*godo.AppDomainSpec{{Domain: "www.example.com", Type: godo.AppDomainSpecType_Primary, Zone: "example.com"}},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		args      map[string]any
+		mock      func(app *MockAppsService, domains *MockDomainsService)
+		expected  DomainResult
+		expectMcp string
+	}{
+		{
+			name: "Zone detected from the account's domains",
+			args: map[string]any{"AppID": "app-123", "Domain": "API.staging.example.com."},
+			mock: func(app *MockAppsService, domains *MockDomainsService) {
+				app.EXPECT().Get(gomock.Any(), "app-123").Return(&godo.App{ID: "app-123", Spec: appSpec()}, nil, nil)
+				gomock.InOrder(
+					domains.EXPECT().Get(gomock.Any(), "api.staging.example.com").Return(nil, notFound, errors.New("not found")),
+					domains.EXPECT().Get(gomock.Any(), "staging.example.com").Return(nil, notFound, errors.New("not found")),
+					domains.EXPECT().Get(gomock.Any(), "example.com").Return(&godo.Domain{Name: "example.com"}, nil, nil),
+				)
+				expectedSpec := appSpec()
+				expectedSpec.Domains = append(expectedSpec.Domains, &godo.AppDomainSpec{Domain: "api.staging.example.com", Type: godo.AppDomainSpecType_Alias, Zone: "example.com"})
+				app.EXPECT().Update(gomock.Any(), "app-123", &godo.AppUpdateRequest{Spec: expectedSpec}).
+					Return(&godo.App{ID: "app-123", PendingDeployment: &godo.Deployment{ID: "dep-1"}}, nil, nil)
+			},
+			expected: DomainResult{
+				AppID:               "app-123",
+				Domain:              "api.staging.example.com",
+				Zone:                "example.com",
+				Domains:             []string{"www.example.com", "api.staging.example.com"},
+				PendingDeploymentID: "dep-1",
+			},
+		},
+		{
+			name: "Domain managed elsewhere",
+			args: map[string]any{"AppID": "app-123", "Domain": "shop.other.io"},
+			mock: func(app *MockAppsService, domains *MockDomainsService) {
+				app.EXPECT().Get(gomock.Any(), "app-123").Return(&godo.App{ID: "app-123", Spec: appSpec()}, nil, nil)
+				domains.EXPECT().Get(gomock.Any(), "shop.other.io").Return(nil, notFound, errors.New("not found"))
+				domains.EXPECT().Get(gomock.Any(), "other.io").Return(nil, notFound, errors.New("not found"))
+				expectedSpec := appSpec()
+				expectedSpec.Domains = append(expectedSpec.Domains, &godo.AppDomainSpec{Domain: "shop.other.io", Type: godo.AppDomainSpecType_Alias})
+				app.EXPECT().Update(gomock.Any(), "app-123", &godo.AppUpdateRequest{Spec: expectedSpec}).Return(&godo.App{ID: "app-123"}, nil, nil)
+			},
+			expected: DomainResult{
+				AppID:   "app-123",
+				Domain:  "shop.other.io",
+				Domains: []string{"www.example.com", "shop.other.io"},
+				Warning: "no domain in this account contains shop.other.io, so its DNS is managed elsewhere. Point it at the app's default ingress with a CNAME record at your DNS provider",
+			},
+		},
+		{
+			name: "Given zone not in the account is dropped with a warning",
+			args: map[string]any{"AppID": "app-123", "Domain": "shop.other.io", "Zone": "other.io"},
+			mock: func(app *MockAppsService, domains *MockDomainsService) {
+				app.EXPECT().Get(gomock.Any(), "app-123").Return(&godo.App{ID: "app-123", Spec: appSpec()}, nil, nil)
+				domains.EXPECT().Get(gomock.Any(), "other.io").Return(nil, notFound, errors.New("not found"))
+				expectedSpec := appSpec()
+				expectedSpec.Domains = append(expectedSpec.Domains, &godo.AppDomainSpec{Domain: "shop.other.io", Type: godo.AppDomainSpecType_Alias})
+				app.EXPECT().Update(gomock.Any(), "app-123", &godo.AppUpdateRequest{Spec: expectedSpec}).Return(&godo.App{ID: "app-123"}, nil, nil)
+			},
+			expected: DomainResult{
+				AppID:   "app-123",
+				Domain:  "shop.other.io",
+				Domains: []string{"www.example.com", "shop.other.io"},
+				Warning: "zone other.io is not a domain in this account, so its DNS is managed elsewhere; the domain was added without a zone. Point shop.other.io at the app's default ingress with a CNAME record at your DNS provider",
+			},
+		},
+		{
+			name: "New primary demotes the current one",
+			args: map[string]any{"AppID": "app-123", "Domain": "example.com", "Zone": "example.com", "Type": "primary", "MinimumTLSVersion": "1.3"},
+			mock: func(app *MockAppsService, domains *MockDomainsService) {
+				app.EXPECT().Get(gomock.Any(), "app-123").Return(&godo.App{ID: "app-123", Spec: appSpec()}, nil, nil)
+				domains.EXPECT().Get(gomock.Any(), "example.com").Return(&godo.Domain{Name: "example.com"}, nil, nil)
+				expectedSpec := appSpec()
+				expectedSpec.Domains[0].Type = godo.AppDomainSpecType_Alias
+				expectedSpec.Domains = append(expectedSpec.Domains, &godo.AppDomainSpec{Domain: "example.com", Type: godo.AppDomainSpecType_Primary, Zone: "example.com", MinimumTLSVersion: "1.3"})
+				app.EXPECT().Update(gomock.Any(), "app-123", &godo.AppUpdateRequest{Spec: expectedSpec}).Return(&godo.App{ID: "app-123"}, nil, nil)
+			},
+			expected: DomainResult{
+				AppID:   "app-123",
+				Domain:  "example.com",
+				Zone:    "example.com",
+				Domains: []string{"www.example.com", "example.com"},
+			},
+		},
+		{
+			name: "Domain already on the app",
+			args: map[string]any{"AppID": "app-123", "Domain": "www.example.com"},
+			mock: func(app *MockAppsService, domains *MockDomainsService) {
+				app.EXPECT().Get(gomock.Any(), "app-123").Return(&godo.App{ID: "app-123", Spec: appSpec()}, nil, nil)
+			},
+			expectMcp: "app app-123 already has domain www.example.com",
+		},
+		{
+			name:      "Invalid domain",
+			args:      map[string]any{"AppID": "app-123", "Domain": "bad_domain.example.com"},
+			mock:      func(app *MockAppsService, domains *MockDomainsService) {},
+			expectMcp: "Domain must be a fully qualified domain name",
+		},
+		{
+			name:      "Single label domain",
+			args:      map[string]any{"AppID": "app-123", "Domain": "localhost"},
+			mock:      func(app *MockAppsService, domains *MockDomainsService) {},
+			expectMcp: "Domain must be a fully qualified domain name",
+		},
+		{
+			name:      "Zone does not contain the domain",
+			args:      map[string]any{"AppID": "app-123", "Domain": "app.example.com", "Zone": "ample.com"},
+			mock:      func(app *MockAppsService, domains *MockDomainsService) {},
+			expectMcp: "Zone ample.com does not contain app.example.com",
+		},
+		{
+			name: "Zone lookup fails",
+			args: map[string]any{"AppID": "app-123", "Domain": "app.example.com"},
+			mock: func(app *MockAppsService, domains *MockDomainsService) {
+				app.EXPECT().Get(gomock.Any(), "app-123").Return(&godo.App{ID: "app-123", Spec: appSpec()}, nil, nil)
+				domains.EXPECT().Get(gomock.Any(), "app.example.com").Return(nil, nil, errors.New("connection reset"))
+			},
+			expectMcp: "failed to look up the DNS zone of app.example.com",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tool, mockApps, mockDomains := setupDomainsMock(t)
+			tc.mock(mockApps, mockDomains)
+
+			resp, err := tool.addDomain(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			if tc.expectMcp != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.expectMcp)
+				return
+			}
+			require.False(t, resp.IsError)
+			equalsToolResult(t, tc.expected, resp)
+		})
+	}
+}
+
+func TestRemoveDomain(t *testing.T) {
+	appSpec := func() *godo.AppSpec {
+		return &godo.AppSpec{
+			Name: "my-app",
+			Domains: []*godo.AppDomainSpec{
+				{Domain: "www.example.com", Type: godo.AppDomainSpecType_Primary, Zone: "example.com"},
+				{Domain: "old.example.com", Type: godo.AppDomainSpecType_Alias, Zone: "example.com"},
+			},
+		}
+	}
+
+	t.Run("Removes the domain", func(t *testing.T) {
+		tool, mockApps, _ := setupDomainsMock(t)
+		mockApps.EXPECT().Get(gomock.Any(), "app-123").Return(&godo.App{ID: "app-123", Spec: appSpec()}, nil, nil)
+		expectedSpec := appSpec()
+		expectedSpec.Domains = expectedSpec.Domains[:1]
+		mockApps.EXPECT().Update(gomock.Any(), "app-123", &godo.AppUpdateRequest{Spec: expectedSpec}).
+			Return(&godo.App{ID: "app-123", PendingDeployment: &godo.Deployment{ID: "dep-2"}}, nil, nil)
+
+		resp, err := tool.removeDomain(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"AppID": "app-123", "Domain": "Old.Example.com"}}})
+		require.NoError(t, err)
+		require.False(t, resp.IsError)
+		equalsToolResult(t, DomainResult{
+			AppID:               "app-123",
+			Domain:              "old.example.com",
+			Zone:                "example.com",
+			Domains:             []string{"www.example.com"},
+			PendingDeploymentID: "dep-2",
+		}, resp)
+	})
+
+	t.Run("Unknown domain", func(t *testing.T) {
+		tool, mockApps, _ := setupDomainsMock(t)
+		mockApps.EXPECT().Get(gomock.Any(), "app-123").Return(&godo.App{ID: "app-123", Spec: appSpec()}, nil, nil)
+
+		resp, err := tool.removeDomain(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"AppID": "app-123", "Domain": "api.example.com"}}})
+		require.NoError(t, err)
+		require.True(t, resp.IsError)
+		require.Contains(t, resp.Content[0].(mcp.TextContent).Text, "app app-123 has no domain api.example.com")
+	})
+}
+
+func TestUpdateCORS(t *testing.T) {
+	prefix := func(p string) *godo.AppIngressSpecRuleMatch {
+		return &godo.AppIngressSpecRuleMatch{Path: &godo.AppIngressSpecRuleStringMatch{Prefix: &p}}
+	}
+	existingPolicy := func() *godo.AppCORSPolicy {
+		return &godo.AppCORSPolicy{
+			AllowOrigins: []*godo.AppStringMatch{{Exact: "https://old.example.com"}},
+			AllowMethods: []string{"GET"},
+			MaxAge:       "1h",
+		}
+	}
+	appSpec := func() *godo.AppSpec {
+		return &godo.AppSpec{
+			Name: "my-app",
+			Ingress: &godo.AppIngressSpec{Rules: []*godo.AppIngressSpecRule{
+				{Match: prefix("/"), Component: &godo.AppIngressSpecRuleRoutingComponent{Name: "web"}},
+				{Match: prefix("/api"), Component: &godo.AppIngressSpecRuleRoutingComponent{Name: "api"}, CORS: existingPolicy()},
+			}},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		args      map[string]any
+		spec      *godo.AppSpec
+		mock      func(app *MockAppsService)
+		expected  CORSResult
+		expectMcp string
+	}{
+		{
+			name: "Merges into the existing policy",
+			args: map[string]any{
+				"AppID":        "app-123",
+				"PathPrefix":   "/api",
+				"AllowOrigins": []any{"https://app.example.com", `regex:^https://.*\.example\.com$`},
+				"AllowMethods": []any{"get", "POST"},
+			},
+			mock: func(app *MockAppsService) {
+				app.EXPECT().Get(gomock.Any(), "app-123").Return(&godo.App{ID: "app-123", Spec: appSpec()}, nil, nil)
+				expectedSpec := appSpec()
+				expectedSpec.Ingress.Rules[1].CORS = &godo.AppCORSPolicy{
+					AllowOrigins: []*godo.AppStringMatch{{Exact: "https://app.example.com"}, {Regex: `^https://.*\.example\.com$`}},
+					AllowMethods: []string{"GET", "POST"},
+					MaxAge:       "1h",
+				}
+				app.EXPECT().Update(gomock.Any(), "app-123", &godo.AppUpdateRequest{Spec: expectedSpec}).
+					Return(&godo.App{ID: "app-123", PendingDeployment: &godo.Deployment{ID: "dep-3"}}, nil, nil)
+			},
+			expected: CORSResult{
+				AppID:      "app-123",
+				PathPrefix: "/api",
+				Previous:   existingPolicy(),
+				Current: &godo.AppCORSPolicy{
+					AllowOrigins: []*godo.AppStringMatch{{Exact: "https://app.example.com"}, {Regex: `^https://.*\.example\.com$`}},
+					AllowMethods: []string{"GET", "POST"},
+					MaxAge:       "1h",
+				},
+				PendingDeploymentID: "dep-3",
+			},
+		},
+		{
+			name: "Adds a policy to the default rule",
+			args: map[string]any{"AppID": "app-123", "AllowOrigins": []any{"*"}, "AllowCredentials": false},
+			mock: func(app *MockAppsService) {
+				app.EXPECT().Get(gomock.Any(), "app-123").Return(&godo.App{ID: "app-123", Spec: appSpec()}, nil, nil)
+				expectedSpec := appSpec()
+				expectedSpec.Ingress.Rules[0].CORS = &godo.AppCORSPolicy{AllowOrigins: []*godo.AppStringMatch{{Exact: "*"}}}
+				app.EXPECT().Update(gomock.Any(), "app-123", &godo.AppUpdateRequest{Spec: expectedSpec}).Return(&godo.App{ID: "app-123"}, nil, nil)
+			},
+			expected: CORSResult{
+				AppID:      "app-123",
+				PathPrefix: "/",
+				Current:    &godo.AppCORSPolicy{AllowOrigins: []*godo.AppStringMatch{{Exact: "*"}}},
+			},
+		},
+		{
+			name: "Removes the policy",
+			args: map[string]any{"AppID": "app-123", "PathPrefix": "/api", "Remove": true},
+			mock: func(app *MockAppsService) {
+				app.EXPECT().Get(gomock.Any(), "app-123").Return(&godo.App{ID: "app-123", Spec: appSpec()}, nil, nil)
+				expectedSpec := appSpec()
+				expectedSpec.Ingress.Rules[1].CORS = nil
+				app.EXPECT().Update(gomock.Any(), "app-123", &godo.AppUpdateRequest{Spec: expectedSpec}).Return(&godo.App{ID: "app-123"}, nil, nil)
+			},
+			expected: CORSResult{AppID: "app-123", PathPrefix: "/api", Previous: existingPolicy()},
+		},
+		{
+			name: "Unknown path prefix",
+			args: map[string]any{"AppID": "app-123", "PathPrefix": "/v2", "AllowMethods": []any{"GET"}},
+			mock: func(app *MockAppsService) {
+				app.EXPECT().Get(gomock.Any(), "app-123").Return(&godo.App{ID: "app-123", Spec: appSpec()}, nil, nil)
+			},
+			expectMcp: "app has no ingress rule for path prefix /v2; its rules match [/ /api]",
+		},
+		{
+			name: "App without ingress rules",
+			args: map[string]any{"AppID": "app-123", "AllowMethods": []any{"GET"}},
+			mock: func(app *MockAppsService) {
+				app.EXPECT().Get(gomock.Any(), "app-123").Return(&godo.App{ID: "app-123", Spec: &godo.AppSpec{Name: "my-app"}}, nil, nil)
+			},
+			expectMcp: "app has no ingress rules",
+		},
+		{
+			name:      "Invalid method",
+			args:      map[string]any{"AppID": "app-123", "AllowMethods": []any{"FETCH"}},
+			mock:      func(app *MockAppsService) {},
+			expectMcp: `AllowMethods must be HTTP methods`,
+		},
+		{
+			name:      "Invalid max age",
+			args:      map[string]any{"AppID": "app-123", "MaxAge": "a day"},
+			mock:      func(app *MockAppsService) {},
+			expectMcp: `MaxAge must be a duration such as 5h30m, got "a day"`,
+		},
+		{
+			name:      "Nothing to change",
+			args:      map[string]any{"AppID": "app-123"},
+			mock:      func(app *MockAppsService) {},
+			expectMcp: "at least one CORS setting, or Remove, is required",
+		},
+		{
+			name:      "Remove with settings",
+			args:      map[string]any{"AppID": "app-123", "Remove": true, "MaxAge": "1h"},
+			mock:      func(app *MockAppsService) {},
+			expectMcp: "Remove cannot be combined with CORS settings",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tool, mockApps, _ := setupDomainsMock(t)
+			tc.mock(mockApps)
+
+			resp, err := tool.updateCORS(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			if tc.expectMcp != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.expectMcp)
+				return
+			}
+			require.False(t, resp.IsError)
+			equalsToolResult(t, tc.expected, resp)
+		})
+	}
+}
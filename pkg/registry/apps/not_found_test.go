@@ -0,0 +1,89 @@
+package apps
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"mcp-digitalocean/internal/testhelpers"
+	"mcp-digitalocean/pkg/registry/common"
+)
+
+func TestGetToolsNotFound(t *testing.T) {
+	tests := []struct {
+		name     string
+		handler  func(*AppPlatformTool, context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)
+		args     map[string]any
+		mock     func(app *MockAppsService, err error)
+		resource string
+		id       string
+	}{
+		{
+			name:    "app",
+			handler: (*AppPlatformTool).getAppInfo,
+			args:    map[string]any{"AppID": "missing"},
+			mock: func(app *MockAppsService, err error) {
+				app.EXPECT().Get(gomock.Any(), "missing").Return(nil, nil, err)
+			},
+			resource: "app", id: "missing",
+		},
+		{
+			name:    "deployment status",
+			handler: (*AppPlatformTool).getDeploymentStatus,
+			args:    map[string]any{"AppID": "missing"},
+			mock: func(app *MockAppsService, err error) {
+				app.EXPECT().ListDeployments(gomock.Any(), "missing", gomock.Any()).Return(nil, nil, err)
+			},
+			resource: "app", id: "missing",
+		},
+		{
+			name:    "deployment health",
+			handler: (*AppPlatformTool).getDeploymentStatus,
+			args:    map[string]any{"AppID": "missing"},
+			mock: func(app *MockAppsService, err error) {
+				app.EXPECT().ListDeployments(gomock.Any(), "missing", gomock.Any()).Return([]*godo.Deployment{{ID: "dep-1"}}, nil, nil)
+				app.EXPECT().GetAppHealth(gomock.Any(), "missing").Return(nil, nil, err)
+			},
+			resource: "app", id: "missing",
+		},
+		{
+			name:    "logs",
+			handler: (*AppPlatformTool).getAppLogs,
+			args:    map[string]any{"AppID": "app-1", "DeploymentID": "missing", "Component": "web", "LogType": "RUN"},
+			mock: func(app *MockAppsService, err error) {
+				app.EXPECT().GetLogs(gomock.Any(), "app-1", "missing", "web", godo.AppLogTypeRun, false, 100).Return(nil, nil, err)
+			},
+			resource: "deployment", id: "app-1/missing",
+		},
+		{
+			name:    "deployment diff",
+			handler: (*AppPlatformTool).diffDeployments,
+			args:    map[string]any{"AppID": "app-1", "DeploymentID1": "dep-1", "DeploymentID2": "missing"},
+			mock: func(app *MockAppsService, err error) {
+				app.EXPECT().GetDeployment(gomock.Any(), "app-1", "missing").Return(nil, nil, err)
+			},
+			resource: "deployment", id: "app-1/missing",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client, appService := setupMock(t)
+			tc.mock(appService, testhelpers.NotFoundError("/v2/apps/"+tc.id))
+			tool := &AppPlatformTool{client: client}
+
+			resp, err := tc.handler(tool, context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			require.True(t, resp.IsError)
+			var out common.NotFound
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+			require.Equal(t, common.ErrorCodeNotFound, out.Code)
+			require.Equal(t, tc.resource, out.Resource)
+			require.Equal(t, tc.id, out.ID)
+		})
+	}
+}
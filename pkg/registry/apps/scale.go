@@ -0,0 +1,167 @@
+package apps
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"mcp-digitalocean/pkg/registry/common"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ComponentScale is the instance count and size of a component.
+type ComponentScale struct {
+	InstanceCount    int64  `json:"instance_count"`
+	InstanceSizeSlug string `json:"instance_size_slug"`
+}
+
+// ScaleResult is the result of apps-scale-component.
+type ScaleResult struct {
+	AppID               string         `json:"app_id"`
+	Component           string         `json:"component"`
+	ComponentType       string         `json:"component_type"`
+	Previous            ComponentScale `json:"previous"`
+	Current             ComponentScale `json:"current"`
+	PendingDeploymentID string         `json:"pending_deployment_id,omitempty"`
+}
+
+// scalableComponent points at the scaling fields of a service or worker in an
+// app spec, so both can be updated in place the same way.
+type scalableComponent struct {
+	kind        string
+	count       *int64
+	size        *string
+	autoscaling *godo.AppAutoscalingSpec
+}
+
+// findScalableComponent looks up a service or worker by name. The message is
+// non-empty when there is no such component or it cannot be scaled.
+func findScalableComponent(spec *godo.AppSpec, name string) (*scalableComponent, string) {
+	for _, s := range spec.Services {
+		if s.Name == name {
+			return &scalableComponent{kind: "service", count: &s.InstanceCount, size: &s.InstanceSizeSlug, autoscaling: s.Autoscaling}, ""
+		}
+	}
+	for _, w := range spec.Workers {
+		if w.Name == name {
+			return &scalableComponent{kind: "worker", count: &w.InstanceCount, size: &w.InstanceSizeSlug, autoscaling: w.Autoscaling}, ""
+		}
+	}
+	for _, j := range spec.Jobs {
+		if j.Name == name {
+			return nil, fmt.Sprintf("component %s is a job; only services and workers can be scaled", name)
+		}
+	}
+	for _, s := range spec.StaticSites {
+		if s.Name == name {
+			return nil, fmt.Sprintf("component %s is a static site; only services and workers can be scaled", name)
+		}
+	}
+	for _, f := range spec.Functions {
+		if f.Name == name {
+			return nil, fmt.Sprintf("component %s is a functions component; only services and workers can be scaled", name)
+		}
+	}
+	return nil, fmt.Sprintf("app has no component named %s", name)
+}
+
+// scaleComponent changes the instance count and/or size of one service or
+// worker, leaving the rest of the app spec as it is.
+func (a *AppPlatformTool) scaleComponent(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	appID, ok := args["AppID"].(string)
+	if !ok || appID == "" {
+		return mcp.NewToolResultError("App ID is required"), nil
+	}
+	name, ok := args["ComponentName"].(string)
+	if !ok || name == "" {
+		return mcp.NewToolResultError("ComponentName is required"), nil
+	}
+	countf, hasCount := args["InstanceCount"].(float64)
+	sizeSlug, _ := args["InstanceSizeSlug"].(string)
+	if !hasCount && sizeSlug == "" {
+		return mcp.NewToolResultError("at least one of InstanceCount or InstanceSizeSlug is required"), nil
+	}
+	// The spec omits a zero instance count, so the API would read it as 1.
+	if hasCount && (countf < 1 || countf != float64(int64(countf))) {
+		return mcp.NewToolResultError("InstanceCount must be a whole number of at least 1"), nil
+	}
+
+	client, err := a.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	app, _, err := client.Apps.Get(ctx, appID)
+	if err != nil {
+		return common.ErrorResult(ctx, a.Logger(ctx), fmt.Sprintf("failed to get app %s", appID), err), nil
+	}
+	if app.Spec == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("app %s has no spec", appID)), nil
+	}
+	component, message := findScalableComponent(app.Spec, name)
+	if message != "" {
+		return mcp.NewToolResultError(message), nil
+	}
+	if hasCount && component.autoscaling != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("%s %s uses autoscaling; change its autoscaling limits instead of InstanceCount", component.kind, name)), nil
+	}
+
+	previous := ComponentScale{InstanceCount: *component.count, InstanceSizeSlug: *component.size}
+	current := previous
+	if hasCount {
+		current.InstanceCount = int64(countf)
+	}
+	if sizeSlug != "" {
+		current.InstanceSizeSlug = sizeSlug
+	}
+
+	// Sizes are checked whenever the size changes or more than one instance
+	// is asked for, since some sizes only run a single instance.
+	if sizeSlug != "" || (current.InstanceCount > 1 && current.InstanceSizeSlug != "") {
+		sizes, _, err := client.Apps.ListInstanceSizes(ctx)
+		if err != nil {
+			return common.ErrorResult(ctx, a.Logger(ctx), "failed to list instance sizes", err), nil
+		}
+		var size *godo.AppInstanceSize
+		for _, s := range sizes {
+			if s.Slug == current.InstanceSizeSlug {
+				size = s
+				break
+			}
+		}
+		if size == nil {
+			return mcp.NewToolResultError(fmt.Sprintf("unknown instance size %s; see the App Platform instance sizes for valid slugs", current.InstanceSizeSlug)), nil
+		}
+		if size.SingleInstanceOnly && current.InstanceCount > 1 {
+			return mcp.NewToolResultError(fmt.Sprintf("instance size %s only allows a single instance", current.InstanceSizeSlug)), nil
+		}
+	}
+
+	*component.count = current.InstanceCount
+	*component.size = current.InstanceSizeSlug
+	updated, _, err := client.Apps.Update(ctx, appID, &godo.AppUpdateRequest{Spec: app.Spec})
+	if err != nil {
+		return common.ErrorResult(ctx, a.Logger(ctx), fmt.Sprintf("failed to update app %s", appID), err), nil
+	}
+
+	result := ScaleResult{
+		AppID:         appID,
+		Component:     name,
+		ComponentType: component.kind,
+		Previous:      previous,
+		Current:       current,
+	}
+	if updated != nil && updated.PendingDeployment != nil {
+		result.PendingDeploymentID = updated.PendingDeployment.ID
+	}
+
+	resultJSON, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal scale result: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
@@ -0,0 +1,163 @@
+package apps
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestScaleComponent(t *testing.T) {
+	appSpec := func() *godo.AppSpec {
+		return &godo.AppSpec{
+			Name: "my-app",
+			Services: []*godo.AppServiceSpec{
+				{Name: "web", InstanceCount: 1, InstanceSizeSlug: "apps-s-1vcpu-0.5gb"},
+				{Name: "api", InstanceSizeSlug: "apps-d-1vcpu-2gb", Autoscaling: &godo.AppAutoscalingSpec{MinInstanceCount: 2, MaxInstanceCount: 4}},
+			},
+			Workers:     []*godo.AppWorkerSpec{{Name: "queue", InstanceCount: 2, InstanceSizeSlug: "apps-s-1vcpu-1gb"}},
+			StaticSites: []*godo.AppStaticSiteSpec{{Name: "docs"}},
+		}
+	}
+	sizes := []*godo.AppInstanceSize{
+		{Slug: "apps-s-1vcpu-0.5gb", SingleInstanceOnly: true},
+		{Slug: "apps-s-1vcpu-1gb"},
+		{Slug: "apps-d-1vcpu-2gb"},
+	}
+
+	tests := []struct {
+		name      string
+		args      map[string]any
+		mock      func(app *MockAppsService)
+		expected  ScaleResult
+		expectMcp string
+	}{
+		{
+			name: "Scale a service to a larger size and count",
+			args: map[string]any{"AppID": "app-123", "ComponentName": "web", "InstanceCount": float64(3), "InstanceSizeSlug": "apps-s-1vcpu-1gb"},
+			mock: func(app *MockAppsService) {
+				app.EXPECT().Get(gomock.Any(), "app-123").Return(&godo.App{ID: "app-123", Spec: appSpec()}, nil, nil)
+				app.EXPECT().ListInstanceSizes(gomock.Any()).Return(sizes, nil, nil)
+				expectedSpec := appSpec()
+				expectedSpec.Services[0].InstanceCount = 3
+				expectedSpec.Services[0].InstanceSizeSlug = "apps-s-1vcpu-1gb"
+				app.EXPECT().Update(gomock.Any(), "app-123", &godo.AppUpdateRequest{Spec: expectedSpec}).
+					Return(&godo.App{ID: "app-123", PendingDeployment: &godo.Deployment{ID: "dep-9"}}, nil, nil)
+			},
+			expected: ScaleResult{
+				AppID:               "app-123",
+				Component:           "web",
+				ComponentType:       "service",
+				Previous:            ComponentScale{InstanceCount: 1, InstanceSizeSlug: "apps-s-1vcpu-0.5gb"},
+				Current:             ComponentScale{InstanceCount: 3, InstanceSizeSlug: "apps-s-1vcpu-1gb"},
+				PendingDeploymentID: "dep-9",
+			},
+		},
+		{
+			name: "Scale a worker's count only",
+			args: map[string]any{"AppID": "app-123", "ComponentName": "queue", "InstanceCount": float64(5)},
+			mock: func(app *MockAppsService) {
+				app.EXPECT().Get(gomock.Any(), "app-123").Return(&godo.App{ID: "app-123", Spec: appSpec()}, nil, nil)
+				app.EXPECT().ListInstanceSizes(gomock.Any()).Return(sizes, nil, nil)
+				expectedSpec := appSpec()
+				expectedSpec.Workers[0].InstanceCount = 5
+				app.EXPECT().Update(gomock.Any(), "app-123", &godo.AppUpdateRequest{Spec: expectedSpec}).
+					Return(&godo.App{ID: "app-123"}, nil, nil)
+			},
+			expected: ScaleResult{
+				AppID:         "app-123",
+				Component:     "queue",
+				ComponentType: "worker",
+				Previous:      ComponentScale{InstanceCount: 2, InstanceSizeSlug: "apps-s-1vcpu-1gb"},
+				Current:       ComponentScale{InstanceCount: 5, InstanceSizeSlug: "apps-s-1vcpu-1gb"},
+			},
+		},
+		{
+			name: "Unknown component",
+			args: map[string]any{"AppID": "app-123", "ComponentName": "missing", "InstanceCount": float64(2)},
+			mock: func(app *MockAppsService) {
+				app.EXPECT().Get(gomock.Any(), "app-123").Return(&godo.App{ID: "app-123", Spec: appSpec()}, nil, nil)
+			},
+			expectMcp: "app has no component named missing",
+		},
+		{
+			name: "Static sites cannot be scaled",
+			args: map[string]any{"AppID": "app-123", "ComponentName": "docs", "InstanceCount": float64(2)},
+			mock: func(app *MockAppsService) {
+				app.EXPECT().Get(gomock.Any(), "app-123").Return(&godo.App{ID: "app-123", Spec: appSpec()}, nil, nil)
+			},
+			expectMcp: "component docs is a static site; only services and workers can be scaled",
+		},
+		{
+			name: "Invalid size",
+			args: map[string]any{"AppID": "app-123", "ComponentName": "web", "InstanceSizeSlug": "apps-s-64vcpu"},
+			mock: func(app *MockAppsService) {
+				app.EXPECT().Get(gomock.Any(), "app-123").Return(&godo.App{ID: "app-123", Spec: appSpec()}, nil, nil)
+				app.EXPECT().ListInstanceSizes(gomock.Any()).Return(sizes, nil, nil)
+			},
+			expectMcp: "unknown instance size apps-s-64vcpu",
+		},
+		{
+			name: "Single-instance size cannot run more instances",
+			args: map[string]any{"AppID": "app-123", "ComponentName": "web", "InstanceCount": float64(2)},
+			mock: func(app *MockAppsService) {
+				app.EXPECT().Get(gomock.Any(), "app-123").Return(&godo.App{ID: "app-123", Spec: appSpec()}, nil, nil)
+				app.EXPECT().ListInstanceSizes(gomock.Any()).Return(sizes, nil, nil)
+			},
+			expectMcp: "instance size apps-s-1vcpu-0.5gb only allows a single instance",
+		},
+		{
+			name: "Autoscaled component rejects InstanceCount",
+			args: map[string]any{"AppID": "app-123", "ComponentName": "api", "InstanceCount": float64(3)},
+			mock: func(app *MockAppsService) {
+				app.EXPECT().Get(gomock.Any(), "app-123").Return(&godo.App{ID: "app-123", Spec: appSpec()}, nil, nil)
+			},
+			expectMcp: "service api uses autoscaling",
+		},
+		{
+			name:      "Zero instances",
+			args:      map[string]any{"AppID": "app-123", "ComponentName": "queue", "InstanceCount": float64(0)},
+			mock:      func(app *MockAppsService) {},
+			expectMcp: "InstanceCount must be a whole number of at least 1",
+		},
+		{
+			name:      "Nothing to change",
+			args:      map[string]any{"AppID": "app-123", "ComponentName": "web"},
+			mock:      func(app *MockAppsService) {},
+			expectMcp: "at least one of InstanceCount or InstanceSizeSlug is required",
+		},
+		{
+			name: "Update fails",
+			args: map[string]any{"AppID": "app-123", "ComponentName": "queue", "InstanceSizeSlug": "apps-d-1vcpu-2gb"},
+			mock: func(app *MockAppsService) {
+				app.EXPECT().Get(gomock.Any(), "app-123").Return(&godo.App{ID: "app-123", Spec: appSpec()}, nil, nil)
+				app.EXPECT().ListInstanceSizes(gomock.Any()).Return(sizes, nil, nil)
+				app.EXPECT().Update(gomock.Any(), "app-123", gomock.Any()).Return(nil, nil, errors.New("quota exceeded"))
+			},
+			expectMcp: "failed to update app app-123",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client, mockApps := setupMock(t)
+			tc.mock(mockApps)
+			tool, err := NewAppPlatformTool(client)
+			require.NoError(t, err)
+
+			resp, err := tool.scaleComponent(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			if tc.expectMcp != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.expectMcp)
+				return
+			}
+			require.False(t, resp.IsError)
+			equalsToolResult(t, tc.expected, resp)
+		})
+	}
+}
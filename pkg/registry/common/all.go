@@ -0,0 +1,75 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// AllArg is the argument list tools take to follow pagination and return
+// every item in one result.
+const AllArg = "All"
+
+// MaxAllItems caps how many items an All list returns, so a very large
+// account cannot produce a runaway response.
+const MaxAllItems = 2000
+
+// AllResult is the envelope of a list fetched with All. Truncated reports
+// that more items existed beyond MaxAllItems.
+type AllResult[T any] struct {
+	Items        []T  `json:"items"`
+	Truncated    bool `json:"truncated"`
+	TotalFetched int  `json:"total_fetched"`
+}
+
+// WithAllArg adds the All argument to a list tool.
+func WithAllArg() mcp.ToolOption {
+	return mcp.WithBoolean(AllArg, mcp.DefaultBool(false), mcp.Description(fmt.Sprintf("Follow pagination and return every item, at most %d, as {\"items\": [...], \"truncated\": bool, \"total_fetched\": n}; truncated is true when more items exist. Page and PerPage are ignored", MaxAllItems)))
+}
+
+// IsAll reports whether the call asked for every item.
+func IsAll(args map[string]any) bool {
+	all, _ := args[AllArg].(bool)
+	return all
+}
+
+// FetchForAll fetches the items of list for a call with All, following
+// pagination until MaxAllItems items are fetched. truncated reports whether
+// items beyond MaxAllItems were left out.
+func FetchForAll[T any](ctx context.Context, list func(ctx context.Context, opt *godo.ListOptions) ([]T, *godo.Response, error)) (all []T, truncated bool, err error) {
+	opt := &godo.ListOptions{Page: 1, PerPage: MaxPerPage}
+	for {
+		items, resp, err := list(ctx, opt)
+		if err != nil {
+			return nil, false, err
+		}
+		// a page past the cap only tells whether items were left out.
+		if len(all) == MaxAllItems {
+			return all, len(items) > 0, nil
+		}
+		all = append(all, items...)
+		if len(all) > MaxAllItems {
+			return all[:MaxAllItems], true, nil
+		}
+		// an empty page ends the walk even if the links claim otherwise.
+		if len(items) == 0 || LastPage(resp) {
+			return all, false, nil
+		}
+		opt.Page++
+	}
+}
+
+// NewAllResult returns items in an AllResult envelope.
+func NewAllResult[T any](items []T, truncated bool) (*mcp.CallToolResult, error) {
+	if items == nil {
+		items = []T{}
+	}
+	data, err := json.MarshalIndent(AllResult[T]{Items: items, Truncated: truncated, TotalFetched: len(items)}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(string(data)), nil
+}
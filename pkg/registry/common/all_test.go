@@ -0,0 +1,96 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+// pagedList returns a list of total items served pageSize at a time, and the
+// pages it was asked for.
+func pagedList(total, pageSize int) (func(ctx context.Context, opt *godo.ListOptions) ([]int, *godo.Response, error), *[]int) {
+	var requested []int
+	return func(ctx context.Context, opt *godo.ListOptions) ([]int, *godo.Response, error) {
+		requested = append(requested, opt.Page)
+		start := (opt.Page - 1) * pageSize
+		var items []int
+		for i := start; i < min(start+pageSize, total); i++ {
+			items = append(items, i)
+		}
+		resp := &godo.Response{Links: &godo.Links{}}
+		if start+pageSize < total {
+			resp.Links.Pages = &godo.Pages{Next: "https://api/v2/things?page=next", Last: "https://api/v2/things?page=last"}
+		}
+		return items, resp, nil
+	}, &requested
+}
+
+func TestFetchForAll(t *testing.T) {
+	tests := []struct {
+		name          string
+		total         int
+		pageSize      int
+		wantItems     int
+		wantTruncated bool
+		wantPages     int
+	}{
+		{name: "single page", total: 3, pageSize: MaxPerPage, wantItems: 3, wantPages: 1},
+		{name: "follows several pages", total: 450, pageSize: MaxPerPage, wantItems: 450, wantPages: 3},
+		{name: "exactly the cap", total: MaxAllItems, pageSize: MaxPerPage, wantItems: MaxAllItems, wantPages: MaxAllItems / MaxPerPage},
+		{name: "one over the cap", total: MaxAllItems + 1, pageSize: MaxPerPage, wantItems: MaxAllItems, wantTruncated: true, wantPages: MaxAllItems/MaxPerPage + 1},
+		{name: "cap reached mid-page", total: MaxAllItems + 1, pageSize: 300, wantItems: MaxAllItems, wantTruncated: true, wantPages: 7},
+		{name: "short pages", total: 5, pageSize: 2, wantItems: 5, wantPages: 3},
+		{name: "more short pages than a full cap", total: 30, pageSize: 2, wantItems: 30, wantPages: 15},
+		{name: "empty", total: 0, pageSize: MaxPerPage, wantItems: 0, wantPages: 1},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			list, requested := pagedList(tc.total, tc.pageSize)
+			items, truncated, err := FetchForAll(context.Background(), list)
+			require.NoError(t, err)
+			require.Len(t, items, tc.wantItems)
+			for i, item := range items {
+				require.Equal(t, i, item)
+			}
+			require.Equal(t, tc.wantTruncated, truncated)
+			require.Len(t, *requested, tc.wantPages)
+		})
+	}
+
+	t.Run("links past the cap to an empty page", func(t *testing.T) {
+		more := &godo.Response{Links: &godo.Links{Pages: &godo.Pages{Next: "https://api/v2/things?page=next"}}}
+		items, truncated, err := FetchForAll(context.Background(), func(ctx context.Context, opt *godo.ListOptions) ([]int, *godo.Response, error) {
+			if opt.Page > MaxAllItems/MaxPerPage {
+				return nil, more, nil
+			}
+			return make([]int, MaxPerPage), more, nil
+		})
+		require.NoError(t, err)
+		require.Len(t, items, MaxAllItems)
+		require.False(t, truncated)
+	})
+
+	t.Run("returns errors", func(t *testing.T) {
+		_, _, err := FetchForAll(context.Background(), func(ctx context.Context, opt *godo.ListOptions) ([]int, *godo.Response, error) {
+			return nil, nil, errors.New("boom")
+		})
+		require.EqualError(t, err, "boom")
+	})
+}
+
+func TestNewAllResult(t *testing.T) {
+	result, err := NewAllResult([]int(nil), false)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"items": [], "truncated": false, "total_fetched": 0}`, result.Content[0].(mcp.TextContent).Text)
+
+	result, err = NewAllResult([]int{1, 2}, true)
+	require.NoError(t, err)
+	var envelope AllResult[int]
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &envelope))
+	require.Equal(t, AllResult[int]{Items: []int{1, 2}, Truncated: true, TotalFetched: 2}, envelope)
+}
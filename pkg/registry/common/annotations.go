@@ -1,6 +1,10 @@
 package common
 
-import "github.com/mark3labs/mcp-go/mcp"
+import (
+	"encoding/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
 
 // hints bundles the four standard MCP tool annotation hints — readOnly,
 // destructive, idempotent, openWorld — into a single typed value. Tool
@@ -56,3 +60,71 @@ var (
 	// HintsReplace — destructive AND non-idempotent (see package note above).
 	HintsReplace = hints{destructive: true, openWorld: false}
 )
+
+// CreatesResourceMetaKey marks, in a tool's _meta, tools that create a
+// billable resource. The spend guard middleware refuses these tools once the
+// account's month-to-date usage is over the configured limit.
+const CreatesResourceMetaKey = "com.digitalocean/creates-resource"
+
+// OverrideSpendLimitArg is the argument that lets a single call of a
+// resource-creating tool bypass the spend guard.
+const OverrideSpendLimitArg = "OverrideSpendLimit"
+
+const overrideSpendLimitDescription = "Create the resource even if the account's month-to-date usage is over the server's spend limit"
+
+// WithCreatesResource marks a tool as creating a billable resource and adds the
+// OverrideSpendLimit argument to its input schema. Tools built with
+// mcp.NewToolWithRawSchema, which takes no options, use RawSchemaCreatesResource.
+func WithCreatesResource() mcp.ToolOption {
+	return func(t *mcp.Tool) {
+		if t.Meta == nil {
+			t.Meta = &mcp.Meta{}
+		}
+		if t.Meta.AdditionalFields == nil {
+			t.Meta.AdditionalFields = map[string]any{}
+		}
+		t.Meta.AdditionalFields[CreatesResourceMetaKey] = true
+
+		if t.RawInputSchema == nil {
+			mcp.WithBoolean(OverrideSpendLimitArg, mcp.Description(overrideSpendLimitDescription))(t)
+			return
+		}
+		AddRawSchemaProperty(t, OverrideSpendLimitArg, map[string]any{"type": "boolean", "description": overrideSpendLimitDescription})
+	}
+}
+
+// AddRawSchemaProperty adds an optional property to the input schema of a tool
+// built with mcp.NewToolWithRawSchema.
+func AddRawSchemaProperty(t *mcp.Tool, name string, property map[string]any) {
+	// raw schemas are embedded JSON documents; leave one that does not
+	// parse as an object untouched rather than failing registration.
+	var schema map[string]any
+	if err := json.Unmarshal(t.RawInputSchema, &schema); err != nil || schema == nil {
+		return
+	}
+	properties, _ := schema["properties"].(map[string]any)
+	if properties == nil {
+		properties = map[string]any{}
+		schema["properties"] = properties
+	}
+	properties[name] = property
+	if raw, err := json.Marshal(schema); err == nil {
+		t.RawInputSchema = raw
+	}
+}
+
+// RawSchemaCreatesResource applies WithCreatesResource to a tool built with
+// mcp.NewToolWithRawSchema.
+func RawSchemaCreatesResource(t mcp.Tool) mcp.Tool {
+	WithCreatesResource()(&t)
+	return t
+}
+
+// CreatesResource reports whether t was registered with WithCreatesResource.
+func CreatesResource(t mcp.Tool) bool {
+	if t.Meta == nil {
+		return false
+	}
+	creates, _ := t.Meta.AdditionalFields[CreatesResourceMetaKey].(bool)
+	return creates
+}
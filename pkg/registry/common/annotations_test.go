@@ -0,0 +1,37 @@
+package common
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithCreatesResource(t *testing.T) {
+	tool := mcp.NewTool("thing-create", mcp.WithString("Name"), WithCreatesResource())
+	require.True(t, CreatesResource(tool))
+	require.Contains(t, tool.InputSchema.Properties, OverrideSpendLimitArg)
+	require.Contains(t, tool.InputSchema.Properties, "Name")
+
+	require.False(t, CreatesResource(mcp.NewTool("thing-list")))
+}
+
+func TestRawSchemaCreatesResource(t *testing.T) {
+	tool := RawSchemaCreatesResource(mcp.NewToolWithRawSchema("thing-create", "", json.RawMessage(`{"type":"object","properties":{"Name":{"type":"string"}},"required":["Name"]}`)))
+	require.True(t, CreatesResource(tool))
+
+	var schema struct {
+		Properties map[string]map[string]any `json:"properties"`
+		Required   []string                  `json:"required"`
+	}
+	require.NoError(t, json.Unmarshal(tool.RawInputSchema, &schema))
+	require.Equal(t, "boolean", schema.Properties[OverrideSpendLimitArg]["type"])
+	require.Contains(t, schema.Properties, "Name")
+	require.Equal(t, []string{"Name"}, schema.Required)
+
+	// a schema that does not parse is left as is.
+	bad := RawSchemaCreatesResource(mcp.NewToolWithRawSchema("bad-create", "", json.RawMessage(`not json`)))
+	require.True(t, CreatesResource(bad))
+	require.Equal(t, `not json`, string(bad.RawInputSchema))
+}
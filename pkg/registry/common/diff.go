@@ -0,0 +1,168 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Change operations reported by DiffJSON.
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeChanged = "changed"
+)
+
+// Change is a single difference between two documents. Path uses dotted
+// field names; elements of arrays of objects carrying a "name" or "key" field
+// are addressed by that identity (services[name=web].envs[key=PORT]) so that
+// reordering or inserting elements is not reported as a change to every later
+// index. Other arrays are addressed by index.
+type Change struct {
+	Path string `json:"path"`
+	Op   string `json:"op"`
+	Old  any    `json:"old,omitempty"`
+	New  any    `json:"new,omitempty"`
+}
+
+// DiffJSON compares the JSON representations of before and after and returns
+// the changed leaf paths, sorted by path. Values are compared after a JSON
+// round-trip so struct fields omitted by omitempty are treated as absent.
+func DiffJSON(before, after any) ([]Change, error) {
+	a, err := toGeneric(before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode before: %w", err)
+	}
+	b, err := toGeneric(after)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode after: %w", err)
+	}
+
+	changes := []Change{}
+	diffValue("", a, b, &changes)
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+func toGeneric(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out any
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func diffValue(path string, a, b any, changes *[]Change) {
+	switch {
+	case a == nil && b == nil:
+		return
+	case a == nil:
+		*changes = append(*changes, Change{Path: path, Op: ChangeAdded, New: b})
+		return
+	case b == nil:
+		*changes = append(*changes, Change{Path: path, Op: ChangeRemoved, Old: a})
+		return
+	}
+
+	switch av := a.(type) {
+	case map[string]any:
+		if bv, ok := b.(map[string]any); ok {
+			diffObject(path, av, bv, changes)
+			return
+		}
+	case []any:
+		if bv, ok := b.([]any); ok {
+			diffArray(path, av, bv, changes)
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		*changes = append(*changes, Change{Path: path, Op: ChangeChanged, Old: a, New: b})
+	}
+}
+
+func diffObject(path string, a, b map[string]any, changes *[]Change) {
+	for k, av := range a {
+		diffValue(joinPath(path, k), av, b[k], changes)
+	}
+	for k, bv := range b {
+		if _, ok := a[k]; !ok {
+			diffValue(joinPath(path, k), nil, bv, changes)
+		}
+	}
+}
+
+func diffArray(path string, a, b []any, changes *[]Change) {
+	if field := identityField(a, b); field != "" {
+		bByID := make(map[string]any, len(b))
+		for _, v := range b {
+			bByID[v.(map[string]any)[field].(string)] = v
+		}
+		seen := make(map[string]bool, len(a))
+		for _, v := range a {
+			id := v.(map[string]any)[field].(string)
+			seen[id] = true
+			diffValue(fmt.Sprintf("%s[%s=%s]", path, field, id), v, bByID[id], changes)
+		}
+		for _, v := range b {
+			if id := v.(map[string]any)[field].(string); !seen[id] {
+				diffValue(fmt.Sprintf("%s[%s=%s]", path, field, id), nil, v, changes)
+			}
+		}
+		return
+	}
+
+	for i := 0; i < max(len(a), len(b)); i++ {
+		var av, bv any
+		if i < len(a) {
+			av = a[i]
+		}
+		if i < len(b) {
+			bv = b[i]
+		}
+		diffValue(fmt.Sprintf("%s[%d]", path, i), av, bv, changes)
+	}
+}
+
+// identityField returns "name" or "key" when every element of both arrays is
+// an object with a unique string value for that field, or "" otherwise.
+func identityField(a, b []any) string {
+	for _, field := range []string{"name", "key"} {
+		if hasUniqueField(a, field) && hasUniqueField(b, field) {
+			return field
+		}
+	}
+	return ""
+}
+
+func hasUniqueField(values []any, field string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	seen := make(map[string]bool, len(values))
+	for _, v := range values {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return false
+		}
+		id, ok := obj[field].(string)
+		if !ok || seen[id] {
+			return false
+		}
+		seen[id] = true
+	}
+	return true
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,66 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffJSON(t *testing.T) {
+	type doc struct {
+		Name  string           `json:"name,omitempty"`
+		Size  int              `json:"size,omitempty"`
+		Tags  []string         `json:"tags,omitempty"`
+		Rules []map[string]any `json:"rules,omitempty"`
+	}
+
+	tests := []struct {
+		name   string
+		before any
+		after  any
+		want   []Change
+	}{
+		{
+			name:   "identical",
+			before: doc{Name: "a", Tags: []string{"x"}},
+			after:  doc{Name: "a", Tags: []string{"x"}},
+			want:   []Change{},
+		},
+		{
+			name:   "scalar change, addition and removal",
+			before: doc{Name: "a", Size: 1},
+			after:  doc{Name: "b", Tags: []string{"x"}},
+			want: []Change{
+				{Path: "name", Op: ChangeChanged, Old: "a", New: "b"},
+				{Path: "size", Op: ChangeRemoved, Old: float64(1)},
+				{Path: "tags", Op: ChangeAdded, New: []any{"x"}},
+			},
+		},
+		{
+			name:   "arrays without identity are compared by index",
+			before: doc{Tags: []string{"x", "y"}},
+			after:  doc{Tags: []string{"x", "z", "w"}},
+			want: []Change{
+				{Path: "tags[1]", Op: ChangeChanged, Old: "y", New: "z"},
+				{Path: "tags[2]", Op: ChangeAdded, New: "w"},
+			},
+		},
+		{
+			name:   "arrays of named objects are matched by name",
+			before: doc{Rules: []map[string]any{{"name": "http", "port": 80}, {"name": "https", "port": 443}}},
+			after:  doc{Rules: []map[string]any{{"name": "https", "port": 8443}}},
+			want: []Change{
+				{Path: "rules[name=http]", Op: ChangeRemoved, Old: map[string]any{"name": "http", "port": float64(80)}},
+				{Path: "rules[name=https].port", Op: ChangeChanged, Old: float64(443), New: float64(8443)},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := DiffJSON(tc.before, tc.after)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
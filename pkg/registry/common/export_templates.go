@@ -0,0 +1,369 @@
+package common
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// exportCaveat heads every export. The API does not return everything a
+// resource was created with (SSH keys, user data, certificates' private keys),
+// so the output is a starting point, not an exact replica.
+const exportCaveat = "Review before use: generated on a best-effort basis from the live resource. Settings the API does not return, such as SSH keys and user data, are not included."
+
+var exportFuncs = template.FuncMap{
+	"hcl":     hclString,
+	"hclList": hclStringList,
+	"intList": intList,
+	"sh":      shellQuote,
+	"join":    strings.Join,
+	"joinInt": joinInts,
+}
+
+// exportTemplates holds one template per resource type and format.
+var exportTemplates = map[string]map[string]*template.Template{
+	"droplet": {
+		exportFormatTerraform: exportTemplate(`# {{.Caveat}}
+resource "digitalocean_droplet" {{hcl .Label}} {
+  name   = {{hcl .Name}}
+  region = {{hcl .Region}}
+  size   = {{hcl .Size}}
+  image  = {{hcl .Image}}
+{{- if .VPCUUID}}
+  vpc_uuid = {{hcl .VPCUUID}}
+{{- end}}
+{{- if .Backups}}
+  backups = true
+{{- end}}
+{{- if .IPv6}}
+  ipv6 = true
+{{- end}}
+{{- if .Monitoring}}
+  monitoring = true
+{{- end}}
+{{- if .Tags}}
+  tags = {{hclList .Tags}}
+{{- end}}
+}
+`),
+		exportFormatDoctl: exportTemplate(`# {{.Caveat}}
+doctl compute droplet create {{sh .Name}} \
+  --region {{sh .Region}} \
+  --size {{sh .Size}} \
+  --image {{sh .Image}}
+{{- if .VPCUUID}} \
+  --vpc-uuid {{sh .VPCUUID}}
+{{- end}}
+{{- if .Backups}} \
+  --enable-backups
+{{- end}}
+{{- if .IPv6}} \
+  --enable-ipv6
+{{- end}}
+{{- if .Monitoring}} \
+  --enable-monitoring
+{{- end}}
+{{- if .Tags}} \
+  --tag-names {{sh (join .Tags ",")}}
+{{- end}}
+`),
+	},
+	"loadbalancer": {
+		exportFormatTerraform: exportTemplate(`# {{.Caveat}}
+resource "digitalocean_loadbalancer" {{hcl .Label}} {
+  name   = {{hcl .Name}}
+  region = {{hcl .Region}}
+{{- if .SizeUnit}}
+  size_unit = {{.SizeUnit}}
+{{- else if .Size}}
+  size = {{hcl .Size}}
+{{- end}}
+{{- if .VPCUUID}}
+  vpc_uuid = {{hcl .VPCUUID}}
+{{- end}}
+{{- range .ForwardingRules}}
+
+  forwarding_rule {
+    entry_protocol  = {{hcl .EntryProtocol}}
+    entry_port      = {{.EntryPort}}
+    target_protocol = {{hcl .TargetProtocol}}
+    target_port     = {{.TargetPort}}
+{{- if .CertificateID}}
+    certificate_id  = {{hcl .CertificateID}}
+{{- end}}
+{{- if .TlsPassthrough}}
+    tls_passthrough = true
+{{- end}}
+  }
+{{- end}}
+{{- with .HealthCheck}}
+
+  healthcheck {
+    protocol                 = {{hcl .Protocol}}
+    port                     = {{.Port}}
+{{- if .Path}}
+    path                     = {{hcl .Path}}
+{{- end}}
+    check_interval_seconds   = {{.CheckIntervalSeconds}}
+    response_timeout_seconds = {{.ResponseTimeoutSeconds}}
+    healthy_threshold        = {{.HealthyThreshold}}
+    unhealthy_threshold      = {{.UnhealthyThreshold}}
+  }
+{{- end}}
+{{- if .Tag}}
+
+  droplet_tag = {{hcl .Tag}}
+{{- else if .DropletIDs}}
+
+  droplet_ids = {{intList .DropletIDs}}
+{{- end}}
+{{- if .RedirectHTTPToHTTPS}}
+  redirect_http_to_https = true
+{{- end}}
+{{- if .EnableProxyProtocol}}
+  enable_proxy_protocol = true
+{{- end}}
+}
+`),
+		exportFormatDoctl: exportTemplate(`# {{.Caveat}}
+doctl compute load-balancer create \
+  --name {{sh .Name}} \
+  --region {{sh .Region}}
+{{- if .SizeUnit}} \
+  --size-unit {{.SizeUnit}}
+{{- else if .Size}} \
+  --size {{sh .Size}}
+{{- end}}
+{{- if .VPCUUID}} \
+  --vpc-uuid {{sh .VPCUUID}}
+{{- end}}
+{{- if .ForwardingRules}} \
+  --forwarding-rules {{sh .DoctlForwardingRules}}
+{{- end}}
+{{- if .HealthCheck}} \
+  --health-check {{sh .DoctlHealthCheck}}
+{{- end}}
+{{- if .Tag}} \
+  --tag-name {{sh .Tag}}
+{{- else if .DropletIDs}} \
+  --droplet-ids {{joinInt .DropletIDs}}
+{{- end}}
+{{- if .RedirectHTTPToHTTPS}} \
+  --redirect-http-to-https
+{{- end}}
+{{- if .EnableProxyProtocol}} \
+  --enable-proxy-protocol
+{{- end}}
+`),
+	},
+	"firewall": {
+		exportFormatTerraform: exportTemplate(`# {{.Caveat}}
+resource "digitalocean_firewall" {{hcl .Label}} {
+  name = {{hcl .Name}}
+{{- if .DropletIDs}}
+  droplet_ids = {{intList .DropletIDs}}
+{{- end}}
+{{- if .Tags}}
+  tags = {{hclList .Tags}}
+{{- end}}
+{{- range .InboundRules}}
+
+  inbound_rule {
+    protocol = {{hcl .Protocol}}
+{{- if .PortRange}}
+    port_range = {{hcl .PortRange}}
+{{- end}}
+{{- template "targets" .Targets}}
+  }
+{{- end}}
+{{- range .OutboundRules}}
+
+  outbound_rule {
+    protocol = {{hcl .Protocol}}
+{{- if .PortRange}}
+    port_range = {{hcl .PortRange}}
+{{- end}}
+{{- template "targets" .Targets}}
+  }
+{{- end}}
+}
+{{- define "targets"}}
+{{- if .Addresses}}
+    {{.Prefix}}_addresses = {{hclList .Addresses}}
+{{- end}}
+{{- if .Tags}}
+    {{.Prefix}}_tags = {{hclList .Tags}}
+{{- end}}
+{{- if .DropletIDs}}
+    {{.Prefix}}_droplet_ids = {{intList .DropletIDs}}
+{{- end}}
+{{- if .LoadBalancerUIDs}}
+    {{.Prefix}}_load_balancer_uids = {{hclList .LoadBalancerUIDs}}
+{{- end}}
+{{- if .KubernetesIDs}}
+    {{.Prefix}}_kubernetes_ids = {{hclList .KubernetesIDs}}
+{{- end}}
+{{- end}}
+`),
+		exportFormatDoctl: exportTemplate(`# {{.Caveat}}
+doctl compute firewall create \
+  --name {{sh .Name}}
+{{- if .InboundRules}} \
+  --inbound-rules {{sh .DoctlInboundRules}}
+{{- end}}
+{{- if .OutboundRules}} \
+  --outbound-rules {{sh .DoctlOutboundRules}}
+{{- end}}
+{{- if .DropletIDs}} \
+  --droplet-ids {{joinInt .DropletIDs}}
+{{- end}}
+{{- if .Tags}} \
+  --tag-names {{sh (join .Tags ",")}}
+{{- end}}
+`),
+	},
+	"domain_record": {
+		exportFormatTerraform: exportTemplate(`# {{.Caveat}}
+resource "digitalocean_record" {{hcl .Label}} {
+  domain = {{hcl .Domain}}
+  type   = {{hcl .Type}}
+  name   = {{hcl .Name}}
+  value  = {{hcl .Data}}
+{{- if .TTL}}
+  ttl    = {{.TTL}}
+{{- end}}
+{{- if .HasPriority}}
+  priority = {{.Priority}}
+{{- end}}
+{{- if eq .Type "SRV"}}
+  port   = {{.Port}}
+  weight = {{.Weight}}
+{{- end}}
+{{- if eq .Type "CAA"}}
+  flags  = {{.Flags}}
+  tag    = {{hcl .Tag}}
+{{- end}}
+}
+`),
+		exportFormatDoctl: exportTemplate(`# {{.Caveat}}
+doctl compute domain records create {{sh .Domain}} \
+  --record-type {{sh .Type}} \
+  --record-name {{sh .Name}} \
+  --record-data {{sh .Data}}
+{{- if .TTL}} \
+  --record-ttl {{.TTL}}
+{{- end}}
+{{- if .HasPriority}} \
+  --record-priority {{.Priority}}
+{{- end}}
+{{- if eq .Type "SRV"}} \
+  --record-port {{.Port}} \
+  --record-weight {{.Weight}}
+{{- end}}
+{{- if eq .Type "CAA"}} \
+  --record-flags {{.Flags}} \
+  --record-tag {{sh .Tag}}
+{{- end}}
+`),
+	},
+	"volume": {
+		exportFormatTerraform: exportTemplate(`# {{.Caveat}}
+resource "digitalocean_volume" {{hcl .Label}} {
+  name   = {{hcl .Name}}
+  region = {{hcl .Region}}
+  size   = {{.Size}}
+{{- if .Description}}
+  description = {{hcl .Description}}
+{{- end}}
+{{- if .FilesystemType}}
+  initial_filesystem_type = {{hcl .FilesystemType}}
+{{- end}}
+{{- if .Tags}}
+  tags = {{hclList .Tags}}
+{{- end}}
+}
+{{- range .DropletIDs}}
+
+resource "digitalocean_volume_attachment" "{{$.Label}}_{{.}}" {
+  droplet_id = {{.}}
+  volume_id  = digitalocean_volume.{{$.Label}}.id
+}
+{{- end}}
+`),
+		exportFormatDoctl: exportTemplate(`# {{.Caveat}}
+doctl compute volume create {{sh .Name}} \
+  --region {{sh .Region}} \
+  --size {{.Size}}GiB
+{{- if .Description}} \
+  --desc {{sh .Description}}
+{{- end}}
+{{- if .FilesystemType}} \
+  --fs-type {{sh .FilesystemType}}
+{{- end}}
+{{- if .Tags}} \
+  --tag {{sh (join .Tags ",")}}
+{{- end}}
+{{- range .DropletIDs}}
+# then attach it to droplet {{.}}: doctl compute volume-action attach NEW_VOLUME_ID {{.}}
+{{- end}}
+`),
+	},
+}
+
+// exportTemplate parses a template with the export functions available.
+func exportTemplate(text string) *template.Template {
+	return template.Must(template.New("export").Funcs(exportFuncs).Parse(text))
+}
+
+// hclString quotes s as an HCL string, escaping template sequences.
+func hclString(s string) string {
+	q := strconv.Quote(s)
+	q = strings.ReplaceAll(q, "${", "$${")
+	return strings.ReplaceAll(q, "%{", "%%{")
+}
+
+func hclStringList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = hclString(v)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+func intList(values []int) string {
+	return "[" + strings.ReplaceAll(joinInts(values), ",", ", ") + "]"
+}
+
+func joinInts(values []int) string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = strconv.Itoa(v)
+	}
+	return strings.Join(s, ",")
+}
+
+var shellSafe = regexp.MustCompile(`^[A-Za-z0-9._/:,=@+-]+$`)
+
+// shellQuote single-quotes s unless it only holds characters the shell
+// leaves alone.
+func shellQuote(s string) string {
+	if shellSafe.MatchString(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+var labelInvalid = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// terraformLabel turns a resource name into a valid Terraform block label.
+func terraformLabel(name, fallback string) string {
+	label := strings.Trim(labelInvalid.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if label == "" {
+		label = fallback
+	}
+	if label[0] >= '0' && label[0] <= '9' {
+		label = "r_" + label
+	}
+	return label
+}
@@ -0,0 +1,359 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	exportFormatTerraform = "terraform"
+	exportFormatDoctl     = "doctl"
+)
+
+// exportTypes are the resource types resource-export supports, by URN type.
+var exportTypes = []string{"domain_record", "droplet", "firewall", "loadbalancer", "volume"}
+
+// ExportTools renders existing resources as Terraform or doctl.
+type ExportTools struct {
+	client func(ctx context.Context) (*godo.Client, error)
+}
+
+// NewExportTools creates a new ExportTools instance.
+func NewExportTools(client func(ctx context.Context) (*godo.Client, error)) *ExportTools {
+	return &ExportTools{client: client}
+}
+
+// ResourceExport is the result of resource-export.
+type ResourceExport struct {
+	Type   string `json:"type"`
+	ID     string `json:"id"`
+	Format string `json:"format"`
+	Caveat string `json:"caveat"`
+	Output string `json:"output"`
+}
+
+// exportHeader is shared by every template's data.
+type exportHeader struct {
+	Caveat string
+	Label  string
+}
+
+type dropletExport struct {
+	exportHeader
+	Name, Region, Size, Image, VPCUUID string
+	Backups, IPv6, Monitoring          bool
+	Tags                               []string
+}
+
+type loadBalancerExport struct {
+	exportHeader
+	Name, Region, Size, VPCUUID, Tag         string
+	SizeUnit                                 uint32
+	ForwardingRules                          []godo.ForwardingRule
+	HealthCheck                              *godo.HealthCheck
+	DropletIDs                               []int
+	RedirectHTTPToHTTPS, EnableProxyProtocol bool
+	DoctlForwardingRules, DoctlHealthCheck   string
+}
+
+// ruleTargets are the sources or destinations of a firewall rule, with the
+// attribute prefix Terraform uses for them.
+type ruleTargets struct {
+	Prefix           string
+	Addresses        []string
+	Tags             []string
+	DropletIDs       []int
+	LoadBalancerUIDs []string
+	KubernetesIDs    []string
+}
+
+type firewallRuleExport struct {
+	Protocol, PortRange string
+	Targets             ruleTargets
+}
+
+type firewallExport struct {
+	exportHeader
+	Name                                  string
+	DropletIDs                            []int
+	Tags                                  []string
+	InboundRules, OutboundRules           []firewallRuleExport
+	DoctlInboundRules, DoctlOutboundRules string
+}
+
+type domainRecordExport struct {
+	exportHeader
+	godo.DomainRecord
+	Domain      string
+	HasPriority bool
+}
+
+type volumeExport struct {
+	exportHeader
+	Name, Region, Description, FilesystemType string
+	Size                                      int64
+	Tags                                      []string
+	DropletIDs                                []int
+}
+
+// exportResource fetches the resource named by URN, or by Type and ID, and
+// renders it in the requested Format.
+func (e *ExportTools) exportResource(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	format, _ := args["Format"].(string)
+	if format == "" {
+		format = exportFormatTerraform
+	}
+	if format != exportFormatTerraform && format != exportFormatDoctl {
+		return mcp.NewToolResultError(fmt.Sprintf("unsupported Format %q; choose terraform or doctl", format)), nil
+	}
+
+	urn, _ := args["URN"].(string)
+	resourceType, _ := args["Type"].(string)
+	id, _ := args["ID"].(string)
+	if (urn == "") == (resourceType == "") {
+		return mcp.NewToolResultError("exactly one of URN or Type is required"), nil
+	}
+	if urn != "" {
+		parts := strings.SplitN(urn, ":", 3)
+		if len(parts) != 3 || parts[0] != "do" || parts[2] == "" {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid URN %q; expected do:<type>:<id>", urn)), nil
+		}
+		resourceType, id = parts[1], parts[2]
+	}
+	if !slices.Contains(exportTypes, resourceType) {
+		return mcp.NewToolResultError(fmt.Sprintf("unsupported resource type %q; choose one of %s", resourceType, strings.Join(exportTypes, ", "))), nil
+	}
+	if id == "" {
+		return mcp.NewToolResultError("ID is required"), nil
+	}
+	domain, _ := args["Domain"].(string)
+	if resourceType == "domain_record" && domain == "" {
+		return mcp.NewToolResultError("Domain is required for domain records"), nil
+	}
+	numericID, err := strconv.Atoi(id)
+	if (resourceType == "droplet" || resourceType == "domain_record") && err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("%s ID must be a number, got %q", resourceType, id)), nil
+	}
+
+	client, err := e.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	data, err := fetchExport(ctx, client, resourceType, id, numericID, domain)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+
+	var out bytes.Buffer
+	if err := exportTemplates[resourceType][format].Execute(&out, data); err != nil {
+		return nil, fmt.Errorf("render error: %w", err)
+	}
+
+	jsonData, err := json.MarshalIndent(ResourceExport{
+		Type:   resourceType,
+		ID:     id,
+		Format: format,
+		Caveat: exportCaveat,
+		Output: out.String(),
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+// fetchExport reads the resource and converts it to its template data.
+func fetchExport(ctx context.Context, client *godo.Client, resourceType, id string, numericID int, domain string) (any, error) {
+	header := func(name string) exportHeader {
+		return exportHeader{Caveat: exportCaveat, Label: terraformLabel(name, resourceType+"_"+id)}
+	}
+
+	switch resourceType {
+	case "droplet":
+		d, _, err := client.Droplets.Get(ctx, numericID)
+		if err != nil {
+			return nil, err
+		}
+		data := dropletExport{
+			exportHeader: header(d.Name),
+			Name:         d.Name,
+			Size:         d.SizeSlug,
+			VPCUUID:      d.VPCUUID,
+			Backups:      slices.Contains(d.Features, "backups"),
+			IPv6:         slices.Contains(d.Features, "ipv6"),
+			Monitoring:   slices.Contains(d.Features, "monitoring"),
+			Tags:         d.Tags,
+		}
+		if d.Region != nil {
+			data.Region = d.Region.Slug
+		}
+		if d.Image != nil {
+			data.Image = d.Image.Slug
+			if data.Image == "" {
+				data.Image = strconv.Itoa(d.Image.ID)
+			}
+		}
+		return data, nil
+
+	case "loadbalancer":
+		lb, _, err := client.LoadBalancers.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		data := loadBalancerExport{
+			exportHeader:        header(lb.Name),
+			Name:                lb.Name,
+			Size:                lb.SizeSlug,
+			SizeUnit:            lb.SizeUnit,
+			VPCUUID:             lb.VPCUUID,
+			Tag:                 lb.Tag,
+			ForwardingRules:     lb.ForwardingRules,
+			HealthCheck:         lb.HealthCheck,
+			DropletIDs:          lb.DropletIDs,
+			RedirectHTTPToHTTPS: lb.RedirectHttpToHttps,
+			EnableProxyProtocol: lb.EnableProxyProtocol,
+		}
+		if lb.Region != nil {
+			data.Region = lb.Region.Slug
+		}
+		rules := make([]string, len(lb.ForwardingRules))
+		for i, r := range lb.ForwardingRules {
+			rules[i] = fmt.Sprintf("entry_protocol:%s,entry_port:%d,target_protocol:%s,target_port:%d", r.EntryProtocol, r.EntryPort, r.TargetProtocol, r.TargetPort)
+			if r.CertificateID != "" {
+				rules[i] += ",certificate_id:" + r.CertificateID
+			}
+			if r.TlsPassthrough {
+				rules[i] += ",tls_passthrough:true"
+			}
+		}
+		data.DoctlForwardingRules = strings.Join(rules, " ")
+		if hc := lb.HealthCheck; hc != nil {
+			data.DoctlHealthCheck = fmt.Sprintf("protocol:%s,port:%d", hc.Protocol, hc.Port)
+			if hc.Path != "" {
+				data.DoctlHealthCheck += ",path:" + hc.Path
+			}
+			data.DoctlHealthCheck += fmt.Sprintf(",check_interval_seconds:%d,response_timeout_seconds:%d,healthy_threshold:%d,unhealthy_threshold:%d",
+				hc.CheckIntervalSeconds, hc.ResponseTimeoutSeconds, hc.HealthyThreshold, hc.UnhealthyThreshold)
+		}
+		return data, nil
+
+	case "firewall":
+		fw, _, err := client.Firewalls.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		data := firewallExport{
+			exportHeader: header(fw.Name),
+			Name:         fw.Name,
+			DropletIDs:   fw.DropletIDs,
+			Tags:         fw.Tags,
+		}
+		var inbound, outbound []string
+		for _, r := range fw.InboundRules {
+			rule := firewallRuleExport{Protocol: r.Protocol, PortRange: r.PortRange, Targets: ruleTargets{Prefix: "source"}}
+			if s := r.Sources; s != nil {
+				rule.Targets = ruleTargets{Prefix: "source", Addresses: s.Addresses, Tags: s.Tags, DropletIDs: s.DropletIDs, LoadBalancerUIDs: s.LoadBalancerUIDs, KubernetesIDs: s.KubernetesIDs}
+			}
+			data.InboundRules = append(data.InboundRules, rule)
+			inbound = append(inbound, doctlFirewallRule(rule))
+		}
+		for _, r := range fw.OutboundRules {
+			rule := firewallRuleExport{Protocol: r.Protocol, PortRange: r.PortRange, Targets: ruleTargets{Prefix: "destination"}}
+			if d := r.Destinations; d != nil {
+				rule.Targets = ruleTargets{Prefix: "destination", Addresses: d.Addresses, Tags: d.Tags, DropletIDs: d.DropletIDs, LoadBalancerUIDs: d.LoadBalancerUIDs, KubernetesIDs: d.KubernetesIDs}
+			}
+			data.OutboundRules = append(data.OutboundRules, rule)
+			outbound = append(outbound, doctlFirewallRule(rule))
+		}
+		data.DoctlInboundRules = strings.Join(inbound, " ")
+		data.DoctlOutboundRules = strings.Join(outbound, " ")
+		return data, nil
+
+	case "domain_record":
+		r, _, err := client.Domains.Record(ctx, domain, numericID)
+		if err != nil {
+			return nil, err
+		}
+		return domainRecordExport{
+			exportHeader: header(r.Name + "_" + r.Type),
+			DomainRecord: *r,
+			Domain:       domain,
+			HasPriority:  r.Type == "MX" || r.Type == "SRV",
+		}, nil
+
+	case "volume":
+		v, _, err := client.Storage.GetVolume(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		data := volumeExport{
+			exportHeader:   header(v.Name),
+			Name:           v.Name,
+			Description:    v.Description,
+			FilesystemType: v.FilesystemType,
+			Size:           v.SizeGigaBytes,
+			Tags:           v.Tags,
+			DropletIDs:     v.DropletIDs,
+		}
+		if v.Region != nil {
+			data.Region = v.Region.Slug
+		}
+		return data, nil
+	}
+	return nil, fmt.Errorf("unsupported resource type %q", resourceType)
+}
+
+// doctlFirewallRule formats a rule the way doctl's --inbound-rules and
+// --outbound-rules flags expect.
+func doctlFirewallRule(r firewallRuleExport) string {
+	parts := []string{"protocol:" + r.Protocol}
+	if r.PortRange != "" {
+		parts = append(parts, "ports:"+r.PortRange)
+	}
+	for _, a := range r.Targets.Addresses {
+		parts = append(parts, "address:"+a)
+	}
+	for _, t := range r.Targets.Tags {
+		parts = append(parts, "tag:"+t)
+	}
+	for _, d := range r.Targets.DropletIDs {
+		parts = append(parts, "droplet_id:"+strconv.Itoa(d))
+	}
+	for _, l := range r.Targets.LoadBalancerUIDs {
+		parts = append(parts, "load_balancer_uid:"+l)
+	}
+	for _, k := range r.Targets.KubernetesIDs {
+		parts = append(parts, "kubernetes_id:"+k)
+	}
+	return strings.Join(parts, ",")
+}
+
+// Tools returns the export tools.
+func (e *ExportTools) Tools() []server.ServerTool {
+	return []server.ServerTool{
+		{
+			Handler: e.exportResource,
+			Tool: mcp.NewTool("resource-export",
+				WithHints(HintsRead),
+				mcp.WithDescription("Render an existing droplet, load balancer, firewall, domain record or volume as a Terraform digitalocean_* resource block or the equivalent doctl create command, to move agent-created infrastructure into infrastructure as code. The output is best-effort and must be reviewed before use: settings the API does not return, such as SSH keys and user data, are missing."),
+				mcp.WithString("URN", mcp.Description("URN of the resource, e.g. do:droplet:123. Use either URN or Type and ID")),
+				mcp.WithString("Type", mcp.Enum(exportTypes...), mcp.Description("Type of the resource. Use either URN or Type and ID")),
+				mcp.WithString("ID", mcp.Description("ID of the resource; for domain records, the record ID")),
+				mcp.WithString("Domain", mcp.Description("Domain name the record belongs to; required for domain records")),
+				mcp.WithString("Format", mcp.DefaultString(exportFormatTerraform), mcp.Enum(exportFormatTerraform, exportFormatDoctl), mcp.Description("Output format")),
+			),
+		},
+	}
+}
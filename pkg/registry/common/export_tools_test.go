@@ -0,0 +1,229 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+type exportMocks struct {
+	droplets      *MockDropletsService
+	loadBalancers *MockLoadBalancersService
+	firewalls     *MockFirewallsService
+	domains       *MockDomainsService
+	storage       *MockStorageService
+}
+
+func setupExportToolsWithMocks(ctrl *gomock.Controller) (*ExportTools, exportMocks) {
+	m := exportMocks{
+		droplets:      NewMockDropletsService(ctrl),
+		loadBalancers: NewMockLoadBalancersService(ctrl),
+		firewalls:     NewMockFirewallsService(ctrl),
+		domains:       NewMockDomainsService(ctrl),
+		storage:       NewMockStorageService(ctrl),
+	}
+	client := func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{Droplets: m.droplets, LoadBalancers: m.loadBalancers, Firewalls: m.firewalls, Domains: m.domains, Storage: m.storage}, nil
+	}
+	return NewExportTools(client), m
+}
+
+func TestExportTools_exportResource(t *testing.T) {
+	everywhere := []string{"0.0.0.0/0", "::/0"}
+	tests := []struct {
+		name      string
+		args      map[string]any
+		mockSetup func(exportMocks)
+	}{
+		{
+			name: "droplet",
+			args: map[string]any{"URN": "do:droplet:123"},
+			mockSetup: func(m exportMocks) {
+				m.droplets.EXPECT().Get(gomock.Any(), 123).Return(&godo.Droplet{
+					ID:       123,
+					Name:     "web-1",
+					Region:   &godo.Region{Slug: "nyc3"},
+					SizeSlug: "s-1vcpu-1gb",
+					Image:    &godo.Image{ID: 7, Slug: "ubuntu-24-04-x64"},
+					VPCUUID:  "vpc-1",
+					Features: []string{"backups", "monitoring"},
+					Tags:     []string{"web", "prod"},
+				}, nil, nil)
+			},
+		},
+		{
+			name: "loadbalancer",
+			args: map[string]any{"Type": "loadbalancer", "ID": "lb-1"},
+			mockSetup: func(m exportMocks) {
+				m.loadBalancers.EXPECT().Get(gomock.Any(), "lb-1").Return(&godo.LoadBalancer{
+					ID:       "lb-1",
+					Name:     "public-lb",
+					Region:   &godo.Region{Slug: "ams3"},
+					SizeUnit: 2,
+					ForwardingRules: []godo.ForwardingRule{
+						{EntryProtocol: "http", EntryPort: 80, TargetProtocol: "http", TargetPort: 8080},
+						{EntryProtocol: "https", EntryPort: 443, TargetProtocol: "http", TargetPort: 8080, CertificateID: "cert-1"},
+					},
+					HealthCheck:         &godo.HealthCheck{Protocol: "http", Port: 8080, Path: "/healthz", CheckIntervalSeconds: 10, ResponseTimeoutSeconds: 5, HealthyThreshold: 3, UnhealthyThreshold: 3},
+					Tag:                 "web",
+					RedirectHttpToHttps: true,
+				}, nil, nil)
+			},
+		},
+		{
+			name: "firewall",
+			args: map[string]any{"URN": "do:firewall:fw-1"},
+			mockSetup: func(m exportMocks) {
+				m.firewalls.EXPECT().Get(gomock.Any(), "fw-1").Return(&godo.Firewall{
+					ID:   "fw-1",
+					Name: "web firewall",
+					InboundRules: []godo.InboundRule{
+						{Protocol: "tcp", PortRange: "443", Sources: &godo.Sources{Addresses: everywhere}},
+						{Protocol: "tcp", PortRange: "22", Sources: &godo.Sources{Tags: []string{"bastion"}, DropletIDs: []int{9}}},
+					},
+					OutboundRules: []godo.OutboundRule{
+						{Protocol: "icmp", Destinations: &godo.Destinations{Addresses: everywhere}},
+					},
+					DropletIDs: []int{123, 124},
+				}, nil, nil)
+			},
+		},
+		{
+			name: "domain_record",
+			args: map[string]any{"Type": "domain_record", "ID": "55", "Domain": "example.com"},
+			mockSetup: func(m exportMocks) {
+				m.domains.EXPECT().Record(gomock.Any(), "example.com", 55).Return(&godo.DomainRecord{
+					ID: 55, Type: "MX", Name: "@", Data: "mail.example.com.", Priority: 10, TTL: 3600,
+				}, nil, nil)
+			},
+		},
+		{
+			name: "volume",
+			args: map[string]any{"URN": "do:volume:vol-1"},
+			mockSetup: func(m exportMocks) {
+				m.storage.EXPECT().GetVolume(gomock.Any(), "vol-1").Return(&godo.Volume{
+					ID:             "vol-1",
+					Name:           "pg-data",
+					Region:         &godo.Region{Slug: "nyc3"},
+					SizeGigaBytes:  100,
+					Description:    "postgres data",
+					FilesystemType: "ext4",
+					DropletIDs:     []int{123},
+					Tags:           []string{"db"},
+				}, nil, nil)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		for _, format := range []string{exportFormatTerraform, exportFormatDoctl} {
+			t.Run(tc.name+"/"+format, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				tool, m := setupExportToolsWithMocks(ctrl)
+				tc.mockSetup(m)
+
+				args := map[string]any{"Format": format}
+				for k, v := range tc.args {
+					args[k] = v
+				}
+				resp, err := tool.exportResource(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+				require.NoError(t, err)
+				require.False(t, resp.IsError)
+
+				var export ResourceExport
+				require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &export))
+				require.Equal(t, tc.name, export.Type)
+				require.Equal(t, format, export.Format)
+				require.Contains(t, export.Caveat, "Review before use")
+
+				golden := filepath.Join("testdata", "export", tc.name+"."+format+".golden")
+				if *updateGolden {
+					require.NoError(t, os.MkdirAll(filepath.Dir(golden), 0o755))
+					require.NoError(t, os.WriteFile(golden, []byte(export.Output), 0o644))
+				}
+				want, err := os.ReadFile(golden)
+				require.NoError(t, err)
+				require.Equal(t, string(want), export.Output)
+			})
+		}
+	}
+}
+
+func TestExportTools_exportResourceErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(exportMocks)
+		wantMessage string
+	}{
+		{
+			name:        "no resource",
+			args:        map[string]any{},
+			wantMessage: "exactly one of URN or Type is required",
+		},
+		{
+			name:        "urn and type",
+			args:        map[string]any{"URN": "do:droplet:1", "Type": "droplet"},
+			wantMessage: "exactly one of URN or Type is required",
+		},
+		{
+			name:        "invalid urn",
+			args:        map[string]any{"URN": "droplet-1"},
+			wantMessage: `invalid URN "droplet-1"`,
+		},
+		{
+			name:        "unsupported type",
+			args:        map[string]any{"URN": "do:kubernetes:abc"},
+			wantMessage: `unsupported resource type "kubernetes"`,
+		},
+		{
+			name:        "unsupported format",
+			args:        map[string]any{"URN": "do:droplet:1", "Format": "pulumi"},
+			wantMessage: `unsupported Format "pulumi"`,
+		},
+		{
+			name:        "non-numeric droplet id",
+			args:        map[string]any{"Type": "droplet", "ID": "web-1"},
+			wantMessage: `droplet ID must be a number, got "web-1"`,
+		},
+		{
+			name:        "record without domain",
+			args:        map[string]any{"Type": "domain_record", "ID": "5"},
+			wantMessage: "Domain is required for domain records",
+		},
+		{
+			name: "api error",
+			args: map[string]any{"URN": "do:volume:vol-9"},
+			mockSetup: func(m exportMocks) {
+				m.storage.EXPECT().GetVolume(gomock.Any(), "vol-9").Return(nil, nil, errors.New("volume not found"))
+			},
+			wantMessage: "volume not found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			tool, m := setupExportToolsWithMocks(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(m)
+			}
+
+			resp, err := tool.exportResource(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			require.True(t, resp.IsError)
+			require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.wantMessage)
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// FieldNaming selects the field names of droplet, load balancer and image
+// outputs.
+type FieldNaming string
+
+const (
+	// FieldNamingGodo keeps the API's snake_case names, as godo serializes
+	// them. It is the default.
+	FieldNamingGodo FieldNaming = "godo"
+	// FieldNamingDoctl uses doctl's column names, such as PublicIPv4 and
+	// Memory, for scripts migrated from doctl.
+	FieldNamingDoctl FieldNaming = "doctl"
+)
+
+// ParseFieldNaming parses a --field-naming value; "" is FieldNamingGodo.
+func ParseFieldNaming(s string) (FieldNaming, error) {
+	switch naming := FieldNaming(strings.ToLower(strings.TrimSpace(s))); naming {
+	case "", FieldNamingGodo:
+		return FieldNamingGodo, nil
+	case FieldNamingDoctl:
+		return naming, nil
+	default:
+		return "", fmt.Errorf("unknown field naming %q: use %s or %s", s, FieldNamingGodo, FieldNamingDoctl)
+	}
+}
+
+// The doctl*Names structs give, in doctl tags, the doctl names of the fields
+// whose doctl column is not simply the godo field name. The json tags match
+// the godo field they rename.
+type (
+	dropletDoctlNames struct {
+		Vcpus     int      `json:"vcpus" doctl:"VCPUs"`
+		VolumeIDs []string `json:"volume_ids" doctl:"Volumes"`
+	}
+	loadBalancerDoctlNames struct {
+		SizeSlug string `json:"size" doctl:"Size"`
+	}
+	imageDoctlNames struct {
+		MinDiskSize int `json:"min_disk_size" doctl:"MinDisk"`
+	}
+)
+
+// FieldRenamer renames the top-level fields of a resource's JSON output.
+// Nested objects keep their names.
+type FieldRenamer struct {
+	renames map[string]string
+	// derive adds fields computed from the renamed object, such as a
+	// droplet's PublicIPv4. It is optional.
+	derive func(obj map[string]any)
+}
+
+// jsonName returns the JSON name of a struct field, or "" for fields that are
+// not serialized.
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "-" || !f.IsExported() {
+		return ""
+	}
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
+
+// doctlRenames maps the JSON names of resource's fields to their doctl names:
+// the godo field name, or the doctl tag of the field with the same JSON name
+// in overrides.
+func doctlRenames(resource, overrides reflect.Type) map[string]string {
+	renames := map[string]string{}
+	for f := range resource.Fields() {
+		if name := jsonName(f); name != "" {
+			renames[name] = f.Name
+		}
+	}
+	for f := range overrides.Fields() {
+		renames[jsonName(f)] = f.Tag.Get("doctl")
+	}
+	return renames
+}
+
+// doctlDropletIPs adds doctl's PublicIPv4, PrivateIPv4 and PublicIPv6 columns
+// to a renamed droplet.
+func doctlDropletIPs(obj map[string]any) {
+	raw, ok := obj["Networks"]
+	if !ok {
+		return
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return
+	}
+	var droplet godo.Droplet
+	if json.Unmarshal(data, &droplet.Networks) != nil {
+		return
+	}
+	obj["PublicIPv4"], _ = droplet.PublicIPv4()
+	obj["PrivateIPv4"], _ = droplet.PrivateIPv4()
+	obj["PublicIPv6"], _ = droplet.PublicIPv6()
+}
+
+var (
+	// DoctlDropletFields renames droplet outputs to doctl's names.
+	DoctlDropletFields = FieldRenamer{
+		renames: doctlRenames(reflect.TypeFor[godo.Droplet](), reflect.TypeFor[dropletDoctlNames]()),
+		derive:  doctlDropletIPs,
+	}
+	// DoctlLoadBalancerFields renames load balancer outputs to doctl's names.
+	DoctlLoadBalancerFields = FieldRenamer{
+		renames: doctlRenames(reflect.TypeFor[godo.LoadBalancer](), reflect.TypeFor[loadBalancerDoctlNames]()),
+	}
+	// DoctlImageFields renames image outputs to doctl's names.
+	DoctlImageFields = FieldRenamer{
+		renames: doctlRenames(reflect.TypeFor[godo.Image](), reflect.TypeFor[imageDoctlNames]()),
+	}
+)
+
+// renameObject renames the fields of obj in place and reports whether any
+// were renamed.
+func (r FieldRenamer) renameObject(obj map[string]any) bool {
+	renamed := false
+	for from, to := range r.renames {
+		if v, ok := obj[from]; ok && from != to {
+			delete(obj, from)
+			obj[to] = v
+			renamed = true
+		}
+	}
+	if renamed && r.derive != nil {
+		r.derive(obj)
+	}
+	return renamed
+}
+
+// rename renames the objects in v, an object, an array of objects or the
+// AllResult envelope of a list fetched with All, and reports whether any field
+// was renamed.
+func (r FieldRenamer) rename(v any) bool {
+	renamed := false
+	switch v := v.(type) {
+	case map[string]any:
+		if items, ok := v["items"].([]any); ok {
+			if _, ok := v["truncated"]; ok {
+				return r.rename(items)
+			}
+		}
+		renamed = r.renameObject(v)
+	case []any:
+		for _, item := range v {
+			if obj, ok := item.(map[string]any); ok {
+				renamed = r.renameObject(obj) || renamed
+			}
+		}
+	}
+	return renamed
+}
+
+// decodeJSON decodes data, a single JSON value, keeping numbers exact so
+// large IDs survive.
+func decodeJSON(data string) (any, error) {
+	dec := json.NewDecoder(strings.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, errors.New("trailing data after JSON value")
+	}
+	return v, nil
+}
+
+// renameText renames the fields in a text content item: JSON Lines as
+// returned by NewStreamResult, whose chunks end in a newline, or an indented
+// JSON document. Items that are not JSON or have no field to rename, such as
+// meta items, are returned unchanged.
+func (r FieldRenamer) renameText(text string) string {
+	if !strings.HasSuffix(text, "\n") {
+		v, err := decodeJSON(text)
+		if err != nil || !r.rename(v) {
+			return text
+		}
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return text
+		}
+		return string(data)
+	}
+
+	var out bytes.Buffer
+	for line := range strings.Lines(text) {
+		v, err := decodeJSON(line)
+		if err != nil {
+			return text
+		}
+		if !r.rename(v) {
+			out.WriteString(line)
+			continue
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return text
+		}
+		out.Write(data)
+		out.WriteByte('\n')
+	}
+	return out.String()
+}
+
+// RenameResult renames the fields in the text content of a successful
+// result. Structured content keeps the godo names its output schema declares.
+func (r FieldRenamer) RenameResult(result *mcp.CallToolResult) {
+	if result == nil || result.IsError {
+		return
+	}
+	for i, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			text.Text = r.renameText(text.Text)
+			result.Content[i] = text
+		}
+	}
+}
+
+// Wrap returns handler with its results renamed.
+func (r FieldRenamer) Wrap(handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := handler(ctx, req)
+		if err == nil {
+			r.RenameResult(result)
+		}
+		return result, err
+	}
+}
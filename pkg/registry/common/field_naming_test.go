@@ -0,0 +1,148 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+var sampleDroplet = godo.Droplet{
+	ID:       123456789,
+	Name:     "web-1",
+	Memory:   2048,
+	Vcpus:    2,
+	Disk:     50,
+	Region:   &godo.Region{Slug: "nyc3", Name: "New York 3"},
+	Image:    &godo.Image{ID: 7, Slug: "ubuntu-24-04-x64", Distribution: "Ubuntu", MinDiskSize: 15},
+	Size:     &godo.Size{Slug: "s-2vcpu-2gb", Memory: 2048, Vcpus: 2, Disk: 50},
+	SizeSlug: "s-2vcpu-2gb",
+	Status:   "active",
+	Networks: &godo.Networks{
+		V4: []godo.NetworkV4{
+			{IPAddress: "203.0.113.10", Netmask: "255.255.255.0", Gateway: "203.0.113.1", Type: "public"},
+			{IPAddress: "10.10.0.5", Netmask: "255.255.0.0", Gateway: "10.10.0.1", Type: "private"},
+		},
+		V6: []godo.NetworkV6{{IPAddress: "2001:db8::10", Netmask: 64, Gateway: "2001:db8::1", Type: "public"}},
+	},
+	Created:   "2026-01-02T03:04:05Z",
+	Tags:      []string{"web"},
+	VolumeIDs: []string{"vol-1"},
+	VPCUUID:   "5a4981aa-9653-4bd1-bef5-d6bff52042e4",
+}
+
+func TestParseFieldNaming(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    FieldNaming
+		wantErr bool
+	}{
+		{in: "", want: FieldNamingGodo},
+		{in: "godo", want: FieldNamingGodo},
+		{in: " Doctl ", want: FieldNamingDoctl},
+		{in: "camel", wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := ParseFieldNaming(tc.in)
+			if tc.wantErr {
+				require.ErrorContains(t, err, "unknown field naming")
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+// renderDroplet returns the droplet-get output for sampleDroplet under naming.
+func renderDroplet(t *testing.T, naming FieldNaming) string {
+	data, err := json.MarshalIndent(sampleDroplet, "", "  ")
+	require.NoError(t, err)
+	result := mcp.NewToolResultText(string(data))
+	if naming == FieldNamingDoctl {
+		DoctlDropletFields.RenameResult(result)
+	}
+	return result.Content[0].(mcp.TextContent).Text
+}
+
+func TestFieldNaming_dropletGolden(t *testing.T) {
+	for _, naming := range []FieldNaming{FieldNamingGodo, FieldNamingDoctl} {
+		t.Run(string(naming), func(t *testing.T) {
+			output := renderDroplet(t, naming)
+			golden := filepath.Join("testdata", "field_naming", "droplet."+string(naming)+".golden")
+			if *updateGolden {
+				require.NoError(t, os.MkdirAll(filepath.Dir(golden), 0o755))
+				require.NoError(t, os.WriteFile(golden, []byte(output), 0o644))
+			}
+			want, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			require.Equal(t, string(want), output)
+		})
+	}
+}
+
+func TestFieldRenamer_RenameResult(t *testing.T) {
+	t.Run("list keeps nested names and exact IDs", func(t *testing.T) {
+		result := mcp.NewToolResultText(`[{"id": 9007199254740993, "size_slug": "s-1vcpu-1gb", "region": {"slug": "nyc3"}}]`)
+		DoctlDropletFields.RenameResult(result)
+		require.JSONEq(t, `[{"ID": 9007199254740993, "SizeSlug": "s-1vcpu-1gb", "Region": {"slug": "nyc3"}}]`, result.Content[0].(mcp.TextContent).Text)
+	})
+
+	t.Run("json lines and meta", func(t *testing.T) {
+		result, err := NewStreamResult([]godo.Image{{ID: 1, MinDiskSize: 20}, {ID: 2}}, StreamMeta{})
+		require.NoError(t, err)
+		DoctlImageFields.RenameResult(result)
+		require.Len(t, result.Content, 2)
+		require.Contains(t, result.Content[0].(mcp.TextContent).Text, `"MinDisk":20`)
+		require.Contains(t, result.Content[0].(mcp.TextContent).Text, `{"ID":2}`)
+		require.Equal(t, `{"meta":{"records":2,"chunks":1,"chunk_size":100}}`, result.Content[1].(mcp.TextContent).Text)
+	})
+
+	t.Run("all envelope", func(t *testing.T) {
+		result, err := NewAllResult([]godo.Droplet{{ID: 1, Vcpus: 2}}, true)
+		require.NoError(t, err)
+		DoctlDropletFields.RenameResult(result)
+		var envelope struct {
+			Items        []map[string]any `json:"items"`
+			Truncated    bool             `json:"truncated"`
+			TotalFetched int              `json:"total_fetched"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &envelope))
+		require.Len(t, envelope.Items, 1)
+		require.Equal(t, float64(2), envelope.Items[0]["VCPUs"])
+		require.NotContains(t, envelope.Items[0], "vcpus")
+		require.True(t, envelope.Truncated)
+		require.Equal(t, 1, envelope.TotalFetched)
+	})
+
+	t.Run("load balancer size", func(t *testing.T) {
+		result := mcp.NewToolResultText(`{"id": "lb-1", "size": "lb-small", "size_unit": 1}`)
+		DoctlLoadBalancerFields.RenameResult(result)
+		require.JSONEq(t, `{"ID": "lb-1", "Size": "lb-small", "SizeUnit": 1}`, result.Content[0].(mcp.TextContent).Text)
+	})
+
+	t.Run("errors and plain text are untouched", func(t *testing.T) {
+		errResult := mcp.NewToolResultError(`{"size_slug": "bad"}`)
+		DoctlDropletFields.RenameResult(errResult)
+		require.Equal(t, `{"size_slug": "bad"}`, errResult.Content[0].(mcp.TextContent).Text)
+
+		text := mcp.NewToolResultText("droplet deleted")
+		DoctlDropletFields.RenameResult(text)
+		require.Equal(t, "droplet deleted", text.Content[0].(mcp.TextContent).Text)
+	})
+
+	t.Run("wrap", func(t *testing.T) {
+		handler := DoctlDropletFields.Wrap(func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(`{"vcpus": 2}`), nil
+		})
+		result, err := handler(context.Background(), mcp.CallToolRequest{})
+		require.NoError(t, err)
+		require.JSONEq(t, `{"VCPUs": 2}`, result.Content[0].(mcp.TextContent).Text)
+	})
+}
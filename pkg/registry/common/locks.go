@@ -0,0 +1,111 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DefaultResourceLockTimeout bounds how long a mutation waits for another
+// operation on the same resource to finish.
+const DefaultResourceLockTimeout = 30 * time.Second
+
+// SharedResourceLocks is the lock set every tool in this process uses, so
+// mutations of one resource through different tools are serialized too.
+var SharedResourceLocks = NewResourceLocks(DefaultResourceLockTimeout)
+
+// ResourceLocks serializes operations on the same resource, keyed by URN
+// (do:loadbalancer:<id>). Locks only exist within this process: several
+// server instances behind one HTTP endpoint do not see each other's locks.
+type ResourceLocks struct {
+	timeout time.Duration
+
+	mu    sync.Mutex
+	locks map[string]*resourceLock
+}
+
+// resourceLock is held while its one-slot channel is full. refs counts the
+// holder and waiters so unused locks can be dropped from the map.
+type resourceLock struct {
+	held chan struct{}
+	refs int
+}
+
+// NewResourceLocks returns a lock set whose Lock gives up after timeout.
+func NewResourceLocks(timeout time.Duration) *ResourceLocks {
+	return &ResourceLocks{timeout: timeout, locks: make(map[string]*resourceLock)}
+}
+
+// Lock waits until no other operation holds urn and returns the function that
+// releases it. It returns an error if the lock is not free within the timeout
+// or ctx is cancelled first.
+func (l *ResourceLocks) Lock(ctx context.Context, urn string) (func(), error) {
+	l.mu.Lock()
+	lock, ok := l.locks[urn]
+	if !ok {
+		lock = &resourceLock{held: make(chan struct{}, 1)}
+		l.locks[urn] = lock
+	}
+	lock.refs++
+	l.mu.Unlock()
+
+	timer := time.NewTimer(l.timeout)
+	defer timer.Stop()
+	select {
+	case lock.held <- struct{}{}:
+		return func() {
+			<-lock.held
+			l.release(urn, lock)
+		}, nil
+	case <-timer.C:
+		l.release(urn, lock)
+		return nil, fmt.Errorf("resource busy: another operation in progress on %s; gave up after %s. Operations are only serialized within this server process, so changes made through other server instances or clients are not waited for", urn, l.timeout)
+	case <-ctx.Done():
+		l.release(urn, lock)
+		return nil, ctx.Err()
+	}
+}
+
+func (l *ResourceLocks) release(urn string, lock *resourceLock) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lock.refs--
+	if lock.refs == 0 {
+		delete(l.locks, urn)
+	}
+}
+
+// Serialize wraps handler so calls on the same resource run one at a time.
+// urn returns the resource's URN for a request, or "" to run the handler
+// without a lock, for example when its ID argument is missing and the handler
+// will report that itself. A lock that cannot be taken is a tool error.
+func (l *ResourceLocks) Serialize(urn func(req mcp.CallToolRequest) string, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		key := urn(req)
+		if key == "" {
+			return handler(ctx, req)
+		}
+		unlock, err := l.Lock(ctx, key)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		defer unlock()
+		return handler(ctx, req)
+	}
+}
+
+// URNFromArg returns a urn function for Serialize that reads the string
+// argument arg as the ID of a resource of resourceType, e.g. "loadbalancer".
+func URNFromArg(resourceType, arg string) func(req mcp.CallToolRequest) string {
+	return func(req mcp.CallToolRequest) string {
+		id, _ := req.GetArguments()[arg].(string)
+		if id == "" {
+			return ""
+		}
+		return "do:" + resourceType + ":" + id
+	}
+}
@@ -0,0 +1,110 @@
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceLocks(t *testing.T) {
+	t.Run("waits for the holder to release", func(t *testing.T) {
+		locks := NewResourceLocks(time.Second)
+		unlock, err := locks.Lock(context.Background(), "do:loadbalancer:1")
+		require.NoError(t, err)
+
+		acquired := make(chan struct{})
+		go func() {
+			unlock2, err := locks.Lock(context.Background(), "do:loadbalancer:1")
+			if err == nil {
+				unlock2()
+			}
+			close(acquired)
+		}()
+
+		select {
+		case <-acquired:
+			t.Fatal("second lock acquired while the first was held")
+		case <-time.After(20 * time.Millisecond):
+		}
+		unlock()
+		<-acquired
+	})
+
+	t.Run("different resources do not wait", func(t *testing.T) {
+		locks := NewResourceLocks(10 * time.Millisecond)
+		unlock, err := locks.Lock(context.Background(), "do:firewall:1")
+		require.NoError(t, err)
+		defer unlock()
+
+		unlock2, err := locks.Lock(context.Background(), "do:firewall:2")
+		require.NoError(t, err)
+		unlock2()
+	})
+
+	t.Run("times out as resource busy", func(t *testing.T) {
+		locks := NewResourceLocks(10 * time.Millisecond)
+		unlock, err := locks.Lock(context.Background(), "do:app:1")
+		require.NoError(t, err)
+		defer unlock()
+
+		_, err = locks.Lock(context.Background(), "do:app:1")
+		require.ErrorContains(t, err, "resource busy: another operation in progress on do:app:1")
+		require.ErrorContains(t, err, "only serialized within this server process")
+	})
+
+	t.Run("stops waiting when the context is cancelled", func(t *testing.T) {
+		locks := NewResourceLocks(time.Second)
+		unlock, err := locks.Lock(context.Background(), "do:app:1")
+		require.NoError(t, err)
+		defer unlock()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = locks.Lock(ctx, "do:app:1")
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("released locks are dropped", func(t *testing.T) {
+		locks := NewResourceLocks(10 * time.Millisecond)
+		unlock, err := locks.Lock(context.Background(), "do:kubernetes:1")
+		require.NoError(t, err)
+		_, err = locks.Lock(context.Background(), "do:kubernetes:1")
+		require.Error(t, err)
+		unlock()
+		require.Empty(t, locks.locks)
+	})
+}
+
+func TestResourceLocks_Serialize(t *testing.T) {
+	locks := NewResourceLocks(10 * time.Millisecond)
+	calls := 0
+	handler := locks.Serialize(URNFromArg("firewall", "ID"), func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		calls++
+		return mcp.NewToolResultText("ok"), nil
+	})
+	request := func(args map[string]any) mcp.CallToolRequest {
+		return mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
+	}
+
+	resp, err := handler(context.Background(), request(map[string]any{"ID": "fw-1"}))
+	require.NoError(t, err)
+	require.False(t, resp.IsError)
+
+	unlock, err := locks.Lock(context.Background(), "do:firewall:fw-1")
+	require.NoError(t, err)
+	resp, err = handler(context.Background(), request(map[string]any{"ID": "fw-1"}))
+	require.NoError(t, err)
+	require.True(t, resp.IsError)
+	require.Contains(t, resp.Content[0].(mcp.TextContent).Text, "resource busy")
+
+	// Without an ID the handler runs unlocked and reports the missing argument itself.
+	resp, err = handler(context.Background(), request(map[string]any{}))
+	require.NoError(t, err)
+	require.False(t, resp.IsError)
+	unlock()
+
+	require.Equal(t, 2, calls)
+}
@@ -0,0 +1,85 @@
+package common
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"mcp-digitalocean/internal/errutil"
+)
+
+// loggerKey is the context key of a request's logger.
+type loggerKey struct{}
+
+// discardLogger is returned by LoggerFromContext when there is no logger.
+var discardLogger = slog.New(slog.DiscardHandler)
+
+// ContextWithLogger returns ctx carrying logger as the request's logger. The
+// request logger middleware sets it with the tool name and a request ID.
+func ContextWithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// LoggerFromContext returns the request's logger, or fallback when ctx has
+// none, such as in a handler called outside the MCP server. With neither, it
+// returns a logger that discards everything, so handlers need no nil checks.
+func LoggerFromContext(ctx context.Context, fallback *slog.Logger) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok && logger != nil {
+		return logger
+	}
+	if fallback != nil {
+		return fallback
+	}
+	return discardLogger
+}
+
+// ToolLogger gives the handlers of a tool that embeds it the logger to log
+// with. registry.Register sets its fallback, the server's logger, with
+// WithLogger.
+type ToolLogger struct {
+	fallback *slog.Logger
+}
+
+// SetLogger sets the logger used for calls whose context carries none.
+func (l *ToolLogger) SetLogger(logger *slog.Logger) {
+	l.fallback = logger
+}
+
+// Logger returns the request's logger, or the fallback set with SetLogger.
+func (l *ToolLogger) Logger(ctx context.Context) *slog.Logger {
+	return LoggerFromContext(ctx, l.fallback)
+}
+
+// WithLogger sets the fallback logger of tool and returns tool, e.g.
+// common.WithLogger(droplet.NewDropletTool(getClient), logger).Tools().
+func WithLogger[T interface{ SetLogger(*slog.Logger) }](tool T, logger *slog.Logger) T {
+	tool.SetLogger(logger)
+	return tool
+}
+
+// ErrorResult logs err with the request's logger and returns it as an error
+// result, like mcp.NewToolResultErrorFromErr(text, err). It logs at warn level,
+// or at error level when the API answered with a 5xx status.
+func ErrorResult(ctx context.Context, fallback *slog.Logger, text string, err error) *mcp.CallToolResult {
+	LoggerFromContext(ctx, fallback).Log(ctx, errorLevel(nil, err), text, "error", err)
+	return mcp.NewToolResultErrorFromErr(text, err)
+}
+
+// APIErrorResult is ErrorResult for a failed API call that returned resp. The
+// result is a JSON payload with the status, request ID and rate limit, as
+// built by errutil.NewAPIErrorResultWithText, so callers can quote the request
+// ID to support. resp may be nil when the call discarded it.
+func APIErrorResult(ctx context.Context, fallback *slog.Logger, text string, resp *godo.Response, err error) *mcp.CallToolResult {
+	LoggerFromContext(ctx, fallback).Log(ctx, errorLevel(resp, err), text, "error", err)
+	return errutil.NewAPIErrorResultWithText(text, resp, err)
+}
+
+// errorLevel is the level a failed call is logged at: error for a 5xx status,
+// which is the API's fault, and warn otherwise.
+func errorLevel(resp *godo.Response, err error) slog.Level {
+	if apiErr, ok := errutil.NewAPIError("", resp, err); ok && apiErr.StatusCode >= 500 {
+		return slog.LevelError
+	}
+	return slog.LevelWarn
+}
@@ -0,0 +1,60 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoggerFromContext(t *testing.T) {
+	requestLogger := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+	fallback := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+
+	require.Same(t, requestLogger, LoggerFromContext(ContextWithLogger(context.Background(), requestLogger), fallback))
+	require.Same(t, fallback, LoggerFromContext(context.Background(), fallback))
+	require.Same(t, discardLogger, LoggerFromContext(context.Background(), nil))
+}
+
+func TestToolLogger(t *testing.T) {
+	requestLogger := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+	fallback := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+
+	var tool struct{ ToolLogger }
+	require.Same(t, discardLogger, tool.Logger(context.Background()))
+
+	require.Same(t, &tool, WithLogger(&tool, fallback))
+	require.Same(t, fallback, tool.Logger(context.Background()))
+	require.Same(t, requestLogger, tool.Logger(ContextWithLogger(context.Background(), requestLogger)))
+}
+
+func TestErrorResult(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	err := errors.New("boom")
+
+	result := ErrorResult(ContextWithLogger(context.Background(), logger), nil, "api error", err)
+	require.Equal(t, mcp.NewToolResultErrorFromErr("api error", err), result)
+	require.Contains(t, logs.String(), `level=WARN msg="api error" error=boom`)
+}
+
+func TestAPIErrorResult(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	err := apiError(http.StatusUnprocessableEntity)
+
+	result := APIErrorResult(context.Background(), logger, "failed to create node pool", nil, err)
+	require.True(t, result.IsError)
+	require.Contains(t, result.Content[0].(mcp.TextContent).Text, `"error": "failed to create node pool"`)
+	require.Contains(t, result.Content[0].(mcp.TextContent).Text, `"status_code": 422`)
+	require.Contains(t, logs.String(), `level=WARN msg="failed to create node pool"`)
+
+	logs.Reset()
+	APIErrorResult(context.Background(), logger, "failed to create node pool", nil, apiError(http.StatusServiceUnavailable))
+	require.Contains(t, logs.String(), `level=ERROR msg="failed to create node pool"`)
+}
@@ -0,0 +1,41 @@
+package common
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ValidHostname reports whether name is an RFC 1123 hostname: dot-separated
+// labels of 1-63 letters, digits and hyphens that do not start or end with a
+// hyphen, 253 characters at most in total. Droplet names must be hostnames.
+func ValidHostname(name string) bool {
+	if name == "" || len(name) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(name, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// ValidSnapshotName reports whether name is 1-255 printable characters, as
+// the API requires of snapshot and image names.
+func ValidSnapshotName(name string) bool {
+	if name == "" || utf8.RuneCountInString(name) > 255 {
+		return false
+	}
+	for _, c := range name {
+		if !unicode.IsPrint(c) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,37 @@
+package common
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidHostname(t *testing.T) {
+	for _, name := range []string{"web", "web-01", "web.example.com", "A1", strings.Repeat("a", 63)} {
+		require.True(t, ValidHostname(name), name)
+	}
+	for _, name := range []string{
+		"",
+		"my droplet",
+		"-web",
+		"web-",
+		"web..example",
+		".web",
+		"web_01",
+		"wéb",
+		strings.Repeat("a", 64),
+		strings.Repeat("a.", 127) + "a",
+	} {
+		require.False(t, ValidHostname(name), name)
+	}
+}
+
+func TestValidSnapshotName(t *testing.T) {
+	for _, name := range []string{"nightly", "before upgrade 2026-01-02", "snapshot ✓", strings.Repeat("a", 255)} {
+		require.True(t, ValidSnapshotName(name), name)
+	}
+	for _, name := range []string{"", "line\nbreak", "tab\there", strings.Repeat("a", 256)} {
+		require.False(t, ValidSnapshotName(name), name)
+	}
+}
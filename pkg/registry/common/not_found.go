@@ -0,0 +1,59 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"mcp-digitalocean/internal/errutil"
+)
+
+// ErrorCodeNotFound is the code of the error result a get tool returns when
+// the resource it was asked for does not exist.
+const ErrorCodeNotFound = "not_found"
+
+// NotFound is the body of a not_found error result. ID is the identifier the
+// caller asked for, so a model can tell which of several lookups failed.
+type NotFound struct {
+	Code      string `json:"code"`
+	Resource  string `json:"resource"`
+	ID        string `json:"id"`
+	RequestID string `json:"request_id,omitempty"`
+	Message   string `json:"message"`
+}
+
+// IsNotFound reports whether err is, or wraps, a 404 from the API.
+func IsNotFound(err error) bool {
+	var apiErr *godo.ErrorResponse
+	return errors.As(err, &apiErr) && apiErr.Response != nil && apiErr.Response.StatusCode == http.StatusNotFound
+}
+
+// GetErrorResult maps an error from getting one resource to an error result.
+// A 404 becomes a not_found result naming resource and the requested id; any
+// other error is returned as APIErrorResult(ctx, fallback, text, nil, err).
+// Get tools use it instead of returning the error, which would surface as a
+// protocol error rather than a tool result.
+func GetErrorResult(ctx context.Context, fallback *slog.Logger, text, resource, id string, err error) *mcp.CallToolResult {
+	if !IsNotFound(err) {
+		return APIErrorResult(ctx, fallback, text, nil, err)
+	}
+	LoggerFromContext(ctx, fallback).DebugContext(ctx, "resource not found", "resource", resource, "id", id, "error", err)
+	// the message keeps the API error, whose status the logging middleware
+	// classifies the result by.
+	apiErr, _ := errutil.NewAPIError(text, nil, err)
+	data, marshalErr := json.MarshalIndent(NotFound{
+		Code:      ErrorCodeNotFound,
+		Resource:  resource,
+		ID:        id,
+		RequestID: apiErr.RequestID,
+		Message:   resource + " " + id + " not found: " + err.Error(),
+	}, "", "  ")
+	if marshalErr != nil {
+		return ErrorResult(ctx, fallback, text, err)
+	}
+	return mcp.NewToolResultError(string(data))
+}
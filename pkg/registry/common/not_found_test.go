@@ -0,0 +1,49 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+func apiError(status int) error {
+	u, _ := url.Parse("https://api.digitalocean.com/v2/droplets/123")
+	return &godo.ErrorResponse{
+		Response: &http.Response{StatusCode: status, Request: &http.Request{Method: http.MethodGet, URL: u}},
+		Message:  "The resource you were accessing could not be found.",
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	require.True(t, IsNotFound(apiError(http.StatusNotFound)))
+	require.True(t, IsNotFound(fmt.Errorf("failed to get droplet: %w", apiError(http.StatusNotFound))))
+	require.False(t, IsNotFound(apiError(http.StatusForbidden)))
+	require.False(t, IsNotFound(&godo.ErrorResponse{}))
+	require.False(t, IsNotFound(errors.New("404")))
+}
+
+func TestGetErrorResult(t *testing.T) {
+	result := GetErrorResult(context.Background(), nil, "api error", "droplet", "123", apiError(http.StatusNotFound))
+	require.True(t, result.IsError)
+	var body NotFound
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &body))
+	require.Equal(t, ErrorCodeNotFound, body.Code)
+	require.Equal(t, "droplet", body.Resource)
+	require.Equal(t, "123", body.ID)
+	require.Contains(t, body.Message, "GET https://api.digitalocean.com/v2/droplets/123: 404")
+
+	result = GetErrorResult(context.Background(), nil, "api error", "droplet", "123", apiError(http.StatusInternalServerError))
+	require.True(t, result.IsError)
+	require.Contains(t, result.Content[0].(mcp.TextContent).Text, `"status_code": 500`)
+
+	err := errors.New("connection refused")
+	require.Equal(t, mcp.NewToolResultErrorFromErr("api error", err), GetErrorResult(context.Background(), nil, "api error", "droplet", "123", err))
+}
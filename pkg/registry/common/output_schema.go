@@ -0,0 +1,52 @@
+package common
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// outputTypeSchemas overrides types whose JSON form differs from their Go
+// structure. godo.Timestamp embeds time.Time and marshals as a string.
+var outputTypeSchemas = map[reflect.Type]*jsonschema.Schema{
+	reflect.TypeFor[godo.Timestamp](): {Types: []string{"null", "string"}},
+}
+
+// WithOutputSchema declares T, usually a godo type, as the tool's output
+// schema. The tool should return its structured content with
+// NewStructuredResourceResult. Types that cannot be described leave the tool
+// without an output schema rather than failing registration.
+func WithOutputSchema[T any]() mcp.ToolOption {
+	return func(t *mcp.Tool) {
+		schema, err := jsonschema.For[T](&jsonschema.ForOptions{IgnoreInvalidTypes: true, TypeSchemas: outputTypeSchemas})
+		if err != nil {
+			return
+		}
+		raw, err := json.Marshal(schema)
+		if err != nil {
+			return
+		}
+		t.RawOutputSchema = raw
+	}
+}
+
+// HasOutputSchema reports whether t declares an output schema.
+func HasOutputSchema(t mcp.Tool) bool {
+	return t.RawOutputSchema != nil || t.OutputSchema.Type != ""
+}
+
+// NewStructuredResourceResult is NewResourceResult with v also returned as
+// structured content, for tools declared with WithOutputSchema.
+func NewStructuredResourceResult(v any) (*mcp.CallToolResult, error) {
+	result, err := NewResourceResult(v)
+	if err != nil {
+		return nil, err
+	}
+	if !isNilPointer(v) {
+		result.StructuredContent = v
+	}
+	return result, nil
+}
@@ -0,0 +1,82 @@
+package common
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithOutputSchema(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		tool  mcp.Tool
+		value any
+	}{
+		{
+			name: "droplet",
+			tool: mcp.NewTool("droplet-get", WithOutputSchema[godo.Droplet]()),
+			value: &godo.Droplet{
+				ID:               123,
+				Name:             "web-1",
+				Size:             &godo.Size{Slug: "s-1vcpu-1gb", PriceMonthly: 6},
+				Region:           &godo.Region{Slug: "nyc3"},
+				Networks:         &godo.Networks{V4: []godo.NetworkV4{{IPAddress: "10.0.0.2", Type: "private"}}},
+				NextBackupWindow: &godo.BackupWindow{Start: &godo.Timestamp{Time: now}, End: &godo.Timestamp{Time: now.Add(time.Hour)}},
+				Tags:             []string{"web"},
+			},
+		},
+		{
+			name:  "load balancer",
+			tool:  mcp.NewTool("lb-get", WithOutputSchema[godo.LoadBalancer]()),
+			value: &godo.LoadBalancer{ID: "lb-1", Name: "lb", Region: &godo.Region{Slug: "nyc3"}, DropletIDs: []int{1, 2}},
+		},
+		{
+			name:  "kubernetes cluster",
+			tool:  mcp.NewTool("doks-get-cluster", WithOutputSchema[godo.KubernetesCluster]()),
+			value: &godo.KubernetesCluster{ID: "k8s-1", CreatedAt: now, NodePools: []*godo.KubernetesNodePool{{Name: "pool", Count: 3}}},
+		},
+		{
+			name:  "database",
+			tool:  mcp.NewTool("db-cluster-get", WithOutputSchema[godo.Database]()),
+			value: &godo.Database{ID: "db-1", EngineSlug: "pg", CreatedAt: now, Connection: &godo.DatabaseConnection{Host: "db", Port: 25060}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.True(t, HasOutputSchema(tc.tool))
+
+			var schema jsonschema.Schema
+			require.NoError(t, json.Unmarshal(tc.tool.RawOutputSchema, &schema))
+			resolved, err := schema.Resolve(nil)
+			require.NoError(t, err)
+
+			result, err := NewStructuredResourceResult(tc.value)
+			require.NoError(t, err)
+			require.Same(t, tc.value, result.StructuredContent)
+
+			// the structured content is valid against the declared schema and
+			// carries the same document as the text content.
+			structured, err := json.Marshal(result.StructuredContent)
+			require.NoError(t, err)
+			var instance any
+			require.NoError(t, json.Unmarshal(structured, &instance))
+			require.NoError(t, resolved.Validate(instance))
+			require.JSONEq(t, result.Content[0].(mcp.TextContent).Text, string(structured))
+		})
+	}
+
+	require.False(t, HasOutputSchema(mcp.NewTool("droplet-list")))
+}
+
+func TestNewStructuredResourceResult_Nil(t *testing.T) {
+	result, err := NewStructuredResourceResult((*godo.Droplet)(nil))
+	require.NoError(t, err)
+	require.Nil(t, result.StructuredContent)
+}
@@ -0,0 +1,198 @@
+package common
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// MaxPerPage is the largest page size the DigitalOcean API honours. Larger
+// values are silently clamped by the API.
+const MaxPerPage = 200
+
+// PageMeta describes the page a list tool actually requested.
+type PageMeta struct {
+	Page    int `json:"page"`
+	PerPage int `json:"per_page"`
+	// RequestedPerPage is the PerPage the caller asked for, set only when it
+	// was clamped.
+	RequestedPerPage int    `json:"requested_per_page,omitempty"`
+	Note             string `json:"note,omitempty"`
+	// NextCursor continues the list at the next page, set only by tools that
+	// take a Cursor and only when there are more pages.
+	NextCursor string `json:"next_cursor,omitempty"`
+
+	// filters is the filtersHash of the list, for the next cursor.
+	filters string
+}
+
+// ClampPerPage bounds perPage to [1, MaxPerPage] and reports whether it was
+// changed.
+func ClampPerPage(perPage int) (int, bool) {
+	switch {
+	case perPage < 1:
+		return 1, true
+	case perPage > MaxPerPage:
+		return MaxPerPage, true
+	default:
+		return perPage, false
+	}
+}
+
+// ListOptionsFromArgs reads the Page and PerPage tool arguments, falling back
+// to page 1 and defaultPerPage, and clamps PerPage to what the API honours.
+func ListOptionsFromArgs(args map[string]any, defaultPerPage int) (*godo.ListOptions, PageMeta) {
+	page := 1
+	if p, ok := args["Page"].(float64); ok && p >= 1 {
+		page = int(p)
+	}
+	requested := defaultPerPage
+	if pp, ok := args["PerPage"].(float64); ok {
+		requested = int(pp)
+	}
+
+	perPage, clamped := ClampPerPage(requested)
+	meta := PageMeta{Page: page, PerPage: perPage}
+	if clamped {
+		meta.RequestedPerPage = requested
+		meta.Note = fmt.Sprintf("PerPage %d is outside the API's range of 1-%d and was clamped to %d", requested, MaxPerPage, perPage)
+	}
+	return &godo.ListOptions{Page: page, PerPage: perPage}, meta
+}
+
+// CursorArg is the argument list tools take to continue from a NextCursor.
+const CursorArg = "Cursor"
+
+// pageCursor is the content of an opaque Cursor.
+type pageCursor struct {
+	Page    int    `json:"p"`
+	PerPage int    `json:"pp"`
+	Filters string `json:"f"`
+}
+
+// filtersHash identifies a list by the tool that lists it and the values of its
+// filter arguments, so a cursor cannot be replayed against a different list.
+func filtersHash(scope string, args map[string]any, filterKeys []string) string {
+	filters := map[string]any{"": scope}
+	for _, k := range filterKeys {
+		filters[k] = args[k]
+	}
+	// json.Marshal sorts map keys, so the hash does not depend on their order.
+	data, _ := json.Marshal(filters)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+func encodeCursor(c pageCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(s string) (pageCursor, error) {
+	var c pageCursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, err
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return c, err
+	}
+	if c.Page < 1 || c.PerPage < 1 || c.PerPage > MaxPerPage {
+		return c, errors.New("page out of range")
+	}
+	return c, nil
+}
+
+// ListOptionsFromCursor is like ListOptionsFromArgs but, when the Cursor
+// argument is set, continues from it instead of reading Page and PerPage.
+// scope names the list, usually the tool, and filterKeys are the arguments
+// that select what is listed; a cursor issued for a different scope or
+// different filter values is rejected. Pass the returned meta to
+// WithNextCursor once the page has been fetched.
+func ListOptionsFromCursor(args map[string]any, defaultPerPage int, scope string, filterKeys ...string) (*godo.ListOptions, PageMeta, error) {
+	filters := filtersHash(scope, args, filterKeys)
+	cursor, _ := args[CursorArg].(string)
+	if cursor == "" {
+		opt, meta := ListOptionsFromArgs(args, defaultPerPage)
+		meta.filters = filters
+		return opt, meta, nil
+	}
+
+	c, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, PageMeta{}, errors.New("Cursor is not valid; pass the next_cursor of a previous result unchanged")
+	}
+	if c.Filters != filters {
+		return nil, PageMeta{}, errors.New("Cursor was issued for a different list or different filters; drop it to start again from the first page")
+	}
+	return &godo.ListOptions{Page: c.Page, PerPage: c.PerPage}, PageMeta{Page: c.Page, PerPage: c.PerPage, filters: filters}, nil
+}
+
+// WithNextCursor sets meta.NextCursor to the page after meta when resp says
+// there is one.
+func WithNextCursor(meta PageMeta, resp *godo.Response) PageMeta {
+	if !LastPage(resp) {
+		meta.NextCursor = encodeCursor(pageCursor{Page: meta.Page + 1, PerPage: meta.PerPage, Filters: meta.filters})
+	}
+	return meta
+}
+
+// WithPageMeta appends meta to result as a second text content item,
+// {"meta": {...}}, when PerPage was clamped or there is a next cursor. Other
+// results are returned unchanged.
+func WithPageMeta(result *mcp.CallToolResult, meta PageMeta) (*mcp.CallToolResult, error) {
+	if meta.Note == "" && meta.NextCursor == "" {
+		return result, nil
+	}
+	data, err := json.Marshal(map[string]PageMeta{"meta": meta})
+	if err != nil {
+		return nil, err
+	}
+	result.Content = append(result.Content, mcp.NewTextContent(string(data)))
+	return result, nil
+}
+
+// LastPage reports whether resp is the final page of a list call.
+func LastPage(resp *godo.Response) bool {
+	return resp == nil || resp.Links == nil || resp.Links.IsLastPage()
+}
+
+// FetchAll calls list for every page, starting at page 1 with perPage clamped
+// to MaxPerPage, and returns all items. It follows the response links rather
+// than comparing page lengths to perPage, since the API may return fewer items
+// per page than were asked for.
+func FetchAll[T any](ctx context.Context, perPage int, list func(ctx context.Context, opt *godo.ListOptions) ([]T, *godo.Response, error)) ([]T, error) {
+	all, _, err := FetchPages(ctx, perPage, 0, list)
+	return all, err
+}
+
+// FetchPages is like FetchAll but stops after maxPages pages, for lists such as
+// the account's action history that can grow without bound. truncated reports
+// whether more pages were left. maxPages of 0 means no limit.
+func FetchPages[T any](ctx context.Context, perPage, maxPages int, list func(ctx context.Context, opt *godo.ListOptions) ([]T, *godo.Response, error)) (all []T, truncated bool, err error) {
+	perPage, _ = ClampPerPage(perPage)
+	opt := &godo.ListOptions{Page: 1, PerPage: perPage}
+
+	for {
+		items, resp, err := list(ctx, opt)
+		if err != nil {
+			return nil, false, err
+		}
+		all = append(all, items...)
+		// an empty page ends the walk even if the links claim otherwise.
+		if len(items) == 0 || LastPage(resp) {
+			return all, false, nil
+		}
+		if maxPages > 0 && opt.Page >= maxPages {
+			return all, true, nil
+		}
+		opt.Page++
+	}
+}
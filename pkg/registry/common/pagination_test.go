@@ -0,0 +1,206 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListOptionsFromArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     map[string]any
+		wantOpt  godo.ListOptions
+		wantMeta PageMeta
+	}{
+		{
+			name:     "defaults",
+			args:     map[string]any{},
+			wantOpt:  godo.ListOptions{Page: 1, PerPage: 50},
+			wantMeta: PageMeta{Page: 1, PerPage: 50},
+		},
+		{
+			name:     "within range",
+			args:     map[string]any{"Page": float64(3), "PerPage": float64(200)},
+			wantOpt:  godo.ListOptions{Page: 3, PerPage: 200},
+			wantMeta: PageMeta{Page: 3, PerPage: 200},
+		},
+		{
+			name:    "above the API cap",
+			args:    map[string]any{"PerPage": float64(1000)},
+			wantOpt: godo.ListOptions{Page: 1, PerPage: 200},
+			wantMeta: PageMeta{Page: 1, PerPage: 200, RequestedPerPage: 1000,
+				Note: "PerPage 1000 is outside the API's range of 1-200 and was clamped to 200"},
+		},
+		{
+			name:    "below one",
+			args:    map[string]any{"Page": float64(0), "PerPage": float64(-5)},
+			wantOpt: godo.ListOptions{Page: 1, PerPage: 1},
+			wantMeta: PageMeta{Page: 1, PerPage: 1, RequestedPerPage: -5,
+				Note: "PerPage -5 is outside the API's range of 1-200 and was clamped to 1"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			opt, meta := ListOptionsFromArgs(tc.args, 50)
+			require.Equal(t, tc.wantOpt, *opt)
+			require.Equal(t, tc.wantMeta, meta)
+		})
+	}
+}
+
+func TestWithPageMeta(t *testing.T) {
+	result, err := WithPageMeta(mcp.NewToolResultText("[]"), PageMeta{Page: 1, PerPage: 50})
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+
+	_, meta := ListOptionsFromArgs(map[string]any{"PerPage": float64(500)}, 50)
+	result, err = WithPageMeta(mcp.NewToolResultText("[]"), meta)
+	require.NoError(t, err)
+	require.Len(t, result.Content, 2)
+	require.JSONEq(t, `{"meta":{"page":1,"per_page":200,"requested_per_page":500,"note":"PerPage 500 is outside the API's range of 1-200 and was clamped to 200"}}`,
+		result.Content[1].(mcp.TextContent).Text)
+}
+
+func TestFetchAll(t *testing.T) {
+	more := &godo.Response{Links: &godo.Links{Pages: &godo.Pages{Next: "https://api/v2/things?page=next", Last: "https://api/v2/things?page=3"}}}
+
+	t.Run("follows links when pages are shorter than requested", func(t *testing.T) {
+		var requested []godo.ListOptions
+		items, err := FetchAll(context.Background(), 1000, func(ctx context.Context, opt *godo.ListOptions) ([]int, *godo.Response, error) {
+			requested = append(requested, *opt)
+			switch opt.Page {
+			case 1:
+				return []int{1, 2}, more, nil
+			case 2:
+				return []int{3}, more, nil
+			default:
+				return []int{4}, &godo.Response{Links: &godo.Links{}}, nil
+			}
+		})
+		require.NoError(t, err)
+		require.Equal(t, []int{1, 2, 3, 4}, items)
+		require.Equal(t, []godo.ListOptions{{Page: 1, PerPage: 200}, {Page: 2, PerPage: 200}, {Page: 3, PerPage: 200}}, requested)
+	})
+
+	t.Run("stops on an empty page", func(t *testing.T) {
+		calls := 0
+		items, err := FetchAll(context.Background(), 50, func(ctx context.Context, opt *godo.ListOptions) ([]int, *godo.Response, error) {
+			calls++
+			return nil, more, nil
+		})
+		require.NoError(t, err)
+		require.Empty(t, items)
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("returns errors", func(t *testing.T) {
+		_, err := FetchAll(context.Background(), 50, func(ctx context.Context, opt *godo.ListOptions) ([]int, *godo.Response, error) {
+			return nil, nil, errors.New("boom")
+		})
+		require.EqualError(t, err, "boom")
+	})
+}
+
+func TestFetchPages(t *testing.T) {
+	more := &godo.Response{Links: &godo.Links{Pages: &godo.Pages{Next: "https://api/v2/things?page=next", Last: "https://api/v2/things?page=9"}}}
+	list := func(ctx context.Context, opt *godo.ListOptions) ([]int, *godo.Response, error) {
+		if opt.Page == 3 {
+			return []int{opt.Page}, &godo.Response{Links: &godo.Links{}}, nil
+		}
+		return []int{opt.Page}, more, nil
+	}
+
+	items, truncated, err := FetchPages(context.Background(), 50, 2, list)
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2}, items)
+	require.True(t, truncated)
+
+	items, truncated, err = FetchPages(context.Background(), 50, 3, list)
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3}, items)
+	require.False(t, truncated)
+}
+
+func TestListOptionsFromCursor(t *testing.T) {
+	more := &godo.Response{Links: &godo.Links{Pages: &godo.Pages{Next: "https://api/v2/droplets?page=3", Last: "https://api/v2/droplets?page=9"}}}
+	last := &godo.Response{Links: &godo.Links{}}
+
+	// the first page comes from Page and PerPage.
+	args := map[string]any{"Page": float64(2), "PerPage": float64(100), "Tag": "web"}
+	opt, meta, err := ListOptionsFromCursor(args, 50, "droplet-list", "Tag")
+	require.NoError(t, err)
+	require.Equal(t, &godo.ListOptions{Page: 2, PerPage: 100}, opt)
+	require.Empty(t, WithNextCursor(meta, last).NextCursor)
+
+	meta = WithNextCursor(meta, more)
+	require.NotEmpty(t, meta.NextCursor)
+
+	// the cursor continues at the next page with the same page size, whatever
+	// Page and PerPage say.
+	opt, meta, err = ListOptionsFromCursor(map[string]any{CursorArg: meta.NextCursor, "Page": float64(1), "Tag": "web"}, 50, "droplet-list", "Tag")
+	require.NoError(t, err)
+	require.Equal(t, &godo.ListOptions{Page: 3, PerPage: 100}, opt)
+	next := WithNextCursor(meta, more)
+
+	opt, _, err = ListOptionsFromCursor(map[string]any{CursorArg: next.NextCursor, "Tag": "web"}, 50, "droplet-list", "Tag")
+	require.NoError(t, err)
+	require.Equal(t, &godo.ListOptions{Page: 4, PerPage: 100}, opt)
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		scope       string
+		expectedErr string
+	}{
+		{
+			name:        "different filter value",
+			args:        map[string]any{CursorArg: next.NextCursor, "Tag": "db"},
+			scope:       "droplet-list",
+			expectedErr: "Cursor was issued for a different list or different filters",
+		},
+		{
+			name:        "filter dropped",
+			args:        map[string]any{CursorArg: next.NextCursor},
+			scope:       "droplet-list",
+			expectedErr: "Cursor was issued for a different list or different filters",
+		},
+		{
+			name:        "different tool",
+			args:        map[string]any{CursorArg: next.NextCursor, "Tag": "web"},
+			scope:       "action-list",
+			expectedErr: "Cursor was issued for a different list or different filters",
+		},
+		{
+			name:        "not a cursor",
+			args:        map[string]any{CursorArg: "page-3"},
+			scope:       "droplet-list",
+			expectedErr: "Cursor is not valid",
+		},
+		{
+			name:        "page out of range",
+			args:        map[string]any{CursorArg: encodeCursor(pageCursor{Page: 0, PerPage: 50})},
+			scope:       "droplet-list",
+			expectedErr: "Cursor is not valid",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := ListOptionsFromCursor(tc.args, 50, tc.scope, "Tag")
+			require.ErrorContains(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestWithPageMeta_nextCursor(t *testing.T) {
+	meta := PageMeta{Page: 1, PerPage: 50, NextCursor: "abc"}
+	result, err := WithPageMeta(mcp.NewToolResultText("[]"), meta)
+	require.NoError(t, err)
+	require.Len(t, result.Content, 2)
+	require.JSONEq(t, `{"meta":{"page":1,"per_page":50,"next_cursor":"abc"}}`, result.Content[1].(mcp.TextContent).Text)
+}
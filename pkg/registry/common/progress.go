@@ -0,0 +1,27 @@
+package common
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// NotifyProgress sends a notifications/progress message for req to the
+// calling client. It does nothing when the client did not ask for progress by
+// passing a progress token, or when ctx carries no server session.
+func NotifyProgress(ctx context.Context, req mcp.CallToolRequest, progress float64, message string) {
+	if req.Params.Meta == nil || req.Params.Meta.ProgressToken == nil {
+		return
+	}
+	srv := server.ServerFromContext(ctx)
+	if srv == nil {
+		return
+	}
+	// delivery is best effort; a client that went away just misses the update.
+	_ = srv.SendNotificationToClient(ctx, "notifications/progress", map[string]any{
+		"progressToken": req.Params.Meta.ProgressToken,
+		"progress":      progress,
+		"message":       message,
+	})
+}
@@ -0,0 +1,62 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSession struct {
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (f *fakeSession) Initialize()       {}
+func (f *fakeSession) Initialized() bool { return true }
+func (f *fakeSession) SessionID() string { return "session-1" }
+func (f *fakeSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return f.notifications
+}
+
+func TestNotifyProgress(t *testing.T) {
+	tests := []struct {
+		name      string
+		meta      string
+		wantCount int
+	}{
+		{name: "with progress token", meta: `,"_meta":{"progressToken":"tok-1"}`, wantCount: 1},
+		{name: "without progress token", wantCount: 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := server.NewMCPServer("test", "0.0.0")
+			s.AddTool(mcp.NewTool("slow"), func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				NotifyProgress(ctx, req, 2, "node is draining")
+				return mcp.NewToolResultText("done"), nil
+			})
+
+			session := &fakeSession{notifications: make(chan mcp.JSONRPCNotification, 1)}
+			ctx := s.WithContext(context.Background(), session)
+			s.HandleMessage(ctx, json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"slow"`+tc.meta+`}}`))
+
+			require.Len(t, session.notifications, tc.wantCount)
+			if tc.wantCount == 0 {
+				return
+			}
+			n := <-session.notifications
+			require.Equal(t, "notifications/progress", n.Method)
+			require.Equal(t, "tok-1", n.Params.AdditionalFields["progressToken"])
+			require.Equal(t, float64(2), n.Params.AdditionalFields["progress"])
+			require.Equal(t, "node is draining", n.Params.AdditionalFields["message"])
+		})
+	}
+}
+
+func TestNotifyProgressWithoutServer(t *testing.T) {
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Meta: &mcp.Meta{ProgressToken: "tok-1"}}}
+	require.NotPanics(t, func() { NotifyProgress(context.Background(), req, 1, "ignored") })
+}
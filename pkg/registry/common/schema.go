@@ -0,0 +1,47 @@
+package common
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ValidateRawSchema checks that schema, an input schema for
+// mcp.NewToolWithRawSchema, is a JSON object with "type": "object" and a
+// "properties" object. A schema that fails these checks would otherwise only
+// surface when a client lists the tools.
+func ValidateRawSchema(schema []byte) error {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(schema, &doc); err != nil {
+		return fmt.Errorf("not a JSON object: %w", err)
+	}
+	if doc == nil {
+		return errors.New("not a JSON object: null")
+	}
+	rawType, ok := doc["type"]
+	if !ok {
+		return errors.New(`missing top-level key "type"`)
+	}
+	var schemaType string
+	if err := json.Unmarshal(rawType, &schemaType); err != nil || schemaType != "object" {
+		return fmt.Errorf(`top-level "type" is %s, want "object"`, rawType)
+	}
+	rawProperties, ok := doc["properties"]
+	if !ok {
+		return errors.New(`missing top-level key "properties"`)
+	}
+	var properties map[string]json.RawMessage
+	if err := json.Unmarshal(rawProperties, &properties); err != nil || properties == nil {
+		return errors.New(`top-level "properties" is not an object`)
+	}
+	return nil
+}
+
+// SchemaDigest returns the hex SHA-256 of schema, for tracking schema changes
+// between releases.
+func SchemaDigest(schema []byte) string {
+	sum := sha256.Sum256(schema)
+	return hex.EncodeToString(sum[:])
+}
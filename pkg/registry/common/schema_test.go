@@ -0,0 +1,41 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRawSchema(t *testing.T) {
+	tests := []struct {
+		name        string
+		schema      string
+		expectedErr string
+	}{
+		{name: "valid", schema: `{"type": "object", "properties": {"Name": {"type": "string"}}}`},
+		{name: "empty properties", schema: `{"type": "object", "properties": {}}`},
+		{name: "truncated", schema: `{"type": "object", "properties": {`, expectedErr: "not a JSON object"},
+		{name: "array", schema: `[]`, expectedErr: "not a JSON object"},
+		{name: "null", schema: `null`, expectedErr: "not a JSON object"},
+		{name: "missing type", schema: `{"properties": {}}`, expectedErr: `missing top-level key "type"`},
+		{name: "wrong type", schema: `{"type": "array", "properties": {}}`, expectedErr: `top-level "type" is "array", want "object"`},
+		{name: "missing properties", schema: `{"type": "object"}`, expectedErr: `missing top-level key "properties"`},
+		{name: "properties not an object", schema: `{"type": "object", "properties": []}`, expectedErr: `top-level "properties" is not an object`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateRawSchema([]byte(tc.schema))
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestSchemaDigest(t *testing.T) {
+	require.Equal(t, "44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a", SchemaDigest([]byte("{}")))
+	require.NotEqual(t, SchemaDigest([]byte(`{"a":1}`)), SchemaDigest([]byte(`{"a":2}`)))
+}
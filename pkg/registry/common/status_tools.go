@@ -0,0 +1,203 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	statusSummaryURL   = "https://status.digitalocean.com/api/v2/summary.json"
+	statusFetchTimeout = 3 * time.Second
+	// StatusUnknown is reported when the status page cannot be read.
+	StatusUnknown = "status unknown"
+
+	maxStatusResponseSize = 2 * 1024 * 1024
+)
+
+// StatusTools reports incidents from the public DigitalOcean status page.
+type StatusTools struct {
+	httpClient *http.Client
+	url        string
+}
+
+// NewStatusTools creates a StatusTools instance. The status page is public, so
+// no DigitalOcean client is needed.
+func NewStatusTools() *StatusTools {
+	return &StatusTools{
+		httpClient: &http.Client{Timeout: statusFetchTimeout},
+		url:        statusSummaryURL,
+	}
+}
+
+// statusPageComponent is a component as listed by the status page API.
+type statusPageComponent struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	GroupID string `json:"group_id"`
+}
+
+// statusPageSummary is the subset of the status page summary.json we read.
+type statusPageSummary struct {
+	Status struct {
+		Description string `json:"description"`
+	} `json:"status"`
+	Components []statusPageComponent `json:"components"`
+	Incidents  []struct {
+		Name            string                `json:"name"`
+		Status          string                `json:"status"`
+		Impact          string                `json:"impact"`
+		Shortlink       string                `json:"shortlink"`
+		StartedAt       time.Time             `json:"started_at"`
+		Components      []statusPageComponent `json:"components"`
+		IncidentUpdates []struct {
+			Body string `json:"body"`
+		} `json:"incident_updates"`
+	} `json:"incidents"`
+}
+
+// StatusComponent is a component that is not fully operational.
+type StatusComponent struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// StatusIncident is an unresolved incident.
+type StatusIncident struct {
+	Name         string    `json:"name"`
+	Status       string    `json:"status"`
+	Impact       string    `json:"impact"`
+	StartedAt    time.Time `json:"started_at"`
+	LatestUpdate string    `json:"latest_update,omitempty"`
+	Components   []string  `json:"components,omitempty"`
+	URL          string    `json:"url,omitempty"`
+}
+
+// DOStatus is the compact status summary returned by do-status.
+type DOStatus struct {
+	Status             string            `json:"status"`
+	Region             string            `json:"region,omitempty"`
+	DegradedComponents []StatusComponent `json:"degraded_components"`
+	Incidents          []StatusIncident  `json:"incidents"`
+	Note               string            `json:"note,omitempty"`
+}
+
+// getStatus summarizes the status page, limited to Region when it is given.
+// A status page that cannot be read is reported as StatusUnknown rather than
+// as a tool error, since the caller is usually already handling a failure.
+func (s *StatusTools) getStatus(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	region, _ := req.GetArguments()["Region"].(string)
+	region = strings.ToLower(strings.TrimSpace(region))
+
+	status := DOStatus{Region: region, DegradedComponents: []StatusComponent{}, Incidents: []StatusIncident{}}
+	summary, err := s.fetchSummary(ctx)
+	if err != nil {
+		status.Status = StatusUnknown
+		status.Note = fmt.Sprintf("could not read the DigitalOcean status page: %v", err)
+	} else {
+		summarizeStatus(&status, summary, region)
+	}
+
+	jsonData, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+func (s *StatusTools) fetchSummary(ctx context.Context) (*statusPageSummary, error) {
+	ctx, cancel := context.WithTimeout(ctx, statusFetchTimeout)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	var summary statusPageSummary
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxStatusResponseSize)).Decode(&summary); err != nil {
+		return nil, fmt.Errorf("invalid status JSON: %w", err)
+	}
+	return &summary, nil
+}
+
+// summarizeStatus fills status with the degraded components and incidents of
+// summary. With a region, only components whose name or group name contains
+// it are kept, and only incidents touching such a component or listing no
+// components at all.
+func summarizeStatus(status *DOStatus, summary *statusPageSummary, region string) {
+	status.Status = summary.Status.Description
+
+	groups := make(map[string]string, len(summary.Components))
+	for _, c := range summary.Components {
+		groups[c.ID] = c.Name
+	}
+	matches := func(c statusPageComponent) bool {
+		if region == "" {
+			return true
+		}
+		return strings.Contains(strings.ToLower(c.Name), region) || strings.Contains(strings.ToLower(groups[c.GroupID]), region)
+	}
+
+	for _, c := range summary.Components {
+		if c.Status != "operational" && matches(c) {
+			status.DegradedComponents = append(status.DegradedComponents, StatusComponent{Name: c.Name, Status: c.Status})
+		}
+	}
+
+	for _, i := range summary.Incidents {
+		relevant := len(i.Components) == 0
+		names := make([]string, 0, len(i.Components))
+		for _, c := range i.Components {
+			names = append(names, c.Name)
+			relevant = relevant || matches(c)
+		}
+		if !relevant {
+			continue
+		}
+		incident := StatusIncident{
+			Name:       i.Name,
+			Status:     i.Status,
+			Impact:     i.Impact,
+			StartedAt:  i.StartedAt,
+			Components: names,
+			URL:        i.Shortlink,
+		}
+		if len(i.IncidentUpdates) > 0 {
+			incident.LatestUpdate = i.IncidentUpdates[0].Body
+		}
+		status.Incidents = append(status.Incidents, incident)
+	}
+}
+
+// Tools returns the status tools.
+func (s *StatusTools) Tools() []server.ServerTool {
+	return []server.ServerTool{
+		{
+			Handler: s.getStatus,
+			Tool: mcp.NewTool("do-status",
+				WithHints(HintsRead),
+				mcp.WithDescription("Check status.digitalocean.com for ongoing incidents and degraded components. Use it when API calls fail unexpectedly to tell a DigitalOcean outage from a problem with the request. Returns \"status unknown\" if the status page cannot be reached."),
+				mcp.WithString("Region", mcp.Description("Region slug to limit the summary to, e.g. nyc3")),
+			),
+		},
+	}
+}
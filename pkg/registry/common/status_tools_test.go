@@ -0,0 +1,161 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+const statusSummaryFixture = `{
+  "status": {"indicator": "minor", "description": "Partially Degraded Service"},
+  "components": [
+    {"id": "g-nyc3", "name": "NYC3", "status": "degraded_performance", "group_id": null},
+    {"id": "c-1", "name": "Droplets", "status": "degraded_performance", "group_id": "g-nyc3"},
+    {"id": "c-2", "name": "Spaces", "status": "operational", "group_id": "g-nyc3"},
+    {"id": "g-ams3", "name": "AMS3", "status": "operational", "group_id": null},
+    {"id": "c-3", "name": "Droplets", "status": "operational", "group_id": "g-ams3"},
+    {"id": "c-4", "name": "API", "status": "partial_outage", "group_id": null}
+  ],
+  "incidents": [
+    {
+      "name": "Droplet creation delays in NYC3",
+      "status": "investigating",
+      "impact": "minor",
+      "shortlink": "https://stspg.io/abc",
+      "started_at": "2026-05-01T10:00:00Z",
+      "components": [{"id": "c-1", "name": "Droplets", "status": "degraded_performance", "group_id": "g-nyc3"}],
+      "incident_updates": [{"body": "We are investigating."}]
+    },
+    {
+      "name": "Elevated API error rates",
+      "status": "identified",
+      "impact": "major",
+      "started_at": "2026-05-01T09:00:00Z",
+      "components": [{"id": "c-4", "name": "API", "status": "partial_outage", "group_id": null}]
+    }
+  ]
+}`
+
+func setupStatusTools(t *testing.T, handler http.HandlerFunc) *StatusTools {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	tools := NewStatusTools()
+	tools.url = srv.URL
+	return tools
+}
+
+func callStatus(t *testing.T, tools *StatusTools, args map[string]any) DOStatus {
+	resp, err := tools.getStatus(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+	require.NoError(t, err)
+	require.False(t, resp.IsError)
+	var status DOStatus
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &status))
+	return status
+}
+
+func TestStatusTools_getStatus(t *testing.T) {
+	tools := setupStatusTools(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Empty(t, r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(statusSummaryFixture))
+	})
+
+	apiIncident := StatusIncident{
+		Name:       "Elevated API error rates",
+		Status:     "identified",
+		Impact:     "major",
+		StartedAt:  time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC),
+		Components: []string{"API"},
+	}
+	nycIncident := StatusIncident{
+		Name:         "Droplet creation delays in NYC3",
+		Status:       "investigating",
+		Impact:       "minor",
+		StartedAt:    time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC),
+		LatestUpdate: "We are investigating.",
+		Components:   []string{"Droplets"},
+		URL:          "https://stspg.io/abc",
+	}
+
+	tests := []struct {
+		name string
+		args map[string]any
+		want DOStatus
+	}{
+		{
+			name: "all regions",
+			args: map[string]any{},
+			want: DOStatus{
+				Status: "Partially Degraded Service",
+				DegradedComponents: []StatusComponent{
+					{Name: "NYC3", Status: "degraded_performance"},
+					{Name: "Droplets", Status: "degraded_performance"},
+					{Name: "API", Status: "partial_outage"},
+				},
+				Incidents: []StatusIncident{nycIncident, apiIncident},
+			},
+		},
+		{
+			name: "affected region",
+			args: map[string]any{"Region": "NYC3"},
+			want: DOStatus{
+				Status: "Partially Degraded Service",
+				Region: "nyc3",
+				DegradedComponents: []StatusComponent{
+					{Name: "NYC3", Status: "degraded_performance"},
+					{Name: "Droplets", Status: "degraded_performance"},
+				},
+				Incidents: []StatusIncident{nycIncident},
+			},
+		},
+		{
+			name: "unaffected region",
+			args: map[string]any{"Region": "ams3"},
+			want: DOStatus{
+				Status:             "Partially Degraded Service",
+				Region:             "ams3",
+				DegradedComponents: []StatusComponent{},
+				Incidents:          []StatusIncident{},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, callStatus(t, tools, tc.args))
+		})
+	}
+}
+
+func TestStatusTools_getStatusUnknown(t *testing.T) {
+	tests := []struct {
+		name     string
+		handler  http.HandlerFunc
+		wantNote string
+	}{
+		{
+			name:     "server error",
+			handler:  func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusServiceUnavailable) },
+			wantNote: "HTTP 503",
+		},
+		{
+			name:     "invalid json",
+			handler:  func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write([]byte("<html>")) },
+			wantNote: "invalid status JSON",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			status := callStatus(t, setupStatusTools(t, tc.handler), map[string]any{"Region": "nyc3"})
+			require.Equal(t, StatusUnknown, status.Status)
+			require.Contains(t, status.Note, tc.wantNote)
+			require.Empty(t, status.Incidents)
+		})
+	}
+}
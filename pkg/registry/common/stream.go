@@ -0,0 +1,67 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// StreamArg is the argument list tools take to return every item as JSON
+// Lines chunks instead of one page as a JSON array.
+const StreamArg = "Stream"
+
+// StreamChunkSize is the most records in one JSON Lines content item.
+const StreamChunkSize = 100
+
+// StreamMeta is the last content item of a streamed list, {"meta": {...}}.
+type StreamMeta struct {
+	Records   int `json:"records"`
+	Chunks    int `json:"chunks"`
+	ChunkSize int `json:"chunk_size"`
+	// Truncated reports that the list was cut short, see Note.
+	Truncated bool   `json:"truncated,omitempty"`
+	Note      string `json:"note,omitempty"`
+}
+
+// WithStreamArg adds the Stream argument to a list tool.
+func WithStreamArg() mcp.ToolOption {
+	return mcp.WithBoolean(StreamArg, mcp.Description(fmt.Sprintf("Return every item instead of one page, as JSON Lines content items of at most %d records each followed by a meta item with the counts. Page, PerPage and Cursor are ignored", StreamChunkSize)))
+}
+
+// IsStream reports whether the call asked for a streamed list.
+func IsStream(args map[string]any) bool {
+	stream, _ := args[StreamArg].(bool)
+	return stream
+}
+
+// NewStreamResult returns items as JSON Lines, one record per line and at
+// most StreamChunkSize records per text content item, followed by a
+// {"meta": StreamMeta} item. Pass a truncated meta for lists that were cut
+// short; its counts are filled in.
+func NewStreamResult[T any](items []T, meta StreamMeta) (*mcp.CallToolResult, error) {
+	result := &mcp.CallToolResult{}
+	for start := 0; start < len(items); start += StreamChunkSize {
+		var chunk bytes.Buffer
+		for _, item := range items[start:min(start+StreamChunkSize, len(items))] {
+			line, err := json.Marshal(item)
+			if err != nil {
+				return nil, fmt.Errorf("marshal error: %w", err)
+			}
+			chunk.Write(line)
+			chunk.WriteByte('\n')
+		}
+		result.Content = append(result.Content, mcp.NewTextContent(chunk.String()))
+	}
+
+	meta.Records = len(items)
+	meta.Chunks = len(result.Content)
+	meta.ChunkSize = StreamChunkSize
+	data, err := json.Marshal(map[string]StreamMeta{"meta": meta})
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	result.Content = append(result.Content, mcp.NewTextContent(string(data)))
+	return result, nil
+}
@@ -0,0 +1,75 @@
+package common
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+type streamRecord struct {
+	ID int `json:"id"`
+}
+
+func TestNewStreamResult(t *testing.T) {
+	tests := []struct {
+		records    int
+		wantChunks []int
+	}{
+		{records: 0, wantChunks: nil},
+		{records: 1, wantChunks: []int{1}},
+		{records: 100, wantChunks: []int{100}},
+		{records: 101, wantChunks: []int{100, 1}},
+		{records: 250, wantChunks: []int{100, 100, 50}},
+	}
+
+	for _, tc := range tests {
+		items := make([]streamRecord, tc.records)
+		for i := range items {
+			items[i] = streamRecord{ID: i}
+		}
+
+		result, err := NewStreamResult(items, StreamMeta{})
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		require.Len(t, result.Content, len(tc.wantChunks)+1)
+
+		// records come back in order, one JSON object per line.
+		next := 0
+		for i, want := range tc.wantChunks {
+			text := result.Content[i].(mcp.TextContent).Text
+			require.True(t, strings.HasSuffix(text, "\n"))
+			lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+			require.Len(t, lines, want)
+			for _, line := range lines {
+				var record streamRecord
+				require.NoError(t, json.Unmarshal([]byte(line), &record))
+				require.Equal(t, next, record.ID)
+				next++
+			}
+		}
+		require.Equal(t, tc.records, next)
+
+		var meta struct {
+			Meta StreamMeta `json:"meta"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(result.Content[len(result.Content)-1].(mcp.TextContent).Text), &meta))
+		require.Equal(t, StreamMeta{Records: tc.records, Chunks: len(tc.wantChunks), ChunkSize: StreamChunkSize}, meta.Meta)
+	}
+}
+
+func TestNewStreamResult_truncated(t *testing.T) {
+	result, err := NewStreamResult([]streamRecord{{ID: 1}}, StreamMeta{Truncated: true, Note: "cut short"})
+	require.NoError(t, err)
+	require.Len(t, result.Content, 2)
+	require.JSONEq(t, `{"meta":{"records":1,"chunks":1,"chunk_size":100,"truncated":true,"note":"cut short"}}`, result.Content[1].(mcp.TextContent).Text)
+}
+
+func TestIsStream(t *testing.T) {
+	require.True(t, IsStream(map[string]any{"Stream": true}))
+	require.False(t, IsStream(map[string]any{"Stream": false}))
+	require.False(t, IsStream(map[string]any{"Stream": "true"}))
+	require.False(t, IsStream(nil))
+}
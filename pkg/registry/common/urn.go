@@ -0,0 +1,77 @@
+package common
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const consoleBaseURL = "https://cloud.digitalocean.com"
+
+// consolePaths maps a URN resource type to its cloud console path prefix.
+var consolePaths = map[string]string{
+	"droplet":      "/droplets/",
+	"loadbalancer": "/networking/load_balancers/",
+	"kubernetes":   "/kubernetes/clusters/",
+	"dbaas":        "/databases/",
+	"app":          "/apps/",
+	"volume":       "/volumes/",
+}
+
+// ResourceRef identifies a resource compactly so agents can carry it from one
+// tool call to the next.
+type ResourceRef struct {
+	URN        string `json:"urn"`
+	ConsoleURL string `json:"console_url,omitempty"`
+}
+
+// NewResourceRef returns the reference for r, using godo's canonical URN
+// (do:droplet:123).
+func NewResourceRef(r godo.ResourceWithURN) ResourceRef {
+	urn := r.URN()
+	return ResourceRef{URN: urn, ConsoleURL: ConsoleURL(urn)}
+}
+
+// ConsoleURL returns the cloud console URL of the resource a URN names, or ""
+// for resource types without a console page.
+func ConsoleURL(urn string) string {
+	parts := strings.SplitN(urn, ":", 3)
+	if len(parts) != 3 || parts[0] != "do" || parts[2] == "" {
+		return ""
+	}
+	path, ok := consolePaths[parts[1]]
+	if !ok {
+		return ""
+	}
+	return consoleBaseURL + path + parts[2]
+}
+
+// NewResourceResult returns v as indented JSON text content. When v is a
+// non-nil resource with a URN, a second text content item holds its
+// ResourceRef as JSON.
+func NewResourceResult(v any) (*mcp.CallToolResult, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	result := mcp.NewToolResultText(string(data))
+
+	r, ok := v.(godo.ResourceWithURN)
+	if !ok || isNilPointer(v) {
+		return result, nil
+	}
+	ref, err := json.Marshal(NewResourceRef(r))
+	if err != nil {
+		return nil, err
+	}
+	result.Content = append(result.Content, mcp.NewTextContent(string(ref)))
+	return result, nil
+}
+
+func isNilPointer(v any) bool {
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Pointer && rv.IsNil()
+}
@@ -0,0 +1,78 @@
+package common
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewResourceResult(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		wantRef string
+	}{
+		{
+			name:    "droplet",
+			value:   &godo.Droplet{ID: 123, Name: "web-1"},
+			wantRef: `{"urn":"do:droplet:123","console_url":"https://cloud.digitalocean.com/droplets/123"}`,
+		},
+		{
+			name:    "load balancer",
+			value:   &godo.LoadBalancer{ID: "lb-1"},
+			wantRef: `{"urn":"do:loadbalancer:lb-1","console_url":"https://cloud.digitalocean.com/networking/load_balancers/lb-1"}`,
+		},
+		{
+			name:    "kubernetes cluster",
+			value:   &godo.KubernetesCluster{ID: "k8s-1"},
+			wantRef: `{"urn":"do:kubernetes:k8s-1","console_url":"https://cloud.digitalocean.com/kubernetes/clusters/k8s-1"}`,
+		},
+		{
+			name:    "database",
+			value:   &godo.Database{ID: "db-1"},
+			wantRef: `{"urn":"do:dbaas:db-1","console_url":"https://cloud.digitalocean.com/databases/db-1"}`,
+		},
+		{
+			name:    "resource without a console page",
+			value:   &godo.Firewall{ID: "fw-1"},
+			wantRef: `{"urn":"do:firewall:fw-1"}`,
+		},
+		{
+			name:  "not a resource",
+			value: map[string]string{"id": "x"},
+		},
+		{
+			name:  "nil resource",
+			value: (*godo.Droplet)(nil),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := NewResourceResult(tc.value)
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			want, err := json.MarshalIndent(tc.value, "", "  ")
+			require.NoError(t, err)
+			require.Equal(t, string(want), result.Content[0].(mcp.TextContent).Text)
+
+			if tc.wantRef == "" {
+				require.Len(t, result.Content, 1)
+				return
+			}
+			require.Len(t, result.Content, 2)
+			require.JSONEq(t, tc.wantRef, result.Content[1].(mcp.TextContent).Text)
+		})
+	}
+}
+
+func TestConsoleURL(t *testing.T) {
+	require.Equal(t, "https://cloud.digitalocean.com/apps/app-1", ConsoleURL("do:app:app-1"))
+	require.Empty(t, ConsoleURL("do:droplet:"))
+	require.Empty(t, ConsoleURL("droplet:123"))
+	require.Empty(t, ConsoleURL("arn:droplet:123"))
+}
@@ -0,0 +1,41 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"mcp-digitalocean/internal/waiter"
+)
+
+const (
+	// DefaultActionPollInterval is how often WaitForAction polls an action.
+	DefaultActionPollInterval = 2 * time.Second
+	// DefaultActionWaitTimeout bounds how long a tool call waits for an action.
+	DefaultActionWaitTimeout = 5 * time.Minute
+)
+
+// WaitForAction polls get until the action reaches completed or errored, the
+// timeout elapses, or ctx is cancelled. An errored action is returned together
+// with an error so callers can report the final action state.
+func WaitForAction(ctx context.Context, get func(ctx context.Context) (*godo.Action, *godo.Response, error), interval, timeout time.Duration) (*godo.Action, error) {
+	action, err := waiter.Poll(ctx, interval, timeout, func(ctx context.Context) (*godo.Action, bool, error) {
+		action, _, err := get(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		switch action.Status {
+		case godo.ActionCompleted:
+			return action, true, nil
+		case "errored":
+			return action, false, fmt.Errorf("action %d (%s) errored", action.ID, action.Type)
+		}
+		return action, false, nil
+	}, waiter.WithJitter(0.1))
+	if errors.Is(err, waiter.ErrTimeout) && action != nil {
+		return action, fmt.Errorf("timed out waiting for action %d (%s) to complete, last status %q: %w", action.ID, action.Type, action.Status, err)
+	}
+	return action, err
+}
@@ -0,0 +1,44 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitForAction(t *testing.T) {
+	t.Run("returns once completed", func(t *testing.T) {
+		calls := 0
+		action, err := WaitForAction(context.Background(), func(ctx context.Context) (*godo.Action, *godo.Response, error) {
+			calls++
+			if calls < 3 {
+				return &godo.Action{ID: 1, Status: godo.ActionInProgress}, nil, nil
+			}
+			return &godo.Action{ID: 1, Status: godo.ActionCompleted}, nil, nil
+		}, time.Millisecond, time.Second)
+		require.NoError(t, err)
+		require.Equal(t, godo.ActionCompleted, action.Status)
+		require.Equal(t, 3, calls)
+	})
+
+	t.Run("returns api errors", func(t *testing.T) {
+		_, err := WaitForAction(context.Background(), func(ctx context.Context) (*godo.Action, *godo.Response, error) {
+			return nil, nil, errors.New("api error")
+		}, time.Millisecond, time.Second)
+		require.EqualError(t, err, "api error")
+	})
+
+	t.Run("stops when the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		action, err := WaitForAction(ctx, func(ctx context.Context) (*godo.Action, *godo.Response, error) {
+			return &godo.Action{ID: 1, Status: godo.ActionInProgress}, nil, nil
+		}, time.Hour, time.Hour)
+		require.ErrorIs(t, err, context.Canceled)
+		require.Equal(t, godo.ActionInProgress, action.Status)
+	})
+}
@@ -0,0 +1,144 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"mcp-digitalocean/pkg/registry/account"
+	"mcp-digitalocean/pkg/registry/common"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// compositeGroup is a set of tools that call the APIs of several services, so
+// no single --services value owns them. They are registered only when every
+// service in dependsOn is enabled.
+type compositeGroup struct {
+	name      string
+	dependsOn []string
+	tools     func(getClient getClientFn) []server.ServerTool
+}
+
+// compositeGroups are the cross-service tool groups.
+var compositeGroups = []compositeGroup{
+	{
+		// resource-export reads droplets, load balancers, firewalls, domain
+		// records and volumes.
+		name:      "resource-export",
+		dependsOn: []string{"droplets", "networking", "volumes"},
+		tools: func(getClient getClientFn) []server.ServerTool {
+			return common.NewExportTools(getClient).Tools()
+		},
+	},
+	{
+		// cost-by-tag lists the tagged droplets, volumes, load balancers and
+		// database clusters.
+		name:      "cost-by-tag",
+		dependsOn: []string{"droplets", "volumes", "networking", "databases"},
+		tools: func(getClient getClientFn) []server.ServerTool {
+			return account.NewCostTool(getClient).Tools()
+		},
+	},
+	{
+		// account-inventory lists droplets, volumes, load balancers, DOKS
+		// clusters, database clusters, apps and domains.
+		name:      "account-inventory",
+		dependsOn: []string{"droplets", "volumes", "networking", "doks", "databases", "apps"},
+		tools: func(getClient getClientFn) []server.ServerTool {
+			return account.NewInventoryTool(getClient).Tools()
+		},
+	},
+	{
+		// sandbox-cleanup lists and deletes droplets, snapshots, volumes, load
+		// balancers and DOKS clusters.
+		name:      "sandbox-cleanup",
+		dependsOn: []string{"droplets", "volumes", "networking", "doks"},
+		tools: func(getClient getClientFn) []server.ServerTool {
+			return account.NewSandboxTool(getClient).Tools()
+		},
+	},
+}
+
+// missingDependencies returns the services in dependsOn that are not enabled.
+func (g compositeGroup) missingDependencies(enabled []string) []string {
+	var missing []string
+	for _, dep := range g.dependsOn {
+		if !slices.Contains(enabled, dep) {
+			missing = append(missing, dep)
+		}
+	}
+	return missing
+}
+
+// CompositeDescription describes a composite group in describe-services.
+type CompositeDescription struct {
+	Name      string   `json:"name"`
+	Tools     []string `json:"tools"`
+	DependsOn []string `json:"depends_on"`
+	Enabled   bool     `json:"enabled"`
+	// Missing are the services that must also be enabled to register the group.
+	Missing []string `json:"missing,omitempty"`
+}
+
+// ServicesDescription is the result of describe-services.
+type ServicesDescription struct {
+	Enabled   []string               `json:"enabled"`
+	Supported []string               `json:"supported"`
+	Composite []CompositeDescription `json:"composite"`
+	// Aliases are other names registered for tools, listed apart from the
+	// services since they add no functionality.
+	Aliases []AliasDescription `json:"aliases"`
+}
+
+// describeServices returns which services and composite groups are enabled,
+// given the enabled services, and the registered aliases.
+func describeServices(getClient getClientFn, enabled []string, aliases []AliasDescription) ServicesDescription {
+	description := ServicesDescription{
+		Enabled:   slices.Sorted(slices.Values(enabled)),
+		Supported: make([]string, 0, len(supportedServices)),
+		Composite: make([]CompositeDescription, 0, len(compositeGroups)),
+		Aliases:   make([]AliasDescription, 0, len(aliases)),
+	}
+	description.Aliases = append(description.Aliases, aliases...)
+	for svc := range supportedServices {
+		description.Supported = append(description.Supported, svc)
+	}
+	slices.Sort(description.Supported)
+
+	for _, group := range compositeGroups {
+		var names []string
+		for _, tool := range group.tools(getClient) {
+			names = append(names, tool.Tool.Name)
+		}
+		missing := group.missingDependencies(enabled)
+		description.Composite = append(description.Composite, CompositeDescription{
+			Name:      group.name,
+			Tools:     names,
+			DependsOn: group.dependsOn,
+			Enabled:   len(missing) == 0,
+			Missing:   missing,
+		})
+	}
+	return description
+}
+
+// describeServicesTool returns the describe-services tool, which answers which
+// --services values enable which tools.
+func describeServicesTool(description ServicesDescription) server.ServerTool {
+	return server.ServerTool{
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			jsonDescription, err := json.MarshalIndent(description, "", "  ")
+			if err != nil {
+				return nil, fmt.Errorf("marshal error: %w", err)
+			}
+			return mcp.NewToolResultText(string(jsonDescription)), nil
+		},
+		Tool: mcp.NewTool("describe-services",
+			common.WithHints(common.HintsRead),
+			mcp.WithDescription("List the services enabled on this server and the supported --services values. Composite tools span several services and are only available when every service they depend on is enabled; each lists its dependencies and any that are missing. aliases lists other names for tools, such as floating-ip-* for reserved-ip-*, each with its canonical tool."),
+		),
+	}
+}
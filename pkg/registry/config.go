@@ -0,0 +1,122 @@
+package registry
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	middleware "mcp-digitalocean/internal"
+)
+
+// ToolFilter limits registered tools by name with path.Match patterns such as
+// "droplet-*". A tool is registered when it matches an Allow pattern, or Allow
+// is empty, and matches no Deny pattern.
+type ToolFilter struct {
+	Allow []string
+	Deny  []string
+}
+
+func (f ToolFilter) allows(name string) bool {
+	match := func(pattern string) bool {
+		ok, _ := path.Match(pattern, name)
+		return ok
+	}
+	if len(f.Allow) > 0 && !slices.ContainsFunc(f.Allow, match) {
+		return false
+	}
+	return !slices.ContainsFunc(f.Deny, match)
+}
+
+// validate rejects malformed patterns, which path.Match would otherwise
+// treat as matching nothing.
+func (f ToolFilter) validate() error {
+	for _, pattern := range slices.Concat(f.Allow, f.Deny) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid tool pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// ToolConfig is the tool configuration read from a config file.
+type ToolConfig struct {
+	// Services replace the --services flag when not empty.
+	Services []string
+	Filter   ToolFilter
+	// Quotas limit how often each token may call resource-creating tools.
+	Quotas middleware.Quotas
+}
+
+// LoadToolConfig reads a tool configuration file with one key=value per line.
+// The keys are services (a comma-separated list), allow and deny (tool name
+// patterns, comma-separated or repeated) and quota (one line per token, see
+// middleware.ParseQuota). Blank lines and lines starting with # are skipped.
+func LoadToolConfig(file string) (ToolConfig, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return ToolConfig{}, err
+	}
+	defer f.Close()
+
+	var cfg ToolConfig
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(text, "=")
+		if !ok {
+			return ToolConfig{}, fmt.Errorf("%s:%d: expected key=value", file, line)
+		}
+		values := splitValues(value)
+		switch strings.TrimSpace(key) {
+		case "services":
+			for _, svc := range values {
+				if _, ok := supportedServices[svc]; !ok {
+					return ToolConfig{}, fmt.Errorf("%s:%d: unsupported service %s", file, line, svc)
+				}
+			}
+			cfg.Services = append(cfg.Services, values...)
+		case "allow":
+			cfg.Filter.Allow = append(cfg.Filter.Allow, values...)
+		case "deny":
+			cfg.Filter.Deny = append(cfg.Filter.Deny, values...)
+		case "quota":
+			token, limits, err := middleware.ParseQuota(value)
+			if err != nil {
+				return ToolConfig{}, fmt.Errorf("%s:%d: %w", file, line, err)
+			}
+			if _, ok := cfg.Quotas[token]; ok {
+				return ToolConfig{}, fmt.Errorf("%s:%d: duplicate quota for %s", file, line, token)
+			}
+			if cfg.Quotas == nil {
+				cfg.Quotas = make(middleware.Quotas)
+			}
+			cfg.Quotas[token] = limits
+		default:
+			return ToolConfig{}, fmt.Errorf("%s:%d: unknown key %q, expected services, allow, deny or quota", file, line, strings.TrimSpace(key))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return ToolConfig{}, err
+	}
+	if err := cfg.Filter.validate(); err != nil {
+		return ToolConfig{}, fmt.Errorf("%s: %w", file, err)
+	}
+	return cfg, nil
+}
+
+// splitValues splits a comma-separated value, dropping empty items.
+func splitValues(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
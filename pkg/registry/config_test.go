@@ -0,0 +1,83 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	middleware "mcp-digitalocean/internal"
+)
+
+func TestToolFilter_allows(t *testing.T) {
+	filter := ToolFilter{Allow: []string{"droplet-*", "volume-*"}, Deny: []string{"*-delete*"}}
+	require.True(t, filter.allows("droplet-create"))
+	require.True(t, filter.allows("volume-list"))
+	require.False(t, filter.allows("droplet-delete"))
+	require.False(t, filter.allows("droplet-delete-by-tag"))
+	require.False(t, filter.allows("apps-list"))
+
+	require.True(t, ToolFilter{}.allows("apps-list"))
+	require.False(t, ToolFilter{Deny: []string{"apps-list"}}.allows("apps-list"))
+}
+
+func TestLoadToolConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		expected    ToolConfig
+		expectedErr string
+	}{
+		{
+			name: "all keys",
+			content: `# tools for the support team
+services = droplets, volumes
+
+allow=droplet-*
+allow=volume-*
+deny=*-delete*, droplet-rebuild
+`,
+			expected: ToolConfig{
+				Services: []string{"droplets", "volumes"},
+				Filter:   ToolFilter{Allow: []string{"droplet-*", "volume-*"}, Deny: []string{"*-delete*", "droplet-rebuild"}},
+			},
+		},
+		{
+			name:     "deny only",
+			content:  "deny=*-delete\n",
+			expected: ToolConfig{Filter: ToolFilter{Deny: []string{"*-delete"}}},
+		},
+		{
+			name:    "quotas",
+			content: "quota = default: droplet-create=5, *=20\nquota = 3f9a1c0e7b2d: droplet-create=50\n",
+			expected: ToolConfig{Quotas: middleware.Quotas{
+				"default":      {{Tools: "droplet-create", Limit: 5}, {Tools: "*", Limit: 20}},
+				"3f9a1c0e7b2d": {{Tools: "droplet-create", Limit: 50}},
+			}},
+		},
+		{name: "missing equals", content: "services\n", expectedErr: ":1: expected key=value"},
+		{name: "unknown key", content: "\nblock=droplet-*\n", expectedErr: `:2: unknown key "block", expected services, allow, deny or quota`},
+		{name: "unknown service", content: "services=droplets,servers\n", expectedErr: ":1: unsupported service servers"},
+		{name: "bad pattern", content: "allow=droplet-[\n", expectedErr: `invalid tool pattern "droplet-["`},
+		{name: "bad quota", content: "quota=default: droplet-create=many\n", expectedErr: `:1: invalid limit "many" for droplet-create`},
+		{name: "duplicate quota", content: "quota=default: *=5\nquota=default: *=6\n", expectedErr: ":2: duplicate quota for default"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "tools.conf")
+			require.NoError(t, os.WriteFile(file, []byte(tc.content), 0o600))
+
+			cfg, err := LoadToolConfig(file)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, cfg)
+		})
+	}
+
+	_, err := LoadToolConfig(filepath.Join(t.TempDir(), "missing.conf"))
+	require.Error(t, err)
+}
@@ -3,22 +3,45 @@ package dbaas
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"mcp-digitalocean/internal/waiter"
+	"mcp-digitalocean/pkg/registry/common"
+)
+
+const (
+	// ClusterStatusOnline is the status of a database cluster that is ready
+	// for connections.
+	ClusterStatusOnline = "online"
+	// defaultClusterWaitTimeout bounds how long db-cluster-create waits for a
+	// cluster to come online when WaitTimeoutSeconds is not set.
+	defaultClusterWaitTimeout = 30 * time.Minute
+	// defaultClusterPollInterval is how often a new cluster's status is polled.
+	defaultClusterPollInterval = 15 * time.Second
 )
 
 type ClusterTool struct {
-	client func(ctx context.Context) (*godo.Client, error)
+	common.ToolLogger
+
+	client       func(ctx context.Context) (*godo.Client, error)
+	clusters     *clusterResolver
+	pollInterval time.Duration
+	notify       func(ctx context.Context, req mcp.CallToolRequest, progress float64, message string)
 }
 
 func NewClusterTool(client func(ctx context.Context) (*godo.Client, error)) *ClusterTool {
 	return &ClusterTool{
-		client: client,
+		client:       client,
+		clusters:     sharedClusterResolver,
+		pollInterval: defaultClusterPollInterval,
+		notify:       common.NotifyProgress,
 	}
 }
 
@@ -49,7 +72,7 @@ func (s *ClusterTool) listCluster(ctx context.Context, req mcp.CallToolRequest)
 
 	clusters, _, err := client.Databases.List(ctx, opts)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, s.Logger(ctx), "api error", err), nil
 	}
 	jsonClusters, err := json.MarshalIndent(clusters, "", "  ")
 	if err != nil {
@@ -68,16 +91,20 @@ func (s *ClusterTool) getCluster(ctx context.Context, req mcp.CallToolRequest) (
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	cluster, _, err := client.Databases.Get(ctx, id)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.GetErrorResult(ctx, s.Logger(ctx), "api error", "cluster", id, err), nil
 	}
-	jsonCluster, err := json.MarshalIndent(cluster, "", "  ")
+	result, err := common.NewStructuredResourceResult(cluster)
 	if err != nil {
 		return nil, fmt.Errorf("marshal error: %w", err)
 	}
-	return mcp.NewToolResultText(string(jsonCluster)), nil
+	return result, nil
 }
 
 func (s *ClusterTool) createCluster(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -89,6 +116,7 @@ func (s *ClusterTool) createCluster(ctx context.Context, req mcp.CallToolRequest
 	region, _ := args["region"].(string)
 	size, _ := args["size"].(string)
 	numNodes, _ := args["num_nodes"].(float64) // JSON numbers are float64
+	privateNetworkUUID, _ := args["private_network_uuid"].(string)
 
 	tags := []string{}
 	if tagsRaw, ok := args["tags"].(string); ok && tagsRaw != "" {
@@ -100,14 +128,24 @@ func (s *ClusterTool) createCluster(ctx context.Context, req mcp.CallToolRequest
 		}
 	}
 
+	waitForOnline, _ := args["WaitForOnline"].(bool)
+	waitTimeout := defaultClusterWaitTimeout
+	if secs, ok := args["WaitTimeoutSeconds"].(float64); ok {
+		if secs <= 0 {
+			return mcp.NewToolResultError("WaitTimeoutSeconds must be positive"), nil
+		}
+		waitTimeout = time.Duration(secs * float64(time.Second))
+	}
+
 	createReq := &godo.DatabaseCreateRequest{
-		Name:       name,
-		EngineSlug: engine,
-		Version:    version,
-		Region:     region,
-		SizeSlug:   size,
-		NumNodes:   int(numNodes),
-		Tags:       tags,
+		Name:               name,
+		EngineSlug:         engine,
+		Version:            version,
+		Region:             region,
+		SizeSlug:           size,
+		NumNodes:           int(numNodes),
+		Tags:               tags,
+		PrivateNetworkUUID: privateNetworkUUID,
 	}
 
 	client, err := s.client(ctx)
@@ -115,15 +153,56 @@ func (s *ClusterTool) createCluster(ctx context.Context, req mcp.CallToolRequest
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
+	// a bad VPC only fails once provisioning is well under way, so check it
+	// before creating anything.
+	if privateNetworkUUID != "" {
+		vpc, _, err := client.VPCs.Get(ctx, privateNetworkUUID)
+		if err != nil {
+			return common.ErrorResult(ctx, s.Logger(ctx), fmt.Sprintf("private_network_uuid %s", privateNetworkUUID), err), nil
+		}
+		if region != "" && vpc.RegionSlug != region {
+			return mcp.NewToolResultError(fmt.Sprintf("VPC %s is in region %s, but the cluster is being created in %s", privateNetworkUUID, vpc.RegionSlug, region)), nil
+		}
+	}
+
 	cluster, _, err := client.Databases.Create(ctx, createReq)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, s.Logger(ctx), "api error", err), nil
 	}
-	jsonCluster, err := json.MarshalIndent(cluster, "", "  ")
+	if waitForOnline {
+		cluster, err = s.waitForOnline(ctx, req, client, cluster, waitTimeout)
+		if err != nil {
+			return common.ErrorResult(ctx, s.Logger(ctx), fmt.Sprintf("cluster %s was created but is not online yet; check it with db-cluster-get", cluster.ID), err), nil
+		}
+	}
+	result, err := common.NewResourceResult(cluster)
 	if err != nil {
 		return nil, fmt.Errorf("marshal error: %w", err)
 	}
-	return mcp.NewToolResultText(string(jsonCluster)), nil
+	return result, nil
+}
+
+// waitForOnline polls the cluster until its status is ClusterStatusOnline, the
+// timeout elapses or ctx is cancelled, sending a progress notification on every
+// poll. The last cluster read is returned alongside any error.
+func (s *ClusterTool) waitForOnline(ctx context.Context, req mcp.CallToolRequest, client *godo.Client, cluster *godo.Database, timeout time.Duration) (*godo.Database, error) {
+	polls := 0
+	db, err := waiter.Poll(ctx, s.pollInterval, timeout, func(ctx context.Context) (*godo.Database, bool, error) {
+		db, _, err := client.Databases.Get(ctx, cluster.ID)
+		if err != nil {
+			return nil, false, err
+		}
+		polls++
+		s.notify(ctx, req, float64(polls), fmt.Sprintf("cluster %s is %s", db.ID, db.Status))
+		return db, db.Status == ClusterStatusOnline, nil
+	})
+	if db == nil {
+		db = cluster
+	}
+	if errors.Is(err, waiter.ErrTimeout) {
+		return db, fmt.Errorf("timed out after %s waiting for cluster %s to come online, last status %q", timeout, db.ID, db.Status)
+	}
+	return db, err
 }
 
 func (s *ClusterTool) deleteCluster(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -135,9 +214,13 @@ func (s *ClusterTool) deleteCluster(ctx context.Context, req mcp.CallToolRequest
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 	_, err = client.Databases.Delete(ctx, id)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, s.Logger(ctx), "api error", err), nil
 	}
 	return mcp.NewToolResultText("Cluster deleted successfully"), nil
 }
@@ -174,9 +257,13 @@ func (s *ClusterTool) resizeCluster(ctx context.Context, req mcp.CallToolRequest
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 	_, err = client.Databases.Resize(ctx, id, resizeReq)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, s.Logger(ctx), "api error", err), nil
 	}
 	return mcp.NewToolResultText("Cluster resize initiated successfully"), nil
 }
@@ -190,9 +277,13 @@ func (s *ClusterTool) getCA(ctx context.Context, req mcp.CallToolRequest) (*mcp.
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 	ca, _, err := client.Databases.GetCA(ctx, id)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.GetErrorResult(ctx, s.Logger(ctx), "api error", "cluster", id, err), nil
 	}
 	jsonCA, err := json.MarshalIndent(ca, "", "  ")
 	if err != nil {
@@ -229,9 +320,13 @@ func (s *ClusterTool) listBackups(ctx context.Context, req mcp.CallToolRequest)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 	backups, _, err := client.Databases.ListBackups(ctx, id, opts)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, s.Logger(ctx), "api error", err), nil
 	}
 	jsonBackups, err := json.MarshalIndent(backups, "", "  ")
 	if err != nil {
@@ -247,7 +342,7 @@ func (s *ClusterTool) listOptions(ctx context.Context, req mcp.CallToolRequest)
 	}
 	options, _, err := client.Databases.ListOptions(ctx)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, s.Logger(ctx), "api error", err), nil
 	}
 	jsonOptions, err := json.MarshalIndent(options, "", "  ")
 	if err != nil {
@@ -256,7 +351,106 @@ func (s *ClusterTool) listOptions(ctx context.Context, req mcp.CallToolRequest)
 	return mcp.NewToolResultText(string(jsonOptions)), nil
 }
 
-func (s *ClusterTool) upgradeMajorVersion(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+// engineVersions returns the versions offered for a cluster engine, as named
+// by godo.Database.EngineSlug.
+func engineVersions(options *godo.DatabaseOptions, engine string) ([]string, bool) {
+	switch engine {
+	case "pg":
+		return options.PostgresSQLOptions.Versions, true
+	case "mysql":
+		return options.MySQLOptions.Versions, true
+	case "redis":
+		return options.RedisOptions.Versions, true
+	case "valkey":
+		return options.ValkeyOptions.Versions, true
+	case "mongodb":
+		return options.MongoDBOptions.Versions, true
+	case "kafka":
+		return options.KafkaOptions.Versions, true
+	case "opensearch":
+		return options.OpensearchOptions.Versions, true
+	}
+	return nil, false
+}
+
+// compareVersions compares dotted numeric versions such as "8" and "6.0",
+// returning -1, 0 or 1.
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// AvailableVersions lists the versions a cluster can be upgraded to.
+type AvailableVersions struct {
+	ID             string   `json:"id"`
+	Engine         string   `json:"engine"`
+	CurrentVersion string   `json:"current_version"`
+	UpgradeTargets []string `json:"upgrade_targets"`
+}
+
+func (s *ClusterTool) listAvailableVersions(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	id, ok := req.GetArguments()["id"].(string)
+	if !ok || id == "" {
+		return mcp.NewToolResultError("Cluster id is required"), nil
+	}
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	cluster, _, err := client.Databases.Get(ctx, id)
+	if err != nil {
+		return common.ErrorResult(ctx, s.Logger(ctx), "api error", err), nil
+	}
+	options, _, err := client.Databases.ListOptions(ctx)
+	if err != nil {
+		return common.ErrorResult(ctx, s.Logger(ctx), "api error", err), nil
+	}
+	versions, ok := engineVersions(options, cluster.EngineSlug)
+	if !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("no version options are known for engine %s", cluster.EngineSlug)), nil
+	}
+
+	available := AvailableVersions{
+		ID:             cluster.ID,
+		Engine:         cluster.EngineSlug,
+		CurrentVersion: cluster.VersionSlug,
+		UpgradeTargets: []string{},
+	}
+	for _, v := range versions {
+		if compareVersions(v, cluster.VersionSlug) > 0 {
+			available.UpgradeTargets = append(available.UpgradeTargets, v)
+		}
+	}
+	jsonVersions, err := json.MarshalIndent(available, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(string(jsonVersions)), nil
+}
+
+// upgradeVersion upgrades a cluster's major version. Upgrades cannot be rolled
+// back, so the caller has to confirm and, unless told otherwise, the cluster
+// must have a backup to restore from.
+func (s *ClusterTool) upgradeVersion(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	args := req.GetArguments()
 	id, ok := args["id"].(string)
 	if !ok || id == "" {
@@ -266,14 +460,31 @@ func (s *ClusterTool) upgradeMajorVersion(ctx context.Context, req mcp.CallToolR
 	if !ok || version == "" {
 		return mcp.NewToolResultError("Target version is required"), nil
 	}
-	upgradeReq := &godo.UpgradeVersionRequest{Version: version}
+	if confirm, _ := args["confirm"].(bool); !confirm {
+		return mcp.NewToolResultError("confirm must be true: a major version upgrade cannot be undone"), nil
+	}
+	skipBackupCheck, _ := args["skip_backup_check"].(bool)
+
 	client, err := s.client(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
-	_, err = client.Databases.UpgradeMajorVersion(ctx, id, upgradeReq)
+	id, err = s.clusters.resolve(ctx, client, id)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if !skipBackupCheck {
+		backups, _, err := client.Databases.ListBackups(ctx, id, nil)
+		if err != nil {
+			return common.ErrorResult(ctx, s.Logger(ctx), "api error", err), nil
+		}
+		if len(backups) == 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("cluster %s has no backups to restore from if the upgrade goes wrong; wait for a backup or set skip_backup_check", id)), nil
+		}
+	}
+	_, err = client.Databases.UpgradeMajorVersion(ctx, id, &godo.UpgradeVersionRequest{Version: version})
+	if err != nil {
+		return common.ErrorResult(ctx, s.Logger(ctx), "api error", err), nil
 	}
 	return mcp.NewToolResultText("Major version upgrade initiated successfully"), nil
 }
@@ -301,6 +512,12 @@ func (s *ClusterTool) startOnlineMigration(ctx context.Context, req mcp.CallTool
 	if err := json.Unmarshal(sourceBytes, &source); err != nil {
 		return mcp.NewToolResultError("Invalid source object: " + err.Error()), nil
 	}
+	if source.Host == "" {
+		return mcp.NewToolResultError("source.host is required"), nil
+	}
+	if source.Port < 1 || source.Port > 65535 {
+		return mcp.NewToolResultError(fmt.Sprintf("source.port must be between 1 and 65535, got %d", source.Port)), nil
+	}
 	disableSSL := false
 	if dssl, ok := args["disable_ssl"].(bool); ok {
 		disableSSL = dssl
@@ -323,9 +540,13 @@ func (s *ClusterTool) startOnlineMigration(ctx context.Context, req mcp.CallTool
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 	status, _, err := client.Databases.StartOnlineMigration(ctx, id, startReq)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return mcp.NewToolResultError("api error: " + redactPassword(err.Error(), source.Password)), nil
 	}
 	jsonStatus, err := json.MarshalIndent(status, "", "  ")
 	if err != nil {
@@ -334,6 +555,15 @@ func (s *ClusterTool) startOnlineMigration(ctx context.Context, req mcp.CallTool
 	return mcp.NewToolResultText(string(jsonStatus)), nil
 }
 
+// redactPassword masks password wherever it appears in msg, so an API error
+// quoting the migration request never echoes the source credentials back.
+func redactPassword(msg, password string) string {
+	if password == "" {
+		return msg
+	}
+	return strings.ReplaceAll(msg, password, "[REDACTED]")
+}
+
 func (s *ClusterTool) stopOnlineMigration(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	args := req.GetArguments()
 	id, ok := args["id"].(string)
@@ -348,9 +578,13 @@ func (s *ClusterTool) stopOnlineMigration(ctx context.Context, req mcp.CallToolR
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 	_, err = client.Databases.StopOnlineMigration(ctx, id, migrationID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, s.Logger(ctx), "api error", err), nil
 	}
 	return mcp.NewToolResultText("Online migration stopped successfully"), nil
 }
@@ -365,9 +599,13 @@ func (s *ClusterTool) getOnlineMigrationStatus(ctx context.Context, req mcp.Call
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 	status, _, err := client.Databases.GetOnlineMigrationStatus(ctx, id)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.GetErrorResult(ctx, s.Logger(ctx), "api error", "cluster", id, err), nil
 	}
 	jsonStatus, err := json.MarshalIndent(status, "", "  ")
 	if err != nil {
@@ -376,6 +614,51 @@ func (s *ClusterTool) getOnlineMigrationStatus(ctx context.Context, req mcp.Call
 	return mcp.NewToolResultText(string(jsonStatus)), nil
 }
 
+// getMetricsCredentials returns the basic auth credentials used to scrape
+// metrics from the account's database clusters. The credentials are shared by
+// all clusters on the account.
+func (s *ClusterTool) getMetricsCredentials(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+	creds, _, err := client.Databases.GetMetricsCredentials(ctx)
+	if err != nil {
+		return common.ErrorResult(ctx, s.Logger(ctx), "api error", err), nil
+	}
+	jsonCreds, err := json.MarshalIndent(creds, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(string(jsonCreds)), nil
+}
+
+func (s *ClusterTool) updateMetricsCredentials(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	username, ok := args["username"].(string)
+	if !ok || username == "" {
+		return mcp.NewToolResultError("username is required"), nil
+	}
+	password, ok := args["password"].(string)
+	if !ok || password == "" {
+		return mcp.NewToolResultError("password is required"), nil
+	}
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+	_, err = client.Databases.UpdateMetricsCredentials(ctx, &godo.DatabaseUpdateMetricsCredentialsRequest{
+		Credentials: &godo.DatabaseMetricsCredentials{
+			BasicAuthUsername: username,
+			BasicAuthPassword: password,
+		},
+	})
+	if err != nil {
+		return common.ErrorResult(ctx, s.Logger(ctx), "api error", err), nil
+	}
+	return mcp.NewToolResultText("Metrics credentials updated successfully"), nil
+}
+
 func (s *ClusterTool) Tools() []server.ServerTool {
 	return []server.ServerTool{
 
@@ -391,19 +674,21 @@ func (s *ClusterTool) Tools() []server.ServerTool {
 			Handler: s.getCluster,
 			Tool: mcp.NewTool("db-cluster-get",
 				mcp.WithDescription("Get a cluster by its id"),
-				mcp.WithString("id", mcp.Required(), mcp.Description("The id of the cluster to retrieve")),
+				common.WithOutputSchema[godo.Database](),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The UUID or name of the cluster to retrieve")),
 			),
 		},
 		{
 			Handler: s.getCA,
 			Tool: mcp.NewTool("db-cluster-get-ca",
 				mcp.WithDescription("Get the CA certificate for a cluster by its id"),
-				mcp.WithString("id", mcp.Required(), mcp.Description("The id of the cluster to retrieve the CA for")),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The UUID or name of the cluster to retrieve the CA for")),
 			),
 		},
 		{
 			Handler: s.createCluster,
 			Tool: mcp.NewTool("db-cluster-create",
+				common.WithCreatesResource(),
 				mcp.WithDescription("Create a new database cluster"),
 				mcp.WithString("name", mcp.Required(), mcp.Description("The name of the cluster")),
 				mcp.WithString("engine", mcp.Required(), mcp.Description("The engine slug (e.g., valkey, pg, mysql, etc.)")),
@@ -412,20 +697,23 @@ func (s *ClusterTool) Tools() []server.ServerTool {
 				mcp.WithString("size", mcp.Required(), mcp.Description("The size slug (e.g., db-s-2vcpu-4gb)")),
 				mcp.WithNumber("num_nodes", mcp.Required(), mcp.Description("The number of nodes")),
 				mcp.WithString("tags", mcp.Description("Comma-separated tags to apply to the cluster")),
+				mcp.WithString("private_network_uuid", mcp.Description("UUID of the VPC to place the cluster in. It must exist and be in the cluster's region; defaults to the region's default VPC")),
+				mcp.WithBoolean("WaitForOnline", mcp.DefaultBool(false), mcp.Description("Wait until the cluster is online before returning, sending progress notifications meanwhile, so the result includes its connection details (default: false)")),
+				mcp.WithNumber("WaitTimeoutSeconds", mcp.DefaultNumber(1800), mcp.Description("How long WaitForOnline waits, in seconds (default: 1800)")),
 			),
 		},
 		{
 			Handler: s.deleteCluster,
 			Tool: mcp.NewTool("db-cluster-delete",
 				mcp.WithDescription("Delete a database cluster by its id"),
-				mcp.WithString("id", mcp.Required(), mcp.Description("The id of the cluster to delete")),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The UUID or name of the cluster to delete")),
 			),
 		},
 		{
 			Handler: s.resizeCluster,
 			Tool: mcp.NewTool("db-cluster-resize",
 				mcp.WithDescription("Resize a database cluster by its id. At least one of size, num_nodes, or storage_size_mib must be provided."),
-				mcp.WithString("id", mcp.Required(), mcp.Description("The id of the cluster to resize")),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The UUID or name of the cluster to resize")),
 				mcp.WithString("size", mcp.Description("The new size slug (e.g., db-s-2vcpu-4gb)")),
 				mcp.WithNumber("num_nodes", mcp.Description("The new number of nodes")),
 				mcp.WithNumber("storage_size_mib", mcp.Description("The new storage size in MiB")),
@@ -435,7 +723,7 @@ func (s *ClusterTool) Tools() []server.ServerTool {
 			Handler: s.listBackups,
 			Tool: mcp.NewTool("db-cluster-list-backups",
 				mcp.WithDescription("List backups for a database cluster by its id"),
-				mcp.WithString("id", mcp.Required(), mcp.Description("The id of the cluster to list backups for")),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The UUID or name of the cluster to list backups for")),
 				mcp.WithString("page", mcp.Description("Page number for pagination (optional, integer as string)")),
 				mcp.WithNumber("per_page", mcp.Description("Number of results per page (optional, integer)")),
 			),
@@ -447,29 +735,38 @@ func (s *ClusterTool) Tools() []server.ServerTool {
 			),
 		},
 		{
-			Handler: s.upgradeMajorVersion,
-			Tool: mcp.NewTool("db-cluster-upgrade-major-version",
-				mcp.WithDescription("Upgrade the major version of a database cluster by its id. Requires the target version."),
-				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
+			Handler: s.listAvailableVersions,
+			Tool: mcp.NewTool("db-cluster-list-available-versions",
+				mcp.WithDescription("List the versions a database cluster can be upgraded to: the versions offered for its engine that are newer than the one it runs."),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID or name")),
+			),
+		},
+		{
+			Handler: s.upgradeVersion,
+			Tool: mcp.NewTool("db-cluster-upgrade-version",
+				mcp.WithDescription("Upgrade the major version of a database cluster by its id. Major upgrades cannot be undone, so confirm must be true, and the cluster must have at least one backup unless skip_backup_check is set. Use db-cluster-list-available-versions to find valid targets."),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID or name")),
 				mcp.WithString("version", mcp.Required(), mcp.Description("The target major version to upgrade to (e.g., 15 for PostgreSQL)")),
+				mcp.WithBoolean("confirm", mcp.Required(), mcp.Description("Must be true only after the user has confirmed the irreversible upgrade")),
+				mcp.WithBoolean("skip_backup_check", mcp.DefaultBool(false), mcp.Description("Upgrade even if the cluster has no backups")),
 			),
 		},
 		{
 			Handler: s.startOnlineMigration,
 			Tool: mcp.NewTool("db-cluster-start-online-migration",
 				mcp.WithDescription("Start an online migration for a database cluster by its id."),
-				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID or name")),
 				mcp.WithObject("source",
 					mcp.Required(),
 					mcp.Description("The source database configuration"),
 					mcp.Properties(map[string]any{
 						"host": map[string]any{
 							"type":        "string",
-							"description": "Hostname or IP of the source database",
+							"description": "Hostname or IP of the source database (required)",
 						},
 						"port": map[string]any{
 							"type":        "integer",
-							"description": "Source database port",
+							"description": "Source database port (1-65535)",
 						},
 						"dbname": map[string]any{
 							"type":        "string",
@@ -481,7 +778,7 @@ func (s *ClusterTool) Tools() []server.ServerTool {
 						},
 						"password": map[string]any{
 							"type":        "string",
-							"description": "Password for source connection",
+							"description": "Password for source connection. Never included in results or logs",
 						},
 					}),
 				),
@@ -493,15 +790,30 @@ func (s *ClusterTool) Tools() []server.ServerTool {
 			Handler: s.stopOnlineMigration,
 			Tool: mcp.NewTool("db-cluster-stop-online-migration",
 				mcp.WithDescription("Stop an online migration for a database cluster by its id and migration_id."),
-				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID or name")),
 				mcp.WithString("migration_id", mcp.Required(), mcp.Description("The migration id to stop")),
 			),
 		},
 		{
 			Handler: s.getOnlineMigrationStatus,
-			Tool: mcp.NewTool("db-cluster-get-migration",
+			Tool: mcp.NewTool("db-cluster-get-online-migration-status",
 				mcp.WithDescription("Get the online migration status for a database cluster by its id."),
-				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID or name")),
+			),
+		},
+		{
+			Handler: s.getMetricsCredentials,
+			Tool: mcp.NewTool("db-cluster-get-metrics-credentials",
+				mcp.WithDescription("Get the basic auth username and password used to scrape metrics from the account's database clusters (shared by all clusters). "+
+					"Use them against each cluster's metrics endpoint (GET https://<cluster host>:9273/metrics). Wraps GET /v2/databases/metrics/credentials."),
+			),
+		},
+		{
+			Handler: s.updateMetricsCredentials,
+			Tool: mcp.NewTool("db-cluster-update-metrics-credentials",
+				mcp.WithDescription("Set the basic auth username and password used to scrape metrics from the account's database clusters. Wraps PUT /v2/databases/metrics/credentials."),
+				mcp.WithString("username", mcp.Required(), mcp.Description("The basic auth username for the metrics endpoint")),
+				mcp.WithString("password", mcp.Required(), mcp.Description("The basic auth password for the metrics endpoint")),
 			),
 		},
 	}
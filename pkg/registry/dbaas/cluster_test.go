@@ -2,6 +2,8 @@ package dbaas
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"mcp-digitalocean/pkg/registry/dbaas/mocks"
 	"testing"
 	"time"
@@ -45,8 +47,8 @@ func TestClusterTool_getCluster(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockDB := mocks.NewMockDatabasesService(ctrl)
-	cluster := &godo.Database{Name: "my-cluster"}
-	mockDB.EXPECT().Get(gomock.Any(), "abc").Return(cluster, nil, nil)
+	cluster := &godo.Database{ID: clusterUUID, Name: "my-cluster"}
+	mockDB.EXPECT().Get(gomock.Any(), clusterUUID).Return(cluster, nil, nil)
 
 	client := func(ctx context.Context) (*godo.Client, error) {
 		return &godo.Client{
@@ -56,10 +58,14 @@ func TestClusterTool_getCluster(t *testing.T) {
 
 	ct := &ClusterTool{client: client}
 
-	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"id": "abc"}}}
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"id": clusterUUID}}}
 	res, err := ct.getCluster(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Contains(t, getText(res), "my-cluster")
+	assert.Equal(t, cluster, res.StructuredContent)
+	if assert.Len(t, res.Content, 2) {
+		assert.JSONEq(t, `{"urn":"do:dbaas:9cc10173-e9ea-4176-9dbc-a4cee4c4ff30","console_url":"https://cloud.digitalocean.com/databases/9cc10173-e9ea-4176-9dbc-a4cee4c4ff30"}`, res.Content[1].(mcp.TextContent).Text)
+	}
 
 	// Error case: missing id (should not expect a call to Get)
 	reqMissing := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{}}}
@@ -118,7 +124,7 @@ func TestClusterTool_deleteCluster(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockDB := mocks.NewMockDatabasesService(ctrl)
-	mockDB.EXPECT().Delete(gomock.Any(), "abc").Return(nil, nil)
+	mockDB.EXPECT().Delete(gomock.Any(), clusterUUID).Return(nil, nil)
 
 	client := func(ctx context.Context) (*godo.Client, error) {
 		return &godo.Client{
@@ -128,7 +134,7 @@ func TestClusterTool_deleteCluster(t *testing.T) {
 
 	ct := &ClusterTool{client: client}
 
-	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"id": "abc"}}}
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"id": clusterUUID}}}
 	res, err := ct.deleteCluster(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Contains(t, getText(res), "Cluster deleted successfully")
@@ -144,7 +150,7 @@ func TestClusterTool_resizeCluster(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockDB := mocks.NewMockDatabasesService(ctrl)
-	mockDB.EXPECT().Resize(gomock.Any(), "abc", gomock.Any()).Return(nil, nil)
+	mockDB.EXPECT().Resize(gomock.Any(), clusterUUID, gomock.Any()).Return(nil, nil)
 
 	client := func(ctx context.Context) (*godo.Client, error) {
 		return &godo.Client{
@@ -153,7 +159,7 @@ func TestClusterTool_resizeCluster(t *testing.T) {
 	}
 
 	ct := &ClusterTool{client: client}
-	args := map[string]interface{}{"id": "abc", "size": "db-s-2vcpu-4gb", "num_nodes": float64(3)}
+	args := map[string]interface{}{"id": clusterUUID, "size": "db-s-2vcpu-4gb", "num_nodes": float64(3)}
 	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err := ct.resizeCluster(context.Background(), req)
 	assert.NoError(t, err)
@@ -170,7 +176,7 @@ func TestClusterTool_getCA(t *testing.T) {
 	defer ctrl.Finish()
 	mockDB := mocks.NewMockDatabasesService(ctrl)
 	ca := &godo.DatabaseCA{Certificate: []byte("cert-data")}
-	mockDB.EXPECT().GetCA(gomock.Any(), "abc").Return(ca, nil, nil)
+	mockDB.EXPECT().GetCA(gomock.Any(), clusterUUID).Return(ca, nil, nil)
 
 	client := func(ctx context.Context) (*godo.Client, error) {
 		return &godo.Client{
@@ -179,7 +185,7 @@ func TestClusterTool_getCA(t *testing.T) {
 	}
 
 	ct := &ClusterTool{client: client}
-	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"id": "abc"}}}
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"id": clusterUUID}}}
 	res, err := ct.getCA(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Contains(t, getText(res), "Y2VydC1kYXRh")
@@ -194,7 +200,7 @@ func TestClusterTool_listBackups(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockDB := mocks.NewMockDatabasesService(ctrl)
-	mockDB.EXPECT().ListBackups(gomock.Any(), "abc", gomock.Any()).Return([]godo.DatabaseBackup{{CreatedAt: time.Now()}}, nil, nil)
+	mockDB.EXPECT().ListBackups(gomock.Any(), clusterUUID, gomock.Any()).Return([]godo.DatabaseBackup{{CreatedAt: time.Now()}}, nil, nil)
 
 	client := func(ctx context.Context) (*godo.Client, error) {
 		return &godo.Client{
@@ -203,7 +209,7 @@ func TestClusterTool_listBackups(t *testing.T) {
 	}
 
 	ct := &ClusterTool{client: client}
-	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"id": "abc"}}}
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"id": clusterUUID}}}
 	res, err := ct.listBackups(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Contains(t, getText(res), "created_at")
@@ -233,11 +239,116 @@ func TestClusterTool_listOptions(t *testing.T) {
 	assert.Contains(t, getText(res), "pg")
 }
 
-func TestClusterTool_upgradeMajorVersion(t *testing.T) {
+func TestClusterTool_upgradeVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(m *mocks.MockDatabasesService)
+		expectText  string
+		expectError bool
+	}{
+		{
+			name: "upgrades when confirmed and backed up",
+			args: map[string]any{"id": clusterUUID, "version": "16", "confirm": true},
+			mockSetup: func(m *mocks.MockDatabasesService) {
+				m.EXPECT().ListBackups(gomock.Any(), clusterUUID, nil).Return([]godo.DatabaseBackup{{SizeGigabytes: 1}}, nil, nil)
+				m.EXPECT().UpgradeMajorVersion(gomock.Any(), clusterUUID, &godo.UpgradeVersionRequest{Version: "16"}).Return(nil, nil)
+			},
+			expectText: "Major version upgrade initiated successfully",
+		},
+		{
+			name: "skips the backup check",
+			args: map[string]any{"id": clusterUUID, "version": "16", "confirm": true, "skip_backup_check": true},
+			mockSetup: func(m *mocks.MockDatabasesService) {
+				m.EXPECT().UpgradeMajorVersion(gomock.Any(), clusterUUID, &godo.UpgradeVersionRequest{Version: "16"}).Return(nil, nil)
+			},
+			expectText: "Major version upgrade initiated successfully",
+		},
+		{
+			name:        "not confirmed",
+			args:        map[string]any{"id": clusterUUID, "version": "16"},
+			expectText:  "confirm must be true",
+			expectError: true,
+		},
+		{
+			name: "no backups",
+			args: map[string]any{"id": clusterUUID, "version": "16", "confirm": true},
+			mockSetup: func(m *mocks.MockDatabasesService) {
+				m.EXPECT().ListBackups(gomock.Any(), clusterUUID, nil).Return(nil, nil, nil)
+			},
+			expectText:  "cluster 9cc10173-e9ea-4176-9dbc-a4cee4c4ff30 has no backups",
+			expectError: true,
+		},
+		{
+			name: "upgrade api error",
+			args: map[string]any{"id": clusterUUID, "version": "16", "confirm": true, "skip_backup_check": true},
+			mockSetup: func(m *mocks.MockDatabasesService) {
+				m.EXPECT().UpgradeMajorVersion(gomock.Any(), clusterUUID, gomock.Any()).Return(nil, errors.New("version not available"))
+			},
+			expectText:  "version not available",
+			expectError: true,
+		},
+		{
+			name:        "missing version",
+			args:        map[string]any{"id": clusterUUID, "confirm": true},
+			expectText:  "Target version is required",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockDB := mocks.NewMockDatabasesService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockDB)
+			}
+			ct := &ClusterTool{client: func(ctx context.Context) (*godo.Client, error) {
+				return &godo.Client{Databases: mockDB}, nil
+			}}
+
+			res, err := ct.upgradeVersion(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectError, res.IsError)
+			assert.Contains(t, getText(res), tc.expectText)
+		})
+	}
+}
+
+func TestClusterTool_listAvailableVersions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDB := mocks.NewMockDatabasesService(ctrl)
+	mockDB.EXPECT().Get(gomock.Any(), clusterUUID).Return(&godo.Database{ID: clusterUUID, EngineSlug: "pg", VersionSlug: "14"}, nil, nil)
+	mockDB.EXPECT().ListOptions(gomock.Any()).Return(&godo.DatabaseOptions{
+		PostgresSQLOptions: godo.DatabaseEngineOptions{Versions: []string{"13", "14", "15", "16"}},
+		MySQLOptions:       godo.DatabaseEngineOptions{Versions: []string{"8"}},
+	}, nil, nil)
+	ct := &ClusterTool{client: func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{Databases: mockDB}, nil
+	}}
+
+	res, err := ct.listAvailableVersions(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": clusterUUID}}})
+	assert.NoError(t, err)
+	assert.False(t, res.IsError)
+	var out AvailableVersions
+	assert.NoError(t, json.Unmarshal([]byte(getText(res)), &out))
+	assert.Equal(t, AvailableVersions{ID: clusterUUID, Engine: "pg", CurrentVersion: "14", UpgradeTargets: []string{"15", "16"}}, out)
+}
+
+func TestCompareVersions(t *testing.T) {
+	assert.Equal(t, -1, compareVersions("6.0", "7.0"))
+	assert.Equal(t, 1, compareVersions("16", "9"))
+	assert.Equal(t, 0, compareVersions("8", "8.0"))
+}
+
+func TestClusterTool_getMetricsCredentials(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockDB := mocks.NewMockDatabasesService(ctrl)
-	mockDB.EXPECT().UpgradeMajorVersion(gomock.Any(), "abc", gomock.Any()).Return(nil, nil)
+	mockDB.EXPECT().GetMetricsCredentials(gomock.Any()).Return(&godo.DatabaseMetricsCredentials{
+		BasicAuthUsername: "metrics",
+		BasicAuthPassword: "s3cret",
+	}, nil, nil)
 
 	client := func(ctx context.Context) (*godo.Client, error) {
 		return &godo.Client{
@@ -246,15 +357,372 @@ func TestClusterTool_upgradeMajorVersion(t *testing.T) {
 	}
 
 	ct := &ClusterTool{client: client}
-	args := map[string]interface{}{"id": "abc", "version": "15"}
-	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
-	res, err := ct.upgradeMajorVersion(context.Background(), req)
+
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{}}}
+	res, err := ct.getMetricsCredentials(context.Background(), req)
 	assert.NoError(t, err)
-	assert.Contains(t, getText(res), "Major version upgrade initiated successfully")
-	// Error case: missing version
-	args = map[string]interface{}{"id": "abc"}
-	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
-	res, err = ct.upgradeMajorVersion(context.Background(), req)
+	assert.JSONEq(t, `{"basic_auth_username":"metrics","basic_auth_password":"s3cret"}`, getText(res))
+}
+
+func TestClusterTool_updateMetricsCredentials(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDatabasesService(ctrl)
+	mockDB.EXPECT().UpdateMetricsCredentials(gomock.Any(), &godo.DatabaseUpdateMetricsCredentialsRequest{
+		Credentials: &godo.DatabaseMetricsCredentials{
+			BasicAuthUsername: "metrics",
+			BasicAuthPassword: "s3cret",
+		},
+	}).Return(nil, nil)
+
+	client := func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{
+			Databases: mockDB,
+		}, nil
+	}
+
+	ct := &ClusterTool{client: client}
+
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"username": "metrics", "password": "s3cret"}}}
+	res, err := ct.updateMetricsCredentials(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Contains(t, getText(res), "Metrics credentials updated successfully")
+
+	// Error case: missing password (should not expect a call to UpdateMetricsCredentials)
+	reqMissing := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"username": "metrics"}}}
+	res, err = ct.updateMetricsCredentials(context.Background(), reqMissing)
+	assert.NoError(t, err)
+	assert.Contains(t, getText(res), "password is required")
+}
+
+func TestClusterTool_createClusterWaitForOnline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDB := mocks.NewMockDatabasesService(ctrl)
+	mockDB.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&godo.Database{ID: "db-1", Name: "new-cluster", Status: "creating"}, nil, nil)
+	gomock.InOrder(
+		mockDB.EXPECT().Get(gomock.Any(), "db-1").Return(&godo.Database{ID: "db-1", Status: "creating"}, nil, nil),
+		mockDB.EXPECT().Get(gomock.Any(), "db-1").Return(&godo.Database{ID: "db-1", Status: "creating"}, nil, nil),
+		mockDB.EXPECT().Get(gomock.Any(), "db-1").Return(&godo.Database{
+			ID:         "db-1",
+			Name:       "new-cluster",
+			Status:     ClusterStatusOnline,
+			Connection: &godo.DatabaseConnection{Host: "db-1.db.ondigitalocean.com", Port: 25060},
+		}, nil, nil),
+	)
+
+	var progress []string
+	ct := &ClusterTool{
+		client: func(ctx context.Context) (*godo.Client, error) {
+			return &godo.Client{Databases: mockDB}, nil
+		},
+		pollInterval: time.Millisecond,
+		notify: func(ctx context.Context, req mcp.CallToolRequest, p float64, message string) {
+			progress = append(progress, message)
+		},
+	}
+
+	res, err := ct.createCluster(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{
+		"name":          "new-cluster",
+		"engine":        "pg",
+		"num_nodes":     float64(1),
+		"WaitForOnline": true,
+	}}})
 	assert.NoError(t, err)
-	assert.Contains(t, getText(res), "Target version is required")
+	assert.False(t, res.IsError)
+	assert.Contains(t, getText(res), `"status": "online"`)
+	assert.Contains(t, getText(res), "db-1.db.ondigitalocean.com")
+	assert.Equal(t, []string{"cluster db-1 is creating", "cluster db-1 is creating", "cluster db-1 is online"}, progress)
+}
+
+func TestClusterTool_createClusterWaitForOnlineErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         map[string]interface{}
+		ctx          func() context.Context
+		pollInterval time.Duration
+		mockSetup    func(*mocks.MockDatabasesService)
+		wantMessage  string
+	}{
+		{
+			name: "timeout",
+			args: map[string]interface{}{"WaitTimeoutSeconds": 0.02},
+			mockSetup: func(m *mocks.MockDatabasesService) {
+				m.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&godo.Database{ID: "db-1", Status: "creating"}, nil, nil)
+				m.EXPECT().Get(gomock.Any(), "db-1").Return(&godo.Database{ID: "db-1", Status: "creating"}, nil, nil).MinTimes(1)
+			},
+			wantMessage: `cluster db-1 was created but is not online yet; check it with db-cluster-get: timed out after 20ms waiting for cluster db-1 to come online, last status "creating"`,
+		},
+		{
+			name: "cancelled",
+			// a poll interval longer than the test makes the cancellation
+			// the only way out of the wait.
+			pollInterval: time.Hour,
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			},
+			mockSetup: func(m *mocks.MockDatabasesService) {
+				m.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&godo.Database{ID: "db-1", Status: "creating"}, nil, nil)
+				m.EXPECT().Get(gomock.Any(), "db-1").Return(&godo.Database{ID: "db-1", Status: "creating"}, nil, nil)
+			},
+			wantMessage: "context canceled",
+		},
+		{
+			name: "get error",
+			mockSetup: func(m *mocks.MockDatabasesService) {
+				m.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&godo.Database{ID: "db-1", Status: "creating"}, nil, nil)
+				m.EXPECT().Get(gomock.Any(), "db-1").Return(nil, nil, assert.AnError)
+			},
+			wantMessage: assert.AnError.Error(),
+		},
+		{
+			name:        "invalid timeout",
+			args:        map[string]interface{}{"WaitTimeoutSeconds": float64(0)},
+			wantMessage: "WaitTimeoutSeconds must be positive",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockDB := mocks.NewMockDatabasesService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockDB)
+			}
+			ct := NewClusterTool(func(ctx context.Context) (*godo.Client, error) {
+				return &godo.Client{Databases: mockDB}, nil
+			})
+			ct.pollInterval = time.Millisecond
+			if tc.pollInterval != 0 {
+				ct.pollInterval = tc.pollInterval
+			}
+
+			args := map[string]interface{}{"name": "new-cluster", "engine": "pg", "WaitForOnline": true}
+			for k, v := range tc.args {
+				args[k] = v
+			}
+			ctx := context.Background()
+			if tc.ctx != nil {
+				ctx = tc.ctx()
+			}
+
+			res, err := ct.createCluster(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+			assert.NoError(t, err)
+			assert.True(t, res.IsError)
+			assert.Contains(t, getText(res), tc.wantMessage)
+		})
+	}
+}
+
+func TestClusterTool_startOnlineMigration(t *testing.T) {
+	source := map[string]interface{}{
+		"host":     "10.0.0.5",
+		"port":     float64(5432),
+		"dbname":   "app",
+		"username": "migrator",
+		"password": "hunter2",
+	}
+	withSource := func(overrides map[string]interface{}) map[string]interface{} {
+		s := map[string]interface{}{}
+		for k, v := range source {
+			s[k] = v
+		}
+		for k, v := range overrides {
+			s[k] = v
+		}
+		return s
+	}
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func(m *mocks.MockDatabasesService)
+		wantError   bool
+		wantMessage string
+	}{
+		{
+			name: "success",
+			args: map[string]interface{}{"id": clusterUUID, "source": source, "disable_ssl": true, "ignore_dbs": "postgres, template1"},
+			mockSetup: func(m *mocks.MockDatabasesService) {
+				m.EXPECT().StartOnlineMigration(gomock.Any(), clusterUUID, &godo.DatabaseStartOnlineMigrationRequest{
+					Source: &godo.DatabaseOnlineMigrationConfig{
+						Host:         "10.0.0.5",
+						Port:         5432,
+						DatabaseName: "app",
+						Username:     "migrator",
+						Password:     "hunter2",
+					},
+					DisableSSL: true,
+					IgnoreDBs:  []string{"postgres", "template1"},
+				}).Return(&godo.DatabaseOnlineMigrationStatus{ID: "mig-1", Status: "syncing"}, nil, nil)
+			},
+			wantMessage: "mig-1",
+		},
+		{
+			name:        "missing host",
+			args:        map[string]interface{}{"id": clusterUUID, "source": withSource(map[string]interface{}{"host": ""})},
+			wantError:   true,
+			wantMessage: "source.host is required",
+		},
+		{
+			name:        "missing port",
+			args:        map[string]interface{}{"id": clusterUUID, "source": withSource(map[string]interface{}{"port": float64(0)})},
+			wantError:   true,
+			wantMessage: "source.port must be between 1 and 65535, got 0",
+		},
+		{
+			name:        "port out of range",
+			args:        map[string]interface{}{"id": clusterUUID, "source": withSource(map[string]interface{}{"port": float64(70000)})},
+			wantError:   true,
+			wantMessage: "source.port must be between 1 and 65535, got 70000",
+		},
+		{
+			name:        "missing source",
+			args:        map[string]interface{}{"id": clusterUUID},
+			wantError:   true,
+			wantMessage: "Missing or invalid 'source' object",
+		},
+		{
+			name: "api error quoting the password",
+			args: map[string]interface{}{"id": clusterUUID, "source": source},
+			mockSetup: func(m *mocks.MockDatabasesService) {
+				m.EXPECT().StartOnlineMigration(gomock.Any(), clusterUUID, gomock.Any()).
+					Return(nil, nil, errors.New(`could not connect as migrator with password "hunter2"`))
+			},
+			wantError:   true,
+			wantMessage: `api error: could not connect as migrator with password "[REDACTED]"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockDB := mocks.NewMockDatabasesService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockDB)
+			}
+			ct := NewClusterTool(func(ctx context.Context) (*godo.Client, error) {
+				return &godo.Client{Databases: mockDB}, nil
+			})
+
+			res, err := ct.startOnlineMigration(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantError, res.IsError)
+			assert.Contains(t, getText(res), tc.wantMessage)
+			assert.NotContains(t, getText(res), "hunter2")
+		})
+	}
+}
+
+func TestClusterTool_stopOnlineMigration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDB := mocks.NewMockDatabasesService(ctrl)
+	mockDB.EXPECT().StopOnlineMigration(gomock.Any(), clusterUUID, "mig-1").Return(nil, nil)
+	ct := NewClusterTool(func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{Databases: mockDB}, nil
+	})
+
+	res, err := ct.stopOnlineMigration(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"id": clusterUUID, "migration_id": "mig-1"}}})
+	assert.NoError(t, err)
+	assert.False(t, res.IsError)
+	assert.Contains(t, getText(res), "Online migration stopped successfully")
+
+	res, err = ct.stopOnlineMigration(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"id": clusterUUID}}})
+	assert.NoError(t, err)
+	assert.True(t, res.IsError)
+	assert.Contains(t, getText(res), "migration_id is required")
+}
+
+func TestClusterTool_getOnlineMigrationStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDB := mocks.NewMockDatabasesService(ctrl)
+	mockDB.EXPECT().GetOnlineMigrationStatus(gomock.Any(), clusterUUID).
+		Return(&godo.DatabaseOnlineMigrationStatus{ID: "mig-1", Status: "done", CreatedAt: "2026-10-01T00:00:00Z"}, nil, nil)
+	mockDB.EXPECT().GetOnlineMigrationStatus(gomock.Any(), missingClusterUUID).Return(nil, nil, errors.New("not found"))
+	ct := NewClusterTool(func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{Databases: mockDB}, nil
+	})
+
+	res, err := ct.getOnlineMigrationStatus(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"id": clusterUUID}}})
+	assert.NoError(t, err)
+	assert.False(t, res.IsError)
+	assert.Contains(t, getText(res), `"status": "done"`)
+
+	res, err = ct.getOnlineMigrationStatus(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"id": missingClusterUUID}}})
+	assert.NoError(t, err)
+	assert.True(t, res.IsError)
+	assert.Contains(t, getText(res), "api error: not found")
+}
+
+func TestClusterTool_createClusterPrivateNetwork(t *testing.T) {
+	baseArgs := func() map[string]any {
+		return map[string]any{
+			"name":                 "new-cluster",
+			"engine":               "pg",
+			"version":              "16",
+			"region":               "nyc3",
+			"size":                 "db-s-1vcpu-1gb",
+			"num_nodes":            float64(1),
+			"private_network_uuid": "vpc-1",
+		}
+	}
+
+	tests := []struct {
+		name        string
+		mockSetup   func(db *mocks.MockDatabasesService, vpcs *mocks.MockVPCsService)
+		expectText  string
+		expectError bool
+	}{
+		{
+			name: "places the cluster in the VPC",
+			mockSetup: func(db *mocks.MockDatabasesService, vpcs *mocks.MockVPCsService) {
+				vpcs.EXPECT().Get(gomock.Any(), "vpc-1").Return(&godo.VPC{ID: "vpc-1", RegionSlug: "nyc3"}, nil, nil)
+				db.EXPECT().Create(gomock.Any(), &godo.DatabaseCreateRequest{
+					Name:               "new-cluster",
+					EngineSlug:         "pg",
+					Version:            "16",
+					Region:             "nyc3",
+					SizeSlug:           "db-s-1vcpu-1gb",
+					NumNodes:           1,
+					Tags:               []string{},
+					PrivateNetworkUUID: "vpc-1",
+				}).Return(&godo.Database{ID: "db-1", Name: "new-cluster", PrivateNetworkUUID: "vpc-1"}, nil, nil)
+			},
+			expectText: `"private_network_uuid": "vpc-1"`,
+		},
+		{
+			name: "unknown VPC fails before creating",
+			mockSetup: func(db *mocks.MockDatabasesService, vpcs *mocks.MockVPCsService) {
+				vpcs.EXPECT().Get(gomock.Any(), "vpc-1").Return(nil, nil, errors.New("vpc not found"))
+			},
+			expectText:  "private_network_uuid vpc-1: vpc not found",
+			expectError: true,
+		},
+		{
+			name: "VPC in another region fails before creating",
+			mockSetup: func(db *mocks.MockDatabasesService, vpcs *mocks.MockVPCsService) {
+				vpcs.EXPECT().Get(gomock.Any(), "vpc-1").Return(&godo.VPC{ID: "vpc-1", RegionSlug: "ams3"}, nil, nil)
+			},
+			expectText:  "VPC vpc-1 is in region ams3, but the cluster is being created in nyc3",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockDB := mocks.NewMockDatabasesService(ctrl)
+			mockVPCs := mocks.NewMockVPCsService(ctrl)
+			tc.mockSetup(mockDB, mockVPCs)
+			ct := &ClusterTool{client: func(ctx context.Context) (*godo.Client, error) {
+				return &godo.Client{Databases: mockDB, VPCs: mockVPCs}, nil
+			}}
+
+			res, err := ct.createCluster(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: baseArgs()}})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectError, res.IsError)
+			assert.Contains(t, getText(res), tc.expectText)
+		})
+	}
 }
@@ -8,15 +8,20 @@ import (
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"mcp-digitalocean/pkg/registry/common"
 )
 
 type FirewallTool struct {
-	client func(ctx context.Context) (*godo.Client, error)
+	common.ToolLogger
+
+	client   func(ctx context.Context) (*godo.Client, error)
+	clusters *clusterResolver
 }
 
 func NewFirewallTool(client func(ctx context.Context) (*godo.Client, error)) *FirewallTool {
 	return &FirewallTool{
-		client: client,
+		client:   client,
+		clusters: sharedClusterResolver,
 	}
 }
 
@@ -31,10 +36,14 @@ func (s *FirewallTool) getFirewallRules(ctx context.Context, req mcp.CallToolReq
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	rules, _, err := client.Databases.GetFirewallRules(ctx, id)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.GetErrorResult(ctx, s.Logger(ctx), "api error", "cluster", id, err), nil
 	}
 
 	jsonRules, err := json.MarshalIndent(rules, "", "  ")
@@ -82,28 +91,82 @@ func (s *FirewallTool) updateFirewallRules(ctx context.Context, req mcp.CallTool
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 	_, err = client.Databases.UpdateFirewallRules(ctx, id, updateReq)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, s.Logger(ctx), "api error", err), nil
 	}
 
 	return mcp.NewToolResultText("Firewall rules updated successfully"), nil
 }
 
+// updatePublicAccess turns public access to a cluster on or off. Managed
+// databases have no separate switch for this: a cluster with no trusted
+// sources accepts connections from anywhere, so access is restricted by
+// replacing the trusted sources with the cluster's VPC IP range.
+func (s *FirewallTool) updatePublicAccess(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	id, ok := args["id"].(string)
+	if !ok || id == "" {
+		return mcp.NewToolResultError("Cluster id is required"), nil
+	}
+	enabled, ok := args["enabled"].(bool)
+	if !ok {
+		return mcp.NewToolResultError("enabled is required"), nil
+	}
+
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	rules := []*godo.DatabaseFirewallRule{}
+	message := "Public access enabled: the cluster's trusted sources were removed, so it accepts connections from any address"
+	if !enabled {
+		cluster, _, err := client.Databases.Get(ctx, id)
+		if err != nil {
+			return common.ErrorResult(ctx, s.Logger(ctx), "api error", err), nil
+		}
+		if cluster.PrivateNetworkUUID == "" {
+			return mcp.NewToolResultError(fmt.Sprintf("cluster %s is not in a VPC, so access cannot be limited to one", id)), nil
+		}
+		vpc, _, err := client.VPCs.Get(ctx, cluster.PrivateNetworkUUID)
+		if err != nil {
+			return common.ErrorResult(ctx, s.Logger(ctx), "api error", err), nil
+		}
+		rules = append(rules, &godo.DatabaseFirewallRule{Type: "ip_addr", Value: vpc.IPRange})
+		message = fmt.Sprintf("Public access disabled: the cluster's only trusted source is now VPC %s (%s)", vpc.ID, vpc.IPRange)
+	}
+
+	_, err = client.Databases.UpdateFirewallRules(ctx, id, &godo.DatabaseUpdateFirewallRulesRequest{Rules: rules})
+	if err != nil {
+		return common.ErrorResult(ctx, s.Logger(ctx), "api error", err), nil
+	}
+
+	return mcp.NewToolResultText(message), nil
+}
+
 func (s *FirewallTool) Tools() []server.ServerTool {
 	return []server.ServerTool{
 		{
 			Handler: s.getFirewallRules,
 			Tool: mcp.NewTool("db-cluster-get-firewall-rules",
 				mcp.WithDescription("Get firewall rules for a database cluster."),
-				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID or name")),
 			),
 		},
 		{
 			Handler: s.updateFirewallRules,
 			Tool: mcp.NewTool("db-cluster-update-firewall-rules",
 				mcp.WithDescription("Update firewall rules for a cluster using a structured list of rules."),
-				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID or name")),
 				mcp.WithArray("rules",
 					mcp.Items(map[string]any{
 						"type": "object",
@@ -129,5 +192,13 @@ func (s *FirewallTool) Tools() []server.ServerTool {
 				),
 			),
 		},
+		{
+			Handler: s.updatePublicAccess,
+			Tool: mcp.NewTool("db-cluster-update-public-access",
+				mcp.WithDescription("Turn public access to a database cluster on or off. Managed databases have no separate switch, so this replaces the cluster's trusted sources: disabling limits them to the IP range of the cluster's VPC, and enabling removes them all so any address can connect. Existing trusted sources are replaced either way; use db-cluster-update-firewall-rules for finer control."),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID or name")),
+				mcp.WithBoolean("enabled", mcp.Required(), mcp.Description("true to accept connections from any address, false to accept them only from the cluster's VPC")),
+			),
+		},
 	}
 }
@@ -15,7 +15,7 @@ func TestFirewallTool_getFirewallRules(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockDB := mocks.NewMockDatabasesService(ctrl)
-	mockDB.EXPECT().GetFirewallRules(gomock.Any(), "cid").Return([]godo.DatabaseFirewallRule{{UUID: "rule1"}}, nil, nil)
+	mockDB.EXPECT().GetFirewallRules(gomock.Any(), clusterUUID).Return([]godo.DatabaseFirewallRule{{UUID: "rule1"}}, nil, nil)
 
 	client := func(ctx context.Context) (*godo.Client, error) {
 		return &godo.Client{
@@ -24,7 +24,7 @@ func TestFirewallTool_getFirewallRules(t *testing.T) {
 	}
 
 	ft := &FirewallTool{client: client}
-	args := map[string]interface{}{"id": "cid"}
+	args := map[string]interface{}{"id": clusterUUID}
 	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err := ft.getFirewallRules(context.Background(), req)
 	assert.NoError(t, err)
@@ -40,7 +40,7 @@ func TestFirewallTool_updateFirewallRules(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockDB := mocks.NewMockDatabasesService(ctrl)
-	mockDB.EXPECT().UpdateFirewallRules(gomock.Any(), "cid", gomock.Any()).Return(nil, nil)
+	mockDB.EXPECT().UpdateFirewallRules(gomock.Any(), clusterUUID, gomock.Any()).Return(nil, nil)
 
 	client := func(ctx context.Context) (*godo.Client, error) {
 		return &godo.Client{
@@ -52,15 +52,81 @@ func TestFirewallTool_updateFirewallRules(t *testing.T) {
 	rules := []any{
 		map[string]any{"uuid": "rule2"},
 	}
-	args := map[string]interface{}{"id": "cid", "rules": rules}
+	args := map[string]interface{}{"id": clusterUUID, "rules": rules}
 	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err := ft.updateFirewallRules(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "Firewall rules updated successfully")
 	// Error case: missing rules
-	args = map[string]interface{}{"id": "cid"}
+	args = map[string]interface{}{"id": clusterUUID}
 	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err = ft.updateFirewallRules(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "Missing or invalid 'rules' array object")
 }
+
+func TestFirewallTool_updatePublicAccess(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(db *mocks.MockDatabasesService, vpcs *mocks.MockVPCsService)
+		expectText  string
+		expectError bool
+	}{
+		{
+			name: "disable limits trusted sources to the VPC",
+			args: map[string]any{"id": clusterUUID, "enabled": false},
+			mockSetup: func(db *mocks.MockDatabasesService, vpcs *mocks.MockVPCsService) {
+				db.EXPECT().Get(gomock.Any(), clusterUUID).Return(&godo.Database{ID: clusterUUID, PrivateNetworkUUID: "vpc-1"}, nil, nil)
+				vpcs.EXPECT().Get(gomock.Any(), "vpc-1").Return(&godo.VPC{ID: "vpc-1", IPRange: "10.10.0.0/20"}, nil, nil)
+				db.EXPECT().UpdateFirewallRules(gomock.Any(), clusterUUID, &godo.DatabaseUpdateFirewallRulesRequest{
+					Rules: []*godo.DatabaseFirewallRule{{Type: "ip_addr", Value: "10.10.0.0/20"}},
+				}).Return(nil, nil)
+			},
+			expectText: "only trusted source is now VPC vpc-1 (10.10.0.0/20)",
+		},
+		{
+			name: "enable removes trusted sources",
+			args: map[string]any{"id": clusterUUID, "enabled": true},
+			mockSetup: func(db *mocks.MockDatabasesService, vpcs *mocks.MockVPCsService) {
+				db.EXPECT().UpdateFirewallRules(gomock.Any(), clusterUUID, &godo.DatabaseUpdateFirewallRulesRequest{
+					Rules: []*godo.DatabaseFirewallRule{},
+				}).Return(nil, nil)
+			},
+			expectText: "Public access enabled",
+		},
+		{
+			name: "cluster outside a VPC",
+			args: map[string]any{"id": clusterUUID, "enabled": false},
+			mockSetup: func(db *mocks.MockDatabasesService, vpcs *mocks.MockVPCsService) {
+				db.EXPECT().Get(gomock.Any(), clusterUUID).Return(&godo.Database{ID: clusterUUID}, nil, nil)
+			},
+			expectText:  "cluster 9cc10173-e9ea-4176-9dbc-a4cee4c4ff30 is not in a VPC",
+			expectError: true,
+		},
+		{
+			name:        "missing enabled",
+			args:        map[string]any{"id": clusterUUID},
+			mockSetup:   func(db *mocks.MockDatabasesService, vpcs *mocks.MockVPCsService) {},
+			expectText:  "enabled is required",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockDB := mocks.NewMockDatabasesService(ctrl)
+			mockVPCs := mocks.NewMockVPCsService(ctrl)
+			tc.mockSetup(mockDB, mockVPCs)
+			ft := &FirewallTool{client: func(ctx context.Context) (*godo.Client, error) {
+				return &godo.Client{Databases: mockDB, VPCs: mockVPCs}, nil
+			}}
+
+			res, err := ft.updatePublicAccess(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectError, res.IsError)
+			assert.Contains(t, res.Content[0].(mcp.TextContent).Text, tc.expectText)
+		})
+	}
+}
@@ -1,3 +1,4 @@
 package dbaas
 
 //go:generate mockgen -destination=./mocks/databases_service_mock.go -package=mocks github.com/digitalocean/godo DatabasesService
+//go:generate mockgen -destination=./mocks/vpcs_service_mock.go -package=mocks github.com/digitalocean/godo VPCsService
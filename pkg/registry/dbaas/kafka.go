@@ -10,14 +10,18 @@ import (
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"mcp-digitalocean/pkg/registry/common"
 )
 
 type KafkaTool struct {
-	client func(ctx context.Context) (*godo.Client, error)
+	common.ToolLogger
+
+	client   func(ctx context.Context) (*godo.Client, error)
+	clusters *clusterResolver
 }
 
 func NewKafkaTool(client func(ctx context.Context) (*godo.Client, error)) *KafkaTool {
-	return &KafkaTool{client: client}
+	return &KafkaTool{client: client, clusters: sharedClusterResolver}
 }
 
 func (s *KafkaTool) getKafkaConfig(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -30,9 +34,13 @@ func (s *KafkaTool) getKafkaConfig(ctx context.Context, req mcp.CallToolRequest)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 	cfg, _, err := client.Databases.GetKafkaConfig(ctx, id)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.GetErrorResult(ctx, s.Logger(ctx), "api error", "cluster", id, err), nil
 	}
 	jsonCfg, err := json.MarshalIndent(cfg, "", "  ")
 	if err != nil {
@@ -64,9 +72,13 @@ func (s *KafkaTool) updateKafkaConfig(ctx context.Context, req mcp.CallToolReque
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 	_, err = client.Databases.UpdateKafkaConfig(ctx, id, &config)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, s.Logger(ctx), "api error", err), nil
 	}
 	return mcp.NewToolResultText("Kafka config updated successfully"), nil
 }
@@ -115,9 +127,13 @@ func (s *KafkaTool) listTopics(ctx context.Context, req mcp.CallToolRequest) (*m
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 	topics, _, err := client.Databases.ListTopics(ctx, id, opts)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, s.Logger(ctx), "api error", err), nil
 	}
 	jsonTopics, err := json.MarshalIndent(topics, "", "  ")
 	if err != nil {
@@ -172,9 +188,13 @@ func (s *KafkaTool) createTopic(ctx context.Context, req mcp.CallToolRequest) (*
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 	topic, _, err := client.Databases.CreateTopic(ctx, id, createReq)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, s.Logger(ctx), "api error", err), nil
 	}
 	jsonTopic, err := json.MarshalIndent(topic, "", "  ")
 	if err != nil {
@@ -197,9 +217,13 @@ func (s *KafkaTool) getTopic(ctx context.Context, req mcp.CallToolRequest) (*mcp
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 	topic, _, err := client.Databases.GetTopic(ctx, id, name)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.GetErrorResult(ctx, s.Logger(ctx), "api error", "topic", id+"/"+name, err), nil
 	}
 	jsonTopic, err := json.MarshalIndent(topic, "", "  ")
 	if err != nil {
@@ -222,9 +246,13 @@ func (s *KafkaTool) deleteTopic(ctx context.Context, req mcp.CallToolRequest) (*
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 	_, err = client.Databases.DeleteTopic(ctx, id, name)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, s.Logger(ctx), "api error", err), nil
 	}
 	return mcp.NewToolResultText("Topic deleted successfully"), nil
 }
@@ -274,9 +302,13 @@ func (s *KafkaTool) updateTopic(ctx context.Context, req mcp.CallToolRequest) (*
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 	_, err = client.Databases.UpdateTopic(ctx, id, name, updateReq)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, s.Logger(ctx), "api error", err), nil
 	}
 	return mcp.NewToolResultText("Topic updated successfully"), nil
 }
@@ -287,7 +319,7 @@ func (s *KafkaTool) Tools() []server.ServerTool {
 			Handler: s.listTopics,
 			Tool: mcp.NewTool("db-cluster-list-topics",
 				mcp.WithDescription("List topics for a Kafka cluster by its ID. Supports pagination and filtering."),
-				mcp.WithString("id", mcp.Required(), mcp.Description("The Kafka cluster UUID")),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The Kafka cluster UUID or name")),
 				mcp.WithString("page", mcp.Description("Page number (string)")),
 				mcp.WithNumber("per_page", mcp.Description("Number of results per page (integer)")),
 				mcp.WithString("with_projects", mcp.Description("Include project field (bool as string)")),
@@ -300,7 +332,7 @@ func (s *KafkaTool) Tools() []server.ServerTool {
 			Handler: s.createTopic,
 			Tool: mcp.NewTool("db-cluster-create-topic",
 				mcp.WithDescription("Create a topic for a Kafka cluster."),
-				mcp.WithString("id", mcp.Required(), mcp.Description("Kafka cluster UUID")),
+				mcp.WithString("id", mcp.Required(), mcp.Description("Kafka cluster UUID or name")),
 				mcp.WithString("name", mcp.Required(), mcp.Description("Topic name")),
 				mcp.WithString("partition_count", mcp.Description("Number of partitions")),
 				mcp.WithString("replication_factor", mcp.Description("Replication factor")),
@@ -337,7 +369,7 @@ func (s *KafkaTool) Tools() []server.ServerTool {
 			Handler: s.getTopic,
 			Tool: mcp.NewTool("db-cluster-get-topic",
 				mcp.WithDescription("Get a Kafka topic by name."),
-				mcp.WithString("id", mcp.Required(), mcp.Description("Kafka cluster UUID")),
+				mcp.WithString("id", mcp.Required(), mcp.Description("Kafka cluster UUID or name")),
 				mcp.WithString("name", mcp.Required(), mcp.Description("Topic name")),
 			),
 		},
@@ -345,7 +377,7 @@ func (s *KafkaTool) Tools() []server.ServerTool {
 			Handler: s.deleteTopic,
 			Tool: mcp.NewTool("db-cluster-delete-topic",
 				mcp.WithDescription("Delete a Kafka topic by name."),
-				mcp.WithString("id", mcp.Required(), mcp.Description("Kafka cluster UUID")),
+				mcp.WithString("id", mcp.Required(), mcp.Description("Kafka cluster UUID or name")),
 				mcp.WithString("name", mcp.Required(), mcp.Description("Topic name")),
 			),
 		},
@@ -353,7 +385,7 @@ func (s *KafkaTool) Tools() []server.ServerTool {
 			Handler: s.updateTopic,
 			Tool: mcp.NewTool("db-cluster-update-topic",
 				mcp.WithDescription("Update a Kafka topic's partition count, replication factor, or config."),
-				mcp.WithString("id", mcp.Required(), mcp.Description("Kafka cluster UUID")),
+				mcp.WithString("id", mcp.Required(), mcp.Description("Kafka cluster UUID or name")),
 				mcp.WithString("name", mcp.Required(), mcp.Description("Topic name")),
 				mcp.WithString("partition_count", mcp.Description("Number of partitions")),
 				mcp.WithString("replication_factor", mcp.Description("Replication factor")),
@@ -390,14 +422,14 @@ func (s *KafkaTool) Tools() []server.ServerTool {
 			Handler: s.getKafkaConfig,
 			Tool: mcp.NewTool("db-cluster-get-kafka-config",
 				mcp.WithDescription("Get the Kafka config for a cluster."),
-				mcp.WithString("id", mcp.Required(), mcp.Description("Kafka cluster UUID")),
+				mcp.WithString("id", mcp.Required(), mcp.Description("Kafka cluster UUID or name")),
 			),
 		},
 		{
 			Handler: s.updateKafkaConfig,
 			Tool: mcp.NewTool("db-cluster-update-kafka-config",
 				mcp.WithDescription("Update the Kafka cluster configuration."),
-				mcp.WithString("id", mcp.Required(), mcp.Description("Kafka cluster UUID")),
+				mcp.WithString("id", mcp.Required(), mcp.Description("Kafka cluster UUID or name")),
 				mcp.WithObject("config",
 					mcp.Required(),
 					mcp.Description("Kafka configuration object"),
@@ -15,7 +15,7 @@ func TestKafkaTool_getKafkaConfig(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockDB := mocks.NewMockDatabasesService(ctrl)
-	mockDB.EXPECT().GetKafkaConfig(gomock.Any(), "cid").Return(&godo.KafkaConfig{}, nil, nil)
+	mockDB.EXPECT().GetKafkaConfig(gomock.Any(), clusterUUID).Return(&godo.KafkaConfig{}, nil, nil)
 
 	client := func(ctx context.Context) (*godo.Client, error) {
 		return &godo.Client{
@@ -24,7 +24,7 @@ func TestKafkaTool_getKafkaConfig(t *testing.T) {
 	}
 
 	kt := &KafkaTool{client: client}
-	args := map[string]interface{}{"id": "cid"}
+	args := map[string]interface{}{"id": clusterUUID}
 	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err := kt.getKafkaConfig(context.Background(), req)
 	assert.NoError(t, err)
@@ -40,7 +40,7 @@ func TestKafkaTool_updateKafkaConfig(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockDB := mocks.NewMockDatabasesService(ctrl)
-	mockDB.EXPECT().UpdateKafkaConfig(gomock.Any(), "cid", gomock.Any()).Return(nil, nil)
+	mockDB.EXPECT().UpdateKafkaConfig(gomock.Any(), clusterUUID, gomock.Any()).Return(nil, nil)
 
 	client := func(ctx context.Context) (*godo.Client, error) {
 		return &godo.Client{
@@ -50,13 +50,13 @@ func TestKafkaTool_updateKafkaConfig(t *testing.T) {
 
 	kt := &KafkaTool{client: client}
 	cfg := map[string]any{}
-	args := map[string]interface{}{"id": "cid", "config": cfg}
+	args := map[string]interface{}{"id": clusterUUID, "config": cfg}
 	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err := kt.updateKafkaConfig(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "Kafka config updated successfully")
 	// Error case: missing config
-	args = map[string]interface{}{"id": "cid"}
+	args = map[string]interface{}{"id": clusterUUID}
 	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err = kt.updateKafkaConfig(context.Background(), req)
 	assert.NoError(t, err)
@@ -67,7 +67,7 @@ func TestKafkaTool_listTopics(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockDB := mocks.NewMockDatabasesService(ctrl)
-	mockDB.EXPECT().ListTopics(gomock.Any(), "cid", gomock.Any()).Return([]godo.DatabaseTopic{{Name: "topic1"}}, nil, nil)
+	mockDB.EXPECT().ListTopics(gomock.Any(), clusterUUID, gomock.Any()).Return([]godo.DatabaseTopic{{Name: "topic1"}}, nil, nil)
 
 	client := func(ctx context.Context) (*godo.Client, error) {
 		return &godo.Client{
@@ -76,7 +76,7 @@ func TestKafkaTool_listTopics(t *testing.T) {
 	}
 
 	kt := &KafkaTool{client: client}
-	args := map[string]interface{}{"id": "cid"}
+	args := map[string]interface{}{"id": clusterUUID}
 	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err := kt.listTopics(context.Background(), req)
 	assert.NoError(t, err)
@@ -92,7 +92,7 @@ func TestKafkaTool_createTopic(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockDB := mocks.NewMockDatabasesService(ctrl)
-	mockDB.EXPECT().CreateTopic(gomock.Any(), "cid", gomock.Any()).Return(&godo.DatabaseTopic{Name: "topic2"}, nil, nil)
+	mockDB.EXPECT().CreateTopic(gomock.Any(), clusterUUID, gomock.Any()).Return(&godo.DatabaseTopic{Name: "topic2"}, nil, nil)
 
 	client := func(ctx context.Context) (*godo.Client, error) {
 		return &godo.Client{
@@ -101,13 +101,13 @@ func TestKafkaTool_createTopic(t *testing.T) {
 	}
 
 	kt := &KafkaTool{client: client}
-	args := map[string]interface{}{"id": "cid", "name": "topic2"}
+	args := map[string]interface{}{"id": clusterUUID, "name": "topic2"}
 	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err := kt.createTopic(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "topic2")
 	// Error case: missing name
-	args = map[string]interface{}{"id": "cid"}
+	args = map[string]interface{}{"id": clusterUUID}
 	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err = kt.createTopic(context.Background(), req)
 	assert.NoError(t, err)
@@ -118,7 +118,7 @@ func TestKafkaTool_getTopic(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockDB := mocks.NewMockDatabasesService(ctrl)
-	mockDB.EXPECT().GetTopic(gomock.Any(), "cid", "topic3").Return(&godo.DatabaseTopic{Name: "topic3"}, nil, nil)
+	mockDB.EXPECT().GetTopic(gomock.Any(), clusterUUID, "topic3").Return(&godo.DatabaseTopic{Name: "topic3"}, nil, nil)
 
 	client := func(ctx context.Context) (*godo.Client, error) {
 		return &godo.Client{
@@ -127,13 +127,13 @@ func TestKafkaTool_getTopic(t *testing.T) {
 	}
 
 	kt := &KafkaTool{client: client}
-	args := map[string]interface{}{"id": "cid", "name": "topic3"}
+	args := map[string]interface{}{"id": clusterUUID, "name": "topic3"}
 	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err := kt.getTopic(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "topic3")
 	// Error case: missing name
-	args = map[string]interface{}{"id": "cid"}
+	args = map[string]interface{}{"id": clusterUUID}
 	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err = kt.getTopic(context.Background(), req)
 	assert.NoError(t, err)
@@ -144,7 +144,7 @@ func TestKafkaTool_deleteTopic(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockDB := mocks.NewMockDatabasesService(ctrl)
-	mockDB.EXPECT().DeleteTopic(gomock.Any(), "cid", "topic4").Return(nil, nil)
+	mockDB.EXPECT().DeleteTopic(gomock.Any(), clusterUUID, "topic4").Return(nil, nil)
 
 	client := func(ctx context.Context) (*godo.Client, error) {
 		return &godo.Client{
@@ -153,13 +153,13 @@ func TestKafkaTool_deleteTopic(t *testing.T) {
 	}
 
 	kt := &KafkaTool{client: client}
-	args := map[string]interface{}{"id": "cid", "name": "topic4"}
+	args := map[string]interface{}{"id": clusterUUID, "name": "topic4"}
 	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err := kt.deleteTopic(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "Topic deleted successfully")
 	// Error case: missing name
-	args = map[string]interface{}{"id": "cid"}
+	args = map[string]interface{}{"id": clusterUUID}
 	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err = kt.deleteTopic(context.Background(), req)
 	assert.NoError(t, err)
@@ -170,7 +170,7 @@ func TestKafkaTool_updateTopic(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockDB := mocks.NewMockDatabasesService(ctrl)
-	mockDB.EXPECT().UpdateTopic(gomock.Any(), "cid", "topic5", gomock.Any()).Return(nil, nil)
+	mockDB.EXPECT().UpdateTopic(gomock.Any(), clusterUUID, "topic5", gomock.Any()).Return(nil, nil)
 
 	client := func(ctx context.Context) (*godo.Client, error) {
 		return &godo.Client{
@@ -179,13 +179,13 @@ func TestKafkaTool_updateTopic(t *testing.T) {
 	}
 
 	kt := &KafkaTool{client: client}
-	args := map[string]interface{}{"id": "cid", "name": "topic5"}
+	args := map[string]interface{}{"id": clusterUUID, "name": "topic5"}
 	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err := kt.updateTopic(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "Topic updated successfully")
 	// Error case: missing name
-	args = map[string]interface{}{"id": "cid"}
+	args = map[string]interface{}{"id": clusterUUID}
 	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err = kt.updateTopic(context.Background(), req)
 	assert.NoError(t, err)
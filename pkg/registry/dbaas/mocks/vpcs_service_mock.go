@@ -0,0 +1,285 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/digitalocean/godo (interfaces: VPCsService)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/vpcs_service_mock.go -package=mocks github.com/digitalocean/godo VPCsService
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	godo "github.com/digitalocean/godo"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockVPCsService is a mock of VPCsService interface.
+type MockVPCsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockVPCsServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockVPCsServiceMockRecorder is the mock recorder for MockVPCsService.
+type MockVPCsServiceMockRecorder struct {
+	mock *MockVPCsService
+}
+
+// NewMockVPCsService creates a new mock instance.
+func NewMockVPCsService(ctrl *gomock.Controller) *MockVPCsService {
+	mock := &MockVPCsService{ctrl: ctrl}
+	mock.recorder = &MockVPCsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVPCsService) EXPECT() *MockVPCsServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockVPCsService) Create(arg0 context.Context, arg1 *godo.VPCCreateRequest) (*godo.VPC, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*godo.VPC)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockVPCsServiceMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockVPCsService)(nil).Create), arg0, arg1)
+}
+
+// CreateVPCPeering mocks base method.
+func (m *MockVPCsService) CreateVPCPeering(arg0 context.Context, arg1 *godo.VPCPeeringCreateRequest) (*godo.VPCPeering, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVPCPeering", arg0, arg1)
+	ret0, _ := ret[0].(*godo.VPCPeering)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateVPCPeering indicates an expected call of CreateVPCPeering.
+func (mr *MockVPCsServiceMockRecorder) CreateVPCPeering(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVPCPeering", reflect.TypeOf((*MockVPCsService)(nil).CreateVPCPeering), arg0, arg1)
+}
+
+// CreateVPCPeeringByVPCID mocks base method.
+func (m *MockVPCsService) CreateVPCPeeringByVPCID(arg0 context.Context, arg1 string, arg2 *godo.VPCPeeringCreateRequestByVPCID) (*godo.VPCPeering, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVPCPeeringByVPCID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.VPCPeering)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateVPCPeeringByVPCID indicates an expected call of CreateVPCPeeringByVPCID.
+func (mr *MockVPCsServiceMockRecorder) CreateVPCPeeringByVPCID(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVPCPeeringByVPCID", reflect.TypeOf((*MockVPCsService)(nil).CreateVPCPeeringByVPCID), arg0, arg1, arg2)
+}
+
+// Delete mocks base method.
+func (m *MockVPCsService) Delete(arg0 context.Context, arg1 string) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockVPCsServiceMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockVPCsService)(nil).Delete), arg0, arg1)
+}
+
+// DeleteVPCPeering mocks base method.
+func (m *MockVPCsService) DeleteVPCPeering(arg0 context.Context, arg1 string) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVPCPeering", arg0, arg1)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteVPCPeering indicates an expected call of DeleteVPCPeering.
+func (mr *MockVPCsServiceMockRecorder) DeleteVPCPeering(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVPCPeering", reflect.TypeOf((*MockVPCsService)(nil).DeleteVPCPeering), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockVPCsService) Get(arg0 context.Context, arg1 string) (*godo.VPC, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*godo.VPC)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockVPCsServiceMockRecorder) Get(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockVPCsService)(nil).Get), arg0, arg1)
+}
+
+// GetVPCPeering mocks base method.
+func (m *MockVPCsService) GetVPCPeering(arg0 context.Context, arg1 string) (*godo.VPCPeering, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVPCPeering", arg0, arg1)
+	ret0, _ := ret[0].(*godo.VPCPeering)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetVPCPeering indicates an expected call of GetVPCPeering.
+func (mr *MockVPCsServiceMockRecorder) GetVPCPeering(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVPCPeering", reflect.TypeOf((*MockVPCsService)(nil).GetVPCPeering), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockVPCsService) List(arg0 context.Context, arg1 *godo.ListOptions) ([]*godo.VPC, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].([]*godo.VPC)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockVPCsServiceMockRecorder) List(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockVPCsService)(nil).List), arg0, arg1)
+}
+
+// ListMembers mocks base method.
+func (m *MockVPCsService) ListMembers(arg0 context.Context, arg1 string, arg2 *godo.VPCListMembersRequest, arg3 *godo.ListOptions) ([]*godo.VPCMember, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMembers", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*godo.VPCMember)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListMembers indicates an expected call of ListMembers.
+func (mr *MockVPCsServiceMockRecorder) ListMembers(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMembers", reflect.TypeOf((*MockVPCsService)(nil).ListMembers), arg0, arg1, arg2, arg3)
+}
+
+// ListVPCPeerings mocks base method.
+func (m *MockVPCsService) ListVPCPeerings(arg0 context.Context, arg1 *godo.ListOptions) ([]*godo.VPCPeering, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVPCPeerings", arg0, arg1)
+	ret0, _ := ret[0].([]*godo.VPCPeering)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListVPCPeerings indicates an expected call of ListVPCPeerings.
+func (mr *MockVPCsServiceMockRecorder) ListVPCPeerings(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVPCPeerings", reflect.TypeOf((*MockVPCsService)(nil).ListVPCPeerings), arg0, arg1)
+}
+
+// ListVPCPeeringsByVPCID mocks base method.
+func (m *MockVPCsService) ListVPCPeeringsByVPCID(arg0 context.Context, arg1 string, arg2 *godo.ListOptions) ([]*godo.VPCPeering, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVPCPeeringsByVPCID", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*godo.VPCPeering)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListVPCPeeringsByVPCID indicates an expected call of ListVPCPeeringsByVPCID.
+func (mr *MockVPCsServiceMockRecorder) ListVPCPeeringsByVPCID(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVPCPeeringsByVPCID", reflect.TypeOf((*MockVPCsService)(nil).ListVPCPeeringsByVPCID), arg0, arg1, arg2)
+}
+
+// Set mocks base method.
+func (m *MockVPCsService) Set(arg0 context.Context, arg1 string, arg2 ...godo.VPCSetField) (*godo.VPC, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Set", varargs...)
+	ret0, _ := ret[0].(*godo.VPC)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockVPCsServiceMockRecorder) Set(arg0, arg1 any, arg2 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockVPCsService)(nil).Set), varargs...)
+}
+
+// Update mocks base method.
+func (m *MockVPCsService) Update(arg0 context.Context, arg1 string, arg2 *godo.VPCUpdateRequest) (*godo.VPC, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.VPC)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockVPCsServiceMockRecorder) Update(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockVPCsService)(nil).Update), arg0, arg1, arg2)
+}
+
+// UpdateVPCPeering mocks base method.
+func (m *MockVPCsService) UpdateVPCPeering(arg0 context.Context, arg1 string, arg2 *godo.VPCPeeringUpdateRequest) (*godo.VPCPeering, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVPCPeering", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.VPCPeering)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateVPCPeering indicates an expected call of UpdateVPCPeering.
+func (mr *MockVPCsServiceMockRecorder) UpdateVPCPeering(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVPCPeering", reflect.TypeOf((*MockVPCsService)(nil).UpdateVPCPeering), arg0, arg1, arg2)
+}
+
+// UpdateVPCPeeringByVPCID mocks base method.
+func (m *MockVPCsService) UpdateVPCPeeringByVPCID(arg0 context.Context, arg1, arg2 string, arg3 *godo.VPCPeeringUpdateRequest) (*godo.VPCPeering, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVPCPeeringByVPCID", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*godo.VPCPeering)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateVPCPeeringByVPCID indicates an expected call of UpdateVPCPeeringByVPCID.
+func (mr *MockVPCsServiceMockRecorder) UpdateVPCPeeringByVPCID(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVPCPeeringByVPCID", reflect.TypeOf((*MockVPCsService)(nil).UpdateVPCPeeringByVPCID), arg0, arg1, arg2, arg3)
+}
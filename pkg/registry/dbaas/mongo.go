@@ -8,15 +8,20 @@ import (
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"mcp-digitalocean/pkg/registry/common"
 )
 
 type MongoTool struct {
-	client func(ctx context.Context) (*godo.Client, error)
+	common.ToolLogger
+
+	client   func(ctx context.Context) (*godo.Client, error)
+	clusters *clusterResolver
 }
 
 func NewMongoTool(client func(ctx context.Context) (*godo.Client, error)) *MongoTool {
 	return &MongoTool{
-		client: client,
+		client:   client,
+		clusters: sharedClusterResolver,
 	}
 }
 
@@ -30,9 +35,13 @@ func (s *MongoTool) getMongoDBConfig(ctx context.Context, req mcp.CallToolReques
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 	cfg, _, err := client.Databases.GetMongoDBConfig(ctx, id)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.GetErrorResult(ctx, s.Logger(ctx), "api error", "cluster", id, err), nil
 	}
 	jsonCfg, err := json.MarshalIndent(cfg, "", "  ")
 	if err != nil {
@@ -67,9 +76,13 @@ func (s *MongoTool) updateMongoDBConfig(ctx context.Context, req mcp.CallToolReq
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 	_, err = client.Databases.UpdateMongoDBConfig(ctx, id, &config)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, s.Logger(ctx), "api error", err), nil
 	}
 
 	return mcp.NewToolResultText("MongoDB config updated successfully"), nil
@@ -81,14 +94,14 @@ func (s *MongoTool) Tools() []server.ServerTool {
 			Handler: s.getMongoDBConfig,
 			Tool: mcp.NewTool("db-cluster-get-mongodb-config",
 				mcp.WithDescription("Get the MongoDB config for a cluster by its id"),
-				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID or name")),
 			),
 		},
 		{
 			Handler: s.updateMongoDBConfig,
 			Tool: mcp.NewTool("db-cluster-update-mongodb-config",
 				mcp.WithDescription("Update the MongoDB config for a cluster by its id. Accepts a structured config object."),
-				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID or name")),
 				mcp.WithObject("config",
 					mcp.Required(),
 					mcp.Description("Configuration parameters for MongoDB"),
@@ -15,7 +15,7 @@ func TestMongoTool_getMongoDBConfig(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockDB := mocks.NewMockDatabasesService(ctrl)
-	mockDB.EXPECT().GetMongoDBConfig(gomock.Any(), "cid").Return(&godo.MongoDBConfig{Verbosity: new(int)}, nil, nil)
+	mockDB.EXPECT().GetMongoDBConfig(gomock.Any(), clusterUUID).Return(&godo.MongoDBConfig{Verbosity: new(int)}, nil, nil)
 
 	client := func(ctx context.Context) (*godo.Client, error) {
 		return &godo.Client{
@@ -24,7 +24,7 @@ func TestMongoTool_getMongoDBConfig(t *testing.T) {
 	}
 
 	mt := &MongoTool{client: client}
-	args := map[string]interface{}{"id": "cid"}
+	args := map[string]interface{}{"id": clusterUUID}
 	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err := mt.getMongoDBConfig(context.Background(), req)
 	assert.NoError(t, err)
@@ -40,7 +40,7 @@ func TestMongoTool_updateMongoDBConfig(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockDB := mocks.NewMockDatabasesService(ctrl)
-	mockDB.EXPECT().UpdateMongoDBConfig(gomock.Any(), "cid", gomock.Any()).Return(nil, nil)
+	mockDB.EXPECT().UpdateMongoDBConfig(gomock.Any(), clusterUUID, gomock.Any()).Return(nil, nil)
 
 	client := func(ctx context.Context) (*godo.Client, error) {
 		return &godo.Client{
@@ -50,13 +50,13 @@ func TestMongoTool_updateMongoDBConfig(t *testing.T) {
 
 	mt := &MongoTool{client: client}
 	cfg := map[string]any{}
-	args := map[string]interface{}{"id": "cid", "config": cfg}
+	args := map[string]interface{}{"id": clusterUUID, "config": cfg}
 	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err := mt.updateMongoDBConfig(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "MongoDB config updated successfully")
 	// Error case: missing config
-	args = map[string]interface{}{"id": "cid"}
+	args = map[string]interface{}{"id": clusterUUID}
 	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err = mt.updateMongoDBConfig(context.Background(), req)
 	assert.NoError(t, err)
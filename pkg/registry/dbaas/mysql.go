@@ -9,15 +9,20 @@ import (
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"mcp-digitalocean/pkg/registry/common"
 )
 
 type MysqlTool struct {
-	client func(ctx context.Context) (*godo.Client, error)
+	common.ToolLogger
+
+	client   func(ctx context.Context) (*godo.Client, error)
+	clusters *clusterResolver
 }
 
 func NewMysqlTool(client func(ctx context.Context) (*godo.Client, error)) *MysqlTool {
 	return &MysqlTool{
-		client: client,
+		client:   client,
+		clusters: sharedClusterResolver,
 	}
 }
 
@@ -31,9 +36,13 @@ func (s *MysqlTool) getMySQLConfig(ctx context.Context, req mcp.CallToolRequest)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 	cfg, _, err := client.Databases.GetMySQLConfig(ctx, id)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.GetErrorResult(ctx, s.Logger(ctx), "api error", "cluster", id, err), nil
 	}
 	jsonCfg, err := json.MarshalIndent(cfg, "", "  ")
 	if err != nil {
@@ -68,9 +77,13 @@ func (s *MysqlTool) updateMySQLConfig(ctx context.Context, req mcp.CallToolReque
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 	_, err = client.Databases.UpdateMySQLConfig(ctx, id, &config)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, s.Logger(ctx), "api error", err), nil
 	}
 	return mcp.NewToolResultText("MySQL config updated successfully"), nil
 }
@@ -85,9 +98,13 @@ func (s *MysqlTool) getSQLMode(ctx context.Context, req mcp.CallToolRequest) (*m
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 	mode, _, err := client.Databases.GetSQLMode(ctx, id)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.GetErrorResult(ctx, s.Logger(ctx), "api error", "cluster", id, err), nil
 	}
 	return mcp.NewToolResultText(mode), nil
 }
@@ -113,9 +130,13 @@ func (s *MysqlTool) setSQLMode(ctx context.Context, req mcp.CallToolRequest) (*m
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 	_, err = client.Databases.SetSQLMode(ctx, id, modes...)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, s.Logger(ctx), "api error", err), nil
 	}
 	return mcp.NewToolResultText("SQL mode set successfully"), nil
 }
@@ -126,14 +147,14 @@ func (s *MysqlTool) Tools() []server.ServerTool {
 			Handler: s.getMySQLConfig,
 			Tool: mcp.NewTool("db-cluster-get-mysql-config",
 				mcp.WithDescription("Get the MySQL config for a cluster by its id"),
-				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID or name")),
 			),
 		},
 		{
 			Handler: s.updateMySQLConfig,
 			Tool: mcp.NewTool("db-cluster-update-mysql-config",
 				mcp.WithDescription("Update the MySQL config for a cluster by its id. Accepts a structured 'config' object."),
-				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID or name")),
 				mcp.WithObject("config",
 					mcp.Required(),
 					mcp.Description("Structured configuration for MySQL"),
@@ -180,14 +201,14 @@ func (s *MysqlTool) Tools() []server.ServerTool {
 			Handler: s.getSQLMode,
 			Tool: mcp.NewTool("db-cluster-get-sql-mode",
 				mcp.WithDescription("Get the SQL mode for a cluster by its id"),
-				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID or name")),
 			),
 		},
 		{
 			Handler: s.setSQLMode,
 			Tool: mcp.NewTool("db-cluster-set-sql-mode",
 				mcp.WithDescription("Set the SQL mode for a cluster by its id"),
-				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID or name")),
 				mcp.WithString("modes", mcp.Required(), mcp.Description("Comma-separated SQL modes to set")),
 			),
 		},
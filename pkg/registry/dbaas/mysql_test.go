@@ -15,7 +15,7 @@ func TestMysqlTool_getMySQLConfig(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockDB := mocks.NewMockDatabasesService(ctrl)
-	mockDB.EXPECT().GetMySQLConfig(gomock.Any(), "cid").Return(&godo.MySQLConfig{SQLMode: new(string)}, nil, nil)
+	mockDB.EXPECT().GetMySQLConfig(gomock.Any(), clusterUUID).Return(&godo.MySQLConfig{SQLMode: new(string)}, nil, nil)
 
 	client := func(ctx context.Context) (*godo.Client, error) {
 		return &godo.Client{
@@ -24,7 +24,7 @@ func TestMysqlTool_getMySQLConfig(t *testing.T) {
 	}
 
 	mt := &MysqlTool{client: client}
-	args := map[string]interface{}{"id": "cid"}
+	args := map[string]interface{}{"id": clusterUUID}
 	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err := mt.getMySQLConfig(context.Background(), req)
 	assert.NoError(t, err)
@@ -40,7 +40,7 @@ func TestMysqlTool_updateMySQLConfig(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockDB := mocks.NewMockDatabasesService(ctrl)
-	mockDB.EXPECT().UpdateMySQLConfig(gomock.Any(), "cid", gomock.Any()).Return(nil, nil)
+	mockDB.EXPECT().UpdateMySQLConfig(gomock.Any(), clusterUUID, gomock.Any()).Return(nil, nil)
 
 	client := func(ctx context.Context) (*godo.Client, error) {
 		return &godo.Client{
@@ -50,13 +50,13 @@ func TestMysqlTool_updateMySQLConfig(t *testing.T) {
 
 	mt := &MysqlTool{client: client}
 	cfg := map[string]any{}
-	args := map[string]interface{}{"id": "cid", "config": cfg}
+	args := map[string]interface{}{"id": clusterUUID, "config": cfg}
 	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err := mt.updateMySQLConfig(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "MySQL config updated successfully")
 	// Error case: missing config
-	args = map[string]interface{}{"id": "cid"}
+	args = map[string]interface{}{"id": clusterUUID}
 	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err = mt.updateMySQLConfig(context.Background(), req)
 	assert.NoError(t, err)
@@ -67,7 +67,7 @@ func TestMysqlTool_getSQLMode(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockDB := mocks.NewMockDatabasesService(ctrl)
-	mockDB.EXPECT().GetSQLMode(gomock.Any(), "cid").Return("STRICT_TRANS_TABLES", nil, nil)
+	mockDB.EXPECT().GetSQLMode(gomock.Any(), clusterUUID).Return("STRICT_TRANS_TABLES", nil, nil)
 
 	client := func(ctx context.Context) (*godo.Client, error) {
 		return &godo.Client{
@@ -76,7 +76,7 @@ func TestMysqlTool_getSQLMode(t *testing.T) {
 	}
 
 	mt := &MysqlTool{client: client}
-	args := map[string]interface{}{"id": "cid"}
+	args := map[string]interface{}{"id": clusterUUID}
 	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err := mt.getSQLMode(context.Background(), req)
 	assert.NoError(t, err)
@@ -92,7 +92,7 @@ func TestMysqlTool_setSQLMode(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockDB := mocks.NewMockDatabasesService(ctrl)
-	mockDB.EXPECT().SetSQLMode(gomock.Any(), "cid", gomock.Any()).Return(nil, nil)
+	mockDB.EXPECT().SetSQLMode(gomock.Any(), clusterUUID, gomock.Any()).Return(nil, nil)
 
 	client := func(ctx context.Context) (*godo.Client, error) {
 		return &godo.Client{
@@ -101,13 +101,13 @@ func TestMysqlTool_setSQLMode(t *testing.T) {
 	}
 
 	mt := &MysqlTool{client: client}
-	args := map[string]interface{}{"id": "cid", "modes": "STRICT_TRANS_TABLES"}
+	args := map[string]interface{}{"id": clusterUUID, "modes": "STRICT_TRANS_TABLES"}
 	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err := mt.setSQLMode(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "SQL mode set successfully")
 	// Error case: missing modes
-	args = map[string]interface{}{"id": "cid"}
+	args = map[string]interface{}{"id": clusterUUID}
 	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err = mt.setSQLMode(context.Background(), req)
 	assert.NoError(t, err)
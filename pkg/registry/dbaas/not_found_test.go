@@ -0,0 +1,168 @@
+package dbaas
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"mcp-digitalocean/internal/testhelpers"
+	"mcp-digitalocean/pkg/registry/common"
+	"mcp-digitalocean/pkg/registry/dbaas/mocks"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetToolsNotFound(t *testing.T) {
+	type handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	type clientFn = func(ctx context.Context) (*godo.Client, error)
+	clusterArgs := map[string]any{"id": clusterUUID}
+	tests := []struct {
+		name     string
+		handler  func(client clientFn) handler
+		args     map[string]any
+		mock     func(db *mocks.MockDatabasesService, err error)
+		resource string
+		id       string
+	}{
+		{
+			name:    "cluster",
+			handler: func(client clientFn) handler { return NewClusterTool(client).getCluster },
+			args:    clusterArgs,
+			mock: func(db *mocks.MockDatabasesService, err error) {
+				db.EXPECT().Get(gomock.Any(), clusterUUID).Return(nil, nil, err)
+			},
+			resource: "cluster", id: clusterUUID,
+		},
+		{
+			name:    "CA",
+			handler: func(client clientFn) handler { return NewClusterTool(client).getCA },
+			args:    clusterArgs,
+			mock: func(db *mocks.MockDatabasesService, err error) {
+				db.EXPECT().GetCA(gomock.Any(), clusterUUID).Return(nil, nil, err)
+			},
+			resource: "cluster", id: clusterUUID,
+		},
+		{
+			name:    "online migration status",
+			handler: func(client clientFn) handler { return NewClusterTool(client).getOnlineMigrationStatus },
+			args:    clusterArgs,
+			mock: func(db *mocks.MockDatabasesService, err error) {
+				db.EXPECT().GetOnlineMigrationStatus(gomock.Any(), clusterUUID).Return(nil, nil, err)
+			},
+			resource: "cluster", id: clusterUUID,
+		},
+		{
+			name:    "firewall rules",
+			handler: func(client clientFn) handler { return NewFirewallTool(client).getFirewallRules },
+			args:    clusterArgs,
+			mock: func(db *mocks.MockDatabasesService, err error) {
+				db.EXPECT().GetFirewallRules(gomock.Any(), clusterUUID).Return(nil, nil, err)
+			},
+			resource: "cluster", id: clusterUUID,
+		},
+		{
+			name:    "kafka config",
+			handler: func(client clientFn) handler { return NewKafkaTool(client).getKafkaConfig },
+			args:    clusterArgs,
+			mock: func(db *mocks.MockDatabasesService, err error) {
+				db.EXPECT().GetKafkaConfig(gomock.Any(), clusterUUID).Return(nil, nil, err)
+			},
+			resource: "cluster", id: clusterUUID,
+		},
+		{
+			name:    "kafka topic",
+			handler: func(client clientFn) handler { return NewKafkaTool(client).getTopic },
+			args:    map[string]any{"id": clusterUUID, "name": "events"},
+			mock: func(db *mocks.MockDatabasesService, err error) {
+				db.EXPECT().GetTopic(gomock.Any(), clusterUUID, "events").Return(nil, nil, err)
+			},
+			resource: "topic", id: clusterUUID + "/events",
+		},
+		{
+			name:    "mongodb config",
+			handler: func(client clientFn) handler { return NewMongoTool(client).getMongoDBConfig },
+			args:    clusterArgs,
+			mock: func(db *mocks.MockDatabasesService, err error) {
+				db.EXPECT().GetMongoDBConfig(gomock.Any(), clusterUUID).Return(nil, nil, err)
+			},
+			resource: "cluster", id: clusterUUID,
+		},
+		{
+			name:    "mysql config",
+			handler: func(client clientFn) handler { return NewMysqlTool(client).getMySQLConfig },
+			args:    clusterArgs,
+			mock: func(db *mocks.MockDatabasesService, err error) {
+				db.EXPECT().GetMySQLConfig(gomock.Any(), clusterUUID).Return(nil, nil, err)
+			},
+			resource: "cluster", id: clusterUUID,
+		},
+		{
+			name:    "sql mode",
+			handler: func(client clientFn) handler { return NewMysqlTool(client).getSQLMode },
+			args:    clusterArgs,
+			mock: func(db *mocks.MockDatabasesService, err error) {
+				db.EXPECT().GetSQLMode(gomock.Any(), clusterUUID).Return("", nil, err)
+			},
+			resource: "cluster", id: clusterUUID,
+		},
+		{
+			name:    "opensearch config",
+			handler: func(client clientFn) handler { return NewOpenSearchTool(client).getOpensearchConfig },
+			args:    clusterArgs,
+			mock: func(db *mocks.MockDatabasesService, err error) {
+				db.EXPECT().GetOpensearchConfig(gomock.Any(), clusterUUID).Return(nil, nil, err)
+			},
+			resource: "cluster", id: clusterUUID,
+		},
+		{
+			name:    "postgresql config",
+			handler: func(client clientFn) handler { return NewPostgreSQLTool(client).getPostgreSQLConfig },
+			args:    clusterArgs,
+			mock: func(db *mocks.MockDatabasesService, err error) {
+				db.EXPECT().GetPostgreSQLConfig(gomock.Any(), clusterUUID).Return(nil, nil, err)
+			},
+			resource: "cluster", id: clusterUUID,
+		},
+		{
+			name:    "redis config",
+			handler: func(client clientFn) handler { return NewRedisTool(client).getRedisConfig },
+			args:    clusterArgs,
+			mock: func(db *mocks.MockDatabasesService, err error) {
+				db.EXPECT().GetRedisConfig(gomock.Any(), clusterUUID).Return(nil, nil, err)
+			},
+			resource: "cluster", id: clusterUUID,
+		},
+		{
+			name:    "user",
+			handler: func(client clientFn) handler { return NewUserTool(client).getUser },
+			args:    map[string]any{"id": clusterUUID, "user": "app"},
+			mock: func(db *mocks.MockDatabasesService, err error) {
+				db.EXPECT().GetUser(gomock.Any(), clusterUUID, "app").Return(nil, nil, err)
+			},
+			resource: "user", id: clusterUUID + "/app",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockDB := mocks.NewMockDatabasesService(ctrl)
+			tc.mock(mockDB, testhelpers.NotFoundError("/v2/databases/"+tc.id))
+			get := tc.handler(func(ctx context.Context) (*godo.Client, error) {
+				return &godo.Client{Databases: mockDB}, nil
+			})
+
+			resp, err := get(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			require.True(t, resp.IsError)
+			var out common.NotFound
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+			require.Equal(t, common.ErrorCodeNotFound, out.Code)
+			require.Equal(t, tc.resource, out.Resource)
+			require.Equal(t, tc.id, out.ID)
+		})
+	}
+}
@@ -8,15 +8,20 @@ import (
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"mcp-digitalocean/pkg/registry/common"
 )
 
 type OpenSearchTool struct {
-	client func(ctx context.Context) (*godo.Client, error)
+	common.ToolLogger
+
+	client   func(ctx context.Context) (*godo.Client, error)
+	clusters *clusterResolver
 }
 
 func NewOpenSearchTool(client func(ctx context.Context) (*godo.Client, error)) *OpenSearchTool {
 	return &OpenSearchTool{
-		client: client,
+		client:   client,
+		clusters: sharedClusterResolver,
 	}
 }
 
@@ -30,9 +35,13 @@ func (s *OpenSearchTool) getOpensearchConfig(ctx context.Context, req mcp.CallTo
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 	cfg, _, err := client.Databases.GetOpensearchConfig(ctx, id)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.GetErrorResult(ctx, s.Logger(ctx), "api error", "cluster", id, err), nil
 	}
 	jsonCfg, err := json.MarshalIndent(cfg, "", "  ")
 	if err != nil {
@@ -63,9 +72,13 @@ func (s *OpenSearchTool) updateOpensearchConfig(ctx context.Context, req mcp.Cal
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 	_, err = client.Databases.UpdateOpensearchConfig(ctx, id, &config)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, s.Logger(ctx), "api error", err), nil
 	}
 	return mcp.NewToolResultText("Opensearch config updated successfully"), nil
 }
@@ -76,14 +89,14 @@ func (s *OpenSearchTool) Tools() []server.ServerTool {
 			Handler: s.getOpensearchConfig,
 			Tool: mcp.NewTool("db-cluster-get-opensearch-config",
 				mcp.WithDescription("Get the Opensearch config for a cluster by its id"),
-				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID or name")),
 			),
 		},
 		{
 			Handler: s.updateOpensearchConfig,
 			Tool: mcp.NewTool("db-cluster-update-os-config",
 				mcp.WithDescription("Update the Opensearch config for a cluster by its id. Accepts a structured config object."),
-				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID or name")),
 				mcp.WithObject("config",
 					mcp.Required(),
 					mcp.Description("Structured configuration for Opensearch cluster"),
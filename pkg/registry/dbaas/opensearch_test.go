@@ -16,7 +16,7 @@ func TestOpenSearchTool_getOpensearchConfig(t *testing.T) {
 	defer ctrl.Finish()
 	mockDB := mocks.NewMockDatabasesService(ctrl)
 	val := 12345
-	mockDB.EXPECT().GetOpensearchConfig(gomock.Any(), "cid").Return(&godo.OpensearchConfig{HttpMaxContentLengthBytes: &val}, nil, nil)
+	mockDB.EXPECT().GetOpensearchConfig(gomock.Any(), clusterUUID).Return(&godo.OpensearchConfig{HttpMaxContentLengthBytes: &val}, nil, nil)
 
 	client := func(ctx context.Context) (*godo.Client, error) {
 		return &godo.Client{
@@ -25,7 +25,7 @@ func TestOpenSearchTool_getOpensearchConfig(t *testing.T) {
 	}
 
 	ot := &OpenSearchTool{client: client}
-	args := map[string]interface{}{"id": "cid"}
+	args := map[string]interface{}{"id": clusterUUID}
 	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err := ot.getOpensearchConfig(context.Background(), req)
 	assert.NoError(t, err)
@@ -36,8 +36,8 @@ func TestOpenSearchTool_getOpensearchConfig(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "Cluster id is required")
 	// API error
-	mockDB.EXPECT().GetOpensearchConfig(gomock.Any(), "badid").Return(nil, nil, assert.AnError)
-	args = map[string]interface{}{"id": "badid"}
+	mockDB.EXPECT().GetOpensearchConfig(gomock.Any(), badClusterUUID).Return(nil, nil, assert.AnError)
+	args = map[string]interface{}{"id": badClusterUUID}
 	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err = ot.getOpensearchConfig(context.Background(), req)
 	assert.NoError(t, err)
@@ -49,7 +49,7 @@ func TestOpenSearchTool_updateOpensearchConfig(t *testing.T) {
 	defer ctrl.Finish()
 	mockDB := mocks.NewMockDatabasesService(ctrl)
 	val := 54321
-	mockDB.EXPECT().UpdateOpensearchConfig(gomock.Any(), "cid", gomock.Any()).Return(&godo.Response{}, nil)
+	mockDB.EXPECT().UpdateOpensearchConfig(gomock.Any(), clusterUUID, gomock.Any()).Return(&godo.Response{}, nil)
 
 	client := func(ctx context.Context) (*godo.Client, error) {
 		return &godo.Client{
@@ -59,7 +59,7 @@ func TestOpenSearchTool_updateOpensearchConfig(t *testing.T) {
 
 	ot := &OpenSearchTool{client: client}
 	config := map[string]any{"http_max_content_length_bytes": val}
-	args := map[string]interface{}{"id": "cid", "config": config}
+	args := map[string]interface{}{"id": clusterUUID, "config": config}
 	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err := ot.updateOpensearchConfig(context.Background(), req)
 	assert.NoError(t, err)
@@ -71,20 +71,20 @@ func TestOpenSearchTool_updateOpensearchConfig(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "Cluster id is required")
 	// Error case: missing config
-	args = map[string]interface{}{"id": "cid"}
+	args = map[string]interface{}{"id": clusterUUID}
 	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err = ot.updateOpensearchConfig(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "Missing or invalid 'config' object")
 	// Error case: invalid config (not a map)
-	args = map[string]interface{}{"id": "cid", "config": "notmap"}
+	args = map[string]interface{}{"id": clusterUUID, "config": "notmap"}
 	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err = ot.updateOpensearchConfig(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "Missing or invalid 'config' object")
 	// API error
-	mockDB.EXPECT().UpdateOpensearchConfig(gomock.Any(), "badid", gomock.Any()).Return(nil, assert.AnError)
-	args = map[string]interface{}{"id": "badid", "config": config}
+	mockDB.EXPECT().UpdateOpensearchConfig(gomock.Any(), badClusterUUID, gomock.Any()).Return(nil, assert.AnError)
+	args = map[string]interface{}{"id": badClusterUUID, "config": config}
 	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err = ot.updateOpensearchConfig(context.Background(), req)
 	assert.NoError(t, err)
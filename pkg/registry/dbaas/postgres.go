@@ -8,15 +8,20 @@ import (
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"mcp-digitalocean/pkg/registry/common"
 )
 
 type PostgreSQLTool struct {
-	client func(ctx context.Context) (*godo.Client, error)
+	common.ToolLogger
+
+	client   func(ctx context.Context) (*godo.Client, error)
+	clusters *clusterResolver
 }
 
 func NewPostgreSQLTool(client func(ctx context.Context) (*godo.Client, error)) *PostgreSQLTool {
 	return &PostgreSQLTool{
-		client: client,
+		client:   client,
+		clusters: sharedClusterResolver,
 	}
 }
 
@@ -31,10 +36,14 @@ func (s *PostgreSQLTool) getPostgreSQLConfig(ctx context.Context, req mcp.CallTo
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	cfg, _, err := client.Databases.GetPostgreSQLConfig(ctx, id)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.GetErrorResult(ctx, s.Logger(ctx), "api error", "cluster", id, err), nil
 	}
 	jsonCfg, err := json.MarshalIndent(cfg, "", "  ")
 	if err != nil {
@@ -69,10 +78,14 @@ func (s *PostgreSQLTool) updatePostgreSQLConfig(ctx context.Context, req mcp.Cal
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	_, err = client.Databases.UpdatePostgreSQLConfig(ctx, id, &config)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, s.Logger(ctx), "api error", err), nil
 	}
 
 	return mcp.NewToolResultText("PostgreSQL config updated successfully"), nil
@@ -84,14 +97,14 @@ func (s *PostgreSQLTool) Tools() []server.ServerTool {
 			Handler: s.getPostgreSQLConfig,
 			Tool: mcp.NewTool("db-cluster-get-postgresql-config",
 				mcp.WithDescription("Get the PostgreSQL config for a cluster by its id"),
-				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID or name")),
 			),
 		},
 		{
 			Handler: s.updatePostgreSQLConfig,
 			Tool: mcp.NewTool("db-cluster-update-psql-config",
 				mcp.WithDescription("Update the PostgreSQL config for a cluster by its id. Accepts a structured config object."),
-				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID or name")),
 				mcp.WithObject("config",
 					mcp.Required(),
 					mcp.Description("Configuration object for PostgreSQL database"),
@@ -16,7 +16,7 @@ func TestPostgreSQLTool_getPostgreSQLConfig(t *testing.T) {
 	defer ctrl.Finish()
 	mockDB := mocks.NewMockDatabasesService(ctrl)
 	val := 42
-	mockDB.EXPECT().GetPostgreSQLConfig(gomock.Any(), "cid").Return(&godo.PostgreSQLConfig{BackupHour: &val}, nil, nil)
+	mockDB.EXPECT().GetPostgreSQLConfig(gomock.Any(), clusterUUID).Return(&godo.PostgreSQLConfig{BackupHour: &val}, nil, nil)
 
 	client := func(ctx context.Context) (*godo.Client, error) {
 		return &godo.Client{
@@ -25,7 +25,7 @@ func TestPostgreSQLTool_getPostgreSQLConfig(t *testing.T) {
 	}
 
 	pt := &PostgreSQLTool{client: client}
-	args := map[string]interface{}{"id": "cid"}
+	args := map[string]interface{}{"id": clusterUUID}
 	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err := pt.getPostgreSQLConfig(context.Background(), req)
 	assert.NoError(t, err)
@@ -36,8 +36,8 @@ func TestPostgreSQLTool_getPostgreSQLConfig(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "Cluster id is required")
 	// API error
-	mockDB.EXPECT().GetPostgreSQLConfig(gomock.Any(), "badid").Return(nil, nil, assert.AnError)
-	args = map[string]interface{}{"id": "badid"}
+	mockDB.EXPECT().GetPostgreSQLConfig(gomock.Any(), badClusterUUID).Return(nil, nil, assert.AnError)
+	args = map[string]interface{}{"id": badClusterUUID}
 	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err = pt.getPostgreSQLConfig(context.Background(), req)
 	assert.NoError(t, err)
@@ -49,7 +49,7 @@ func TestPostgreSQLTool_updatePostgreSQLConfig(t *testing.T) {
 	defer ctrl.Finish()
 	mockDB := mocks.NewMockDatabasesService(ctrl)
 	val := 99
-	mockDB.EXPECT().UpdatePostgreSQLConfig(gomock.Any(), "cid", gomock.Any()).Return(&godo.Response{}, nil)
+	mockDB.EXPECT().UpdatePostgreSQLConfig(gomock.Any(), clusterUUID, gomock.Any()).Return(&godo.Response{}, nil)
 
 	client := func(ctx context.Context) (*godo.Client, error) {
 		return &godo.Client{
@@ -59,7 +59,7 @@ func TestPostgreSQLTool_updatePostgreSQLConfig(t *testing.T) {
 
 	pt := &PostgreSQLTool{client: client}
 	config := map[string]any{"backup_hour": val}
-	args := map[string]interface{}{"id": "cid", "config": config}
+	args := map[string]interface{}{"id": clusterUUID, "config": config}
 	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err := pt.updatePostgreSQLConfig(context.Background(), req)
 	assert.NoError(t, err)
@@ -71,20 +71,20 @@ func TestPostgreSQLTool_updatePostgreSQLConfig(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "Cluster id is required")
 	// Error case: missing config
-	args = map[string]interface{}{"id": "cid"}
+	args = map[string]interface{}{"id": clusterUUID}
 	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err = pt.updatePostgreSQLConfig(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "Missing or invalid 'config' object")
 	// Error case: invalid config (not a map)
-	args = map[string]interface{}{"id": "cid", "config": "notmap"}
+	args = map[string]interface{}{"id": clusterUUID, "config": "notmap"}
 	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err = pt.updatePostgreSQLConfig(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "Missing or invalid 'config' object")
 	// API error
-	mockDB.EXPECT().UpdatePostgreSQLConfig(gomock.Any(), "badid", gomock.Any()).Return(nil, assert.AnError)
-	args = map[string]interface{}{"id": "badid", "config": config}
+	mockDB.EXPECT().UpdatePostgreSQLConfig(gomock.Any(), badClusterUUID, gomock.Any()).Return(nil, assert.AnError)
+	args = map[string]interface{}{"id": badClusterUUID, "config": config}
 	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err = pt.updatePostgreSQLConfig(context.Background(), req)
 	assert.NoError(t, err)
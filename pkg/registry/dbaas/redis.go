@@ -8,15 +8,22 @@ import (
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"mcp-digitalocean/pkg/registry/common"
+
+	"mcp-digitalocean/pkg/registry/internal/enums"
 )
 
 type RedisTool struct {
-	client func(ctx context.Context) (*godo.Client, error)
+	common.ToolLogger
+
+	client   func(ctx context.Context) (*godo.Client, error)
+	clusters *clusterResolver
 }
 
 func NewRedisTool(client func(ctx context.Context) (*godo.Client, error)) *RedisTool {
 	return &RedisTool{
-		client: client,
+		client:   client,
+		clusters: sharedClusterResolver,
 	}
 }
 
@@ -31,10 +38,14 @@ func (s *RedisTool) getRedisConfig(ctx context.Context, req mcp.CallToolRequest)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	cfg, _, err := client.Databases.GetRedisConfig(ctx, id)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.GetErrorResult(ctx, s.Logger(ctx), "api error", "cluster", id, err), nil
 	}
 
 	jsonCfg, err := json.MarshalIndent(cfg, "", "  ")
@@ -65,15 +76,24 @@ func (s *RedisTool) updateRedisConfig(ctx context.Context, req mcp.CallToolReque
 	if err := json.Unmarshal(cfgBytes, &config); err != nil {
 		return mcp.NewToolResultError("Invalid config object: " + err.Error()), nil
 	}
+	if config.RedisMaxmemoryPolicy != nil {
+		if err := enums.RedisMaxmemoryPolicies.Validate("redis_maxmemory_policy", *config.RedisMaxmemoryPolicy); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
 
 	client, err := s.client(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	_, err = client.Databases.UpdateRedisConfig(ctx, id, &config)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, s.Logger(ctx), "api error", err), nil
 	}
 
 	return mcp.NewToolResultText("Redis config updated successfully"), nil
@@ -85,20 +105,21 @@ func (s *RedisTool) Tools() []server.ServerTool {
 			Handler: s.getRedisConfig,
 			Tool: mcp.NewTool("db-cluster-get-redis-config",
 				mcp.WithDescription("Get the Redis config for a cluster by its id."),
-				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID or name")),
 			),
 		},
 		{
 			Handler: s.updateRedisConfig,
 			Tool: mcp.NewTool("db-cluster-update-redis-config",
 				mcp.WithDescription("Update the Redis config for a cluster by its id. Accepts a structured config object."),
-				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID or name")),
 				mcp.WithObject("config",
 					mcp.Required(),
 					mcp.Description("Structured configuration for Redis database"),
 					mcp.Properties(map[string]any{
 						"redis_maxmemory_policy": map[string]any{
 							"type":        "string",
+							"enum":        enums.RedisMaxmemoryPolicies,
 							"description": "Policy for eviction when memory is full (e.g., allkeys-lru)",
 						},
 						"redis_pubsub_client_output_buffer_limit": map[string]any{
@@ -16,7 +16,7 @@ func TestRedisTool_getRedisConfig(t *testing.T) {
 	defer ctrl.Finish()
 	mockDB := mocks.NewMockDatabasesService(ctrl)
 	val := "volatile-lru"
-	mockDB.EXPECT().GetRedisConfig(gomock.Any(), "cid").Return(&godo.RedisConfig{RedisMaxmemoryPolicy: &val}, nil, nil)
+	mockDB.EXPECT().GetRedisConfig(gomock.Any(), clusterUUID).Return(&godo.RedisConfig{RedisMaxmemoryPolicy: &val}, nil, nil)
 
 	client := func(ctx context.Context) (*godo.Client, error) {
 		return &godo.Client{
@@ -25,7 +25,7 @@ func TestRedisTool_getRedisConfig(t *testing.T) {
 	}
 
 	rt := &RedisTool{client: client}
-	args := map[string]interface{}{"id": "cid"}
+	args := map[string]interface{}{"id": clusterUUID}
 	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err := rt.getRedisConfig(context.Background(), req)
 	assert.NoError(t, err)
@@ -36,8 +36,8 @@ func TestRedisTool_getRedisConfig(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "Cluster id is required")
 	// API error
-	mockDB.EXPECT().GetRedisConfig(gomock.Any(), "badid").Return(nil, nil, assert.AnError)
-	args = map[string]interface{}{"id": "badid"}
+	mockDB.EXPECT().GetRedisConfig(gomock.Any(), badClusterUUID).Return(nil, nil, assert.AnError)
+	args = map[string]interface{}{"id": badClusterUUID}
 	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err = rt.getRedisConfig(context.Background(), req)
 	assert.NoError(t, err)
@@ -49,7 +49,7 @@ func TestRedisTool_updateRedisConfig(t *testing.T) {
 	defer ctrl.Finish()
 	mockDB := mocks.NewMockDatabasesService(ctrl)
 	val := "allkeys-lru"
-	mockDB.EXPECT().UpdateRedisConfig(gomock.Any(), "cid", gomock.Any()).Return(&godo.Response{}, nil)
+	mockDB.EXPECT().UpdateRedisConfig(gomock.Any(), clusterUUID, gomock.Any()).Return(&godo.Response{}, nil)
 
 	client := func(ctx context.Context) (*godo.Client, error) {
 		return &godo.Client{
@@ -59,7 +59,7 @@ func TestRedisTool_updateRedisConfig(t *testing.T) {
 
 	rt := &RedisTool{client: client}
 	config := map[string]any{"redis_maxmemory_policy": val}
-	args := map[string]interface{}{"id": "cid", "config": config}
+	args := map[string]interface{}{"id": clusterUUID, "config": config}
 	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err := rt.updateRedisConfig(context.Background(), req)
 	assert.NoError(t, err)
@@ -71,20 +71,27 @@ func TestRedisTool_updateRedisConfig(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "Cluster id is required")
 	// Error case: missing config
-	args = map[string]interface{}{"id": "cid"}
+	args = map[string]interface{}{"id": clusterUUID}
 	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err = rt.updateRedisConfig(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "Missing or invalid 'config' object")
 	// Error case: invalid config (not a map)
-	args = map[string]interface{}{"id": "cid", "config": "notmap"}
+	args = map[string]interface{}{"id": clusterUUID, "config": "notmap"}
 	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err = rt.updateRedisConfig(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "Missing or invalid 'config' object")
+	// Error case: unknown eviction policy
+	args = map[string]interface{}{"id": clusterUUID, "config": map[string]any{"redis_maxmemory_policy": "allkeys"}}
+	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
+	res, err = rt.updateRedisConfig(context.Background(), req)
+	assert.NoError(t, err)
+	assert.True(t, res.IsError)
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, `redis_maxmemory_policy must be one of noeviction, allkeys_lru`)
 	// API error
-	mockDB.EXPECT().UpdateRedisConfig(gomock.Any(), "badid", gomock.Any()).Return(nil, assert.AnError)
-	args = map[string]interface{}{"id": "badid", "config": config}
+	mockDB.EXPECT().UpdateRedisConfig(gomock.Any(), badClusterUUID, gomock.Any()).Return(nil, assert.AnError)
+	args = map[string]interface{}{"id": badClusterUUID, "config": config}
 	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 	res, err = rt.updateRedisConfig(context.Background(), req)
 	assert.NoError(t, err)
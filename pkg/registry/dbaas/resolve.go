@@ -0,0 +1,90 @@
+package dbaas
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/uuid"
+	middleware "mcp-digitalocean/internal"
+	"mcp-digitalocean/pkg/registry/common"
+)
+
+// defaultClusterNameTTL is how long a token's cluster names are reused before
+// the clusters are listed again.
+const defaultClusterNameTTL = 5 * time.Minute
+
+// sharedClusterResolver is used by every database tool, so a name resolved by
+// one tool is reused by the next.
+var sharedClusterResolver = newClusterResolver(defaultClusterNameTTL)
+
+// clusterResolver lets database tools take a cluster name wherever they take
+// a cluster id. Names are resolved with Databases.List, and each token's
+// name to id mapping is cached for ttl.
+type clusterResolver struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu       sync.Mutex
+	clusters map[string]clusterNames
+}
+
+// clusterNames maps cluster names to the ids of the clusters with that name.
+type clusterNames struct {
+	ids     map[string][]string
+	expires time.Time
+}
+
+func newClusterResolver(ttl time.Duration) *clusterResolver {
+	return &clusterResolver{
+		ttl:      ttl,
+		now:      time.Now,
+		clusters: make(map[string]clusterNames),
+	}
+}
+
+// resolve returns id unchanged when it is a UUID. Otherwise it is taken as a
+// cluster name and the id of the one cluster with exactly that name is
+// returned. A name that is not cached is looked up again, in case the cluster
+// was created since the clusters were listed.
+func (r *clusterResolver) resolve(ctx context.Context, client *godo.Client, id string) (string, error) {
+	if _, err := uuid.Parse(id); err == nil {
+		return id, nil
+	}
+	key := middleware.AuthFingerprint(ctx)
+
+	r.mu.Lock()
+	cached, ok := r.clusters[key]
+	r.mu.Unlock()
+	if !ok || !r.now().Before(cached.expires) || len(cached.ids[id]) == 0 {
+		clusters, err := common.FetchAll(ctx, common.MaxPerPage, client.Databases.List)
+		if err != nil {
+			return "", fmt.Errorf("failed to list database clusters to resolve %q: %w", id, err)
+		}
+		cached = clusterNames{ids: make(map[string][]string), expires: r.now().Add(r.ttl)}
+		for _, cluster := range clusters {
+			cached.ids[cluster.Name] = append(cached.ids[cluster.Name], cluster.ID)
+		}
+		r.mu.Lock()
+		for k, c := range r.clusters {
+			if !r.now().Before(c.expires) {
+				delete(r.clusters, k)
+			}
+		}
+		r.clusters[key] = cached
+		r.mu.Unlock()
+	}
+
+	ids := cached.ids[id]
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no database cluster is named %q; pass the cluster UUID or check the name with db-cluster-list", id)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("%d database clusters are named %q (%s); pass the cluster UUID instead", len(ids), id, strings.Join(ids, ", "))
+	}
+}
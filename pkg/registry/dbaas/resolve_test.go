@@ -0,0 +1,161 @@
+package dbaas
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	middleware "mcp-digitalocean/internal"
+	"mcp-digitalocean/pkg/registry/dbaas/mocks"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	clusterUUID        = "9cc10173-e9ea-4176-9dbc-a4cee4c4ff30"
+	missingClusterUUID = "0f8a1c2e-3b4d-4e5f-8a9b-0c1d2e3f4a5b"
+	badClusterUUID     = "b4d1d000-0000-4000-8000-000000000000"
+	otherClusterUUID   = "4f1e2d3c-5b6a-4978-8a1b-2c3d4e5f6a7b"
+)
+
+var testClusters = []godo.Database{
+	{ID: clusterUUID, Name: "staging-pg"},
+	{ID: otherClusterUUID, Name: "shared"},
+	{ID: badClusterUUID, Name: "shared"},
+}
+
+func TestClusterResolver_resolve(t *testing.T) {
+	tests := []struct {
+		name        string
+		id          string
+		mock        func(m *mocks.MockDatabasesService)
+		expected    string
+		expectedErr string
+	}{
+		{
+			name:     "UUID is passed through",
+			id:       clusterUUID,
+			mock:     func(m *mocks.MockDatabasesService) {},
+			expected: clusterUUID,
+		},
+		{
+			name: "name hit",
+			id:   "staging-pg",
+			mock: func(m *mocks.MockDatabasesService) {
+				m.EXPECT().List(gomock.Any(), gomock.Any()).Return(testClusters, nil, nil)
+			},
+			expected: clusterUUID,
+		},
+		{
+			name: "name miss",
+			id:   "prod-pg",
+			mock: func(m *mocks.MockDatabasesService) {
+				m.EXPECT().List(gomock.Any(), gomock.Any()).Return(testClusters, nil, nil)
+			},
+			expectedErr: `no database cluster is named "prod-pg"; pass the cluster UUID or check the name with db-cluster-list`,
+		},
+		{
+			name: "ambiguous name",
+			id:   "shared",
+			mock: func(m *mocks.MockDatabasesService) {
+				m.EXPECT().List(gomock.Any(), gomock.Any()).Return(testClusters, nil, nil)
+			},
+			expectedErr: `2 database clusters are named "shared" (` + otherClusterUUID + ", " + badClusterUUID + "); pass the cluster UUID instead",
+		},
+		{
+			name: "list error",
+			id:   "staging-pg",
+			mock: func(m *mocks.MockDatabasesService) {
+				m.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil, errors.New("api down"))
+			},
+			expectedErr: `failed to list database clusters to resolve "staging-pg": api down`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockDB := mocks.NewMockDatabasesService(ctrl)
+			tc.mock(mockDB)
+
+			id, err := newClusterResolver(time.Minute).resolve(context.Background(), &godo.Client{Databases: mockDB}, tc.id)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, id)
+		})
+	}
+}
+
+func TestClusterResolver_cache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDB := mocks.NewMockDatabasesService(ctrl)
+	client := &godo.Client{Databases: mockDB}
+	now := time.Now()
+	r := newClusterResolver(5 * time.Minute)
+	r.now = func() time.Time { return now }
+	ctxA := middleware.WithAuthKey(context.Background(), "Bearer a")
+	ctxB := middleware.WithAuthKey(context.Background(), "Bearer b")
+
+	// one list serves every name for the same token.
+	mockDB.EXPECT().List(gomock.Any(), gomock.Any()).Return(testClusters, nil, nil).Times(1)
+	for range 2 {
+		id, err := r.resolve(ctxA, client, "staging-pg")
+		require.NoError(t, err)
+		require.Equal(t, clusterUUID, id)
+	}
+	_, err := r.resolve(ctxA, client, "shared")
+	require.ErrorContains(t, err, "2 database clusters")
+
+	// another token lists its own clusters.
+	mockDB.EXPECT().List(gomock.Any(), gomock.Any()).Return([]godo.Database{{ID: otherClusterUUID, Name: "staging-pg"}}, nil, nil)
+	id, err := r.resolve(ctxB, client, "staging-pg")
+	require.NoError(t, err)
+	require.Equal(t, otherClusterUUID, id)
+
+	// a name missing from the cache is looked up again.
+	mockDB.EXPECT().List(gomock.Any(), gomock.Any()).Return(append(testClusters, godo.Database{ID: missingClusterUUID, Name: "new-pg"}), nil, nil)
+	id, err = r.resolve(ctxA, client, "new-pg")
+	require.NoError(t, err)
+	require.Equal(t, missingClusterUUID, id)
+
+	// the cache expires.
+	now = now.Add(5 * time.Minute)
+	mockDB.EXPECT().List(gomock.Any(), gomock.Any()).Return(testClusters, nil, nil)
+	_, err = r.resolve(ctxA, client, "staging-pg")
+	require.NoError(t, err)
+}
+
+func TestClusterTool_byName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDB := mocks.NewMockDatabasesService(ctrl)
+	mockDB.EXPECT().List(gomock.Any(), gomock.Any()).Return(testClusters, nil, nil)
+	mockDB.EXPECT().Get(gomock.Any(), clusterUUID).Return(&godo.Database{ID: clusterUUID, Name: "staging-pg"}, nil, nil)
+	mockDB.EXPECT().Delete(gomock.Any(), clusterUUID).Return(nil, nil)
+	client := func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{Databases: mockDB}, nil
+	}
+	ct := &ClusterTool{client: client, clusters: newClusterResolver(time.Minute)}
+
+	args := map[string]any{"id": "staging-pg"}
+	res, err := ct.getCluster(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+	require.NoError(t, err)
+	require.False(t, res.IsError, getText(res))
+	assert.Contains(t, getText(res), clusterUUID)
+
+	res, err = ct.deleteCluster(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+	require.NoError(t, err)
+	require.False(t, res.IsError, getText(res))
+
+	res, err = ct.getCluster(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": "shared"}}})
+	require.NoError(t, err)
+	require.True(t, res.IsError)
+	assert.Contains(t, getText(res), "pass the cluster UUID instead")
+}
@@ -9,15 +9,21 @@ import (
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"mcp-digitalocean/pkg/registry/common"
+	"mcp-digitalocean/pkg/registry/internal/enums"
 )
 
 type UserTool struct {
-	client func(ctx context.Context) (*godo.Client, error)
+	common.ToolLogger
+
+	client   func(ctx context.Context) (*godo.Client, error)
+	clusters *clusterResolver
 }
 
 func NewUserTool(client func(ctx context.Context) (*godo.Client, error)) *UserTool {
 	return &UserTool{
-		client: client,
+		client:   client,
+		clusters: sharedClusterResolver,
 	}
 }
 
@@ -36,10 +42,14 @@ func (s *UserTool) getUser(ctx context.Context, req mcp.CallToolRequest) (*mcp.C
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	dbUser, _, err := client.Databases.GetUser(ctx, id, user)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.GetErrorResult(ctx, s.Logger(ctx), "api error", "user", id+"/"+user, err), nil
 	}
 
 	jsonUser, err := json.MarshalIndent(dbUser, "", "  ")
@@ -77,10 +87,14 @@ func (s *UserTool) listUsers(ctx context.Context, req mcp.CallToolRequest) (*mcp
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	users, _, err := client.Databases.ListUsers(ctx, id, opts)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, s.Logger(ctx), "api error", err), nil
 	}
 
 	jsonUsers, err := json.MarshalIndent(users, "", "  ")
@@ -91,6 +105,90 @@ func (s *UserTool) listUsers(ctx context.Context, req mcp.CallToolRequest) (*mcp
 	return mcp.NewToolResultText(string(jsonUsers)), nil
 }
 
+// Permissions the API accepts in user ACLs, per engine.
+var (
+	kafkaACLPermissions      = enums.Set{"admin", "consume", "produce", "produceconsume"}
+	openSearchACLPermissions = enums.Set{"deny", "admin", "read", "readwrite", "write"}
+)
+
+// parseUserSettings decodes the optional settings argument of
+// db-cluster-create-user and db-cluster-update-user.
+func parseUserSettings(args map[string]any) (*godo.DatabaseUserSettings, *mcp.CallToolResult) {
+	settingsVal, ok := args["settings"]
+	if !ok {
+		return nil, nil
+	}
+	settingsMap, ok := settingsVal.(map[string]any)
+	if !ok {
+		return nil, mcp.NewToolResultError("Invalid settings object: must be an object")
+	}
+	settingsBytes, _ := json.Marshal(settingsMap)
+	var settings godo.DatabaseUserSettings
+	if err := json.Unmarshal(settingsBytes, &settings); err != nil {
+		return nil, mcp.NewToolResultError("Invalid settings object: " + err.Error())
+	}
+	return &settings, nil
+}
+
+// checkUserSettings fetches the cluster's engine and checks that settings
+// only use the fields and permissions that engine supports, since the API
+// rejects the rest with a generic error.
+func checkUserSettings(ctx context.Context, client *godo.Client, id string, settings *godo.DatabaseUserSettings) *mcp.CallToolResult {
+	if settings == nil {
+		return nil
+	}
+	cluster, _, err := client.Databases.Get(ctx, id)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err)
+	}
+	if err := validateUserSettings(cluster.EngineSlug, settings); err != nil {
+		return mcp.NewToolResultError("Invalid settings object: " + err.Error())
+	}
+	return nil
+}
+
+func validateUserSettings(engine string, settings *godo.DatabaseUserSettings) error {
+	// each engine has exactly one settings field of its own.
+	fields := map[string]bool{
+		"acl":                 len(settings.ACL) > 0,
+		"opensearch_acl":      len(settings.OpenSearchACL) > 0,
+		"mongo_user_settings": settings.MongoUserSettings != nil,
+	}
+	var own string
+	switch engine {
+	case "kafka":
+		own = "acl"
+		for i, acl := range settings.ACL {
+			if acl == nil || acl.Topic == "" {
+				return fmt.Errorf("acl[%d].topic is required", i)
+			}
+			if err := kafkaACLPermissions.Validate(fmt.Sprintf("acl[%d].permission", i), acl.Permission); err != nil {
+				return err
+			}
+		}
+	case "opensearch":
+		own = "opensearch_acl"
+		for i, acl := range settings.OpenSearchACL {
+			if acl == nil || acl.Index == "" {
+				return fmt.Errorf("opensearch_acl[%d].index is required", i)
+			}
+			if err := openSearchACLPermissions.Validate(fmt.Sprintf("opensearch_acl[%d].permission", i), acl.Permission); err != nil {
+				return err
+			}
+		}
+	case "mongodb":
+		own = "mongo_user_settings"
+	default:
+		return fmt.Errorf("%s clusters do not support user settings; only kafka, opensearch and mongodb users have them", engine)
+	}
+	for _, field := range []string{"acl", "opensearch_acl", "mongo_user_settings"} {
+		if fields[field] && field != own {
+			return fmt.Errorf("%s is not supported for %s clusters; use %s", field, engine, own)
+		}
+	}
+	return nil
+}
+
 func (s *UserTool) createUser(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	args := req.GetArguments()
 	id, ok := args["id"].(string)
@@ -108,32 +206,32 @@ func (s *UserTool) createUser(ctx context.Context, req mcp.CallToolRequest) (*mc
 		createReq.MySQLSettings = &godo.DatabaseMySQLUserSettings{AuthPlugin: plugin}
 	}
 
-	if settingsVal, ok := args["settings"]; ok {
-		settingsMap, ok := settingsVal.(map[string]any)
-		if !ok {
-			return mcp.NewToolResultError("Invalid settings object: must be an object"), nil
-		}
-		settingsBytes, _ := json.Marshal(settingsMap)
-		var settings godo.DatabaseUserSettings
-		if err := json.Unmarshal(settingsBytes, &settings); err != nil {
-			return mcp.NewToolResultError("Invalid settings object: " + err.Error()), nil
-		}
-		createReq.Settings = &settings
+	settings, errResult := parseUserSettings(args)
+	if errResult != nil {
+		return errResult, nil
 	}
+	createReq.Settings = settings
 
 	client, err := s.client(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	// Nil check for s.client.Databases after argument validation
 	if client.Databases == nil {
 		return nil, fmt.Errorf("database client is not configured")
 	}
+	if errResult := checkUserSettings(ctx, client, id, settings); errResult != nil {
+		return errResult, nil
+	}
 
 	dbUser, _, err := client.Databases.CreateUser(ctx, id, createReq)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, s.Logger(ctx), "api error", err), nil
 	}
 
 	jsonUser, err := json.MarshalIndent(dbUser, "", "  ")
@@ -157,32 +255,32 @@ func (s *UserTool) updateUser(ctx context.Context, req mcp.CallToolRequest) (*mc
 
 	updateReq := &godo.DatabaseUpdateUserRequest{}
 
-	if settingsVal, ok := args["settings"]; ok {
-		settingsMap, ok := settingsVal.(map[string]any)
-		if !ok {
-			return mcp.NewToolResultError("Invalid settings object: must be an object"), nil
-		}
-		settingsBytes, _ := json.Marshal(settingsMap)
-		var settings godo.DatabaseUserSettings
-		if err := json.Unmarshal(settingsBytes, &settings); err != nil {
-			return mcp.NewToolResultError("Invalid settings object: " + err.Error()), nil
-		}
-		updateReq.Settings = &settings
+	settings, errResult := parseUserSettings(args)
+	if errResult != nil {
+		return errResult, nil
 	}
+	updateReq.Settings = settings
 
 	client, err := s.client(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	// Nil check for s.client.Databases after argument validation and settings validation
 	if client.Databases == nil {
 		return nil, fmt.Errorf("database client is not configured")
 	}
+	if errResult := checkUserSettings(ctx, client, id, settings); errResult != nil {
+		return errResult, nil
+	}
 
 	dbUser, _, err := client.Databases.UpdateUser(ctx, id, user, updateReq)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, s.Logger(ctx), "api error", err), nil
 	}
 
 	jsonUser, err := json.MarshalIndent(dbUser, "", "  ")
@@ -208,16 +306,20 @@ func (s *UserTool) deleteUser(ctx context.Context, req mcp.CallToolRequest) (*mc
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	id, err = s.clusters.resolve(ctx, client, id)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	_, err = client.Databases.DeleteUser(ctx, id, user)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, s.Logger(ctx), "api error", err), nil
 	}
 	return mcp.NewToolResultText("User deleted successfully"), nil
 }
 
 var dbSettings = mcp.WithObject("settings",
-	mcp.Description("Optional user settings object"),
+	mcp.Description("Optional user settings; which field applies depends on the cluster's engine: acl for kafka, opensearch_acl for opensearch and mongo_user_settings for mongodb. Other engines have no user settings"),
 	mcp.Properties(map[string]any{
 		"acl": map[string]any{
 			"type": "array",
@@ -225,8 +327,8 @@ var dbSettings = mcp.WithObject("settings",
 				"type": "object",
 				"properties": map[string]any{
 					"id":         map[string]any{"type": "string"},
-					"permission": map[string]any{"type": "string"},
-					"topic":      map[string]any{"type": "string"},
+					"permission": map[string]any{"type": "string", "enum": kafkaACLPermissions},
+					"topic":      map[string]any{"type": "string", "description": "Topic name or pattern, e.g. events.*"},
 				},
 			},
 		},
@@ -235,8 +337,8 @@ var dbSettings = mcp.WithObject("settings",
 			"items": map[string]any{
 				"type": "object",
 				"properties": map[string]any{
-					"index":      map[string]any{"type": "string"},
-					"permission": map[string]any{"type": "string"},
+					"index":      map[string]any{"type": "string", "description": "Index name or pattern, e.g. logs-*"},
+					"permission": map[string]any{"type": "string", "enum": openSearchACLPermissions},
 				},
 			},
 		},
@@ -259,7 +361,7 @@ func (s *UserTool) Tools() []server.ServerTool {
 			Handler: s.getUser,
 			Tool: mcp.NewTool("db-cluster-get-user",
 				mcp.WithDescription("Get a database user by cluster id and user name"),
-				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster ID")),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID or name")),
 				mcp.WithString("user", mcp.Required(), mcp.Description("The user name")),
 			),
 		},
@@ -267,7 +369,7 @@ func (s *UserTool) Tools() []server.ServerTool {
 			Handler: s.listUsers,
 			Tool: mcp.NewTool("db-cluster-list-users",
 				mcp.WithDescription("List database users for a cluster"),
-				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster ID")),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID or name")),
 				mcp.WithString("page", mcp.Description("Page number for pagination (optional)")),
 				mcp.WithNumber("per_page", mcp.Description("Number of results per page (optional)")),
 			),
@@ -276,7 +378,7 @@ func (s *UserTool) Tools() []server.ServerTool {
 			Handler: s.createUser,
 			Tool: mcp.NewTool("db-cluster-create-user",
 				mcp.WithDescription("Create a new database user for a cluster"),
-				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster ID")),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID or name")),
 				mcp.WithString("name", mcp.Required(), mcp.Description("The user name")),
 				mcp.WithString("mysql_auth_plugin", mcp.Description("MySQL auth plugin (optional)")),
 				dbSettings,
@@ -286,7 +388,7 @@ func (s *UserTool) Tools() []server.ServerTool {
 			Handler: s.updateUser,
 			Tool: mcp.NewTool("db-cluster-update-user",
 				mcp.WithDescription("Update a database user's settings"),
-				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster ID")),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID or name")),
 				mcp.WithString("user", mcp.Required(), mcp.Description("The user name")),
 				dbSettings,
 			),
@@ -295,7 +397,7 @@ func (s *UserTool) Tools() []server.ServerTool {
 			Handler: s.deleteUser,
 			Tool: mcp.NewTool("db-cluster-delete-user",
 				mcp.WithDescription("Delete a database user from a cluster"),
-				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster ID")),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID or name")),
 				mcp.WithString("user", mcp.Required(), mcp.Description("The user name to delete")),
 			),
 		},
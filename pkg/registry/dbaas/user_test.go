@@ -45,10 +45,10 @@ func TestUserTool_getUser(t *testing.T) {
 	ctx := context.Background()
 
 	dbUser := &godo.DatabaseUser{Name: "testuser"}
-	mockSvc.EXPECT().GetUser(ctx, "cid", "testuser").Return(dbUser, nil, nil)
+	mockSvc.EXPECT().GetUser(ctx, clusterUUID, "testuser").Return(dbUser, nil, nil)
 
 	// Success
-	res, err := tool.getUser(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": "cid", "user": "testuser"}}})
+	res, err := tool.getUser(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": clusterUUID, "user": "testuser"}}})
 	assert.NoError(t, err)
 	assert.Contains(t, getTextContent(res), "testuser")
 
@@ -58,14 +58,14 @@ func TestUserTool_getUser(t *testing.T) {
 	assert.Equal(t, "Cluster id is required", getTextContent(res))
 
 	// Missing user
-	res, err = tool.getUser(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": "cid"}}})
+	res, err = tool.getUser(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": clusterUUID}}})
 	assert.NoError(t, err)
 	assert.Equal(t, "User name is required", getTextContent(res))
 
 	// API error
 	errApi := errors.New("api fail")
-	mockSvc.EXPECT().GetUser(ctx, "cid", "failuser").Return(nil, nil, errApi)
-	res, err = tool.getUser(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": "cid", "user": "failuser"}}})
+	mockSvc.EXPECT().GetUser(ctx, clusterUUID, "failuser").Return(nil, nil, errApi)
+	res, err = tool.getUser(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": clusterUUID, "user": "failuser"}}})
 	assert.NoError(t, err)
 	assert.Contains(t, getTextContent(res), "api error")
 }
@@ -77,8 +77,8 @@ func TestUserTool_listUsers(t *testing.T) {
 		tool, mockSvc, ctrl := newUserToolWithMock(t)
 		defer ctrl.Finish()
 		users := []godo.DatabaseUser{{Name: "u1"}, {Name: "u2"}}
-		mockSvc.EXPECT().ListUsers(ctx, "cid", (*godo.ListOptions)(nil)).Return(users, nil, nil)
-		res, err := tool.listUsers(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": "cid"}}})
+		mockSvc.EXPECT().ListUsers(ctx, clusterUUID, (*godo.ListOptions)(nil)).Return(users, nil, nil)
+		res, err := tool.listUsers(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": clusterUUID}}})
 		assert.NoError(t, err)
 		assert.Contains(t, getTextContent(res), "u1")
 		assert.Contains(t, getTextContent(res), "u2")
@@ -89,8 +89,8 @@ func TestUserTool_listUsers(t *testing.T) {
 		defer ctrl.Finish()
 		users := []godo.DatabaseUser{{Name: "u1"}, {Name: "u2"}}
 		opts := &godo.ListOptions{Page: 2, PerPage: 5}
-		mockSvc.EXPECT().ListUsers(ctx, "cid", opts).Return(users, nil, nil)
-		res, err := tool.listUsers(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": "cid", "page": "2", "per_page": 5}}})
+		mockSvc.EXPECT().ListUsers(ctx, clusterUUID, opts).Return(users, nil, nil)
+		res, err := tool.listUsers(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": clusterUUID, "page": "2", "per_page": 5}}})
 		assert.NoError(t, err)
 		assert.Contains(t, getTextContent(res), "u1")
 	})
@@ -107,8 +107,8 @@ func TestUserTool_listUsers(t *testing.T) {
 		tool, mockSvc, ctrl := newUserToolWithMock(t)
 		defer ctrl.Finish()
 		errApi := errors.New("api fail")
-		mockSvc.EXPECT().ListUsers(ctx, "cid", (*godo.ListOptions)(nil)).Return(nil, nil, errApi)
-		res, err := tool.listUsers(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": "cid"}}})
+		mockSvc.EXPECT().ListUsers(ctx, clusterUUID, (*godo.ListOptions)(nil)).Return(nil, nil, errApi)
+		res, err := tool.listUsers(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": clusterUUID}}})
 		assert.NoError(t, err)
 		assert.Contains(t, getTextContent(res), "api error")
 	})
@@ -121,11 +121,11 @@ func TestUserTool_createUser(t *testing.T) {
 		tool, mockSvc, ctrl := newUserToolWithMock(t)
 		defer ctrl.Finish()
 		dbUser := &godo.DatabaseUser{Name: "newuser"}
-		mockSvc.EXPECT().CreateUser(ctx, "cid", gomock.Any()).Return(dbUser, nil, nil)
+		mockSvc.EXPECT().CreateUser(ctx, clusterUUID, gomock.Any()).Return(dbUser, nil, nil)
 
 		res, err := tool.createUser(ctx, mcp.CallToolRequest{
 			Params: mcp.CallToolParams{Arguments: map[string]any{
-				"id":   "cid",
+				"id":   clusterUUID,
 				"name": "newuser",
 			}},
 		})
@@ -137,13 +137,13 @@ func TestUserTool_createUser(t *testing.T) {
 		tool, mockSvc, ctrl := newUserToolWithMock(t)
 		defer ctrl.Finish()
 		dbUser := &godo.DatabaseUser{Name: "pluginuser"}
-		mockSvc.EXPECT().CreateUser(ctx, "cid", mock.MatchedBy(func(req *godo.DatabaseCreateUserRequest) bool {
+		mockSvc.EXPECT().CreateUser(ctx, clusterUUID, mock.MatchedBy(func(req *godo.DatabaseCreateUserRequest) bool {
 			return req.MySQLSettings != nil && req.MySQLSettings.AuthPlugin == "mysql_native_password"
 		})).Return(dbUser, nil, nil)
 
 		res, err := tool.createUser(ctx, mcp.CallToolRequest{
 			Params: mcp.CallToolParams{Arguments: map[string]any{
-				"id":                "cid",
+				"id":                clusterUUID,
 				"name":              "pluginuser",
 				"mysql_auth_plugin": "mysql_native_password",
 			}},
@@ -156,15 +156,16 @@ func TestUserTool_createUser(t *testing.T) {
 		tool, mockSvc, ctrl := newUserToolWithMock(t)
 		defer ctrl.Finish()
 		dbUser := &godo.DatabaseUser{Name: "settingsuser"}
-		mockSvc.EXPECT().CreateUser(ctx, "cid", gomock.Any()).Return(dbUser, nil, nil)
+		mockSvc.EXPECT().Get(ctx, clusterUUID).Return(&godo.Database{ID: clusterUUID, EngineSlug: "kafka"}, nil, nil)
+		mockSvc.EXPECT().CreateUser(ctx, clusterUUID, gomock.Any()).Return(dbUser, nil, nil)
 
 		res, err := tool.createUser(ctx, mcp.CallToolRequest{
 			Params: mcp.CallToolParams{Arguments: map[string]any{
-				"id":   "cid",
+				"id":   clusterUUID,
 				"name": "settingsuser",
 				"settings": map[string]any{
 					"acl": []map[string]any{
-						{"id": "acl1", "permission": "read", "topic": "topic1"},
+						{"id": "acl1", "permission": "consume", "topic": "topic1"},
 					},
 				},
 			}},
@@ -178,7 +179,7 @@ func TestUserTool_createUser(t *testing.T) {
 		tool, _, ctrl := newUserToolWithMock(t)
 		defer ctrl.Finish()
 		args := map[string]any{
-			"id":       "cid",
+			"id":       clusterUUID,
 			"name":     "broken",
 			"settings": "notjson",
 		}
@@ -212,7 +213,7 @@ func TestUserTool_createUser(t *testing.T) {
 		tool := &UserTool{client: client}
 		res, err := tool.createUser(ctx, mcp.CallToolRequest{
 			Params: mcp.CallToolParams{Arguments: map[string]any{
-				"id": "cid",
+				"id": clusterUUID,
 			}},
 		})
 		assert.NoError(t, err)
@@ -222,11 +223,11 @@ func TestUserTool_createUser(t *testing.T) {
 	t.Run("api error", func(t *testing.T) {
 		tool, mockSvc, ctrl := newUserToolWithMock(t)
 		defer ctrl.Finish()
-		mockSvc.EXPECT().CreateUser(ctx, "cid", gomock.Any()).Return(nil, nil, fmt.Errorf("api fail"))
+		mockSvc.EXPECT().CreateUser(ctx, clusterUUID, gomock.Any()).Return(nil, nil, fmt.Errorf("api fail"))
 
 		res, err := tool.createUser(ctx, mcp.CallToolRequest{
 			Params: mcp.CallToolParams{Arguments: map[string]any{
-				"id":   "cid",
+				"id":   clusterUUID,
 				"name": "failuser",
 			}},
 		})
@@ -242,8 +243,8 @@ func TestUserTool_updateUser(t *testing.T) {
 		tool, mockSvc, ctrl := newUserToolWithMock(t)
 		defer ctrl.Finish()
 		dbUser := &godo.DatabaseUser{Name: "updateduser"}
-		mockSvc.EXPECT().UpdateUser(ctx, "cid", "updateduser", gomock.Any()).Return(dbUser, nil, nil)
-		res, err := tool.updateUser(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": "cid", "user": "updateduser"}}})
+		mockSvc.EXPECT().UpdateUser(ctx, clusterUUID, "updateduser", gomock.Any()).Return(dbUser, nil, nil)
+		res, err := tool.updateUser(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": clusterUUID, "user": "updateduser"}}})
 		assert.NoError(t, err)
 		assert.Contains(t, getTextContent(res), "updateduser")
 	})
@@ -252,21 +253,22 @@ func TestUserTool_updateUser(t *testing.T) {
 		tool, mockSvc, ctrl := newUserToolWithMock(t)
 		defer ctrl.Finish()
 		dbUser := &godo.DatabaseUser{Name: "updateduser"}
-		settings := godo.DatabaseUserSettings{ACL: []*godo.KafkaACL{{ID: "acl2", Permission: "write"}}}
+		settings := godo.DatabaseUserSettings{ACL: []*godo.KafkaACL{{ID: "acl2", Permission: "produce", Topic: "events"}}}
 		settingsBytes, _ := json.Marshal(settings)
 		var settingsMap map[string]any
 		_ = json.Unmarshal(settingsBytes, &settingsMap)
-		mockSvc.EXPECT().UpdateUser(ctx, "cid", "updateduser", mock.MatchedBy(func(req *godo.DatabaseUpdateUserRequest) bool {
+		mockSvc.EXPECT().Get(ctx, clusterUUID).Return(&godo.Database{ID: clusterUUID, EngineSlug: "kafka"}, nil, nil)
+		mockSvc.EXPECT().UpdateUser(ctx, clusterUUID, "updateduser", mock.MatchedBy(func(req *godo.DatabaseUpdateUserRequest) bool {
 			return req.Settings != nil && len(req.Settings.ACL) > 0 && req.Settings.ACL[0].ID == "acl2"
 		})).Return(dbUser, nil, nil)
-		res, err := tool.updateUser(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": "cid", "user": "updateduser", "settings": settingsMap}}})
+		res, err := tool.updateUser(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": clusterUUID, "user": "updateduser", "settings": settingsMap}}})
 		assert.NoError(t, err)
 		assert.Contains(t, getTextContent(res), "updateduser")
 	})
 
 	t.Run("invalid settings_json", func(t *testing.T) {
 		tool := &UserTool{client: nil}
-		res, err := tool.updateUser(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": "cid", "user": "updateduser", "settings": "notjson"}}})
+		res, err := tool.updateUser(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": clusterUUID, "user": "updateduser", "settings": "notjson"}}})
 		assert.NoError(t, err)
 		assert.Contains(t, getTextContent(res), "Invalid settings object")
 	})
@@ -280,7 +282,7 @@ func TestUserTool_updateUser(t *testing.T) {
 
 	t.Run("missing user", func(t *testing.T) {
 		tool := &UserTool{client: nil}
-		res, err := tool.updateUser(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": "cid"}}})
+		res, err := tool.updateUser(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": clusterUUID}}})
 		assert.NoError(t, err)
 		assert.Equal(t, "User name is required", getTextContent(res))
 	})
@@ -289,8 +291,137 @@ func TestUserTool_updateUser(t *testing.T) {
 		tool, mockSvc, ctrl := newUserToolWithMock(t)
 		defer ctrl.Finish()
 		errApi := errors.New("api fail")
-		mockSvc.EXPECT().UpdateUser(ctx, "cid", "failuser", gomock.Any()).Return(nil, nil, errApi)
-		res, err := tool.updateUser(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": "cid", "user": "failuser"}}})
+		mockSvc.EXPECT().UpdateUser(ctx, clusterUUID, "failuser", gomock.Any()).Return(nil, nil, errApi)
+		res, err := tool.updateUser(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": clusterUUID, "user": "failuser"}}})
+		assert.NoError(t, err)
+		assert.Contains(t, getTextContent(res), "api error")
+	})
+}
+
+func TestUserTool_engineSettings(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		engine     string
+		settings   map[string]any
+		expect     *godo.DatabaseUserSettings
+		expectText string
+	}{
+		{
+			name:   "kafka acl",
+			engine: "kafka",
+			settings: map[string]any{"acl": []any{
+				map[string]any{"topic": "events.*", "permission": "produceconsume"},
+				map[string]any{"topic": "audit", "permission": "consume"},
+			}},
+			expect: &godo.DatabaseUserSettings{ACL: []*godo.KafkaACL{
+				{Topic: "events.*", Permission: "produceconsume"},
+				{Topic: "audit", Permission: "consume"},
+			}},
+		},
+		{
+			name:   "opensearch acl",
+			engine: "opensearch",
+			settings: map[string]any{"opensearch_acl": []any{
+				map[string]any{"index": "logs-*", "permission": "read"},
+				map[string]any{"index": "metrics", "permission": "readwrite"},
+			}},
+			expect: &godo.DatabaseUserSettings{OpenSearchACL: []*godo.OpenSearchACL{
+				{Index: "logs-*", Permission: "read"},
+				{Index: "metrics", Permission: "readwrite"},
+			}},
+		},
+		{
+			name:     "mongodb settings",
+			engine:   "mongodb",
+			settings: map[string]any{"mongo_user_settings": map[string]any{"databases": []any{"app"}, "role": "readWrite"}},
+			expect:   &godo.DatabaseUserSettings{MongoUserSettings: &godo.MongoUserSettings{Databases: []string{"app"}, Role: "readWrite"}},
+		},
+		{
+			name:       "kafka permission not valid for kafka",
+			engine:     "kafka",
+			settings:   map[string]any{"acl": []any{map[string]any{"topic": "events", "permission": "read"}}},
+			expectText: `acl[0].permission must be one of admin, consume, produce, produceconsume, got "read"`,
+		},
+		{
+			name:       "kafka acl without topic",
+			engine:     "kafka",
+			settings:   map[string]any{"acl": []any{map[string]any{"permission": "admin"}}},
+			expectText: "acl[0].topic is required",
+		},
+		{
+			name:       "opensearch permission not valid for opensearch",
+			engine:     "opensearch",
+			settings:   map[string]any{"opensearch_acl": []any{map[string]any{"index": "logs-*", "permission": "produce"}}},
+			expectText: `opensearch_acl[0].permission must be one of deny, admin, read, readwrite, write, got "produce"`,
+		},
+		{
+			name:       "opensearch acl without index",
+			engine:     "opensearch",
+			settings:   map[string]any{"opensearch_acl": []any{map[string]any{"permission": "read"}}},
+			expectText: "opensearch_acl[0].index is required",
+		},
+		{
+			name:       "kafka acl on an opensearch cluster",
+			engine:     "opensearch",
+			settings:   map[string]any{"acl": []any{map[string]any{"topic": "events", "permission": "admin"}}},
+			expectText: "acl is not supported for opensearch clusters; use opensearch_acl",
+		},
+		{
+			name:       "unsupported engine",
+			engine:     "pg",
+			settings:   map[string]any{"acl": []any{map[string]any{"topic": "events", "permission": "admin"}}},
+			expectText: "pg clusters do not support user settings",
+		},
+	}
+	for _, tc := range tests {
+		for _, op := range []string{"create", "update"} {
+			t.Run(tc.name+"/"+op, func(t *testing.T) {
+				tool, mockSvc, ctrl := newUserToolWithMock(t)
+				defer ctrl.Finish()
+				mockSvc.EXPECT().Get(ctx, clusterUUID).Return(&godo.Database{ID: clusterUUID, EngineSlug: tc.engine}, nil, nil)
+
+				var res *mcp.CallToolResult
+				var err error
+				if op == "create" {
+					if tc.expect != nil {
+						mockSvc.EXPECT().CreateUser(ctx, clusterUUID, &godo.DatabaseCreateUserRequest{Name: "app", Settings: tc.expect}).
+							Return(&godo.DatabaseUser{Name: "app", Settings: tc.expect}, nil, nil)
+					}
+					res, err = tool.createUser(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+						"id": clusterUUID, "name": "app", "settings": tc.settings,
+					}}})
+				} else {
+					if tc.expect != nil {
+						mockSvc.EXPECT().UpdateUser(ctx, clusterUUID, "app", &godo.DatabaseUpdateUserRequest{Settings: tc.expect}).
+							Return(&godo.DatabaseUser{Name: "app", Settings: tc.expect}, nil, nil)
+					}
+					res, err = tool.updateUser(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+						"id": clusterUUID, "user": "app", "settings": tc.settings,
+					}}})
+				}
+				assert.NoError(t, err)
+				if tc.expectText != "" {
+					assert.True(t, res.IsError)
+					assert.Contains(t, getTextContent(res), tc.expectText)
+					return
+				}
+				assert.False(t, res.IsError)
+				var user godo.DatabaseUser
+				assert.NoError(t, json.Unmarshal([]byte(getTextContent(res)), &user))
+				assert.Equal(t, tc.expect, user.Settings)
+			})
+		}
+	}
+
+	t.Run("cluster lookup fails", func(t *testing.T) {
+		tool, mockSvc, ctrl := newUserToolWithMock(t)
+		defer ctrl.Finish()
+		mockSvc.EXPECT().Get(ctx, clusterUUID).Return(nil, nil, errors.New("not found"))
+		res, err := tool.createUser(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+			"id": clusterUUID, "name": "app", "settings": map[string]any{"acl": []any{}},
+		}}})
 		assert.NoError(t, err)
 		assert.Contains(t, getTextContent(res), "api error")
 	})
@@ -302,8 +433,8 @@ func TestUserTool_deleteUser(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		tool, mockSvc, ctrl := newUserToolWithMock(t)
 		defer ctrl.Finish()
-		mockSvc.EXPECT().DeleteUser(ctx, "cid", "deluser").Return((*godo.Response)(nil), nil)
-		res, err := tool.deleteUser(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": "cid", "user": "deluser"}}})
+		mockSvc.EXPECT().DeleteUser(ctx, clusterUUID, "deluser").Return((*godo.Response)(nil), nil)
+		res, err := tool.deleteUser(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": clusterUUID, "user": "deluser"}}})
 		assert.NoError(t, err)
 		assert.Equal(t, "User deleted successfully", getTextContent(res))
 	})
@@ -317,7 +448,7 @@ func TestUserTool_deleteUser(t *testing.T) {
 
 	t.Run("missing user", func(t *testing.T) {
 		tool := &UserTool{client: nil}
-		res, err := tool.deleteUser(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": "cid"}}})
+		res, err := tool.deleteUser(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": clusterUUID}}})
 		assert.NoError(t, err)
 		assert.Equal(t, "User name is required", getTextContent(res))
 	})
@@ -326,8 +457,8 @@ func TestUserTool_deleteUser(t *testing.T) {
 		tool, mockSvc, ctrl := newUserToolWithMock(t)
 		defer ctrl.Finish()
 		errApi := errors.New("api fail")
-		mockSvc.EXPECT().DeleteUser(ctx, "cid", "failuser").Return((*godo.Response)(nil), errApi)
-		res, err := tool.deleteUser(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": "cid", "user": "failuser"}}})
+		mockSvc.EXPECT().DeleteUser(ctx, clusterUUID, "failuser").Return((*godo.Response)(nil), errApi)
+		res, err := tool.deleteUser(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": clusterUUID, "user": "failuser"}}})
 		assert.NoError(t, err)
 		assert.Contains(t, getTextContent(res), "api error")
 	})
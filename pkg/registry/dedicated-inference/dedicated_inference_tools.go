@@ -8,6 +8,7 @@ import (
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"mcp-digitalocean/pkg/registry/common"
 )
 
 // DedicatedInferenceTool provides Dedicated Inference lifecycle management tools.
@@ -223,6 +224,7 @@ func (d *DedicatedInferenceTool) Tools() []server.ServerTool {
 			Handler: d.createDedicatedInference,
 			Tool: mcp.NewTool(
 				"dedicated-inference-create",
+				common.WithCreatesResource(),
 				mcp.WithDescription("Create a new Dedicated Inference instance (CreateDedicatedInferenceV2). See spec/dedicated-inference-create-schema.json for the HTTP/API-aligned request shape. Tool arguments use UpperCamelCase; returns instance and optional initial auth token."),
 				mcp.WithString("Name", mcp.Required(), mcp.Description("Name of the dedicated inference instance")),
 				mcp.WithString("Region", mcp.Required(), mcp.Description("Region slug for deployment (e.g. nyc2, tor1, atl1)")),
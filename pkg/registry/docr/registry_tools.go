@@ -8,6 +8,7 @@ import (
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"mcp-digitalocean/pkg/registry/common"
 )
 
 // RegistryTool provides container registry management tools
@@ -216,6 +217,7 @@ func (r *RegistryTool) Tools() []server.ServerTool {
 		{
 			Handler: r.create,
 			Tool: mcp.NewTool("docr-create",
+				common.WithCreatesResource(),
 				mcp.WithDescription("Create a new container registry"),
 				mcp.WithString("Name", mcp.Required(), mcp.Description("Name of the container registry")),
 				mcp.WithString("SubscriptionTierSlug", mcp.Description("Subscription tier slug (e.g., 'starter', 'basic', 'professional')")),
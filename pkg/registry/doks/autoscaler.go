@@ -0,0 +1,84 @@
+package doks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"mcp-digitalocean/pkg/registry/common"
+)
+
+// autoscalerConfigUpdate reads the doks-update-autoscaler-config arguments
+// and applies them to current, the cluster's configuration, so settings that
+// are not passed keep their value. The API's fields are not optional, so a
+// setting left out of the request would be cleared.
+func autoscalerConfigUpdate(args map[string]any, current *godo.KubernetesClusterAutoscalerConfiguration) (*godo.KubernetesClusterAutoscalerConfiguration, error) {
+	threshold, hasThreshold := args["ScaleDownUtilizationThreshold"].(float64)
+	unneeded, hasUnneeded := args["ScaleDownUnneededTime"].(string)
+	if !hasThreshold && !hasUnneeded {
+		return nil, fmt.Errorf("at least one of ScaleDownUtilizationThreshold or ScaleDownUnneededTime is required")
+	}
+
+	config := &godo.KubernetesClusterAutoscalerConfiguration{}
+	if current != nil {
+		*config = *current
+	}
+	if hasThreshold {
+		if threshold < 0 || threshold > 1 {
+			return nil, fmt.Errorf("ScaleDownUtilizationThreshold must be between 0 and 1, got %v", threshold)
+		}
+		config.ScaleDownUtilizationThreshold = &threshold
+	}
+	if hasUnneeded {
+		d, err := time.ParseDuration(unneeded)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("ScaleDownUnneededTime must be a positive duration such as 10m or 1h30m, got %q", unneeded)
+		}
+		// the API reports durations in Go's format, e.g. 10m0s
+		normalized := d.String()
+		config.ScaleDownUnneededTime = &normalized
+	}
+	return config, nil
+}
+
+// updateAutoscalerConfig updates a cluster's autoscaler configuration and
+// returns the configuration the cluster reports afterwards.
+func (d *DoksTool) updateAutoscalerConfig(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	clusterID, ok := args["ClusterID"].(string)
+	if !ok || clusterID == "" {
+		return mcp.NewToolResultError("ClusterID is required and must be a string"), nil
+	}
+	// validate before reading the cluster, then again against its settings
+	if _, err := autoscalerConfigUpdate(args, nil); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := d.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	cluster, resp, err := client.Kubernetes.Get(ctx, clusterID)
+	if err != nil {
+		return common.APIErrorResult(ctx, d.Logger(ctx), "failed to get cluster", resp, err), nil
+	}
+	config, err := autoscalerConfigUpdate(args, cluster.ClusterAutoscalerConfiguration)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	updated, resp, err := client.Kubernetes.Update(ctx, clusterID, &godo.KubernetesClusterUpdateRequest{ClusterAutoscalerConfiguration: config})
+	if err != nil {
+		return common.APIErrorResult(ctx, d.Logger(ctx), "failed to update cluster", resp, err), nil
+	}
+
+	configJSON, err := json.MarshalIndent(updated.ClusterAutoscalerConfiguration, "", "  ")
+	if err != nil {
+		return common.ErrorResult(ctx, d.Logger(ctx), "failed to marshal autoscaler configuration", err), nil
+	}
+	return mcp.NewToolResultText(string(configJSON)), nil
+}
@@ -0,0 +1,167 @@
+package doks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAutoscalerConfigUpdate(t *testing.T) {
+	current := &godo.KubernetesClusterAutoscalerConfiguration{
+		ScaleDownUtilizationThreshold: godo.PtrTo(0.5),
+		ScaleDownUnneededTime:         godo.PtrTo("1m0s"),
+		Expanders:                     []string{"priority"},
+	}
+	tests := []struct {
+		name    string
+		args    map[string]any
+		current *godo.KubernetesClusterAutoscalerConfiguration
+		want    *godo.KubernetesClusterAutoscalerConfiguration
+		wantErr string
+	}{
+		{
+			name:    "nothing to update",
+			args:    map[string]any{},
+			wantErr: "at least one of",
+		},
+		{
+			name:    "threshold below 0",
+			args:    map[string]any{"ScaleDownUtilizationThreshold": -0.1},
+			wantErr: "between 0 and 1, got -0.1",
+		},
+		{
+			name:    "threshold above 1",
+			args:    map[string]any{"ScaleDownUtilizationThreshold": 1.5},
+			wantErr: "between 0 and 1, got 1.5",
+		},
+		{
+			name:    "unparseable duration",
+			args:    map[string]any{"ScaleDownUnneededTime": "ten minutes"},
+			wantErr: `positive duration such as 10m or 1h30m, got "ten minutes"`,
+		},
+		{
+			name:    "zero duration",
+			args:    map[string]any{"ScaleDownUnneededTime": "0s"},
+			wantErr: "positive duration",
+		},
+		{
+			name:    "threshold keeps the other settings",
+			args:    map[string]any{"ScaleDownUtilizationThreshold": 0.65},
+			current: current,
+			want: &godo.KubernetesClusterAutoscalerConfiguration{
+				ScaleDownUtilizationThreshold: godo.PtrTo(0.65),
+				ScaleDownUnneededTime:         godo.PtrTo("1m0s"),
+				Expanders:                     []string{"priority"},
+			},
+		},
+		{
+			name:    "duration is normalized",
+			args:    map[string]any{"ScaleDownUnneededTime": "90m"},
+			current: current,
+			want: &godo.KubernetesClusterAutoscalerConfiguration{
+				ScaleDownUtilizationThreshold: godo.PtrTo(0.5),
+				ScaleDownUnneededTime:         godo.PtrTo("1h30m0s"),
+				Expanders:                     []string{"priority"},
+			},
+		},
+		{
+			name: "bounds are inclusive, without a current configuration",
+			args: map[string]any{"ScaleDownUtilizationThreshold": float64(1), "ScaleDownUnneededTime": "10m"},
+			want: &godo.KubernetesClusterAutoscalerConfiguration{
+				ScaleDownUtilizationThreshold: godo.PtrTo(1.0),
+				ScaleDownUnneededTime:         godo.PtrTo("10m0s"),
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := autoscalerConfigUpdate(tc.args, tc.current)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+	// the current configuration is not modified.
+	require.Equal(t, 0.5, *current.ScaleDownUtilizationThreshold)
+}
+
+func TestDoksTool_updateAutoscalerConfig(t *testing.T) {
+	cluster := &godo.KubernetesCluster{ID: "cluster-1", ClusterAutoscalerConfiguration: &godo.KubernetesClusterAutoscalerConfiguration{
+		ScaleDownUtilizationThreshold: godo.PtrTo(0.5),
+		ScaleDownUnneededTime:         godo.PtrTo("1m0s"),
+		Expanders:                     []string{"priority"},
+	}}
+	wantConfig := &godo.KubernetesClusterAutoscalerConfiguration{
+		ScaleDownUtilizationThreshold: godo.PtrTo(0.5),
+		ScaleDownUnneededTime:         godo.PtrTo("10m0s"),
+		Expanders:                     []string{"priority"},
+	}
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(*MockKubernetesService)
+		expectError string
+	}{
+		{
+			name: "updates only the autoscaler configuration",
+			args: map[string]any{"ClusterID": "cluster-1", "ScaleDownUnneededTime": "10m"},
+			mockSetup: func(m *MockKubernetesService) {
+				m.EXPECT().Get(gomock.Any(), "cluster-1").Return(cluster, nil, nil)
+				m.EXPECT().
+					Update(gomock.Any(), "cluster-1", &godo.KubernetesClusterUpdateRequest{ClusterAutoscalerConfiguration: wantConfig}).
+					Return(&godo.KubernetesCluster{ID: "cluster-1", ClusterAutoscalerConfiguration: wantConfig}, nil, nil)
+			},
+		},
+		{
+			name:        "invalid values never reach the API",
+			args:        map[string]any{"ClusterID": "cluster-1", "ScaleDownUtilizationThreshold": 2.0},
+			expectError: "between 0 and 1",
+		},
+		{
+			name:        "missing cluster ID",
+			args:        map[string]any{"ScaleDownUnneededTime": "10m"},
+			expectError: "ClusterID is required",
+		},
+		{
+			name: "update fails",
+			args: map[string]any{"ClusterID": "cluster-1", "ScaleDownUnneededTime": "10m"},
+			mockSetup: func(m *MockKubernetesService) {
+				m.EXPECT().Get(gomock.Any(), "cluster-1").Return(cluster, nil, nil)
+				m.EXPECT().Update(gomock.Any(), "cluster-1", gomock.Any()).Return(nil, nil, errors.New("invalid autoscaler configuration"))
+			},
+			expectError: "failed to update cluster: invalid autoscaler configuration",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockKubernetes := NewMockKubernetesService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockKubernetes)
+			}
+			tool, _ := setupDoksToolWithMock(mockKubernetes)
+
+			result, err := tool.updateAutoscalerConfig(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			text := result.Content[0].(mcp.TextContent).Text
+			if tc.expectError != "" {
+				require.True(t, result.IsError)
+				require.Contains(t, text, tc.expectError)
+				return
+			}
+			require.False(t, result.IsError)
+			var got godo.KubernetesClusterAutoscalerConfiguration
+			require.NoError(t, json.Unmarshal([]byte(text), &got))
+			require.Equal(t, *wantConfig, got)
+		})
+	}
+}
@@ -3,12 +3,17 @@ package doks
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
+	"time"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"mcp-digitalocean/internal/waiter"
+	"mcp-digitalocean/pkg/registry/common"
 
 	_ "embed"
 )
@@ -19,13 +24,76 @@ var clusterCreateSchemaJSON []byte
 //go:embed spec/node-pool-create-schema.json
 var nodePoolCreateSchemaJSON []byte
 
+// embeddedSchema is an input schema embedded from spec/.
+type embeddedSchema struct {
+	file   string
+	schema []byte
+}
+
+// embeddedSchemas are the schemas the raw-schema tools are built from.
+var embeddedSchemas = []embeddedSchema{
+	{file: "spec/cluster-create-schema.json", schema: clusterCreateSchemaJSON},
+	{file: "spec/node-pool-create-schema.json", schema: nodePoolCreateSchemaJSON},
+}
+
+// ValidateSchemas checks the embedded input schemas, so a malformed schema
+// fails registration instead of surfacing when a client lists the tools.
+func ValidateSchemas() error {
+	return validateSchemas(embeddedSchemas)
+}
+
+func validateSchemas(schemas []embeddedSchema) error {
+	for _, s := range schemas {
+		if err := common.ValidateRawSchema(s.schema); err != nil {
+			return fmt.Errorf("invalid embedded schema %s: %w", s.file, err)
+		}
+	}
+	return nil
+}
+
+const (
+	// defaultNodePollInterval is how often a waited-on node deletion is polled.
+	defaultNodePollInterval = 10 * time.Second
+	// defaultNodeWaitTimeout bounds how long doks-delete-node waits for a node
+	// to drain and disappear.
+	defaultNodeWaitTimeout = 15 * time.Minute
+)
+
 type DoksTool struct {
-	client func(ctx context.Context) (*godo.Client, error)
+	common.ToolLogger
+
+	client       func(ctx context.Context) (*godo.Client, error)
+	pollInterval time.Duration
+	waitTimeout  time.Duration
+	notify       func(ctx context.Context, req mcp.CallToolRequest, progress float64, message string)
+	locks        *common.ResourceLocks
 }
 
+// clusterURN keys cluster mutations for the resource locks.
+var clusterURN = common.URNFromArg("kubernetes", "ClusterID")
+
 // NewDoksTool creates a new DOKS tool
 func NewDoksTool(client func(ctx context.Context) (*godo.Client, error)) *DoksTool {
-	return &DoksTool{client: client}
+	return &DoksTool{
+		client:       client,
+		pollInterval: defaultNodePollInterval,
+		waitTimeout:  defaultNodeWaitTimeout,
+		notify:       common.NotifyProgress,
+		locks:        common.SharedResourceLocks,
+	}
+}
+
+// DOKSNodeDeleteResult reports how a waited-on node deletion ended.
+type DOKSNodeDeleteResult struct {
+	NodeID string `json:"node_id"`
+	// Deleted is true once the node no longer appears in its node pool.
+	Deleted bool `json:"deleted"`
+	// Drain is "completed" when the node was drained before removal and
+	// "force_terminated" when SkipDrain removed it without draining.
+	Drain string `json:"drain"`
+	// LastState is the last node state seen before the node disappeared.
+	LastState string `json:"last_state,omitempty"`
+	Polls     int    `json:"polls"`
 }
 
 // getDoksCluster gets a DOKS cluster
@@ -46,16 +114,16 @@ func (d *DoksTool) getDoksCluster(ctx context.Context, req mcp.CallToolRequest)
 	// Make the API call
 	cluster, _, err := client.Kubernetes.Get(ctx, clusterID)
 	if err != nil {
-		return nil, err
+		return common.GetErrorResult(ctx, d.Logger(ctx), "api error", "cluster", clusterID, err), nil
 	}
 
 	// Marshal the response
-	clusterJSON, err := json.MarshalIndent(cluster, "", "  ")
+	result, err := common.NewStructuredResourceResult(cluster)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("marshal error", err), nil
+		return common.ErrorResult(ctx, d.Logger(ctx), "marshal error", err), nil
 	}
 
-	return mcp.NewToolResultText(string(clusterJSON)), nil
+	return result, nil
 }
 
 // ListDOKSClusters lists DOKS clusters
@@ -80,19 +148,27 @@ func (d *DoksTool) listDOKSClusters(ctx context.Context, req mcp.CallToolRequest
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
+	if common.IsAll(args) {
+		clusters, truncated, err := common.FetchForAll(ctx, client.Kubernetes.List)
+		if err != nil {
+			return common.APIErrorResult(ctx, d.Logger(ctx), "api error", nil, err), nil
+		}
+		return common.NewAllResult(clusters, truncated)
+	}
+
 	// Make the API call
-	clusters, _, err := client.Kubernetes.List(ctx, &godo.ListOptions{
+	clusters, resp, err := client.Kubernetes.List(ctx, &godo.ListOptions{
 		Page:    page,
 		PerPage: perPage,
 	})
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, d.Logger(ctx), "api error", resp, err), nil
 	}
 
 	// Marshal the response
 	clustersJSON, err := json.MarshalIndent(clusters, "", "  ")
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("marshal error", err), nil
+		return common.ErrorResult(ctx, d.Logger(ctx), "marshal error", err), nil
 	}
 
 	return mcp.NewToolResultText(string(clustersJSON)), nil
@@ -107,7 +183,7 @@ func (d *DoksTool) createDOKSCluster(ctx context.Context, req mcp.CallToolReques
 
 	createRequest := &godo.KubernetesClusterCreateRequest{}
 	if err := json.Unmarshal(jsonBytes, createRequest); err != nil {
-		return mcp.NewToolResultErrorFromErr("failed to parse cluster create request", err), nil
+		return common.ErrorResult(ctx, d.Logger(ctx), "failed to parse cluster create request", err), nil
 	}
 
 	client, err := d.client(ctx)
@@ -115,23 +191,23 @@ func (d *DoksTool) createDOKSCluster(ctx context.Context, req mcp.CallToolReques
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
+	if isDryRun(req) {
+		return dryRunCluster(ctx, client, createRequest)
+	}
+
 	// Make the API call
 	cluster, resp, err := client.Kubernetes.Create(ctx, createRequest)
 	if err != nil {
-		// Include more context in the error message for better debugging
-		if resp != nil {
-			return mcp.NewToolResultErrorFromErr(fmt.Sprintf("failed to create cluster: (status: %d)", resp.StatusCode), err), nil
-		}
-		return mcp.NewToolResultErrorFromErr("failed to create cluster", err), nil
+		return common.APIErrorResult(ctx, d.Logger(ctx), "failed to create cluster", resp, err), nil
 	}
 
 	// Marshal the response
-	clusterJSON, err := json.MarshalIndent(cluster, "", "  ")
+	result, err := common.NewResourceResult(cluster)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("failed to marshal cluster", err), nil
+		return common.ErrorResult(ctx, d.Logger(ctx), "failed to marshal cluster", err), nil
 	}
 
-	return mcp.NewToolResultText(string(clusterJSON)), nil
+	return result, nil
 }
 
 // UpdateDOKSCluster updates a Kubernetes cluster
@@ -203,21 +279,74 @@ func (d *DoksTool) updateDOKSCluster(ctx context.Context, req mcp.CallToolReques
 	}
 
 	// Make the API call
-	cluster, _, err := client.Kubernetes.Update(ctx, clusterID, updateRequest)
+	cluster, resp, err := client.Kubernetes.Update(ctx, clusterID, updateRequest)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("failed to update cluster", err), nil
+		return common.APIErrorResult(ctx, d.Logger(ctx), "failed to update cluster", resp, err), nil
 	}
 
 	// Marshal the response
 	clusterJSON, err := json.MarshalIndent(cluster, "", "  ")
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("failed to marshal cluster", err), nil
+		return common.ErrorResult(ctx, d.Logger(ctx), "failed to marshal cluster", err), nil
 	}
 
 	return mcp.NewToolResultText(string(clusterJSON)), nil
 }
 
-// DeleteDOKSCluster deletes a Kubernetes cluster
+// Delete modes of doks-delete-cluster. The default mode deletes only the
+// cluster, dangerous also deletes every resource associated with it, and
+// selective deletes the listed associated resources.
+const (
+	deleteModeDefault   = "default"
+	deleteModeDangerous = "dangerous"
+	deleteModeSelective = "selective"
+)
+
+// stringsArg returns the strings in the array argument key.
+func stringsArg(args map[string]any, key string) []string {
+	var values []string
+	if list, ok := args[key].([]any); ok {
+		for _, v := range list {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+	}
+	return values
+}
+
+// clusterDeleteSelection reads the delete mode of doks-delete-cluster and, for
+// the selective mode, the associated resources to delete with the cluster.
+func clusterDeleteSelection(args map[string]any) (string, *godo.KubernetesClusterDeleteSelectiveRequest, error) {
+	mode, _ := args["DeleteMode"].(string)
+	if mode == "" {
+		mode = deleteModeDefault
+	}
+	selection := &godo.KubernetesClusterDeleteSelectiveRequest{
+		Volumes:         stringsArg(args, "Volumes"),
+		VolumeSnapshots: stringsArg(args, "VolumeSnapshots"),
+		LoadBalancers:   stringsArg(args, "LoadBalancers"),
+	}
+	hasResources := len(selection.Volumes)+len(selection.VolumeSnapshots)+len(selection.LoadBalancers) > 0
+
+	switch mode {
+	case deleteModeDefault, deleteModeDangerous:
+		if hasResources {
+			return "", nil, fmt.Errorf("Volumes, VolumeSnapshots and LoadBalancers can only be used with DeleteMode %q", deleteModeSelective)
+		}
+		return mode, nil, nil
+	case deleteModeSelective:
+		if !hasResources {
+			return "", nil, fmt.Errorf("DeleteMode %q requires at least one of Volumes, VolumeSnapshots or LoadBalancers", deleteModeSelective)
+		}
+		return mode, selection, nil
+	default:
+		return "", nil, fmt.Errorf("DeleteMode must be one of %q, %q or %q, got %q", deleteModeDefault, deleteModeDangerous, deleteModeSelective, mode)
+	}
+}
+
+// DeleteDOKSCluster deletes a Kubernetes cluster, and with the dangerous or
+// selective delete mode its associated resources too
 func (d *DoksTool) deleteDOKSCluster(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	args := req.GetArguments()
 
@@ -226,6 +355,10 @@ func (d *DoksTool) deleteDOKSCluster(ctx context.Context, req mcp.CallToolReques
 	if !ok {
 		return mcp.NewToolResultError("ClusterID is required and must be a string"), nil
 	}
+	mode, selection, err := clusterDeleteSelection(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	client, err := d.client(ctx)
 	if err != nil {
@@ -233,11 +366,24 @@ func (d *DoksTool) deleteDOKSCluster(ctx context.Context, req mcp.CallToolReques
 	}
 
 	// Make the API call
-	_, err = client.Kubernetes.Delete(ctx, clusterID)
+	switch mode {
+	case deleteModeDangerous:
+		_, err = client.Kubernetes.DeleteDangerous(ctx, clusterID)
+	case deleteModeSelective:
+		_, err = client.Kubernetes.DeleteSelective(ctx, clusterID, selection)
+	default:
+		_, err = client.Kubernetes.Delete(ctx, clusterID)
+	}
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("failed to delete cluster", err), nil
+		return common.APIErrorResult(ctx, d.Logger(ctx), "failed to delete cluster", nil, err), nil
 	}
 
+	switch mode {
+	case deleteModeDangerous:
+		return mcp.NewToolResultText(fmt.Sprintf("Cluster %s and all its associated resources deleted successfully", clusterID)), nil
+	case deleteModeSelective:
+		return mcp.NewToolResultText(fmt.Sprintf("Cluster %s and the selected associated resources deleted successfully", clusterID)), nil
+	}
 	return mcp.NewToolResultText(fmt.Sprintf("Cluster %s deleted successfully", clusterID)), nil
 }
 
@@ -263,11 +409,11 @@ func (d *DoksTool) upgradeDOKSCluster(ctx context.Context, req mcp.CallToolReque
 	}
 
 	// Make the API call
-	_, err = client.Kubernetes.Upgrade(ctx, clusterID, &godo.KubernetesClusterUpgradeRequest{
+	resp, err := client.Kubernetes.Upgrade(ctx, clusterID, &godo.KubernetesClusterUpgradeRequest{
 		VersionSlug: version,
 	})
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("failed to upgrade cluster", err), nil
+		return common.APIErrorResult(ctx, d.Logger(ctx), "failed to upgrade cluster", resp, err), nil
 	}
 
 	return mcp.NewToolResultText(fmt.Sprintf("Cluster %s upgraded to %s", clusterID, version)), nil
@@ -291,13 +437,13 @@ func (d *DoksTool) getDOKSClusterUpgrades(ctx context.Context, req mcp.CallToolR
 	// Make the API call
 	upgrades, _, err := client.Kubernetes.GetUpgrades(ctx, clusterID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("failed to get upgrades", err), nil
+		return common.GetErrorResult(ctx, d.Logger(ctx), "failed to get upgrades", "cluster", clusterID, err), nil
 	}
 
 	// Marshal the response
 	upgradesJSON, err := json.MarshalIndent(upgrades, "", "  ")
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("failed to marshal upgrades", err), nil
+		return common.ErrorResult(ctx, d.Logger(ctx), "failed to marshal upgrades", err), nil
 	}
 
 	return mcp.NewToolResultText(string(upgradesJSON)), nil
@@ -321,12 +467,75 @@ func (d *DoksTool) getDOKSClusterKubeConfig(ctx context.Context, req mcp.CallToo
 	// Make the API call
 	kubecfg, _, err := client.Kubernetes.GetKubeConfig(ctx, clusterID, nil)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("failed to get kubeconfig", err), nil
+		return common.GetErrorResult(ctx, d.Logger(ctx), "failed to get kubeconfig", "cluster", clusterID, err), nil
 	}
 
-	return mcp.NewToolResultText(string(kubecfg.KubeconfigYAML)), nil
+	result := mcp.NewToolResultText(string(kubecfg.KubeconfigYAML))
+	meta, err := json.Marshal(map[string]kubeconfigMeta{"meta": clusterUserMeta(ctx, client, clusterID)})
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	result.Content = append(result.Content, mcp.NewTextContent(string(meta)))
+	return result, nil
+}
+
+// kubeconfigMeta describes who a kubeconfig authenticates as.
+type kubeconfigMeta struct {
+	ClusterUser *godo.KubernetesClusterUser `json:"cluster_user,omitempty"`
+	Note        string                      `json:"note"`
 }
 
+// clusterUserMeta looks up the cluster user so the kubeconfig result can say
+// which RBAC groups it carries. A failed lookup is noted rather than failing
+// the kubeconfig call.
+func clusterUserMeta(ctx context.Context, client *godo.Client, clusterID string) kubeconfigMeta {
+	user, _, err := client.Kubernetes.GetUser(ctx, clusterID)
+	if err != nil {
+		return kubeconfigMeta{Note: fmt.Sprintf("Could not look up the cluster user: %v", err)}
+	}
+	if len(user.Groups) == 0 {
+		return kubeconfigMeta{ClusterUser: user, Note: fmt.Sprintf("This kubeconfig authenticates as %s, which is in no groups.", user.Username)}
+	}
+	return kubeconfigMeta{
+		ClusterUser: user,
+		Note:        fmt.Sprintf("This kubeconfig authenticates as %s in groups %s; the cluster's RBAC bindings for these groups decide what it may do.", user.Username, strings.Join(user.Groups, ", ")),
+	}
+}
+
+// GetDOKSClusterUser gets the authenticated user for a cluster
+func (d *DoksTool) getDOKSClusterUser(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+
+	// Extract cluster ID
+	clusterID, ok := args["ClusterID"].(string)
+	if !ok {
+		return mcp.NewToolResultError("ClusterID is required and must be a string"), nil
+	}
+
+	client, err := d.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	// Make the API call
+	user, _, err := client.Kubernetes.GetUser(ctx, clusterID)
+	if err != nil {
+		return common.GetErrorResult(ctx, d.Logger(ctx), "failed to get cluster user", "cluster", clusterID, err), nil
+	}
+
+	// Marshal the response
+	userJSON, err := json.MarshalIndent(user, "", "  ")
+	if err != nil {
+		return common.ErrorResult(ctx, d.Logger(ctx), "failed to marshal cluster user", err), nil
+	}
+
+	return mcp.NewToolResultText(string(userJSON)), nil
+}
+
+// tokenOnlyExpirySeconds is how long a TokenOnly token lives when no
+// ExpirySeconds is given.
+const tokenOnlyExpirySeconds = 3600
+
 // GetDOKSClusterCredentials gets the credentials for a cluster
 func (d *DoksTool) getDOKSClusterCredentials(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	args := req.GetArguments()
@@ -336,6 +545,16 @@ func (d *DoksTool) getDOKSClusterCredentials(ctx context.Context, req mcp.CallTo
 	if !ok {
 		return mcp.NewToolResultError("ClusterID is required and must be a string"), nil
 	}
+	tokenOnly, _ := args["TokenOnly"].(bool)
+
+	credRequest := &godo.KubernetesClusterCredentialsGetRequest{}
+	if v, ok := args["ExpirySeconds"].(float64); ok && int(v) > 0 {
+		expiry := int(v)
+		credRequest.ExpirySeconds = &expiry
+	} else if tokenOnly {
+		expiry := tokenOnlyExpirySeconds
+		credRequest.ExpirySeconds = &expiry
+	}
 
 	client, err := d.client(ctx)
 	if err != nil {
@@ -343,32 +562,45 @@ func (d *DoksTool) getDOKSClusterCredentials(ctx context.Context, req mcp.CallTo
 	}
 
 	// Make the API call
-	credentials, _, err := client.Kubernetes.GetCredentials(ctx, clusterID, &godo.KubernetesClusterCredentialsGetRequest{})
+	credentials, _, err := client.Kubernetes.GetCredentials(ctx, clusterID, credRequest)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("failed to get credentials", err), nil
+		return common.GetErrorResult(ctx, d.Logger(ctx), "failed to get credentials", "cluster", clusterID, err), nil
 	}
 
 	// Build response
-	var result struct {
-		Server                   string `json:"server"`
-		CertificateAuthorityData string `json:"certificate_authority_data"`
-		ClientCertificateData    string `json:"client_certificate_data"`
-		ClientKeyData            string `json:"client_key_data"`
-		Token                    string `json:"token"`
-		ExpiresAt                string `json:"expires_at"`
-	}
-
-	result.Server = credentials.Server
-	result.CertificateAuthorityData = string(credentials.CertificateAuthorityData)
-	result.ClientCertificateData = string(credentials.ClientCertificateData)
-	result.ClientKeyData = string(credentials.ClientKeyData)
-	result.Token = credentials.Token
-	result.ExpiresAt = credentials.ExpiresAt.String()
+	var result any
+	if tokenOnly {
+		result = struct {
+			Server    string `json:"server"`
+			Token     string `json:"token"`
+			ExpiresAt string `json:"expires_at"`
+		}{
+			Server:    credentials.Server,
+			Token:     credentials.Token,
+			ExpiresAt: credentials.ExpiresAt.String(),
+		}
+	} else {
+		result = struct {
+			Server                   string `json:"server"`
+			CertificateAuthorityData string `json:"certificate_authority_data"`
+			ClientCertificateData    string `json:"client_certificate_data"`
+			ClientKeyData            string `json:"client_key_data"`
+			Token                    string `json:"token"`
+			ExpiresAt                string `json:"expires_at"`
+		}{
+			Server:                   credentials.Server,
+			CertificateAuthorityData: string(credentials.CertificateAuthorityData),
+			ClientCertificateData:    string(credentials.ClientCertificateData),
+			ClientKeyData:            string(credentials.ClientKeyData),
+			Token:                    credentials.Token,
+			ExpiresAt:                credentials.ExpiresAt.String(),
+		}
+	}
 
 	// Marshal the response
 	resultJSON, err := json.MarshalIndent(result, "", "  ")
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("marshal error", err), nil
+		return common.ErrorResult(ctx, d.Logger(ctx), "marshal error", err), nil
 	}
 
 	return mcp.NewToolResultText(string(resultJSON)), nil
@@ -392,12 +624,12 @@ func (d *DoksTool) createDOKSNodePool(ctx context.Context, req mcp.CallToolReque
 
 	jsonBytes, err := json.Marshal(createNPRequest)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("marshal error", err), nil
+		return common.ErrorResult(ctx, d.Logger(ctx), "marshal error", err), nil
 	}
 
 	createRequest := &godo.KubernetesNodePoolCreateRequest{}
 	if err := json.Unmarshal(jsonBytes, createRequest); err != nil {
-		return mcp.NewToolResultErrorFromErr("failed to parse node pool create request", err), nil
+		return common.ErrorResult(ctx, d.Logger(ctx), "failed to parse node pool create request", err), nil
 	}
 
 	client, err := d.client(ctx)
@@ -405,16 +637,20 @@ func (d *DoksTool) createDOKSNodePool(ctx context.Context, req mcp.CallToolReque
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
+	if isDryRun(req) {
+		return dryRunNodePool(ctx, client, clusterID, createRequest)
+	}
+
 	// Make the API call
-	nodePool, _, err := client.Kubernetes.CreateNodePool(ctx, clusterID, createRequest)
+	nodePool, resp, err := client.Kubernetes.CreateNodePool(ctx, clusterID, createRequest)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("failed to create node pool", err), nil
+		return common.APIErrorResult(ctx, d.Logger(ctx), "failed to create node pool", resp, err), nil
 	}
 
 	// Marshal the response
 	nodePoolJSON, err := json.MarshalIndent(nodePool, "", "  ")
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("failed to marshal node pool", err), nil
+		return common.ErrorResult(ctx, d.Logger(ctx), "failed to marshal node pool", err), nil
 	}
 
 	return mcp.NewToolResultText(string(nodePoolJSON)), nil
@@ -444,13 +680,13 @@ func (d *DoksTool) getDOKSNodePool(ctx context.Context, req mcp.CallToolRequest)
 	// Make the API call
 	nodePool, _, err := client.Kubernetes.GetNodePool(ctx, clusterID, nodePoolID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("failed to get node pool", err), nil
+		return common.GetErrorResult(ctx, d.Logger(ctx), "failed to get node pool", "node pool", clusterID+"/"+nodePoolID, err), nil
 	}
 
 	// Marshal the response
 	nodePoolJSON, err := json.MarshalIndent(nodePool, "", "  ")
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("failed to marshal node pool", err), nil
+		return common.ErrorResult(ctx, d.Logger(ctx), "failed to marshal node pool", err), nil
 	}
 
 	return mcp.NewToolResultText(string(nodePoolJSON)), nil
@@ -472,15 +708,15 @@ func (d *DoksTool) listDOKSNodePools(ctx context.Context, req mcp.CallToolReques
 	}
 
 	// Make the API call
-	nodePools, _, err := client.Kubernetes.ListNodePools(ctx, clusterID, nil)
+	nodePools, resp, err := client.Kubernetes.ListNodePools(ctx, clusterID, nil)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("failed to list node pools", err), nil
+		return common.APIErrorResult(ctx, d.Logger(ctx), "failed to list node pools", resp, err), nil
 	}
 
 	// Marshal the response
 	nodePoolsJSON, err := json.MarshalIndent(nodePools, "", "  ")
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("failed to marshal node pools", err), nil
+		return common.ErrorResult(ctx, d.Logger(ctx), "failed to marshal node pools", err), nil
 	}
 
 	return mcp.NewToolResultText(string(nodePoolsJSON)), nil
@@ -593,15 +829,15 @@ func (d *DoksTool) updateDOKSNodePool(ctx context.Context, req mcp.CallToolReque
 	}
 
 	// Make the API call
-	nodePool, _, err := client.Kubernetes.UpdateNodePool(ctx, clusterID, nodePoolID, updateRequest)
+	nodePool, resp, err := client.Kubernetes.UpdateNodePool(ctx, clusterID, nodePoolID, updateRequest)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("failed to update node pool", err), nil
+		return common.APIErrorResult(ctx, d.Logger(ctx), "failed to update node pool", resp, err), nil
 	}
 
 	// Marshal the response
 	nodePoolJSON, err := json.MarshalIndent(nodePool, "", "  ")
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("failed to marshal node pool", err), nil
+		return common.ErrorResult(ctx, d.Logger(ctx), "failed to marshal node pool", err), nil
 	}
 
 	return mcp.NewToolResultText(string(nodePoolJSON)), nil
@@ -629,9 +865,9 @@ func (d *DoksTool) deleteDOKSNodePool(ctx context.Context, req mcp.CallToolReque
 	}
 
 	// Make the API call
-	_, err = client.Kubernetes.DeleteNodePool(ctx, clusterID, nodePoolID)
+	resp, err := client.Kubernetes.DeleteNodePool(ctx, clusterID, nodePoolID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("failed to delete node pool", err), nil
+		return common.APIErrorResult(ctx, d.Logger(ctx), "failed to delete node pool", resp, err), nil
 	}
 
 	return mcp.NewToolResultText(fmt.Sprintf("Node pool %s deleted successfully", nodePoolID)), nil
@@ -677,15 +913,79 @@ func (d *DoksTool) deleteDOKSNode(ctx context.Context, req mcp.CallToolRequest)
 	}
 
 	// Make the API call
-	_, err = client.Kubernetes.DeleteNode(ctx, clusterID, nodePoolID, nodeID, &godo.KubernetesNodeDeleteRequest{
+	resp, err := client.Kubernetes.DeleteNode(ctx, clusterID, nodePoolID, nodeID, &godo.KubernetesNodeDeleteRequest{
 		SkipDrain: skipDrain,
 		Replace:   replace,
 	})
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("failed to delete node", err), nil
+		return common.APIErrorResult(ctx, d.Logger(ctx), "failed to delete node", resp, err), nil
+	}
+
+	if wait, _ := args["Wait"].(bool); !wait {
+		return mcp.NewToolResultText(fmt.Sprintf("Node %s deleted successfully", nodeID)), nil
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Node %s deleted successfully", nodeID)), nil
+	result, err := d.waitForNodeRemoval(ctx, req, client, clusterID, nodePoolID, nodeID)
+	if err != nil {
+		return common.APIErrorResult(ctx, d.Logger(ctx), "failed waiting for node removal", nil, err), nil
+	}
+	result.Drain = "completed"
+	if skipDrain {
+		result.Drain = "force_terminated"
+	}
+
+	jsonData, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+// waitForNodeRemoval polls the node pool until nodeID is no longer listed in
+// it, sending a progress notification with the node's state on every poll.
+func (d *DoksTool) waitForNodeRemoval(ctx context.Context, req mcp.CallToolRequest, client *godo.Client, clusterID, nodePoolID, nodeID string) (*DOKSNodeDeleteResult, error) {
+	result := &DOKSNodeDeleteResult{NodeID: nodeID}
+	_, err := waiter.Poll(ctx, d.pollInterval, d.waitTimeout, func(ctx context.Context) (*godo.KubernetesNode, bool, error) {
+		pool, resp, err := client.Kubernetes.GetNodePool(ctx, clusterID, nodePoolID)
+		// a pool that is gone cannot hold the node any more.
+		if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+			return nil, false, err
+		}
+		result.Polls++
+
+		node := findNode(pool, nodeID)
+		if node == nil {
+			result.Deleted = true
+			d.notify(ctx, req, float64(result.Polls), fmt.Sprintf("node %s removed", nodeID))
+			return nil, true, nil
+		}
+		if node.Status != nil {
+			result.LastState = node.Status.State
+		}
+		d.notify(ctx, req, float64(result.Polls), fmt.Sprintf("node %s is %s", nodeID, result.LastState))
+		return node, false, nil
+	})
+	if errors.Is(err, waiter.ErrTimeout) {
+		return nil, fmt.Errorf("timed out after %s waiting for node %s to be removed, last state %q", d.waitTimeout, nodeID, result.LastState)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// findNode returns the node with the given ID in pool, or nil.
+func findNode(pool *godo.KubernetesNodePool, nodeID string) *godo.KubernetesNode {
+	if pool == nil {
+		return nil
+	}
+	for _, node := range pool.Nodes {
+		if node != nil && node.ID == nodeID {
+			return node
+		}
+	}
+	return nil
 }
 
 // RecycleDOKSNodes recycles nodes in a node pool
@@ -725,11 +1025,11 @@ func (d *DoksTool) recycleDOKSNodes(ctx context.Context, req mcp.CallToolRequest
 	}
 
 	// Make the API call
-	_, err = client.Kubernetes.RecycleNodePoolNodes(ctx, clusterID, nodePoolID, &godo.KubernetesNodePoolRecycleNodesRequest{
+	resp, err := client.Kubernetes.RecycleNodePoolNodes(ctx, clusterID, nodePoolID, &godo.KubernetesNodePoolRecycleNodesRequest{
 		Nodes: nodeIDs,
 	})
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("failed to recycle nodes", err), nil
+		return common.APIErrorResult(ctx, d.Logger(ctx), "failed to recycle nodes", resp, err), nil
 	}
 
 	return mcp.NewToolResultText(fmt.Sprintf("Successfully recycled %d nodes in node pool %s", len(nodeIDs), nodePoolID)), nil
@@ -743,15 +1043,15 @@ func (d *DoksTool) getKubernetesOptions(ctx context.Context, _ mcp.CallToolReque
 	}
 
 	// Make the API call to get Kubernetes options
-	options, _, err := client.Kubernetes.GetOptions(ctx)
+	options, resp, err := client.Kubernetes.GetOptions(ctx)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("failed to get kubernetes options", err), nil
+		return common.APIErrorResult(ctx, d.Logger(ctx), "failed to get kubernetes options", resp, err), nil
 	}
 
 	// Marshal the response
 	optionsJSON, err := json.MarshalIndent(options, "", "  ")
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("failed to marshal kubernetes options", err), nil
+		return common.ErrorResult(ctx, d.Logger(ctx), "failed to marshal kubernetes options", err), nil
 	}
 
 	return mcp.NewToolResultText(string(optionsJSON)), nil
@@ -797,6 +1097,7 @@ func (d *DoksTool) Tools() []server.ServerTool {
 			Handler: d.getDoksCluster,
 			Tool: mcp.NewTool("doks-get-cluster",
 				mcp.WithDescription("Get a DigitalOcean Kubernetes cluster"),
+				common.WithOutputSchema[godo.KubernetesCluster](),
 				mcp.WithString("ClusterID", mcp.Required(), mcp.Description("The ID of the Kubernetes cluster")),
 			),
 		},
@@ -806,16 +1107,17 @@ func (d *DoksTool) Tools() []server.ServerTool {
 				mcp.WithDescription("List all DigitalOcean Kubernetes clusters"),
 				mcp.WithNumber("Page", mcp.DefaultNumber(1), mcp.Description("Page number of the results to fetch")),
 				mcp.WithNumber("PerPage", mcp.DefaultNumber(20), mcp.Description("Number of items returned per page")),
+				common.WithAllArg(),
 			),
 		},
 		{
 			Handler: d.createDOKSCluster,
-			Tool: mcp.NewToolWithRawSchema("doks-create-cluster",
-				"Create a new DigitalOcean Kubernetes cluster", clusterCreateSchemaJSON,
-			),
+			Tool: withDryRunArg(common.RawSchemaCreatesResource(mcp.NewToolWithRawSchema("doks-create-cluster",
+				"Create a new DigitalOcean Kubernetes cluster. With DryRun the request is validated and returned, marked dry_run, without creating the cluster", clusterCreateSchemaJSON,
+			))),
 		},
 		{
-			Handler: d.updateDOKSCluster,
+			Handler: d.locks.Serialize(clusterURN, d.updateDOKSCluster),
 			Tool: mcp.NewTool("doks-update-cluster",
 				mcp.WithDescription("Update a DigitalOcean Kubernetes cluster"),
 				mcp.WithString("ClusterID", mcp.Required(), mcp.Description("The ID of the Kubernetes cluster")),
@@ -827,14 +1129,27 @@ func (d *DoksTool) Tools() []server.ServerTool {
 			),
 		},
 		{
-			Handler: d.deleteDOKSCluster,
+			Handler: d.locks.Serialize(clusterURN, d.updateAutoscalerConfig),
+			Tool: mcp.NewTool("doks-update-autoscaler-config",
+				mcp.WithDescription("Update the cluster autoscaler configuration of a DigitalOcean Kubernetes cluster and return the new configuration. Settings that are not passed keep their current value. doks-get-cluster shows the configuration under cluster_autoscaler_configuration"),
+				mcp.WithString("ClusterID", mcp.Required(), mcp.Description("The ID of the Kubernetes cluster")),
+				mcp.WithNumber("ScaleDownUtilizationThreshold", mcp.Min(0), mcp.Max(1), mcp.Description("Node utilization, from 0 to 1, below which a node may be scaled down")),
+				mcp.WithString("ScaleDownUnneededTime", mcp.Description("How long a node must be unneeded before it is scaled down, as a duration such as 10m or 1h30m")),
+			),
+		},
+		{
+			Handler: d.locks.Serialize(clusterURN, d.deleteDOKSCluster),
 			Tool: mcp.NewTool("doks-delete-cluster",
-				mcp.WithDescription("Delete a DigitalOcean Kubernetes cluster"),
+				mcp.WithDescription("Delete a DigitalOcean Kubernetes cluster. By default only the cluster is deleted, and the delete fails while load balancers or volumes created by the cluster remain. DeleteMode dangerous also deletes every associated resource; selective deletes the listed ones. Deleted resources cannot be recovered"),
 				mcp.WithString("ClusterID", mcp.Required(), mcp.Description("The ID of the Kubernetes cluster")),
+				mcp.WithString("DeleteMode", mcp.Enum(deleteModeDefault, deleteModeDangerous, deleteModeSelective), mcp.DefaultString(deleteModeDefault), mcp.Description("default deletes only the cluster, dangerous also deletes all its associated resources, selective also deletes the resources listed in Volumes, VolumeSnapshots and LoadBalancers")),
+				mcp.WithArray("Volumes", mcp.Description("IDs of associated volumes to delete with the cluster (selective mode only)"), mcp.Items(map[string]any{"type": "string"})),
+				mcp.WithArray("VolumeSnapshots", mcp.Description("IDs of associated volume snapshots to delete with the cluster (selective mode only)"), mcp.Items(map[string]any{"type": "string"})),
+				mcp.WithArray("LoadBalancers", mcp.Description("IDs of associated load balancers to delete with the cluster (selective mode only)"), mcp.Items(map[string]any{"type": "string"})),
 			),
 		},
 		{
-			Handler: d.upgradeDOKSCluster,
+			Handler: d.locks.Serialize(clusterURN, d.upgradeDOKSCluster),
 			Tool: mcp.NewTool("doks-upgrade-cluster",
 				mcp.WithDescription("Upgrade a DigitalOcean Kubernetes cluster"),
 				mcp.WithString("ClusterID", mcp.Required(), mcp.Description("The ID of the Kubernetes cluster")),
@@ -851,7 +1166,14 @@ func (d *DoksTool) Tools() []server.ServerTool {
 		{
 			Handler: d.getDOKSClusterKubeConfig,
 			Tool: mcp.NewTool("doks-get-kubeconfig",
-				mcp.WithDescription("Get kubeconfig for a DigitalOcean Kubernetes cluster"),
+				mcp.WithDescription("Get kubeconfig for a DigitalOcean Kubernetes cluster. A second content item, {\"meta\": {...}}, names the cluster user the kubeconfig authenticates as and its RBAC groups"),
+				mcp.WithString("ClusterID", mcp.Required(), mcp.Description("The ID of the Kubernetes cluster")),
+			),
+		},
+		{
+			Handler: d.getDOKSClusterUser,
+			Tool: mcp.NewTool("doks-get-cluster-user",
+				mcp.WithDescription("Get the authenticated user for a DigitalOcean Kubernetes cluster, with the groups its RBAC bindings apply to. Useful to explain forbidden errors from the cluster"),
 				mcp.WithString("ClusterID", mcp.Required(), mcp.Description("The ID of the Kubernetes cluster")),
 			),
 		},
@@ -860,13 +1182,15 @@ func (d *DoksTool) Tools() []server.ServerTool {
 			Tool: mcp.NewTool("doks-get-credentials",
 				mcp.WithDescription("Get credentials for a DigitalOcean Kubernetes cluster"),
 				mcp.WithString("ClusterID", mcp.Required(), mcp.Description("The ID of the Kubernetes cluster")),
+				mcp.WithBoolean("TokenOnly", mcp.Description("Return only the server, an expiring token and its expiry, without the client certificate and key")),
+				mcp.WithNumber("ExpirySeconds", mcp.Description("Number of seconds until the token expires. Defaults to 3600 with TokenOnly; otherwise the API default applies")),
 			),
 		},
 		{
 			Handler: d.createDOKSNodePool,
-			Tool: mcp.NewToolWithRawSchema("doks-create-nodepool",
-				"Create a new node pool in a DigitalOcean Kubernetes cluster", nodePoolCreateSchemaJSON,
-			),
+			Tool: withDryRunArg(common.RawSchemaCreatesResource(mcp.NewToolWithRawSchema("doks-create-nodepool",
+				"Create a new node pool in a DigitalOcean Kubernetes cluster. With DryRun the request is validated and returned, marked dry_run, without creating the node pool", nodePoolCreateSchemaJSON,
+			))),
 		},
 		{
 			Handler: d.getDOKSNodePool,
@@ -916,6 +1240,7 @@ func (d *DoksTool) Tools() []server.ServerTool {
 				mcp.WithString("NodeID", mcp.Required(), mcp.Description("The ID of the node")),
 				mcp.WithBoolean("SkipDrain", mcp.Description("Whether to skip draining the node before deletion")),
 				mcp.WithBoolean("Replace", mcp.Description("Whether to replace the node after deletion")),
+				mcp.WithBoolean("Wait", mcp.DefaultBool(false), mcp.Description("Wait for the node to drain and leave the node pool, sending progress notifications while it does, and report whether the drain completed or was force-terminated (default: false)")),
 			),
 		},
 		{
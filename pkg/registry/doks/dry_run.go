@@ -0,0 +1,146 @@
+package doks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"mcp-digitalocean/internal/errutil"
+	"mcp-digitalocean/pkg/registry/common"
+)
+
+// DryRunArg makes doks-create-cluster and doks-create-nodepool validate the
+// request and return it instead of creating anything.
+const DryRunArg = "DryRun"
+
+const dryRunDescription = "Validate the request, including against the regions, versions and sizes from the Kubernetes options, and return it without creating anything"
+
+// withDryRunArg adds the DryRun argument to a raw-schema create tool.
+func withDryRunArg(t mcp.Tool) mcp.Tool {
+	common.AddRawSchemaProperty(&t, DryRunArg, map[string]any{"type": "boolean", "description": dryRunDescription})
+	return t
+}
+
+// DryRunResult is the request a create tool would have sent.
+type DryRunResult struct {
+	DryRun    bool   `json:"dry_run"`
+	ClusterID string `json:"cluster_id,omitempty"`
+	Request   any    `json:"request"`
+}
+
+// isDryRun reports whether the request asked for a dry run.
+func isDryRun(req mcp.CallToolRequest) bool {
+	dryRun, _ := req.GetArguments()[DryRunArg].(bool)
+	return dryRun
+}
+
+// nodePoolProblems returns what is wrong with a node pool create request.
+// field prefixes each problem, e.g. "node_pools[0]".
+func nodePoolProblems(field string, pool *godo.KubernetesNodePoolCreateRequest) []string {
+	var problems []string
+	if pool.Name == "" {
+		problems = append(problems, field+".name is required")
+	}
+	if pool.Size == "" {
+		problems = append(problems, field+".size is required")
+	}
+	if pool.AutoScale {
+		if pool.MaxNodes < 1 {
+			problems = append(problems, field+".max_nodes must be at least 1 with auto_scale")
+		}
+		if pool.MinNodes > pool.MaxNodes {
+			problems = append(problems, fmt.Sprintf("%s.min_nodes (%d) must not exceed max_nodes (%d)", field, pool.MinNodes, pool.MaxNodes))
+		}
+		if pool.Count != 0 && (pool.Count < pool.MinNodes || pool.Count > pool.MaxNodes) {
+			problems = append(problems, fmt.Sprintf("%s.count (%d) must be between min_nodes (%d) and max_nodes (%d)", field, pool.Count, pool.MinNodes, pool.MaxNodes))
+		}
+	} else if pool.Count < 1 {
+		problems = append(problems, field+".count must be at least 1")
+	}
+	return problems
+}
+
+// clusterProblems returns what is wrong with a cluster create request.
+func clusterProblems(create *godo.KubernetesClusterCreateRequest) []string {
+	var problems []string
+	if create.Name == "" {
+		problems = append(problems, "name is required")
+	}
+	if create.RegionSlug == "" {
+		problems = append(problems, "region is required")
+	}
+	if create.VersionSlug == "" {
+		problems = append(problems, "version is required")
+	}
+	if len(create.NodePools) == 0 {
+		problems = append(problems, "at least one node pool is required")
+	}
+	for i, pool := range create.NodePools {
+		problems = append(problems, nodePoolProblems(fmt.Sprintf("node_pools[%d]", i), pool)...)
+	}
+	return problems
+}
+
+// optionsProblems checks a region, version and node sizes against the
+// Kubernetes options. Empty region and version are not checked.
+func optionsProblems(options *godo.KubernetesOptions, region, version string, sizes map[string]string) []string {
+	var problems []string
+	if region != "" && !slices.ContainsFunc(options.Regions, func(r *godo.KubernetesRegion) bool { return r.Slug == region }) {
+		problems = append(problems, fmt.Sprintf("region %q does not offer Kubernetes", region))
+	}
+	if version != "" && version != "latest" && !slices.ContainsFunc(options.Versions, func(v *godo.KubernetesVersion) bool { return v.Slug == version }) {
+		problems = append(problems, fmt.Sprintf("version %q is not an available Kubernetes version", version))
+	}
+	fields := make([]string, 0, len(sizes))
+	for field := range sizes {
+		fields = append(fields, field)
+	}
+	slices.Sort(fields)
+	for _, field := range fields {
+		size := sizes[field]
+		if size != "" && !slices.ContainsFunc(options.Sizes, func(s *godo.KubernetesNodeSize) bool { return s.Slug == size }) {
+			problems = append(problems, fmt.Sprintf("%s.size %q is not an available node size", field, size))
+		}
+	}
+	return problems
+}
+
+// dryRun cross-checks a create request against the Kubernetes options and
+// returns it marked dry_run, or a tool error listing every problem found.
+func dryRun(ctx context.Context, client *godo.Client, problems []string, region, version string, sizes map[string]string, result DryRunResult) (*mcp.CallToolResult, error) {
+	options, resp, err := client.Kubernetes.GetOptions(ctx)
+	if err != nil {
+		return errutil.NewAPIErrorResultWithText("failed to get kubernetes options", resp, err), nil
+	}
+	problems = append(problems, optionsProblems(options, region, version, sizes)...)
+	if len(problems) > 0 {
+		return mcp.NewToolResultError("dry run found problems: " + strings.Join(problems, "; ")), nil
+	}
+
+	result.DryRun = true
+	resultJSON, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// dryRunCluster validates a cluster create request without creating it.
+func dryRunCluster(ctx context.Context, client *godo.Client, create *godo.KubernetesClusterCreateRequest) (*mcp.CallToolResult, error) {
+	sizes := make(map[string]string, len(create.NodePools))
+	for i, pool := range create.NodePools {
+		sizes[fmt.Sprintf("node_pools[%d]", i)] = pool.Size
+	}
+	return dryRun(ctx, client, clusterProblems(create), create.RegionSlug, create.VersionSlug, sizes, DryRunResult{Request: create})
+}
+
+// dryRunNodePool validates a node pool create request without creating it.
+func dryRunNodePool(ctx context.Context, client *godo.Client, clusterID string, create *godo.KubernetesNodePoolCreateRequest) (*mcp.CallToolResult, error) {
+	field := "node_pool_create_request"
+	sizes := map[string]string{field: create.Size}
+	return dryRun(ctx, client, nodePoolProblems(field, create), "", "", sizes, DryRunResult{ClusterID: clusterID, Request: create})
+}
@@ -0,0 +1,188 @@
+package doks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var testKubernetesOptions = &godo.KubernetesOptions{
+	Versions: []*godo.KubernetesVersion{{Slug: "1.33.1-do.0"}, {Slug: "1.32.5-do.0"}},
+	Regions:  []*godo.KubernetesRegion{{Slug: "nyc1"}, {Slug: "sfo3"}},
+	Sizes:    []*godo.KubernetesNodeSize{{Slug: "s-2vcpu-4gb"}, {Slug: "s-4vcpu-8gb"}},
+}
+
+func TestDoksTool_createDOKSClusterDryRun(t *testing.T) {
+	validPool := map[string]any{"name": "workers", "size": "s-2vcpu-4gb", "count": float64(3)}
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		optionsErr  error
+		wantProblem []string
+		want        *godo.KubernetesClusterCreateRequest
+	}{
+		{
+			name: "valid request is echoed",
+			args: map[string]any{
+				"name": "prod", "region": "nyc1", "version": "1.33.1-do.0", "tags": []any{"team:web"},
+				"node_pools": []any{validPool, map[string]any{"name": "burst", "size": "s-4vcpu-8gb", "auto_scale": true, "min_nodes": float64(0), "max_nodes": float64(5)}},
+				"DryRun":     true,
+			},
+			want: &godo.KubernetesClusterCreateRequest{
+				Name: "prod", RegionSlug: "nyc1", VersionSlug: "1.33.1-do.0", Tags: []string{"team:web"},
+				NodePools: []*godo.KubernetesNodePoolCreateRequest{
+					{Name: "workers", Size: "s-2vcpu-4gb", Count: 3},
+					{Name: "burst", Size: "s-4vcpu-8gb", AutoScale: true, MinNodes: 0, MaxNodes: 5},
+				},
+			},
+		},
+		{
+			name: "latest version is accepted",
+			args: map[string]any{"name": "prod", "region": "sfo3", "version": "latest", "node_pools": []any{validPool}, "DryRun": true},
+			want: &godo.KubernetesClusterCreateRequest{
+				Name: "prod", RegionSlug: "sfo3", VersionSlug: "latest",
+				NodePools: []*godo.KubernetesNodePoolCreateRequest{{Name: "workers", Size: "s-2vcpu-4gb", Count: 3}},
+			},
+		},
+		{
+			name: "local problems are all reported",
+			args: map[string]any{
+				"region": "nyc1", "version": "1.33.1-do.0",
+				"node_pools": []any{map[string]any{"name": "workers", "size": "s-2vcpu-4gb"}},
+				"DryRun":     true,
+			},
+			wantProblem: []string{"name is required", "node_pools[0].count must be at least 1"},
+		},
+		{
+			name: "options cross-check",
+			args: map[string]any{
+				"name": "prod", "region": "ams9", "version": "1.20.0-do.0",
+				"node_pools": []any{map[string]any{"name": "workers", "size": "m-64vcpu", "count": float64(1)}},
+				"DryRun":     true,
+			},
+			wantProblem: []string{
+				`region "ams9" does not offer Kubernetes`,
+				`version "1.20.0-do.0" is not an available Kubernetes version`,
+				`node_pools[0].size "m-64vcpu" is not an available node size`,
+			},
+		},
+		{
+			name:        "options api error",
+			args:        map[string]any{"name": "prod", "region": "nyc1", "version": "latest", "node_pools": []any{validPool}, "DryRun": true},
+			optionsErr:  errors.New("unavailable"),
+			wantProblem: []string{"failed to get kubernetes options"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockKubernetes := NewMockKubernetesService(ctrl)
+			// No Create expectation: the mock fails the test if it is called.
+			if tc.optionsErr != nil {
+				mockKubernetes.EXPECT().GetOptions(gomock.Any()).Return(nil, nil, tc.optionsErr)
+			} else {
+				mockKubernetes.EXPECT().GetOptions(gomock.Any()).Return(testKubernetesOptions, nil, nil)
+			}
+			tool, _ := setupDoksToolWithMock(mockKubernetes)
+
+			resp, err := tool.createDOKSCluster(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			text := resp.Content[0].(mcp.TextContent).Text
+			if tc.wantProblem != nil {
+				require.True(t, resp.IsError)
+				for _, problem := range tc.wantProblem {
+					require.Contains(t, text, problem)
+				}
+				return
+			}
+			require.False(t, resp.IsError)
+
+			var out struct {
+				DryRun  bool                                `json:"dry_run"`
+				Request godo.KubernetesClusterCreateRequest `json:"request"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(text), &out))
+			require.True(t, out.DryRun)
+			require.Equal(t, *tc.want, out.Request)
+		})
+	}
+}
+
+func TestDoksTool_createDOKSNodePoolDryRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		pool        map[string]any
+		wantProblem []string
+		want        *godo.KubernetesNodePoolCreateRequest
+	}{
+		{
+			name: "valid request is echoed",
+			pool: map[string]any{"name": "gpu", "size": "s-4vcpu-8gb", "count": float64(2), "labels": map[string]any{"role": "batch"}},
+			want: &godo.KubernetesNodePoolCreateRequest{Name: "gpu", Size: "s-4vcpu-8gb", Count: 2, Labels: map[string]string{"role": "batch"}},
+		},
+		{
+			name:        "unknown size",
+			pool:        map[string]any{"name": "gpu", "size": "gpu-h100x8", "count": float64(1)},
+			wantProblem: []string{`node_pool_create_request.size "gpu-h100x8" is not an available node size`},
+		},
+		{
+			name:        "autoscale bounds",
+			pool:        map[string]any{"name": "burst", "size": "s-2vcpu-4gb", "auto_scale": true, "min_nodes": float64(4), "max_nodes": float64(2)},
+			wantProblem: []string{"node_pool_create_request.min_nodes (4) must not exceed max_nodes (2)"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockKubernetes := NewMockKubernetesService(ctrl)
+			mockKubernetes.EXPECT().GetOptions(gomock.Any()).Return(testKubernetesOptions, nil, nil)
+			tool, _ := setupDoksToolWithMock(mockKubernetes)
+
+			args := map[string]any{"cluster_id": "cluster-1", "node_pool_create_request": tc.pool, "DryRun": true}
+			resp, err := tool.createDOKSNodePool(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+			require.NoError(t, err)
+			text := resp.Content[0].(mcp.TextContent).Text
+			if tc.wantProblem != nil {
+				require.True(t, resp.IsError)
+				for _, problem := range tc.wantProblem {
+					require.Contains(t, text, problem)
+				}
+				return
+			}
+			require.False(t, resp.IsError)
+
+			var out struct {
+				DryRun    bool                                 `json:"dry_run"`
+				ClusterID string                               `json:"cluster_id"`
+				Request   godo.KubernetesNodePoolCreateRequest `json:"request"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(text), &out))
+			require.True(t, out.DryRun)
+			require.Equal(t, "cluster-1", out.ClusterID)
+			require.Equal(t, *tc.want, out.Request)
+		})
+	}
+}
+
+func TestDoksTool_createToolsHaveDryRunArg(t *testing.T) {
+	tool, _ := setupDoksToolWithMock(nil)
+	for _, st := range tool.Tools() {
+		if st.Tool.Name != "doks-create-cluster" && st.Tool.Name != "doks-create-nodepool" {
+			continue
+		}
+		var schema struct {
+			Properties map[string]map[string]any `json:"properties"`
+		}
+		require.NoError(t, json.Unmarshal(st.Tool.RawInputSchema, &schema))
+		require.Equal(t, "boolean", schema.Properties[DryRunArg]["type"], st.Tool.Name)
+	}
+}
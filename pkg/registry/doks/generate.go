@@ -0,0 +1,3 @@
+package doks
+
+//go:generate mockgen -destination=./mocks.go -package doks github.com/digitalocean/godo KubernetesService
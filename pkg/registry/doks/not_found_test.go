@@ -0,0 +1,98 @@
+package doks
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"mcp-digitalocean/internal/testhelpers"
+	"mcp-digitalocean/pkg/registry/common"
+)
+
+func TestDoksTool_getNotFound(t *testing.T) {
+	clusterArgs := map[string]any{"ClusterID": "missing"}
+	tests := []struct {
+		name      string
+		handler   func(*DoksTool, context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)
+		args      map[string]any
+		mockSetup func(*MockKubernetesService, error)
+		resource  string
+		id        string
+	}{
+		{
+			name:    "cluster",
+			handler: (*DoksTool).getDoksCluster,
+			args:    clusterArgs,
+			mockSetup: func(m *MockKubernetesService, err error) {
+				m.EXPECT().Get(gomock.Any(), "missing").Return(nil, nil, err)
+			},
+			resource: "cluster", id: "missing",
+		},
+		{
+			name:    "upgrades",
+			handler: (*DoksTool).getDOKSClusterUpgrades,
+			args:    clusterArgs,
+			mockSetup: func(m *MockKubernetesService, err error) {
+				m.EXPECT().GetUpgrades(gomock.Any(), "missing").Return(nil, nil, err)
+			},
+			resource: "cluster", id: "missing",
+		},
+		{
+			name:    "kubeconfig",
+			handler: (*DoksTool).getDOKSClusterKubeConfig,
+			args:    clusterArgs,
+			mockSetup: func(m *MockKubernetesService, err error) {
+				m.EXPECT().GetKubeConfig(gomock.Any(), "missing", gomock.Any()).Return(nil, nil, err)
+			},
+			resource: "cluster", id: "missing",
+		},
+		{
+			name:    "cluster user",
+			handler: (*DoksTool).getDOKSClusterUser,
+			args:    clusterArgs,
+			mockSetup: func(m *MockKubernetesService, err error) {
+				m.EXPECT().GetUser(gomock.Any(), "missing").Return(nil, nil, err)
+			},
+			resource: "cluster", id: "missing",
+		},
+		{
+			name:    "credentials",
+			handler: (*DoksTool).getDOKSClusterCredentials,
+			args:    clusterArgs,
+			mockSetup: func(m *MockKubernetesService, err error) {
+				m.EXPECT().GetCredentials(gomock.Any(), "missing", gomock.Any()).Return(nil, nil, err)
+			},
+			resource: "cluster", id: "missing",
+		},
+		{
+			name:    "node pool",
+			handler: (*DoksTool).getDOKSNodePool,
+			args:    map[string]any{"ClusterID": "cluster-1", "NodePoolID": "missing"},
+			mockSetup: func(m *MockKubernetesService, err error) {
+				m.EXPECT().GetNodePool(gomock.Any(), "cluster-1", "missing").Return(nil, nil, err)
+			},
+			resource: "node pool", id: "cluster-1/missing",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockKubernetes := NewMockKubernetesService(ctrl)
+			tc.mockSetup(mockKubernetes, testhelpers.NotFoundError("/v2/kubernetes/clusters/"+tc.id))
+			tool, _ := setupDoksToolWithMock(mockKubernetes)
+
+			resp, err := tc.handler(tool, context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			require.True(t, resp.IsError)
+			var out common.NotFound
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+			require.Equal(t, common.ErrorCodeNotFound, out.Code)
+			require.Equal(t, tc.resource, out.Resource)
+			require.Equal(t, tc.id, out.ID)
+		})
+	}
+}
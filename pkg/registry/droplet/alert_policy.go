@@ -0,0 +1,101 @@
+package droplet
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/digitalocean/godo"
+
+	"mcp-digitalocean/pkg/registry/internal/enums"
+)
+
+// dropletAlertTypePrefix is the prefix of the alert types that can be scoped to
+// a single droplet.
+const dropletAlertTypePrefix = "v1/insights/droplet/"
+
+// alertWindows are the evaluation windows the Monitoring API accepts.
+var alertWindows = []string{"5m", "10m", "30m", "1h"}
+
+// dropletAlertPolicy is the AlertPolicy argument of droplet-create.
+type dropletAlertPolicy struct {
+	Type    string
+	Compare godo.AlertPolicyComp
+	Value   float32
+	Window  string
+	Emails  []string
+}
+
+// parseAlertPolicy reads the optional AlertPolicy argument. It returns nil when
+// the argument is absent and a non-empty message when it is invalid.
+func parseAlertPolicy(args map[string]any) (*dropletAlertPolicy, string) {
+	raw, ok := args["AlertPolicy"].(map[string]any)
+	if !ok || raw == nil {
+		return nil, ""
+	}
+
+	policy := &dropletAlertPolicy{
+		Type:    godo.DropletCPUUtilizationPercent,
+		Compare: godo.AlertPolicyComp(stringArg(raw, "Compare")),
+		Window:  stringArg(raw, "Window"),
+	}
+	if alertType := stringArg(raw, "Type"); alertType != "" {
+		policy.Type = alertType
+	}
+	if !strings.HasPrefix(policy.Type, dropletAlertTypePrefix) {
+		return nil, fmt.Sprintf("AlertPolicy.Type must be a droplet alert type starting with %q, got %q", dropletAlertTypePrefix, policy.Type)
+	}
+	if err := enums.AlertComparisons.Validate("AlertPolicy.Compare", string(policy.Compare)); err != nil {
+		return nil, err.Error()
+	}
+	value, ok := raw["Value"].(float64)
+	if !ok {
+		return nil, "AlertPolicy.Value is required"
+	}
+	policy.Value = float32(value)
+	if !slices.Contains(alertWindows, policy.Window) {
+		return nil, fmt.Sprintf("AlertPolicy.Window must be one of %s", strings.Join(alertWindows, ", "))
+	}
+	if emails, ok := raw["Emails"].([]any); ok {
+		for _, v := range emails {
+			if email, ok := v.(string); ok && email != "" {
+				policy.Emails = append(policy.Emails, email)
+			}
+		}
+	}
+	if len(policy.Emails) == 0 {
+		return nil, "AlertPolicy.Emails must list at least one address"
+	}
+	return policy, ""
+}
+
+func stringArg(args map[string]any, key string) string {
+	s, _ := args[key].(string)
+	return s
+}
+
+// create creates the policy scoped to the given droplet.
+func (p *dropletAlertPolicy) create(ctx context.Context, client *godo.Client, droplet *godo.Droplet) (*godo.AlertPolicy, error) {
+	enabled := true
+	policy, _, err := client.Monitoring.CreateAlertPolicy(ctx, &godo.AlertPolicyCreateRequest{
+		Type:        p.Type,
+		Description: fmt.Sprintf("%s %s %g over %s on droplet %s", strings.TrimPrefix(p.Type, dropletAlertTypePrefix), p.Compare, p.Value, p.Window, droplet.Name),
+		Compare:     p.Compare,
+		Value:       p.Value,
+		Window:      p.Window,
+		Entities:    []string{strconv.Itoa(droplet.ID)},
+		Alerts:      godo.Alerts{Email: p.Emails},
+		Enabled:     &enabled,
+	})
+	return policy, err
+}
+
+// createdDroplet is the droplet-create result when an AlertPolicy was
+// requested. The droplet is returned even if the policy could not be created.
+type createdDroplet struct {
+	*godo.Droplet
+	AlertPolicy *godo.AlertPolicy `json:"alert_policy,omitempty"`
+	Warning     string            `json:"warning,omitempty"`
+}
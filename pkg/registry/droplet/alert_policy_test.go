@@ -0,0 +1,165 @@
+package droplet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestParseAlertPolicy(t *testing.T) {
+	valid := func() map[string]any {
+		return map[string]any{
+			"Compare": "GreaterThan",
+			"Value":   float64(80),
+			"Window":  "5m",
+			"Emails":  []any{"ops@example.com"},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		modify      func(policy map[string]any)
+		expectedErr string
+	}{
+		{name: "defaults to cpu", modify: func(map[string]any) {}},
+		{
+			name:        "non-droplet type",
+			modify:      func(p map[string]any) { p["Type"] = "v1/dbaas/alerts/cpu_alerts" },
+			expectedErr: "AlertPolicy.Type must be a droplet alert type",
+		},
+		{
+			name:        "bad compare",
+			modify:      func(p map[string]any) { p["Compare"] = ">" },
+			expectedErr: "AlertPolicy.Compare must be one of GreaterThan, LessThan",
+		},
+		{
+			name:        "missing value",
+			modify:      func(p map[string]any) { delete(p, "Value") },
+			expectedErr: "AlertPolicy.Value is required",
+		},
+		{
+			name:        "bad window",
+			modify:      func(p map[string]any) { p["Window"] = "2m" },
+			expectedErr: "AlertPolicy.Window must be one of 5m, 10m, 30m, 1h",
+		},
+		{
+			name:        "no emails",
+			modify:      func(p map[string]any) { p["Emails"] = []any{} },
+			expectedErr: "AlertPolicy.Emails must list at least one address",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			raw := valid()
+			tc.modify(raw)
+			policy, message := parseAlertPolicy(map[string]any{"AlertPolicy": raw})
+			if tc.expectedErr != "" {
+				require.Nil(t, policy)
+				require.Contains(t, message, tc.expectedErr)
+				return
+			}
+			require.Empty(t, message)
+			require.Equal(t, &dropletAlertPolicy{
+				Type:    godo.DropletCPUUtilizationPercent,
+				Compare: godo.GreaterThan,
+				Value:   80,
+				Window:  "5m",
+				Emails:  []string{"ops@example.com"},
+			}, policy)
+		})
+	}
+
+	policy, message := parseAlertPolicy(map[string]any{})
+	require.Nil(t, policy)
+	require.Empty(t, message)
+}
+
+func TestDropletTool_createDropletWithAlertPolicy(t *testing.T) {
+	args := map[string]any{
+		"Name":      "web-1",
+		"Size":      "s-1vcpu-1gb",
+		"ImageSlug": "ubuntu-24-04-x64",
+		"Region":    "nyc3",
+		"AlertPolicy": map[string]any{
+			"Type":    "v1/insights/droplet/cpu",
+			"Compare": "GreaterThan",
+			"Value":   float64(80),
+			"Window":  "5m",
+			"Emails":  []any{"ops@example.com"},
+		},
+	}
+	droplet := &godo.Droplet{ID: 123, Name: "web-1"}
+	enabled := true
+	expectedRequest := &godo.AlertPolicyCreateRequest{
+		Type:        godo.DropletCPUUtilizationPercent,
+		Description: "cpu GreaterThan 80 over 5m on droplet web-1",
+		Compare:     godo.GreaterThan,
+		Value:       80,
+		Window:      "5m",
+		Entities:    []string{"123"},
+		Alerts:      godo.Alerts{Email: []string{"ops@example.com"}},
+		Enabled:     &enabled,
+	}
+
+	tests := []struct {
+		name            string
+		policy          *godo.AlertPolicy
+		policyErr       error
+		expectedWarning string
+	}{
+		{
+			name:   "policy created",
+			policy: &godo.AlertPolicy{UUID: "policy-1", Type: godo.DropletCPUUtilizationPercent, Entities: []string{"123"}},
+		},
+		{
+			name:            "policy fails",
+			policyErr:       errors.New("422 invalid window"),
+			expectedWarning: "droplet created, but the alert policy could not be: 422 invalid window",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockDroplets := NewMockDropletsService(ctrl)
+			mockMonitoring := NewMockMonitoringService(ctrl)
+			mockDroplets.EXPECT().Create(gomock.Any(), gomock.Any()).Return(droplet, nil, nil)
+			mockMonitoring.EXPECT().CreateAlertPolicy(gomock.Any(), expectedRequest).Return(tc.policy, nil, tc.policyErr)
+
+			tool := NewDropletTool(func(ctx context.Context) (*godo.Client, error) {
+				return &godo.Client{Droplets: mockDroplets, Monitoring: mockMonitoring}, nil
+			})
+			resp, err := tool.createDroplet(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+			require.NoError(t, err)
+			require.False(t, resp.IsError)
+
+			var out struct {
+				ID          int               `json:"id"`
+				Name        string            `json:"name"`
+				AlertPolicy *godo.AlertPolicy `json:"alert_policy"`
+				Warning     string            `json:"warning"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+			require.Equal(t, 123, out.ID)
+			require.Equal(t, "web-1", out.Name)
+			require.Equal(t, tc.policy, out.AlertPolicy)
+			require.Equal(t, tc.expectedWarning, out.Warning)
+		})
+	}
+
+	t.Run("invalid policy creates nothing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		tool := setupDropletToolWithMocks(NewMockDropletsService(ctrl), nil)
+		invalid := map[string]any{"Name": "web-1", "Size": "s-1vcpu-1gb", "ImageSlug": "ubuntu-24-04-x64", "Region": "nyc3", "AlertPolicy": map[string]any{"Compare": "GreaterThan"}}
+		resp, err := tool.createDroplet(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: invalid}})
+		require.NoError(t, err)
+		require.True(t, resp.IsError)
+	})
+}
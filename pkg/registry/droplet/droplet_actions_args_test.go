@@ -0,0 +1,103 @@
+package droplet
+
+import (
+	"context"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequiredInt(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		want    int
+		wantErr bool
+	}{
+		{name: "number", value: float64(123), want: 123},
+		{name: "numeric string", value: "123", want: 123},
+		{name: "numeric string with spaces", value: " 123 ", want: 123},
+		{name: "missing", value: nil, wantErr: true},
+		{name: "fraction", value: 1.5, wantErr: true},
+		{name: "zero", value: float64(0), wantErr: true},
+		{name: "negative", value: float64(-1), wantErr: true},
+		{name: "non-numeric string", value: "abc", wantErr: true},
+		{name: "bool", value: true, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			args := map[string]any{}
+			if tc.value != nil {
+				args["ID"] = tc.value
+			}
+			got, errResult := requiredInt(args, "ID")
+			if tc.wantErr {
+				require.NotNil(t, errResult)
+				require.True(t, errResult.IsError)
+				require.Equal(t, "ID is required and must be a number", errResult.Content[0].(mcp.TextContent).Text)
+				return
+			}
+			require.Nil(t, errResult)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+// TestDropletActionsTool_invalidArguments calls every droplet action tool with
+// each required argument missing or of the wrong type. The handlers must
+// report a tool error before building a client rather than panic.
+func TestDropletActionsTool_invalidArguments(t *testing.T) {
+	validValues := map[string]any{
+		"number": float64(123),
+		"string": "web-1",
+	}
+	wrongValues := map[string]any{
+		"number": "not-a-number",
+		"string": float64(123),
+	}
+
+	tool := NewDropletActionsTool(func(ctx context.Context) (*godo.Client, error) {
+		t.Fatal("the client must not be requested for invalid arguments")
+		return nil, nil
+	})
+	for _, st := range tool.Tools() {
+		schema := st.Tool.InputSchema
+		for _, arg := range schema.Required {
+			argType := schema.Properties[arg].(map[string]any)["type"].(string)
+			valid := map[string]any{}
+			for _, other := range schema.Required {
+				prop := schema.Properties[other].(map[string]any)
+				valid[other] = validValues[prop["type"].(string)]
+				if enum, ok := prop["enum"].([]string); ok {
+					valid[other] = enum[0]
+				}
+			}
+
+			cases := map[string]any{"missing": nil, "wrong type": wrongValues[argType]}
+			for name, value := range cases {
+				t.Run(st.Tool.Name+"/"+arg+"/"+name, func(t *testing.T) {
+					args := map[string]any{}
+					for k, v := range valid {
+						args[k] = v
+					}
+					delete(args, arg)
+					if value != nil {
+						args[arg] = value
+					}
+
+					req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
+					var result *mcp.CallToolResult
+					var err error
+					require.NotPanics(t, func() {
+						result, err = st.Handler(context.Background(), req)
+					})
+					require.NoError(t, err)
+					require.True(t, result.IsError)
+					require.Contains(t, result.Content[0].(mcp.TextContent).Text, arg)
+				})
+			}
+		}
+	}
+}
@@ -5,38 +5,104 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
+	"mcp-digitalocean/internal/errutil"
 	"mcp-digitalocean/pkg/registry/common"
 )
 
+// minActionPollInterval is the shortest PollIntervalSeconds
+// droplet-action-wait accepts, so a wait cannot hammer the API.
+const minActionPollInterval = time.Second
+
 // DropletActionsTool provides tools for droplet actions
 type DropletActionsTool struct {
-	client func(ctx context.Context) (*godo.Client, error)
+	client          func(ctx context.Context) (*godo.Client, error)
+	minPollInterval time.Duration
 }
 
 // NewDropletActionsTool creates a new droplet actions tool
 func NewDropletActionsTool(client func(ctx context.Context) (*godo.Client, error)) *DropletActionsTool {
 	return &DropletActionsTool{
-		client: client,
+		client:          client,
+		minPollInterval: minActionPollInterval,
+	}
+}
+
+// noCredentials is the tool error returned when no DigitalOcean client can be
+// built for the request, e.g. an HTTP request without a bearer token.
+func noCredentials(err error) *mcp.CallToolResult {
+	return mcp.NewToolResultErrorFromErr("no credentials provided", err)
+}
+
+// requiredInt reads the required numeric argument name as a positive whole number.
+// Some clients send IDs as strings, so numeric strings such as "12345" are
+// accepted as well.
+func requiredInt(args map[string]any, name string) (int, *mcp.CallToolResult) {
+	switch v := args[name].(type) {
+	case float64:
+		if v > 0 && v == math.Trunc(v) && v <= math.MaxInt32 {
+			return int(v), nil
+		}
+	case string:
+		if n, err := strconv.Atoi(strings.TrimSpace(v)); err == nil && n > 0 {
+			return n, nil
+		}
+	}
+	return 0, mcp.NewToolResultError(name + " is required and must be a number")
+}
+
+// maxExactID is the largest whole number a JSON number holds exactly.
+const maxExactID = 1<<53 - 1
+
+// requiredID reads the required numeric argument name as a positive whole
+// number like requiredInt, but without its MaxInt32 cap, for IDs such as action
+// IDs that have outgrown it.
+func requiredID(args map[string]any, name string) (int, *mcp.CallToolResult) {
+	switch v := args[name].(type) {
+	case float64:
+		if v > 0 && v == math.Trunc(v) && v <= maxExactID && v <= math.MaxInt {
+			return int(v), nil
+		}
+	case string:
+		if n, err := strconv.Atoi(strings.TrimSpace(v)); err == nil && n > 0 {
+			return n, nil
+		}
+	}
+	return 0, mcp.NewToolResultError(name + " is required and must be a number")
+}
+
+// requiredString reads the required string argument name, which must not be empty.
+func requiredString(args map[string]any, name string) (string, *mcp.CallToolResult) {
+	s, ok := args[name].(string)
+	if !ok || s == "" {
+		return "", mcp.NewToolResultError(name + " is required and must be a string")
 	}
+	return s, nil
 }
 
 // rebootDroplet reboots a droplet
 func (da *DropletActionsTool) rebootDroplet(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	dropletID := req.GetArguments()["ID"].(float64)
+	dropletID, errResult := requiredInt(req.GetArguments(), "ID")
+	if errResult != nil {
+		return errResult, nil
+	}
 
 	client, err := da.client(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+		return noCredentials(err), nil
 	}
 
-	action, _, err := client.DropletActions.Reboot(ctx, int(dropletID))
+	action, resp, err := client.DropletActions.Reboot(ctx, dropletID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return errutil.NewAPIErrorResult(resp, err), nil
 	}
 
 	jsonAction, err := json.MarshalIndent(action, "", "  ")
@@ -47,18 +113,75 @@ func (da *DropletActionsTool) rebootDroplet(ctx context.Context, req mcp.CallToo
 	return mcp.NewToolResultText(string(jsonAction)), nil
 }
 
+// waitForAction polls a droplet action until it completes or errors, the
+// timeout elapses or the client goes away, and returns the final action.
+func (da *DropletActionsTool) waitForAction(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	dropletID, errResult := requiredInt(args, "DropletID")
+	if errResult != nil {
+		return errResult, nil
+	}
+	actionID, errResult := requiredID(args, "ActionID")
+	if errResult != nil {
+		return errResult, nil
+	}
+	interval := common.DefaultActionPollInterval
+	if secs, ok := args["PollIntervalSeconds"].(float64); ok {
+		interval = time.Duration(secs * float64(time.Second))
+		if interval < da.minPollInterval {
+			return mcp.NewToolResultError(fmt.Sprintf("PollIntervalSeconds must be at least %v", da.minPollInterval.Seconds())), nil
+		}
+	}
+	timeout := common.DefaultActionWaitTimeout
+	if secs, ok := args["TimeoutSeconds"].(float64); ok {
+		if secs <= 0 {
+			return mcp.NewToolResultError("TimeoutSeconds must be positive"), nil
+		}
+		timeout = time.Duration(secs * float64(time.Second))
+	}
+
+	client, err := da.client(ctx)
+	if err != nil {
+		return noCredentials(err), nil
+	}
+
+	action, err := common.WaitForAction(ctx, func(ctx context.Context) (*godo.Action, *godo.Response, error) {
+		return client.DropletActions.Get(ctx, dropletID, actionID)
+	}, interval, timeout)
+	if err != nil {
+		result := mcp.NewToolResultErrorFromErr("failed waiting for action", err)
+		// the final action of an errored or timed out wait says what it was
+		// and when it started.
+		if action != nil {
+			if jsonAction, err := json.MarshalIndent(action, "", "  "); err == nil {
+				result.Content = append(result.Content, mcp.NewTextContent(string(jsonAction)))
+			}
+		}
+		return result, nil
+	}
+
+	jsonAction, err := json.MarshalIndent(action, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(string(jsonAction)), nil
+}
+
 // passwordResetDroplet resets the password for a droplet
 func (da *DropletActionsTool) passwordResetDroplet(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	dropletID := req.GetArguments()["ID"].(float64)
+	dropletID, errResult := requiredInt(req.GetArguments(), "ID")
+	if errResult != nil {
+		return errResult, nil
+	}
 
 	client, err := da.client(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+		return noCredentials(err), nil
 	}
 
-	action, _, err := client.DropletActions.PasswordReset(ctx, int(dropletID))
+	action, resp, err := client.DropletActions.PasswordReset(ctx, dropletID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return errutil.NewAPIErrorResult(resp, err), nil
 	}
 
 	jsonAction, err := json.MarshalIndent(action, "", "  ")
@@ -71,17 +194,23 @@ func (da *DropletActionsTool) passwordResetDroplet(ctx context.Context, req mcp.
 
 // RebuildByImageSlugDroplet rebuilds a droplet using an image slug
 func (da *DropletActionsTool) rebuildByImageSlugDroplet(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	dropletID := req.GetArguments()["ID"].(float64)
-	imageSlug := req.GetArguments()["ImageSlug"].(string)
+	dropletID, errResult := requiredInt(req.GetArguments(), "ID")
+	if errResult != nil {
+		return errResult, nil
+	}
+	imageSlug, errResult := requiredString(req.GetArguments(), "ImageSlug")
+	if errResult != nil {
+		return errResult, nil
+	}
 
 	client, err := da.client(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+		return noCredentials(err), nil
 	}
 
-	action, _, err := client.DropletActions.RebuildByImageSlug(ctx, int(dropletID), imageSlug)
+	action, resp, err := client.DropletActions.RebuildByImageSlug(ctx, dropletID, imageSlug)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return errutil.NewAPIErrorResult(resp, err), nil
 	}
 
 	jsonAction, err := json.MarshalIndent(action, "", "  ")
@@ -94,16 +223,19 @@ func (da *DropletActionsTool) rebuildByImageSlugDroplet(ctx context.Context, req
 
 // powerCycleByTag power cycles droplets by tag
 func (da *DropletActionsTool) powerCycleByTag(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	tag := req.GetArguments()["Tag"].(string)
+	tag, errResult := requiredString(req.GetArguments(), "Tag")
+	if errResult != nil {
+		return errResult, nil
+	}
 
 	client, err := da.client(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+		return noCredentials(err), nil
 	}
 
-	actions, _, err := client.DropletActions.PowerCycleByTag(ctx, tag)
+	actions, resp, err := client.DropletActions.PowerCycleByTag(ctx, tag)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return errutil.NewAPIErrorResult(resp, err), nil
 	}
 
 	jsonActions, err := json.MarshalIndent(actions, "", "  ")
@@ -116,16 +248,19 @@ func (da *DropletActionsTool) powerCycleByTag(ctx context.Context, req mcp.CallT
 
 // powerOnByTag powers on droplets by tag
 func (da *DropletActionsTool) powerOnByTag(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	tag := req.GetArguments()["Tag"].(string)
+	tag, errResult := requiredString(req.GetArguments(), "Tag")
+	if errResult != nil {
+		return errResult, nil
+	}
 
 	client, err := da.client(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+		return noCredentials(err), nil
 	}
 
-	actions, _, err := client.DropletActions.PowerOnByTag(ctx, tag)
+	actions, resp, err := client.DropletActions.PowerOnByTag(ctx, tag)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return errutil.NewAPIErrorResult(resp, err), nil
 	}
 
 	jsonActions, err := json.MarshalIndent(actions, "", "  ")
@@ -138,16 +273,19 @@ func (da *DropletActionsTool) powerOnByTag(ctx context.Context, req mcp.CallTool
 
 // powerOffByTag powers off droplets by tag
 func (da *DropletActionsTool) powerOffByTag(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	tag := req.GetArguments()["Tag"].(string)
+	tag, errResult := requiredString(req.GetArguments(), "Tag")
+	if errResult != nil {
+		return errResult, nil
+	}
 
 	client, err := da.client(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+		return noCredentials(err), nil
 	}
 
-	actions, _, err := client.DropletActions.PowerOffByTag(ctx, tag)
+	actions, resp, err := client.DropletActions.PowerOffByTag(ctx, tag)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return errutil.NewAPIErrorResult(resp, err), nil
 	}
 
 	jsonActions, err := json.MarshalIndent(actions, "", "  ")
@@ -160,16 +298,19 @@ func (da *DropletActionsTool) powerOffByTag(ctx context.Context, req mcp.CallToo
 
 // shutdownByTag shuts down droplets by tag
 func (da *DropletActionsTool) shutdownByTag(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	tag := req.GetArguments()["Tag"].(string)
+	tag, errResult := requiredString(req.GetArguments(), "Tag")
+	if errResult != nil {
+		return errResult, nil
+	}
 
 	client, err := da.client(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+		return noCredentials(err), nil
 	}
 
-	actions, _, err := client.DropletActions.ShutdownByTag(ctx, tag)
+	actions, resp, err := client.DropletActions.ShutdownByTag(ctx, tag)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return errutil.NewAPIErrorResult(resp, err), nil
 	}
 
 	jsonActions, err := json.MarshalIndent(actions, "", "  ")
@@ -182,16 +323,19 @@ func (da *DropletActionsTool) shutdownByTag(ctx context.Context, req mcp.CallToo
 
 // enableBackupsByTag enables backups on droplets by tag
 func (da *DropletActionsTool) enableBackupsByTag(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	tag := req.GetArguments()["Tag"].(string)
+	tag, errResult := requiredString(req.GetArguments(), "Tag")
+	if errResult != nil {
+		return errResult, nil
+	}
 
 	client, err := da.client(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+		return noCredentials(err), nil
 	}
 
-	actions, _, err := client.DropletActions.EnableBackupsByTag(ctx, tag)
+	actions, resp, err := client.DropletActions.EnableBackupsByTag(ctx, tag)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return errutil.NewAPIErrorResult(resp, err), nil
 	}
 
 	jsonActions, err := json.MarshalIndent(actions, "", "  ")
@@ -204,16 +348,19 @@ func (da *DropletActionsTool) enableBackupsByTag(ctx context.Context, req mcp.Ca
 
 // disableBackupsByTag disables backups on droplets by tag
 func (da *DropletActionsTool) disableBackupsByTag(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	tag := req.GetArguments()["Tag"].(string)
+	tag, errResult := requiredString(req.GetArguments(), "Tag")
+	if errResult != nil {
+		return errResult, nil
+	}
 
 	client, err := da.client(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+		return noCredentials(err), nil
 	}
 
-	actions, _, err := client.DropletActions.DisableBackupsByTag(ctx, tag)
+	actions, resp, err := client.DropletActions.DisableBackupsByTag(ctx, tag)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return errutil.NewAPIErrorResult(resp, err), nil
 	}
 
 	jsonActions, err := json.MarshalIndent(actions, "", "  ")
@@ -224,19 +371,32 @@ func (da *DropletActionsTool) disableBackupsByTag(ctx context.Context, req mcp.C
 	return mcp.NewToolResultText(string(jsonActions)), nil
 }
 
-// snapshotByTag takes a snapshot of droplets by tag
+// snapshotByTag takes a snapshot of droplets by tag. With PerDroplet set, it
+// snapshots each tagged droplet separately and reports the action per droplet.
 func (da *DropletActionsTool) snapshotByTag(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	tag := req.GetArguments()["Tag"].(string)
-	name := req.GetArguments()["Name"].(string)
+	tag, errResult := requiredString(req.GetArguments(), "Tag")
+	if errResult != nil {
+		return errResult, nil
+	}
+	name, _ := req.GetArguments()["Name"].(string)
+	if !common.ValidSnapshotName(name) {
+		return mcp.NewToolResultError("Name must be 1-255 printable characters"), nil
+	}
+
+	if perDroplet, _ := req.GetArguments()["PerDroplet"].(bool); perDroplet {
+		return da.tagFanOutResult(ctx, req, func(ctx context.Context, client *godo.Client, id int) (*godo.Action, *godo.Response, error) {
+			return client.DropletActions.Snapshot(ctx, id, name)
+		})
+	}
 
 	client, err := da.client(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+		return noCredentials(err), nil
 	}
 
-	actions, _, err := client.DropletActions.SnapshotByTag(ctx, tag, name)
+	actions, resp, err := client.DropletActions.SnapshotByTag(ctx, tag, name)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return errutil.NewAPIErrorResult(resp, err), nil
 	}
 
 	jsonActions, err := json.MarshalIndent(actions, "", "  ")
@@ -249,16 +409,19 @@ func (da *DropletActionsTool) snapshotByTag(ctx context.Context, req mcp.CallToo
 
 // enableIPv6ByTag enables IPv6 on droplets by tag
 func (da *DropletActionsTool) enableIPv6ByTag(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	tag := req.GetArguments()["Tag"].(string)
+	tag, errResult := requiredString(req.GetArguments(), "Tag")
+	if errResult != nil {
+		return errResult, nil
+	}
 
 	client, err := da.client(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+		return noCredentials(err), nil
 	}
 
-	actions, _, err := client.DropletActions.EnableIPv6ByTag(ctx, tag)
+	actions, resp, err := client.DropletActions.EnableIPv6ByTag(ctx, tag)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return errutil.NewAPIErrorResult(resp, err), nil
 	}
 
 	jsonActions, err := json.MarshalIndent(actions, "", "  ")
@@ -271,16 +434,19 @@ func (da *DropletActionsTool) enableIPv6ByTag(ctx context.Context, req mcp.CallT
 
 // enablePrivateNetworkingByTag enables private networking on droplets by tag
 func (da *DropletActionsTool) enablePrivateNetworkingByTag(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	tag := req.GetArguments()["Tag"].(string)
+	tag, errResult := requiredString(req.GetArguments(), "Tag")
+	if errResult != nil {
+		return errResult, nil
+	}
 
 	client, err := da.client(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+		return noCredentials(err), nil
 	}
 
-	actions, _, err := client.DropletActions.EnablePrivateNetworkingByTag(ctx, tag)
+	actions, resp, err := client.DropletActions.EnablePrivateNetworkingByTag(ctx, tag)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return errutil.NewAPIErrorResult(resp, err), nil
 	}
 
 	jsonActions, err := json.MarshalIndent(actions, "", "  ")
@@ -293,16 +459,19 @@ func (da *DropletActionsTool) enablePrivateNetworkingByTag(ctx context.Context,
 
 // powerCycleDroplet power cycles a droplet
 func (da *DropletActionsTool) powerCycleDroplet(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	dropletID := req.GetArguments()["ID"].(float64)
+	dropletID, errResult := requiredInt(req.GetArguments(), "ID")
+	if errResult != nil {
+		return errResult, nil
+	}
 
 	client, err := da.client(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+		return noCredentials(err), nil
 	}
 
-	action, _, err := client.DropletActions.PowerCycle(ctx, int(dropletID))
+	action, resp, err := client.DropletActions.PowerCycle(ctx, dropletID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return errutil.NewAPIErrorResult(resp, err), nil
 	}
 
 	jsonAction, err := json.MarshalIndent(action, "", "  ")
@@ -315,16 +484,19 @@ func (da *DropletActionsTool) powerCycleDroplet(ctx context.Context, req mcp.Cal
 
 // powerOnDroplet powers on a droplet
 func (da *DropletActionsTool) powerOnDroplet(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	dropletID := req.GetArguments()["ID"].(float64)
+	dropletID, errResult := requiredInt(req.GetArguments(), "ID")
+	if errResult != nil {
+		return errResult, nil
+	}
 
 	client, err := da.client(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+		return noCredentials(err), nil
 	}
 
-	action, _, err := client.DropletActions.PowerOn(ctx, int(dropletID))
+	action, resp, err := client.DropletActions.PowerOn(ctx, dropletID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return errutil.NewAPIErrorResult(resp, err), nil
 	}
 
 	jsonAction, err := json.MarshalIndent(action, "", "  ")
@@ -337,16 +509,19 @@ func (da *DropletActionsTool) powerOnDroplet(ctx context.Context, req mcp.CallTo
 
 // powerOffDroplet powers off a droplet
 func (da *DropletActionsTool) powerOffDroplet(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	dropletID := req.GetArguments()["ID"].(float64)
+	dropletID, errResult := requiredInt(req.GetArguments(), "ID")
+	if errResult != nil {
+		return errResult, nil
+	}
 
 	client, err := da.client(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+		return noCredentials(err), nil
 	}
 
-	action, _, err := client.DropletActions.PowerOff(ctx, int(dropletID))
+	action, resp, err := client.DropletActions.PowerOff(ctx, dropletID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return errutil.NewAPIErrorResult(resp, err), nil
 	}
 
 	jsonAction, err := json.MarshalIndent(action, "", "  ")
@@ -359,16 +534,19 @@ func (da *DropletActionsTool) powerOffDroplet(ctx context.Context, req mcp.CallT
 
 // shutdownDroplet shuts down a droplet
 func (da *DropletActionsTool) shutdownDroplet(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	dropletID := req.GetArguments()["ID"].(float64)
+	dropletID, errResult := requiredInt(req.GetArguments(), "ID")
+	if errResult != nil {
+		return errResult, nil
+	}
 
 	client, err := da.client(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+		return noCredentials(err), nil
 	}
 
-	action, _, err := client.DropletActions.Shutdown(ctx, int(dropletID))
+	action, resp, err := client.DropletActions.Shutdown(ctx, dropletID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return errutil.NewAPIErrorResult(resp, err), nil
 	}
 
 	jsonAction, err := json.MarshalIndent(action, "", "  ")
@@ -381,17 +559,23 @@ func (da *DropletActionsTool) shutdownDroplet(ctx context.Context, req mcp.CallT
 
 // restoreDroplet restores a droplet to a backup image
 func (da *DropletActionsTool) restoreDroplet(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	dropletID := req.GetArguments()["ID"].(float64)
-	imageID := req.GetArguments()["ImageID"].(float64)
+	dropletID, errResult := requiredInt(req.GetArguments(), "ID")
+	if errResult != nil {
+		return errResult, nil
+	}
+	imageID, errResult := requiredInt(req.GetArguments(), "ImageID")
+	if errResult != nil {
+		return errResult, nil
+	}
 
 	client, err := da.client(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+		return noCredentials(err), nil
 	}
 
-	action, _, err := client.DropletActions.Restore(ctx, int(dropletID), int(imageID))
+	action, resp, err := client.DropletActions.Restore(ctx, dropletID, imageID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return errutil.NewAPIErrorResult(resp, err), nil
 	}
 
 	jsonAction, err := json.MarshalIndent(action, "", "  ")
@@ -402,23 +586,91 @@ func (da *DropletActionsTool) restoreDroplet(ctx context.Context, req mcp.CallTo
 	return mcp.NewToolResultText(string(jsonAction)), nil
 }
 
-// resizeDroplet resizes a droplet
+// findSize looks up a droplet size by slug. The sizes API has no get-by-slug
+// endpoint, so every page of sizes is fetched and searched. A nil size with a
+// nil error means the slug does not exist.
+func findSize(ctx context.Context, client *godo.Client, slug string) (*godo.Size, error) {
+	sizes, err := common.FetchAll(ctx, common.MaxPerPage, client.Sizes.List)
+	if err != nil {
+		return nil, err
+	}
+	for i := range sizes {
+		if sizes[i].Slug == slug {
+			return &sizes[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// validateResize checks a resize of droplet to size before it is sent to the
+// API, which otherwise rejects invalid resizes with a vague message. Disks can
+// never shrink, and the target disk must fit the droplet's image. The returned
+// warning is non-empty when the resize permanently grows the disk.
+func validateResize(droplet *godo.Droplet, size *godo.Size, resizeDisk bool) (string, error) {
+	if size.Disk < droplet.Disk {
+		return "", fmt.Errorf("size %s has a %dGB disk but droplet %d already has a %dGB disk; disks can never be shrunk, so choose a size with at least %dGB of disk",
+			size.Slug, size.Disk, droplet.ID, droplet.Disk, droplet.Disk)
+	}
+	if droplet.Image != nil && size.Disk < droplet.Image.MinDiskSize {
+		return "", fmt.Errorf("size %s has a %dGB disk but the droplet's image %q requires at least %dGB",
+			size.Slug, size.Disk, droplet.Image.Name, droplet.Image.MinDiskSize)
+	}
+	if resizeDisk && size.Disk > droplet.Disk {
+		return fmt.Sprintf("ResizeDisk grows the disk from %dGB to %dGB. This is permanent: the droplet can no longer be resized to a size with less than %dGB of disk.",
+			droplet.Disk, size.Disk, size.Disk), nil
+	}
+	return "", nil
+}
+
+// resizeDroplet resizes a droplet after validating the target size against
+// the droplet's current disk and image.
 func (da *DropletActionsTool) resizeDroplet(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	dropletID := req.GetArguments()["ID"].(float64)
-	size := req.GetArguments()["Size"].(string)
+	dropletID, errResult := requiredInt(req.GetArguments(), "ID")
+	if errResult != nil {
+		return errResult, nil
+	}
+	sizeSlug, errResult := requiredString(req.GetArguments(), "Size")
+	if errResult != nil {
+		return errResult, nil
+	}
 	resizeDisk, _ := req.GetArguments()["ResizeDisk"].(bool) // Defaults to false
 
 	client, err := da.client(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+		return noCredentials(err), nil
 	}
 
-	action, _, err := client.DropletActions.Resize(ctx, int(dropletID), size, resizeDisk)
+	droplet, resp, err := client.Droplets.Get(ctx, dropletID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return errutil.NewAPIErrorResult(resp, err), nil
 	}
 
-	jsonAction, err := json.MarshalIndent(action, "", "  ")
+	size, err := findSize(ctx, client, sizeSlug)
+	if err != nil {
+		return errutil.NewAPIErrorResult(nil, err), nil
+	}
+	if size == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("size %s not found; use size-list to find valid size slugs", sizeSlug)), nil
+	}
+
+	warning, err := validateResize(droplet, size, resizeDisk)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	action, resp, err := client.DropletActions.Resize(ctx, dropletID, sizeSlug, resizeDisk)
+	if err != nil {
+		return errutil.NewAPIErrorResult(resp, err), nil
+	}
+
+	// The embedded action keeps the payload shape of the other action tools;
+	// the warning is only present for permanent disk resizes.
+	result := struct {
+		*godo.Action
+		Warning string `json:"warning,omitempty"`
+	}{Action: action, Warning: warning}
+
+	jsonAction, err := json.MarshalIndent(result, "", "  ")
 	if err != nil {
 		return nil, fmt.Errorf("marshal error: %w", err)
 	}
@@ -428,17 +680,23 @@ func (da *DropletActionsTool) resizeDroplet(ctx context.Context, req mcp.CallToo
 
 // rebuildDroplet rebuilds a droplet using a provided image
 func (da *DropletActionsTool) rebuildDroplet(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	dropletID := req.GetArguments()["ID"].(float64)
-	imageID := req.GetArguments()["ImageID"].(float64)
+	dropletID, errResult := requiredInt(req.GetArguments(), "ID")
+	if errResult != nil {
+		return errResult, nil
+	}
+	imageID, errResult := requiredInt(req.GetArguments(), "ImageID")
+	if errResult != nil {
+		return errResult, nil
+	}
 
 	client, err := da.client(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+		return noCredentials(err), nil
 	}
 
-	action, _, err := client.DropletActions.RebuildByImageID(ctx, int(dropletID), int(imageID))
+	action, resp, err := client.DropletActions.RebuildByImageID(ctx, dropletID, imageID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return errutil.NewAPIErrorResult(resp, err), nil
 	}
 
 	jsonAction, err := json.MarshalIndent(action, "", "  ")
@@ -451,17 +709,23 @@ func (da *DropletActionsTool) rebuildDroplet(ctx context.Context, req mcp.CallTo
 
 // renameDroplet renames a droplet
 func (da *DropletActionsTool) renameDroplet(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	dropletID := req.GetArguments()["ID"].(float64)
-	name := req.GetArguments()["Name"].(string)
+	dropletID, errResult := requiredInt(req.GetArguments(), "ID")
+	if errResult != nil {
+		return errResult, nil
+	}
+	name, _ := req.GetArguments()["Name"].(string)
+	if !common.ValidHostname(name) {
+		return mcp.NewToolResultError("Name must be a valid hostname (letters, digits, hyphens, dots)"), nil
+	}
 
 	client, err := da.client(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+		return noCredentials(err), nil
 	}
 
-	action, _, err := client.DropletActions.Rename(ctx, int(dropletID), name)
+	action, resp, err := client.DropletActions.Rename(ctx, dropletID, name)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return errutil.NewAPIErrorResult(resp, err), nil
 	}
 
 	jsonAction, err := json.MarshalIndent(action, "", "  ")
@@ -474,17 +738,23 @@ func (da *DropletActionsTool) renameDroplet(ctx context.Context, req mcp.CallToo
 
 // changeKernel changes a droplet's kernel
 func (da *DropletActionsTool) changeKernel(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	dropletID := req.GetArguments()["ID"].(float64)
-	kernelID := req.GetArguments()["KernelID"].(float64)
+	dropletID, errResult := requiredInt(req.GetArguments(), "ID")
+	if errResult != nil {
+		return errResult, nil
+	}
+	kernelID, errResult := requiredInt(req.GetArguments(), "KernelID")
+	if errResult != nil {
+		return errResult, nil
+	}
 
 	client, err := da.client(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+		return noCredentials(err), nil
 	}
 
-	action, _, err := client.DropletActions.ChangeKernel(ctx, int(dropletID), int(kernelID))
+	action, resp, err := client.DropletActions.ChangeKernel(ctx, dropletID, kernelID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return errutil.NewAPIErrorResult(resp, err), nil
 	}
 
 	jsonAction, err := json.MarshalIndent(action, "", "  ")
@@ -497,16 +767,19 @@ func (da *DropletActionsTool) changeKernel(ctx context.Context, req mcp.CallTool
 
 // enableIPv6 enables IPv6 on a droplet
 func (da *DropletActionsTool) enableIPv6(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	dropletID := req.GetArguments()["ID"].(float64)
+	dropletID, errResult := requiredInt(req.GetArguments(), "ID")
+	if errResult != nil {
+		return errResult, nil
+	}
 
 	client, err := da.client(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+		return noCredentials(err), nil
 	}
 
-	action, _, err := client.DropletActions.EnableIPv6(ctx, int(dropletID))
+	action, resp, err := client.DropletActions.EnableIPv6(ctx, dropletID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return errutil.NewAPIErrorResult(resp, err), nil
 	}
 
 	jsonAction, err := json.MarshalIndent(action, "", "  ")
@@ -519,16 +792,19 @@ func (da *DropletActionsTool) enableIPv6(ctx context.Context, req mcp.CallToolRe
 
 // enableBackups enables backups on a droplet
 func (da *DropletActionsTool) enableBackups(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	dropletID := req.GetArguments()["ID"].(float64)
+	dropletID, errResult := requiredInt(req.GetArguments(), "ID")
+	if errResult != nil {
+		return errResult, nil
+	}
 
 	client, err := da.client(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+		return noCredentials(err), nil
 	}
 
-	action, _, err := client.DropletActions.EnableBackups(ctx, int(dropletID))
+	action, resp, err := client.DropletActions.EnableBackups(ctx, dropletID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return errutil.NewAPIErrorResult(resp, err), nil
 	}
 
 	jsonAction, err := json.MarshalIndent(action, "", "  ")
@@ -541,16 +817,19 @@ func (da *DropletActionsTool) enableBackups(ctx context.Context, req mcp.CallToo
 
 // disableBackups disables backups on a droplet
 func (da *DropletActionsTool) disableBackups(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	dropletID := req.GetArguments()["ID"].(float64)
+	dropletID, errResult := requiredInt(req.GetArguments(), "ID")
+	if errResult != nil {
+		return errResult, nil
+	}
 
 	client, err := da.client(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+		return noCredentials(err), nil
 	}
 
-	action, _, err := client.DropletActions.DisableBackups(ctx, int(dropletID))
+	action, resp, err := client.DropletActions.DisableBackups(ctx, dropletID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return errutil.NewAPIErrorResult(resp, err), nil
 	}
 
 	jsonAction, err := json.MarshalIndent(action, "", "  ")
@@ -563,17 +842,23 @@ func (da *DropletActionsTool) disableBackups(ctx context.Context, req mcp.CallTo
 
 // snapshotDroplet creates a snapshot of a droplet
 func (da *DropletActionsTool) snapshotDroplet(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	dropletID := req.GetArguments()["ID"].(float64)
-	name := req.GetArguments()["Name"].(string)
+	dropletID, errResult := requiredInt(req.GetArguments(), "ID")
+	if errResult != nil {
+		return errResult, nil
+	}
+	name, _ := req.GetArguments()["Name"].(string)
+	if !common.ValidSnapshotName(name) {
+		return mcp.NewToolResultError("Name must be 1-255 printable characters"), nil
+	}
 
 	client, err := da.client(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+		return noCredentials(err), nil
 	}
 
-	action, _, err := client.DropletActions.Snapshot(ctx, int(dropletID), name)
+	action, resp, err := client.DropletActions.Snapshot(ctx, dropletID, name)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return errutil.NewAPIErrorResult(resp, err), nil
 	}
 
 	jsonAction, err := json.MarshalIndent(action, "", "  ")
@@ -595,6 +880,17 @@ func (da *DropletActionsTool) Tools() []server.ServerTool {
 				mcp.WithNumber("ID", mcp.Required(), mcp.Description("ID of the droplet to reboot")),
 			),
 		},
+		{
+			Handler: da.waitForAction,
+			Tool: mcp.NewTool("droplet-action-wait",
+				common.WithHints(common.HintsRead),
+				mcp.WithDescription("Wait for a droplet action, such as one returned by reboot-droplet or resize-droplet, to complete or error, and return the final action. If the action errors or the wait times out, the error is followed by the last action seen"),
+				mcp.WithNumber("DropletID", mcp.Required(), mcp.Description("ID of the droplet")),
+				mcp.WithNumber("ActionID", mcp.Required(), mcp.Description("ID of the action")),
+				mcp.WithNumber("PollIntervalSeconds", mcp.DefaultNumber(2), mcp.Min(1), mcp.Description("How often to check the action, in seconds (default: 2, at least 1)")),
+				mcp.WithNumber("TimeoutSeconds", mcp.DefaultNumber(300), mcp.Description("How long to wait, in seconds (default: 300)")),
+			),
+		},
 		{
 			Handler: da.passwordResetDroplet,
 			Tool: mcp.NewTool("reset-droplet-password",
@@ -667,6 +963,18 @@ func (da *DropletActionsTool) Tools() []server.ServerTool {
 				mcp.WithDescription("Take a snapshot of droplets by tag"),
 				mcp.WithString("Tag", mcp.Required(), mcp.Description("Tag of the droplets")),
 				mcp.WithString("Name", mcp.Required(), mcp.Description("Name for the snapshot")),
+				mcp.WithBoolean("PerDroplet", mcp.DefaultBool(false), mcp.Description("Snapshot each tagged droplet with its own action and return {droplet_id, droplet_name, action_id, error} per droplet, instead of a single tag action (default: false)")),
+				mcp.WithNumber("Concurrency", mcp.DefaultNumber(defaultTagConcurrency), mcp.Description("With PerDroplet, how many snapshot actions to issue at once (1-20, default 5)")),
+			),
+		},
+		{
+			Handler: da.runDropletActionByTag,
+			Tool: mcp.NewTool("droplets-run-action-by-tag",
+				common.WithHints(common.HintsAction),
+				mcp.WithDescription("Run a power action on each droplet with a tag and return {droplet_id, droplet_name, action_id, error} per droplet, so partial failures are easy to spot"),
+				mcp.WithString("Tag", mcp.Required(), mcp.Description("Tag of the droplets")),
+				mcp.WithString("Action", mcp.Required(), mcp.Enum("power_on", "power_off", "power_cycle", "shutdown", "reboot"), mcp.Description("Action to run on each droplet")),
+				mcp.WithNumber("Concurrency", mcp.DefaultNumber(defaultTagConcurrency), mcp.Description("How many actions to issue at once (1-20, default 5)")),
 			),
 		},
 		{
@@ -730,10 +1038,10 @@ func (da *DropletActionsTool) Tools() []server.ServerTool {
 			Handler: da.resizeDroplet,
 			Tool: mcp.NewTool("resize-droplet",
 				common.WithHints(common.HintsToggle),
-				mcp.WithDescription("Resize a droplet"),
+				mcp.WithDescription("Resize a droplet. The target size must have at least as much disk as the droplet currently has and as its image requires; disks can never be shrunk."),
 				mcp.WithNumber("ID", mcp.Required(), mcp.Description("ID of the droplet to resize")),
 				mcp.WithString("Size", mcp.Required(), mcp.Description("Slug of the new size (e.g., s-1vcpu-1gb)")),
-				mcp.WithBoolean("ResizeDisk", mcp.DefaultBool(false), mcp.Description("Whether to resize the disk")),
+				mcp.WithBoolean("ResizeDisk", mcp.DefaultBool(false), mcp.Description("Whether to resize the disk. Growing the disk is permanent and prevents later resizing to a smaller size")),
 			),
 		},
 		{
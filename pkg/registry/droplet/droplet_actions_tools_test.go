@@ -4,12 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"mcp-digitalocean/pkg/registry/common"
 )
 
 func setupDropletActionsToolWithMocks(actions *MockDropletActionsService) *DropletActionsTool {
@@ -450,6 +453,11 @@ func TestDropletActionsTool_snapshotByTag(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name:        "Invalid name",
+			args:        map[string]any{"Tag": "tag7", "Name": "nightly\nbackup"},
+			expectError: true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -883,63 +891,133 @@ func TestDropletActionsTool_restoreDroplet(t *testing.T) {
 }
 
 func TestDropletActionsTool_resizeDroplet(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
 	testAction := &godo.Action{ID: 666, Status: "completed"}
+	sizes := []godo.Size{
+		{Slug: "s-1vcpu-1gb", Disk: 25},
+		{Slug: "s-2vcpu-2gb", Disk: 60},
+		{Slug: "s-4vcpu-8gb", Disk: 160},
+	}
+	droplet := &godo.Droplet{ID: 123, Disk: 60, Image: &godo.Image{Name: "ubuntu-22-04-x64", MinDiskSize: 15}}
+	bigImageDroplet := &godo.Droplet{ID: 124, Disk: 25, Image: &godo.Image{Name: "big-snapshot", MinDiskSize: 50}}
+
 	tests := []struct {
 		name        string
 		args        map[string]any
+		droplet     *godo.Droplet
 		mockSetup   func(*MockDropletActionsService)
-		expectError bool
+		expectError string
+		wantWarning string
 	}{
 		{
-			name: "Successful resize",
-			args: map[string]any{"ID": float64(123), "Size": "s-1vcpu-1gb", "ResizeDisk": true},
+			name:    "Successful resize without disk",
+			args:    map[string]any{"ID": float64(123), "Size": "s-4vcpu-8gb", "ResizeDisk": false},
+			droplet: droplet,
 			mockSetup: func(m *MockDropletActionsService) {
-				m.EXPECT().
-					Resize(gomock.Any(), 123, "s-1vcpu-1gb", true).
-					Return(testAction, nil, nil).
-					Times(1)
+				m.EXPECT().Resize(gomock.Any(), 123, "s-4vcpu-8gb", false).Return(testAction, nil, nil).Times(1)
 			},
 		},
 		{
-			name: "API error",
-			args: map[string]any{"ID": float64(456), "Size": "s-2vcpu-2gb", "ResizeDisk": false},
+			name:    "Disk resize returns permanence warning",
+			args:    map[string]any{"ID": float64(123), "Size": "s-4vcpu-8gb", "ResizeDisk": true},
+			droplet: droplet,
 			mockSetup: func(m *MockDropletActionsService) {
-				m.EXPECT().
-					Resize(gomock.Any(), 456, "s-2vcpu-2gb", false).
-					Return(nil, nil, errors.New("api error")).
-					Times(1)
+				m.EXPECT().Resize(gomock.Any(), 123, "s-4vcpu-8gb", true).Return(testAction, nil, nil).Times(1)
 			},
-			expectError: true,
+			wantWarning: "ResizeDisk grows the disk from 60GB to 160GB. This is permanent: the droplet can no longer be resized to a size with less than 160GB of disk.",
+		},
+		{
+			name:    "Disk resize to same disk has no warning",
+			args:    map[string]any{"ID": float64(123), "Size": "s-2vcpu-2gb", "ResizeDisk": true},
+			droplet: droplet,
+			mockSetup: func(m *MockDropletActionsService) {
+				m.EXPECT().Resize(gomock.Any(), 123, "s-2vcpu-2gb", true).Return(testAction, nil, nil).Times(1)
+			},
+		},
+		{
+			name:        "Rejects disk shrink",
+			args:        map[string]any{"ID": float64(123), "Size": "s-1vcpu-1gb", "ResizeDisk": false},
+			droplet:     droplet,
+			expectError: "size s-1vcpu-1gb has a 25GB disk but droplet 123 already has a 60GB disk; disks can never be shrunk, so choose a size with at least 60GB of disk",
+		},
+		{
+			name:        "Rejects size below image min disk",
+			args:        map[string]any{"ID": float64(124), "Size": "s-1vcpu-1gb", "ResizeDisk": false},
+			droplet:     bigImageDroplet,
+			expectError: `size s-1vcpu-1gb has a 25GB disk but the droplet's image "big-snapshot" requires at least 50GB`,
+		},
+		{
+			name:        "Rejects unknown size",
+			args:        map[string]any{"ID": float64(123), "Size": "s-unknown", "ResizeDisk": false},
+			droplet:     droplet,
+			expectError: "size s-unknown not found; use size-list to find valid size slugs",
+		},
+		{
+			name:    "API error",
+			args:    map[string]any{"ID": float64(123), "Size": "s-2vcpu-2gb", "ResizeDisk": false},
+			droplet: droplet,
+			mockSetup: func(m *MockDropletActionsService) {
+				m.EXPECT().Resize(gomock.Any(), 123, "s-2vcpu-2gb", false).Return(nil, nil, errors.New("api error")).Times(1)
+			},
+			expectError: "api error",
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
 			mockActions := NewMockDropletActionsService(ctrl)
+			mockDroplets := NewMockDropletsService(ctrl)
+			mockSizes := NewMockSizesService(ctrl)
+			mockDroplets.EXPECT().Get(gomock.Any(), tc.droplet.ID).Return(tc.droplet, nil, nil).Times(1)
+			mockSizes.EXPECT().List(gomock.Any(), gomock.Any()).Return(sizes, nil, nil).Times(1)
 			if tc.mockSetup != nil {
 				tc.mockSetup(mockActions)
 			}
-			tool := setupDropletActionsToolWithMocks(mockActions)
+			tool := NewDropletActionsTool(func(context.Context) (*godo.Client, error) {
+				return &godo.Client{DropletActions: mockActions, Droplets: mockDroplets, Sizes: mockSizes}, nil
+			})
 			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
 			resp, err := tool.resizeDroplet(context.Background(), req)
-			if tc.expectError {
-				require.NotNil(t, resp)
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			if tc.expectError != "" {
 				require.True(t, resp.IsError)
+				require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.expectError)
 				return
 			}
-			require.NoError(t, err)
-			require.NotNil(t, resp)
 			require.False(t, resp.IsError)
-			var outAction godo.Action
-			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &outAction))
-			require.Equal(t, testAction.ID, outAction.ID)
+			var out struct {
+				godo.Action
+				Warning string `json:"warning"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+			require.Equal(t, testAction.ID, out.ID)
+			require.Equal(t, tc.wantWarning, out.Warning)
 		})
 	}
 }
 
+func TestFindSizePagesThroughSizes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSizes := NewMockSizesService(ctrl)
+	// the API returns fewer sizes than requested per page; the next link, not
+	// the page length, decides whether there is more to fetch.
+	firstPage := make([]godo.Size, 3)
+	for i := range firstPage {
+		firstPage[i] = godo.Size{Slug: fmt.Sprintf("size-%d", i)}
+	}
+	more := &godo.Response{Links: &godo.Links{Pages: &godo.Pages{Next: "https://api/v2/sizes?page=2", Last: "https://api/v2/sizes?page=2"}}}
+	gomock.InOrder(
+		mockSizes.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: common.MaxPerPage}).Return(firstPage, more, nil),
+		mockSizes.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 2, PerPage: common.MaxPerPage}).Return([]godo.Size{{Slug: "gpu-h100x1-80gb", Disk: 720}}, nil, nil),
+	)
+
+	size, err := findSize(context.Background(), &godo.Client{Sizes: mockSizes}, "gpu-h100x1-80gb")
+	require.NoError(t, err)
+	require.NotNil(t, size)
+	require.Equal(t, 720, size.Disk)
+}
+
 func TestDropletActionsTool_rebuildDroplet(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1030,6 +1108,36 @@ func TestDropletActionsTool_renameDroplet(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name:        "Invalid name with spaces",
+			args:        map[string]any{"ID": float64(123), "Name": "my droplet"},
+			expectError: true,
+		},
+		{
+			name:        "Invalid name with underscore",
+			args:        map[string]any{"ID": float64(123), "Name": "web_01"},
+			expectError: true,
+		},
+		{
+			name:        "Invalid name with leading hyphen",
+			args:        map[string]any{"ID": float64(123), "Name": "-web"},
+			expectError: true,
+		},
+		{
+			name:        "Invalid name with empty label",
+			args:        map[string]any{"ID": float64(123), "Name": "web..example"},
+			expectError: true,
+		},
+		{
+			name:        "Invalid name too long label",
+			args:        map[string]any{"ID": float64(123), "Name": strings.Repeat("a", 64)},
+			expectError: true,
+		},
+		{
+			name:        "Invalid name empty",
+			args:        map[string]any{"ID": float64(123), "Name": ""},
+			expectError: true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -1320,6 +1428,26 @@ func TestDropletActionsTool_snapshotDroplet(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name:        "Invalid name empty",
+			args:        map[string]any{"ID": float64(123), "Name": ""},
+			expectError: true,
+		},
+		{
+			name:        "Invalid name with newline",
+			args:        map[string]any{"ID": float64(123), "Name": "nightly\nbackup"},
+			expectError: true,
+		},
+		{
+			name:        "Invalid name with control character",
+			args:        map[string]any{"ID": float64(123), "Name": "snap\x00"},
+			expectError: true,
+		},
+		{
+			name:        "Invalid name too long",
+			args:        map[string]any{"ID": float64(123), "Name": strings.Repeat("s", 256)},
+			expectError: true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -1345,3 +1473,180 @@ func TestDropletActionsTool_snapshotDroplet(t *testing.T) {
 		})
 	}
 }
+
+func TestDropletActionsTool_noCredentials(t *testing.T) {
+	tool := NewDropletActionsTool(func(ctx context.Context) (*godo.Client, error) {
+		return nil, errors.New("no auth header found")
+	})
+
+	tests := []struct {
+		name    string
+		handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)
+		args    map[string]any
+	}{
+		{name: "reboot", handler: tool.rebootDroplet, args: map[string]any{"ID": float64(1)}},
+		{name: "power off", handler: tool.powerOffDroplet, args: map[string]any{"ID": float64(1)}},
+		{name: "snapshot", handler: tool.snapshotDroplet, args: map[string]any{"ID": float64(1), "Name": "snap"}},
+		{name: "power cycle by tag", handler: tool.powerCycleByTag, args: map[string]any{"Tag": "web"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := tc.handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			require.True(t, resp.IsError)
+			require.Contains(t, resp.Content[0].(mcp.TextContent).Text, "no credentials provided")
+		})
+	}
+}
+
+func TestDropletActionsTool_waitForAction(t *testing.T) {
+	inProgress := &godo.Action{ID: 2001, Type: "resize", Status: "in-progress"}
+	completed := &godo.Action{ID: 2001, Type: "resize", Status: "completed"}
+	errored := &godo.Action{ID: 2001, Type: "resize", Status: "errored"}
+	fast := map[string]any{"DropletID": float64(123), "ActionID": float64(2001), "PollIntervalSeconds": 0.001}
+	withArgs := func(extra map[string]any) map[string]any {
+		args := map[string]any{}
+		for k, v := range fast {
+			args[k] = v
+		}
+		for k, v := range extra {
+			args[k] = v
+		}
+		return args
+	}
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(*MockDropletActionsService)
+		keepMinimum bool
+		wantStatus  string
+		expectError string
+		// expectAction is the status of the action following the error.
+		expectAction string
+	}{
+		{
+			name: "polls until completed",
+			args: fast,
+			mockSetup: func(m *MockDropletActionsService) {
+				gomock.InOrder(
+					m.EXPECT().Get(gomock.Any(), 123, 2001).Return(inProgress, nil, nil).Times(2),
+					m.EXPECT().Get(gomock.Any(), 123, 2001).Return(completed, nil, nil),
+				)
+			},
+			wantStatus: "completed",
+		},
+		{
+			name: "errored action",
+			args: fast,
+			mockSetup: func(m *MockDropletActionsService) {
+				m.EXPECT().Get(gomock.Any(), 123, 2001).Return(errored, nil, nil)
+			},
+			expectError:  "action 2001 (resize) errored",
+			expectAction: "errored",
+		},
+		{
+			name: "timeout reports the last status",
+			args: withArgs(map[string]any{"TimeoutSeconds": 0.02}),
+			mockSetup: func(m *MockDropletActionsService) {
+				m.EXPECT().Get(gomock.Any(), 123, 2001).Return(inProgress, nil, nil).MinTimes(1)
+			},
+			expectError:  `last status "in-progress"`,
+			expectAction: "in-progress",
+		},
+		{
+			name: "action ID above MaxInt32",
+			args: withArgs(map[string]any{"ActionID": float64(3_000_000_001)}),
+			mockSetup: func(m *MockDropletActionsService) {
+				m.EXPECT().Get(gomock.Any(), 123, 3_000_000_001).Return(&godo.Action{ID: 3_000_000_001, Status: "completed"}, nil, nil)
+			},
+			wantStatus: "completed",
+		},
+		{
+			name: "action ID as a string",
+			args: withArgs(map[string]any{"ActionID": "3000000001"}),
+			mockSetup: func(m *MockDropletActionsService) {
+				m.EXPECT().Get(gomock.Any(), 123, 3_000_000_001).Return(&godo.Action{ID: 3_000_000_001, Status: "completed"}, nil, nil)
+			},
+			wantStatus: "completed",
+		},
+		{
+			name:        "action ID beyond exact JSON numbers",
+			args:        withArgs(map[string]any{"ActionID": float64(1 << 60)}),
+			expectError: "ActionID is required and must be a number",
+		},
+		{
+			name: "API error",
+			args: fast,
+			mockSetup: func(m *MockDropletActionsService) {
+				m.EXPECT().Get(gomock.Any(), 123, 2001).Return(nil, nil, errors.New("action not found"))
+			},
+			expectError: "action not found",
+		},
+		{
+			name:        "missing action ID",
+			args:        map[string]any{"DropletID": float64(123)},
+			expectError: "ActionID is required",
+		},
+		{
+			name:        "poll interval below the minimum",
+			args:        map[string]any{"DropletID": float64(123), "ActionID": float64(2001), "PollIntervalSeconds": 0.5},
+			keepMinimum: true,
+			expectError: "PollIntervalSeconds must be at least 1",
+		},
+		{
+			name:        "non-positive timeout",
+			args:        withArgs(map[string]any{"TimeoutSeconds": float64(0)}),
+			expectError: "TimeoutSeconds must be positive",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockActions := NewMockDropletActionsService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockActions)
+			}
+			tool := setupDropletActionsToolWithMocks(mockActions)
+			if !tc.keepMinimum {
+				tool.minPollInterval = 0
+			}
+
+			result, err := tool.waitForAction(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			text := result.Content[0].(mcp.TextContent).Text
+			if tc.expectError != "" {
+				require.True(t, result.IsError)
+				require.Contains(t, text, tc.expectError)
+				if tc.expectAction != "" {
+					require.Len(t, result.Content, 2)
+					var action godo.Action
+					require.NoError(t, json.Unmarshal([]byte(result.Content[1].(mcp.TextContent).Text), &action))
+					require.Equal(t, "resize", action.Type)
+					require.Equal(t, tc.expectAction, action.Status)
+				}
+				return
+			}
+			require.False(t, result.IsError)
+			var action godo.Action
+			require.NoError(t, json.Unmarshal([]byte(text), &action))
+			require.Equal(t, tc.wantStatus, action.Status)
+		})
+	}
+
+	t.Run("stops when the context is cancelled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockActions := NewMockDropletActionsService(ctrl)
+		ctx, cancel := context.WithCancel(context.Background())
+		mockActions.EXPECT().Get(gomock.Any(), 123, 2001).DoAndReturn(func(context.Context, int, int) (*godo.Action, *godo.Response, error) {
+			cancel()
+			return inProgress, nil, nil
+		})
+		tool := setupDropletActionsToolWithMocks(mockActions)
+		tool.minPollInterval = 0
+
+		result, err := tool.waitForAction(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: fast}})
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		require.Contains(t, result.Content[0].(mcp.TextContent).Text, "context canceled")
+	})
+}
@@ -18,14 +18,18 @@ var expectedAnnotations = map[string]struct {
 	readOnly, destructive, idempotent, openWorld bool
 }{
 	// droplet_tools.go
-	"droplet-create":             {false, false, false, false},
-	"droplet-delete":             {false, true, true, false},
-	"droplet-enable-private-net": {false, false, true, false},
-	"droplet-kernels":            {true, false, true, false},
-	"droplet-get":                {true, false, true, false},
-	"droplet-backup-policy":      {true, false, true, false},
-	"droplet-action":             {true, false, true, false},
-	"droplet-list":               {true, false, true, false},
+	"droplet-create":                {false, false, false, false},
+	"droplet-delete":                {false, true, true, false},
+	"droplet-wait-deleted":          {true, false, true, false},
+	"droplet-enable-private-net":    {false, false, true, false},
+	"droplet-kernels":               {true, false, true, false},
+	"droplet-get":                   {true, false, true, false},
+	"droplet-describe-provisioning": {true, false, true, false},
+	"droplet-backup-policy":         {true, false, true, false},
+	"droplet-transfer-usage":        {true, false, true, false},
+	"droplet-action":                {true, false, true, false},
+	"droplet-action-wait":           {true, false, true, false},
+	"droplet-list":                  {true, false, true, false},
 
 	// droplet_actions_tools.go
 	"reboot-droplet":                  {false, false, false, false},
@@ -38,6 +42,7 @@ var expectedAnnotations = map[string]struct {
 	"enable-backups-droplets-tag":     {false, false, true, false},
 	"disable-backups-droplets-tag":    {false, false, true, false},
 	"snapshot-droplets-tag":           {false, false, false, false},
+	"droplets-run-action-by-tag":      {false, false, false, false},
 	"enable-ipv6-droplets-tag":        {false, false, true, false},
 	"enable-private-net-droplets-tag": {false, false, true, false},
 	"power-cycle-droplet":             {false, false, false, false},
@@ -60,14 +65,21 @@ var expectedAnnotations = map[string]struct {
 	"image-action-get":      {true, false, true, false},
 
 	// images_tools.go
-	"image-list":   {true, false, true, false},
-	"image-get":    {true, false, true, false},
-	"image-create": {false, false, false, false},
-	"image-update": {false, false, true, false},
-	"image-delete": {false, true, true, false},
+	"image-list":         {true, false, true, false},
+	"image-get":          {true, false, true, false},
+	"image-wait-deleted": {true, false, true, false},
+	"image-create":       {false, false, false, false},
+	"image-update":       {false, false, true, false},
+	"image-delete":       {false, true, true, false},
 
 	// sizes_tools.go
-	"size-list": {true, false, true, false},
+	"size-list":     {true, false, true, false},
+	"size-list-gpu": {true, false, true, false},
+
+	// snapshots_tools.go
+	"snapshot-list":   {true, false, true, false},
+	"snapshot-get":    {true, false, true, false},
+	"snapshot-delete": {false, true, true, false},
 }
 
 func TestToolAnnotations(t *testing.T) {
@@ -81,6 +93,7 @@ func TestToolAnnotations(t *testing.T) {
 	all = append(all, NewImageActionsTool(clientFn).Tools()...)
 	all = append(all, NewImageTool(clientFn).Tools()...)
 	all = append(all, NewSizesTool(clientFn).Tools()...)
+	all = append(all, NewSnapshotsTool(clientFn).Tools()...)
 
 	if len(all) != len(expectedAnnotations) {
 		t.Fatalf("tool count mismatch: registered=%d, expected=%d (add new tools to expectedAnnotations)", len(all), len(expectedAnnotations))
@@ -3,23 +3,49 @@ package droplet
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"mcp-digitalocean/internal/waiter"
 	"mcp-digitalocean/pkg/registry/common"
 )
 
+const (
+	// DropletStatusActive is the status of a droplet that has booted.
+	DropletStatusActive = "active"
+	// defaultActiveWaitTimeout bounds how long droplet-create waits for a
+	// droplet to become active when TimeoutSeconds is not set.
+	defaultActiveWaitTimeout = 300 * time.Second
+	// defaultActivePollInterval is how often a new droplet's status is polled.
+	defaultActivePollInterval = 5 * time.Second
+)
+
 // DropletTool provides droplet management tools
 type DropletTool struct {
+	common.ToolLogger
+
 	client func(ctx context.Context) (*godo.Client, error)
+	// created remembers the create requests sent by this process.
+	created      *creationCache
+	pollInterval time.Duration
+	notify       func(ctx context.Context, req mcp.CallToolRequest, progress float64, message string)
+	now          func() time.Time
 }
 
 // NewDropletTool creates a new droplet tool
 func NewDropletTool(client func(ctx context.Context) (*godo.Client, error)) *DropletTool {
 	return &DropletTool{
-		client: client,
+		client:       client,
+		created:      newCreationCache(creationCacheSize),
+		pollInterval: defaultActivePollInterval,
+		notify:       common.NotifyProgress,
+		now:          time.Now,
 	}
 }
 
@@ -31,6 +57,7 @@ func (d *DropletTool) createDroplet(ctx context.Context, req mcp.CallToolRequest
 	region := args["Region"].(string)
 	backup, _ := args["Backup"].(bool)         // Defaults to false
 	monitoring, _ := args["Monitoring"].(bool) // Defaults to false
+	userData, _ := args["UserData"].(string)
 
 	imageID, hasID := args["ImageID"].(float64)
 	imageSlug, hasSlug := args["ImageSlug"].(string)
@@ -42,6 +69,18 @@ func (d *DropletTool) createDroplet(ctx context.Context, req mcp.CallToolRequest
 	if hasID && hasSlug {
 		return mcp.NewToolResultError("exactly one of ImageID or ImageSlug must be provided, not both"), nil
 	}
+	alertPolicy, message := parseAlertPolicy(args)
+	if message != "" {
+		return mcp.NewToolResultError(message), nil
+	}
+	waitForActive, _ := args["WaitForActive"].(bool)
+	waitTimeout := defaultActiveWaitTimeout
+	if secs, ok := args["TimeoutSeconds"].(float64); ok {
+		if secs <= 0 {
+			return mcp.NewToolResultError("TimeoutSeconds must be positive"), nil
+		}
+		waitTimeout = time.Duration(secs * float64(time.Second))
+	}
 
 	var image godo.DropletCreateImage
 	if hasSlug {
@@ -85,6 +124,7 @@ func (d *DropletTool) createDroplet(ctx context.Context, req mcp.CallToolRequest
 		Monitoring: monitoring,
 		SSHKeys:    sshKeys,
 		Tags:       tags,
+		UserData:   userData,
 	}
 
 	client, err := d.client(ctx)
@@ -92,15 +132,76 @@ func (d *DropletTool) createDroplet(ctx context.Context, req mcp.CallToolRequest
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
+	// GPU sizes are only offered in a few regions, and the API's rejection
+	// does not say which.
+	if strings.HasPrefix(size, gpuSizePrefix) {
+		message, err := gpuRegionMismatch(ctx, client, size, region)
+		if err != nil {
+			return common.ErrorResult(ctx, d.Logger(ctx), "api error", err), nil
+		}
+		if message != "" {
+			return mcp.NewToolResultError(message), nil
+		}
+	}
+
 	droplet, _, err := client.Droplets.Create(ctx, dropletCreateRequest)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("droplet create", err), nil
+		return common.ErrorResult(ctx, d.Logger(ctx), "droplet create", err), nil
+	}
+	d.created.store(ctx, droplet.ID, dropletCreateRequest, time.Now())
+
+	// The droplet exists from here on, so a slow boot or a failed policy is
+	// reported as a warning rather than failing the call.
+	var warnings []string
+	if waitForActive {
+		active, err := d.waitForActive(ctx, req, client, droplet, waitTimeout)
+		switch {
+		case errors.Is(err, waiter.ErrTimeout):
+			warnings = append(warnings, fmt.Sprintf("droplet %d was created but was still %s after %s; check it with droplet-get", active.ID, active.Status, waitTimeout))
+		case err != nil:
+			return common.ErrorResult(ctx, d.Logger(ctx), fmt.Sprintf("droplet %d was created but is not active yet; check it with droplet-get", droplet.ID), err), nil
+		}
+		droplet = active
+	}
+
+	var out any = droplet
+	if alertPolicy != nil || len(warnings) > 0 {
+		created := createdDroplet{Droplet: droplet}
+		if alertPolicy != nil {
+			created.AlertPolicy, err = alertPolicy.create(ctx, client, droplet)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("droplet created, but the alert policy could not be: %v", err))
+			}
+		}
+		created.Warning = strings.Join(warnings, "; ")
+		out = created
 	}
-	jsonDroplet, err := json.MarshalIndent(droplet, "", "  ")
+	result, err := common.NewResourceResult(out)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("json marshal", err), nil
+		return common.ErrorResult(ctx, d.Logger(ctx), "json marshal", err), nil
 	}
-	return mcp.NewToolResultText(string(jsonDroplet)), nil
+	return result, nil
+}
+
+// waitForActive polls the droplet until its status is DropletStatusActive, the
+// timeout elapses or ctx is cancelled, sending a progress notification on every
+// poll. The last droplet read is returned alongside any error, so a droplet that
+// timed out still reports its status.
+func (d *DropletTool) waitForActive(ctx context.Context, req mcp.CallToolRequest, client *godo.Client, droplet *godo.Droplet, timeout time.Duration) (*godo.Droplet, error) {
+	polls := 0
+	active, err := waiter.Poll(ctx, d.pollInterval, timeout, func(ctx context.Context) (*godo.Droplet, bool, error) {
+		current, _, err := client.Droplets.Get(ctx, droplet.ID)
+		if err != nil {
+			return nil, false, err
+		}
+		polls++
+		d.notify(ctx, req, float64(polls), fmt.Sprintf("droplet %d is %s", current.ID, current.Status))
+		return current, current.Status == DropletStatusActive, nil
+	})
+	if active == nil {
+		active = droplet
+	}
+	return active, err
 }
 
 // deleteDroplet deletes a droplet
@@ -114,7 +215,7 @@ func (d *DropletTool) deleteDroplet(ctx context.Context, req mcp.CallToolRequest
 
 	_, err = client.Droplets.Delete(ctx, int(dropletID))
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, d.Logger(ctx), "api error", err), nil
 	}
 	return mcp.NewToolResultText("Droplet deleted successfully"), nil
 }
@@ -130,7 +231,7 @@ func (d *DropletTool) getDropletNeighbors(ctx context.Context, req mcp.CallToolR
 
 	neighbors, _, err := client.Droplets.Neighbors(ctx, int(dropletID))
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.GetErrorResult(ctx, d.Logger(ctx), "api error", "droplet", strconv.Itoa(int(dropletID)), err), nil
 	}
 
 	jsonNeighbors, err := json.MarshalIndent(neighbors, "", "  ")
@@ -152,7 +253,7 @@ func (d *DropletTool) enablePrivateNetworking(ctx context.Context, req mcp.CallT
 
 	action, _, err := client.DropletActions.EnablePrivateNetworking(ctx, int(dropletID))
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, d.Logger(ctx), "api error", err), nil
 	}
 
 	jsonAction, err := json.MarshalIndent(action, "", "  ")
@@ -180,7 +281,7 @@ func (d *DropletTool) getDropletKernels(ctx context.Context, req mcp.CallToolReq
 
 	kernels, _, err := client.Droplets.Kernels(ctx, int(dropletID), opt)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.GetErrorResult(ctx, d.Logger(ctx), "api error", "droplet", strconv.Itoa(int(dropletID)), err), nil
 	}
 
 	jsonKernels, err := json.MarshalIndent(kernels, "", "  ")
@@ -205,13 +306,13 @@ func (d *DropletTool) getDropletByID(ctx context.Context, req mcp.CallToolReques
 
 	droplet, _, err := client.Droplets.Get(ctx, int(id))
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.GetErrorResult(ctx, d.Logger(ctx), "api error", "droplet", strconv.Itoa(int(id)), err), nil
 	}
-	jsonData, err := json.MarshalIndent(droplet, "", "  ")
+	result, err := common.NewStructuredResourceResult(droplet)
 	if err != nil {
 		return nil, fmt.Errorf("marshal error: %w", err)
 	}
-	return mcp.NewToolResultText(string(jsonData)), nil
+	return result, nil
 }
 
 // getDropletBackupPolicy returns the backup policy for a droplet.
@@ -228,7 +329,7 @@ func (d *DropletTool) getDropletBackupPolicy(ctx context.Context, req mcp.CallTo
 
 	policy, _, err := client.Droplets.GetBackupPolicy(ctx, int(id))
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.GetErrorResult(ctx, d.Logger(ctx), "api error", "droplet", strconv.Itoa(int(id)), err), nil
 	}
 
 	jsonData, err := json.MarshalIndent(policy, "", "  ")
@@ -255,7 +356,7 @@ func (d *DropletTool) getDropletActionByID(ctx context.Context, req mcp.CallTool
 
 	action, _, err := client.DropletActions.Get(ctx, int(dropletID), int(actionID))
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.GetErrorResult(ctx, d.Logger(ctx), "api error", "droplet action", fmt.Sprintf("%d/%d", int(dropletID), int(actionID)), err), nil
 	}
 	jsonData, err := json.MarshalIndent(action, "", "  ")
 	if err != nil {
@@ -264,20 +365,48 @@ func (d *DropletTool) getDropletActionByID(ctx context.Context, req mcp.CallTool
 	return mcp.NewToolResultText(string(jsonData)), nil
 }
 
+// dropletListItem returns the fields of droplet that droplet-list returns.
+func dropletListItem(droplet godo.Droplet) map[string]any {
+	return map[string]any{
+		"id":                 droplet.ID,
+		"name":               droplet.Name,
+		"memory":             droplet.Memory,
+		"vcpus":              droplet.Vcpus,
+		"disk":               droplet.Disk,
+		"region":             droplet.Region,
+		"image":              droplet.Image,
+		"size":               droplet.Size,
+		"size_slug":          droplet.SizeSlug,
+		"backup_ids":         droplet.BackupIDs,
+		"next_backup_window": droplet.NextBackupWindow,
+		"snapshot_ids":       droplet.SnapshotIDs,
+		"features":           droplet.Features,
+		"locked":             droplet.Locked,
+		"status":             droplet.Status,
+		"networks":           droplet.Networks,
+		"created_at":         droplet.Created,
+		"kernel":             droplet.Kernel,
+		"tags":               droplet.Tags,
+		"volume_ids":         droplet.VolumeIDs,
+		"vpc_uuid":           droplet.VPCUUID,
+	}
+}
+
 // getDroplets lists all droplets for a user
 func (d *DropletTool) getDroplets(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	page, ok := req.GetArguments()["Page"].(float64)
-	if !ok {
-		page = 1
+	if common.IsStream(req.GetArguments()) && common.IsAll(req.GetArguments()) {
+		return mcp.NewToolResultError("Stream and All cannot be used together: Stream returns every droplet as JSON Lines, All as one list; pass one of them"), nil
 	}
-	perPage, ok := req.GetArguments()["PerPage"].(float64)
-	if !ok {
-		perPage = 50
+	if common.IsStream(req.GetArguments()) {
+		return d.streamDroplets(ctx)
+	}
+	if common.IsAll(req.GetArguments()) {
+		return d.allDroplets(ctx)
 	}
 
-	opt := &godo.ListOptions{
-		Page:    int(page),
-		PerPage: int(perPage),
+	opt, pageMeta, err := common.ListOptionsFromCursor(req.GetArguments(), 50, "droplet-list")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	client, err := d.client(ctx)
@@ -285,36 +414,15 @@ func (d *DropletTool) getDroplets(ctx context.Context, req mcp.CallToolRequest)
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	droplets, _, err := client.Droplets.List(ctx, opt)
+	droplets, resp, err := client.Droplets.List(ctx, opt)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, d.Logger(ctx), "api error", err), nil
 	}
+	pageMeta = common.WithNextCursor(pageMeta, resp)
 
 	filteredDroplets := make([]map[string]any, len(droplets))
 	for i, droplet := range droplets {
-		filteredDroplets[i] = map[string]any{
-			"id":                 droplet.ID,
-			"name":               droplet.Name,
-			"memory":             droplet.Memory,
-			"vcpus":              droplet.Vcpus,
-			"disk":               droplet.Disk,
-			"region":             droplet.Region,
-			"image":              droplet.Image,
-			"size":               droplet.Size,
-			"size_slug":          droplet.SizeSlug,
-			"backup_ids":         droplet.BackupIDs,
-			"next_backup_window": droplet.NextBackupWindow,
-			"snapshot_ids":       droplet.SnapshotIDs,
-			"features":           droplet.Features,
-			"locked":             droplet.Locked,
-			"status":             droplet.Status,
-			"networks":           droplet.Networks,
-			"created_at":         droplet.Created,
-			"kernel":             droplet.Kernel,
-			"tags":               droplet.Tags,
-			"volume_ids":         droplet.VolumeIDs,
-			"vpc_uuid":           droplet.VPCUUID,
-		}
+		filteredDroplets[i] = dropletListItem(droplet)
 	}
 
 	jsonData, err := json.MarshalIndent(filteredDroplets, "", "  ")
@@ -322,7 +430,43 @@ func (d *DropletTool) getDroplets(ctx context.Context, req mcp.CallToolRequest)
 		return nil, fmt.Errorf("marshal error: %w", err)
 	}
 
-	return mcp.NewToolResultText(string(jsonData)), nil
+	return common.WithPageMeta(mcp.NewToolResultText(string(jsonData)), pageMeta)
+}
+
+// allDroplets returns up to common.MaxAllItems droplets in one result.
+func (d *DropletTool) allDroplets(ctx context.Context) (*mcp.CallToolResult, error) {
+	client, err := d.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	droplets, truncated, err := common.FetchForAll(ctx, client.Droplets.List)
+	if err != nil {
+		return common.ErrorResult(ctx, d.Logger(ctx), "api error", err), nil
+	}
+	items := make([]map[string]any, len(droplets))
+	for i, droplet := range droplets {
+		items[i] = dropletListItem(droplet)
+	}
+	return common.NewAllResult(items, truncated)
+}
+
+// streamDroplets returns every droplet as JSON Lines chunks.
+func (d *DropletTool) streamDroplets(ctx context.Context) (*mcp.CallToolResult, error) {
+	client, err := d.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	droplets, err := common.FetchAll(ctx, common.MaxPerPage, client.Droplets.List)
+	if err != nil {
+		return common.ErrorResult(ctx, d.Logger(ctx), "api error", err), nil
+	}
+	items := make([]map[string]any, len(droplets))
+	for i, droplet := range droplets {
+		items[i] = dropletListItem(droplet)
+	}
+	return common.NewStreamResult(items, common.StreamMeta{})
 }
 
 func (d *DropletTool) Tools() []server.ServerTool {
@@ -331,6 +475,7 @@ func (d *DropletTool) Tools() []server.ServerTool {
 			Handler: d.createDroplet,
 			Tool: mcp.NewTool("droplet-create",
 				common.WithHints(common.HintsAction),
+				common.WithCreatesResource(),
 				mcp.WithDescription("Create a new droplet. Supports standard distribution images via ImageID and 1-click marketplace app images via ImageSlug. Exactly one of ImageID or ImageSlug must be provided."),
 				mcp.WithString("Name", mcp.Required(), mcp.Description("Name of the droplet")),
 				mcp.WithString("Size", mcp.Required(), mcp.Description("Slug of the droplet size (e.g., s-1vcpu-1gb)")),
@@ -341,6 +486,19 @@ func (d *DropletTool) Tools() []server.ServerTool {
 				mcp.WithBoolean("Monitoring", mcp.DefaultBool(false), mcp.Description("Whether to enable monitoring")),
 				mcp.WithArray("SSHKeys", mcp.Description("Array of SSH key IDs (numbers) or fingerprints (strings) to add to the droplet"), mcp.Items(map[string]any{"type": "string"})),
 				mcp.WithArray("Tags", mcp.Description("Array of tag names to apply to the droplet"), mcp.Items(map[string]any{"type": "string"})),
+				mcp.WithString("UserData", mcp.Description("Cloud-init user data or a shell script to run on first boot")),
+				mcp.WithObject("AlertPolicy",
+					mcp.Description("Monitoring alert policy to create for the new droplet once it exists. The policy is returned under alert_policy; if it cannot be created the droplet is still returned, with a warning. Metrics require Monitoring to be enabled."),
+					mcp.Properties(map[string]any{
+						"Type":    map[string]any{"type": "string", "description": "Droplet alert type, e.g. v1/insights/droplet/cpu (the default)"},
+						"Compare": map[string]any{"type": "string", "enum": []string{"GreaterThan", "LessThan"}},
+						"Value":   map[string]any{"type": "number", "description": "Threshold to compare against"},
+						"Window":  map[string]any{"type": "string", "enum": []string{"5m", "10m", "30m", "1h"}},
+						"Emails":  map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": "Addresses to notify"},
+					}),
+				),
+				mcp.WithBoolean("WaitForActive", mcp.DefaultBool(false), mcp.Description("Wait until the droplet is active and return it with its IP addresses. If it is not active within TimeoutSeconds the droplet is still returned, with a warning. Progress is reported while waiting")),
+				mcp.WithNumber("TimeoutSeconds", mcp.DefaultNumber(300), mcp.Description("How long WaitForActive waits, in seconds (default: 300)")),
 			),
 		},
 		{
@@ -351,6 +509,15 @@ func (d *DropletTool) Tools() []server.ServerTool {
 				mcp.WithNumber("ID", mcp.Required(), mcp.Description("ID of the droplet to delete")),
 			),
 		},
+		{
+			Handler: d.waitDropletDeleted,
+			Tool: mcp.NewTool("droplet-wait-deleted",
+				common.WithHints(common.HintsRead),
+				mcp.WithDescription("Wait until a droplet is deleted, e.g. after a deletion started outside this server, and return the elapsed time. On timeout the error gives the last status seen"),
+				mcp.WithNumber("ID", mcp.Required(), mcp.Description("ID of the droplet")),
+				mcp.WithNumber("TimeoutSeconds", mcp.DefaultNumber(300), mcp.Description("How long to wait, in seconds (default: 300)")),
+			),
+		},
 		{
 			Handler: d.enablePrivateNetworking,
 			Tool: mcp.NewTool("droplet-enable-private-net",
@@ -372,6 +539,23 @@ func (d *DropletTool) Tools() []server.ServerTool {
 			Tool: mcp.NewTool("droplet-get",
 				common.WithHints(common.HintsRead),
 				mcp.WithDescription("Get a droplet by its ID"),
+				common.WithOutputSchema[godo.Droplet](),
+				mcp.WithNumber("ID", mcp.Required(), mcp.Description("Droplet ID")),
+			),
+		},
+		{
+			Handler: d.describeProvisioning,
+			Tool: mcp.NewTool("droplet-describe-provisioning",
+				common.WithHints(common.HintsRead),
+				mcp.WithDescription("Describe what a droplet was provisioned with. The API does not return user data, so this combines the create request this server sent, marked \"as-created (cached)\" with secrets in user data redacted, with the droplet's live image, tags and VPC, marked \"live\". The cached part is only available for droplets created by this server process."),
+				mcp.WithNumber("ID", mcp.Required(), mcp.Description("Droplet ID")),
+			),
+		},
+		{
+			Handler: d.getTransferUsage,
+			Tool: mcp.NewTool("droplet-transfer-usage",
+				common.WithHints(common.HintsRead),
+				mcp.WithDescription("Estimate a droplet's outbound transfer for the current billing month (UTC calendar month) from its public bandwidth metrics, against its size's transfer allowance prorated for the time it has existed this month. Reports estimated used, included and projected GiB and flags droplets likely to exceed their allowance. All figures are estimates, and allowances are pooled across the account."),
 				mcp.WithNumber("ID", mcp.Required(), mcp.Description("Droplet ID")),
 			),
 		},
@@ -396,9 +580,12 @@ func (d *DropletTool) Tools() []server.ServerTool {
 			Handler: d.getDroplets,
 			Tool: mcp.NewTool("droplet-list",
 				common.WithHints(common.HintsRead),
-				mcp.WithDescription("List all droplets for the user. Supports pagination."),
+				mcp.WithDescription("List all droplets for the user. Supports pagination: when there are more pages, the result's meta has a next_cursor to pass back as Cursor. For thousands of droplets, set Stream to get them all at once as JSON Lines."),
 				mcp.WithNumber("Page", mcp.DefaultNumber(1), mcp.Description("Page number")),
 				mcp.WithNumber("PerPage", mcp.DefaultNumber(50), mcp.Description("Items per page")),
+				mcp.WithString(common.CursorArg, mcp.Description("next_cursor from a previous result, to fetch the following page. Overrides Page and PerPage")),
+				common.WithStreamArg(),
+				common.WithAllArg(),
 			),
 		},
 	}
@@ -4,12 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"mcp-digitalocean/pkg/registry/common"
 )
 
 func setupDropletToolWithMocks(droplets *MockDropletsService, actions *MockDropletActionsService) *DropletTool {
@@ -155,6 +159,140 @@ func TestDropletTool_createDroplet(t *testing.T) {
 	}
 }
 
+func TestDropletTool_createDropletWaitForActive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDroplets := NewMockDropletsService(ctrl)
+	mockDroplets.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&godo.Droplet{ID: 123, Name: "web-1", Status: "new"}, nil, nil)
+	gomock.InOrder(
+		mockDroplets.EXPECT().Get(gomock.Any(), 123).Return(&godo.Droplet{ID: 123, Status: "new"}, nil, nil),
+		mockDroplets.EXPECT().Get(gomock.Any(), 123).Return(&godo.Droplet{
+			ID:     123,
+			Name:   "web-1",
+			Status: DropletStatusActive,
+			Networks: &godo.Networks{V4: []godo.NetworkV4{
+				{IPAddress: "10.10.0.5", Type: "private"},
+				{IPAddress: "203.0.113.10", Type: "public"},
+			}},
+		}, nil, nil),
+	)
+
+	var progress []string
+	tool := setupDropletToolWithMocks(mockDroplets, NewMockDropletActionsService(ctrl))
+	tool.pollInterval = time.Millisecond
+	tool.notify = func(ctx context.Context, req mcp.CallToolRequest, p float64, message string) {
+		progress = append(progress, message)
+	}
+
+	resp, err := tool.createDroplet(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"Name":          "web-1",
+		"Size":          "s-1vcpu-1gb",
+		"ImageSlug":     "ubuntu-24-04-x64",
+		"Region":        "nyc3",
+		"WaitForActive": true,
+	}}})
+	require.NoError(t, err)
+	require.False(t, resp.IsError)
+
+	var out createdDroplet
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+	require.Equal(t, DropletStatusActive, out.Status)
+	ip, err := out.PublicIPv4()
+	require.NoError(t, err)
+	require.Equal(t, "203.0.113.10", ip)
+	require.Empty(t, out.Warning)
+	require.Equal(t, []string{"droplet 123 is new", "droplet 123 is active"}, progress)
+}
+
+func TestDropletTool_createDropletWaitForActiveErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         map[string]any
+		ctx          func() context.Context
+		pollInterval time.Duration
+		mockSetup    func(*MockDropletsService)
+		wantWarning  string
+		wantError    string
+	}{
+		{
+			name: "timeout returns the droplet with a warning",
+			args: map[string]any{"TimeoutSeconds": 0.02},
+			mockSetup: func(m *MockDropletsService) {
+				m.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&godo.Droplet{ID: 123, Status: "new"}, nil, nil)
+				m.EXPECT().Get(gomock.Any(), 123).Return(&godo.Droplet{ID: 123, Name: "web-1", Status: "new"}, nil, nil).MinTimes(1)
+			},
+			wantWarning: "droplet 123 was created but was still new after 20ms; check it with droplet-get",
+		},
+		{
+			name: "cancelled",
+			// a poll interval longer than the test makes the cancellation
+			// the only way out of the wait.
+			pollInterval: time.Hour,
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			},
+			mockSetup: func(m *MockDropletsService) {
+				m.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&godo.Droplet{ID: 123, Status: "new"}, nil, nil)
+				m.EXPECT().Get(gomock.Any(), 123).Return(&godo.Droplet{ID: 123, Status: "new"}, nil, nil)
+			},
+			wantError: "droplet 123 was created but is not active yet; check it with droplet-get: context canceled",
+		},
+		{
+			name: "get error",
+			mockSetup: func(m *MockDropletsService) {
+				m.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&godo.Droplet{ID: 123, Status: "new"}, nil, nil)
+				m.EXPECT().Get(gomock.Any(), 123).Return(nil, nil, errors.New("droplet lookup failed"))
+			},
+			wantError: "droplet lookup failed",
+		},
+		{
+			name:      "invalid timeout",
+			args:      map[string]any{"TimeoutSeconds": float64(0)},
+			wantError: "TimeoutSeconds must be positive",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockDroplets := NewMockDropletsService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockDroplets)
+			}
+			tool := setupDropletToolWithMocks(mockDroplets, NewMockDropletActionsService(ctrl))
+			tool.pollInterval = time.Millisecond
+			if tc.pollInterval != 0 {
+				tool.pollInterval = tc.pollInterval
+			}
+
+			args := map[string]any{"Name": "web-1", "Size": "s-1vcpu-1gb", "ImageSlug": "ubuntu-24-04-x64", "Region": "nyc3", "WaitForActive": true}
+			for k, v := range tc.args {
+				args[k] = v
+			}
+			ctx := context.Background()
+			if tc.ctx != nil {
+				ctx = tc.ctx()
+			}
+
+			resp, err := tool.createDroplet(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+			require.NoError(t, err)
+			text := resp.Content[0].(mcp.TextContent).Text
+			if tc.wantError != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, text, tc.wantError)
+				return
+			}
+			require.False(t, resp.IsError)
+			var out createdDroplet
+			require.NoError(t, json.Unmarshal([]byte(text), &out))
+			require.Equal(t, 123, out.ID)
+			require.Equal(t, "web-1", out.Name)
+			require.Equal(t, tc.wantWarning, out.Warning)
+		})
+	}
+}
+
 func TestDropletTool_getDropletByID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -219,6 +357,9 @@ func TestDropletTool_getDropletByID(t *testing.T) {
 			var outDroplet godo.Droplet
 			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &outDroplet))
 			require.Equal(t, testDroplet.ID, outDroplet.ID)
+			require.Equal(t, testDroplet, resp.StructuredContent)
+			require.Len(t, resp.Content, 2)
+			require.JSONEq(t, `{"urn":"do:droplet:123","console_url":"https://cloud.digitalocean.com/droplets/123"}`, resp.Content[1].(mcp.TextContent).Text)
 		})
 	}
 }
@@ -441,3 +582,222 @@ func TestDropletTool_getDroplets(t *testing.T) {
 		})
 	}
 }
+
+func TestDropletTool_createDropletGPURegion(t *testing.T) {
+	gpuSize := godo.Size{
+		Slug:    "gpu-h100x1-80gb",
+		Regions: []string{"nyc2", "tor1"},
+		GPUInfo: &godo.GPUInfo{Count: 1, Model: "nvidia_h100"},
+	}
+	tests := []struct {
+		name        string
+		region      string
+		sizes       []godo.Size
+		wantCreate  bool
+		wantMessage string
+	}{
+		{
+			name:        "GPU size in a non-GPU region",
+			region:      "nyc1",
+			sizes:       []godo.Size{gpuSize},
+			wantMessage: "GPU size gpu-h100x1-80gb is not offered in region nyc1; it is offered in nyc2, tor1",
+		},
+		{
+			name:       "GPU size in a GPU region",
+			region:     "tor1",
+			sizes:      []godo.Size{gpuSize},
+			wantCreate: true,
+		},
+		{
+			name:       "unknown GPU size is left to the API",
+			region:     "nyc1",
+			sizes:      []godo.Size{{Slug: "s-1vcpu-1gb"}},
+			wantCreate: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockDroplets := NewMockDropletsService(ctrl)
+			mockSizes := NewMockSizesService(ctrl)
+			mockSizes.EXPECT().List(gomock.Any(), gomock.Any()).Return(tc.sizes, nil, nil)
+			if tc.wantCreate {
+				mockDroplets.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&godo.Droplet{ID: 1}, nil, nil)
+			}
+			tool := NewDropletTool(func(ctx context.Context) (*godo.Client, error) {
+				return &godo.Client{Droplets: mockDroplets, Sizes: mockSizes}, nil
+			})
+
+			resp, err := tool.createDroplet(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+				"Name":      "gpu-1",
+				"Size":      "gpu-h100x1-80gb",
+				"ImageSlug": "gpu-h100x1-base",
+				"Region":    tc.region,
+			}}})
+			require.NoError(t, err)
+			require.Equal(t, !tc.wantCreate, resp.IsError)
+			if tc.wantMessage != "" {
+				require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.wantMessage)
+			}
+		})
+	}
+}
+
+func TestDropletTool_getDropletsCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDroplets := NewMockDropletsService(ctrl)
+	more := &godo.Response{Links: &godo.Links{Pages: &godo.Pages{Next: "https://api/v2/droplets?page=2", Last: "https://api/v2/droplets?page=100"}}}
+	gomock.InOrder(
+		mockDroplets.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: 50}).Return([]godo.Droplet{{ID: 1}}, more, nil),
+		mockDroplets.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 2, PerPage: 50}).Return([]godo.Droplet{{ID: 2}}, more, nil),
+	)
+	tool := setupDropletToolWithMocks(mockDroplets, NewMockDropletActionsService(ctrl))
+
+	nextCursor := func(resp *mcp.CallToolResult) string {
+		t.Helper()
+		require.False(t, resp.IsError)
+		require.Len(t, resp.Content, 2)
+		var meta struct {
+			Meta common.PageMeta `json:"meta"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(resp.Content[1].(mcp.TextContent).Text), &meta))
+		require.NotEmpty(t, meta.Meta.NextCursor)
+		return meta.Meta.NextCursor
+	}
+
+	resp, err := tool.getDroplets(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{}}})
+	require.NoError(t, err)
+	cursor := nextCursor(resp)
+
+	resp, err = tool.getDroplets(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"Cursor": cursor}}})
+	require.NoError(t, err)
+	require.NotEqual(t, cursor, nextCursor(resp))
+}
+
+func TestDropletTool_getDropletsAll(t *testing.T) {
+	more := &godo.Response{Links: &godo.Links{Pages: &godo.Pages{Next: "https://api/v2/droplets?page=next", Last: "https://api/v2/droplets?page=last"}}}
+	page := func(first, n int) []godo.Droplet {
+		droplets := make([]godo.Droplet, n)
+		for i := range droplets {
+			droplets[i] = godo.Droplet{ID: first + i, Name: fmt.Sprintf("web-%d", first+i)}
+		}
+		return droplets
+	}
+
+	tests := []struct {
+		name          string
+		setup         func(m *MockDropletsService)
+		wantFetched   int
+		wantTruncated bool
+	}{
+		{
+			name: "follows every page",
+			setup: func(m *MockDropletsService) {
+				gomock.InOrder(
+					m.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: common.MaxPerPage}).Return(page(1, 200), more, nil),
+					m.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 2, PerPage: common.MaxPerPage}).Return(page(201, 50), &godo.Response{Links: &godo.Links{}}, nil),
+				)
+			},
+			wantFetched: 250,
+		},
+		{
+			name: "stops at the cap",
+			setup: func(m *MockDropletsService) {
+				m.EXPECT().List(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, opt *godo.ListOptions) ([]godo.Droplet, *godo.Response, error) {
+						return page((opt.Page-1)*200+1, 200), more, nil
+					}).
+					Times(common.MaxAllItems/common.MaxPerPage + 1)
+			},
+			wantFetched:   common.MaxAllItems,
+			wantTruncated: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockDroplets := NewMockDropletsService(ctrl)
+			tc.setup(mockDroplets)
+			tool := setupDropletToolWithMocks(mockDroplets, NewMockDropletActionsService(ctrl))
+
+			// Page, PerPage and Cursor are ignored with All.
+			args := map[string]any{"All": true, "Page": float64(3), "PerPage": float64(5), "Cursor": "ignored"}
+			resp, err := tool.getDroplets(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+			require.NoError(t, err)
+			require.False(t, resp.IsError)
+			require.Len(t, resp.Content, 1)
+
+			var out common.AllResult[struct {
+				ID   int    `json:"id"`
+				Name string `json:"name"`
+			}]
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+			require.Equal(t, tc.wantFetched, out.TotalFetched)
+			require.Equal(t, tc.wantTruncated, out.Truncated)
+			require.Len(t, out.Items, tc.wantFetched)
+			require.Equal(t, 1, out.Items[0].ID)
+			require.Equal(t, tc.wantFetched, out.Items[tc.wantFetched-1].ID)
+		})
+	}
+}
+
+func TestDropletTool_getDropletsStreamAndAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tool := setupDropletToolWithMocks(NewMockDropletsService(ctrl), NewMockDropletActionsService(ctrl))
+
+	args := map[string]any{"Stream": true, "All": true}
+	resp, err := tool.getDroplets(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+	require.NoError(t, err)
+	require.True(t, resp.IsError)
+	require.Contains(t, resp.Content[0].(mcp.TextContent).Text, "Stream and All cannot be used together")
+}
+
+func TestDropletTool_getDropletsStream(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDroplets := NewMockDropletsService(ctrl)
+	page := func(first, n int) []godo.Droplet {
+		droplets := make([]godo.Droplet, n)
+		for i := range droplets {
+			droplets[i] = godo.Droplet{ID: first + i, Name: fmt.Sprintf("web-%d", first+i)}
+		}
+		return droplets
+	}
+	more := &godo.Response{Links: &godo.Links{Pages: &godo.Pages{Next: "https://api/v2/droplets?page=2", Last: "https://api/v2/droplets?page=2"}}}
+	gomock.InOrder(
+		mockDroplets.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: common.MaxPerPage}).Return(page(1, 200), more, nil),
+		mockDroplets.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 2, PerPage: common.MaxPerPage}).Return(page(201, 50), &godo.Response{Links: &godo.Links{}}, nil),
+	)
+	tool := setupDropletToolWithMocks(mockDroplets, NewMockDropletActionsService(ctrl))
+
+	// Page, PerPage and Cursor are ignored when streaming.
+	args := map[string]any{"Stream": true, "Page": float64(3), "PerPage": float64(5), "Cursor": "ignored"}
+	resp, err := tool.getDroplets(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+	require.NoError(t, err)
+	require.False(t, resp.IsError)
+	require.Len(t, resp.Content, 4)
+
+	var ids []int
+	for i, want := range []int{100, 100, 50} {
+		lines := strings.Split(strings.TrimSuffix(resp.Content[i].(mcp.TextContent).Text, "\n"), "\n")
+		require.Len(t, lines, want)
+		for _, line := range lines {
+			var droplet struct {
+				ID   int    `json:"id"`
+				Name string `json:"name"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(line), &droplet))
+			require.Equal(t, fmt.Sprintf("web-%d", droplet.ID), droplet.Name)
+			ids = append(ids, droplet.ID)
+		}
+	}
+	require.Len(t, ids, 250)
+	require.Equal(t, 1, ids[0])
+	require.Equal(t, 250, ids[249])
+
+	var meta struct {
+		Meta common.StreamMeta `json:"meta"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[3].(mcp.TextContent).Text), &meta))
+	require.Equal(t, common.StreamMeta{Records: 250, Chunks: 3, ChunkSize: common.StreamChunkSize}, meta.Meta)
+}
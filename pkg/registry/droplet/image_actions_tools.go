@@ -13,6 +13,8 @@ import (
 
 // ImageActionsTool provides tool-based handlers for DigitalOcean image actions.
 type ImageActionsTool struct {
+	common.ToolLogger
+
 	client func(ctx context.Context) (*godo.Client, error)
 }
 
@@ -44,7 +46,7 @@ func (ia *ImageActionsTool) transferImage(ctx context.Context, req mcp.CallToolR
 
 	action, _, err := client.ImageActions.Transfer(ctx, int(imageID), transferRequest)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, ia.Logger(ctx), "api error", err), nil
 	}
 
 	jsonAction, err := json.MarshalIndent(action, "", "  ")
@@ -69,7 +71,7 @@ func (ia *ImageActionsTool) convertImageToSnapshot(ctx context.Context, req mcp.
 
 	action, _, err := client.ImageActions.Convert(ctx, int(imageID))
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, ia.Logger(ctx), "api error", err), nil
 	}
 
 	jsonAction, err := json.MarshalIndent(action, "", "  ")
@@ -98,7 +100,7 @@ func (ia *ImageActionsTool) getImageAction(ctx context.Context, req mcp.CallTool
 
 	action, _, err := client.ImageActions.Get(ctx, int(imageID), int(actionID))
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.GetErrorResult(ctx, ia.Logger(ctx), "api error", "image action", fmt.Sprintf("%d/%d", int(imageID), int(actionID)), err), nil
 	}
 
 	jsonAction, err := json.MarshalIndent(action, "", "  ")
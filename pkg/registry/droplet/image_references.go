@@ -0,0 +1,79 @@
+package droplet
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/digitalocean/godo"
+
+	"mcp-digitalocean/pkg/registry/common"
+)
+
+// Kinds of resource that can reference an image.
+const (
+	imageReferenceDroplet       = "droplet"
+	imageReferenceAutoscalePool = "autoscale_pool"
+)
+
+// imageReferenceLimits lists what image-delete and snapshot-delete cannot
+// check, since the API does not record it.
+var imageReferenceLimits = []string{
+	"Droplets only report the image they were last created or rebuilt from, so a droplet created from this image and since rebuilt from another is not found.",
+	"Templates outside DigitalOcean's API, such as Terraform configurations or scripts naming the image ID, are not checked.",
+}
+
+// ImageReference is a resource that uses an image.
+type ImageReference struct {
+	Kind   string `json:"kind"`
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// ImageDeleteResult is the result of image-delete, or of snapshot-delete for a
+// droplet snapshot, when references were checked.
+type ImageDeleteResult struct {
+	ImageID    int              `json:"image_id"`
+	Deleted    bool             `json:"deleted"`
+	Message    string           `json:"message"`
+	References []ImageReference `json:"references"`
+	NotChecked []string         `json:"not_checked"`
+}
+
+// imageReferences finds the droplets created or rebuilt from image id and the
+// droplet autoscale pools whose template uses it.
+func imageReferences(ctx context.Context, client *godo.Client, id int) ([]ImageReference, error) {
+	references := []ImageReference{}
+
+	droplets, err := common.FetchAll(ctx, common.MaxPerPage, client.Droplets.List)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list droplets: %w", err)
+	}
+	for _, droplet := range droplets {
+		if droplet.Image != nil && droplet.Image.ID == id {
+			references = append(references, ImageReference{
+				Kind:   imageReferenceDroplet,
+				ID:     strconv.Itoa(droplet.ID),
+				Name:   droplet.Name,
+				Reason: "created or last rebuilt from this image; the droplet keeps running, but cannot be rebuilt from the image once it is deleted",
+			})
+		}
+	}
+
+	pools, err := common.FetchAll(ctx, common.MaxPerPage, client.DropletAutoscale.List)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list droplet autoscale pools: %w", err)
+	}
+	for _, pool := range pools {
+		if pool.DropletTemplate != nil && pool.DropletTemplate.Image == strconv.Itoa(id) {
+			references = append(references, ImageReference{
+				Kind:   imageReferenceAutoscalePool,
+				ID:     pool.ID,
+				Name:   pool.Name,
+				Reason: "the pool's droplet template uses this image; the pool cannot create droplets once it is deleted",
+			})
+		}
+	}
+	return references, nil
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strconv"
+	"time"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,29 +21,30 @@ const (
 
 // ImageTool provides tool-based handlers for DigitalOcean images.
 type ImageTool struct {
-	client func(ctx context.Context) (*godo.Client, error)
+	common.ToolLogger
+
+	client       func(ctx context.Context) (*godo.Client, error)
+	pollInterval time.Duration
 }
 
 // NewImageTool creates a new ImageTool instance.
 func NewImageTool(client func(ctx context.Context) (*godo.Client, error)) *ImageTool {
-	return &ImageTool{client: client}
+	return &ImageTool{client: client, pollInterval: defaultDeletePollInterval}
 }
 
-// listImages lists images with pagination and optional type filtering.
+// listImages lists images with pagination and optional type, private and tag filtering.
 func (i *ImageTool) listImages(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	page, ok := req.GetArguments()["Page"].(float64)
-	if !ok {
-		page = defaultImagesPage
-	}
-	perPage, ok := req.GetArguments()["PerPage"].(float64)
-	if !ok {
-		perPage = defaultImagesPageSize
-	}
+	opt, pageMeta := common.ListOptionsFromArgs(req.GetArguments(), defaultImagesPageSize)
 	imageType, _ := req.GetArguments()["Type"].(string)
+	private, _ := req.GetArguments()["Private"].(bool)
+	tag, _ := req.GetArguments()["Tag"].(string)
 
-	opt := &godo.ListOptions{
-		Page:    int(page),
-		PerPage: int(perPage),
+	// Private images are the user's own snapshots, backups and custom images.
+	if private {
+		if imageType != "" && imageType != "user" {
+			return mcp.NewToolResultError(fmt.Sprintf("Private cannot be combined with Type '%s'", imageType)), nil
+		}
+		imageType = "user"
 	}
 
 	client, err := i.client(ctx)
@@ -48,48 +52,80 @@ func (i *ImageTool) listImages(ctx context.Context, req mcp.CallToolRequest) (*m
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	var images []godo.Image
-	var apiErr error
-
-	// Dispatch based on requested image type
-	switch imageType {
-	case "distribution":
-		images, _, apiErr = client.Images.ListDistribution(ctx, opt)
-	case "application":
-		images, _, apiErr = client.Images.ListApplication(ctx, opt)
-	case "user":
-		images, _, apiErr = client.Images.ListUser(ctx, opt)
+	// Dispatch based on requested image type. A tag on its own is served by
+	// the API's tag filter so pagination reflects the matching images.
+	var list func(ctx context.Context, opt *godo.ListOptions) ([]godo.Image, *godo.Response, error)
+	switch {
+	case imageType == "" && tag != "":
+		list = func(ctx context.Context, opt *godo.ListOptions) ([]godo.Image, *godo.Response, error) {
+			return client.Images.ListByTag(ctx, tag, opt)
+		}
+	case imageType == "distribution":
+		list = client.Images.ListDistribution
+	case imageType == "application":
+		list = client.Images.ListApplication
+	case imageType == "user":
+		list = client.Images.ListUser
 	default:
 		// Default to listing all if unspecified
-		images, _, apiErr = client.Images.List(ctx, opt)
+		list = client.Images.List
 	}
 
+	all := common.IsAll(req.GetArguments())
+	var images []godo.Image
+	var truncated bool
+	var apiErr error
+	if all {
+		images, truncated, apiErr = common.FetchForAll(ctx, list)
+	} else {
+		images, _, apiErr = list(ctx, opt)
+	}
 	if apiErr != nil {
-		return mcp.NewToolResultErrorFromErr("api error", apiErr), nil
+		return common.ErrorResult(ctx, i.Logger(ctx), "api error", apiErr), nil
+	}
+
+	// The API's tag filter doesn't compose with the type filters, so when both
+	// are given the tag is matched client-side on the returned images.
+	if tag != "" && imageType != "" {
+		tagged := make([]godo.Image, 0, len(images))
+		for _, image := range images {
+			if slices.Contains(image.Tags, tag) {
+				tagged = append(tagged, image)
+			}
+		}
+		images = tagged
 	}
 
 	// returning mapped structure to match other tools' verbosity.
+	// min_disk_size and size_gigabytes let callers pick a droplet size whose
+	// disk is large enough for the image before calling droplet-create.
 	filteredImages := make([]map[string]any, len(images))
 	for idx, image := range images {
 		filteredImages[idx] = map[string]any{
-			"id":            image.ID,
-			"name":          image.Name,
-			"slug":          image.Slug,
-			"distribution":  image.Distribution,
-			"type":          image.Type,
-			"public":        image.Public,
-			"regions":       image.Regions,
-			"created_at":    image.Created,
-			"min_disk_size": image.MinDiskSize,
+			"id":             image.ID,
+			"name":           image.Name,
+			"slug":           image.Slug,
+			"distribution":   image.Distribution,
+			"type":           image.Type,
+			"public":         image.Public,
+			"regions":        image.Regions,
+			"created_at":     image.Created,
+			"min_disk_size":  image.MinDiskSize,
+			"size_gigabytes": image.SizeGigaBytes,
+			"tags":           image.Tags,
 		}
 	}
 
+	if all {
+		return common.NewAllResult(filteredImages, truncated)
+	}
+
 	jsonData, err := json.MarshalIndent(filteredImages, "", "  ")
 	if err != nil {
 		return nil, fmt.Errorf("marshal error: %w", err)
 	}
 
-	return mcp.NewToolResultText(string(jsonData)), nil
+	return common.WithPageMeta(mcp.NewToolResultText(string(jsonData)), pageMeta)
 }
 
 // getImageByID retrieves a specific image by its numeric ID.
@@ -106,7 +142,7 @@ func (i *ImageTool) getImageByID(ctx context.Context, req mcp.CallToolRequest) (
 
 	image, _, err := client.Images.GetByID(ctx, int(id))
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.GetErrorResult(ctx, i.Logger(ctx), "api error", "image", strconv.Itoa(int(id)), err), nil
 	}
 
 	jsonData, err := json.MarshalIndent(image, "", "  ")
@@ -158,7 +194,7 @@ func (i *ImageTool) createImage(ctx context.Context, req mcp.CallToolRequest) (*
 
 	image, _, err := client.Images.Create(ctx, createRequest)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, i.Logger(ctx), "api error", err), nil
 	}
 
 	jsonData, err := json.MarshalIndent(image, "", "  ")
@@ -191,7 +227,7 @@ func (i *ImageTool) updateImage(ctx context.Context, req mcp.CallToolRequest) (*
 
 	image, _, err := client.Images.Update(ctx, int(id), updateReq)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, i.Logger(ctx), "api error", err), nil
 	}
 
 	jsonData, err := json.MarshalIndent(image, "", "  ")
@@ -204,22 +240,56 @@ func (i *ImageTool) updateImage(ctx context.Context, req mcp.CallToolRequest) (*
 
 // deleteImage deletes an image/snapshot by its numeric ID.
 func (i *ImageTool) deleteImage(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	id, ok := req.GetArguments()["ID"].(float64)
+	args := req.GetArguments()
+	id, ok := args["ID"].(float64)
 	if !ok {
 		return mcp.NewToolResultError("ID is required"), nil
 	}
+	checkReferences := true
+	if v, ok := args["CheckReferences"].(bool); ok {
+		checkReferences = v
+	}
+	force, _ := args["Force"].(bool)
 
 	client, err := i.client(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
+	if !checkReferences {
+		_, err = client.Images.Delete(ctx, int(id))
+		if err != nil {
+			return common.ErrorResult(ctx, i.Logger(ctx), "api error", err), nil
+		}
+		return mcp.NewToolResultText("Image deleted successfully"), nil
+	}
+
+	references, err := imageReferences(ctx, client, int(id))
+	if err != nil {
+		return common.ErrorResult(ctx, i.Logger(ctx), "failed to check references", err), nil
+	}
+	result := ImageDeleteResult{ImageID: int(id), References: references, NotChecked: imageReferenceLimits}
+	if len(references) > 0 && !force {
+		result.Message = fmt.Sprintf("Image %d was not deleted: %d resources reference it. Pass Force: true to delete it anyway", int(id), len(references))
+		jsonResult, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("marshal error: %w", err)
+		}
+		return mcp.NewToolResultError(string(jsonResult)), nil
+	}
+
 	_, err = client.Images.Delete(ctx, int(id))
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, i.Logger(ctx), "api error", err), nil
 	}
+	result.Deleted = true
+	result.Message = fmt.Sprintf("Image %d deleted successfully", int(id))
 
-	return mcp.NewToolResultText("Image deleted successfully"), nil
+	jsonResult, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(string(jsonResult)), nil
 }
 
 // Tools returns the list of server tools for images.
@@ -230,10 +300,13 @@ func (i *ImageTool) Tools() []server.ServerTool {
 			Tool: mcp.NewTool(
 				"image-list",
 				common.WithHints(common.HintsRead),
-				mcp.WithDescription("List available images (snapshots, backups, distributions, applications)."),
+				mcp.WithDescription("List available images (snapshots, backups, distributions, applications). Each image includes min_disk_size (GB); choose a droplet size with at least that much disk."),
 				mcp.WithNumber("Page", mcp.DefaultNumber(defaultImagesPage), mcp.Description("Page number")),
 				mcp.WithNumber("PerPage", mcp.DefaultNumber(defaultImagesPageSize), mcp.Description("Items per page")),
+				common.WithAllArg(),
 				mcp.WithString("Type", mcp.Description("Filter by type: 'distribution', 'application', 'user' (snapshots/backups). If omitted, lists all.")),
+				mcp.WithBoolean("Private", mcp.Description("Only list the account's private images (snapshots, backups, custom images). Equivalent to Type 'user'.")),
+				mcp.WithString("Tag", mcp.Description("Only return images carrying this tag. When combined with Type or Private, the tag is matched within the requested page.")),
 			),
 		},
 		{
@@ -245,6 +318,16 @@ func (i *ImageTool) Tools() []server.ServerTool {
 				mcp.WithNumber("ID", mcp.Required(), mcp.Description("Image ID")),
 			),
 		},
+		{
+			Handler: i.waitImageDeleted,
+			Tool: mcp.NewTool(
+				"image-wait-deleted",
+				common.WithHints(common.HintsRead),
+				mcp.WithDescription("Wait until an image or snapshot is deleted, e.g. after a deletion started outside this server, and return the elapsed time. On timeout the error gives the last status seen"),
+				mcp.WithNumber("ID", mcp.Required(), mcp.Description("Image ID")),
+				mcp.WithNumber("TimeoutSeconds", mcp.DefaultNumber(300), mcp.Description("How long to wait, in seconds (default: 300)")),
+			),
+		},
 		{
 			Handler: i.createImage,
 			Tool: mcp.NewTool(
@@ -274,8 +357,10 @@ func (i *ImageTool) Tools() []server.ServerTool {
 			Tool: mcp.NewTool(
 				"image-delete",
 				common.WithHints(common.HintsDelete),
-				mcp.WithDescription("Delete an image or snapshot."),
+				mcp.WithDescription("Delete an image or snapshot. By default it first looks for droplets created or rebuilt from the image and droplet autoscale pools whose template uses it, and refuses to delete a referenced image unless Force is true. The result lists the references found and what could not be checked."),
 				mcp.WithNumber("ID", mcp.Required(), mcp.Description("ID of the image to delete")),
+				mcp.WithBoolean("CheckReferences", mcp.DefaultBool(true), mcp.Description("Look for droplets and autoscale pools using the image before deleting it (default: true)")),
+				mcp.WithBoolean("Force", mcp.DefaultBool(false), mcp.Description("Delete the image even if resources reference it (default: false)")),
 			),
 		},
 	}
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"mcp-digitalocean/pkg/registry/common"
 )
 
 // Helper to initialize tool and mock
@@ -59,6 +60,25 @@ func TestImageTool_listImages(t *testing.T) {
 				m.EXPECT().ListUser(gomock.Any(), gomock.Any()).Return(images, nil, nil)
 			},
 		},
+		{
+			name: "Private routes to user images",
+			args: map[string]any{"Private": true},
+			setup: func(m *MockImagesService) {
+				m.EXPECT().ListUser(gomock.Any(), gomock.Any()).Return(images, nil, nil)
+			},
+		},
+		{
+			name: "Private with user type",
+			args: map[string]any{"Private": true, "Type": "user"},
+			setup: func(m *MockImagesService) {
+				m.EXPECT().ListUser(gomock.Any(), gomock.Any()).Return(images, nil, nil)
+			},
+		},
+		{
+			name:    "Private conflicts with distribution type",
+			args:    map[string]any{"Private": true, "Type": "distribution"},
+			wantErr: true,
+		},
 		{
 			name: "API Error",
 			args: map[string]any{},
@@ -91,6 +111,128 @@ func TestImageTool_listImages(t *testing.T) {
 	}
 }
 
+func TestImageTool_listImagesAll(t *testing.T) {
+	more := &godo.Response{Links: &godo.Links{Pages: &godo.Pages{Next: "https://api/v2/images?page=next", Last: "https://api/v2/images?page=last"}}}
+	page := func(n int) []godo.Image {
+		images := make([]godo.Image, n)
+		for i := range images {
+			images[i] = godo.Image{ID: i + 1, Tags: []string{"golden"}}
+		}
+		return images
+	}
+
+	t.Run("follows pages of the requested type", func(t *testing.T) {
+		tool, m := newTestTool(t)
+		m.EXPECT().ListUser(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: common.MaxPerPage}).Return(page(200), more, nil)
+		m.EXPECT().ListUser(gomock.Any(), &godo.ListOptions{Page: 2, PerPage: common.MaxPerPage}).Return(page(10), &godo.Response{Links: &godo.Links{}}, nil)
+
+		res, err := tool.listImages(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"All": true, "Private": true}}})
+		require.NoError(t, err)
+		require.False(t, res.IsError)
+		var out common.AllResult[map[string]any]
+		require.NoError(t, json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &out))
+		require.Equal(t, 210, out.TotalFetched)
+		require.False(t, out.Truncated)
+		require.Contains(t, out.Items[0], "min_disk_size")
+	})
+
+	t.Run("truncates at the cap", func(t *testing.T) {
+		tool, m := newTestTool(t)
+		m.EXPECT().ListByTag(gomock.Any(), "golden", gomock.Any()).Return(page(200), more, nil).Times(common.MaxAllItems/common.MaxPerPage + 1)
+
+		res, err := tool.listImages(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"All": true, "Tag": "golden"}}})
+		require.NoError(t, err)
+		var out common.AllResult[map[string]any]
+		require.NoError(t, json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &out))
+		require.Equal(t, common.MaxAllItems, out.TotalFetched)
+		require.True(t, out.Truncated)
+	})
+}
+
+func TestImageTool_listImagesTagFilter(t *testing.T) {
+	// images returns a fresh fixture per call so no subtest can observe
+	// another's handler output.
+	images := func() []godo.Image {
+		return []godo.Image{
+			{ID: 1, Name: "web-snap", Tags: []string{"web", "prod"}, MinDiskSize: 25, SizeGigaBytes: 2.36},
+			{ID: 2, Name: "db-snap", Tags: []string{"db"}, MinDiskSize: 50, SizeGigaBytes: 10.5},
+			{ID: 3, Name: "untagged"},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		args    map[string]any
+		setup   func(*MockImagesService)
+		wantIDs []float64
+	}{
+		{
+			name: "Tag alone uses the API tag filter",
+			args: map[string]any{"Tag": "web"},
+			setup: func(m *MockImagesService) {
+				m.EXPECT().ListByTag(gomock.Any(), "web", &godo.ListOptions{Page: 1, PerPage: 50}).Return(images()[:1], nil, nil)
+			},
+			wantIDs: []float64{1},
+		},
+		{
+			name: "Tag filter combined with Private",
+			args: map[string]any{"Tag": "db", "Private": true},
+			setup: func(m *MockImagesService) {
+				m.EXPECT().ListUser(gomock.Any(), gomock.Any()).Return(images(), nil, nil)
+			},
+			wantIDs: []float64{2},
+		},
+		{
+			name: "Tag filter combined with Type",
+			args: map[string]any{"Tag": "web", "Type": "application"},
+			setup: func(m *MockImagesService) {
+				m.EXPECT().ListApplication(gomock.Any(), gomock.Any()).Return(images(), nil, nil)
+			},
+			wantIDs: []float64{1},
+		},
+		{
+			name: "Tag filter with no matches",
+			args: map[string]any{"Tag": "missing", "Type": "user"},
+			setup: func(m *MockImagesService) {
+				m.EXPECT().ListUser(gomock.Any(), gomock.Any()).Return(images(), nil, nil)
+			},
+			wantIDs: []float64{},
+		},
+		{
+			name: "No tag filter",
+			args: map[string]any{},
+			setup: func(m *MockImagesService) {
+				m.EXPECT().List(gomock.Any(), gomock.Any()).Return(images(), nil, nil)
+			},
+			wantIDs: []float64{1, 2, 3},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tool, m := newTestTool(t)
+			tc.setup(m)
+
+			res, err := tool.listImages(context.Background(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Arguments: tc.args},
+			})
+			require.NoError(t, err)
+			require.False(t, res.IsError)
+
+			var out []map[string]any
+			require.NoError(t, json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &out))
+
+			gotIDs := make([]float64, 0, len(out))
+			for _, img := range out {
+				gotIDs = append(gotIDs, img["id"].(float64))
+				require.Contains(t, img, "min_disk_size")
+				require.Contains(t, img, "size_gigabytes")
+			}
+			assert.Equal(t, tc.wantIDs, gotIDs)
+		})
+	}
+}
+
 func TestImageTool_getImageByID(t *testing.T) {
 	image := &godo.Image{ID: 123, Name: "test-image"}
 
@@ -246,14 +388,14 @@ func TestImageTool_deleteImage(t *testing.T) {
 	}{
 		{
 			name: "Successful delete",
-			args: map[string]any{"ID": 123.0},
+			args: map[string]any{"ID": 123.0, "CheckReferences": false},
 			setup: func(m *MockImagesService) {
 				m.EXPECT().Delete(gomock.Any(), 123).Return(nil, nil)
 			},
 		},
 		{
 			name: "API Error",
-			args: map[string]any{"ID": 456.0},
+			args: map[string]any{"ID": 456.0, "CheckReferences": false},
 			setup: func(m *MockImagesService) {
 				m.EXPECT().Delete(gomock.Any(), 456).Return(nil, errors.New("error"))
 			},
@@ -278,3 +420,80 @@ func TestImageTool_deleteImage(t *testing.T) {
 		})
 	}
 }
+
+func TestImageTool_deleteImageReferences(t *testing.T) {
+	droplets := []godo.Droplet{
+		{ID: 1, Name: "web-1", Image: &godo.Image{ID: 123}},
+		{ID: 2, Name: "web-2", Image: &godo.Image{ID: 999}},
+		{ID: 3, Name: "no-image"},
+	}
+	pools := []*godo.DropletAutoscalePool{
+		{ID: "pool-1", Name: "web-pool", DropletTemplate: &godo.DropletAutoscaleResourceTemplate{Image: "123"}},
+		{ID: "pool-2", Name: "api-pool", DropletTemplate: &godo.DropletAutoscaleResourceTemplate{Image: "ubuntu-24-04-x64"}},
+	}
+	wantReferences := []ImageReference{
+		{Kind: "droplet", ID: "1", Name: "web-1", Reason: "created or last rebuilt from this image; the droplet keeps running, but cannot be rebuilt from the image once it is deleted"},
+		{Kind: "autoscale_pool", ID: "pool-1", Name: "web-pool", Reason: "the pool's droplet template uses this image; the pool cannot create droplets once it is deleted"},
+	}
+
+	tests := []struct {
+		name           string
+		args           map[string]any
+		wantDelete     bool
+		wantErr        bool
+		wantReferences []ImageReference
+		wantMessage    string
+	}{
+		{
+			name:           "referenced image is not deleted",
+			args:           map[string]any{"ID": 123.0},
+			wantErr:        true,
+			wantReferences: wantReferences,
+			wantMessage:    "Image 123 was not deleted: 2 resources reference it. Pass Force: true to delete it anyway",
+		},
+		{
+			name:           "force deletes a referenced image",
+			args:           map[string]any{"ID": 123.0, "Force": true},
+			wantDelete:     true,
+			wantReferences: wantReferences,
+			wantMessage:    "Image 123 deleted successfully",
+		},
+		{
+			name:           "unreferenced image is deleted",
+			args:           map[string]any{"ID": 456.0},
+			wantDelete:     true,
+			wantReferences: []ImageReference{},
+			wantMessage:    "Image 456 deleted successfully",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			images := NewMockImagesService(ctrl)
+			dropletsService := NewMockDropletsService(ctrl)
+			autoscale := NewMockDropletAutoscaleService(ctrl)
+			dropletsService.EXPECT().List(gomock.Any(), gomock.Any()).Return(droplets, nil, nil)
+			autoscale.EXPECT().List(gomock.Any(), gomock.Any()).Return(pools, nil, nil)
+			id := int(tc.args["ID"].(float64))
+			if tc.wantDelete {
+				images.EXPECT().Delete(gomock.Any(), id).Return(nil, nil)
+			}
+			tool := NewImageTool(func(context.Context) (*godo.Client, error) {
+				return &godo.Client{Images: images, Droplets: dropletsService, DropletAutoscale: autoscale}, nil
+			})
+
+			res, err := tool.deleteImage(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			require.Equal(t, tc.wantErr, res.IsError)
+
+			var result ImageDeleteResult
+			require.NoError(t, json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &result))
+			require.Equal(t, id, result.ImageID)
+			require.Equal(t, tc.wantDelete, result.Deleted)
+			require.Equal(t, tc.wantMessage, result.Message)
+			require.Equal(t, tc.wantReferences, result.References)
+			require.NotEmpty(t, result.NotChecked)
+		})
+	}
+}
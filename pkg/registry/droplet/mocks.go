@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/digitalocean/godo (interfaces: DropletsService,DropletActionsService,SizesService,ImagesService,ImageActionsService)
+// Source: github.com/digitalocean/godo (interfaces: DropletsService,DropletActionsService,SizesService,ImagesService,ImageActionsService,RegionsService,MonitoringService,DropletAutoscaleService,SnapshotsService)
 //
 // Generated by this command:
 //
-//	mockgen -destination=./mocks.go -package droplet github.com/digitalocean/godo DropletsService,DropletActionsService,SizesService,ImagesService,ImageActionsService
+//	mockgen -destination=./mocks.go -package droplet github.com/digitalocean/godo DropletsService,DropletActionsService,SizesService,ImagesService,ImageActionsService,RegionsService,MonitoringService,DropletAutoscaleService,SnapshotsService
 //
 
 // Package droplet is a generated GoMock package.
@@ -1141,3 +1141,1199 @@ func (mr *MockImageActionsServiceMockRecorder) Transfer(arg0, arg1, arg2 any) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transfer", reflect.TypeOf((*MockImageActionsService)(nil).Transfer), arg0, arg1, arg2)
 }
+
+// MockRegionsService is a mock of RegionsService interface.
+type MockRegionsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRegionsServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockRegionsServiceMockRecorder is the mock recorder for MockRegionsService.
+type MockRegionsServiceMockRecorder struct {
+	mock *MockRegionsService
+}
+
+// NewMockRegionsService creates a new mock instance.
+func NewMockRegionsService(ctrl *gomock.Controller) *MockRegionsService {
+	mock := &MockRegionsService{ctrl: ctrl}
+	mock.recorder = &MockRegionsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRegionsService) EXPECT() *MockRegionsServiceMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockRegionsService) List(arg0 context.Context, arg1 *godo.ListOptions) ([]godo.Region, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].([]godo.Region)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockRegionsServiceMockRecorder) List(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRegionsService)(nil).List), arg0, arg1)
+}
+
+// MockMonitoringService is a mock of MonitoringService interface.
+type MockMonitoringService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMonitoringServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockMonitoringServiceMockRecorder is the mock recorder for MockMonitoringService.
+type MockMonitoringServiceMockRecorder struct {
+	mock *MockMonitoringService
+}
+
+// NewMockMonitoringService creates a new mock instance.
+func NewMockMonitoringService(ctrl *gomock.Controller) *MockMonitoringService {
+	mock := &MockMonitoringService{ctrl: ctrl}
+	mock.recorder = &MockMonitoringServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMonitoringService) EXPECT() *MockMonitoringServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateAlertPolicy mocks base method.
+func (m *MockMonitoringService) CreateAlertPolicy(arg0 context.Context, arg1 *godo.AlertPolicyCreateRequest) (*godo.AlertPolicy, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAlertPolicy", arg0, arg1)
+	ret0, _ := ret[0].(*godo.AlertPolicy)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateAlertPolicy indicates an expected call of CreateAlertPolicy.
+func (mr *MockMonitoringServiceMockRecorder) CreateAlertPolicy(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAlertPolicy", reflect.TypeOf((*MockMonitoringService)(nil).CreateAlertPolicy), arg0, arg1)
+}
+
+// DeleteAlertPolicy mocks base method.
+func (m *MockMonitoringService) DeleteAlertPolicy(arg0 context.Context, arg1 string) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAlertPolicy", arg0, arg1)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAlertPolicy indicates an expected call of DeleteAlertPolicy.
+func (mr *MockMonitoringServiceMockRecorder) DeleteAlertPolicy(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAlertPolicy", reflect.TypeOf((*MockMonitoringService)(nil).DeleteAlertPolicy), arg0, arg1)
+}
+
+// GetAlertPolicy mocks base method.
+func (m *MockMonitoringService) GetAlertPolicy(arg0 context.Context, arg1 string) (*godo.AlertPolicy, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlertPolicy", arg0, arg1)
+	ret0, _ := ret[0].(*godo.AlertPolicy)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAlertPolicy indicates an expected call of GetAlertPolicy.
+func (mr *MockMonitoringServiceMockRecorder) GetAlertPolicy(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlertPolicy", reflect.TypeOf((*MockMonitoringService)(nil).GetAlertPolicy), arg0, arg1)
+}
+
+// GetDbaasMysqlCpuUsage mocks base method.
+func (m *MockMonitoringService) GetDbaasMysqlCpuUsage(ctx context.Context, args *godo.DbaasMysqlCpuUsageRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDbaasMysqlCpuUsage", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDbaasMysqlCpuUsage indicates an expected call of GetDbaasMysqlCpuUsage.
+func (mr *MockMonitoringServiceMockRecorder) GetDbaasMysqlCpuUsage(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDbaasMysqlCpuUsage", reflect.TypeOf((*MockMonitoringService)(nil).GetDbaasMysqlCpuUsage), ctx, args)
+}
+
+// GetDbaasMysqlDiskUsage mocks base method.
+func (m *MockMonitoringService) GetDbaasMysqlDiskUsage(ctx context.Context, args *godo.DbaasMysqlDiskUsageRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDbaasMysqlDiskUsage", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDbaasMysqlDiskUsage indicates an expected call of GetDbaasMysqlDiskUsage.
+func (mr *MockMonitoringServiceMockRecorder) GetDbaasMysqlDiskUsage(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDbaasMysqlDiskUsage", reflect.TypeOf((*MockMonitoringService)(nil).GetDbaasMysqlDiskUsage), ctx, args)
+}
+
+// GetDbaasMysqlIndexVsSequentialReads mocks base method.
+func (m *MockMonitoringService) GetDbaasMysqlIndexVsSequentialReads(ctx context.Context, args *godo.DbaasMysqlServiceMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDbaasMysqlIndexVsSequentialReads", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDbaasMysqlIndexVsSequentialReads indicates an expected call of GetDbaasMysqlIndexVsSequentialReads.
+func (mr *MockMonitoringServiceMockRecorder) GetDbaasMysqlIndexVsSequentialReads(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDbaasMysqlIndexVsSequentialReads", reflect.TypeOf((*MockMonitoringService)(nil).GetDbaasMysqlIndexVsSequentialReads), ctx, args)
+}
+
+// GetDbaasMysqlLoad mocks base method.
+func (m *MockMonitoringService) GetDbaasMysqlLoad(ctx context.Context, args *godo.DbaasMysqlLoadRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDbaasMysqlLoad", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDbaasMysqlLoad indicates an expected call of GetDbaasMysqlLoad.
+func (mr *MockMonitoringServiceMockRecorder) GetDbaasMysqlLoad(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDbaasMysqlLoad", reflect.TypeOf((*MockMonitoringService)(nil).GetDbaasMysqlLoad), ctx, args)
+}
+
+// GetDbaasMysqlMemoryUsage mocks base method.
+func (m *MockMonitoringService) GetDbaasMysqlMemoryUsage(ctx context.Context, args *godo.DbaasMysqlMemoryUsageRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDbaasMysqlMemoryUsage", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDbaasMysqlMemoryUsage indicates an expected call of GetDbaasMysqlMemoryUsage.
+func (mr *MockMonitoringServiceMockRecorder) GetDbaasMysqlMemoryUsage(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDbaasMysqlMemoryUsage", reflect.TypeOf((*MockMonitoringService)(nil).GetDbaasMysqlMemoryUsage), ctx, args)
+}
+
+// GetDbaasMysqlOpRates mocks base method.
+func (m *MockMonitoringService) GetDbaasMysqlOpRates(ctx context.Context, args *godo.DbaasMysqlOpRatesRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDbaasMysqlOpRates", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDbaasMysqlOpRates indicates an expected call of GetDbaasMysqlOpRates.
+func (mr *MockMonitoringServiceMockRecorder) GetDbaasMysqlOpRates(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDbaasMysqlOpRates", reflect.TypeOf((*MockMonitoringService)(nil).GetDbaasMysqlOpRates), ctx, args)
+}
+
+// GetDbaasMysqlSchemaLatency mocks base method.
+func (m *MockMonitoringService) GetDbaasMysqlSchemaLatency(ctx context.Context, args *godo.DbaasMysqlSchemaLatencyRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDbaasMysqlSchemaLatency", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDbaasMysqlSchemaLatency indicates an expected call of GetDbaasMysqlSchemaLatency.
+func (mr *MockMonitoringServiceMockRecorder) GetDbaasMysqlSchemaLatency(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDbaasMysqlSchemaLatency", reflect.TypeOf((*MockMonitoringService)(nil).GetDbaasMysqlSchemaLatency), ctx, args)
+}
+
+// GetDbaasMysqlSchemaThroughput mocks base method.
+func (m *MockMonitoringService) GetDbaasMysqlSchemaThroughput(ctx context.Context, args *godo.DbaasMysqlSchemaThroughputRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDbaasMysqlSchemaThroughput", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDbaasMysqlSchemaThroughput indicates an expected call of GetDbaasMysqlSchemaThroughput.
+func (mr *MockMonitoringServiceMockRecorder) GetDbaasMysqlSchemaThroughput(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDbaasMysqlSchemaThroughput", reflect.TypeOf((*MockMonitoringService)(nil).GetDbaasMysqlSchemaThroughput), ctx, args)
+}
+
+// GetDbaasMysqlThreadsActive mocks base method.
+func (m *MockMonitoringService) GetDbaasMysqlThreadsActive(ctx context.Context, args *godo.DbaasMysqlServiceMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDbaasMysqlThreadsActive", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDbaasMysqlThreadsActive indicates an expected call of GetDbaasMysqlThreadsActive.
+func (mr *MockMonitoringServiceMockRecorder) GetDbaasMysqlThreadsActive(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDbaasMysqlThreadsActive", reflect.TypeOf((*MockMonitoringService)(nil).GetDbaasMysqlThreadsActive), ctx, args)
+}
+
+// GetDbaasMysqlThreadsConnected mocks base method.
+func (m *MockMonitoringService) GetDbaasMysqlThreadsConnected(ctx context.Context, args *godo.DbaasMysqlServiceMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDbaasMysqlThreadsConnected", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDbaasMysqlThreadsConnected indicates an expected call of GetDbaasMysqlThreadsConnected.
+func (mr *MockMonitoringServiceMockRecorder) GetDbaasMysqlThreadsConnected(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDbaasMysqlThreadsConnected", reflect.TypeOf((*MockMonitoringService)(nil).GetDbaasMysqlThreadsConnected), ctx, args)
+}
+
+// GetDbaasMysqlThreadsCreatedRate mocks base method.
+func (m *MockMonitoringService) GetDbaasMysqlThreadsCreatedRate(ctx context.Context, args *godo.DbaasMysqlServiceMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDbaasMysqlThreadsCreatedRate", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDbaasMysqlThreadsCreatedRate indicates an expected call of GetDbaasMysqlThreadsCreatedRate.
+func (mr *MockMonitoringServiceMockRecorder) GetDbaasMysqlThreadsCreatedRate(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDbaasMysqlThreadsCreatedRate", reflect.TypeOf((*MockMonitoringService)(nil).GetDbaasMysqlThreadsCreatedRate), ctx, args)
+}
+
+// GetDropletAvailableMemory mocks base method.
+func (m *MockMonitoringService) GetDropletAvailableMemory(arg0 context.Context, arg1 *godo.DropletMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDropletAvailableMemory", arg0, arg1)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDropletAvailableMemory indicates an expected call of GetDropletAvailableMemory.
+func (mr *MockMonitoringServiceMockRecorder) GetDropletAvailableMemory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDropletAvailableMemory", reflect.TypeOf((*MockMonitoringService)(nil).GetDropletAvailableMemory), arg0, arg1)
+}
+
+// GetDropletBandwidth mocks base method.
+func (m *MockMonitoringService) GetDropletBandwidth(arg0 context.Context, arg1 *godo.DropletBandwidthMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDropletBandwidth", arg0, arg1)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDropletBandwidth indicates an expected call of GetDropletBandwidth.
+func (mr *MockMonitoringServiceMockRecorder) GetDropletBandwidth(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDropletBandwidth", reflect.TypeOf((*MockMonitoringService)(nil).GetDropletBandwidth), arg0, arg1)
+}
+
+// GetDropletCPU mocks base method.
+func (m *MockMonitoringService) GetDropletCPU(arg0 context.Context, arg1 *godo.DropletMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDropletCPU", arg0, arg1)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDropletCPU indicates an expected call of GetDropletCPU.
+func (mr *MockMonitoringServiceMockRecorder) GetDropletCPU(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDropletCPU", reflect.TypeOf((*MockMonitoringService)(nil).GetDropletCPU), arg0, arg1)
+}
+
+// GetDropletCachedMemory mocks base method.
+func (m *MockMonitoringService) GetDropletCachedMemory(arg0 context.Context, arg1 *godo.DropletMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDropletCachedMemory", arg0, arg1)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDropletCachedMemory indicates an expected call of GetDropletCachedMemory.
+func (mr *MockMonitoringServiceMockRecorder) GetDropletCachedMemory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDropletCachedMemory", reflect.TypeOf((*MockMonitoringService)(nil).GetDropletCachedMemory), arg0, arg1)
+}
+
+// GetDropletFilesystemFree mocks base method.
+func (m *MockMonitoringService) GetDropletFilesystemFree(arg0 context.Context, arg1 *godo.DropletMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDropletFilesystemFree", arg0, arg1)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDropletFilesystemFree indicates an expected call of GetDropletFilesystemFree.
+func (mr *MockMonitoringServiceMockRecorder) GetDropletFilesystemFree(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDropletFilesystemFree", reflect.TypeOf((*MockMonitoringService)(nil).GetDropletFilesystemFree), arg0, arg1)
+}
+
+// GetDropletFilesystemSize mocks base method.
+func (m *MockMonitoringService) GetDropletFilesystemSize(arg0 context.Context, arg1 *godo.DropletMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDropletFilesystemSize", arg0, arg1)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDropletFilesystemSize indicates an expected call of GetDropletFilesystemSize.
+func (mr *MockMonitoringServiceMockRecorder) GetDropletFilesystemSize(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDropletFilesystemSize", reflect.TypeOf((*MockMonitoringService)(nil).GetDropletFilesystemSize), arg0, arg1)
+}
+
+// GetDropletFreeMemory mocks base method.
+func (m *MockMonitoringService) GetDropletFreeMemory(arg0 context.Context, arg1 *godo.DropletMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDropletFreeMemory", arg0, arg1)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDropletFreeMemory indicates an expected call of GetDropletFreeMemory.
+func (mr *MockMonitoringServiceMockRecorder) GetDropletFreeMemory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDropletFreeMemory", reflect.TypeOf((*MockMonitoringService)(nil).GetDropletFreeMemory), arg0, arg1)
+}
+
+// GetDropletLoad1 mocks base method.
+func (m *MockMonitoringService) GetDropletLoad1(arg0 context.Context, arg1 *godo.DropletMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDropletLoad1", arg0, arg1)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDropletLoad1 indicates an expected call of GetDropletLoad1.
+func (mr *MockMonitoringServiceMockRecorder) GetDropletLoad1(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDropletLoad1", reflect.TypeOf((*MockMonitoringService)(nil).GetDropletLoad1), arg0, arg1)
+}
+
+// GetDropletLoad15 mocks base method.
+func (m *MockMonitoringService) GetDropletLoad15(arg0 context.Context, arg1 *godo.DropletMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDropletLoad15", arg0, arg1)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDropletLoad15 indicates an expected call of GetDropletLoad15.
+func (mr *MockMonitoringServiceMockRecorder) GetDropletLoad15(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDropletLoad15", reflect.TypeOf((*MockMonitoringService)(nil).GetDropletLoad15), arg0, arg1)
+}
+
+// GetDropletLoad5 mocks base method.
+func (m *MockMonitoringService) GetDropletLoad5(arg0 context.Context, arg1 *godo.DropletMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDropletLoad5", arg0, arg1)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDropletLoad5 indicates an expected call of GetDropletLoad5.
+func (mr *MockMonitoringServiceMockRecorder) GetDropletLoad5(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDropletLoad5", reflect.TypeOf((*MockMonitoringService)(nil).GetDropletLoad5), arg0, arg1)
+}
+
+// GetDropletTotalMemory mocks base method.
+func (m *MockMonitoringService) GetDropletTotalMemory(arg0 context.Context, arg1 *godo.DropletMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDropletTotalMemory", arg0, arg1)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDropletTotalMemory indicates an expected call of GetDropletTotalMemory.
+func (mr *MockMonitoringServiceMockRecorder) GetDropletTotalMemory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDropletTotalMemory", reflect.TypeOf((*MockMonitoringService)(nil).GetDropletTotalMemory), arg0, arg1)
+}
+
+// GetLoadBalancerDropletsConnections mocks base method.
+func (m *MockMonitoringService) GetLoadBalancerDropletsConnections(ctx context.Context, args *godo.LoadBalancerMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadBalancerDropletsConnections", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLoadBalancerDropletsConnections indicates an expected call of GetLoadBalancerDropletsConnections.
+func (mr *MockMonitoringServiceMockRecorder) GetLoadBalancerDropletsConnections(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerDropletsConnections", reflect.TypeOf((*MockMonitoringService)(nil).GetLoadBalancerDropletsConnections), ctx, args)
+}
+
+// GetLoadBalancerDropletsDowntime mocks base method.
+func (m *MockMonitoringService) GetLoadBalancerDropletsDowntime(ctx context.Context, args *godo.LoadBalancerMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadBalancerDropletsDowntime", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLoadBalancerDropletsDowntime indicates an expected call of GetLoadBalancerDropletsDowntime.
+func (mr *MockMonitoringServiceMockRecorder) GetLoadBalancerDropletsDowntime(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerDropletsDowntime", reflect.TypeOf((*MockMonitoringService)(nil).GetLoadBalancerDropletsDowntime), ctx, args)
+}
+
+// GetLoadBalancerDropletsHealthChecks mocks base method.
+func (m *MockMonitoringService) GetLoadBalancerDropletsHealthChecks(ctx context.Context, args *godo.LoadBalancerMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadBalancerDropletsHealthChecks", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLoadBalancerDropletsHealthChecks indicates an expected call of GetLoadBalancerDropletsHealthChecks.
+func (mr *MockMonitoringServiceMockRecorder) GetLoadBalancerDropletsHealthChecks(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerDropletsHealthChecks", reflect.TypeOf((*MockMonitoringService)(nil).GetLoadBalancerDropletsHealthChecks), ctx, args)
+}
+
+// GetLoadBalancerDropletsHttpResponseTime50P mocks base method.
+func (m *MockMonitoringService) GetLoadBalancerDropletsHttpResponseTime50P(ctx context.Context, args *godo.LoadBalancerMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadBalancerDropletsHttpResponseTime50P", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLoadBalancerDropletsHttpResponseTime50P indicates an expected call of GetLoadBalancerDropletsHttpResponseTime50P.
+func (mr *MockMonitoringServiceMockRecorder) GetLoadBalancerDropletsHttpResponseTime50P(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerDropletsHttpResponseTime50P", reflect.TypeOf((*MockMonitoringService)(nil).GetLoadBalancerDropletsHttpResponseTime50P), ctx, args)
+}
+
+// GetLoadBalancerDropletsHttpResponseTime95P mocks base method.
+func (m *MockMonitoringService) GetLoadBalancerDropletsHttpResponseTime95P(ctx context.Context, args *godo.LoadBalancerMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadBalancerDropletsHttpResponseTime95P", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLoadBalancerDropletsHttpResponseTime95P indicates an expected call of GetLoadBalancerDropletsHttpResponseTime95P.
+func (mr *MockMonitoringServiceMockRecorder) GetLoadBalancerDropletsHttpResponseTime95P(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerDropletsHttpResponseTime95P", reflect.TypeOf((*MockMonitoringService)(nil).GetLoadBalancerDropletsHttpResponseTime95P), ctx, args)
+}
+
+// GetLoadBalancerDropletsHttpResponseTime99P mocks base method.
+func (m *MockMonitoringService) GetLoadBalancerDropletsHttpResponseTime99P(ctx context.Context, args *godo.LoadBalancerMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadBalancerDropletsHttpResponseTime99P", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLoadBalancerDropletsHttpResponseTime99P indicates an expected call of GetLoadBalancerDropletsHttpResponseTime99P.
+func (mr *MockMonitoringServiceMockRecorder) GetLoadBalancerDropletsHttpResponseTime99P(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerDropletsHttpResponseTime99P", reflect.TypeOf((*MockMonitoringService)(nil).GetLoadBalancerDropletsHttpResponseTime99P), ctx, args)
+}
+
+// GetLoadBalancerDropletsHttpResponseTimeAvg mocks base method.
+func (m *MockMonitoringService) GetLoadBalancerDropletsHttpResponseTimeAvg(ctx context.Context, args *godo.LoadBalancerMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadBalancerDropletsHttpResponseTimeAvg", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLoadBalancerDropletsHttpResponseTimeAvg indicates an expected call of GetLoadBalancerDropletsHttpResponseTimeAvg.
+func (mr *MockMonitoringServiceMockRecorder) GetLoadBalancerDropletsHttpResponseTimeAvg(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerDropletsHttpResponseTimeAvg", reflect.TypeOf((*MockMonitoringService)(nil).GetLoadBalancerDropletsHttpResponseTimeAvg), ctx, args)
+}
+
+// GetLoadBalancerDropletsHttpResponses mocks base method.
+func (m *MockMonitoringService) GetLoadBalancerDropletsHttpResponses(ctx context.Context, args *godo.LoadBalancerMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadBalancerDropletsHttpResponses", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLoadBalancerDropletsHttpResponses indicates an expected call of GetLoadBalancerDropletsHttpResponses.
+func (mr *MockMonitoringServiceMockRecorder) GetLoadBalancerDropletsHttpResponses(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerDropletsHttpResponses", reflect.TypeOf((*MockMonitoringService)(nil).GetLoadBalancerDropletsHttpResponses), ctx, args)
+}
+
+// GetLoadBalancerDropletsHttpSessionDuration50P mocks base method.
+func (m *MockMonitoringService) GetLoadBalancerDropletsHttpSessionDuration50P(ctx context.Context, args *godo.LoadBalancerMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadBalancerDropletsHttpSessionDuration50P", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLoadBalancerDropletsHttpSessionDuration50P indicates an expected call of GetLoadBalancerDropletsHttpSessionDuration50P.
+func (mr *MockMonitoringServiceMockRecorder) GetLoadBalancerDropletsHttpSessionDuration50P(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerDropletsHttpSessionDuration50P", reflect.TypeOf((*MockMonitoringService)(nil).GetLoadBalancerDropletsHttpSessionDuration50P), ctx, args)
+}
+
+// GetLoadBalancerDropletsHttpSessionDuration95P mocks base method.
+func (m *MockMonitoringService) GetLoadBalancerDropletsHttpSessionDuration95P(ctx context.Context, args *godo.LoadBalancerMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadBalancerDropletsHttpSessionDuration95P", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLoadBalancerDropletsHttpSessionDuration95P indicates an expected call of GetLoadBalancerDropletsHttpSessionDuration95P.
+func (mr *MockMonitoringServiceMockRecorder) GetLoadBalancerDropletsHttpSessionDuration95P(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerDropletsHttpSessionDuration95P", reflect.TypeOf((*MockMonitoringService)(nil).GetLoadBalancerDropletsHttpSessionDuration95P), ctx, args)
+}
+
+// GetLoadBalancerDropletsHttpSessionDurationAvg mocks base method.
+func (m *MockMonitoringService) GetLoadBalancerDropletsHttpSessionDurationAvg(ctx context.Context, args *godo.LoadBalancerMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadBalancerDropletsHttpSessionDurationAvg", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLoadBalancerDropletsHttpSessionDurationAvg indicates an expected call of GetLoadBalancerDropletsHttpSessionDurationAvg.
+func (mr *MockMonitoringServiceMockRecorder) GetLoadBalancerDropletsHttpSessionDurationAvg(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerDropletsHttpSessionDurationAvg", reflect.TypeOf((*MockMonitoringService)(nil).GetLoadBalancerDropletsHttpSessionDurationAvg), ctx, args)
+}
+
+// GetLoadBalancerDropletsQueueSize mocks base method.
+func (m *MockMonitoringService) GetLoadBalancerDropletsQueueSize(ctx context.Context, args *godo.LoadBalancerMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadBalancerDropletsQueueSize", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLoadBalancerDropletsQueueSize indicates an expected call of GetLoadBalancerDropletsQueueSize.
+func (mr *MockMonitoringServiceMockRecorder) GetLoadBalancerDropletsQueueSize(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerDropletsQueueSize", reflect.TypeOf((*MockMonitoringService)(nil).GetLoadBalancerDropletsQueueSize), ctx, args)
+}
+
+// GetLoadBalancerFrontendConnectionsCurrent mocks base method.
+func (m *MockMonitoringService) GetLoadBalancerFrontendConnectionsCurrent(ctx context.Context, args *godo.LoadBalancerMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadBalancerFrontendConnectionsCurrent", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLoadBalancerFrontendConnectionsCurrent indicates an expected call of GetLoadBalancerFrontendConnectionsCurrent.
+func (mr *MockMonitoringServiceMockRecorder) GetLoadBalancerFrontendConnectionsCurrent(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerFrontendConnectionsCurrent", reflect.TypeOf((*MockMonitoringService)(nil).GetLoadBalancerFrontendConnectionsCurrent), ctx, args)
+}
+
+// GetLoadBalancerFrontendConnectionsLimit mocks base method.
+func (m *MockMonitoringService) GetLoadBalancerFrontendConnectionsLimit(ctx context.Context, args *godo.LoadBalancerMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadBalancerFrontendConnectionsLimit", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLoadBalancerFrontendConnectionsLimit indicates an expected call of GetLoadBalancerFrontendConnectionsLimit.
+func (mr *MockMonitoringServiceMockRecorder) GetLoadBalancerFrontendConnectionsLimit(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerFrontendConnectionsLimit", reflect.TypeOf((*MockMonitoringService)(nil).GetLoadBalancerFrontendConnectionsLimit), ctx, args)
+}
+
+// GetLoadBalancerFrontendCpuUtilization mocks base method.
+func (m *MockMonitoringService) GetLoadBalancerFrontendCpuUtilization(ctx context.Context, args *godo.LoadBalancerMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadBalancerFrontendCpuUtilization", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLoadBalancerFrontendCpuUtilization indicates an expected call of GetLoadBalancerFrontendCpuUtilization.
+func (mr *MockMonitoringServiceMockRecorder) GetLoadBalancerFrontendCpuUtilization(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerFrontendCpuUtilization", reflect.TypeOf((*MockMonitoringService)(nil).GetLoadBalancerFrontendCpuUtilization), ctx, args)
+}
+
+// GetLoadBalancerFrontendFirewallDroppedBytes mocks base method.
+func (m *MockMonitoringService) GetLoadBalancerFrontendFirewallDroppedBytes(ctx context.Context, args *godo.LoadBalancerMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadBalancerFrontendFirewallDroppedBytes", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLoadBalancerFrontendFirewallDroppedBytes indicates an expected call of GetLoadBalancerFrontendFirewallDroppedBytes.
+func (mr *MockMonitoringServiceMockRecorder) GetLoadBalancerFrontendFirewallDroppedBytes(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerFrontendFirewallDroppedBytes", reflect.TypeOf((*MockMonitoringService)(nil).GetLoadBalancerFrontendFirewallDroppedBytes), ctx, args)
+}
+
+// GetLoadBalancerFrontendFirewallDroppedPackets mocks base method.
+func (m *MockMonitoringService) GetLoadBalancerFrontendFirewallDroppedPackets(ctx context.Context, args *godo.LoadBalancerMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadBalancerFrontendFirewallDroppedPackets", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLoadBalancerFrontendFirewallDroppedPackets indicates an expected call of GetLoadBalancerFrontendFirewallDroppedPackets.
+func (mr *MockMonitoringServiceMockRecorder) GetLoadBalancerFrontendFirewallDroppedPackets(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerFrontendFirewallDroppedPackets", reflect.TypeOf((*MockMonitoringService)(nil).GetLoadBalancerFrontendFirewallDroppedPackets), ctx, args)
+}
+
+// GetLoadBalancerFrontendHttpRequestsPerSecond mocks base method.
+func (m *MockMonitoringService) GetLoadBalancerFrontendHttpRequestsPerSecond(ctx context.Context, args *godo.LoadBalancerMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadBalancerFrontendHttpRequestsPerSecond", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLoadBalancerFrontendHttpRequestsPerSecond indicates an expected call of GetLoadBalancerFrontendHttpRequestsPerSecond.
+func (mr *MockMonitoringServiceMockRecorder) GetLoadBalancerFrontendHttpRequestsPerSecond(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerFrontendHttpRequestsPerSecond", reflect.TypeOf((*MockMonitoringService)(nil).GetLoadBalancerFrontendHttpRequestsPerSecond), ctx, args)
+}
+
+// GetLoadBalancerFrontendHttpResponses mocks base method.
+func (m *MockMonitoringService) GetLoadBalancerFrontendHttpResponses(ctx context.Context, args *godo.LoadBalancerMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadBalancerFrontendHttpResponses", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLoadBalancerFrontendHttpResponses indicates an expected call of GetLoadBalancerFrontendHttpResponses.
+func (mr *MockMonitoringServiceMockRecorder) GetLoadBalancerFrontendHttpResponses(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerFrontendHttpResponses", reflect.TypeOf((*MockMonitoringService)(nil).GetLoadBalancerFrontendHttpResponses), ctx, args)
+}
+
+// GetLoadBalancerFrontendNetworkThroughputHttp mocks base method.
+func (m *MockMonitoringService) GetLoadBalancerFrontendNetworkThroughputHttp(ctx context.Context, args *godo.LoadBalancerMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadBalancerFrontendNetworkThroughputHttp", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLoadBalancerFrontendNetworkThroughputHttp indicates an expected call of GetLoadBalancerFrontendNetworkThroughputHttp.
+func (mr *MockMonitoringServiceMockRecorder) GetLoadBalancerFrontendNetworkThroughputHttp(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerFrontendNetworkThroughputHttp", reflect.TypeOf((*MockMonitoringService)(nil).GetLoadBalancerFrontendNetworkThroughputHttp), ctx, args)
+}
+
+// GetLoadBalancerFrontendNetworkThroughputTcp mocks base method.
+func (m *MockMonitoringService) GetLoadBalancerFrontendNetworkThroughputTcp(ctx context.Context, args *godo.LoadBalancerMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadBalancerFrontendNetworkThroughputTcp", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLoadBalancerFrontendNetworkThroughputTcp indicates an expected call of GetLoadBalancerFrontendNetworkThroughputTcp.
+func (mr *MockMonitoringServiceMockRecorder) GetLoadBalancerFrontendNetworkThroughputTcp(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerFrontendNetworkThroughputTcp", reflect.TypeOf((*MockMonitoringService)(nil).GetLoadBalancerFrontendNetworkThroughputTcp), ctx, args)
+}
+
+// GetLoadBalancerFrontendNetworkThroughputUdp mocks base method.
+func (m *MockMonitoringService) GetLoadBalancerFrontendNetworkThroughputUdp(ctx context.Context, args *godo.LoadBalancerMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadBalancerFrontendNetworkThroughputUdp", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLoadBalancerFrontendNetworkThroughputUdp indicates an expected call of GetLoadBalancerFrontendNetworkThroughputUdp.
+func (mr *MockMonitoringServiceMockRecorder) GetLoadBalancerFrontendNetworkThroughputUdp(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerFrontendNetworkThroughputUdp", reflect.TypeOf((*MockMonitoringService)(nil).GetLoadBalancerFrontendNetworkThroughputUdp), ctx, args)
+}
+
+// GetLoadBalancerFrontendNlbTcpNetworkThroughput mocks base method.
+func (m *MockMonitoringService) GetLoadBalancerFrontendNlbTcpNetworkThroughput(ctx context.Context, args *godo.LoadBalancerMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadBalancerFrontendNlbTcpNetworkThroughput", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLoadBalancerFrontendNlbTcpNetworkThroughput indicates an expected call of GetLoadBalancerFrontendNlbTcpNetworkThroughput.
+func (mr *MockMonitoringServiceMockRecorder) GetLoadBalancerFrontendNlbTcpNetworkThroughput(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerFrontendNlbTcpNetworkThroughput", reflect.TypeOf((*MockMonitoringService)(nil).GetLoadBalancerFrontendNlbTcpNetworkThroughput), ctx, args)
+}
+
+// GetLoadBalancerFrontendNlbUdpNetworkThroughput mocks base method.
+func (m *MockMonitoringService) GetLoadBalancerFrontendNlbUdpNetworkThroughput(ctx context.Context, args *godo.LoadBalancerMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadBalancerFrontendNlbUdpNetworkThroughput", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLoadBalancerFrontendNlbUdpNetworkThroughput indicates an expected call of GetLoadBalancerFrontendNlbUdpNetworkThroughput.
+func (mr *MockMonitoringServiceMockRecorder) GetLoadBalancerFrontendNlbUdpNetworkThroughput(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerFrontendNlbUdpNetworkThroughput", reflect.TypeOf((*MockMonitoringService)(nil).GetLoadBalancerFrontendNlbUdpNetworkThroughput), ctx, args)
+}
+
+// GetLoadBalancerFrontendTlsConnectionsCurrent mocks base method.
+func (m *MockMonitoringService) GetLoadBalancerFrontendTlsConnectionsCurrent(ctx context.Context, args *godo.LoadBalancerMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadBalancerFrontendTlsConnectionsCurrent", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLoadBalancerFrontendTlsConnectionsCurrent indicates an expected call of GetLoadBalancerFrontendTlsConnectionsCurrent.
+func (mr *MockMonitoringServiceMockRecorder) GetLoadBalancerFrontendTlsConnectionsCurrent(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerFrontendTlsConnectionsCurrent", reflect.TypeOf((*MockMonitoringService)(nil).GetLoadBalancerFrontendTlsConnectionsCurrent), ctx, args)
+}
+
+// GetLoadBalancerFrontendTlsConnectionsExceedingRateLimit mocks base method.
+func (m *MockMonitoringService) GetLoadBalancerFrontendTlsConnectionsExceedingRateLimit(ctx context.Context, args *godo.LoadBalancerMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadBalancerFrontendTlsConnectionsExceedingRateLimit", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLoadBalancerFrontendTlsConnectionsExceedingRateLimit indicates an expected call of GetLoadBalancerFrontendTlsConnectionsExceedingRateLimit.
+func (mr *MockMonitoringServiceMockRecorder) GetLoadBalancerFrontendTlsConnectionsExceedingRateLimit(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerFrontendTlsConnectionsExceedingRateLimit", reflect.TypeOf((*MockMonitoringService)(nil).GetLoadBalancerFrontendTlsConnectionsExceedingRateLimit), ctx, args)
+}
+
+// GetLoadBalancerFrontendTlsConnectionsLimit mocks base method.
+func (m *MockMonitoringService) GetLoadBalancerFrontendTlsConnectionsLimit(ctx context.Context, args *godo.LoadBalancerMetricsRequest) (*godo.MetricsResponse, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadBalancerFrontendTlsConnectionsLimit", ctx, args)
+	ret0, _ := ret[0].(*godo.MetricsResponse)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLoadBalancerFrontendTlsConnectionsLimit indicates an expected call of GetLoadBalancerFrontendTlsConnectionsLimit.
+func (mr *MockMonitoringServiceMockRecorder) GetLoadBalancerFrontendTlsConnectionsLimit(ctx, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerFrontendTlsConnectionsLimit", reflect.TypeOf((*MockMonitoringService)(nil).GetLoadBalancerFrontendTlsConnectionsLimit), ctx, args)
+}
+
+// ListAlertPolicies mocks base method.
+func (m *MockMonitoringService) ListAlertPolicies(arg0 context.Context, arg1 *godo.ListOptions) ([]godo.AlertPolicy, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAlertPolicies", arg0, arg1)
+	ret0, _ := ret[0].([]godo.AlertPolicy)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListAlertPolicies indicates an expected call of ListAlertPolicies.
+func (mr *MockMonitoringServiceMockRecorder) ListAlertPolicies(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAlertPolicies", reflect.TypeOf((*MockMonitoringService)(nil).ListAlertPolicies), arg0, arg1)
+}
+
+// UpdateAlertPolicy mocks base method.
+func (m *MockMonitoringService) UpdateAlertPolicy(arg0 context.Context, arg1 string, arg2 *godo.AlertPolicyUpdateRequest) (*godo.AlertPolicy, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAlertPolicy", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.AlertPolicy)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateAlertPolicy indicates an expected call of UpdateAlertPolicy.
+func (mr *MockMonitoringServiceMockRecorder) UpdateAlertPolicy(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAlertPolicy", reflect.TypeOf((*MockMonitoringService)(nil).UpdateAlertPolicy), arg0, arg1, arg2)
+}
+
+// MockDropletAutoscaleService is a mock of DropletAutoscaleService interface.
+type MockDropletAutoscaleService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDropletAutoscaleServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockDropletAutoscaleServiceMockRecorder is the mock recorder for MockDropletAutoscaleService.
+type MockDropletAutoscaleServiceMockRecorder struct {
+	mock *MockDropletAutoscaleService
+}
+
+// NewMockDropletAutoscaleService creates a new mock instance.
+func NewMockDropletAutoscaleService(ctrl *gomock.Controller) *MockDropletAutoscaleService {
+	mock := &MockDropletAutoscaleService{ctrl: ctrl}
+	mock.recorder = &MockDropletAutoscaleServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDropletAutoscaleService) EXPECT() *MockDropletAutoscaleServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockDropletAutoscaleService) Create(arg0 context.Context, arg1 *godo.DropletAutoscalePoolRequest) (*godo.DropletAutoscalePool, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*godo.DropletAutoscalePool)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDropletAutoscaleServiceMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDropletAutoscaleService)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockDropletAutoscaleService) Delete(arg0 context.Context, arg1 string) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDropletAutoscaleServiceMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDropletAutoscaleService)(nil).Delete), arg0, arg1)
+}
+
+// DeleteDangerous mocks base method.
+func (m *MockDropletAutoscaleService) DeleteDangerous(arg0 context.Context, arg1 string) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDangerous", arg0, arg1)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteDangerous indicates an expected call of DeleteDangerous.
+func (mr *MockDropletAutoscaleServiceMockRecorder) DeleteDangerous(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDangerous", reflect.TypeOf((*MockDropletAutoscaleService)(nil).DeleteDangerous), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockDropletAutoscaleService) Get(arg0 context.Context, arg1 string) (*godo.DropletAutoscalePool, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*godo.DropletAutoscalePool)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockDropletAutoscaleServiceMockRecorder) Get(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDropletAutoscaleService)(nil).Get), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockDropletAutoscaleService) List(arg0 context.Context, arg1 *godo.ListOptions) ([]*godo.DropletAutoscalePool, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].([]*godo.DropletAutoscalePool)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockDropletAutoscaleServiceMockRecorder) List(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDropletAutoscaleService)(nil).List), arg0, arg1)
+}
+
+// ListHistory mocks base method.
+func (m *MockDropletAutoscaleService) ListHistory(arg0 context.Context, arg1 string, arg2 *godo.ListOptions) ([]*godo.DropletAutoscaleHistoryEvent, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHistory", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*godo.DropletAutoscaleHistoryEvent)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListHistory indicates an expected call of ListHistory.
+func (mr *MockDropletAutoscaleServiceMockRecorder) ListHistory(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHistory", reflect.TypeOf((*MockDropletAutoscaleService)(nil).ListHistory), arg0, arg1, arg2)
+}
+
+// ListMembers mocks base method.
+func (m *MockDropletAutoscaleService) ListMembers(arg0 context.Context, arg1 string, arg2 *godo.ListOptions) ([]*godo.DropletAutoscaleResource, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMembers", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*godo.DropletAutoscaleResource)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListMembers indicates an expected call of ListMembers.
+func (mr *MockDropletAutoscaleServiceMockRecorder) ListMembers(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMembers", reflect.TypeOf((*MockDropletAutoscaleService)(nil).ListMembers), arg0, arg1, arg2)
+}
+
+// Update mocks base method.
+func (m *MockDropletAutoscaleService) Update(arg0 context.Context, arg1 string, arg2 *godo.DropletAutoscalePoolRequest) (*godo.DropletAutoscalePool, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*godo.DropletAutoscalePool)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockDropletAutoscaleServiceMockRecorder) Update(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDropletAutoscaleService)(nil).Update), arg0, arg1, arg2)
+}
+
+// MockSnapshotsService is a mock of SnapshotsService interface.
+type MockSnapshotsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSnapshotsServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockSnapshotsServiceMockRecorder is the mock recorder for MockSnapshotsService.
+type MockSnapshotsServiceMockRecorder struct {
+	mock *MockSnapshotsService
+}
+
+// NewMockSnapshotsService creates a new mock instance.
+func NewMockSnapshotsService(ctrl *gomock.Controller) *MockSnapshotsService {
+	mock := &MockSnapshotsService{ctrl: ctrl}
+	mock.recorder = &MockSnapshotsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSnapshotsService) EXPECT() *MockSnapshotsServiceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockSnapshotsService) Delete(arg0 context.Context, arg1 string) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSnapshotsServiceMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSnapshotsService)(nil).Delete), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockSnapshotsService) Get(arg0 context.Context, arg1 string) (*godo.Snapshot, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*godo.Snapshot)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSnapshotsServiceMockRecorder) Get(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSnapshotsService)(nil).Get), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockSnapshotsService) List(arg0 context.Context, arg1 *godo.ListOptions) ([]godo.Snapshot, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].([]godo.Snapshot)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockSnapshotsServiceMockRecorder) List(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSnapshotsService)(nil).List), arg0, arg1)
+}
+
+// ListDroplet mocks base method.
+func (m *MockSnapshotsService) ListDroplet(arg0 context.Context, arg1 *godo.ListOptions) ([]godo.Snapshot, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDroplet", arg0, arg1)
+	ret0, _ := ret[0].([]godo.Snapshot)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListDroplet indicates an expected call of ListDroplet.
+func (mr *MockSnapshotsServiceMockRecorder) ListDroplet(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDroplet", reflect.TypeOf((*MockSnapshotsService)(nil).ListDroplet), arg0, arg1)
+}
+
+// ListVolume mocks base method.
+func (m *MockSnapshotsService) ListVolume(arg0 context.Context, arg1 *godo.ListOptions) ([]godo.Snapshot, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVolume", arg0, arg1)
+	ret0, _ := ret[0].([]godo.Snapshot)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListVolume indicates an expected call of ListVolume.
+func (mr *MockSnapshotsServiceMockRecorder) ListVolume(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVolume", reflect.TypeOf((*MockSnapshotsService)(nil).ListVolume), arg0, arg1)
+}
+
+// ListVolumeSnapshotByRegion mocks base method.
+func (m *MockSnapshotsService) ListVolumeSnapshotByRegion(arg0 context.Context, arg1 string, arg2 *godo.ListOptions) ([]godo.Snapshot, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVolumeSnapshotByRegion", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]godo.Snapshot)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListVolumeSnapshotByRegion indicates an expected call of ListVolumeSnapshotByRegion.
+func (mr *MockSnapshotsServiceMockRecorder) ListVolumeSnapshotByRegion(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVolumeSnapshotByRegion", reflect.TypeOf((*MockSnapshotsService)(nil).ListVolumeSnapshotByRegion), arg0, arg1, arg2)
+}
@@ -0,0 +1,126 @@
+package droplet
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"mcp-digitalocean/internal/testhelpers"
+	"mcp-digitalocean/pkg/registry/common"
+)
+
+func staticClient(client *godo.Client) func(ctx context.Context) (*godo.Client, error) {
+	return func(ctx context.Context) (*godo.Client, error) { return client, nil }
+}
+
+func TestGetToolsNotFound(t *testing.T) {
+	type handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	tests := []struct {
+		name     string
+		setup    func(ctrl *gomock.Controller, err error) handler
+		args     map[string]any
+		resource string
+		id       string
+	}{
+		{
+			name: "droplet",
+			setup: func(ctrl *gomock.Controller, err error) handler {
+				m := NewMockDropletsService(ctrl)
+				m.EXPECT().Get(gomock.Any(), 404).Return(nil, nil, err)
+				return NewDropletTool(staticClient(&godo.Client{Droplets: m})).getDropletByID
+			},
+			args:     map[string]any{"ID": float64(404)},
+			resource: "droplet", id: "404",
+		},
+		{
+			name: "droplet neighbors",
+			setup: func(ctrl *gomock.Controller, err error) handler {
+				m := NewMockDropletsService(ctrl)
+				m.EXPECT().Neighbors(gomock.Any(), 404).Return(nil, nil, err)
+				return NewDropletTool(staticClient(&godo.Client{Droplets: m})).getDropletNeighbors
+			},
+			args:     map[string]any{"ID": float64(404)},
+			resource: "droplet", id: "404",
+		},
+		{
+			name: "droplet kernels",
+			setup: func(ctrl *gomock.Controller, err error) handler {
+				m := NewMockDropletsService(ctrl)
+				m.EXPECT().Kernels(gomock.Any(), 404, gomock.Any()).Return(nil, nil, err)
+				return NewDropletTool(staticClient(&godo.Client{Droplets: m})).getDropletKernels
+			},
+			args:     map[string]any{"ID": float64(404)},
+			resource: "droplet", id: "404",
+		},
+		{
+			name: "droplet backup policy",
+			setup: func(ctrl *gomock.Controller, err error) handler {
+				m := NewMockDropletsService(ctrl)
+				m.EXPECT().GetBackupPolicy(gomock.Any(), 404).Return(nil, nil, err)
+				return NewDropletTool(staticClient(&godo.Client{Droplets: m})).getDropletBackupPolicy
+			},
+			args:     map[string]any{"ID": float64(404)},
+			resource: "droplet", id: "404",
+		},
+		{
+			name: "droplet transfer usage",
+			setup: func(ctrl *gomock.Controller, err error) handler {
+				m := NewMockDropletsService(ctrl)
+				m.EXPECT().Get(gomock.Any(), 404).Return(nil, nil, err)
+				return NewDropletTool(staticClient(&godo.Client{Droplets: m})).getTransferUsage
+			},
+			args:     map[string]any{"ID": float64(404)},
+			resource: "droplet", id: "404",
+		},
+		{
+			name: "droplet action",
+			setup: func(ctrl *gomock.Controller, err error) handler {
+				m := NewMockDropletActionsService(ctrl)
+				m.EXPECT().Get(gomock.Any(), 1, 404).Return(nil, nil, err)
+				return NewDropletTool(staticClient(&godo.Client{DropletActions: m})).getDropletActionByID
+			},
+			args:     map[string]any{"DropletID": float64(1), "ActionID": float64(404)},
+			resource: "droplet action", id: "1/404",
+		},
+		{
+			name: "image",
+			setup: func(ctrl *gomock.Controller, err error) handler {
+				m := NewMockImagesService(ctrl)
+				m.EXPECT().GetByID(gomock.Any(), 404).Return(nil, nil, err)
+				return NewImageTool(staticClient(&godo.Client{Images: m})).getImageByID
+			},
+			args:     map[string]any{"ID": float64(404)},
+			resource: "image", id: "404",
+		},
+		{
+			name: "image action",
+			setup: func(ctrl *gomock.Controller, err error) handler {
+				m := NewMockImageActionsService(ctrl)
+				m.EXPECT().Get(gomock.Any(), 1, 404).Return(nil, nil, err)
+				return NewImageActionsTool(staticClient(&godo.Client{ImageActions: m})).getImageAction
+			},
+			args:     map[string]any{"ImageID": float64(1), "ActionID": float64(404)},
+			resource: "image action", id: "1/404",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			get := tc.setup(ctrl, testhelpers.NotFoundError("/v2/"+tc.id))
+
+			resp, err := get(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			require.True(t, resp.IsError)
+			var out common.NotFound
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+			require.Equal(t, common.ErrorCodeNotFound, out.Code)
+			require.Equal(t, tc.resource, out.Resource)
+			require.Equal(t, tc.id, out.ID)
+		})
+	}
+}
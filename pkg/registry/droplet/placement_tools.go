@@ -0,0 +1,247 @@
+package droplet
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	middleware "mcp-digitalocean/internal"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"mcp-digitalocean/pkg/registry/common"
+)
+
+// placementCacheTTL is how long a computed set of placement options is reused.
+const placementCacheTTL = 10 * time.Minute
+
+// PlacementTool finds the regions where a droplet of a given size and image
+// can be created.
+type PlacementTool struct {
+	client           func(ctx context.Context) (*godo.Client, error)
+	preferredRegions []string
+	ttl              time.Duration
+	now              func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]placementEntry
+}
+
+type placementEntry struct {
+	options *PlacementOptions
+	expires time.Time
+}
+
+// PlacementRegion is a region that offers both the size and the image.
+type PlacementRegion struct {
+	Slug      string `json:"slug"`
+	Name      string `json:"name"`
+	Preferred bool   `json:"preferred,omitempty"`
+}
+
+// PlacementOptions lists the regions a droplet can be created in, preferred
+// regions first.
+type PlacementOptions struct {
+	Size    string            `json:"size"`
+	Image   string            `json:"image"`
+	Regions []PlacementRegion `json:"regions"`
+	Note    string            `json:"note,omitempty"`
+}
+
+// NewPlacementTool creates a placement tool that lists preferredRegions, in
+// that order, ahead of the other regions.
+func NewPlacementTool(client func(ctx context.Context) (*godo.Client, error), preferredRegions []string) *PlacementTool {
+	return &PlacementTool{
+		client:           client,
+		preferredRegions: preferredRegions,
+		ttl:              placementCacheTTL,
+		now:              time.Now,
+		cache:            make(map[string]placementEntry),
+	}
+}
+
+// placementOptions returns the regions offering both Size and the image given
+// by ImageSlug or ImageID.
+func (p *PlacementTool) placementOptions(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	size, _ := args["Size"].(string)
+	if size == "" {
+		return mcp.NewToolResultError("Size is required"), nil
+	}
+	imageSlug, _ := args["ImageSlug"].(string)
+	imageID, hasImageID := args["ImageID"].(float64)
+	if (imageSlug == "") == !hasImageID {
+		return mcp.NewToolResultError("exactly one of ImageSlug or ImageID is required"), nil
+	}
+	image := imageSlug
+	if hasImageID {
+		image = strconv.Itoa(int(imageID))
+	}
+
+	key := placementCacheKey(ctx, size, image)
+	options, ok := p.cached(key)
+	if !ok {
+		client, err := p.client(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+		}
+		options, err = p.lookup(ctx, client, size, imageSlug, int(imageID))
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("api error", err), nil
+		}
+		if options == nil {
+			return mcp.NewToolResultError(fmt.Sprintf("size %q not found", size)), nil
+		}
+		p.store(key, options)
+	}
+
+	jsonData, err := json.MarshalIndent(options, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+// lookup intersects the size's and the image's regions and keeps those that
+// are currently available. It returns nil options when the size does not exist.
+func (p *PlacementTool) lookup(ctx context.Context, client *godo.Client, sizeSlug, imageSlug string, imageID int) (*PlacementOptions, error) {
+	size, err := findSize(ctx, client, sizeSlug)
+	if err != nil || size == nil {
+		return nil, err
+	}
+
+	var image *godo.Image
+	if imageSlug != "" {
+		image, _, err = client.Images.GetBySlug(ctx, imageSlug)
+	} else {
+		image, _, err = client.Images.GetByID(ctx, imageID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	regions, err := common.FetchAll(ctx, common.MaxPerPage, client.Regions.List)
+	if err != nil {
+		return nil, err
+	}
+
+	options := &PlacementOptions{Size: size.Slug, Image: image.Slug, Regions: placementRegions(size, image, regions, p.preferredRegions)}
+	if options.Image == "" {
+		options.Image = strconv.Itoa(image.ID)
+	}
+	if len(options.Regions) == 0 {
+		options.Note = fmt.Sprintf("no available region offers both size %s and image %s; choose a different size or image", options.Size, options.Image)
+	}
+	return options, nil
+}
+
+// placementRegions returns the available regions listed by both size and
+// image, preferred regions first in the given order and the rest by slug.
+func placementRegions(size *godo.Size, image *godo.Image, regions []godo.Region, preferred []string) []PlacementRegion {
+	available := make(map[string]godo.Region, len(regions))
+	for _, r := range regions {
+		if r.Available {
+			available[r.Slug] = r
+		}
+	}
+
+	result := []PlacementRegion{}
+	for _, slug := range size.Regions {
+		r, ok := available[slug]
+		if !ok || !slices.Contains(image.Regions, slug) {
+			continue
+		}
+		result = append(result, PlacementRegion{Slug: r.Slug, Name: r.Name, Preferred: slices.Contains(preferred, slug)})
+	}
+
+	rank := func(slug string) int {
+		if i := slices.Index(preferred, slug); i >= 0 {
+			return i
+		}
+		return len(preferred)
+	}
+	slices.SortFunc(result, func(a, b PlacementRegion) int {
+		if d := rank(a.Slug) - rank(b.Slug); d != 0 {
+			return d
+		}
+		return cmp.Compare(a.Slug, b.Slug)
+	})
+	return result
+}
+
+// gpuSizePrefix starts the slug of every GPU droplet size.
+const gpuSizePrefix = "gpu-"
+
+// gpuRegionMismatch returns a message naming the regions that offer GPU size
+// sizeSlug when region is not one of them. It returns no message for sizes
+// without GPUs or that are unknown, leaving those to the API.
+func gpuRegionMismatch(ctx context.Context, client *godo.Client, sizeSlug, region string) (string, error) {
+	size, err := findSize(ctx, client, sizeSlug)
+	if err != nil || size == nil || size.GPUInfo == nil {
+		return "", err
+	}
+	if slices.Contains(size.Regions, region) {
+		return "", nil
+	}
+	if len(size.Regions) == 0 {
+		return fmt.Sprintf("GPU size %s is not currently offered in any region; use size-list-gpu to choose another GPU size", size.Slug), nil
+	}
+	return fmt.Sprintf("GPU size %s is not offered in region %s; it is offered in %s. Use size-list-gpu or placement-options to choose a region",
+		size.Slug, region, strings.Join(size.Regions, ", ")), nil
+}
+
+func (p *PlacementTool) cached(key string) (*PlacementOptions, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	entry, ok := p.cache[key]
+	if !ok || !p.now().Before(entry.expires) {
+		return nil, false
+	}
+	return entry.options, true
+}
+
+func (p *PlacementTool) store(key string, options *PlacementOptions) {
+	now := p.now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for k, e := range p.cache {
+		if !now.Before(e.expires) {
+			delete(p.cache, k)
+		}
+	}
+	p.cache[key] = placementEntry{options: options, expires: now.Add(p.ttl)}
+}
+
+// placementCacheKey scopes cached options to the caller's token, since
+// private images are only visible to their owner.
+func placementCacheKey(ctx context.Context, size, image string) string {
+	auth, _ := ctx.Value(middleware.AuthKey{}).(string)
+	h := sha256.Sum256([]byte(auth))
+	return hex.EncodeToString(h[:8]) + ":" + size + ":" + image
+}
+
+// Tools returns the placement tools.
+func (p *PlacementTool) Tools() []server.ServerTool {
+	return []server.ServerTool{
+		{
+			Handler: p.placementOptions,
+			Tool: mcp.NewTool("placement-options",
+				common.WithHints(common.HintsRead),
+				mcp.WithDescription("List the regions where a droplet with the given size and image can be created: regions offering both the size and the image that are currently available. Check this before droplet-create to avoid region mismatches. Results are cached for 10 minutes."),
+				mcp.WithString("Size", mcp.Required(), mcp.Description("Slug of the droplet size, e.g. s-1vcpu-1gb")),
+				mcp.WithString("ImageSlug", mcp.Description("Slug of the image, e.g. ubuntu-24-04-x64. Use either ImageSlug or ImageID")),
+				mcp.WithNumber("ImageID", mcp.Description("ID of the image, for snapshots and custom images. Use either ImageSlug or ImageID")),
+			),
+		},
+	}
+}
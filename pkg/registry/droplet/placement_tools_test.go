@@ -0,0 +1,213 @@
+package droplet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type placementMocks struct {
+	sizes   *MockSizesService
+	images  *MockImagesService
+	regions *MockRegionsService
+}
+
+func setupPlacementToolWithMocks(ctrl *gomock.Controller, preferred []string, now *time.Time) (*PlacementTool, placementMocks) {
+	m := placementMocks{
+		sizes:   NewMockSizesService(ctrl),
+		images:  NewMockImagesService(ctrl),
+		regions: NewMockRegionsService(ctrl),
+	}
+	client := func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{Sizes: m.sizes, Images: m.images, Regions: m.regions}, nil
+	}
+	tool := NewPlacementTool(client, preferred)
+	tool.now = func() time.Time { return *now }
+	return tool, m
+}
+
+var placementTestRegions = []godo.Region{
+	{Slug: "nyc1", Name: "New York 1", Available: true},
+	{Slug: "nyc3", Name: "New York 3", Available: true},
+	{Slug: "ams3", Name: "Amsterdam 3", Available: true},
+	{Slug: "sfo1", Name: "San Francisco 1", Available: false},
+	{Slug: "sgp1", Name: "Singapore 1", Available: true},
+}
+
+func TestPlacementRegions(t *testing.T) {
+	tests := []struct {
+		name         string
+		sizeRegions  []string
+		imageRegions []string
+		preferred    []string
+		want         []PlacementRegion
+	}{
+		{
+			name:         "intersection sorted by slug",
+			sizeRegions:  []string{"sgp1", "nyc3", "ams3", "nyc1"},
+			imageRegions: []string{"nyc1", "nyc3", "sgp1", "fra1"},
+			want: []PlacementRegion{
+				{Slug: "nyc1", Name: "New York 1"},
+				{Slug: "nyc3", Name: "New York 3"},
+				{Slug: "sgp1", Name: "Singapore 1"},
+			},
+		},
+		{
+			name:         "preferred regions first in flag order",
+			sizeRegions:  []string{"sgp1", "nyc3", "ams3", "nyc1"},
+			imageRegions: []string{"sgp1", "nyc3", "ams3", "nyc1"},
+			preferred:    []string{"sgp1", "fra1", "ams3"},
+			want: []PlacementRegion{
+				{Slug: "sgp1", Name: "Singapore 1", Preferred: true},
+				{Slug: "ams3", Name: "Amsterdam 3", Preferred: true},
+				{Slug: "nyc1", Name: "New York 1"},
+				{Slug: "nyc3", Name: "New York 3"},
+			},
+		},
+		{
+			name:         "unavailable regions are dropped",
+			sizeRegions:  []string{"sfo1", "nyc3"},
+			imageRegions: []string{"sfo1", "nyc3"},
+			want:         []PlacementRegion{{Slug: "nyc3", Name: "New York 3"}},
+		},
+		{
+			name:         "no overlap",
+			sizeRegions:  []string{"nyc1"},
+			imageRegions: []string{"ams3"},
+			want:         []PlacementRegion{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := placementRegions(&godo.Size{Regions: tc.sizeRegions}, &godo.Image{Regions: tc.imageRegions}, placementTestRegions, tc.preferred)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestPlacementTool_placementOptionsCaches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	tool, m := setupPlacementToolWithMocks(ctrl, []string{"ams3"}, &now)
+
+	expectLookup := func() {
+		m.sizes.EXPECT().List(gomock.Any(), gomock.Any()).Return([]godo.Size{{Slug: "s-1vcpu-1gb", Regions: []string{"nyc3", "ams3"}}}, nil, nil)
+		m.images.EXPECT().GetBySlug(gomock.Any(), "ubuntu-24-04-x64").Return(&godo.Image{ID: 7, Slug: "ubuntu-24-04-x64", Regions: []string{"nyc3", "ams3"}}, nil, nil)
+		m.regions.EXPECT().List(gomock.Any(), gomock.Any()).Return(placementTestRegions, nil, nil)
+	}
+	call := func() PlacementOptions {
+		resp, err := tool.placementOptions(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+			"Size":      "s-1vcpu-1gb",
+			"ImageSlug": "ubuntu-24-04-x64",
+		}}})
+		require.NoError(t, err)
+		require.False(t, resp.IsError)
+		var options PlacementOptions
+		require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &options))
+		return options
+	}
+
+	expectLookup()
+	want := PlacementOptions{Size: "s-1vcpu-1gb", Image: "ubuntu-24-04-x64", Regions: []PlacementRegion{
+		{Slug: "ams3", Name: "Amsterdam 3", Preferred: true},
+		{Slug: "nyc3", Name: "New York 3"},
+	}}
+	require.Equal(t, want, call())
+
+	// served from the cache until the TTL passes.
+	now = now.Add(placementCacheTTL - time.Second)
+	require.Equal(t, want, call())
+
+	now = now.Add(time.Second)
+	expectLookup()
+	require.Equal(t, want, call())
+}
+
+func TestPlacementTool_placementOptionsEmpty(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	tool, m := setupPlacementToolWithMocks(ctrl, nil, &now)
+
+	m.sizes.EXPECT().List(gomock.Any(), gomock.Any()).Return([]godo.Size{{Slug: "gpu-h100x1-80gb", Regions: []string{"tor1"}}}, nil, nil)
+	m.images.EXPECT().GetByID(gomock.Any(), 42).Return(&godo.Image{ID: 42, Regions: []string{"nyc3"}}, nil, nil)
+	m.regions.EXPECT().List(gomock.Any(), gomock.Any()).Return(placementTestRegions, nil, nil)
+
+	resp, err := tool.placementOptions(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"Size":    "gpu-h100x1-80gb",
+		"ImageID": float64(42),
+	}}})
+	require.NoError(t, err)
+	require.False(t, resp.IsError)
+
+	var options PlacementOptions
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &options))
+	require.Empty(t, options.Regions)
+	require.Equal(t, "42", options.Image)
+	require.Contains(t, options.Note, "no available region offers both size gpu-h100x1-80gb and image 42")
+}
+
+func TestPlacementTool_placementOptionsErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(placementMocks)
+		wantMessage string
+	}{
+		{
+			name:        "missing size",
+			args:        map[string]any{"ImageSlug": "ubuntu-24-04-x64"},
+			wantMessage: "Size is required",
+		},
+		{
+			name:        "no image",
+			args:        map[string]any{"Size": "s-1vcpu-1gb"},
+			wantMessage: "exactly one of ImageSlug or ImageID is required",
+		},
+		{
+			name:        "both images",
+			args:        map[string]any{"Size": "s-1vcpu-1gb", "ImageSlug": "ubuntu-24-04-x64", "ImageID": float64(7)},
+			wantMessage: "exactly one of ImageSlug or ImageID is required",
+		},
+		{
+			name: "unknown size",
+			args: map[string]any{"Size": "s-huge", "ImageSlug": "ubuntu-24-04-x64"},
+			mockSetup: func(m placementMocks) {
+				m.sizes.EXPECT().List(gomock.Any(), gomock.Any()).Return([]godo.Size{{Slug: "s-1vcpu-1gb"}}, nil, nil)
+			},
+			wantMessage: `size "s-huge" not found`,
+		},
+		{
+			name: "image error",
+			args: map[string]any{"Size": "s-1vcpu-1gb", "ImageSlug": "nope"},
+			mockSetup: func(m placementMocks) {
+				m.sizes.EXPECT().List(gomock.Any(), gomock.Any()).Return([]godo.Size{{Slug: "s-1vcpu-1gb"}}, nil, nil)
+				m.images.EXPECT().GetBySlug(gomock.Any(), "nope").Return(nil, nil, errors.New("image not found"))
+			},
+			wantMessage: "image not found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+			tool, m := setupPlacementToolWithMocks(ctrl, nil, &now)
+			if tc.mockSetup != nil {
+				tc.mockSetup(m)
+			}
+
+			resp, err := tool.placementOptions(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			require.True(t, resp.IsError)
+			require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.wantMessage)
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package droplet
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	middleware "mcp-digitalocean/internal"
+	"mcp-digitalocean/pkg/registry/common"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// creationCacheSize is how many droplet create requests are remembered for
+// droplet-describe-provisioning.
+const creationCacheSize = 100
+
+const (
+	sourceCached = "as-created (cached)"
+	sourceLive   = "live"
+)
+
+var (
+	// secretLinePattern matches "key: value" and "KEY=value" lines whose key
+	// names a secret, as in cloud-config or shell user data.
+	secretLinePattern = regexp.MustCompile(`(?im)^(\s*(?:-\s+)?(?:export\s+)?[\w.-]*(?:password|passwd|secret|token|api[_-]?key|access[_-]?key|private[_-]?key)[\w.-]*["']?\s*[:=]\s*).+$`)
+	// privateKeyPattern matches PEM private key blocks.
+	privateKeyPattern = regexp.MustCompile(`(?s)-----BEGIN [A-Z ]*PRIVATE KEY-----.*?-----END [A-Z ]*PRIVATE KEY-----`)
+)
+
+// sanitizeUserData redacts secret values and private keys from user data so
+// the cached copy never holds them.
+func sanitizeUserData(userData string) string {
+	sanitized := privateKeyPattern.ReplaceAllString(userData, "[REDACTED PRIVATE KEY]")
+	return secretLinePattern.ReplaceAllString(sanitized, "${1}[REDACTED]")
+}
+
+// AsCreated is what this server sent to the API when it created a droplet.
+type AsCreated struct {
+	Source         string    `json:"source"`
+	CreatedAt      time.Time `json:"created_at"`
+	Name           string    `json:"name"`
+	Region         string    `json:"region"`
+	Size           string    `json:"size"`
+	Image          string    `json:"image"`
+	SSHKeys        []string  `json:"ssh_keys,omitempty"`
+	Tags           []string  `json:"tags,omitempty"`
+	VPCUUID        string    `json:"vpc_uuid,omitempty"`
+	Backups        bool      `json:"backups"`
+	Monitoring     bool      `json:"monitoring"`
+	UserData       string    `json:"user_data,omitempty"`
+	UserDataSHA256 string    `json:"user_data_sha256,omitempty"`
+}
+
+// LiveProvisioning is what the API reports about a droplet now.
+type LiveProvisioning struct {
+	Source   string   `json:"source"`
+	Name     string   `json:"name"`
+	Status   string   `json:"status"`
+	Region   string   `json:"region,omitempty"`
+	Size     string   `json:"size"`
+	Image    string   `json:"image,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	VPCUUID  string   `json:"vpc_uuid,omitempty"`
+	Features []string `json:"features,omitempty"`
+}
+
+// Provisioning combines the cached create request with the live droplet.
+type Provisioning struct {
+	DropletID int               `json:"droplet_id"`
+	AsCreated *AsCreated        `json:"as_created"`
+	Live      *LiveProvisioning `json:"live"`
+	Notes     []string          `json:"notes"`
+}
+
+// creationCache remembers sanitized create requests by droplet, dropping the
+// oldest once full. Entries are scoped to the caller's token.
+type creationCache struct {
+	size int
+
+	mu      sync.Mutex
+	entries map[string]*AsCreated
+	order   []string
+}
+
+func newCreationCache(size int) *creationCache {
+	return &creationCache{size: size, entries: make(map[string]*AsCreated)}
+}
+
+func creationCacheKey(ctx context.Context, dropletID int) string {
+	auth, _ := ctx.Value(middleware.AuthKey{}).(string)
+	h := sha256.Sum256([]byte(auth))
+	return hex.EncodeToString(h[:8]) + ":" + strconv.Itoa(dropletID)
+}
+
+// store records a sanitized copy of req for the droplet it created.
+func (c *creationCache) store(ctx context.Context, dropletID int, req *godo.DropletCreateRequest, now time.Time) {
+	created := &AsCreated{
+		Source:     sourceCached,
+		CreatedAt:  now.UTC(),
+		Name:       req.Name,
+		Region:     req.Region,
+		Size:       req.Size,
+		Image:      req.Image.Slug,
+		Tags:       req.Tags,
+		VPCUUID:    req.VPCUUID,
+		Backups:    req.Backups,
+		Monitoring: req.Monitoring,
+	}
+	if created.Image == "" {
+		created.Image = strconv.Itoa(req.Image.ID)
+	}
+	for _, key := range req.SSHKeys {
+		if key.Fingerprint != "" {
+			created.SSHKeys = append(created.SSHKeys, key.Fingerprint)
+		} else {
+			created.SSHKeys = append(created.SSHKeys, strconv.Itoa(key.ID))
+		}
+	}
+	if req.UserData != "" {
+		sum := sha256.Sum256([]byte(req.UserData))
+		created.UserDataSHA256 = hex.EncodeToString(sum[:])
+		created.UserData = sanitizeUserData(req.UserData)
+	}
+
+	key := creationCacheKey(ctx, dropletID)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; !ok {
+		c.order = append(c.order, key)
+	}
+	c.entries[key] = created
+	for len(c.order) > c.size {
+		delete(c.entries, c.order[0])
+		c.order = c.order[1:]
+	}
+}
+
+func (c *creationCache) get(ctx context.Context, dropletID int) (*AsCreated, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	created, ok := c.entries[creationCacheKey(ctx, dropletID)]
+	return created, ok
+}
+
+// describeProvisioning reports what a droplet was created with, as far as this
+// process remembers, next to what the API reports now.
+func (d *DropletTool) describeProvisioning(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	id, ok := req.GetArguments()["ID"].(float64)
+	if !ok {
+		return mcp.NewToolResultError("Droplet ID is required"), nil
+	}
+
+	client, err := d.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	droplet, _, err := client.Droplets.Get(ctx, int(id))
+	if err != nil {
+		return common.ErrorResult(ctx, d.Logger(ctx), "api error", err), nil
+	}
+
+	out := Provisioning{
+		DropletID: droplet.ID,
+		Live: &LiveProvisioning{
+			Source:   sourceLive,
+			Name:     droplet.Name,
+			Status:   droplet.Status,
+			Size:     droplet.SizeSlug,
+			Tags:     droplet.Tags,
+			VPCUUID:  droplet.VPCUUID,
+			Features: droplet.Features,
+		},
+		Notes: []string{"The DigitalOcean API does not return user data or SSH keys for an existing droplet."},
+	}
+	if droplet.Region != nil {
+		out.Live.Region = droplet.Region.Slug
+	}
+	if droplet.Image != nil {
+		out.Live.Image = droplet.Image.Slug
+		if out.Live.Image == "" {
+			out.Live.Image = strconv.Itoa(droplet.Image.ID)
+		}
+	}
+
+	if created, ok := d.created.get(ctx, droplet.ID); ok {
+		out.AsCreated = created
+		if created.UserData != "" {
+			out.Notes = append(out.Notes, "as_created.user_data has secret values redacted; user_data_sha256 is the hash of the original.")
+		}
+	} else {
+		out.Notes = append(out.Notes, "No create request is cached for this droplet: it was created by another process, before this server started, or too long ago.")
+	}
+
+	jsonData, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -28,19 +29,7 @@ func NewSizesTool(client func(ctx context.Context) (*godo.Client, error)) *Sizes
 
 // listSizes lists all available droplet sizes with pagination support.
 func (s *SizesTool) listSizes(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	page, ok := req.GetArguments()["Page"].(float64)
-	if !ok {
-		page = defaultSizesPage
-	}
-	perPage, ok := req.GetArguments()["PerPage"].(float64)
-	if !ok {
-		perPage = defaultSizesPageSize
-	}
-
-	opt := &godo.ListOptions{
-		Page:    int(page),
-		PerPage: int(perPage),
-	}
+	opt, pageMeta := common.ListOptionsFromArgs(req.GetArguments(), defaultSizesPageSize)
 
 	client, err := s.client(ctx)
 	if err != nil {
@@ -72,9 +61,81 @@ func (s *SizesTool) listSizes(ctx context.Context, req mcp.CallToolRequest) (*mc
 		return nil, fmt.Errorf("marshal error: %w", err)
 	}
 
+	return common.WithPageMeta(mcp.NewToolResultText(string(jsonData)), pageMeta)
+}
+
+// GPUSize describes a droplet size with GPUs attached.
+type GPUSize struct {
+	Slug         string   `json:"slug"`
+	Description  string   `json:"description,omitempty"`
+	GPUModel     string   `json:"gpu_model"`
+	GPUCount     int      `json:"gpu_count"`
+	VRAM         string   `json:"vram,omitempty"`
+	Vcpus        int      `json:"vcpus"`
+	Memory       int      `json:"memory"`
+	PriceMonthly float64  `json:"price_monthly"`
+	PriceHourly  float64  `json:"price_hourly"`
+	Available    bool     `json:"available"`
+	Regions      []string `json:"regions"`
+}
+
+// listGPUSizes lists the droplet sizes that have GPUs, optionally only those
+// offered in Region.
+func (s *SizesTool) listGPUSizes(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	region, _ := req.GetArguments()["Region"].(string)
+
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	sizes, err := common.FetchAll(ctx, common.MaxPerPage, client.Sizes.List)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+
+	jsonData, err := json.MarshalIndent(gpuSizes(sizes, region), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+
 	return mcp.NewToolResultText(string(jsonData)), nil
 }
 
+// gpuSizes keeps the sizes with GPU info, and when region is set, only those
+// offered there.
+func gpuSizes(sizes []godo.Size, region string) []GPUSize {
+	result := []GPUSize{}
+	for _, size := range sizes {
+		if size.GPUInfo == nil {
+			continue
+		}
+		if region != "" && !slices.Contains(size.Regions, region) {
+			continue
+		}
+		gpu := GPUSize{
+			Slug:         size.Slug,
+			Description:  size.Description,
+			GPUModel:     size.GPUInfo.Model,
+			GPUCount:     size.GPUInfo.Count,
+			Vcpus:        size.Vcpus,
+			Memory:       size.Memory,
+			PriceMonthly: size.PriceMonthly,
+			PriceHourly:  size.PriceHourly,
+			Available:    size.Available,
+			Regions:      size.Regions,
+		}
+		if vram := size.GPUInfo.VRAM; vram != nil {
+			gpu.VRAM = fmt.Sprintf("%d %s", vram.Amount, vram.Unit)
+		}
+		if gpu.Regions == nil {
+			gpu.Regions = []string{}
+		}
+		result = append(result, gpu)
+	}
+	return result
+}
+
 // Tools returns the list of server tools for droplet sizes.
 func (s *SizesTool) Tools() []server.ServerTool {
 	return []server.ServerTool{
@@ -88,5 +149,14 @@ func (s *SizesTool) Tools() []server.ServerTool {
 				mcp.WithNumber("PerPage", mcp.DefaultNumber(defaultSizesPageSize), mcp.Description("Items per page")),
 			),
 		},
+		{
+			Handler: s.listGPUSizes,
+			Tool: mcp.NewTool(
+				"size-list-gpu",
+				common.WithHints(common.HintsRead),
+				mcp.WithDescription("List the droplet sizes with GPUs: GPU model, count and VRAM, price, and the regions each size is offered in. Use it to pick a GPU size and region before droplet-create."),
+				mcp.WithString("Region", mcp.Description("Only list GPU sizes offered in this region slug, e.g. tor1")),
+			),
+		},
 	}
 }
@@ -130,3 +130,102 @@ func TestSizesTool_listSizes(t *testing.T) {
 		})
 	}
 }
+
+func TestSizesTool_listGPUSizes(t *testing.T) {
+	sizes := []godo.Size{
+		{Slug: "s-1vcpu-1gb", Vcpus: 1, Memory: 1024, Available: true, Regions: []string{"nyc1", "tor1"}},
+		{
+			Slug:         "gpu-h100x1-80gb",
+			Description:  "H100 GPU - 1X",
+			Vcpus:        20,
+			Memory:       245760,
+			PriceMonthly: 4529.0,
+			PriceHourly:  6.74,
+			Available:    true,
+			Regions:      []string{"nyc2", "tor1"},
+			GPUInfo:      &godo.GPUInfo{Count: 1, Model: "nvidia_h100", VRAM: &godo.VRAM{Amount: 80, Unit: "gib"}},
+		},
+		{Slug: "c-2", Vcpus: 2, Memory: 4096, Available: true, Regions: []string{"nyc2"}},
+		{
+			Slug:      "gpu-l40sx1-48gb",
+			Vcpus:     8,
+			Memory:    65536,
+			Available: true,
+			Regions:   []string{"ams3"},
+			GPUInfo:   &godo.GPUInfo{Count: 1, Model: "nvidia_l40s", VRAM: &godo.VRAM{Amount: 48, Unit: "gib"}},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		args      map[string]any
+		wantSlugs []string
+	}{
+		{
+			name:      "all GPU sizes",
+			args:      map[string]any{},
+			wantSlugs: []string{"gpu-h100x1-80gb", "gpu-l40sx1-48gb"},
+		},
+		{
+			name:      "GPU sizes in a region",
+			args:      map[string]any{"Region": "tor1"},
+			wantSlugs: []string{"gpu-h100x1-80gb"},
+		},
+		{
+			name:      "region without GPU sizes",
+			args:      map[string]any{"Region": "nyc1"},
+			wantSlugs: []string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockSizes := NewMockSizesService(ctrl)
+			mockSizes.EXPECT().
+				List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: 200}).
+				Return(sizes, nil, nil).
+				Times(1)
+			tool := setupSizesToolWithMock(mockSizes)
+
+			resp, err := tool.listGPUSizes(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			require.False(t, resp.IsError)
+
+			var out []GPUSize
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+			slugs := []string{}
+			for _, s := range out {
+				slugs = append(slugs, s.Slug)
+			}
+			require.Equal(t, tc.wantSlugs, slugs)
+			if len(out) > 0 && out[0].Slug == "gpu-h100x1-80gb" {
+				require.Equal(t, GPUSize{
+					Slug:         "gpu-h100x1-80gb",
+					Description:  "H100 GPU - 1X",
+					GPUModel:     "nvidia_h100",
+					GPUCount:     1,
+					VRAM:         "80 gib",
+					Vcpus:        20,
+					Memory:       245760,
+					PriceMonthly: 4529.0,
+					PriceHourly:  6.74,
+					Available:    true,
+					Regions:      []string{"nyc2", "tor1"},
+				}, out[0])
+			}
+		})
+	}
+}
+
+func TestSizesTool_listGPUSizesAPIError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSizes := NewMockSizesService(ctrl)
+	mockSizes.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil, errors.New("boom"))
+	tool := setupSizesToolWithMock(mockSizes)
+
+	resp, err := tool.listGPUSizes(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	require.True(t, resp.IsError)
+	require.Contains(t, resp.Content[0].(mcp.TextContent).Text, "boom")
+}
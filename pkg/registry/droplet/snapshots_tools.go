@@ -0,0 +1,182 @@
+package droplet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"mcp-digitalocean/pkg/registry/common"
+)
+
+const defaultSnapshotsPageSize = 20
+
+// snapshotResourceTypes are the kinds of resource a snapshot is taken of.
+var snapshotResourceTypes = []string{string(godo.DropletResourceType), string(godo.VolumeResourceType)}
+
+// SnapshotsTool provides tools for the snapshots of droplets and volumes.
+type SnapshotsTool struct {
+	common.ToolLogger
+
+	client func(ctx context.Context) (*godo.Client, error)
+}
+
+// NewSnapshotsTool creates a new SnapshotsTool instance.
+func NewSnapshotsTool(client func(ctx context.Context) (*godo.Client, error)) *SnapshotsTool {
+	return &SnapshotsTool{client: client}
+}
+
+// listSnapshots lists snapshots, optionally only those of droplets or of
+// volumes, with pagination support.
+func (s *SnapshotsTool) listSnapshots(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	resourceType, _ := args["ResourceType"].(string)
+	if resourceType != "" && !slices.Contains(snapshotResourceTypes, resourceType) {
+		return mcp.NewToolResultError(fmt.Sprintf("ResourceType must be one of %s, got %q", strings.Join(snapshotResourceTypes, ", "), resourceType)), nil
+	}
+	opt, pageMeta := common.ListOptionsFromArgs(args, defaultSnapshotsPageSize)
+
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	list := client.Snapshots.List
+	switch godo.ResourceType(resourceType) {
+	case godo.DropletResourceType:
+		list = client.Snapshots.ListDroplet
+	case godo.VolumeResourceType:
+		list = client.Snapshots.ListVolume
+	}
+	snapshots, resp, err := list(ctx, opt)
+	if err != nil {
+		return common.APIErrorResult(ctx, s.Logger(ctx), "api error", resp, err), nil
+	}
+
+	jsonData, err := json.MarshalIndent(snapshots, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return common.WithPageMeta(mcp.NewToolResultText(string(jsonData)), pageMeta)
+}
+
+// getSnapshot gets a snapshot by its ID.
+func (s *SnapshotsTool) getSnapshot(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	id, _ := req.GetArguments()["ID"].(string)
+	if id == "" {
+		return mcp.NewToolResultError("ID is required"), nil
+	}
+
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	snapshot, _, err := client.Snapshots.Get(ctx, id)
+	if err != nil {
+		return common.GetErrorResult(ctx, s.Logger(ctx), "api error", "snapshot", id, err), nil
+	}
+
+	jsonData, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+// deleteSnapshot deletes a snapshot by its ID. A droplet snapshot is an image,
+// so, as with image-delete, the droplets and autoscale pools using it are
+// looked for first and a referenced snapshot is only deleted with Force.
+func (s *SnapshotsTool) deleteSnapshot(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	id, _ := args["ID"].(string)
+	if id == "" {
+		return mcp.NewToolResultError("ID is required"), nil
+	}
+	checkReferences := true
+	if v, ok := args["CheckReferences"].(bool); ok {
+		checkReferences = v
+	}
+	force, _ := args["Force"].(bool)
+
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	// Volume snapshot IDs are UUIDs; only droplet snapshots can be referenced.
+	imageID, err := strconv.Atoi(id)
+	if !checkReferences || err != nil {
+		resp, err := client.Snapshots.Delete(ctx, id)
+		if err != nil {
+			return common.APIErrorResult(ctx, s.Logger(ctx), "api error", resp, err), nil
+		}
+		return mcp.NewToolResultText("Snapshot deleted successfully"), nil
+	}
+
+	references, err := imageReferences(ctx, client, imageID)
+	if err != nil {
+		return common.ErrorResult(ctx, s.Logger(ctx), "failed to check references", err), nil
+	}
+	result := ImageDeleteResult{ImageID: imageID, References: references, NotChecked: imageReferenceLimits}
+	if len(references) > 0 && !force {
+		result.Message = fmt.Sprintf("Snapshot %d was not deleted: %d resources reference it. Pass Force: true to delete it anyway", imageID, len(references))
+		jsonResult, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("marshal error: %w", err)
+		}
+		return mcp.NewToolResultError(string(jsonResult)), nil
+	}
+
+	resp, err := client.Snapshots.Delete(ctx, id)
+	if err != nil {
+		return common.APIErrorResult(ctx, s.Logger(ctx), "api error", resp, err), nil
+	}
+	result.Deleted = true
+	result.Message = fmt.Sprintf("Snapshot %d deleted successfully", imageID)
+
+	jsonResult, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(string(jsonResult)), nil
+}
+
+// Tools returns the list of server tools for snapshots.
+func (s *SnapshotsTool) Tools() []server.ServerTool {
+	return []server.ServerTool{
+		{
+			Handler: s.listSnapshots,
+			Tool: mcp.NewTool("snapshot-list",
+				common.WithHints(common.HintsRead),
+				mcp.WithDescription("List snapshots of droplets and volumes"),
+				mcp.WithString("ResourceType", mcp.Enum(snapshotResourceTypes...), mcp.Description("Only list snapshots of this kind of resource; all snapshots are listed when omitted")),
+				mcp.WithNumber("Page", mcp.DefaultNumber(1), mcp.Description("Page number")),
+				mcp.WithNumber("PerPage", mcp.DefaultNumber(defaultSnapshotsPageSize), mcp.Description("Items per page")),
+			),
+		},
+		{
+			Handler: s.getSnapshot,
+			Tool: mcp.NewTool("snapshot-get",
+				common.WithHints(common.HintsRead),
+				mcp.WithDescription("Get a droplet or volume snapshot by ID"),
+				mcp.WithString("ID", mcp.Required(), mcp.Description("ID of the snapshot. Droplet snapshot IDs are numeric, volume snapshot IDs are UUIDs")),
+			),
+		},
+		{
+			Handler: s.deleteSnapshot,
+			Tool: mcp.NewTool("snapshot-delete",
+				common.WithHints(common.HintsDelete),
+				mcp.WithDescription("Delete a droplet or volume snapshot by ID. For a droplet snapshot it first looks for droplets created or rebuilt from it and droplet autoscale pools whose template uses it, and refuses to delete a referenced snapshot unless Force is true, like image-delete."),
+				mcp.WithString("ID", mcp.Required(), mcp.Description("ID of the snapshot to delete")),
+				mcp.WithBoolean("CheckReferences", mcp.DefaultBool(true), mcp.Description("Look for droplets and autoscale pools using a droplet snapshot before deleting it (default: true)")),
+				mcp.WithBoolean("Force", mcp.DefaultBool(false), mcp.Description("Delete the snapshot even if resources reference it (default: false)")),
+			),
+		},
+	}
+}
@@ -0,0 +1,281 @@
+package droplet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func setupSnapshotsToolWithMock(snapshots *MockSnapshotsService) *SnapshotsTool {
+	client := func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{Snapshots: snapshots}, nil
+	}
+	return NewSnapshotsTool(client)
+}
+
+func TestSnapshotsTool_listSnapshots(t *testing.T) {
+	dropletSnapshot := godo.Snapshot{ID: "6372321", Name: "web-01-nightly", ResourceType: "droplet", ResourceID: "200776916"}
+	volumeSnapshot := godo.Snapshot{ID: "fbe805e8-866b-11e6-96bf-000f53315a41", Name: "data-nightly", ResourceType: "volume"}
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(*MockSnapshotsService)
+		expectIDs   []string
+		expectError string
+	}{
+		{
+			name: "All snapshots",
+			args: map[string]any{"Page": float64(2), "PerPage": float64(10)},
+			mockSetup: func(m *MockSnapshotsService) {
+				m.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 2, PerPage: 10}).
+					Return([]godo.Snapshot{dropletSnapshot, volumeSnapshot}, &godo.Response{}, nil).Times(1)
+			},
+			expectIDs: []string{dropletSnapshot.ID, volumeSnapshot.ID},
+		},
+		{
+			name: "Droplet snapshots",
+			args: map[string]any{"ResourceType": "droplet"},
+			mockSetup: func(m *MockSnapshotsService) {
+				m.EXPECT().ListDroplet(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: defaultSnapshotsPageSize}).
+					Return([]godo.Snapshot{dropletSnapshot}, &godo.Response{}, nil).Times(1)
+			},
+			expectIDs: []string{dropletSnapshot.ID},
+		},
+		{
+			name: "Volume snapshots",
+			args: map[string]any{"ResourceType": "volume"},
+			mockSetup: func(m *MockSnapshotsService) {
+				m.EXPECT().ListVolume(gomock.Any(), gomock.Any()).
+					Return([]godo.Snapshot{volumeSnapshot}, &godo.Response{}, nil).Times(1)
+			},
+			expectIDs: []string{volumeSnapshot.ID},
+		},
+		{
+			name:        "Unknown ResourceType",
+			args:        map[string]any{"ResourceType": "image"},
+			expectError: `ResourceType must be one of droplet, volume, got "image"`,
+		},
+		{
+			name: "API error",
+			args: map[string]any{},
+			mockSetup: func(m *MockSnapshotsService) {
+				m.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil, errors.New("api failure")).Times(1)
+			},
+			expectError: "api failure",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockSnapshots := NewMockSnapshotsService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockSnapshots)
+			}
+			tool := setupSnapshotsToolWithMock(mockSnapshots)
+
+			resp, err := tool.listSnapshots(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			text := resp.Content[0].(mcp.TextContent).Text
+			if tc.expectError != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, text, tc.expectError)
+				return
+			}
+			require.False(t, resp.IsError)
+			var out []godo.Snapshot
+			require.NoError(t, json.Unmarshal([]byte(text), &out))
+			var ids []string
+			for _, s := range out {
+				ids = append(ids, s.ID)
+			}
+			require.Equal(t, tc.expectIDs, ids)
+		})
+	}
+}
+
+func TestSnapshotsTool_getSnapshot(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(*MockSnapshotsService)
+		expectError string
+	}{
+		{
+			name: "Successful get",
+			args: map[string]any{"ID": "6372321"},
+			mockSetup: func(m *MockSnapshotsService) {
+				m.EXPECT().Get(gomock.Any(), "6372321").Return(&godo.Snapshot{ID: "6372321", Name: "web-01-nightly"}, nil, nil).Times(1)
+			},
+		},
+		{
+			name:        "Missing ID",
+			args:        map[string]any{},
+			expectError: "ID is required",
+		},
+		{
+			name: "API error",
+			args: map[string]any{"ID": "6372321"},
+			mockSetup: func(m *MockSnapshotsService) {
+				m.EXPECT().Get(gomock.Any(), "6372321").Return(nil, nil, errors.New("api failure")).Times(1)
+			},
+			expectError: "api failure",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockSnapshots := NewMockSnapshotsService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockSnapshots)
+			}
+			tool := setupSnapshotsToolWithMock(mockSnapshots)
+
+			resp, err := tool.getSnapshot(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			text := resp.Content[0].(mcp.TextContent).Text
+			if tc.expectError != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, text, tc.expectError)
+				return
+			}
+			require.False(t, resp.IsError)
+			var out godo.Snapshot
+			require.NoError(t, json.Unmarshal([]byte(text), &out))
+			require.Equal(t, "web-01-nightly", out.Name)
+		})
+	}
+}
+
+func TestSnapshotsTool_deleteSnapshot(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(*MockSnapshotsService)
+		expectText  string
+		expectError string
+	}{
+		{
+			name: "Successful delete",
+			args: map[string]any{"ID": "fbe805e8-866b-11e6-96bf-000f53315a41"},
+			mockSetup: func(m *MockSnapshotsService) {
+				m.EXPECT().Delete(gomock.Any(), "fbe805e8-866b-11e6-96bf-000f53315a41").Return(nil, nil).Times(1)
+			},
+			expectText: "Snapshot deleted successfully",
+		},
+		{
+			name:        "Missing ID",
+			args:        map[string]any{},
+			expectError: "ID is required",
+		},
+		{
+			name: "API error",
+			args: map[string]any{"ID": "6372321", "CheckReferences": false},
+			mockSetup: func(m *MockSnapshotsService) {
+				m.EXPECT().Delete(gomock.Any(), "6372321").Return(nil, errors.New("snapshot is in use")).Times(1)
+			},
+			expectError: "snapshot is in use",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockSnapshots := NewMockSnapshotsService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockSnapshots)
+			}
+			tool := setupSnapshotsToolWithMock(mockSnapshots)
+
+			resp, err := tool.deleteSnapshot(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			text := resp.Content[0].(mcp.TextContent).Text
+			if tc.expectError != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, text, tc.expectError)
+				return
+			}
+			require.False(t, resp.IsError)
+			require.Equal(t, tc.expectText, text)
+		})
+	}
+}
+
+func TestSnapshotsTool_deleteSnapshotReferences(t *testing.T) {
+	droplets := []godo.Droplet{
+		{ID: 1, Name: "web-1", Image: &godo.Image{ID: 6372321}},
+		{ID: 2, Name: "web-2", Image: &godo.Image{ID: 999}},
+	}
+	wantReferences := []ImageReference{
+		{Kind: "droplet", ID: "1", Name: "web-1", Reason: "created or last rebuilt from this image; the droplet keeps running, but cannot be rebuilt from the image once it is deleted"},
+	}
+
+	tests := []struct {
+		name           string
+		args           map[string]any
+		wantDelete     bool
+		wantErr        bool
+		wantReferences []ImageReference
+		wantMessage    string
+	}{
+		{
+			name:           "referenced droplet snapshot is not deleted",
+			args:           map[string]any{"ID": "6372321"},
+			wantErr:        true,
+			wantReferences: wantReferences,
+			wantMessage:    "Snapshot 6372321 was not deleted: 1 resources reference it. Pass Force: true to delete it anyway",
+		},
+		{
+			name:           "force deletes a referenced droplet snapshot",
+			args:           map[string]any{"ID": "6372321", "Force": true},
+			wantDelete:     true,
+			wantReferences: wantReferences,
+			wantMessage:    "Snapshot 6372321 deleted successfully",
+		},
+		{
+			name:           "unreferenced droplet snapshot is deleted",
+			args:           map[string]any{"ID": "456"},
+			wantDelete:     true,
+			wantReferences: []ImageReference{},
+			wantMessage:    "Snapshot 456 deleted successfully",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			snapshots := NewMockSnapshotsService(ctrl)
+			dropletsService := NewMockDropletsService(ctrl)
+			autoscale := NewMockDropletAutoscaleService(ctrl)
+			dropletsService.EXPECT().List(gomock.Any(), gomock.Any()).Return(droplets, nil, nil)
+			autoscale.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil, nil)
+			id := tc.args["ID"].(string)
+			if tc.wantDelete {
+				snapshots.EXPECT().Delete(gomock.Any(), id).Return(nil, nil)
+			}
+			tool := NewSnapshotsTool(func(context.Context) (*godo.Client, error) {
+				return &godo.Client{Snapshots: snapshots, Droplets: dropletsService, DropletAutoscale: autoscale}, nil
+			})
+
+			res, err := tool.deleteSnapshot(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			require.Equal(t, tc.wantErr, res.IsError)
+
+			var result ImageDeleteResult
+			require.NoError(t, json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &result))
+			require.Equal(t, id, strconv.Itoa(result.ImageID))
+			require.Equal(t, tc.wantDelete, result.Deleted)
+			require.Equal(t, tc.wantMessage, result.Message)
+			require.Equal(t, tc.wantReferences, result.References)
+			require.NotEmpty(t, result.NotChecked)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package droplet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-digitalocean/pkg/registry/common"
+)
+
+const (
+	// defaultTagConcurrency is how many per-droplet actions run at once when
+	// the caller does not set Concurrency.
+	defaultTagConcurrency = 5
+	// maxTagConcurrency bounds Concurrency to stay well inside the API's rate
+	// limit.
+	maxTagConcurrency = 20
+)
+
+// DropletTagActionResult is the outcome of one droplet's action in a per-droplet
+// tag fan-out.
+type DropletTagActionResult struct {
+	DropletID   int    `json:"droplet_id"`
+	DropletName string `json:"droplet_name"`
+	ActionID    int    `json:"action_id,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// dropletActionFunc issues an action against a single droplet.
+type dropletActionFunc func(ctx context.Context, client *godo.Client, dropletID int) (*godo.Action, *godo.Response, error)
+
+// tagPowerActions are the actions droplets-run-action-by-tag accepts.
+var tagPowerActions = map[string]dropletActionFunc{
+	"power_on": func(ctx context.Context, client *godo.Client, id int) (*godo.Action, *godo.Response, error) {
+		return client.DropletActions.PowerOn(ctx, id)
+	},
+	"power_off": func(ctx context.Context, client *godo.Client, id int) (*godo.Action, *godo.Response, error) {
+		return client.DropletActions.PowerOff(ctx, id)
+	},
+	"power_cycle": func(ctx context.Context, client *godo.Client, id int) (*godo.Action, *godo.Response, error) {
+		return client.DropletActions.PowerCycle(ctx, id)
+	},
+	"shutdown": func(ctx context.Context, client *godo.Client, id int) (*godo.Action, *godo.Response, error) {
+		return client.DropletActions.Shutdown(ctx, id)
+	},
+	"reboot": func(ctx context.Context, client *godo.Client, id int) (*godo.Action, *godo.Response, error) {
+		return client.DropletActions.Reboot(ctx, id)
+	},
+}
+
+// tagConcurrency reads the Concurrency argument, defaulting to
+// defaultTagConcurrency. It reports false when the value is out of range.
+func tagConcurrency(args map[string]any) (int, bool) {
+	c, ok := args["Concurrency"].(float64)
+	if !ok {
+		return defaultTagConcurrency, true
+	}
+	return int(c), c >= 1 && c <= maxTagConcurrency
+}
+
+// runActionByTag lists the droplets carrying tag and runs action on each of
+// them, at most concurrency at a time. Results keep the listing order; a
+// failed action is reported in its result rather than stopping the others.
+func runActionByTag(ctx context.Context, client *godo.Client, tag string, concurrency int, action dropletActionFunc) ([]DropletTagActionResult, error) {
+	droplets, err := common.FetchAll(ctx, common.MaxPerPage, func(ctx context.Context, opt *godo.ListOptions) ([]godo.Droplet, *godo.Response, error) {
+		return client.Droplets.ListByTag(ctx, tag, opt)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]DropletTagActionResult, len(droplets))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, d := range droplets {
+		results[i] = DropletTagActionResult{DropletID: d.ID, DropletName: d.Name}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			a, _, err := action(ctx, client, d.ID)
+			switch {
+			case err != nil:
+				results[i].Error = err.Error()
+			case a != nil:
+				results[i].ActionID = a.ID
+			}
+		}()
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+// tagFanOutResult runs action on every droplet carrying the Tag argument and
+// returns the per-droplet results as JSON.
+func (da *DropletActionsTool) tagFanOutResult(ctx context.Context, req mcp.CallToolRequest, action dropletActionFunc) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	tag, _ := args["Tag"].(string)
+	if tag == "" {
+		return mcp.NewToolResultError("Tag is required"), nil
+	}
+	concurrency, ok := tagConcurrency(args)
+	if !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("Concurrency must be between 1 and %d", maxTagConcurrency)), nil
+	}
+
+	client, err := da.client(ctx)
+	if err != nil {
+		return noCredentials(err), nil
+	}
+
+	results, err := runActionByTag(ctx, client, tag, concurrency, action)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+	if len(results) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("no droplets are tagged %q", tag)), nil
+	}
+
+	jsonResults, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(jsonResults)), nil
+}
+
+// runDropletActionByTag runs a power action on each droplet with a tag and
+// reports the outcome per droplet.
+func (da *DropletActionsTool) runDropletActionByTag(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, _ := req.GetArguments()["Action"].(string)
+	action, ok := tagPowerActions[name]
+	if !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("unsupported Action %q", name)), nil
+	}
+
+	return da.tagFanOutResult(ctx, req, action)
+}
@@ -0,0 +1,151 @@
+package droplet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func setupTagFanOutToolWithMocks(droplets *MockDropletsService, actions *MockDropletActionsService) *DropletActionsTool {
+	client := func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{Droplets: droplets, DropletActions: actions}, nil
+	}
+	return NewDropletActionsTool(client)
+}
+
+func taggedDroplets(n int) []godo.Droplet {
+	droplets := make([]godo.Droplet, n)
+	for i := range droplets {
+		droplets[i] = godo.Droplet{ID: 100 + i, Name: fmt.Sprintf("web-%d", i)}
+	}
+	return droplets
+}
+
+func TestDropletActionsTool_snapshotByTagPerDroplet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDroplets := NewMockDropletsService(ctrl)
+	mockActions := NewMockDropletActionsService(ctrl)
+	tool := setupTagFanOutToolWithMocks(mockDroplets, mockActions)
+
+	mockDroplets.EXPECT().ListByTag(gomock.Any(), "web", &godo.ListOptions{Page: 1, PerPage: 200}).Return(taggedDroplets(3), nil, nil)
+	mockActions.EXPECT().Snapshot(gomock.Any(), 100, "nightly").Return(&godo.Action{ID: 9000}, nil, nil)
+	mockActions.EXPECT().Snapshot(gomock.Any(), 101, "nightly").Return(nil, nil, errors.New("droplet is locked"))
+	mockActions.EXPECT().Snapshot(gomock.Any(), 102, "nightly").Return(&godo.Action{ID: 9002}, nil, nil)
+
+	resp, err := tool.snapshotByTag(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"Tag":        "web",
+		"Name":       "nightly",
+		"PerDroplet": true,
+	}}})
+	require.NoError(t, err)
+	require.False(t, resp.IsError)
+
+	var results []DropletTagActionResult
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &results))
+	require.Equal(t, []DropletTagActionResult{
+		{DropletID: 100, DropletName: "web-0", ActionID: 9000},
+		{DropletID: 101, DropletName: "web-1", Error: "droplet is locked"},
+		{DropletID: 102, DropletName: "web-2", ActionID: 9002},
+	}, results)
+}
+
+func TestDropletActionsTool_runDropletActionByTagBoundsConcurrency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDroplets := NewMockDropletsService(ctrl)
+	mockActions := NewMockDropletActionsService(ctrl)
+	tool := setupTagFanOutToolWithMocks(mockDroplets, mockActions)
+
+	mockDroplets.EXPECT().ListByTag(gomock.Any(), "web", gomock.Any()).Return(taggedDroplets(12), nil, nil)
+
+	var inFlight, peak atomic.Int32
+	mockActions.EXPECT().PowerOff(gomock.Any(), gomock.Any()).Times(12).DoAndReturn(func(ctx context.Context, id int) (*godo.Action, *godo.Response, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		return &godo.Action{ID: id + 1000}, nil, nil
+	})
+
+	resp, err := tool.runDropletActionByTag(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"Tag":         "web",
+		"Action":      "power_off",
+		"Concurrency": float64(3),
+	}}})
+	require.NoError(t, err)
+	require.False(t, resp.IsError)
+	require.LessOrEqual(t, peak.Load(), int32(3))
+
+	var results []DropletTagActionResult
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &results))
+	require.Len(t, results, 12)
+	for i, r := range results {
+		require.Equal(t, 100+i, r.DropletID)
+		require.Equal(t, 1100+i, r.ActionID)
+	}
+}
+
+func TestDropletActionsTool_runDropletActionByTagErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(*MockDropletsService)
+		wantMessage string
+	}{
+		{
+			name:        "unsupported action",
+			args:        map[string]any{"Tag": "web", "Action": "destroy"},
+			wantMessage: `unsupported Action "destroy"`,
+		},
+		{
+			name:        "concurrency out of range",
+			args:        map[string]any{"Tag": "web", "Action": "reboot", "Concurrency": float64(50)},
+			wantMessage: "Concurrency must be between 1 and 20",
+		},
+		{
+			name: "no tagged droplets",
+			args: map[string]any{"Tag": "web", "Action": "reboot"},
+			mockSetup: func(m *MockDropletsService) {
+				m.EXPECT().ListByTag(gomock.Any(), "web", gomock.Any()).Return(nil, nil, nil)
+			},
+			wantMessage: `no droplets are tagged "web"`,
+		},
+		{
+			name: "list error",
+			args: map[string]any{"Tag": "web", "Action": "reboot"},
+			mockSetup: func(m *MockDropletsService) {
+				m.EXPECT().ListByTag(gomock.Any(), "web", gomock.Any()).Return(nil, nil, errors.New("boom"))
+			},
+			wantMessage: "boom",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockDroplets := NewMockDropletsService(ctrl)
+			tool := setupTagFanOutToolWithMocks(mockDroplets, NewMockDropletActionsService(ctrl))
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockDroplets)
+			}
+
+			resp, err := tool.runDropletActionByTag(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			require.True(t, resp.IsError)
+			require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.wantMessage)
+		})
+	}
+}
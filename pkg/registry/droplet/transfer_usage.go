@@ -0,0 +1,162 @@
+package droplet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/digitalocean/godo/metrics"
+	"github.com/mark3labs/mcp-go/mcp"
+	"mcp-digitalocean/pkg/registry/common"
+)
+
+// gibPerTB is how many GiB of transfer DigitalOcean includes per TB of a
+// size's transfer allowance.
+const gibPerTB = 1000
+
+// TransferUsage estimates a droplet's outbound transfer for the current
+// billing month against its size's allowance.
+type TransferUsage struct {
+	DropletID     int       `json:"droplet_id"`
+	Name          string    `json:"name"`
+	Size          string    `json:"size"`
+	PeriodStart   time.Time `json:"period_start"`
+	PeriodEnd     time.Time `json:"period_end"`
+	MonthEnd      time.Time `json:"month_end"`
+	IncludedGiB   float64   `json:"estimated_included_gib"`
+	UsedGiB       float64   `json:"estimated_used_gib"`
+	ProjectedGiB  float64   `json:"estimated_projected_gib"`
+	UsedPercent   float64   `json:"estimated_used_percent"`
+	LikelyOverage bool      `json:"likely_overage"`
+	Notes         []string  `json:"notes"`
+}
+
+// billingPeriod returns the start of the droplet's usage in the calendar month
+// containing now, in UTC, and the end of that month.
+func billingPeriod(droplet *godo.Droplet, now time.Time) (start, monthEnd time.Time) {
+	now = now.UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	monthEnd = monthStart.AddDate(0, 1, 0)
+	start = monthStart
+	if created, err := time.Parse(time.RFC3339, droplet.Created); err == nil && created.After(start) {
+		start = created.UTC()
+	}
+	return start, monthEnd
+}
+
+// transferredBytes integrates bandwidth series in Mbps over [start, end). Each
+// sample's rate is held until the next sample in its series, and only the part
+// of each step inside the window counts, so samples from before the window
+// start are ignored.
+func transferredBytes(series []metrics.SampleStream, start, end time.Time) float64 {
+	var total float64
+	for _, s := range series {
+		values := append([]metrics.SamplePair(nil), s.Values...)
+		sort.Slice(values, func(i, j int) bool { return values[i].Timestamp < values[j].Timestamp })
+		for i := 0; i+1 < len(values); i++ {
+			from, to := values[i].Timestamp.Time(), values[i+1].Timestamp.Time()
+			if from.Before(start) {
+				from = start
+			}
+			if to.After(end) {
+				to = end
+			}
+			if !to.After(from) {
+				continue
+			}
+			mbps := float64(values[i].Value)
+			total += mbps * 1e6 / 8 * to.Sub(from).Seconds()
+		}
+	}
+	return total
+}
+
+// round2 rounds to two decimal places for display.
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// getTransferUsage estimates a droplet's outbound transfer this month.
+func (d *DropletTool) getTransferUsage(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	id, ok := req.GetArguments()["ID"].(float64)
+	if !ok {
+		return mcp.NewToolResultError("Droplet ID is required"), nil
+	}
+
+	client, err := d.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	droplet, _, err := client.Droplets.Get(ctx, int(id))
+	if err != nil {
+		return common.GetErrorResult(ctx, d.Logger(ctx), "api error", "droplet", strconv.Itoa(int(id)), err), nil
+	}
+
+	now := d.now().UTC()
+	start, monthEnd := billingPeriod(droplet, now)
+	resp, _, err := client.Monitoring.GetDropletBandwidth(ctx, &godo.DropletBandwidthMetricsRequest{
+		DropletMetricsRequest: godo.DropletMetricsRequest{
+			HostID: strconv.Itoa(droplet.ID),
+			Start:  start,
+			End:    now,
+		},
+		Interface: "public",
+		Direction: "outbound",
+	})
+	if err != nil {
+		return common.ErrorResult(ctx, d.Logger(ctx), "api error", err), nil
+	}
+
+	var series []metrics.SampleStream
+	if resp != nil {
+		series = resp.Data.Result
+	}
+	usedGiB := transferredBytes(series, start, now) / (1 << 30)
+
+	out := TransferUsage{
+		DropletID:   droplet.ID,
+		Name:        droplet.Name,
+		Size:        droplet.SizeSlug,
+		PeriodStart: start,
+		PeriodEnd:   now,
+		MonthEnd:    monthEnd,
+		UsedGiB:     round2(usedGiB),
+		Notes: []string{
+			"All figures are estimates: used transfer is integrated from public outbound bandwidth samples, which are averaged rates, and the projection assumes the rest of the month continues at the average rate so far.",
+			"Transfer allowances are pooled across all droplets in the account, so overage is only billed when the account-wide total exceeds the pooled allowance.",
+		},
+	}
+
+	// The allowance is prorated by the hours the droplet exists this month.
+	if droplet.Size != nil {
+		lifetime := monthEnd.Sub(start).Hours() / monthEnd.Sub(monthEnd.AddDate(0, -1, 0)).Hours()
+		out.IncludedGiB = round2(droplet.Size.Transfer * gibPerTB * lifetime)
+	} else {
+		out.Notes = append(out.Notes, "The droplet's size was not returned, so its transfer allowance is unknown.")
+	}
+
+	projected := usedGiB
+	if elapsed := now.Sub(start); elapsed > 0 {
+		projected = usedGiB * monthEnd.Sub(start).Hours() / elapsed.Hours()
+	}
+	out.ProjectedGiB = round2(projected)
+	if out.IncludedGiB > 0 {
+		out.UsedPercent = round2(usedGiB / out.IncludedGiB * 100)
+		out.LikelyOverage = projected > out.IncludedGiB
+	}
+	if len(series) == 0 {
+		out.Notes = append(out.Notes, "No bandwidth samples were returned for this period.")
+	}
+
+	jsonData, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
@@ -0,0 +1,245 @@
+package droplet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/digitalocean/godo/metrics"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// hourlySeries returns hourly samples at a constant rate in Mbps from from to
+// to inclusive.
+func hourlySeries(from, to time.Time, mbps float64) metrics.SampleStream {
+	var values []metrics.SamplePair
+	for t := from; !t.After(to); t = t.Add(time.Hour) {
+		values = append(values, metrics.SamplePair{
+			Timestamp: metrics.TimeFromUnix(t.Unix()),
+			Value:     metrics.SampleValue(mbps),
+		})
+	}
+	return metrics.SampleStream{Values: values}
+}
+
+func bandwidthResponse(series ...metrics.SampleStream) *godo.MetricsResponse {
+	resp := &godo.MetricsResponse{}
+	resp.Data.Result = series
+	return resp
+}
+
+// mbpsHourGiB is the GiB transferred by one hour at 1 Mbps.
+const mbpsHourGiB = 1e6 / 8 * 3600 / (1 << 30)
+
+func TestTransferredBytes(t *testing.T) {
+	monthStart := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	now := monthStart.Add(10 * time.Hour)
+
+	tests := []struct {
+		name   string
+		series []metrics.SampleStream
+		want   float64
+	}{
+		{
+			name: "Samples before the month start are ignored",
+			series: []metrics.SampleStream{
+				hourlySeries(monthStart.Add(-3*time.Hour), monthStart.Add(-time.Hour), 1000),
+				hourlySeries(monthStart, now, 8),
+			},
+			want: 10 * 8 * mbpsHourGiB,
+		},
+		{
+			name: "A step crossing the month start is clipped",
+			series: []metrics.SampleStream{{Values: []metrics.SamplePair{
+				{Timestamp: metrics.TimeFromUnix(monthStart.Add(-30 * time.Minute).Unix()), Value: 4},
+				{Timestamp: metrics.TimeFromUnix(monthStart.Add(30 * time.Minute).Unix()), Value: 0},
+			}}},
+			want: 0.5 * 4 * mbpsHourGiB,
+		},
+		{
+			name: "Unsorted samples are ordered first",
+			series: []metrics.SampleStream{{Values: []metrics.SamplePair{
+				{Timestamp: metrics.TimeFromUnix(monthStart.Add(2 * time.Hour).Unix()), Value: 0},
+				{Timestamp: metrics.TimeFromUnix(monthStart.Unix()), Value: 2},
+			}}},
+			want: 2 * 2 * mbpsHourGiB,
+		},
+		{
+			name: "Series are summed",
+			series: []metrics.SampleStream{
+				hourlySeries(monthStart, now, 1),
+				hourlySeries(monthStart, now, 3),
+			},
+			want: 10 * 4 * mbpsHourGiB,
+		},
+		{
+			name: "A single sample has no duration",
+			series: []metrics.SampleStream{
+				hourlySeries(monthStart, monthStart, 100),
+			},
+			want: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := transferredBytes(tc.series, monthStart, now) / (1 << 30)
+			require.InDelta(t, tc.want, got, 1e-9)
+		})
+	}
+}
+
+func TestDropletTool_getTransferUsage(t *testing.T) {
+	now := time.Date(2026, 10, 11, 0, 0, 0, 0, time.UTC)
+	monthStart := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	monthEnd := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	lastMonth := monthStart.Add(-6 * time.Hour)
+
+	droplet := func(created time.Time) *godo.Droplet {
+		return &godo.Droplet{
+			ID:       123,
+			Name:     "web-1",
+			SizeSlug: "s-1vcpu-1gb",
+			Size:     &godo.Size{Slug: "s-1vcpu-1gb", Transfer: 1},
+			Created:  created.Format(time.RFC3339),
+		}
+	}
+	bandwidthRequest := func(start time.Time) *godo.DropletBandwidthMetricsRequest {
+		return &godo.DropletBandwidthMetricsRequest{
+			DropletMetricsRequest: godo.DropletMetricsRequest{HostID: "123", Start: start, End: now},
+			Interface:             "public",
+			Direction:             "outbound",
+		}
+	}
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(*MockDropletsService, *MockMonitoringService)
+		expectError bool
+		check       func(t *testing.T, usage TransferUsage)
+	}{
+		{
+			name: "Heavy egress since last month is likely to exceed the allowance",
+			args: map[string]any{"ID": float64(123)},
+			mockSetup: func(d *MockDropletsService, m *MockMonitoringService) {
+				d.EXPECT().Get(gomock.Any(), 123).Return(droplet(monthStart.AddDate(0, -3, 0)), nil, nil)
+				m.EXPECT().GetDropletBandwidth(gomock.Any(), bandwidthRequest(monthStart)).
+					Return(bandwidthResponse(hourlySeries(lastMonth, now, 8)), nil, nil)
+			},
+			check: func(t *testing.T, usage TransferUsage) {
+				require.Equal(t, monthStart, usage.PeriodStart)
+				require.Equal(t, monthEnd, usage.MonthEnd)
+				require.Equal(t, 1000.0, usage.IncludedGiB)
+				used := 240 * 8 * mbpsHourGiB
+				require.InDelta(t, used, usage.UsedGiB, 0.01)
+				require.InDelta(t, used*744/240, usage.ProjectedGiB, 0.01)
+				require.InDelta(t, used/10, usage.UsedPercent, 0.01)
+				require.True(t, usage.LikelyOverage)
+				require.Len(t, usage.Notes, 2)
+				require.Contains(t, usage.Notes[0], "estimates")
+			},
+		},
+		{
+			name: "Light egress stays within the allowance",
+			args: map[string]any{"ID": float64(123)},
+			mockSetup: func(d *MockDropletsService, m *MockMonitoringService) {
+				d.EXPECT().Get(gomock.Any(), 123).Return(droplet(monthStart.AddDate(-1, 0, 0)), nil, nil)
+				m.EXPECT().GetDropletBandwidth(gomock.Any(), bandwidthRequest(monthStart)).
+					Return(bandwidthResponse(hourlySeries(lastMonth, now, 1)), nil, nil)
+			},
+			check: func(t *testing.T, usage TransferUsage) {
+				require.InDelta(t, 240*mbpsHourGiB, usage.UsedGiB, 0.01)
+				require.InDelta(t, 744*mbpsHourGiB, usage.ProjectedGiB, 0.01)
+				require.False(t, usage.LikelyOverage)
+			},
+		},
+		{
+			name: "Allowance is prorated for a droplet created this month",
+			args: map[string]any{"ID": float64(123)},
+			mockSetup: func(d *MockDropletsService, m *MockMonitoringService) {
+				created := monthStart.AddDate(0, 0, 5)
+				d.EXPECT().Get(gomock.Any(), 123).Return(droplet(created), nil, nil)
+				m.EXPECT().GetDropletBandwidth(gomock.Any(), bandwidthRequest(created)).
+					Return(bandwidthResponse(hourlySeries(lastMonth, now, 2)), nil, nil)
+			},
+			check: func(t *testing.T, usage TransferUsage) {
+				require.Equal(t, monthStart.AddDate(0, 0, 5), usage.PeriodStart)
+				require.InDelta(t, 1000.0*624/744, usage.IncludedGiB, 0.01)
+				used := 120 * 2 * mbpsHourGiB
+				require.InDelta(t, used, usage.UsedGiB, 0.01)
+				require.InDelta(t, used*624/120, usage.ProjectedGiB, 0.01)
+				require.False(t, usage.LikelyOverage)
+			},
+		},
+		{
+			name: "No samples",
+			args: map[string]any{"ID": float64(123)},
+			mockSetup: func(d *MockDropletsService, m *MockMonitoringService) {
+				d.EXPECT().Get(gomock.Any(), 123).Return(droplet(monthStart.AddDate(-1, 0, 0)), nil, nil)
+				m.EXPECT().GetDropletBandwidth(gomock.Any(), gomock.Any()).Return(bandwidthResponse(), nil, nil)
+			},
+			check: func(t *testing.T, usage TransferUsage) {
+				require.Zero(t, usage.UsedGiB)
+				require.False(t, usage.LikelyOverage)
+				require.Contains(t, usage.Notes[len(usage.Notes)-1], "No bandwidth samples")
+			},
+		},
+		{
+			name: "Droplet API error",
+			args: map[string]any{"ID": float64(123)},
+			mockSetup: func(d *MockDropletsService, m *MockMonitoringService) {
+				d.EXPECT().Get(gomock.Any(), 123).Return(nil, nil, errors.New("not found"))
+			},
+			expectError: true,
+		},
+		{
+			name: "Monitoring API error",
+			args: map[string]any{"ID": float64(123)},
+			mockSetup: func(d *MockDropletsService, m *MockMonitoringService) {
+				d.EXPECT().Get(gomock.Any(), 123).Return(droplet(monthStart.AddDate(-1, 0, 0)), nil, nil)
+				m.EXPECT().GetDropletBandwidth(gomock.Any(), gomock.Any()).Return(nil, nil, errors.New("metrics unavailable"))
+			},
+			expectError: true,
+		},
+		{
+			name:        "Missing ID argument",
+			args:        map[string]any{},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockDroplets := NewMockDropletsService(ctrl)
+			mockMonitoring := NewMockMonitoringService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockDroplets, mockMonitoring)
+			}
+			tool := NewDropletTool(func(ctx context.Context) (*godo.Client, error) {
+				return &godo.Client{Droplets: mockDroplets, Monitoring: mockMonitoring}, nil
+			})
+			tool.now = func() time.Time { return now }
+
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.getTransferUsage(context.Background(), req)
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, resp.IsError)
+				return
+			}
+			require.False(t, resp.IsError)
+
+			var usage TransferUsage
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &usage))
+			tc.check(t, usage)
+		})
+	}
+}
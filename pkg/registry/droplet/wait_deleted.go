@@ -0,0 +1,115 @@
+package droplet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"mcp-digitalocean/internal/waiter"
+	"mcp-digitalocean/pkg/registry/common"
+)
+
+const (
+	// defaultDeleteWaitTimeout bounds how long the wait-deleted tools wait
+	// when TimeoutSeconds is not set.
+	defaultDeleteWaitTimeout = 300 * time.Second
+	// defaultDeletePollInterval is how often a deleted resource is polled.
+	defaultDeletePollInterval = 5 * time.Second
+)
+
+// deletedResult is the result of droplet-wait-deleted and image-wait-deleted.
+type deletedResult struct {
+	ID             int     `json:"id"`
+	Deleted        bool    `json:"deleted"`
+	ElapsedSeconds float64 `json:"elapsed_seconds"`
+}
+
+// deleteWaitTimeout returns the TimeoutSeconds argument, or
+// defaultDeleteWaitTimeout when it is not set.
+func deleteWaitTimeout(req mcp.CallToolRequest) (time.Duration, *mcp.CallToolResult) {
+	secs, ok := req.GetArguments()["TimeoutSeconds"].(float64)
+	if !ok {
+		return defaultDeleteWaitTimeout, nil
+	}
+	if secs <= 0 {
+		return 0, mcp.NewToolResultError("TimeoutSeconds must be positive")
+	}
+	return time.Duration(secs * float64(time.Second)), nil
+}
+
+// waitDeleted polls status until it fails with a 404, the timeout elapses or
+// ctx is cancelled, and returns how long the resource took to go. A timeout
+// error result gives the last status seen, so a deletion that was never
+// started can be told apart from a slow one.
+func waitDeleted(ctx context.Context, kind string, id int, interval, timeout time.Duration, status func(ctx context.Context) (string, error)) *mcp.CallToolResult {
+	start := time.Now()
+	last, err := waiter.Poll(ctx, interval, timeout, func(ctx context.Context) (string, bool, error) {
+		s, err := status(ctx)
+		if common.IsNotFound(err) {
+			return "", true, nil
+		}
+		return s, false, err
+	}, waiter.WithJitter(0.1))
+	switch {
+	case errors.Is(err, waiter.ErrTimeout):
+		return mcp.NewToolResultError(fmt.Sprintf("%s %d still exists after %s, last status %q; check it with %s-get or wait again", kind, id, timeout, last, kind))
+	case err != nil:
+		return mcp.NewToolResultErrorFromErr(fmt.Sprintf("failed to wait for %s %d to be deleted", kind, id), err)
+	}
+
+	jsonData, err := json.MarshalIndent(deletedResult{ID: id, Deleted: true, ElapsedSeconds: time.Since(start).Seconds()}, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("marshal error", err)
+	}
+	return mcp.NewToolResultText(string(jsonData))
+}
+
+// waitDropletDeleted waits until a droplet is gone, e.g. after a deletion
+// started outside this server.
+func (d *DropletTool) waitDropletDeleted(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	id, errResult := requiredInt(req.GetArguments(), "ID")
+	if errResult != nil {
+		return errResult, nil
+	}
+	timeout, errResult := deleteWaitTimeout(req)
+	if errResult != nil {
+		return errResult, nil
+	}
+	client, err := d.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+	return waitDeleted(ctx, "droplet", id, d.pollInterval, timeout, func(ctx context.Context) (string, error) {
+		droplet, _, err := client.Droplets.Get(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		return droplet.Status, nil
+	}), nil
+}
+
+// waitImageDeleted waits until an image is gone.
+func (i *ImageTool) waitImageDeleted(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	id, errResult := requiredInt(req.GetArguments(), "ID")
+	if errResult != nil {
+		return errResult, nil
+	}
+	timeout, errResult := deleteWaitTimeout(req)
+	if errResult != nil {
+		return errResult, nil
+	}
+	client, err := i.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+	return waitDeleted(ctx, "image", id, i.pollInterval, timeout, func(ctx context.Context) (string, error) {
+		image, _, err := client.Images.GetByID(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		return image.Status, nil
+	}), nil
+}
@@ -0,0 +1,166 @@
+package droplet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"mcp-digitalocean/internal/testhelpers"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDropletTool_waitDropletDeleted(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      map[string]any
+		mockSetup func(*MockDropletsService)
+		wantError string
+	}{
+		{
+			name: "gone immediately",
+			mockSetup: func(m *MockDropletsService) {
+				m.EXPECT().Get(gomock.Any(), 123).Return(nil, nil, testhelpers.NotFoundError("/v2/droplets/123"))
+			},
+		},
+		{
+			name: "eventually gone",
+			mockSetup: func(m *MockDropletsService) {
+				gomock.InOrder(
+					m.EXPECT().Get(gomock.Any(), 123).Return(&godo.Droplet{ID: 123, Status: "active"}, nil, nil),
+					m.EXPECT().Get(gomock.Any(), 123).Return(&godo.Droplet{ID: 123, Status: "off"}, nil, nil),
+					m.EXPECT().Get(gomock.Any(), 123).Return(nil, nil, testhelpers.NotFoundError("/v2/droplets/123")),
+				)
+			},
+		},
+		{
+			name: "timeout gives the last status",
+			args: map[string]any{"TimeoutSeconds": 0.02},
+			mockSetup: func(m *MockDropletsService) {
+				m.EXPECT().Get(gomock.Any(), 123).Return(&godo.Droplet{ID: 123, Status: "active"}, nil, nil).MinTimes(1)
+			},
+			wantError: `droplet 123 still exists after 20ms, last status "active"; check it with droplet-get or wait again`,
+		},
+		{
+			name: "get error",
+			mockSetup: func(m *MockDropletsService) {
+				m.EXPECT().Get(gomock.Any(), 123).Return(nil, nil, errors.New("droplet lookup failed"))
+			},
+			wantError: "failed to wait for droplet 123 to be deleted: droplet lookup failed",
+		},
+		{
+			name:      "invalid timeout",
+			args:      map[string]any{"TimeoutSeconds": float64(-1)},
+			wantError: "TimeoutSeconds must be positive",
+		},
+		{
+			name: "string ID",
+			args: map[string]any{"ID": "123"},
+			mockSetup: func(m *MockDropletsService) {
+				m.EXPECT().Get(gomock.Any(), 123).Return(nil, nil, testhelpers.NotFoundError("/v2/droplets/123"))
+			},
+		},
+		{
+			name:      "invalid ID",
+			args:      map[string]any{"ID": float64(-5)},
+			wantError: "ID is required and must be a number",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockDroplets := NewMockDropletsService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockDroplets)
+			}
+			tool := setupDropletToolWithMocks(mockDroplets, NewMockDropletActionsService(ctrl))
+			tool.pollInterval = time.Millisecond
+
+			args := map[string]any{"ID": float64(123)}
+			for k, v := range tc.args {
+				args[k] = v
+			}
+			resp, err := tool.waitDropletDeleted(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+			require.NoError(t, err)
+			text := resp.Content[0].(mcp.TextContent).Text
+			if tc.wantError != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, text, tc.wantError)
+				return
+			}
+			require.False(t, resp.IsError, text)
+			var out deletedResult
+			require.NoError(t, json.Unmarshal([]byte(text), &out))
+			require.Equal(t, 123, out.ID)
+			require.True(t, out.Deleted)
+			require.GreaterOrEqual(t, out.ElapsedSeconds, 0.0)
+		})
+	}
+}
+
+func TestImageTool_waitImageDeleted(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      map[string]any
+		mockSetup func(*MockImagesService)
+		wantError string
+	}{
+		{
+			name: "gone immediately",
+			mockSetup: func(m *MockImagesService) {
+				m.EXPECT().GetByID(gomock.Any(), 42).Return(nil, nil, testhelpers.NotFoundError("/v2/images/42"))
+			},
+		},
+		{
+			name: "eventually gone",
+			mockSetup: func(m *MockImagesService) {
+				gomock.InOrder(
+					m.EXPECT().GetByID(gomock.Any(), 42).Return(&godo.Image{ID: 42, Status: "available"}, nil, nil),
+					m.EXPECT().GetByID(gomock.Any(), 42).Return(nil, nil, testhelpers.NotFoundError("/v2/images/42")),
+				)
+			},
+		},
+		{
+			name: "timeout gives the last status",
+			args: map[string]any{"TimeoutSeconds": 0.02},
+			mockSetup: func(m *MockImagesService) {
+				m.EXPECT().GetByID(gomock.Any(), 42).Return(&godo.Image{ID: 42, Status: "available"}, nil, nil).MinTimes(1)
+			},
+			wantError: `image 42 still exists after 20ms, last status "available"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockImages := NewMockImagesService(ctrl)
+			tc.mockSetup(mockImages)
+			tool := NewImageTool(staticClient(&godo.Client{Images: mockImages}))
+			tool.pollInterval = time.Millisecond
+
+			args := map[string]any{"ID": float64(42)}
+			for k, v := range tc.args {
+				args[k] = v
+			}
+			resp, err := tool.waitImageDeleted(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+			require.NoError(t, err)
+			text := resp.Content[0].(mcp.TextContent).Text
+			if tc.wantError != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, text, tc.wantError)
+				return
+			}
+			require.False(t, resp.IsError, text)
+			var out deletedResult
+			require.NoError(t, json.Unmarshal([]byte(text), &out))
+			require.Equal(t, 42, out.ID)
+			require.True(t, out.Deleted)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package registry
+
+import (
+	"mcp-digitalocean/pkg/registry/common"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// doctlFieldTools are the tools whose text output Options.FieldNaming
+// renames, with the renamer for the resource they return.
+var doctlFieldTools = map[string]common.FieldRenamer{
+	"droplet-create": common.DoctlDropletFields,
+	"droplet-get":    common.DoctlDropletFields,
+	"droplet-list":   common.DoctlDropletFields,
+	"lb-create":      common.DoctlLoadBalancerFields,
+	"lb-get":         common.DoctlLoadBalancerFields,
+	"lb-list":        common.DoctlLoadBalancerFields,
+	"lb-update":      common.DoctlLoadBalancerFields,
+	"image-create":   common.DoctlImageFields,
+	"image-get":      common.DoctlImageFields,
+	"image-list":     common.DoctlImageFields,
+	"image-update":   common.DoctlImageFields,
+}
+
+// withFieldNaming returns tool with its output renamed for naming.
+func withFieldNaming(tool server.ServerTool, naming common.FieldNaming) server.ServerTool {
+	if naming != common.FieldNamingDoctl {
+		return tool
+	}
+	if renamer, ok := doctlFieldTools[tool.Tool.Name]; ok {
+		tool.Handler = renamer.Wrap(tool.Handler)
+	}
+	return tool
+}
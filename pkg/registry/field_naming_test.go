@@ -0,0 +1,91 @@
+package registry
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"mcp-digitalocean/internal/testhelpers"
+	"mcp-digitalocean/pkg/registry/common"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegister_fieldNaming(t *testing.T) {
+	api := testhelpers.NewFakeAPI(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		testhelpers.WriteJSON(w, http.StatusOK, map[string]any{"droplet": map[string]any{"id": 1, "vcpus": 2, "size_slug": "s-2vcpu-2gb"}})
+	}))
+	t.Cleanup(api.Close)
+	getClient := func(ctx context.Context) (*godo.Client, error) {
+		return godo.New(http.DefaultClient, godo.SetBaseURL(api.URL))
+	}
+
+	tests := []struct {
+		naming common.FieldNaming
+		want   string
+	}{
+		{naming: "", want: `"vcpus": 2`},
+		{naming: common.FieldNamingGodo, want: `"vcpus": 2`},
+		{naming: common.FieldNamingDoctl, want: `"VCPUs": 2`},
+	}
+	for _, tc := range tests {
+		t.Run(string(tc.naming), func(t *testing.T) {
+			s := server.NewMCPServer("test", "0.0.0")
+			_, err := Register(slog.New(slog.NewTextHandler(io.Discard, nil)), s, getClient, Options{FieldNaming: tc.naming}, "droplets")
+			require.NoError(t, err)
+
+			tool := s.GetTool("droplet-get")
+			require.NotNil(t, tool)
+			req := mcp.CallToolRequest{}
+			req.Params.Arguments = map[string]any{"ID": float64(1)}
+			result, err := tool.Handler(context.Background(), req)
+			require.NoError(t, err)
+			require.Contains(t, result.Content[0].(mcp.TextContent).Text, tc.want)
+		})
+	}
+}
+
+func TestDoctlFieldTools_exist(t *testing.T) {
+	s := server.NewMCPServer("test", "0.0.0")
+	_, err := Register(slog.New(slog.NewTextHandler(io.Discard, nil)), s, func(ctx context.Context) (*godo.Client, error) {
+		return godo.NewFromToken("token"), nil
+	}, Options{})
+	require.NoError(t, err)
+	for name := range doctlFieldTools {
+		require.NotNil(t, s.GetTool(name), name)
+	}
+}
+
+func TestRegister_fieldNamingAll(t *testing.T) {
+	api := testhelpers.NewFakeAPI(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		testhelpers.WriteJSON(w, http.StatusOK, map[string]any{
+			"droplets": []any{map[string]any{"id": 1, "vcpus": 2, "size_slug": "s-2vcpu-2gb"}},
+			"links":    map[string]any{},
+		})
+	}))
+	t.Cleanup(api.Close)
+	getClient := func(ctx context.Context) (*godo.Client, error) {
+		return godo.New(http.DefaultClient, godo.SetBaseURL(api.URL))
+	}
+
+	s := server.NewMCPServer("test", "0.0.0")
+	_, err := Register(slog.New(slog.NewTextHandler(io.Discard, nil)), s, getClient, Options{FieldNaming: common.FieldNamingDoctl}, "droplets")
+	require.NoError(t, err)
+
+	tool := s.GetTool("droplet-list")
+	require.NotNil(t, tool)
+	req := mcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{common.AllArg: true}
+	result, err := tool.Handler(context.Background(), req)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	text := result.Content[0].(mcp.TextContent).Text
+	require.Contains(t, text, `"VCPUs": 2`)
+	require.Contains(t, text, `"SizeSlug": "s-2vcpu-2gb"`)
+	require.Contains(t, text, `"total_fetched": 1`)
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -16,6 +17,7 @@ import (
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"mcp-digitalocean/internal/waiter"
 )
 
 // genAIAPIPath is the relative path prefix for GenAI endpoints (same style as godo's "v2/droplets").
@@ -885,41 +887,32 @@ func (et *EvaluationTool) runEvaluationWorkflow(ctx context.Context, req mcp.Cal
 	// Step 7: Poll for completion
 	timeout := time.Duration(timeoutSec) * time.Second
 	pollInterval := time.Duration(pollIntervalSec) * time.Second
-	deadline := time.Now().Add(timeout)
-
-	var finalRun *EvaluationRun
-	for {
-		if time.Now().After(deadline) {
-			return mcp.NewToolResultError("step 7: evaluation polling timed out"), nil
-		}
-
+	finalRun, err := waiter.Poll(ctx, pollInterval, timeout, func(ctx context.Context) (*EvaluationRun, bool, error) {
 		getReq, err := client.NewRequest(ctx, http.MethodGet, genAIAPIPath+"/evaluation_runs/"+evaluationRunUUID, nil)
 		if err != nil {
-			return mcp.NewToolResultErrorFromErr("step 7: failed to create request", err), nil
+			return nil, false, fmt.Errorf("failed to create request: %w", err)
 		}
 
 		var output GetEvaluationRunOutput
-		resp, err = client.Do(ctx, getReq, &output)
-		if err != nil || resp.StatusCode >= 400 {
-			return mcp.NewToolResultErrorFromErr("step 7: failed to poll evaluation run", err), nil
+		resp, err := client.Do(ctx, getReq, &output)
+		if err == nil && resp.StatusCode >= 400 {
+			err = fmt.Errorf("unexpected status %d", resp.StatusCode)
 		}
-
-		finalRun = output.EvaluationRun
-		if finalRun == nil {
-			return mcp.NewToolResultError("step 7: evaluation run missing from API response"), nil
-		}
-
-		// Check for terminal status
-		if isTerminalStatus(finalRun.Status) {
-			break
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to poll evaluation run: %w", err)
 		}
-
-		select {
-		case <-ctx.Done():
-			return mcp.NewToolResultError("workflow cancelled"), nil
-		case <-time.After(pollInterval):
-			// Continue polling
+		if output.EvaluationRun == nil {
+			return nil, false, errors.New("evaluation run missing from API response")
 		}
+		return output.EvaluationRun, isTerminalStatus(output.EvaluationRun.Status), nil
+	})
+	switch {
+	case errors.Is(err, waiter.ErrTimeout):
+		return mcp.NewToolResultError("step 7: evaluation polling timed out"), nil
+	case ctx.Err() != nil:
+		return mcp.NewToolResultError("workflow cancelled"), nil
+	case err != nil:
+		return mcp.NewToolResultErrorFromErr("step 7", err), nil
 	}
 
 	duration := time.Since(startTime).Seconds()
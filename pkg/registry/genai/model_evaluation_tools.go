@@ -3,6 +3,7 @@ package genai
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -13,6 +14,7 @@ import (
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"mcp-digitalocean/internal/waiter"
 )
 
 // toGodoStarMetric converts the locally parsed star metric into the godo SDK type.
@@ -850,33 +852,23 @@ func (met *ModelEvaluationTool) runWorkflow(ctx context.Context, req mcp.CallToo
 	// Step 7: Poll for completion
 	timeout := time.Duration(timeoutSec) * time.Second
 	pollInterval := time.Duration(pollIntervalSec) * time.Second
-	deadline := time.Now().Add(timeout)
-
-	var finalRun *godo.ModelEvaluationRunDetail
-	for {
-		if time.Now().After(deadline) {
-			return mcp.NewToolResultError("step 7: evaluation polling timed out"), nil
-		}
-
+	finalRun, err := waiter.Poll(ctx, pollInterval, timeout, func(ctx context.Context) (*godo.ModelEvaluationRunDetail, bool, error) {
 		output, _, err := client.GradientAI.GetModelEvaluationRun(ctx, evalRunUUID, nil)
 		if err != nil {
-			return mcp.NewToolResultErrorFromErr("step 7: failed to poll evaluation run", err), nil
+			return nil, false, fmt.Errorf("failed to poll evaluation run: %w", err)
 		}
-
-		finalRun = output.Run
-		if finalRun == nil {
-			return mcp.NewToolResultError("step 7: evaluation run missing from API response"), nil
-		}
-
-		if isGodoModelEvalRunTerminal(finalRun.Status) {
-			break
-		}
-
-		select {
-		case <-ctx.Done():
-			return mcp.NewToolResultError("workflow cancelled"), nil
-		case <-time.After(pollInterval):
+		if output.Run == nil {
+			return nil, false, errors.New("evaluation run missing from API response")
 		}
+		return output.Run, isGodoModelEvalRunTerminal(output.Run.Status), nil
+	})
+	switch {
+	case errors.Is(err, waiter.ErrTimeout):
+		return mcp.NewToolResultError("step 7: evaluation polling timed out"), nil
+	case ctx.Err() != nil:
+		return mcp.NewToolResultError("workflow cancelled"), nil
+	case err != nil:
+		return mcp.NewToolResultErrorFromErr("step 7", err), nil
 	}
 
 	duration := time.Since(startTime).Seconds()
@@ -0,0 +1,110 @@
+package insights
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-digitalocean/pkg/registry/common"
+)
+
+// Kinds of alert destination.
+const (
+	alertDestinationEmail = "email"
+	alertDestinationSlack = "slack"
+)
+
+// AlertDestination is an email address or Slack channel that alert policies
+// notify, with the policies that notify it.
+type AlertDestination struct {
+	Kind               string                   `json:"kind"`
+	Destination        string                   `json:"destination"`
+	PolicyCount        int                      `json:"policy_count"`
+	EnabledPolicyCount int                      `json:"enabled_policy_count"`
+	Policies           []AlertDestinationPolicy `json:"policies"`
+}
+
+// AlertDestinationPolicy is an alert policy notifying a destination.
+type AlertDestinationPolicy struct {
+	UUID        string `json:"uuid"`
+	Description string `json:"description"`
+	Type        string `json:"type"`
+	Enabled     bool   `json:"enabled"`
+}
+
+// alertDestinations groups policies by the destinations they notify, ordered
+// by kind and destination. Email addresses are compared case-insensitively.
+// Slack destinations are grouped by channel; webhook URLs are secrets, so they
+// are left out. A policy listing a destination twice is counted once.
+func alertDestinations(policies []godo.AlertPolicy) []AlertDestination {
+	byKey := map[string]*AlertDestination{}
+	add := func(kind, destination string, policy godo.AlertPolicy) {
+		key := kind + "\x00" + destination
+		dest, ok := byKey[key]
+		if !ok {
+			dest = &AlertDestination{Kind: kind, Destination: destination, Policies: []AlertDestinationPolicy{}}
+			byKey[key] = dest
+		}
+		if n := len(dest.Policies); n > 0 && dest.Policies[n-1].UUID == policy.UUID {
+			return
+		}
+		dest.Policies = append(dest.Policies, AlertDestinationPolicy{
+			UUID:        policy.UUID,
+			Description: policy.Description,
+			Type:        policy.Type,
+			Enabled:     policy.Enabled,
+		})
+		dest.PolicyCount++
+		if policy.Enabled {
+			dest.EnabledPolicyCount++
+		}
+	}
+
+	for _, policy := range policies {
+		for _, email := range policy.Alerts.Email {
+			add(alertDestinationEmail, strings.ToLower(strings.TrimSpace(email)), policy)
+		}
+		for _, slack := range policy.Alerts.Slack {
+			add(alertDestinationSlack, slack.Channel, policy)
+		}
+	}
+
+	destinations := make([]AlertDestination, 0, len(byKey))
+	for _, dest := range byKey {
+		destinations = append(destinations, *dest)
+	}
+	sort.Slice(destinations, func(i, j int) bool {
+		if destinations[i].Kind != destinations[j].Kind {
+			return destinations[i].Kind < destinations[j].Kind
+		}
+		return destinations[i].Destination < destinations[j].Destination
+	})
+	return destinations
+}
+
+// listAlertDestinations lists the email addresses and Slack channels every
+// alert policy in the account notifies, so an operator can audit who gets
+// paged.
+func (a *AlertPolicyTool) listAlertDestinations(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	client, err := a.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	policies, err := common.FetchAll(ctx, common.MaxPerPage, client.Monitoring.ListAlertPolicies)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+
+	jsonDestinations, err := json.MarshalIndent(alertDestinations(policies), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(jsonDestinations)), nil
+}
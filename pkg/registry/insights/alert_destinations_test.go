@@ -0,0 +1,102 @@
+package insights
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAlertDestinations(t *testing.T) {
+	policies := []godo.AlertPolicy{
+		{
+			UUID: "cpu", Description: "High CPU", Type: godo.DropletCPUUtilizationPercent, Enabled: true,
+			Alerts: godo.Alerts{
+				Email: []string{"ops@example.com", "oncall@example.com"},
+				Slack: []godo.SlackDetails{{Channel: "#alerts", URL: "https://hooks.slack.com/services/T1/B1/secret"}},
+			},
+		},
+		{
+			UUID: "memory", Description: "High memory", Type: godo.DropletMemoryUtilizationPercent, Enabled: false,
+			Alerts: godo.Alerts{
+				// the same address twice, in another case, counts once.
+				Email: []string{"Ops@Example.com", "ops@example.com"},
+			},
+		},
+		{
+			UUID: "disk", Description: "Disk full", Type: godo.DropletDiskUtilizationPercent, Enabled: true,
+			Alerts: godo.Alerts{
+				Slack: []godo.SlackDetails{{Channel: "#alerts", URL: "https://hooks.slack.com/services/T1/B1/secret"}},
+			},
+		},
+		{UUID: "silent", Description: "No destinations", Type: godo.DropletOneMinuteLoadAverage, Enabled: true},
+	}
+
+	cpu := AlertDestinationPolicy{UUID: "cpu", Description: "High CPU", Type: godo.DropletCPUUtilizationPercent, Enabled: true}
+	memory := AlertDestinationPolicy{UUID: "memory", Description: "High memory", Type: godo.DropletMemoryUtilizationPercent}
+	disk := AlertDestinationPolicy{UUID: "disk", Description: "Disk full", Type: godo.DropletDiskUtilizationPercent, Enabled: true}
+	require.Equal(t, []AlertDestination{
+		{Kind: "email", Destination: "oncall@example.com", PolicyCount: 1, EnabledPolicyCount: 1, Policies: []AlertDestinationPolicy{cpu}},
+		{Kind: "email", Destination: "ops@example.com", PolicyCount: 2, EnabledPolicyCount: 1, Policies: []AlertDestinationPolicy{cpu, memory}},
+		{Kind: "slack", Destination: "#alerts", PolicyCount: 2, EnabledPolicyCount: 2, Policies: []AlertDestinationPolicy{cpu, disk}},
+	}, alertDestinations(policies))
+
+	require.Empty(t, alertDestinations(nil))
+}
+
+func TestAlertPolicyTool_listAlertDestinations(t *testing.T) {
+	tests := []struct {
+		name        string
+		mockSetup   func(*MockMonitoringService)
+		expectError bool
+	}{
+		{
+			name: "success",
+			mockSetup: func(m *MockMonitoringService) {
+				m.EXPECT().ListAlertPolicies(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: 200}).Return([]godo.AlertPolicy{
+					{UUID: "cpu", Enabled: true, Alerts: godo.Alerts{
+						Email: []string{"ops@example.com"},
+						Slack: []godo.SlackDetails{{Channel: "#alerts", URL: "https://hooks.slack.com/services/T1/B1/secret"}},
+					}},
+				}, nil, nil)
+			},
+		},
+		{
+			name: "api error",
+			mockSetup: func(m *MockMonitoringService) {
+				m.EXPECT().ListAlertPolicies(gomock.Any(), gomock.Any()).Return(nil, nil, errors.New("api error"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockMonitoring := NewMockMonitoringService(ctrl)
+			tc.mockSetup(mockMonitoring)
+			tool := setupAlertPolicyToolWithMock(mockMonitoring)
+
+			resp, err := tool.listAlertDestinations(context.Background(), mcp.CallToolRequest{})
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, resp.IsError)
+				return
+			}
+			require.False(t, resp.IsError)
+			text := resp.Content[0].(mcp.TextContent).Text
+			require.NotContains(t, text, "hooks.slack.com")
+
+			var destinations []AlertDestination
+			require.NoError(t, json.Unmarshal([]byte(text), &destinations))
+			require.Len(t, destinations, 2)
+			require.Equal(t, "ops@example.com", destinations[0].Destination)
+			require.Equal(t, "#alerts", destinations[1].Destination)
+		})
+	}
+}
@@ -308,6 +308,12 @@ func (c *AlertPolicyTool) Tools() []server.ServerTool {
 				mcp.WithNumber("PerPage", mcp.DefaultNumber(defaultAlertPoliciesPageSize), mcp.Description("Number of items per page (1-200, default 20)")),
 			),
 		},
+		{
+			Handler: c.listAlertDestinations,
+			Tool: mcp.NewTool("alert-policy-list-destinations",
+				mcp.WithDescription("List the email addresses and Slack channels alert policies notify, each with the policies that notify it and how many of them are enabled, to audit who gets paged. Reads every alert policy in the account. Slack webhook URLs are not returned"),
+			),
+		},
 		{
 			Handler: c.createAlertPolicy,
 			Tool: mcp.NewTool("alert-policy-create",
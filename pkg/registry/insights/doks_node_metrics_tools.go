@@ -0,0 +1,158 @@
+package insights
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DoksNodeMetricsTool provides monitoring metric tools for Kubernetes (DOKS)
+// worker nodes. godo has no Kubernetes metrics endpoints, but every worker node
+// is backed by a droplet, so node metrics are read from the droplet metrics
+// endpoints using the node's droplet ID.
+type DoksNodeMetricsTool struct {
+	client func(ctx context.Context) (*godo.Client, error)
+	now    func() time.Time
+}
+
+// NewDoksNodeMetricsTool creates a new DOKS node metrics tool
+func NewDoksNodeMetricsTool(client func(ctx context.Context) (*godo.Client, error)) *DoksNodeMetricsTool {
+	return &DoksNodeMetricsTool{
+		client: client,
+		now:    time.Now,
+	}
+}
+
+// nodeDropletID resolves the droplet backing the node identified by nodeID
+// (node ID or node name) in the cluster.
+func nodeDropletID(ctx context.Context, client *godo.Client, clusterID, nodeID string) (string, error) {
+	pools, _, err := client.Kubernetes.ListNodePools(ctx, clusterID, &godo.ListOptions{PerPage: 200})
+	if err != nil {
+		return "", err
+	}
+	for _, pool := range pools {
+		for _, node := range pool.Nodes {
+			if node == nil || (node.ID != nodeID && node.Name != nodeID) {
+				continue
+			}
+			if node.DropletID == "" {
+				return "", fmt.Errorf("node %s has no droplet yet; it may still be provisioning", nodeID)
+			}
+			return node.DropletID, nil
+		}
+	}
+	return "", fmt.Errorf("node %s not found in cluster %s", nodeID, clusterID)
+}
+
+// nodeMetricsRequest validates the shared ClusterID, NodeID, Start and End
+// arguments and resolves them into a droplet metrics request. A non-nil result
+// is a tool error to return to the caller.
+func (d *DoksNodeMetricsTool) nodeMetricsRequest(ctx context.Context, req mcp.CallToolRequest) (*godo.Client, *godo.DropletMetricsRequest, *mcp.CallToolResult, error) {
+	clusterID, ok := req.GetArguments()["ClusterID"].(string)
+	if !ok || clusterID == "" {
+		return nil, nil, mcp.NewToolResultError("Cluster ID is required"), nil
+	}
+	nodeID, ok := req.GetArguments()["NodeID"].(string)
+	if !ok || nodeID == "" {
+		return nil, nil, mcp.NewToolResultError("Node ID is required"), nil
+	}
+
+	start, end, err := metricsTimeRange(req.GetArguments(), d.now())
+	if err != nil {
+		return nil, nil, mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := d.client(ctx)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	dropletID, err := nodeDropletID(ctx, client, clusterID, nodeID)
+	if err != nil {
+		return nil, nil, mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+
+	return client, &godo.DropletMetricsRequest{HostID: dropletID, Start: start, End: end}, nil, nil
+}
+
+// getNodeCPU returns the CPU usage of a DOKS node.
+func (d *DoksNodeMetricsTool) getNodeCPU(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	client, metricsReq, toolErr, err := d.nodeMetricsRequest(ctx, req)
+	if toolErr != nil || err != nil {
+		return toolErr, err
+	}
+
+	resp, _, err := client.Monitoring.GetDropletCPU(ctx, metricsReq)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+
+	jsonSeries, err := json.MarshalIndent(flattenMetrics(resp), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(jsonSeries)), nil
+}
+
+// getNodeMemory returns the total and available memory of a DOKS node.
+func (d *DoksNodeMetricsTool) getNodeMemory(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	client, metricsReq, toolErr, err := d.nodeMetricsRequest(ctx, req)
+	if toolErr != nil || err != nil {
+		return toolErr, err
+	}
+
+	total, _, err := client.Monitoring.GetDropletTotalMemory(ctx, metricsReq)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+	available, _, err := client.Monitoring.GetDropletAvailableMemory(ctx, metricsReq)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+
+	jsonMemory, err := json.MarshalIndent(map[string][]metricSeries{
+		"total":     flattenMetrics(total),
+		"available": flattenMetrics(available),
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(jsonMemory)), nil
+}
+
+// doksNodeMetricTool builds a DOKS node metric tool definition with the shared
+// ClusterID, NodeID, Start and End arguments.
+func doksNodeMetricTool(name, description string) mcp.Tool {
+	return mcp.NewTool(name,
+		mcp.WithDescription(description),
+		mcp.WithString("ClusterID", mcp.Required(), mcp.Description("ID of the Kubernetes cluster")),
+		mcp.WithString("NodeID", mcp.Required(), mcp.Description("ID or name of the worker node")),
+		mcp.WithString("Start", mcp.Description("Start of the time range as an RFC3339 timestamp. Defaults to one hour before End")),
+		mcp.WithString("End", mcp.Description("End of the time range as an RFC3339 timestamp. Defaults to now")),
+	)
+}
+
+// Tools returns a list of tool functions
+func (d *DoksNodeMetricsTool) Tools() []server.ServerTool {
+	return []server.ServerTool{
+		{
+			Handler: d.getNodeCPU,
+			Tool: doksNodeMetricTool("monitoring-get-doks-node-cpu",
+				"Get the CPU usage of a Kubernetes worker node, one series per CPU mode. The API has no Kubernetes metrics endpoint; the node's droplet is queried instead, "+
+					"equivalent to GET /v2/monitoring/metrics/droplet/cpu?host_id=<node droplet_id>&start=<unix>&end=<unix>"),
+		},
+		{
+			Handler: d.getNodeMemory,
+			Tool: doksNodeMetricTool("monitoring-get-doks-node-memory",
+				"Get the total and available memory (bytes) of a Kubernetes worker node. The API has no Kubernetes metrics endpoint; the node's droplet is queried instead, "+
+					"equivalent to GET /v2/monitoring/metrics/droplet/memory_total and /memory_available?host_id=<node droplet_id>&start=<unix>&end=<unix>"),
+		},
+	}
+}
@@ -0,0 +1,158 @@
+package insights
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/digitalocean/godo/metrics"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func setupDoksNodeMetricsToolWithMocks(mockKubernetes *MockKubernetesService, mockMonitoring *MockMonitoringService) *DoksNodeMetricsTool {
+	client := func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{
+			Kubernetes: mockKubernetes,
+			Monitoring: mockMonitoring,
+		}, nil
+	}
+
+	tool := NewDoksNodeMetricsTool(client)
+	tool.now = func() time.Time { return testNow }
+	return tool
+}
+
+var testNodePools = []*godo.KubernetesNodePool{
+	{
+		ID: "pool-1",
+		Nodes: []*godo.KubernetesNode{
+			{ID: "node-1", Name: "pool-1-abc", DropletID: "1001"},
+			{ID: "node-2", Name: "pool-1-def", DropletID: "1002"},
+		},
+	},
+	{
+		ID:    "pool-2",
+		Nodes: []*godo.KubernetesNode{{ID: "node-3", Name: "pool-2-ghi"}},
+	},
+}
+
+func TestDoksNodeMetricsTool_getNodeCPU(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockKubernetes := NewMockKubernetesService(ctrl)
+	mockMonitoring := NewMockMonitoringService(ctrl)
+	mockKubernetes.EXPECT().ListNodePools(gomock.Any(), "cluster-1", gomock.Any()).Return(testNodePools, nil, nil)
+	mockMonitoring.EXPECT().GetDropletCPU(gomock.Any(), &godo.DropletMetricsRequest{
+		HostID: "1002",
+		Start:  testNow.Add(-time.Hour),
+		End:    testNow,
+	}).Return(testMetricsResponse(metrics.Metric{"host_id": "1002", "mode": "user"}), nil, nil)
+	tool := setupDoksNodeMetricsToolWithMocks(mockKubernetes, mockMonitoring)
+
+	// nodes can be addressed by name as well as by ID.
+	resp, err := tool.getNodeCPU(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"ClusterID": "cluster-1",
+		"NodeID":    "pool-1-def",
+	}}})
+	require.NoError(t, err)
+	require.False(t, resp.IsError)
+
+	var series []metricSeries
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &series))
+	require.Len(t, series, 1)
+	require.Equal(t, map[string]string{"host_id": "1002", "mode": "user"}, series[0].Labels)
+}
+
+func TestDoksNodeMetricsTool_getNodeMemory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockKubernetes := NewMockKubernetesService(ctrl)
+	mockMonitoring := NewMockMonitoringService(ctrl)
+	wantReq := &godo.DropletMetricsRequest{HostID: "1001", Start: testNow.Add(-time.Hour), End: testNow}
+	mockKubernetes.EXPECT().ListNodePools(gomock.Any(), "cluster-1", gomock.Any()).Return(testNodePools, nil, nil)
+	mockMonitoring.EXPECT().GetDropletTotalMemory(gomock.Any(), wantReq).Return(testMetricsResponse(metrics.Metric{"host_id": "1001"}), nil, nil)
+	mockMonitoring.EXPECT().GetDropletAvailableMemory(gomock.Any(), wantReq).Return(testMetricsResponse(metrics.Metric{"host_id": "1001"}), nil, nil)
+	tool := setupDoksNodeMetricsToolWithMocks(mockKubernetes, mockMonitoring)
+
+	resp, err := tool.getNodeMemory(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"ClusterID": "cluster-1",
+		"NodeID":    "node-1",
+	}}})
+	require.NoError(t, err)
+	require.False(t, resp.IsError)
+
+	var memory map[string][]metricSeries
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &memory))
+	require.Len(t, memory["total"], 1)
+	require.Len(t, memory["available"], 1)
+}
+
+func TestDoksNodeMetricsTool_errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(*MockKubernetesService, *MockMonitoringService)
+		wantMessage string
+	}{
+		{
+			name:        "missing ClusterID",
+			args:        map[string]any{"NodeID": "node-1"},
+			wantMessage: "Cluster ID is required",
+		},
+		{
+			name:        "missing NodeID",
+			args:        map[string]any{"ClusterID": "cluster-1"},
+			wantMessage: "Node ID is required",
+		},
+		{
+			name:        "invalid End",
+			args:        map[string]any{"ClusterID": "cluster-1", "NodeID": "node-1", "End": "now"},
+			wantMessage: "invalid End",
+		},
+		{
+			name: "node not found",
+			args: map[string]any{"ClusterID": "cluster-1", "NodeID": "node-9"},
+			mockSetup: func(k *MockKubernetesService, m *MockMonitoringService) {
+				k.EXPECT().ListNodePools(gomock.Any(), "cluster-1", gomock.Any()).Return(testNodePools, nil, nil)
+			},
+			wantMessage: "node node-9 not found in cluster cluster-1",
+		},
+		{
+			name: "node without droplet",
+			args: map[string]any{"ClusterID": "cluster-1", "NodeID": "node-3"},
+			mockSetup: func(k *MockKubernetesService, m *MockMonitoringService) {
+				k.EXPECT().ListNodePools(gomock.Any(), "cluster-1", gomock.Any()).Return(testNodePools, nil, nil)
+			},
+			wantMessage: "still be provisioning",
+		},
+		{
+			name: "api error",
+			args: map[string]any{"ClusterID": "cluster-1", "NodeID": "node-1"},
+			mockSetup: func(k *MockKubernetesService, m *MockMonitoringService) {
+				k.EXPECT().ListNodePools(gomock.Any(), "cluster-1", gomock.Any()).Return(testNodePools, nil, nil)
+				m.EXPECT().GetDropletCPU(gomock.Any(), gomock.Any()).Return(nil, nil, errors.New("api error"))
+			},
+			wantMessage: "api error",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockKubernetes := NewMockKubernetesService(ctrl)
+			mockMonitoring := NewMockMonitoringService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockKubernetes, mockMonitoring)
+			}
+			tool := setupDoksNodeMetricsToolWithMocks(mockKubernetes, mockMonitoring)
+
+			resp, err := tool.getNodeCPU(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			require.True(t, resp.IsError)
+			require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.wantMessage)
+		})
+	}
+}
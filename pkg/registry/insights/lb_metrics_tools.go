@@ -0,0 +1,99 @@
+package insights
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// lbMetricFn is the method expression type shared by godo's load balancer
+// metric getters, e.g. godo.MonitoringService.GetLoadBalancerFrontendConnectionsCurrent.
+type lbMetricFn func(godo.MonitoringService, context.Context, *godo.LoadBalancerMetricsRequest) (*godo.MetricsResponse, *godo.Response, error)
+
+// LoadBalancerMetricsTool provides load balancer monitoring metric tools
+type LoadBalancerMetricsTool struct {
+	client func(ctx context.Context) (*godo.Client, error)
+	now    func() time.Time
+}
+
+// NewLoadBalancerMetricsTool creates a new load balancer metrics tool
+func NewLoadBalancerMetricsTool(client func(ctx context.Context) (*godo.Client, error)) *LoadBalancerMetricsTool {
+	return &LoadBalancerMetricsTool{
+		client: client,
+		now:    time.Now,
+	}
+}
+
+// getMetric returns a handler that fetches metric for the LbID, Start and End
+// arguments and returns it as flattened series.
+func (l *LoadBalancerMetricsTool) getMetric(metric lbMetricFn) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lbID, ok := req.GetArguments()["LbID"].(string)
+		if !ok || lbID == "" {
+			return mcp.NewToolResultError("Load Balancer ID is required"), nil
+		}
+
+		start, end, err := metricsTimeRange(req.GetArguments(), l.now())
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		client, err := l.client(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+		}
+
+		resp, _, err := metric(client.Monitoring, ctx, &godo.LoadBalancerMetricsRequest{
+			LoadBalancerID: lbID,
+			Start:          start,
+			End:            end,
+		})
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("api error", err), nil
+		}
+
+		jsonSeries, err := json.MarshalIndent(flattenMetrics(resp), "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("marshal error: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonSeries)), nil
+	}
+}
+
+// lbMetricTool builds a load balancer metric tool definition with the shared
+// LbID, Start and End arguments.
+func lbMetricTool(name, description string) mcp.Tool {
+	return mcp.NewTool(name,
+		mcp.WithDescription(description),
+		mcp.WithString("LbID", mcp.Required(), mcp.Description("ID of the load balancer")),
+		mcp.WithString("Start", mcp.Description("Start of the time range as an RFC3339 timestamp. Defaults to one hour before End")),
+		mcp.WithString("End", mcp.Description("End of the time range as an RFC3339 timestamp. Defaults to now")),
+	)
+}
+
+// Tools returns a list of tool functions
+func (l *LoadBalancerMetricsTool) Tools() []server.ServerTool {
+	return []server.ServerTool{
+		{
+			Handler: l.getMetric(godo.MonitoringService.GetLoadBalancerFrontendHttpRequestsPerSecond),
+			Tool: lbMetricTool("monitoring-get-lb-frontend-requests",
+				"Get the HTTP requests per second received by a load balancer's frontend. Use it to confirm the load balancer is receiving traffic"),
+		},
+		{
+			Handler: l.getMetric(godo.MonitoringService.GetLoadBalancerFrontendConnectionsCurrent),
+			Tool: lbMetricTool("monitoring-get-lb-connections",
+				"Get the number of current connections on a load balancer's frontend"),
+		},
+		{
+			Handler: l.getMetric(godo.MonitoringService.GetLoadBalancerFrontendHttpResponses),
+			Tool: lbMetricTool("monitoring-get-lb-http-responses",
+				"Get the HTTP responses returned by a load balancer's frontend, one series per status class (2xx, 3xx, 4xx, 5xx) identified by the series labels"),
+		},
+	}
+}
@@ -0,0 +1,210 @@
+package insights
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/digitalocean/godo/metrics"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var testNow = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func setupLoadBalancerMetricsToolWithMock(mockMonitoring *MockMonitoringService) *LoadBalancerMetricsTool {
+	client := func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{
+			Monitoring: mockMonitoring,
+		}, nil
+	}
+
+	tool := NewLoadBalancerMetricsTool(client)
+	tool.now = func() time.Time { return testNow }
+	return tool
+}
+
+func findTool(t *testing.T, tools []server.ServerTool, name string) server.ServerTool {
+	t.Helper()
+	for _, tool := range tools {
+		if tool.Tool.Name == name {
+			return tool
+		}
+	}
+	t.Fatalf("tool %s not found", name)
+	return server.ServerTool{}
+}
+
+func testMetricsResponse(labels metrics.Metric) *godo.MetricsResponse {
+	return &godo.MetricsResponse{
+		Status: "success",
+		Data: godo.MetricsData{
+			ResultType: "matrix",
+			Result: []metrics.SampleStream{
+				{
+					Metric: labels,
+					Values: []metrics.SamplePair{
+						{Timestamp: metrics.TimeFromUnix(testNow.Add(-time.Minute).Unix()), Value: 1.5},
+						{Timestamp: metrics.TimeFromUnix(testNow.Unix()), Value: 2},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestLoadBalancerMetricsTool_metrics(t *testing.T) {
+	wantReq := &godo.LoadBalancerMetricsRequest{LoadBalancerID: "lb-1", Start: testNow.Add(-time.Hour), End: testNow}
+
+	tests := []struct {
+		tool      string
+		mockSetup func(*MockMonitoringService, *godo.MetricsResponse)
+	}{
+		{
+			tool: "monitoring-get-lb-frontend-requests",
+			mockSetup: func(m *MockMonitoringService, resp *godo.MetricsResponse) {
+				m.EXPECT().GetLoadBalancerFrontendHttpRequestsPerSecond(gomock.Any(), wantReq).Return(resp, nil, nil)
+			},
+		},
+		{
+			tool: "monitoring-get-lb-connections",
+			mockSetup: func(m *MockMonitoringService, resp *godo.MetricsResponse) {
+				m.EXPECT().GetLoadBalancerFrontendConnectionsCurrent(gomock.Any(), wantReq).Return(resp, nil, nil)
+			},
+		},
+		{
+			tool: "monitoring-get-lb-http-responses",
+			mockSetup: func(m *MockMonitoringService, resp *godo.MetricsResponse) {
+				m.EXPECT().GetLoadBalancerFrontendHttpResponses(gomock.Any(), wantReq).Return(resp, nil, nil)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.tool, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockMonitoring := NewMockMonitoringService(ctrl)
+			tc.mockSetup(mockMonitoring, testMetricsResponse(metrics.Metric{"lb_id": "lb-1", "class": "2xx"}))
+			tool := setupLoadBalancerMetricsToolWithMock(mockMonitoring)
+
+			handler := findTool(t, tool.Tools(), tc.tool).Handler
+			resp, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"LbID": "lb-1"}}})
+			require.NoError(t, err)
+			require.False(t, resp.IsError)
+
+			var series []metricSeries
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &series))
+			require.Len(t, series, 1)
+			require.Equal(t, map[string]string{"lb_id": "lb-1", "class": "2xx"}, series[0].Labels)
+			require.Equal(t, []metricPoint{
+				{Timestamp: testNow.Add(-time.Minute), Value: 1.5},
+				{Timestamp: testNow, Value: 2},
+			}, series[0].Points)
+		})
+	}
+}
+
+func TestLoadBalancerMetricsTool_httpResponsesPerClass(t *testing.T) {
+	stream := func(class string, value metrics.SampleValue) metrics.SampleStream {
+		return metrics.SampleStream{
+			Metric: metrics.Metric{"lb_id": "lb-1", "class": metrics.LabelValue(class)},
+			Values: []metrics.SamplePair{{Timestamp: metrics.TimeFromUnix(testNow.Unix()), Value: value}},
+		}
+	}
+	resp := &godo.MetricsResponse{
+		Status: "success",
+		Data: godo.MetricsData{
+			ResultType: "matrix",
+			Result:     []metrics.SampleStream{stream("2xx", 40), stream("4xx", 3), stream("5xx", 1)},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	mockMonitoring := NewMockMonitoringService(ctrl)
+	mockMonitoring.EXPECT().GetLoadBalancerFrontendHttpResponses(gomock.Any(), gomock.Any()).Return(resp, nil, nil)
+	tool := setupLoadBalancerMetricsToolWithMock(mockMonitoring)
+
+	handler := findTool(t, tool.Tools(), "monitoring-get-lb-http-responses").Handler
+	result, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"LbID": "lb-1"}}})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var series []metricSeries
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &series))
+	require.Len(t, series, 3)
+	for i, want := range []struct {
+		class string
+		value float64
+	}{{"2xx", 40}, {"4xx", 3}, {"5xx", 1}} {
+		require.Equal(t, map[string]string{"lb_id": "lb-1", "class": want.class}, series[i].Labels)
+		require.Equal(t, []metricPoint{{Timestamp: testNow, Value: want.value}}, series[i].Points)
+	}
+}
+
+func TestLoadBalancerMetricsTool_errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      map[string]any
+		mockSetup func(*MockMonitoringService)
+	}{
+		{
+			name: "missing LbID",
+			args: map[string]any{},
+		},
+		{
+			name: "invalid Start",
+			args: map[string]any{"LbID": "lb-1", "Start": "yesterday"},
+		},
+		{
+			name: "Start after End",
+			args: map[string]any{"LbID": "lb-1", "Start": "2025-06-01T12:00:00Z", "End": "2025-06-01T11:00:00Z"},
+		},
+		{
+			name: "api error",
+			args: map[string]any{"LbID": "lb-1", "Start": "2025-06-01T10:00:00Z", "End": "2025-06-01T11:00:00Z"},
+			mockSetup: func(m *MockMonitoringService) {
+				m.EXPECT().GetLoadBalancerFrontendConnectionsCurrent(gomock.Any(), &godo.LoadBalancerMetricsRequest{
+					LoadBalancerID: "lb-1",
+					Start:          time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC),
+					End:            time.Date(2025, 6, 1, 11, 0, 0, 0, time.UTC),
+				}).Return(nil, nil, errors.New("api error"))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockMonitoring := NewMockMonitoringService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockMonitoring)
+			}
+			tool := setupLoadBalancerMetricsToolWithMock(mockMonitoring)
+
+			handler := findTool(t, tool.Tools(), "monitoring-get-lb-connections").Handler
+			resp, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			require.True(t, resp.IsError)
+		})
+	}
+}
+
+func TestMetricsTimeRange(t *testing.T) {
+	start, end, err := metricsTimeRange(map[string]any{}, testNow)
+	require.NoError(t, err)
+	require.Equal(t, testNow.Add(-time.Hour), start)
+	require.Equal(t, testNow, end)
+
+	start, end, err = metricsTimeRange(map[string]any{"End": "2025-05-01T00:00:00Z"}, testNow)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2025, 4, 30, 23, 0, 0, 0, time.UTC), start)
+	require.Equal(t, time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), end)
+
+	_, _, err = metricsTimeRange(map[string]any{"Start": "2025-01-01T00:00:00Z"}, testNow)
+	require.ErrorContains(t, err, "exceeds the maximum")
+}
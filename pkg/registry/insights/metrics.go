@@ -0,0 +1,86 @@
+package insights
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+const (
+	// defaultMetricsWindow is the time range queried when Start is omitted.
+	defaultMetricsWindow = time.Hour
+	// maxMetricsWindow bounds a single query; the monitoring API rejects or
+	// heavily downsamples ranges beyond this.
+	maxMetricsWindow = 30 * 24 * time.Hour
+)
+
+// metricsTimeRange parses the Start and End tool arguments (RFC3339) shared by
+// all monitoring metric tools. End defaults to now and Start defaults to one
+// hour before End.
+func metricsTimeRange(args map[string]any, now time.Time) (time.Time, time.Time, error) {
+	end := now
+	if v, ok := args["End"].(string); ok && v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid End %q: must be an RFC3339 timestamp (e.g. 2025-01-02T15:04:05Z)", v)
+		}
+		end = t
+	}
+
+	start := end.Add(-defaultMetricsWindow)
+	if v, ok := args["Start"].(string); ok && v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid Start %q: must be an RFC3339 timestamp (e.g. 2025-01-02T14:04:05Z)", v)
+		}
+		start = t
+	}
+
+	if !start.Before(end) {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid time range: Start (%s) must be before End (%s)", start.Format(time.RFC3339), end.Format(time.RFC3339))
+	}
+	if end.Sub(start) > maxMetricsWindow {
+		return time.Time{}, time.Time{}, fmt.Errorf("time range of %s exceeds the maximum of %s", end.Sub(start), maxMetricsWindow)
+	}
+
+	return start, end, nil
+}
+
+// metricPoint is a single sample in a flattened metric series.
+type metricPoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	Value     float64   `json:"value"`
+}
+
+// metricSeries is one labelled series of a metrics response, flattened from
+// the Prometheus-style matrix the monitoring API returns.
+type metricSeries struct {
+	Labels map[string]string `json:"labels,omitempty"`
+	Points []metricPoint     `json:"points"`
+}
+
+// flattenMetrics converts a monitoring API response into plain series with
+// RFC3339 timestamps and float values.
+func flattenMetrics(resp *godo.MetricsResponse) []metricSeries {
+	if resp == nil {
+		return []metricSeries{}
+	}
+
+	series := make([]metricSeries, 0, len(resp.Data.Result))
+	for _, stream := range resp.Data.Result {
+		s := metricSeries{Points: make([]metricPoint, 0, len(stream.Values))}
+		if len(stream.Metric) > 0 {
+			s.Labels = make(map[string]string, len(stream.Metric))
+			for name, value := range stream.Metric {
+				s.Labels[string(name)] = string(value)
+			}
+		}
+		for _, v := range stream.Values {
+			s.Points = append(s.Points, metricPoint{Timestamp: v.Timestamp.Time().UTC(), Value: float64(v.Value)})
+		}
+		series = append(series, s)
+	}
+
+	return series
+}
@@ -0,0 +1,75 @@
+// Package enums collects the values godo defines constants for into sets that
+// tools validate arguments against, so the accepted values follow godo rather
+// than strings copied into each tool.
+package enums
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/digitalocean/godo"
+)
+
+// Set is the values an argument accepts, in the order error messages and tool
+// schemas list them.
+type Set []string
+
+// Contains reports whether v is one of the values in s.
+func (s Set) Contains(v string) bool {
+	return slices.Contains(s, v)
+}
+
+// Validate returns an error naming arg when v is not one of the values in s.
+func (s Set) Validate(arg, v string) error {
+	if s.Contains(v) {
+		return nil
+	}
+	return fmt.Errorf("%s must be one of %s, got %q", arg, strings.Join(s, ", "), v)
+}
+
+// LoadBalancerTypes are the load balancer types, godo's LoadBalancerType
+// constants.
+var LoadBalancerTypes = Set{
+	godo.LoadBalancerTypeRegional,
+	godo.LoadBalancerTypeRegionalNetwork,
+	godo.LoadBalancerTypeGlobal,
+}
+
+// LoadBalancerNetworkTypes are the load balancer network types, godo's
+// LoadBalancerNetworkType constants.
+var LoadBalancerNetworkTypes = Set{
+	godo.LoadBalancerNetworkTypeExternal,
+	godo.LoadBalancerNetworkTypeInternal,
+}
+
+// EvictionPolicies are the Redis eviction policies, godo's EvictionPolicy
+// constants, in the format SetEvictionPolicy takes.
+var EvictionPolicies = Set{
+	godo.EvictionPolicyNoEviction,
+	godo.EvictionPolicyAllKeysLRU,
+	godo.EvictionPolicyAllKeysRandom,
+	godo.EvictionPolicyVolatileLRU,
+	godo.EvictionPolicyVolatileRandom,
+	godo.EvictionPolicyVolatileTTL,
+}
+
+// RedisMaxmemoryPolicies are the values of the redis_maxmemory_policy setting.
+// The configuration API spells the eviction policies with dashes, e.g.
+// allkeys-lru; godo translates the underscore spelling, so both are accepted.
+var RedisMaxmemoryPolicies = func() Set {
+	policies := slices.Clone(EvictionPolicies)
+	for _, policy := range EvictionPolicies {
+		if dashed := strings.ReplaceAll(policy, "_", "-"); dashed != policy {
+			policies = append(policies, dashed)
+		}
+	}
+	return policies
+}()
+
+// AlertComparisons are the comparisons of a monitoring alert policy, godo's
+// AlertPolicyComp constants.
+var AlertComparisons = Set{
+	string(godo.GreaterThan),
+	string(godo.LessThan),
+}
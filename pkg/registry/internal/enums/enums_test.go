@@ -0,0 +1,115 @@
+package enums
+
+import (
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// godoConstants returns the string constants declared in the godo package,
+// keyed by name, with the name of their declared type if any.
+func godoConstants(t *testing.T) map[string]struct{ value, typ string } {
+	t.Helper()
+	pkg, err := build.Import("github.com/digitalocean/godo", ".", build.FindOnly)
+	if err != nil {
+		t.Skipf("godo sources not found: %v", err)
+	}
+	pkgs, err := parser.ParseDir(token.NewFileSet(), pkg.Dir, func(fi fs.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	require.NoError(t, err)
+
+	constants := map[string]struct{ value, typ string }{}
+	for _, file := range pkgs["godo"].Files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				vs := spec.(*ast.ValueSpec)
+				typ := ""
+				if ident, ok := vs.Type.(*ast.Ident); ok {
+					typ = ident.Name
+				}
+				for i, name := range vs.Names {
+					if i >= len(vs.Values) {
+						continue
+					}
+					lit, ok := vs.Values[i].(*ast.BasicLit)
+					if !ok || lit.Kind != token.STRING {
+						continue
+					}
+					value, err := strconv.Unquote(lit.Value)
+					require.NoError(t, err)
+					constants[name.Name] = struct{ value, typ string }{value, typ}
+				}
+			}
+		}
+	}
+	return constants
+}
+
+// TestSetsCoverGodo fails when godo declares a constant a set does not
+// include, so a godo upgrade that adds a value updates the sets with it.
+func TestSetsCoverGodo(t *testing.T) {
+	constants := godoConstants(t)
+	tests := []struct {
+		name  string
+		set   Set
+		match func(name, typ string) bool
+	}{
+		{
+			name:  "LoadBalancerTypes",
+			set:   LoadBalancerTypes,
+			match: func(name, _ string) bool { return strings.HasPrefix(name, "LoadBalancerType") },
+		},
+		{
+			name:  "LoadBalancerNetworkTypes",
+			set:   LoadBalancerNetworkTypes,
+			match: func(name, _ string) bool { return strings.HasPrefix(name, "LoadBalancerNetworkType") },
+		},
+		{
+			name:  "EvictionPolicies",
+			set:   EvictionPolicies,
+			match: func(name, _ string) bool { return strings.HasPrefix(name, "EvictionPolicy") },
+		},
+		{
+			name:  "AlertComparisons",
+			set:   AlertComparisons,
+			match: func(_, typ string) bool { return typ == "AlertPolicyComp" },
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var want []string
+			for name, c := range constants {
+				if tc.match(name, c.typ) {
+					want = append(want, c.value)
+				}
+			}
+			require.NotEmpty(t, want, "no godo constants matched; were they renamed?")
+			require.ElementsMatch(t, want, []string(tc.set))
+		})
+	}
+}
+
+func TestSet_Validate(t *testing.T) {
+	require.NoError(t, LoadBalancerTypes.Validate("Type", "GLOBAL"))
+	require.EqualError(t, LoadBalancerTypes.Validate("Type", "global"), `Type must be one of REGIONAL, REGIONAL_NETWORK, GLOBAL, got "global"`)
+}
+
+func TestRedisMaxmemoryPolicies(t *testing.T) {
+	for _, policy := range []string{"noeviction", "allkeys_lru", "allkeys-lru", "volatile-ttl"} {
+		require.True(t, RedisMaxmemoryPolicies.Contains(policy), policy)
+	}
+	require.False(t, RedisMaxmemoryPolicies.Contains("allkeys"))
+	require.Len(t, RedisMaxmemoryPolicies, 11)
+}
@@ -3,24 +3,54 @@ package networking
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"mcp-digitalocean/internal/waiter"
+	"mcp-digitalocean/pkg/registry/common"
+)
+
+const (
+	// BYOIPPrefixStatusActive is the status of a prefix that is ready to use.
+	BYOIPPrefixStatusActive = "active"
+	// BYOIPPrefixStatusFailed is the status of a prefix whose validation
+	// failed; its FailureReason says why.
+	BYOIPPrefixStatusFailed = "failed"
+	// defaultBYOIPWaitTimeout bounds how long a prefix is waited on when
+	// TimeoutSeconds is not set.
+	defaultBYOIPWaitTimeout = 10 * time.Minute
+	// defaultBYOIPPollInterval is how often a pending prefix's status is polled.
+	defaultBYOIPPollInterval = 15 * time.Second
 )
 
 type BYOIPPrefixTool struct {
-	client func(ctx context.Context) (*godo.Client, error)
+	common.ToolLogger
+
+	client       func(ctx context.Context) (*godo.Client, error)
+	pollInterval time.Duration
+	notify       func(ctx context.Context, req mcp.CallToolRequest, progress float64, message string)
 }
 
 // NewBYOIPPrefixTool creates a new BYOIPPrefixTool
 func NewBYOIPPrefixTool(client func(ctx context.Context) (*godo.Client, error)) *BYOIPPrefixTool {
 	return &BYOIPPrefixTool{
-		client: client,
+		client:       client,
+		pollInterval: defaultBYOIPPollInterval,
+		notify:       common.NotifyProgress,
 	}
 }
 
+// waitedBYOIPPrefix is a prefix that was waited on. Warning is set when the
+// wait timed out before the prefix reached a final status.
+type waitedBYOIPPrefix struct {
+	*godo.BYOIPPrefix
+	Warning string `json:"warning,omitempty"`
+}
+
 // getBYOIPPrefix fetches BYOIP prefix information by prefix UUID
 func (t *BYOIPPrefixTool) getBYOIPPrefix(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	prefixUUID, ok := req.GetArguments()["UUID"].(string)
@@ -35,7 +65,7 @@ func (t *BYOIPPrefixTool) getBYOIPPrefix(ctx context.Context, req mcp.CallToolRe
 
 	byoipPrefix, _, err := client.BYOIPPrefixes.Get(ctx, prefixUUID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.GetErrorResult(ctx, t.Logger(ctx), "api error", "BYOIP prefix", prefixUUID, err), nil
 	}
 	jsonData, err := json.MarshalIndent(byoipPrefix, "", "  ")
 	if err != nil {
@@ -62,9 +92,17 @@ func (t *BYOIPPrefixTool) listBYOIPPrefix(ctx context.Context, req mcp.CallToolR
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	byoipPrefixes, _, err := client.BYOIPPrefixes.List(ctx, opts)
+	if common.IsAll(req.GetArguments()) {
+		byoipPrefixes, truncated, err := common.FetchForAll(ctx, client.BYOIPPrefixes.List)
+		if err != nil {
+			return common.APIErrorResult(ctx, t.Logger(ctx), "api error", nil, err), nil
+		}
+		return common.NewAllResult(byoipPrefixes, truncated)
+	}
+
+	byoipPrefixes, resp, err := client.BYOIPPrefixes.List(ctx, opts)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, t.Logger(ctx), "api error", resp, err), nil
 	}
 	jsonData, err := json.MarshalIndent(byoipPrefixes, "", "  ")
 	if err != nil {
@@ -90,18 +128,27 @@ func (t *BYOIPPrefixTool) createBYOIPPrefix(ctx context.Context, req mcp.CallToo
 		return mcp.NewToolResultError("region is required"), nil
 	}
 
+	wait, _ := req.GetArguments()["Wait"].(bool)
+	timeout, errResult := waitTimeout(req)
+	if errResult != nil {
+		return errResult, nil
+	}
+
 	client, err := t.client(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	byoipPrefixCreated, _, err := client.BYOIPPrefixes.Create(ctx, &godo.BYOIPPrefixCreateReq{
+	byoipPrefixCreated, resp, err := client.BYOIPPrefixes.Create(ctx, &godo.BYOIPPrefixCreateReq{
 		Prefix:    prefix,
 		Signature: signature,
 		Region:    region,
 	})
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, t.Logger(ctx), "api error", resp, err), nil
+	}
+	if wait {
+		return t.waitResult(ctx, req, client, byoipPrefixCreated.UUID, timeout)
 	}
 
 	jsonData, err := json.MarshalIndent(byoipPrefixCreated, "", "  ")
@@ -111,6 +158,80 @@ func (t *BYOIPPrefixTool) createBYOIPPrefix(ctx context.Context, req mcp.CallToo
 	return mcp.NewToolResultText(string(jsonData)), nil
 }
 
+// waitBYOIPPrefix waits for a prefix created elsewhere to become active or
+// fail.
+func (t *BYOIPPrefixTool) waitBYOIPPrefix(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	prefixUUID, ok := req.GetArguments()["UUID"].(string)
+	if !ok || prefixUUID == "" {
+		return mcp.NewToolResultError("UUID is required"), nil
+	}
+	timeout, errResult := waitTimeout(req)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	client, err := t.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+	return t.waitResult(ctx, req, client, prefixUUID, timeout)
+}
+
+// waitTimeout returns the TimeoutSeconds argument, or defaultBYOIPWaitTimeout
+// when it is not set.
+func waitTimeout(req mcp.CallToolRequest) (time.Duration, *mcp.CallToolResult) {
+	secs, ok := req.GetArguments()["TimeoutSeconds"].(float64)
+	if !ok {
+		return defaultBYOIPWaitTimeout, nil
+	}
+	if secs <= 0 {
+		return 0, mcp.NewToolResultError("TimeoutSeconds must be positive")
+	}
+	return time.Duration(secs * float64(time.Second)), nil
+}
+
+// waitResult waits for the prefix and returns it. A failed prefix is an error
+// result carrying its failure reason; a prefix still pending at the timeout is
+// returned with a warning.
+func (t *BYOIPPrefixTool) waitResult(ctx context.Context, req mcp.CallToolRequest, client *godo.Client, prefixUUID string, timeout time.Duration) (*mcp.CallToolResult, error) {
+	prefix, err := t.waitForPrefix(ctx, req, client, prefixUUID, timeout)
+	out := waitedBYOIPPrefix{BYOIPPrefix: prefix}
+	switch {
+	case errors.Is(err, waiter.ErrTimeout) && prefix != nil:
+		out.Warning = fmt.Sprintf("BYOIP prefix %s was still %s after %s; wait again with byoip-prefix-wait", prefixUUID, prefix.Status, timeout)
+	case err != nil:
+		return common.APIErrorResult(ctx, t.Logger(ctx), fmt.Sprintf("failed to wait for BYOIP prefix %s; check it with byoip-prefix-get", prefixUUID), nil, err), nil
+	case prefix.Status == BYOIPPrefixStatusFailed:
+		reason := prefix.FailureReason
+		if reason == "" {
+			reason = "no failure reason was given"
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("BYOIP prefix %s (%s) failed: %s", prefixUUID, prefix.Prefix, reason)), nil
+	}
+
+	jsonData, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+// waitForPrefix polls the prefix until its status is active or failed, the
+// timeout elapses or ctx is cancelled, sending a progress notification on
+// every poll. The last prefix read is returned alongside any error.
+func (t *BYOIPPrefixTool) waitForPrefix(ctx context.Context, req mcp.CallToolRequest, client *godo.Client, prefixUUID string, timeout time.Duration) (*godo.BYOIPPrefix, error) {
+	polls := 0
+	return waiter.Poll(ctx, t.pollInterval, timeout, func(ctx context.Context) (*godo.BYOIPPrefix, bool, error) {
+		prefix, _, err := client.BYOIPPrefixes.Get(ctx, prefixUUID)
+		if err != nil {
+			return nil, false, err
+		}
+		polls++
+		t.notify(ctx, req, float64(polls), fmt.Sprintf("BYOIP prefix %s is %s", prefixUUID, prefix.Status))
+		return prefix, prefix.Status == BYOIPPrefixStatusActive || prefix.Status == BYOIPPrefixStatusFailed, nil
+	})
+}
+
 // getByOIPPrefixResources fetches resources for a BYOIP prefix
 func (t *BYOIPPrefixTool) getByOIPPrefixResources(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 
@@ -138,7 +259,7 @@ func (t *BYOIPPrefixTool) getByOIPPrefixResources(ctx context.Context, req mcp.C
 
 	byoipPrefixResources, _, err := client.BYOIPPrefixes.GetResources(ctx, prefiUUID, opts)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.GetErrorResult(ctx, t.Logger(ctx), "api error", "BYOIP prefix", prefiUUID, err), nil
 	}
 	jsonData, err := json.MarshalIndent(byoipPrefixResources, "", "  ")
 	if err != nil {
@@ -159,9 +280,9 @@ func (t *BYOIPPrefixTool) deleteBYOIPPrefix(ctx context.Context, req mcp.CallToo
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	_, err = client.BYOIPPrefixes.Delete(ctx, prefiUUID)
+	resp, err := client.BYOIPPrefixes.Delete(ctx, prefiUUID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, t.Logger(ctx), "api error", resp, err), nil
 	}
 
 	return mcp.NewToolResultText("BYOIP Prefix deleted"), nil
@@ -183,6 +304,7 @@ func (t *BYOIPPrefixTool) Tools() []server.ServerTool {
 				mcp.WithDescription("List BYOIP prefixes"),
 				mcp.WithNumber("Page", mcp.DefaultNumber(1), mcp.Description("Page number")),
 				mcp.WithNumber("PerPage", mcp.DefaultNumber(20), mcp.Description("Number of items per page")),
+				common.WithAllArg(),
 			),
 		},
 		{
@@ -201,6 +323,17 @@ func (t *BYOIPPrefixTool) Tools() []server.ServerTool {
 				mcp.WithString("Prefix", mcp.Required(), mcp.Description("The CIDR of the BYOIP prefix")),
 				mcp.WithString("Signature", mcp.Required(), mcp.Description("The signature for the prefix")),
 				mcp.WithString("Region", mcp.Required(), mcp.Description("The region for the prefix")),
+				mcp.WithBoolean("Wait", mcp.Description("Wait until the prefix is active or has failed before returning. Validation can take several minutes; progress notifications are sent while waiting")),
+				mcp.WithNumber("TimeoutSeconds", mcp.Description("How long to wait when Wait is true (default 600). On timeout the prefix is returned with a warning")),
+			),
+		},
+		{
+			Handler: t.waitBYOIPPrefix,
+			Tool: mcp.NewTool("byoip-prefix-wait",
+				common.WithHints(common.HintsRead),
+				mcp.WithDescription("Wait until a BYOIP prefix is active or has failed, polling its status, instead of calling byoip-prefix-get in a loop. A failed prefix is returned as an error with its failure reason. Progress notifications are sent while waiting"),
+				mcp.WithString("UUID", mcp.Required(), mcp.Description("The UUID of the BYOIP prefix")),
+				mcp.WithNumber("TimeoutSeconds", mcp.Description("How long to wait (default 600). On timeout the prefix is returned with a warning")),
 			),
 		},
 		{
@@ -11,6 +11,7 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"mcp-digitalocean/pkg/registry/common"
 )
 
 func setupBYOIPPrefixToolWithMocks(
@@ -189,6 +190,33 @@ func TestBYOIPPrefixTool_listBYOIPPrefix(t *testing.T) {
 	}
 }
 
+func TestBYOIPPrefixTool_listBYOIPPrefixAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	more := &godo.Response{Links: &godo.Links{Pages: &godo.Pages{Next: "https://api/v2/byoip_prefixes?page=2", Last: "https://api/v2/byoip_prefixes?page=2"}}}
+	byoipService := NewMockBYOIPPrefixesService(ctrl)
+	byoipService.EXPECT().
+		List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: common.MaxPerPage}).
+		Return([]*godo.BYOIPPrefix{{Prefix: "5.42.203.0/24"}}, more, nil)
+	byoipService.EXPECT().
+		List(gomock.Any(), &godo.ListOptions{Page: 2, PerPage: common.MaxPerPage}).
+		Return([]*godo.BYOIPPrefix{{Prefix: "129.212.128.0/24"}}, &godo.Response{Links: &godo.Links{}}, nil)
+
+	tool := setupBYOIPPrefixToolWithMocks(byoipService)
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"All": true}}}
+	resp, err := tool.listBYOIPPrefix(context.Background(), req)
+	require.NoError(t, err)
+	require.False(t, resp.IsError)
+
+	var out common.AllResult[godo.BYOIPPrefix]
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+	require.Equal(t, 2, out.TotalFetched)
+	require.False(t, out.Truncated)
+	require.Equal(t, "5.42.203.0/24", out.Items[0].Prefix)
+	require.Equal(t, "129.212.128.0/24", out.Items[1].Prefix)
+}
+
 func TestBYOIPPrefixTool_createBYOIPPrefix(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -452,3 +480,139 @@ func TestBYOIPPrefixTool_deleteBYOIPPrefix(t *testing.T) {
 		})
 	}
 }
+
+func TestBYOIPPrefixTool_createBYOIPPrefixWait(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockBYOIP := NewMockBYOIPPrefixesService(ctrl)
+	mockBYOIP.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&godo.BYOIPPrefixCreateResp{UUID: "new-uuid-123", Region: "nyc3", Status: "pending"}, nil, nil)
+	gomock.InOrder(
+		mockBYOIP.EXPECT().Get(gomock.Any(), "new-uuid-123").Return(&godo.BYOIPPrefix{UUID: "new-uuid-123", Prefix: "192.0.2.0/24", Status: "pending"}, nil, nil),
+		mockBYOIP.EXPECT().Get(gomock.Any(), "new-uuid-123").Return(&godo.BYOIPPrefix{UUID: "new-uuid-123", Prefix: "192.0.2.0/24", Status: "validating"}, nil, nil),
+		mockBYOIP.EXPECT().Get(gomock.Any(), "new-uuid-123").Return(&godo.BYOIPPrefix{UUID: "new-uuid-123", Prefix: "192.0.2.0/24", Status: BYOIPPrefixStatusActive}, nil, nil),
+	)
+
+	var progress []string
+	tool := setupBYOIPPrefixToolWithMocks(mockBYOIP)
+	tool.pollInterval = time.Millisecond
+	tool.notify = func(ctx context.Context, req mcp.CallToolRequest, p float64, message string) {
+		progress = append(progress, message)
+	}
+
+	resp, err := tool.createBYOIPPrefix(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"prefix":    "192.0.2.0/24",
+		"signature": "test-signature-abc123",
+		"region":    "nyc3",
+		"Wait":      true,
+	}}})
+	require.NoError(t, err)
+	require.False(t, resp.IsError)
+
+	var out godo.BYOIPPrefix
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+	require.Equal(t, BYOIPPrefixStatusActive, out.Status)
+	require.Equal(t, "192.0.2.0/24", out.Prefix)
+	require.NotContains(t, resp.Content[0].(mcp.TextContent).Text, "warning")
+	require.Equal(t, []string{
+		"BYOIP prefix new-uuid-123 is pending",
+		"BYOIP prefix new-uuid-123 is validating",
+		"BYOIP prefix new-uuid-123 is active",
+	}, progress)
+}
+
+func TestBYOIPPrefixTool_waitBYOIPPrefix(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(*MockBYOIPPrefixesService)
+		wantStatus  string
+		wantWarning string
+		wantError   string
+	}{
+		{
+			name: "active",
+			args: map[string]any{"UUID": "uuid-1"},
+			mockSetup: func(m *MockBYOIPPrefixesService) {
+				gomock.InOrder(
+					m.EXPECT().Get(gomock.Any(), "uuid-1").Return(&godo.BYOIPPrefix{UUID: "uuid-1", Status: "pending"}, nil, nil),
+					m.EXPECT().Get(gomock.Any(), "uuid-1").Return(&godo.BYOIPPrefix{UUID: "uuid-1", Status: BYOIPPrefixStatusActive}, nil, nil),
+				)
+			},
+			wantStatus: BYOIPPrefixStatusActive,
+		},
+		{
+			name: "failed with a reason",
+			args: map[string]any{"UUID": "uuid-1"},
+			mockSetup: func(m *MockBYOIPPrefixesService) {
+				gomock.InOrder(
+					m.EXPECT().Get(gomock.Any(), "uuid-1").Return(&godo.BYOIPPrefix{UUID: "uuid-1", Status: "pending"}, nil, nil),
+					m.EXPECT().Get(gomock.Any(), "uuid-1").Return(&godo.BYOIPPrefix{
+						UUID: "uuid-1", Prefix: "192.0.2.0/24", Status: BYOIPPrefixStatusFailed, FailureReason: "ROA not found for the prefix",
+					}, nil, nil),
+				)
+			},
+			wantError: "BYOIP prefix uuid-1 (192.0.2.0/24) failed: ROA not found for the prefix",
+		},
+		{
+			name: "failed without a reason",
+			args: map[string]any{"UUID": "uuid-1"},
+			mockSetup: func(m *MockBYOIPPrefixesService) {
+				m.EXPECT().Get(gomock.Any(), "uuid-1").Return(&godo.BYOIPPrefix{UUID: "uuid-1", Prefix: "192.0.2.0/24", Status: BYOIPPrefixStatusFailed}, nil, nil)
+			},
+			wantError: "BYOIP prefix uuid-1 (192.0.2.0/24) failed: no failure reason was given",
+		},
+		{
+			name: "timeout returns the prefix with a warning",
+			args: map[string]any{"UUID": "uuid-1", "TimeoutSeconds": 0.02},
+			mockSetup: func(m *MockBYOIPPrefixesService) {
+				m.EXPECT().Get(gomock.Any(), "uuid-1").Return(&godo.BYOIPPrefix{UUID: "uuid-1", Status: "pending"}, nil, nil).MinTimes(1)
+			},
+			wantStatus:  "pending",
+			wantWarning: "BYOIP prefix uuid-1 was still pending after 20ms; wait again with byoip-prefix-wait",
+		},
+		{
+			name: "get error",
+			args: map[string]any{"UUID": "uuid-1"},
+			mockSetup: func(m *MockBYOIPPrefixesService) {
+				m.EXPECT().Get(gomock.Any(), "uuid-1").Return(nil, nil, errors.New("not found"))
+			},
+			wantError: "failed to wait for BYOIP prefix uuid-1; check it with byoip-prefix-get: not found",
+		},
+		{
+			name:      "missing UUID",
+			args:      map[string]any{},
+			wantError: "UUID is required",
+		},
+		{
+			name:      "invalid timeout",
+			args:      map[string]any{"UUID": "uuid-1", "TimeoutSeconds": float64(-1)},
+			wantError: "TimeoutSeconds must be positive",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockBYOIP := NewMockBYOIPPrefixesService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockBYOIP)
+			}
+			tool := setupBYOIPPrefixToolWithMocks(mockBYOIP)
+			tool.pollInterval = time.Millisecond
+			tool.notify = func(ctx context.Context, req mcp.CallToolRequest, p float64, message string) {}
+
+			resp, err := tool.waitBYOIPPrefix(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			text := resp.Content[0].(mcp.TextContent).Text
+			if tc.wantError != "" {
+				require.True(t, resp.IsError)
+				require.Equal(t, tc.wantError, text)
+				return
+			}
+			require.False(t, resp.IsError)
+			var out waitedBYOIPPrefix
+			require.NoError(t, json.Unmarshal([]byte(text), &out))
+			require.Equal(t, tc.wantStatus, out.Status)
+			require.Equal(t, tc.wantWarning, out.Warning)
+		})
+	}
+}
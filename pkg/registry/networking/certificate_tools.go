@@ -8,10 +8,14 @@ import (
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+
+	"mcp-digitalocean/pkg/registry/common"
 )
 
 // CertificateTool provides tools for managing certificates
 type CertificateTool struct {
+	common.ToolLogger
+
 	client func(ctx context.Context) (*godo.Client, error)
 }
 
@@ -42,9 +46,9 @@ func (c *CertificateTool) createCustomCertificate(ctx context.Context, req mcp.C
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	certificate, _, err := client.Certificates.Create(ctx, certRequest)
+	certificate, resp, err := client.Certificates.Create(ctx, certRequest)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, c.Logger(ctx), "api error", resp, err), nil
 	}
 
 	jsonCert, err := json.MarshalIndent(certificate, "", "  ")
@@ -75,9 +79,9 @@ func (c *CertificateTool) createLetsEncryptCertificate(ctx context.Context, req
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	certificate, _, err := client.Certificates.Create(ctx, certRequest)
+	certificate, resp, err := client.Certificates.Create(ctx, certRequest)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, c.Logger(ctx), "api error", resp, err), nil
 	}
 
 	jsonCert, err := json.MarshalIndent(certificate, "", "  ")
@@ -97,9 +101,9 @@ func (c *CertificateTool) deleteCertificate(ctx context.Context, req mcp.CallToo
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	_, err = client.Certificates.Delete(ctx, certID)
+	resp, err := client.Certificates.Delete(ctx, certID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, c.Logger(ctx), "api error", resp, err), nil
 	}
 
 	return mcp.NewToolResultText("Certificate deleted successfully"), nil
@@ -119,7 +123,7 @@ func (c *CertificateTool) getCertificate(ctx context.Context, req mcp.CallToolRe
 
 	certificate, _, err := client.Certificates.Get(ctx, id)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.GetErrorResult(ctx, c.Logger(ctx), "api error", "certificate", id, err), nil
 	}
 
 	jsonCert, err := json.MarshalIndent(certificate, "", "  ")
@@ -146,9 +150,9 @@ func (c *CertificateTool) listCertificates(ctx context.Context, req mcp.CallTool
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	certs, _, err := client.Certificates.List(ctx, &godo.ListOptions{Page: page, PerPage: perPage})
+	certs, resp, err := client.Certificates.List(ctx, &godo.ListOptions{Page: page, PerPage: perPage})
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, c.Logger(ctx), "api error", resp, err), nil
 	}
 	jsonCerts, err := json.MarshalIndent(certs, "", "  ")
 	if err != nil {
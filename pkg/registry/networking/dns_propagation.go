@@ -0,0 +1,189 @@
+package networking
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// publicResolvers are the DNS servers dns-check-propagation queries by default.
+var publicResolvers = []string{"1.1.1.1:53", "8.8.8.8:53", "9.9.9.9:53"}
+
+// resolverTimeout bounds each resolver's lookup, so one unreachable resolver
+// does not hold up the check.
+const resolverTimeout = 2 * time.Second
+
+// propagationTypes are the record types dns-check-propagation can look up.
+var propagationTypes = []string{"A", "AAAA", "CNAME", "MX", "NS", "TXT"}
+
+// ResolverAnswer is what one resolver returned for the record.
+type ResolverAnswer struct {
+	Resolver string   `json:"resolver"`
+	Values   []string `json:"values"`
+	Matches  bool     `json:"matches"`
+	Error    string   `json:"error,omitempty"`
+}
+
+// PropagationResult is the result of dns-check-propagation. Propagated is true
+// when every resolver that answered returned the expected value.
+type PropagationResult struct {
+	Name          string           `json:"name"`
+	Type          string           `json:"type"`
+	ExpectedValue string           `json:"expected_value"`
+	Propagated    bool             `json:"propagated"`
+	Resolvers     []ResolverAnswer `json:"resolvers"`
+}
+
+// checkPropagation asks each resolver for the record and compares its answer
+// with the expected value.
+func (d *DomainsTool) checkPropagation(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	name, _ := args["Name"].(string)
+	name = strings.TrimSuffix(name, ".")
+	if name == "" {
+		return mcp.NewToolResultError("Name is required"), nil
+	}
+	recordType, _ := args["Type"].(string)
+	recordType = strings.ToUpper(recordType)
+	if !slices.Contains(propagationTypes, recordType) {
+		return mcp.NewToolResultError(fmt.Sprintf("Type must be one of %s", strings.Join(propagationTypes, ", "))), nil
+	}
+	expected, _ := args["ExpectedValue"].(string)
+	if expected == "" {
+		return mcp.NewToolResultError("ExpectedValue is required"), nil
+	}
+
+	result := PropagationResult{
+		Name:          name,
+		Type:          recordType,
+		ExpectedValue: expected,
+	}
+	result.Resolvers, result.Propagated = d.queryResolvers(ctx, name, recordType, expected)
+
+	jsonResult, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(string(jsonResult)), nil
+}
+
+// queryResolvers asks each of the tool's resolvers for the record and compares
+// its answer with expected. It reports whether every resolver that answered
+// returned one of the expected values, and false when none answered.
+func (d *DomainsTool) queryResolvers(ctx context.Context, name, recordType string, expected ...string) ([]ResolverAnswer, bool) {
+	answers := make([]ResolverAnswer, len(d.resolvers))
+	var wg sync.WaitGroup
+	for i, server := range d.resolvers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			answer := ResolverAnswer{Resolver: server, Values: []string{}}
+			values, err := lookupRecord(ctx, server, name, recordType)
+			if err != nil {
+				answer.Error = err.Error()
+			} else {
+				answer.Values = values
+				answer.Matches = slices.ContainsFunc(values, func(v string) bool {
+					return slices.ContainsFunc(expected, func(e string) bool {
+						return normalizeRecordValue(recordType, v) == normalizeRecordValue(recordType, e)
+					})
+				})
+			}
+			answers[i] = answer
+		}()
+	}
+	wg.Wait()
+
+	answered := 0
+	matches := true
+	for _, answer := range answers {
+		if answer.Error != "" {
+			continue
+		}
+		answered++
+		matches = matches && answer.Matches
+	}
+	return answers, matches && answered > 0
+}
+
+// lookupRecord queries a single DNS server, bypassing the system resolver
+// configuration.
+func lookupRecord(ctx context.Context, server, name, recordType string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, resolverTimeout)
+	defer cancel()
+
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, network, server)
+		},
+	}
+	// A trailing dot keeps search domains from being appended.
+	fqdn := name + "."
+
+	switch recordType {
+	case "A", "AAAA":
+		network := "ip4"
+		if recordType == "AAAA" {
+			network = "ip6"
+		}
+		ips, err := resolver.LookupIP(ctx, network, fqdn)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]string, len(ips))
+		for i, ip := range ips {
+			values[i] = ip.String()
+		}
+		return values, nil
+	case "CNAME":
+		cname, err := resolver.LookupCNAME(ctx, fqdn)
+		if err != nil {
+			return nil, err
+		}
+		return []string{strings.TrimSuffix(cname, ".")}, nil
+	case "MX":
+		mxs, err := resolver.LookupMX(ctx, fqdn)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]string, len(mxs))
+		for i, mx := range mxs {
+			values[i] = strings.TrimSuffix(mx.Host, ".")
+		}
+		return values, nil
+	case "NS":
+		nss, err := resolver.LookupNS(ctx, fqdn)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]string, len(nss))
+		for i, ns := range nss {
+			values[i] = strings.TrimSuffix(ns.Host, ".")
+		}
+		return values, nil
+	default:
+		return resolver.LookupTXT(ctx, fqdn)
+	}
+}
+
+// normalizeRecordValue makes host names and addresses comparable regardless
+// of case and a trailing dot. TXT values are compared as they are.
+func normalizeRecordValue(recordType, value string) string {
+	if recordType == "TXT" {
+		return value
+	}
+	value = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(value), "."))
+	if ip := net.ParseIP(value); ip != nil {
+		return ip.String()
+	}
+	return value
+}
@@ -0,0 +1,181 @@
+package networking
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"net"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	dnsTypeA   = 1
+	dnsTypeTXT = 16
+)
+
+// startDNSStub serves answers from records, keyed by query type, on a local
+// UDP port and returns its address.
+func startDNSStub(t *testing.T, records map[uint16][][]byte) string {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if resp := dnsStubResponse(buf[:n], records); resp != nil {
+				_, _ = conn.WriteTo(resp, addr)
+			}
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+// dnsStubResponse answers the single question in query. Anything after the
+// question, such as an EDNS OPT record, is ignored.
+func dnsStubResponse(query []byte, records map[uint16][][]byte) []byte {
+	if len(query) < 12 {
+		return nil
+	}
+	end := 12
+	for end < len(query) && query[end] != 0 {
+		end += int(query[end]) + 1
+	}
+	end += 5 // root label, type and class
+	if end > len(query) {
+		return nil
+	}
+	qtype := binary.BigEndian.Uint16(query[end-4:])
+	answers := records[qtype]
+
+	resp := make([]byte, 12, 512)
+	copy(resp, query[:2])
+	binary.BigEndian.PutUint16(resp[2:], 0x8180) // response, recursion desired and available
+	binary.BigEndian.PutUint16(resp[4:], 1)
+	binary.BigEndian.PutUint16(resp[6:], uint16(len(answers)))
+	resp = append(resp, query[12:end]...)
+	for _, rdata := range answers {
+		resp = append(resp, 0xc0, 0x0c) // pointer to the question name
+		resp = binary.BigEndian.AppendUint16(resp, qtype)
+		resp = binary.BigEndian.AppendUint16(resp, 1)
+		resp = binary.BigEndian.AppendUint32(resp, 300)
+		resp = binary.BigEndian.AppendUint16(resp, uint16(len(rdata)))
+		resp = append(resp, rdata...)
+	}
+	return resp
+}
+
+func txtRData(s string) []byte {
+	return append([]byte{byte(len(s))}, s...)
+}
+
+// closedUDPAddr returns a local address nothing listens on.
+func closedUDPAddr(t *testing.T) string {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := conn.LocalAddr().String()
+	require.NoError(t, conn.Close())
+	return addr
+}
+
+func TestDomainsTool_checkPropagation(t *testing.T) {
+	updated := startDNSStub(t, map[uint16][][]byte{
+		dnsTypeA:   {{203, 0, 113, 10}},
+		dnsTypeTXT: {txtRData("v=spf1 -all")},
+	})
+	stale := startDNSStub(t, map[uint16][][]byte{
+		dnsTypeA:   {{198, 51, 100, 1}},
+		dnsTypeTXT: {txtRData("v=spf1 ~all")},
+	})
+	unreachable := closedUDPAddr(t)
+
+	tests := []struct {
+		name               string
+		resolvers          []string
+		args               map[string]any
+		expectedPropagated bool
+		expectedMatches    []bool
+		expectedValues     [][]string
+		expectedErr        bool
+	}{
+		{
+			name:               "propagated",
+			resolvers:          []string{updated, updated},
+			args:               map[string]any{"Name": "app.example.test", "Type": "A", "ExpectedValue": "203.0.113.10"},
+			expectedPropagated: true,
+			expectedMatches:    []bool{true, true},
+			expectedValues:     [][]string{{"203.0.113.10"}, {"203.0.113.10"}},
+		},
+		{
+			name:               "one resolver stale",
+			resolvers:          []string{updated, stale},
+			args:               map[string]any{"Name": "app.example.test.", "Type": "a", "ExpectedValue": "203.0.113.10"},
+			expectedPropagated: false,
+			expectedMatches:    []bool{true, false},
+			expectedValues:     [][]string{{"203.0.113.10"}, {"198.51.100.1"}},
+		},
+		{
+			name:               "unreachable resolver does not fail the check",
+			resolvers:          []string{updated, unreachable},
+			args:               map[string]any{"Name": "example.test", "Type": "TXT", "ExpectedValue": "v=spf1 -all"},
+			expectedPropagated: true,
+			expectedMatches:    []bool{true, false},
+			expectedValues:     [][]string{{"v=spf1 -all"}, {}},
+		},
+		{
+			name:        "unsupported type",
+			resolvers:   []string{updated},
+			args:        map[string]any{"Name": "example.test", "Type": "SRV", "ExpectedValue": "x"},
+			expectedErr: true,
+		},
+		{
+			name:        "missing expected value",
+			resolvers:   []string{updated},
+			args:        map[string]any{"Name": "example.test", "Type": "A"},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tool := NewDomainsTool(nil)
+			tool.resolvers = tc.resolvers
+
+			resp, err := tool.checkPropagation(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			if tc.expectedErr {
+				require.True(t, resp.IsError)
+				return
+			}
+			require.False(t, resp.IsError)
+
+			var out PropagationResult
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+			require.Equal(t, tc.expectedPropagated, out.Propagated)
+			require.Len(t, out.Resolvers, len(tc.resolvers))
+			for i, answer := range out.Resolvers {
+				require.Equal(t, tc.resolvers[i], answer.Resolver)
+				require.Equal(t, tc.expectedMatches[i], answer.Matches)
+				require.Equal(t, tc.expectedValues[i], answer.Values)
+			}
+			if tc.resolvers[len(tc.resolvers)-1] == unreachable {
+				require.NotEmpty(t, out.Resolvers[1].Error)
+			}
+		})
+	}
+}
+
+func TestNormalizeRecordValue(t *testing.T) {
+	require.Equal(t, "mail.example.com", normalizeRecordValue("MX", "Mail.Example.com."))
+	require.Equal(t, "2001:db8::1", normalizeRecordValue("AAAA", "2001:DB8:0::1"))
+	require.Equal(t, "Hello World", normalizeRecordValue("TXT", "Hello World"))
+}
@@ -0,0 +1,111 @@
+package networking
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// PointingResult is the result of domain-verify-pointing. Type is the record
+// the hostname needs for ExpectedTarget: A or AAAA for an IP, CNAME for a host
+// name. A zone apex cannot have a CNAME, so for an apex hostname and a host
+// name target Type is A and ExpectedAddresses are the target's addresses.
+// Pointing is true when every resolver that answered returned the target.
+type PointingResult struct {
+	Hostname          string           `json:"hostname"`
+	ExpectedTarget    string           `json:"expected_target"`
+	Type              string           `json:"type"`
+	Apex              bool             `json:"apex,omitempty"`
+	ExpectedAddresses []string         `json:"expected_addresses,omitempty"`
+	Pointing          bool             `json:"pointing"`
+	Resolvers         []ResolverAnswer `json:"resolvers"`
+	Hint              string           `json:"hint,omitempty"`
+}
+
+// verifyPointing checks that a custom domain resolves to the load balancer IP
+// or app default domain it is being attached to, which certificate issuance
+// needs before it can complete.
+func (d *DomainsTool) verifyPointing(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	hostname, _ := args["Hostname"].(string)
+	hostname = strings.TrimSuffix(hostname, ".")
+	if hostname == "" {
+		return mcp.NewToolResultError("Hostname is required"), nil
+	}
+	target, _ := args["ExpectedTarget"].(string)
+	target = strings.TrimSuffix(strings.TrimSpace(target), ".")
+	if target == "" {
+		return mcp.NewToolResultError("ExpectedTarget is required"), nil
+	}
+
+	result := PointingResult{
+		Hostname:       hostname,
+		ExpectedTarget: target,
+		Type:           pointingType(target),
+	}
+	result.Resolvers, result.Pointing = d.queryResolvers(ctx, hostname, result.Type, target)
+	switch {
+	case result.Pointing:
+	case result.Type == "CNAME" && d.isZoneApex(ctx, hostname):
+		// the apex can only point at the target's addresses.
+		result.Type = "A"
+		result.Apex = true
+		result.ExpectedAddresses = d.lookupAddresses(ctx, target)
+		result.Resolvers, result.Pointing = d.queryResolvers(ctx, hostname, result.Type, result.ExpectedAddresses...)
+		if result.Pointing {
+			break
+		}
+		addresses := "its current addresses"
+		if len(result.ExpectedAddresses) > 0 {
+			addresses = strings.Join(result.ExpectedAddresses, ", ")
+		}
+		result.Hint = fmt.Sprintf("%s is the apex of its zone, which cannot have a CNAME record. Create A records, and AAAA records if it has IPv6 addresses, for %s with the addresses of %s (%s), or an ALIAS record if your DNS provider supports them, then check again once the TTL of any existing record has passed", hostname, hostname, target, addresses)
+	default:
+		result.Hint = fmt.Sprintf("Create a %s record for %s with the value %s, then check again once the TTL of any existing record has passed", result.Type, hostname, target)
+	}
+
+	jsonResult, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(string(jsonResult)), nil
+}
+
+// pointingType returns the record type a hostname pointing at target has.
+func pointingType(target string) string {
+	ip := net.ParseIP(target)
+	switch {
+	case ip == nil:
+		return "CNAME"
+	case ip.To4() != nil:
+		return "A"
+	default:
+		return "AAAA"
+	}
+}
+
+// isZoneApex reports whether a resolver returns NS records for hostname,
+// which makes it the apex of a zone.
+func (d *DomainsTool) isZoneApex(ctx context.Context, hostname string) bool {
+	for _, server := range d.resolvers {
+		if values, err := lookupRecord(ctx, server, hostname, "NS"); err == nil && len(values) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupAddresses returns the IPv4 addresses of host from the first resolver
+// that answers, or nil when none does.
+func (d *DomainsTool) lookupAddresses(ctx context.Context, host string) []string {
+	for _, server := range d.resolvers {
+		if values, err := lookupRecord(ctx, server, host, "A"); err == nil && len(values) > 0 {
+			return values
+		}
+	}
+	return nil
+}
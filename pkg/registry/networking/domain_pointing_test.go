@@ -0,0 +1,168 @@
+package networking
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	dnsTypeNS    = 2
+	dnsTypeCNAME = 5
+)
+
+// nameRData encodes a host name as it appears in the RDATA of a CNAME record.
+func nameRData(name string) []byte {
+	var rdata []byte
+	for _, label := range strings.Split(strings.TrimSuffix(name, "."), ".") {
+		rdata = append(rdata, byte(len(label)))
+		rdata = append(rdata, label...)
+	}
+	return append(rdata, 0)
+}
+
+func TestDomainsTool_verifyPointing(t *testing.T) {
+	lb := startDNSStub(t, map[uint16][][]byte{
+		dnsTypeA: {{203, 0, 113, 10}},
+	})
+	app := startDNSStub(t, map[uint16][][]byte{
+		dnsTypeCNAME: {nameRData("sample-app-abc12.ondigitalocean.app")},
+	})
+	otherIP := startDNSStub(t, map[uint16][][]byte{
+		dnsTypeA: {{198, 51, 100, 1}},
+	})
+	otherHost := startDNSStub(t, map[uint16][][]byte{
+		dnsTypeCNAME: {nameRData("old-host.example.net")},
+	})
+
+	tests := []struct {
+		name             string
+		resolvers        []string
+		args             map[string]any
+		expectedType     string
+		expectedPointing bool
+		expectedValues   []string
+		expectedErr      bool
+	}{
+		{
+			name:             "A record points at the load balancer",
+			resolvers:        []string{lb},
+			args:             map[string]any{"Hostname": "www.example.test", "ExpectedTarget": "203.0.113.10"},
+			expectedType:     "A",
+			expectedPointing: true,
+			expectedValues:   []string{"203.0.113.10"},
+		},
+		{
+			name:           "A record points elsewhere",
+			resolvers:      []string{otherIP},
+			args:           map[string]any{"Hostname": "www.example.test", "ExpectedTarget": "203.0.113.10"},
+			expectedType:   "A",
+			expectedValues: []string{"198.51.100.1"},
+		},
+		{
+			name:             "CNAME points at the app",
+			resolvers:        []string{app},
+			args:             map[string]any{"Hostname": "app.example.test.", "ExpectedTarget": "Sample-App-abc12.ondigitalocean.app."},
+			expectedType:     "CNAME",
+			expectedPointing: true,
+			expectedValues:   []string{"sample-app-abc12.ondigitalocean.app"},
+		},
+		{
+			name:           "CNAME points elsewhere",
+			resolvers:      []string{otherHost},
+			args:           map[string]any{"Hostname": "app.example.test", "ExpectedTarget": "sample-app-abc12.ondigitalocean.app"},
+			expectedType:   "CNAME",
+			expectedValues: []string{"old-host.example.net"},
+		},
+		{
+			name:        "missing target",
+			resolvers:   []string{lb},
+			args:        map[string]any{"Hostname": "www.example.test"},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tool := NewDomainsTool(nil)
+			tool.resolvers = tc.resolvers
+
+			resp, err := tool.verifyPointing(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			if tc.expectedErr {
+				require.True(t, resp.IsError)
+				return
+			}
+			require.False(t, resp.IsError)
+
+			var out PointingResult
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+			require.Equal(t, tc.expectedType, out.Type)
+			require.Equal(t, tc.expectedPointing, out.Pointing)
+			require.Len(t, out.Resolvers, 1)
+			require.Empty(t, out.Resolvers[0].Error)
+			require.Equal(t, tc.expectedValues, out.Resolvers[0].Values)
+			if tc.expectedPointing {
+				require.Empty(t, out.Hint)
+			} else {
+				require.Contains(t, out.Hint, "Create a "+tc.expectedType+" record")
+			}
+		})
+	}
+}
+
+func TestDomainsTool_verifyPointingApex(t *testing.T) {
+	// the stubs answer every name alike, so the apex and the target resolve to
+	// the same address on one resolver.
+	pointed := startDNSStub(t, map[uint16][][]byte{
+		dnsTypeNS: {nameRData("ns1.digitalocean.com")},
+		dnsTypeA:  {{203, 0, 113, 10}},
+	})
+	elsewhere := startDNSStub(t, map[uint16][][]byte{
+		dnsTypeNS: {nameRData("ns1.digitalocean.com")},
+		dnsTypeA:  {{198, 51, 100, 1}},
+	})
+	args := map[string]any{"Hostname": "example.test", "ExpectedTarget": "sample-app-abc12.ondigitalocean.app"}
+
+	tests := []struct {
+		name             string
+		resolvers        []string
+		expectedPointing bool
+	}{
+		{name: "apex points at the target's addresses", resolvers: []string{pointed}, expectedPointing: true},
+		{name: "apex points elsewhere", resolvers: []string{pointed, elsewhere}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tool := NewDomainsTool(nil)
+			tool.resolvers = tc.resolvers
+
+			resp, err := tool.verifyPointing(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+			require.NoError(t, err)
+			require.False(t, resp.IsError)
+
+			var out PointingResult
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+			require.Equal(t, "A", out.Type)
+			require.True(t, out.Apex)
+			require.Equal(t, []string{"203.0.113.10"}, out.ExpectedAddresses)
+			require.Equal(t, tc.expectedPointing, out.Pointing)
+			if tc.expectedPointing {
+				require.Empty(t, out.Hint)
+			} else {
+				require.Contains(t, out.Hint, "example.test is the apex of its zone, which cannot have a CNAME record. Create A records")
+				require.Contains(t, out.Hint, "(203.0.113.10)")
+			}
+		})
+	}
+}
+
+func TestPointingType(t *testing.T) {
+	require.Equal(t, "A", pointingType("203.0.113.10"))
+	require.Equal(t, "AAAA", pointingType("2001:db8::1"))
+	require.Equal(t, "CNAME", pointingType("sample-app-abc12.ondigitalocean.app"))
+}
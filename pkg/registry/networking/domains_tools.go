@@ -4,19 +4,26 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"mcp-digitalocean/pkg/registry/common"
 )
 
 type DomainsTool struct {
+	common.ToolLogger
+
 	client func(ctx context.Context) (*godo.Client, error)
+	// resolvers are the DNS servers dns-check-propagation queries.
+	resolvers []string
 }
 
 func NewDomainsTool(client func(ctx context.Context) (*godo.Client, error)) *DomainsTool {
 	return &DomainsTool{
-		client: client,
+		client:    client,
+		resolvers: publicResolvers,
 	}
 }
 
@@ -34,7 +41,7 @@ func (d *DomainsTool) getDomain(ctx context.Context, req mcp.CallToolRequest) (*
 
 	domain, _, err := client.Domains.Get(ctx, name)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.GetErrorResult(ctx, d.Logger(ctx), "api error", "domain", name, err), nil
 	}
 	jsonDomain, err := json.MarshalIndent(domain, "", "  ")
 	if err != nil {
@@ -59,9 +66,9 @@ func (d *DomainsTool) listDomains(ctx context.Context, req mcp.CallToolRequest)
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	domains, _, err := client.Domains.List(ctx, &godo.ListOptions{Page: page, PerPage: perPage})
+	domains, resp, err := client.Domains.List(ctx, &godo.ListOptions{Page: page, PerPage: perPage})
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, d.Logger(ctx), "api error", resp, err), nil
 	}
 	jsonDomains, err := json.MarshalIndent(domains, "", "  ")
 	if err != nil {
@@ -89,7 +96,7 @@ func (d *DomainsTool) getDomainRecord(ctx context.Context, req mcp.CallToolReque
 
 	record, _, err := client.Domains.Record(ctx, domain, recordID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.GetErrorResult(ctx, d.Logger(ctx), "api error", "domain record", fmt.Sprintf("%s/%d", domain, recordID), err), nil
 	}
 	jsonRecord, err := json.MarshalIndent(record, "", "  ")
 	if err != nil {
@@ -118,9 +125,9 @@ func (d *DomainsTool) listDomainRecords(ctx context.Context, req mcp.CallToolReq
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	records, _, err := client.Domains.Records(ctx, domain, &godo.ListOptions{Page: page, PerPage: perPage})
+	records, resp, err := client.Domains.Records(ctx, domain, &godo.ListOptions{Page: page, PerPage: perPage})
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, d.Logger(ctx), "api error", resp, err), nil
 	}
 	jsonRecords, err := json.MarshalIndent(records, "", "  ")
 	if err != nil {
@@ -143,9 +150,9 @@ func (d *DomainsTool) createDomain(ctx context.Context, req mcp.CallToolRequest)
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	domain, _, err := client.Domains.Create(ctx, createRequest)
+	domain, resp, err := client.Domains.Create(ctx, createRequest)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, d.Logger(ctx), "api error", resp, err), nil
 	}
 
 	jsonDomain, err := json.MarshalIndent(domain, "", "  ")
@@ -164,9 +171,9 @@ func (d *DomainsTool) deleteDomain(ctx context.Context, req mcp.CallToolRequest)
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	_, err = client.Domains.Delete(ctx, name)
+	resp, err := client.Domains.Delete(ctx, name)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, d.Logger(ctx), "api error", resp, err), nil
 	}
 
 	return mcp.NewToolResultText("Domain deleted successfully"), nil
@@ -189,9 +196,9 @@ func (d *DomainsTool) createRecord(ctx context.Context, req mcp.CallToolRequest)
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	record, _, err := client.Domains.CreateRecord(ctx, domain, createRequest)
+	record, resp, err := client.Domains.CreateRecord(ctx, domain, createRequest)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, d.Logger(ctx), "api error", resp, err), nil
 	}
 
 	jsonRecord, err := json.MarshalIndent(record, "", "  ")
@@ -211,9 +218,9 @@ func (d *DomainsTool) deleteRecord(ctx context.Context, req mcp.CallToolRequest)
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	_, err = client.Domains.DeleteRecord(ctx, domain, recordID)
+	resp, err := client.Domains.DeleteRecord(ctx, domain, recordID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, d.Logger(ctx), "api error", resp, err), nil
 	}
 
 	return mcp.NewToolResultText("Record deleted successfully"), nil
@@ -237,9 +244,9 @@ func (d *DomainsTool) editRecord(ctx context.Context, req mcp.CallToolRequest) (
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	record, _, err := client.Domains.EditRecord(ctx, domain, recordID, editRequest)
+	record, resp, err := client.Domains.EditRecord(ctx, domain, recordID, editRequest)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, d.Logger(ctx), "api error", resp, err), nil
 	}
 
 	jsonRecord, err := json.MarshalIndent(record, "", "  ")
@@ -250,6 +257,96 @@ func (d *DomainsTool) editRecord(ctx context.Context, req mcp.CallToolRequest) (
 	return mcp.NewToolResultText(string(jsonRecord)), nil
 }
 
+// minRecordTTL is the lowest TTL the DigitalOcean DNS API accepts.
+const minRecordTTL = 30
+
+// TTLUpdateFailure is a record whose TTL could not be updated.
+type TTLUpdateFailure struct {
+	RecordID int    `json:"record_id"`
+	Error    string `json:"error"`
+}
+
+// TTLUpdateResult is the result of domain-set-default-ttl.
+type TTLUpdateResult struct {
+	Domain    string             `json:"domain"`
+	TTL       int                `json:"ttl"`
+	Updated   []int              `json:"updated"`
+	Unchanged int                `json:"unchanged"`
+	Failed    []TTLUpdateFailure `json:"failed,omitempty"`
+}
+
+// setDefaultTTL sets the TTL of every record of the given types in a domain.
+// A failed record does not stop the others from being updated.
+func (d *DomainsTool) setDefaultTTL(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	domain, ok := args["Domain"].(string)
+	if !ok || domain == "" {
+		return mcp.NewToolResultError("Domain name is required"), nil
+	}
+	ttlf, ok := args["TTL"].(float64)
+	if !ok || int(ttlf) < minRecordTTL {
+		return mcp.NewToolResultError(fmt.Sprintf("TTL must be at least %d seconds", minRecordTTL)), nil
+	}
+	ttl := int(ttlf)
+
+	types := make(map[string]bool)
+	if rawTypes, ok := args["Types"].([]any); ok {
+		for _, v := range rawTypes {
+			if t, ok := v.(string); ok && t != "" {
+				types[strings.ToUpper(t)] = true
+			}
+		}
+	}
+	if len(types) == 0 {
+		return mcp.NewToolResultError("Types must list at least one record type"), nil
+	}
+
+	client, err := d.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	records, err := common.FetchAll(ctx, common.MaxPerPage, func(ctx context.Context, opt *godo.ListOptions) ([]godo.DomainRecord, *godo.Response, error) {
+		return client.Domains.Records(ctx, domain, opt)
+	})
+	if err != nil {
+		return common.APIErrorResult(ctx, d.Logger(ctx), "api error", nil, err), nil
+	}
+
+	result := TTLUpdateResult{Domain: domain, TTL: ttl, Updated: []int{}}
+	for _, record := range records {
+		if !types[record.Type] {
+			continue
+		}
+		if record.TTL == ttl {
+			result.Unchanged++
+			continue
+		}
+		_, _, err := client.Domains.EditRecord(ctx, domain, record.ID, &godo.DomainRecordEditRequest{
+			Type:     record.Type,
+			Name:     record.Name,
+			Data:     record.Data,
+			Priority: record.Priority,
+			Port:     record.Port,
+			TTL:      ttl,
+			Weight:   record.Weight,
+			Flags:    record.Flags,
+			Tag:      record.Tag,
+		})
+		if err != nil {
+			result.Failed = append(result.Failed, TTLUpdateFailure{RecordID: record.ID, Error: err.Error()})
+			continue
+		}
+		result.Updated = append(result.Updated, record.ID)
+	}
+
+	jsonResult, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(string(jsonResult)), nil
+}
+
 func (d *DomainsTool) Tools() []server.ServerTool {
 	return []server.ServerTool{
 		{
@@ -328,5 +425,32 @@ func (d *DomainsTool) Tools() []server.ServerTool {
 				mcp.WithString("Data", mcp.Required(), mcp.Description("Record data")),
 			),
 		},
+		{
+			Handler: d.setDefaultTTL,
+			Tool: mcp.NewTool("domain-set-default-ttl",
+				mcp.WithDescription("Set the TTL of every record of the given types in a domain. Records that already have the TTL are left alone, and a record that fails to update does not stop the rest; failures are listed in the result."),
+				mcp.WithString("Domain", mcp.Required(), mcp.Description("Domain name")),
+				mcp.WithNumber("TTL", mcp.Required(), mcp.Description("TTL in seconds (at least 30)")),
+				mcp.WithArray("Types", mcp.Required(), mcp.Description("Record types to update (e.g., A, AAAA, CNAME)"), mcp.Items(map[string]any{"type": "string"})),
+			),
+		},
+		{
+			Handler: d.checkPropagation,
+			Tool: mcp.NewTool("dns-check-propagation",
+				mcp.WithDescription("Check whether a DNS record has propagated by querying public resolvers (1.1.1.1, 8.8.8.8 and 9.9.9.9) and comparing what each returns with the expected value. Each resolver gets 2 seconds; an unreachable resolver is reported but does not fail the check."),
+				mcp.WithString("Name", mcp.Required(), mcp.Description("Fully qualified name to look up (e.g., www.example.com)")),
+				mcp.WithString("Type", mcp.Required(), mcp.Enum(propagationTypes...), mcp.Description("Record type")),
+				mcp.WithString("ExpectedValue", mcp.Required(), mcp.Description("Value the record should have: an IP for A/AAAA, a host name for CNAME/MX/NS, or the text for TXT")),
+			),
+		},
+		{
+			Handler: d.verifyPointing,
+			Tool: mcp.NewTool("domain-verify-pointing",
+				mcp.WithDescription("Check whether a custom domain points at a load balancer or app before attaching it, since Let's Encrypt certificate issuance stalls until it does. Queries the same public resolvers as dns-check-propagation for an A/AAAA record when ExpectedTarget is an IP, or a CNAME record when it is a host name, and reports per resolver what it returned. A zone apex cannot have a CNAME, so an apex Hostname is checked for A records with the target's addresses instead."),
+				mcp.WithString("Hostname", mcp.Required(), mcp.Description("Custom domain to check (e.g., www.example.com)")),
+				mcp.WithString("ExpectedTarget", mcp.Required(), mcp.Description("Where the domain should point: a load balancer IP, or a CNAME target such as an app's default domain (e.g., sample-app-abc12.ondigitalocean.app)")),
+				common.WithHints(common.HintsRead),
+			),
+		},
 	}
 }
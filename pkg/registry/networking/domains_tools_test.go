@@ -717,3 +717,82 @@ func TestDomainsTool_editRecord(t *testing.T) {
 		})
 	}
 }
+
+func TestDomainsTool_setDefaultTTL(t *testing.T) {
+	records := []godo.DomainRecord{
+		{ID: 1, Type: "A", Name: "@", Data: "203.0.113.10", TTL: 3600},
+		{ID: 2, Type: "A", Name: "www", Data: "203.0.113.10", TTL: 300},
+		{ID: 3, Type: "MX", Name: "@", Data: "mail.example.com.", Priority: 10, TTL: 3600},
+		{ID: 4, Type: "NS", Name: "@", Data: "ns1.digitalocean.com.", TTL: 1800},
+		{ID: 5, Type: "CNAME", Name: "docs", Data: "example.com.", TTL: 3600},
+	}
+
+	tests := []struct {
+		name           string
+		args           map[string]any
+		mockSetup      func(*MockDomainsService)
+		expectedResult *TTLUpdateResult
+		expectError    bool
+	}{
+		{
+			name: "updates matching records and reports failures",
+			args: map[string]any{"Domain": "example.com", "TTL": float64(300), "Types": []any{"a", "MX", "CNAME"}},
+			mockSetup: func(m *MockDomainsService) {
+				m.EXPECT().Records(gomock.Any(), "example.com", gomock.Any()).Return(records, &godo.Response{}, nil)
+				m.EXPECT().EditRecord(gomock.Any(), "example.com", 1, &godo.DomainRecordEditRequest{Type: "A", Name: "@", Data: "203.0.113.10", TTL: 300}).
+					Return(&godo.DomainRecord{}, nil, nil)
+				m.EXPECT().EditRecord(gomock.Any(), "example.com", 3, &godo.DomainRecordEditRequest{Type: "MX", Name: "@", Data: "mail.example.com.", Priority: 10, TTL: 300}).
+					Return(&godo.DomainRecord{}, nil, nil)
+				m.EXPECT().EditRecord(gomock.Any(), "example.com", 5, gomock.Any()).
+					Return(nil, nil, errors.New("rate limited"))
+			},
+			expectedResult: &TTLUpdateResult{
+				Domain:    "example.com",
+				TTL:       300,
+				Updated:   []int{1, 3},
+				Unchanged: 1,
+				Failed:    []TTLUpdateFailure{{RecordID: 5, Error: "rate limited"}},
+			},
+		},
+		{
+			name: "list error",
+			args: map[string]any{"Domain": "example.com", "TTL": float64(300), "Types": []any{"A"}},
+			mockSetup: func(m *MockDomainsService) {
+				m.EXPECT().Records(gomock.Any(), "example.com", gomock.Any()).Return(nil, nil, errors.New("api error"))
+			},
+			expectError: true,
+		},
+		{
+			name:        "TTL too low",
+			args:        map[string]any{"Domain": "example.com", "TTL": float64(10), "Types": []any{"A"}},
+			expectError: true,
+		},
+		{
+			name:        "no types",
+			args:        map[string]any{"Domain": "example.com", "TTL": float64(300), "Types": []any{}},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockDomains := NewMockDomainsService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockDomains)
+			}
+			tool := setupDomainsToolWithMock(mockDomains)
+
+			resp, err := tool.setDefaultTTL(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, resp.IsError)
+				return
+			}
+			require.False(t, resp.IsError)
+			var out TTLUpdateResult
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+			require.Equal(t, *tc.expectedResult, out)
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package networking
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-digitalocean/pkg/registry/common"
+)
+
+// anywhere is every IPv4 and IPv6 address.
+var anywhere = []string{"0.0.0.0/0", "::/0"}
+
+// firewallTemplateParams are the caller-supplied values a template expands with.
+type firewallTemplateParams struct {
+	AllowedCIDRs []string
+	SourceTag    string
+}
+
+// firewallTemplate is a named firewall profile. Expand returns its rules, or an
+// error when a parameter it needs is missing or one it does not use is set.
+type firewallTemplate struct {
+	Description string
+	Expand      func(p firewallTemplateParams) ([]godo.InboundRule, []godo.OutboundRule, error)
+}
+
+// allOutbound allows all TCP, UDP and ICMP traffic to anywhere.
+func allOutbound() []godo.OutboundRule {
+	return []godo.OutboundRule{
+		{Protocol: "tcp", PortRange: "all", Destinations: &godo.Destinations{Addresses: anywhere}},
+		{Protocol: "udp", PortRange: "all", Destinations: &godo.Destinations{Addresses: anywhere}},
+		{Protocol: "icmp", Destinations: &godo.Destinations{Addresses: anywhere}},
+	}
+}
+
+// firewallTemplates are the profiles firewall-create-from-template accepts.
+var firewallTemplates = map[string]firewallTemplate{
+	"web": {
+		Description: "HTTP (80) and HTTPS (443) inbound from anywhere, all outbound",
+		Expand: func(p firewallTemplateParams) ([]godo.InboundRule, []godo.OutboundRule, error) {
+			if err := rejectUnused(p, "web"); err != nil {
+				return nil, nil, err
+			}
+			inbound := []godo.InboundRule{
+				{Protocol: "tcp", PortRange: "80", Sources: &godo.Sources{Addresses: anywhere}},
+				{Protocol: "tcp", PortRange: "443", Sources: &godo.Sources{Addresses: anywhere}},
+			}
+			return inbound, allOutbound(), nil
+		},
+	},
+	"ssh-restricted": {
+		Description: "SSH (22) inbound from AllowedCIDRs only",
+		Expand: func(p firewallTemplateParams) ([]godo.InboundRule, []godo.OutboundRule, error) {
+			if len(p.AllowedCIDRs) == 0 {
+				return nil, nil, fmt.Errorf("template ssh-restricted requires AllowedCIDRs")
+			}
+			if p.SourceTag != "" {
+				return nil, nil, fmt.Errorf("template ssh-restricted does not use SourceTag")
+			}
+			for _, cidr := range p.AllowedCIDRs {
+				if _, err := netip.ParsePrefix(cidr); err != nil {
+					return nil, nil, fmt.Errorf("invalid CIDR %q in AllowedCIDRs", cidr)
+				}
+			}
+			inbound := []godo.InboundRule{
+				{Protocol: "tcp", PortRange: "22", Sources: &godo.Sources{Addresses: p.AllowedCIDRs}},
+			}
+			return inbound, nil, nil
+		},
+	},
+	"db-private": {
+		Description: "PostgreSQL (5432) and MySQL (3306) inbound from droplets with SourceTag only",
+		Expand: func(p firewallTemplateParams) ([]godo.InboundRule, []godo.OutboundRule, error) {
+			if p.SourceTag == "" {
+				return nil, nil, fmt.Errorf("template db-private requires SourceTag")
+			}
+			if len(p.AllowedCIDRs) > 0 {
+				return nil, nil, fmt.Errorf("template db-private does not use AllowedCIDRs")
+			}
+			inbound := []godo.InboundRule{
+				{Protocol: "tcp", PortRange: "5432", Sources: &godo.Sources{Tags: []string{p.SourceTag}}},
+				{Protocol: "tcp", PortRange: "3306", Sources: &godo.Sources{Tags: []string{p.SourceTag}}},
+			}
+			return inbound, nil, nil
+		},
+	},
+}
+
+// rejectUnused fails when a template that takes no parameters is given one.
+func rejectUnused(p firewallTemplateParams, template string) error {
+	if len(p.AllowedCIDRs) > 0 || p.SourceTag != "" {
+		return fmt.Errorf("template %s takes no AllowedCIDRs or SourceTag", template)
+	}
+	return nil
+}
+
+// firewallTemplateNames returns the template names in sorted order.
+func firewallTemplateNames() []string {
+	names := make([]string, 0, len(firewallTemplates))
+	for name := range firewallTemplates {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// FirewallFromTemplate is the result of firewall-create-from-template: the
+// created firewall and the rules its template expanded into.
+type FirewallFromTemplate struct {
+	Template      string              `json:"template"`
+	InboundRules  []godo.InboundRule  `json:"inbound_rules"`
+	OutboundRules []godo.OutboundRule `json:"outbound_rules"`
+	Firewall      *godo.Firewall      `json:"firewall"`
+}
+
+// createFirewallFromTemplate expands a named template into its rules and
+// creates a firewall with them.
+func (f *FirewallTool) createFirewallFromTemplate(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	templateName, _ := args["Template"].(string)
+	template, ok := firewallTemplates[templateName]
+	if !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("unknown Template %q; choose one of %s", templateName, strings.Join(firewallTemplateNames(), ", "))), nil
+	}
+	name, _ := args["Name"].(string)
+	if name == "" {
+		return mcp.NewToolResultError("Name is required"), nil
+	}
+
+	var dropletIDs []int
+	if v, ok := args["DropletIDs"].([]any); ok {
+		for _, id := range v {
+			n, ok := id.(float64)
+			if !ok {
+				return mcp.NewToolResultError("DropletIDs must be numbers"), nil
+			}
+			dropletIDs = append(dropletIDs, int(n))
+		}
+	}
+	tags, err := stringArray(args, "Tags")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if len(dropletIDs) == 0 && len(tags) == 0 {
+		return mcp.NewToolResultError("at least one of DropletIDs or Tags is required"), nil
+	}
+	cidrs, err := stringArray(args, "AllowedCIDRs")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	sourceTag, _ := args["SourceTag"].(string)
+
+	inbound, outbound, err := template.Expand(firewallTemplateParams{AllowedCIDRs: cidrs, SourceTag: sourceTag})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := f.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	firewall, resp, err := client.Firewalls.Create(ctx, &godo.FirewallRequest{
+		Name:          name,
+		InboundRules:  inbound,
+		OutboundRules: outbound,
+		DropletIDs:    dropletIDs,
+		Tags:          tags,
+	})
+	if err != nil {
+		return common.APIErrorResult(ctx, f.Logger(ctx), "api error", resp, err), nil
+	}
+
+	jsonResult, err := json.MarshalIndent(FirewallFromTemplate{
+		Template:      templateName,
+		InboundRules:  inbound,
+		OutboundRules: outbound,
+		Firewall:      firewall,
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(jsonResult)), nil
+}
+
+// stringArray reads an optional array-of-strings argument.
+func stringArray(args map[string]any, key string) ([]string, error) {
+	raw, ok := args[key].([]any)
+	if !ok {
+		return nil, nil
+	}
+	values := make([]string, 0, len(raw))
+	for _, v := range raw {
+		s, ok := v.(string)
+		if !ok || s == "" {
+			return nil, fmt.Errorf("%s must be non-empty strings", key)
+		}
+		values = append(values, s)
+	}
+	return values, nil
+}
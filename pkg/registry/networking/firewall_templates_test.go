@@ -0,0 +1,174 @@
+package networking
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFirewallTool_createFirewallFromTemplate(t *testing.T) {
+	everywhere := []string{"0.0.0.0/0", "::/0"}
+	tests := []struct {
+		name         string
+		args         map[string]any
+		wantRequest  *godo.FirewallRequest
+		wantInbound  int
+		wantOutbound int
+	}{
+		{
+			name: "web",
+			args: map[string]any{"Template": "web", "Name": "web-fw", "Tags": []any{"frontend"}},
+			wantRequest: &godo.FirewallRequest{
+				Name: "web-fw",
+				InboundRules: []godo.InboundRule{
+					{Protocol: "tcp", PortRange: "80", Sources: &godo.Sources{Addresses: everywhere}},
+					{Protocol: "tcp", PortRange: "443", Sources: &godo.Sources{Addresses: everywhere}},
+				},
+				OutboundRules: []godo.OutboundRule{
+					{Protocol: "tcp", PortRange: "all", Destinations: &godo.Destinations{Addresses: everywhere}},
+					{Protocol: "udp", PortRange: "all", Destinations: &godo.Destinations{Addresses: everywhere}},
+					{Protocol: "icmp", Destinations: &godo.Destinations{Addresses: everywhere}},
+				},
+				Tags: []string{"frontend"},
+			},
+			wantInbound:  2,
+			wantOutbound: 3,
+		},
+		{
+			name: "ssh-restricted",
+			args: map[string]any{
+				"Template":     "ssh-restricted",
+				"Name":         "ssh-fw",
+				"DropletIDs":   []any{float64(11), float64(12)},
+				"AllowedCIDRs": []any{"203.0.113.0/24", "2001:db8::/32"},
+			},
+			wantRequest: &godo.FirewallRequest{
+				Name: "ssh-fw",
+				InboundRules: []godo.InboundRule{
+					{Protocol: "tcp", PortRange: "22", Sources: &godo.Sources{Addresses: []string{"203.0.113.0/24", "2001:db8::/32"}}},
+				},
+				DropletIDs: []int{11, 12},
+			},
+			wantInbound: 1,
+		},
+		{
+			name: "db-private",
+			args: map[string]any{"Template": "db-private", "Name": "db-fw", "Tags": []any{"db"}, "SourceTag": "app"},
+			wantRequest: &godo.FirewallRequest{
+				Name: "db-fw",
+				InboundRules: []godo.InboundRule{
+					{Protocol: "tcp", PortRange: "5432", Sources: &godo.Sources{Tags: []string{"app"}}},
+					{Protocol: "tcp", PortRange: "3306", Sources: &godo.Sources{Tags: []string{"app"}}},
+				},
+				Tags: []string{"db"},
+			},
+			wantInbound: 2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockFirewalls := NewMockFirewallsService(ctrl)
+			tool := setupFirewallToolWithMock(mockFirewalls)
+
+			mockFirewalls.EXPECT().Create(gomock.Any(), tc.wantRequest).Return(&godo.Firewall{ID: "fw-1", Name: tc.wantRequest.Name}, nil, nil)
+
+			resp, err := tool.createFirewallFromTemplate(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			require.False(t, resp.IsError)
+
+			var result FirewallFromTemplate
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &result))
+			require.Equal(t, tc.args["Template"], result.Template)
+			require.Equal(t, "fw-1", result.Firewall.ID)
+			require.Len(t, result.InboundRules, tc.wantInbound)
+			require.Len(t, result.OutboundRules, tc.wantOutbound)
+		})
+	}
+}
+
+func TestFirewallTool_createFirewallFromTemplateErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(*MockFirewallsService)
+		wantMessage string
+	}{
+		{
+			name:        "unknown template",
+			args:        map[string]any{"Template": "open-all", "Name": "fw", "Tags": []any{"a"}},
+			wantMessage: `unknown Template "open-all"; choose one of db-private, ssh-restricted, web`,
+		},
+		{
+			name:        "missing name",
+			args:        map[string]any{"Template": "web", "Tags": []any{"a"}},
+			wantMessage: "Name is required",
+		},
+		{
+			name:        "no targets",
+			args:        map[string]any{"Template": "web", "Name": "fw"},
+			wantMessage: "at least one of DropletIDs or Tags is required",
+		},
+		{
+			name:        "ssh without cidrs",
+			args:        map[string]any{"Template": "ssh-restricted", "Name": "fw", "Tags": []any{"a"}},
+			wantMessage: "template ssh-restricted requires AllowedCIDRs",
+		},
+		{
+			name:        "ssh with invalid cidr",
+			args:        map[string]any{"Template": "ssh-restricted", "Name": "fw", "Tags": []any{"a"}, "AllowedCIDRs": []any{"203.0.113.5"}},
+			wantMessage: `invalid CIDR "203.0.113.5" in AllowedCIDRs`,
+		},
+		{
+			name:        "db without source tag",
+			args:        map[string]any{"Template": "db-private", "Name": "fw", "Tags": []any{"a"}},
+			wantMessage: "template db-private requires SourceTag",
+		},
+		{
+			name:        "db with cidrs",
+			args:        map[string]any{"Template": "db-private", "Name": "fw", "Tags": []any{"a"}, "SourceTag": "app", "AllowedCIDRs": []any{"10.0.0.0/8"}},
+			wantMessage: "template db-private does not use AllowedCIDRs",
+		},
+		{
+			name:        "web with source tag",
+			args:        map[string]any{"Template": "web", "Name": "fw", "Tags": []any{"a"}, "SourceTag": "app"},
+			wantMessage: "template web takes no AllowedCIDRs or SourceTag",
+		},
+		{
+			name:        "non-string tag",
+			args:        map[string]any{"Template": "web", "Name": "fw", "Tags": []any{float64(1)}},
+			wantMessage: "Tags must be non-empty strings",
+		},
+		{
+			name: "api error",
+			args: map[string]any{"Template": "web", "Name": "fw", "DropletIDs": []any{float64(1)}},
+			mockSetup: func(m *MockFirewallsService) {
+				m.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, nil, errors.New("quota exceeded"))
+			},
+			wantMessage: "quota exceeded",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockFirewalls := NewMockFirewallsService(ctrl)
+			tool := setupFirewallToolWithMock(mockFirewalls)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockFirewalls)
+			}
+
+			resp, err := tool.createFirewallFromTemplate(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			require.True(t, resp.IsError)
+			require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.wantMessage)
+		})
+	}
+}
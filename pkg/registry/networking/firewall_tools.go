@@ -4,21 +4,30 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"mcp-digitalocean/pkg/registry/common"
 )
 
 // FirewallTool provides firewall management tools
 type FirewallTool struct {
+	common.ToolLogger
+
 	client func(ctx context.Context) (*godo.Client, error)
+	locks  *common.ResourceLocks
 }
 
+// firewallURN keys firewall mutations for the resource locks.
+var firewallURN = common.URNFromArg("firewall", "ID")
+
 // NewFirewallTool creates a new firewall tool
 func NewFirewallTool(client func(ctx context.Context) (*godo.Client, error)) *FirewallTool {
 	return &FirewallTool{
 		client: client,
+		locks:  common.SharedResourceLocks,
 	}
 }
 
@@ -36,7 +45,7 @@ func (f *FirewallTool) getFirewall(ctx context.Context, req mcp.CallToolRequest)
 
 	firewall, _, err := client.Firewalls.Get(ctx, id)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.GetErrorResult(ctx, f.Logger(ctx), "api error", "firewall", id, err), nil
 	}
 	jsonFirewall, err := json.MarshalIndent(firewall, "", "  ")
 	if err != nil {
@@ -47,6 +56,10 @@ func (f *FirewallTool) getFirewall(ctx context.Context, req mcp.CallToolRequest)
 
 // listFirewalls lists firewalls with pagination support
 func (f *FirewallTool) listFirewalls(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if dropletID, ok := req.GetArguments()["DropletID"].(float64); ok && dropletID > 0 {
+		return f.listDropletFirewalls(ctx, int(dropletID))
+	}
+
 	page := 1
 	perPage := 20
 	if v, ok := req.GetArguments()["Page"].(float64); ok && int(v) > 0 {
@@ -61,9 +74,9 @@ func (f *FirewallTool) listFirewalls(ctx context.Context, req mcp.CallToolReques
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	firewalls, _, err := client.Firewalls.List(ctx, &godo.ListOptions{Page: page, PerPage: perPage})
+	firewalls, resp, err := client.Firewalls.List(ctx, &godo.ListOptions{Page: page, PerPage: perPage})
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, f.Logger(ctx), "api error", resp, err), nil
 	}
 	jsonFirewalls, err := json.MarshalIndent(firewalls, "", "  ")
 	if err != nil {
@@ -72,6 +85,56 @@ func (f *FirewallTool) listFirewalls(ctx context.Context, req mcp.CallToolReques
 	return mcp.NewToolResultText(string(jsonFirewalls)), nil
 }
 
+// FirewallMatch is a firewall that applies to a droplet. MatchReason is
+// "direct" when the firewall lists the droplet, or "tag:<tag>" for the first
+// of the firewall's tags the droplet carries.
+type FirewallMatch struct {
+	godo.Firewall
+	MatchReason string `json:"match_reason"`
+}
+
+// firewallMatches returns the firewalls that apply to droplet, in list order.
+func firewallMatches(droplet *godo.Droplet, firewalls []godo.Firewall) []FirewallMatch {
+	matches := []FirewallMatch{}
+	for _, fw := range firewalls {
+		if slices.Contains(fw.DropletIDs, droplet.ID) {
+			matches = append(matches, FirewallMatch{Firewall: fw, MatchReason: "direct"})
+			continue
+		}
+		for _, tag := range fw.Tags {
+			if slices.Contains(droplet.Tags, tag) {
+				matches = append(matches, FirewallMatch{Firewall: fw, MatchReason: "tag:" + tag})
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// listDropletFirewalls lists every firewall that applies to a droplet,
+// directly or through its tags.
+func (f *FirewallTool) listDropletFirewalls(ctx context.Context, dropletID int) (*mcp.CallToolResult, error) {
+	client, err := f.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	droplet, resp, err := client.Droplets.Get(ctx, dropletID)
+	if err != nil {
+		return common.APIErrorResult(ctx, f.Logger(ctx), "api error", resp, err), nil
+	}
+	firewalls, err := common.FetchAll(ctx, common.MaxPerPage, client.Firewalls.List)
+	if err != nil {
+		return common.APIErrorResult(ctx, f.Logger(ctx), "api error", nil, err), nil
+	}
+
+	jsonMatches, err := json.MarshalIndent(firewallMatches(droplet, firewalls), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(string(jsonMatches)), nil
+}
+
 // createFirewall creates a new firewall
 func (f *FirewallTool) createFirewall(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	name := req.GetArguments()["Name"].(string)
@@ -130,9 +193,9 @@ func (f *FirewallTool) createFirewall(ctx context.Context, req mcp.CallToolReque
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	firewall, _, err := client.Firewalls.Create(ctx, firewallRequest)
+	firewall, resp, err := client.Firewalls.Create(ctx, firewallRequest)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, f.Logger(ctx), "api error", resp, err), nil
 	}
 
 	jsonFirewall, err := json.MarshalIndent(firewall, "", "  ")
@@ -152,9 +215,9 @@ func (f *FirewallTool) deleteFirewall(ctx context.Context, req mcp.CallToolReque
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	_, err = client.Firewalls.Delete(ctx, firewallID)
+	resp, err := client.Firewalls.Delete(ctx, firewallID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, f.Logger(ctx), "api error", resp, err), nil
 	}
 	return mcp.NewToolResultText("Firewall deleted successfully"), nil
 }
@@ -175,9 +238,9 @@ func (f *FirewallTool) addDroplets(ctx context.Context, req mcp.CallToolRequest)
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	_, err = client.Firewalls.AddDroplets(ctx, firewallID, dIDs...)
+	resp, err := client.Firewalls.AddDroplets(ctx, firewallID, dIDs...)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, f.Logger(ctx), "api error", resp, err), nil
 	}
 	return mcp.NewToolResultText("Droplet(s) added to firewall successfully"), nil
 }
@@ -197,9 +260,9 @@ func (f *FirewallTool) removeDroplets(ctx context.Context, req mcp.CallToolReque
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	_, err = client.Firewalls.RemoveDroplets(ctx, firewallID, dIDs...)
+	resp, err := client.Firewalls.RemoveDroplets(ctx, firewallID, dIDs...)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, f.Logger(ctx), "api error", resp, err), nil
 	}
 	return mcp.NewToolResultText("Droplet(s) removed from firewall successfully"), nil
 }
@@ -220,9 +283,9 @@ func (f *FirewallTool) addTags(ctx context.Context, req mcp.CallToolRequest) (*m
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	_, err = client.Firewalls.AddTags(ctx, firewallID, tagNamesStr...)
+	resp, err := client.Firewalls.AddTags(ctx, firewallID, tagNamesStr...)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, f.Logger(ctx), "api error", resp, err), nil
 	}
 	return mcp.NewToolResultText("Tag(s) added to firewall successfully"), nil
 }
@@ -243,9 +306,9 @@ func (f *FirewallTool) removeTags(ctx context.Context, req mcp.CallToolRequest)
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	_, err = client.Firewalls.RemoveTags(ctx, firewallID, tagNamesStr...)
+	resp, err := client.Firewalls.RemoveTags(ctx, firewallID, tagNamesStr...)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, f.Logger(ctx), "api error", resp, err), nil
 	}
 	return mcp.NewToolResultText("Tag(s) removed from firewall successfully"), nil
 }
@@ -319,9 +382,9 @@ func (f *FirewallTool) addRules(ctx context.Context, req mcp.CallToolRequest) (*
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	_, err = client.Firewalls.AddRules(ctx, firewallID, rulesRequest)
+	resp, err := client.Firewalls.AddRules(ctx, firewallID, rulesRequest)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, f.Logger(ctx), "api error", resp, err), nil
 	}
 
 	return mcp.NewToolResultText("Rule(s) added to firewall successfully"), nil
@@ -396,9 +459,9 @@ func (f *FirewallTool) removeRules(ctx context.Context, req mcp.CallToolRequest)
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	_, err = client.Firewalls.RemoveRules(ctx, firewallID, rulesRequest)
+	resp, err := client.Firewalls.RemoveRules(ctx, firewallID, rulesRequest)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, f.Logger(ctx), "api error", resp, err), nil
 	}
 
 	return mcp.NewToolResultText("Rule(s) removed from firewall successfully"), nil
@@ -417,9 +480,10 @@ func (f *FirewallTool) Tools() []server.ServerTool {
 		{
 			Handler: f.listFirewalls,
 			Tool: mcp.NewTool("firewall-list",
-				mcp.WithDescription("List firewalls with pagination"),
+				mcp.WithDescription("List firewalls with pagination, or with DropletID every firewall that protects a droplet"),
 				mcp.WithNumber("Page", mcp.DefaultNumber(1), mcp.Description("Page number")),
 				mcp.WithNumber("PerPage", mcp.DefaultNumber(20), mcp.Description("Items per page")),
+				mcp.WithNumber("DropletID", mcp.Description("Only return the firewalls that apply to this droplet, directly or through one of its tags, across all pages. Each has a match_reason of \"direct\" or \"tag:<tag>\". Page and PerPage are ignored")),
 			),
 		},
 		{
@@ -444,14 +508,32 @@ func (f *FirewallTool) Tools() []server.ServerTool {
 			),
 		},
 		{
-			Handler: f.deleteFirewall,
+			Handler: f.createFirewallFromTemplate,
+			Tool: mcp.NewTool("firewall-create-from-template",
+				mcp.WithDescription("Create a firewall from a named template. Templates: web (80 and 443 inbound from anywhere, all outbound), ssh-restricted (22 inbound from AllowedCIDRs), db-private (5432 and 3306 inbound from droplets tagged SourceTag). ssh-restricted and db-private only add inbound rules; apply them alongside a firewall that allows outbound traffic. The result echoes the expanded rules."),
+				mcp.WithString("Template", mcp.Required(), mcp.Enum(firewallTemplateNames()...), mcp.Description("Name of the template")),
+				mcp.WithString("Name", mcp.Required(), mcp.Description("Name of the firewall")),
+				mcp.WithArray("DropletIDs", mcp.Description("Droplet IDs to apply the firewall to. At least one of DropletIDs or Tags is required"), mcp.Items(map[string]any{
+					"type": "number",
+				})),
+				mcp.WithArray("Tags", mcp.Description("Tags to apply the firewall to. At least one of DropletIDs or Tags is required"), mcp.Items(map[string]any{
+					"type": "string",
+				})),
+				mcp.WithArray("AllowedCIDRs", mcp.Description("Source CIDRs allowed to connect, e.g. 203.0.113.0/24. Required by ssh-restricted, rejected by the other templates"), mcp.Items(map[string]any{
+					"type": "string",
+				})),
+				mcp.WithString("SourceTag", mcp.Description("Tag of the droplets allowed to connect. Required by db-private, rejected by the other templates")),
+			),
+		},
+		{
+			Handler: f.locks.Serialize(firewallURN, f.deleteFirewall),
 			Tool: mcp.NewTool("firewall-delete",
 				mcp.WithDescription("Delete a firewall"),
 				mcp.WithString("ID", mcp.Required(), mcp.Description("ID of the firewall to delete")),
 			),
 		},
 		{
-			Handler: f.addDroplets,
+			Handler: f.locks.Serialize(firewallURN, f.addDroplets),
 			Tool: mcp.NewTool("firewall-add-droplets",
 				mcp.WithDescription("Adds one or more droplets to a firewall"),
 				mcp.WithString("ID", mcp.Required(), mcp.Description("ID of the firewall to apply to droplets")),
@@ -462,7 +544,7 @@ func (f *FirewallTool) Tools() []server.ServerTool {
 			),
 		},
 		{
-			Handler: f.addTags,
+			Handler: f.locks.Serialize(firewallURN, f.addTags),
 			Tool: mcp.NewTool("firewall-add-tags",
 				mcp.WithDescription("Adds one or more tags to a firewall"),
 				mcp.WithString("ID", mcp.Required(), mcp.Description("ID of the firewall to update tags")),
@@ -474,7 +556,7 @@ func (f *FirewallTool) Tools() []server.ServerTool {
 		},
 
 		{
-			Handler: f.removeDroplets,
+			Handler: f.locks.Serialize(firewallURN, f.removeDroplets),
 			Tool: mcp.NewTool("firewall-remove-droplets",
 				mcp.WithDescription("Removes one or more droplets from a firewall"),
 				mcp.WithString("ID", mcp.Required(), mcp.Description("ID of the firewall to remove droplets from")),
@@ -485,7 +567,7 @@ func (f *FirewallTool) Tools() []server.ServerTool {
 			),
 		},
 		{
-			Handler: f.removeTags,
+			Handler: f.locks.Serialize(firewallURN, f.removeTags),
 			Tool: mcp.NewTool("firewall-remove-tags",
 				mcp.WithDescription("Removes one or more tags from a firewall"),
 				mcp.WithString("ID", mcp.Required(), mcp.Description("ID of the firewall to update tags")),
@@ -496,7 +578,7 @@ func (f *FirewallTool) Tools() []server.ServerTool {
 			),
 		},
 		{
-			Handler: f.addRules,
+			Handler: f.locks.Serialize(firewallURN, f.addRules),
 			Tool: mcp.NewTool("firewall-add-rules",
 				mcp.WithDescription("Add one or more rules to a firewall"),
 				mcp.WithString("ID", mcp.Required(), mcp.Description("ID of the firewall to add rules to")),
@@ -549,7 +631,7 @@ func (f *FirewallTool) Tools() []server.ServerTool {
 			),
 		},
 		{
-			Handler: f.removeRules,
+			Handler: f.locks.Serialize(firewallURN, f.removeRules),
 			Tool: mcp.NewTool("firewall-remove-rules",
 				mcp.WithDescription("Remove one or more rules from a firewall"),
 				mcp.WithString("ID", mcp.Required(), mcp.Description("ID of the firewall to remove rules from")),
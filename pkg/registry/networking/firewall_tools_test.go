@@ -174,6 +174,78 @@ func TestFirewallTool_listFirewalls(t *testing.T) {
 	}
 }
 
+func TestFirewallTool_listFirewallsByDroplet(t *testing.T) {
+	droplet := &godo.Droplet{ID: 123, Tags: []string{"env:prod", "web"}}
+	// the firewalls span two pages, so both must be read.
+	page1 := []godo.Firewall{
+		{ID: "fw-direct", DropletIDs: []int{7, 123}, Tags: []string{"web"}},
+		{ID: "fw-other", DropletIDs: []int{7}, Tags: []string{"db"}},
+	}
+	page2 := []godo.Firewall{
+		{ID: "fw-tag", Tags: []string{"lb", "web", "env:prod"}},
+	}
+	tests := []struct {
+		name        string
+		droplet     *godo.Droplet
+		want        map[string]string
+		expectError string
+	}{
+		{
+			name:    "direct and tag matches",
+			droplet: droplet,
+			want:    map[string]string{"fw-direct": "direct", "fw-tag": "tag:web"},
+		},
+		{
+			name:    "no matches",
+			droplet: &godo.Droplet{ID: 456, Tags: []string{"batch"}},
+			want:    map[string]string{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockFirewalls := NewMockFirewallsService(ctrl)
+			mockDroplets := NewMockDropletsService(ctrl)
+			mockDroplets.EXPECT().Get(gomock.Any(), tc.droplet.ID).Return(tc.droplet, nil, nil)
+			mockFirewalls.EXPECT().
+				List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: 200}).
+				Return(page1, &godo.Response{Links: &godo.Links{Pages: &godo.Pages{Next: "https://api.digitalocean.com/v2/firewalls?page=2&per_page=200"}}}, nil)
+			mockFirewalls.EXPECT().
+				List(gomock.Any(), &godo.ListOptions{Page: 2, PerPage: 200}).
+				Return(page2, &godo.Response{}, nil)
+			tool := NewFirewallTool(func(ctx context.Context) (*godo.Client, error) {
+				return &godo.Client{Firewalls: mockFirewalls, Droplets: mockDroplets}, nil
+			})
+
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"DropletID": float64(tc.droplet.ID), "Page": float64(5)}}}
+			resp, err := tool.listFirewalls(context.Background(), req)
+			require.NoError(t, err)
+			require.False(t, resp.IsError)
+			var matches []FirewallMatch
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &matches))
+			got := map[string]string{}
+			for _, m := range matches {
+				got[m.ID] = m.MatchReason
+			}
+			require.Equal(t, tc.want, got)
+		})
+	}
+
+	t.Run("droplet not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockDroplets := NewMockDropletsService(ctrl)
+		mockDroplets.EXPECT().Get(gomock.Any(), 999).Return(nil, nil, errors.New("droplet not found"))
+		tool := NewFirewallTool(func(ctx context.Context) (*godo.Client, error) {
+			return &godo.Client{Droplets: mockDroplets}, nil
+		})
+		req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"DropletID": float64(999)}}}
+		resp, err := tool.listFirewalls(context.Background(), req)
+		require.NoError(t, err)
+		require.True(t, resp.IsError)
+		require.Contains(t, resp.Content[0].(mcp.TextContent).Text, "droplet not found")
+	})
+}
+
 func TestFirewallTool_createFirewall(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
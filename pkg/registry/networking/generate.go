@@ -1,3 +1,3 @@
 package networking
 
-//go:generate mockgen -destination=./mocks.go -package networking github.com/digitalocean/godo  CertificatesService,DomainsService,FirewallsService,LoadBalancersService,PartnerAttachmentService,ReservedIPsService,ReservedIPV6sService,ReservedIPActionsService,ReservedIPV6ActionsService,VPCsService,BYOIPPrefixesService
+//go:generate mockgen -destination=./mocks.go -package networking github.com/digitalocean/godo  CertificatesService,DomainsService,FirewallsService,LoadBalancersService,PartnerAttachmentService,ReservedIPsService,ReservedIPV6sService,ReservedIPActionsService,ReservedIPV6ActionsService,VPCsService,BYOIPPrefixesService,DropletsService,ActionsService,RegionsService
@@ -0,0 +1,151 @@
+package networking
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-digitalocean/pkg/registry/common"
+)
+
+// lbHealthConcurrency bounds how many droplets lb-health fetches at once.
+const lbHealthConcurrency = 5
+
+// DropletHealth is the status of one droplet behind a load balancer. Error is
+// set, and Status is empty, when the droplet could not be fetched.
+type DropletHealth struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name,omitempty"`
+	Status  string `json:"status,omitempty"`
+	Healthy bool   `json:"healthy"`
+	Error   string `json:"error,omitempty"`
+}
+
+// LoadBalancerHealth is the result of lb-health. The API does not report the
+// load balancer's own health check results per droplet, so each droplet's
+// status stands in for them: a droplet is healthy when it is active. Healthy
+// is true when the load balancer and every droplet are active.
+type LoadBalancerHealth struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Status      string            `json:"status"`
+	Healthy     bool              `json:"healthy"`
+	Summary     string            `json:"summary"`
+	HealthCheck *godo.HealthCheck `json:"health_check,omitempty"`
+	Tag         string            `json:"tag,omitempty"`
+	Unhealthy   int               `json:"unhealthy"`
+	Unknown     int               `json:"unknown"`
+	Droplets    []DropletHealth   `json:"droplets"`
+}
+
+// getHealth fetches a load balancer and the status of each of its droplets,
+// so one call answers whether anything behind it is unhealthy.
+func (l *LoadBalancersTool) getHealth(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	lbID, ok := req.GetArguments()["LoadBalancerID"].(string)
+	if !ok || lbID == "" {
+		return mcp.NewToolResultError("LoadBalancer ID is required"), nil
+	}
+
+	client, err := l.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	lb, _, err := client.LoadBalancers.Get(ctx, lbID)
+	if err != nil {
+		return common.GetErrorResult(ctx, l.Logger(ctx), "api error", "load balancer", lbID, err), nil
+	}
+
+	result := LoadBalancerHealth{
+		ID:          lb.ID,
+		Name:        lb.Name,
+		Status:      lb.Status,
+		HealthCheck: lb.HealthCheck,
+		Tag:         lb.Tag,
+		Droplets:    dropletHealth(ctx, client, lb.DropletIDs),
+	}
+	for _, droplet := range result.Droplets {
+		switch {
+		case droplet.Error != "":
+			result.Unknown++
+		case !droplet.Healthy:
+			result.Unhealthy++
+		}
+	}
+	result.Healthy = lb.Status == "active" && result.Unhealthy == 0 && result.Unknown == 0
+	result.Summary = healthSummary(result)
+
+	jsonResult, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(string(jsonResult)), nil
+}
+
+// dropletHealth fetches each droplet, at most lbHealthConcurrency at a time,
+// and returns their health in the order of ids. A droplet that cannot be
+// fetched is reported with its error rather than failing the whole call.
+func dropletHealth(ctx context.Context, client *godo.Client, ids []int) []DropletHealth {
+	health := make([]DropletHealth, len(ids))
+	sem := make(chan struct{}, lbHealthConcurrency)
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			h := DropletHealth{ID: id}
+			droplet, _, err := client.Droplets.Get(ctx, id)
+			if err != nil {
+				h.Error = err.Error()
+			} else {
+				h.Name = droplet.Name
+				h.Status = droplet.Status
+				h.Healthy = droplet.Status == "active"
+			}
+			health[i] = h
+		}()
+	}
+	wg.Wait()
+	return health
+}
+
+// healthSummary describes the result in one sentence, e.g. "load balancer is
+// active; 1 of 3 droplets unhealthy (web-2 is off)".
+func healthSummary(h LoadBalancerHealth) string {
+	summary := fmt.Sprintf("load balancer is %s", h.Status)
+	if len(h.Droplets) == 0 {
+		return summary + "; it has no droplets"
+	}
+	if h.Unhealthy == 0 && h.Unknown == 0 {
+		return fmt.Sprintf("%s; all %d droplets are active", summary, len(h.Droplets))
+	}
+	var parts, details []string
+	if h.Unhealthy > 0 {
+		parts = append(parts, fmt.Sprintf("%d of %d droplets unhealthy", h.Unhealthy, len(h.Droplets)))
+	}
+	if h.Unknown > 0 {
+		parts = append(parts, fmt.Sprintf("%d could not be checked", h.Unknown))
+	}
+	for _, droplet := range h.Droplets {
+		if droplet.Error == "" && !droplet.Healthy {
+			name := droplet.Name
+			if name == "" {
+				name = fmt.Sprint(droplet.ID)
+			}
+			details = append(details, fmt.Sprintf("%s is %s", name, droplet.Status))
+		}
+	}
+	summary += "; " + strings.Join(parts, ", ")
+	if len(details) > 0 {
+		summary += " (" + strings.Join(details, ", ") + ")"
+	}
+	return summary
+}
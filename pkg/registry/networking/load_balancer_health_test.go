@@ -0,0 +1,159 @@
+package networking
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func setupLoadBalancerHealthTool(loadBalancers *MockLoadBalancersService, droplets *MockDropletsService) *LoadBalancersTool {
+	client := func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{LoadBalancers: loadBalancers, Droplets: droplets}, nil
+	}
+	return NewLoadBalancersTool(client)
+}
+
+func TestLoadBalancersTool_getHealth(t *testing.T) {
+	healthCheck := &godo.HealthCheck{Protocol: "http", Port: 80, Path: "/healthz"}
+	tests := []struct {
+		name        string
+		lb          *godo.LoadBalancer
+		droplets    map[int]*godo.Droplet
+		expected    LoadBalancerHealth
+		expectError bool
+	}{
+		{
+			name: "all droplets active",
+			lb:   &godo.LoadBalancer{ID: "lb-1", Name: "web", Status: "active", HealthCheck: healthCheck, DropletIDs: []int{1, 2}},
+			droplets: map[int]*godo.Droplet{
+				1: {ID: 1, Name: "web-1", Status: "active"},
+				2: {ID: 2, Name: "web-2", Status: "active"},
+			},
+			expected: LoadBalancerHealth{
+				ID: "lb-1", Name: "web", Status: "active", Healthy: true, HealthCheck: healthCheck,
+				Summary: "load balancer is active; all 2 droplets are active",
+				Droplets: []DropletHealth{
+					{ID: 1, Name: "web-1", Status: "active", Healthy: true},
+					{ID: 2, Name: "web-2", Status: "active", Healthy: true},
+				},
+			},
+		},
+		{
+			name: "unhealthy droplet and partial failure",
+			lb:   &godo.LoadBalancer{ID: "lb-1", Name: "web", Status: "active", Tag: "web", DropletIDs: []int{1, 2, 3}},
+			droplets: map[int]*godo.Droplet{
+				1: {ID: 1, Name: "web-1", Status: "active"},
+				2: {ID: 2, Name: "web-2", Status: "off"},
+				3: nil,
+			},
+			expected: LoadBalancerHealth{
+				ID: "lb-1", Name: "web", Status: "active", Tag: "web", Unhealthy: 1, Unknown: 1,
+				Summary: "load balancer is active; 1 of 3 droplets unhealthy, 1 could not be checked (web-2 is off)",
+				Droplets: []DropletHealth{
+					{ID: 1, Name: "web-1", Status: "active", Healthy: true},
+					{ID: 2, Name: "web-2", Status: "off"},
+					{ID: 3, Error: "droplet 3 not found"},
+				},
+			},
+		},
+		{
+			name: "load balancer not active",
+			lb:   &godo.LoadBalancer{ID: "lb-1", Name: "web", Status: "new"},
+			expected: LoadBalancerHealth{
+				ID: "lb-1", Name: "web", Status: "new",
+				Summary:  "load balancer is new; it has no droplets",
+				Droplets: []DropletHealth{},
+			},
+		},
+		{
+			name:        "load balancer get error",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockLB := NewMockLoadBalancersService(ctrl)
+			mockDroplets := NewMockDropletsService(ctrl)
+			if tc.lb == nil {
+				mockLB.EXPECT().Get(gomock.Any(), "lb-1").Return(nil, nil, errors.New("not found"))
+			} else {
+				mockLB.EXPECT().Get(gomock.Any(), "lb-1").Return(tc.lb, nil, nil)
+			}
+			for id, droplet := range tc.droplets {
+				if droplet == nil {
+					mockDroplets.EXPECT().Get(gomock.Any(), id).Return(nil, nil, errors.New("droplet 3 not found"))
+					continue
+				}
+				mockDroplets.EXPECT().Get(gomock.Any(), id).Return(droplet, nil, nil)
+			}
+
+			tool := setupLoadBalancerHealthTool(mockLB, mockDroplets)
+			resp, err := tool.getHealth(context.Background(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Arguments: map[string]any{"LoadBalancerID": "lb-1"}},
+			})
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, resp.IsError)
+				return
+			}
+			require.False(t, resp.IsError)
+			var result LoadBalancerHealth
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &result))
+			require.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestLoadBalancersTool_getHealthBoundsConcurrency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockLB := NewMockLoadBalancersService(ctrl)
+	mockDroplets := NewMockDropletsService(ctrl)
+
+	ids := make([]int, 12)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+	mockLB.EXPECT().Get(gomock.Any(), "lb-1").Return(&godo.LoadBalancer{ID: "lb-1", Status: "active", DropletIDs: ids}, nil, nil)
+
+	var inFlight, maxInFlight atomic.Int32
+	mockDroplets.EXPECT().Get(gomock.Any(), gomock.Any()).Times(len(ids)).DoAndReturn(
+		func(ctx context.Context, id int) (*godo.Droplet, *godo.Response, error) {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				m := maxInFlight.Load()
+				if n <= m || maxInFlight.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			return &godo.Droplet{ID: id, Status: "active"}, nil, nil
+		})
+
+	tool := setupLoadBalancerHealthTool(mockLB, mockDroplets)
+	resp, err := tool.getHealth(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"LoadBalancerID": "lb-1"}},
+	})
+	require.NoError(t, err)
+	require.False(t, resp.IsError)
+
+	var result LoadBalancerHealth
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &result))
+	require.True(t, result.Healthy)
+	require.Len(t, result.Droplets, len(ids))
+	for i, droplet := range result.Droplets {
+		require.Equal(t, ids[i], droplet.ID, "droplets keep the load balancer's order")
+	}
+	require.LessOrEqual(t, maxInFlight.Load(), int32(lbHealthConcurrency))
+	require.Greater(t, maxInFlight.Load(), int32(1), "droplets are fetched concurrently")
+}
@@ -0,0 +1,130 @@
+package networking
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	middleware "mcp-digitalocean/internal"
+	"mcp-digitalocean/pkg/registry/common"
+)
+
+// defaultRegionSlugTTL is how long a token's region slugs are reused before
+// the regions are listed again.
+const defaultRegionSlugTTL = 10 * time.Minute
+
+// regionCache holds the region slugs of each token, so load balancer creates
+// and updates can check the regions they name without listing the regions
+// every time. The API accepts an unknown slug in RegionPriorities and creates a
+// global load balancer that routes nothing, so slugs are checked up front.
+type regionCache struct {
+	ttl  time.Duration
+	now  func() time.Time
+	list func(ctx context.Context, client *godo.Client) ([]godo.Region, error)
+
+	mu      sync.Mutex
+	regions map[string]regionSlugs
+}
+
+// regionSlugs are the sorted slugs of the regions a token listed.
+type regionSlugs struct {
+	slugs   []string
+	expires time.Time
+}
+
+func newRegionCache(ttl time.Duration) *regionCache {
+	return &regionCache{
+		ttl:     ttl,
+		now:     time.Now,
+		list:    listRegions,
+		regions: make(map[string]regionSlugs),
+	}
+}
+
+func listRegions(ctx context.Context, client *godo.Client) ([]godo.Region, error) {
+	return common.FetchAll(ctx, common.MaxPerPage, client.Regions.List)
+}
+
+// slugs returns the sorted region slugs of the token in ctx, listing the
+// regions when they are not cached or the cached slugs have expired.
+func (c *regionCache) slugs(ctx context.Context, client *godo.Client) ([]string, error) {
+	key := middleware.AuthFingerprint(ctx)
+
+	c.mu.Lock()
+	cached, ok := c.regions[key]
+	c.mu.Unlock()
+	if ok && c.now().Before(cached.expires) {
+		return cached.slugs, nil
+	}
+
+	regions, err := c.list(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	cached = regionSlugs{expires: c.now().Add(c.ttl)}
+	for _, r := range regions {
+		cached.slugs = append(cached.slugs, r.Slug)
+	}
+	slices.Sort(cached.slugs)
+
+	c.mu.Lock()
+	for k, r := range c.regions {
+		if !c.now().Before(r.expires) {
+			delete(c.regions, k)
+		}
+	}
+	c.regions[key] = cached
+	c.mu.Unlock()
+	return cached.slugs, nil
+}
+
+// checkRegions checks the region of a regional load balancer and the
+// RegionPriorities keys of a global one against the region slugs. It returns
+// an error result naming the unknown slugs and the valid ones. When the
+// regions cannot be listed, the slugs are not checked and a warning saying so
+// is returned instead.
+func (l *LoadBalancersTool) checkRegions(ctx context.Context, client *godo.Client, lbr *godo.LoadBalancerRequest) (*mcp.CallToolResult, string) {
+	named := map[string]string{}
+	if lbr.Region != "" {
+		named[lbr.Region] = "Region"
+	}
+	if lbr.GLBSettings != nil {
+		for slug := range lbr.GLBSettings.RegionPriorities {
+			named[slug] = "GLBSettings.RegionPriorities"
+		}
+	}
+	if len(named) == 0 {
+		return nil, ""
+	}
+
+	valid, err := l.regions.slugs(ctx, client)
+	if err != nil {
+		return nil, fmt.Sprintf("region slugs were not checked because the regions could not be listed: %v", err)
+	}
+
+	var unknown []string
+	for slug, arg := range named {
+		if _, found := slices.BinarySearch(valid, slug); !found {
+			unknown = append(unknown, fmt.Sprintf("%q in %s", slug, arg))
+		}
+	}
+	if len(unknown) == 0 {
+		return nil, ""
+	}
+	slices.Sort(unknown)
+	return mcp.NewToolResultError(fmt.Sprintf("unknown region slugs, nothing was changed: %s. Valid regions are %s",
+		strings.Join(unknown, ", "), strings.Join(valid, ", "))), ""
+}
+
+// withWarning adds a non-empty warning to result as another text content item.
+func withWarning(result *mcp.CallToolResult, warning string) *mcp.CallToolResult {
+	if warning != "" {
+		result.Content = append(result.Content, mcp.NewTextContent("warning: "+warning))
+	}
+	return result
+}
@@ -0,0 +1,137 @@
+package networking
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func setupRegionCheckWithMocks(loadBalancers *MockLoadBalancersService, regions *MockRegionsService) *LoadBalancersTool {
+	client := func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{LoadBalancers: loadBalancers, Regions: regions}, nil
+	}
+	return NewLoadBalancersTool(client)
+}
+
+func globalLBArgs(priorities map[string]any) map[string]any {
+	return map[string]any{
+		"Name": "glb",
+		"Type": "GLOBAL",
+		"GLBSettings": map[string]any{
+			"TargetProtocol":   "http",
+			"TargetPort":       float64(80),
+			"RegionPriorities": priorities,
+		},
+	}
+}
+
+func TestLoadBalancersTool_checkRegions(t *testing.T) {
+	regions := []godo.Region{{Slug: "nyc1"}, {Slug: "nyc3"}, {Slug: "sfo3"}}
+	tests := []struct {
+		name        string
+		create      bool
+		args        map[string]any
+		mockSetup   func(*MockLoadBalancersService, *MockRegionsService)
+		expectError string
+		expectWarn  string
+	}{
+		{
+			name:   "Unknown RegionPriorities slug",
+			create: true,
+			args:   globalLBArgs(map[string]any{"nyc3": float64(1), "nyc9": float64(2)}),
+			mockSetup: func(lbs *MockLoadBalancersService, r *MockRegionsService) {
+				r.EXPECT().List(gomock.Any(), gomock.Any()).Return(regions, &godo.Response{}, nil).Times(1)
+			},
+			expectError: `unknown region slugs, nothing was changed: "nyc9" in GLBSettings.RegionPriorities. Valid regions are nyc1, nyc3, sfo3`,
+		},
+		{
+			name: "Unknown regional Region on update",
+			args: map[string]any{"LoadBalancerID": "12345", "Name": "lb", "Type": "REGIONAL", "Region": "ny3"},
+			mockSetup: func(lbs *MockLoadBalancersService, r *MockRegionsService) {
+				r.EXPECT().List(gomock.Any(), gomock.Any()).Return(regions, &godo.Response{}, nil).Times(1)
+			},
+			expectError: `"ny3" in Region`,
+		},
+		{
+			name:   "Known slugs are created",
+			create: true,
+			args:   globalLBArgs(map[string]any{"nyc3": float64(1), "sfo3": float64(2)}),
+			mockSetup: func(lbs *MockLoadBalancersService, r *MockRegionsService) {
+				r.EXPECT().List(gomock.Any(), gomock.Any()).Return(regions, &godo.Response{}, nil).Times(1)
+				lbs.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&godo.LoadBalancer{ID: "glb-1"}, nil, nil).Times(1)
+			},
+		},
+		{
+			name:   "Region list failure skips the check with a warning",
+			create: true,
+			args:   globalLBArgs(map[string]any{"nyc9": float64(1)}),
+			mockSetup: func(lbs *MockLoadBalancersService, r *MockRegionsService) {
+				r.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil, errors.New("503 service unavailable")).Times(1)
+				lbs.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&godo.LoadBalancer{ID: "glb-1"}, nil, nil).Times(1)
+			},
+			expectWarn: "warning: region slugs were not checked because the regions could not be listed: 503 service unavailable",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockLBs := NewMockLoadBalancersService(ctrl)
+			mockRegions := NewMockRegionsService(ctrl)
+			tc.mockSetup(mockLBs, mockRegions)
+			tool := setupRegionCheckWithMocks(mockLBs, mockRegions)
+
+			handler := tool.updateLoadBalancer
+			if tc.create {
+				handler = tool.createLoadBalancer
+			}
+			resp, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			text := resp.Content[0].(mcp.TextContent).Text
+			if tc.expectError != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, text, tc.expectError)
+				return
+			}
+			require.False(t, resp.IsError, text)
+			last := resp.Content[len(resp.Content)-1].(mcp.TextContent).Text
+			if tc.expectWarn != "" {
+				require.Equal(t, tc.expectWarn, last)
+			} else {
+				require.NotContains(t, last, "warning:")
+			}
+		})
+	}
+}
+
+func TestRegionCache_slugs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRegions := NewMockRegionsService(ctrl)
+	client := &godo.Client{Regions: mockRegions}
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	cache := newRegionCache(defaultRegionSlugTTL)
+	cache.now = func() time.Time { return now }
+
+	mockRegions.EXPECT().List(gomock.Any(), gomock.Any()).Return([]godo.Region{{Slug: "sfo3"}, {Slug: "ams3"}}, &godo.Response{}, nil).Times(1)
+	for range 2 {
+		slugs, err := cache.slugs(context.Background(), client)
+		require.NoError(t, err)
+		require.Equal(t, []string{"ams3", "sfo3"}, slugs)
+	}
+
+	// the slugs are listed again once they expire, and a failure is not cached.
+	now = now.Add(defaultRegionSlugTTL)
+	mockRegions.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil, errors.New("timeout")).Times(1)
+	_, err := cache.slugs(context.Background(), client)
+	require.Error(t, err)
+	mockRegions.EXPECT().List(gomock.Any(), gomock.Any()).Return([]godo.Region{{Slug: "nyc3"}}, &godo.Response{}, nil).Times(1)
+	slugs, err := cache.slugs(context.Background(), client)
+	require.NoError(t, err)
+	require.Equal(t, []string{"nyc3"}, slugs)
+}
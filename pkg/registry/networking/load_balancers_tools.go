@@ -4,27 +4,100 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"mcp-digitalocean/pkg/registry/common"
+	"mcp-digitalocean/pkg/registry/internal/enums"
 )
 
 // LoadBalancersTool provides load balancer management tools
 type LoadBalancersTool struct {
-	client func(ctx context.Context) (*godo.Client, error)
+	common.ToolLogger
+
+	client  func(ctx context.Context) (*godo.Client, error)
+	locks   *common.ResourceLocks
+	regions *regionCache
 }
 
 // NewLoadBalancersTool creates a new LoadBalancersTool
 func NewLoadBalancersTool(client func(ctx context.Context) (*godo.Client, error)) *LoadBalancersTool {
 	return &LoadBalancersTool{
-		client: client,
+		client:  client,
+		locks:   common.SharedResourceLocks,
+		regions: newRegionCache(defaultRegionSlugTTL),
+	}
+}
+
+// lbURN keys load balancer mutations for the resource locks.
+var lbURN = common.URNFromArg("loadbalancer", "LoadBalancerID")
+
+var (
+	entryProtocols       = []string{"http", "https", "http2", "http3", "tcp", "udp"}
+	targetProtocols      = []string{"http", "https", "http2", "tcp", "udp"}
+	healthCheckProtocols = []string{"http", "https", "tcp"}
+	stickySessionTypes   = []string{"none", "cookies"}
+)
+
+// forwardingRuleError returns why a forwarding rule would be rejected by the
+// API, or an empty string if it is valid. The API reports most of these with a
+// generic error, so they are checked up front.
+func forwardingRuleError(rule godo.ForwardingRule) string {
+	if !slices.Contains(entryProtocols, rule.EntryProtocol) {
+		return fmt.Sprintf("EntryProtocol %q is not supported; use one of %s", rule.EntryProtocol, strings.Join(entryProtocols, ", "))
+	}
+	if !slices.Contains(targetProtocols, rule.TargetProtocol) {
+		return fmt.Sprintf("TargetProtocol %q is not supported; use one of %s", rule.TargetProtocol, strings.Join(targetProtocols, ", "))
+	}
+
+	switch rule.EntryProtocol {
+	case "tcp", "udp":
+		if rule.TargetProtocol != rule.EntryProtocol {
+			return fmt.Sprintf("%s entry requires a %s target, got %s", rule.EntryProtocol, rule.EntryProtocol, rule.TargetProtocol)
+		}
+	case "http3":
+		if rule.TargetProtocol != "http" && rule.TargetProtocol != "https" {
+			return fmt.Sprintf("http3 entry requires an http or https target, got %s", rule.TargetProtocol)
+		}
+		if rule.TlsPassthrough {
+			return "http3 entry does not support TlsPassthrough"
+		}
+		if rule.CertificateID == "" {
+			return "http3 entry requires a CertificateID"
+		}
+	default:
+		if rule.TargetProtocol != "http" && rule.TargetProtocol != "https" && rule.TargetProtocol != "http2" {
+			return fmt.Sprintf("%s entry requires an http, https or http2 target, got %s", rule.EntryProtocol, rule.TargetProtocol)
+		}
+	}
+
+	if rule.TlsPassthrough {
+		if rule.EntryProtocol != "https" && rule.EntryProtocol != "http2" {
+			return fmt.Sprintf("TlsPassthrough is only supported for https and http2 entry, got %s", rule.EntryProtocol)
+		}
+		if rule.TargetProtocol != "https" {
+			return fmt.Sprintf("TlsPassthrough requires an https target, got %s", rule.TargetProtocol)
+		}
+		if rule.CertificateID != "" {
+			return "TlsPassthrough and CertificateID cannot be used together"
+		}
+	} else if (rule.EntryProtocol == "https" || rule.EntryProtocol == "http2") && rule.CertificateID == "" {
+		return fmt.Sprintf("%s entry requires a CertificateID or TlsPassthrough", rule.EntryProtocol)
 	}
+	return ""
 }
 
 func parseForwardingRules(rules []any) ([]godo.ForwardingRule, *mcp.CallToolResult) {
 	forwardingRules := []godo.ForwardingRule{}
-	for _, ruleData := range rules {
+	// entries maps an entry port and protocol to the index of the rule using it.
+	entries := map[string]int{}
+	for i, ruleData := range rules {
 		rule, ok := ruleData.(map[string]any)
 		if !ok {
 			return nil, mcp.NewToolResultError("invalid rule format")
@@ -58,18 +131,291 @@ func parseForwardingRules(rules []any) ([]godo.ForwardingRule, *mcp.CallToolResu
 		}
 
 		forwardingRule := godo.ForwardingRule{
-			EntryProtocol:  entryProtocol,
+			EntryProtocol:  strings.ToLower(entryProtocol),
 			EntryPort:      int(entryPort),
-			TargetProtocol: targetProtocol,
+			TargetProtocol: strings.ToLower(targetProtocol),
 			TargetPort:     int(targetPort),
 			TlsPassthrough: tlsPassthrough,
 			CertificateID:  certificateID,
 		}
+		if msg := forwardingRuleError(forwardingRule); msg != "" {
+			return nil, mcp.NewToolResultError(fmt.Sprintf("ForwardingRules[%d]: %s", i, msg))
+		}
+		entry := fmt.Sprintf("%d/%s", forwardingRule.EntryPort, forwardingRule.EntryProtocol)
+		if first, ok := entries[entry]; ok {
+			return nil, mcp.NewToolResultError(fmt.Sprintf("ForwardingRules[%d] and ForwardingRules[%d] both use entry port %s", first, i, entry))
+		}
+		entries[entry] = i
 		forwardingRules = append(forwardingRules, forwardingRule)
 	}
 	return forwardingRules, nil
 }
 
+// intSetting reads an optional whole number from settings[key], returning why
+// it is invalid, naming it label, when it is not a number or lies outside
+// [lo, hi].
+func intSetting(settings map[string]any, key, label string, lo, hi int) (int, string) {
+	raw, ok := settings[key]
+	if !ok || raw == nil {
+		return 0, ""
+	}
+	v, ok := raw.(float64)
+	if !ok || v != float64(int(v)) {
+		return 0, fmt.Sprintf("%s must be a whole number", label)
+	}
+	if int(v) < lo || int(v) > hi {
+		return 0, fmt.Sprintf("%s must be between %d and %d, got %d", label, lo, hi, int(v))
+	}
+	return int(v), ""
+}
+
+// parseHealthCheck parses the HealthCheck argument. The API fills in defaults
+// for fields that are left out.
+func parseHealthCheck(raw any) (*godo.HealthCheck, string) {
+	settings, ok := raw.(map[string]any)
+	if !ok {
+		return nil, "HealthCheck must be an object"
+	}
+	hc := &godo.HealthCheck{}
+	if v, ok := settings["Protocol"]; ok && v != nil {
+		protocol, ok := v.(string)
+		if !ok || !slices.Contains(healthCheckProtocols, strings.ToLower(protocol)) {
+			return nil, fmt.Sprintf("HealthCheck.Protocol must be one of %s, got %v", strings.Join(healthCheckProtocols, ", "), v)
+		}
+		hc.Protocol = strings.ToLower(protocol)
+	}
+	if v, ok := settings["Path"]; ok && v != nil {
+		path, ok := v.(string)
+		if !ok || !strings.HasPrefix(path, "/") {
+			return nil, fmt.Sprintf("HealthCheck.Path must be a string starting with /, got %v", v)
+		}
+		if hc.Protocol == "tcp" {
+			return nil, "HealthCheck.Path is only used by http and https health checks"
+		}
+		hc.Path = path
+	}
+	for _, field := range []struct {
+		key    string
+		lo, hi int
+		dst    *int
+	}{
+		{"Port", 1, 65535, &hc.Port},
+		{"CheckIntervalSeconds", 3, 300, &hc.CheckIntervalSeconds},
+		{"ResponseTimeoutSeconds", 3, 300, &hc.ResponseTimeoutSeconds},
+		{"HealthyThreshold", 2, 10, &hc.HealthyThreshold},
+		{"UnhealthyThreshold", 2, 10, &hc.UnhealthyThreshold},
+	} {
+		v, msg := intSetting(settings, field.key, "HealthCheck."+field.key, field.lo, field.hi)
+		if msg != "" {
+			return nil, msg
+		}
+		*field.dst = v
+	}
+	if v, ok := settings["ProxyProtocol"]; ok && v != nil {
+		proxyProtocol, ok := v.(bool)
+		if !ok {
+			return nil, "HealthCheck.ProxyProtocol must be a boolean"
+		}
+		hc.ProxyProtocol = &proxyProtocol
+	}
+	return hc, ""
+}
+
+// parseStickySessions parses the StickySessions argument. Cookie sessions
+// need a cookie name and lifetime; the API rejects them otherwise.
+func parseStickySessions(raw any) (*godo.StickySessions, string) {
+	settings, ok := raw.(map[string]any)
+	if !ok {
+		return nil, "StickySessions must be an object"
+	}
+	sessionType, _ := settings["Type"].(string)
+	if !slices.Contains(stickySessionTypes, sessionType) {
+		return nil, fmt.Sprintf("StickySessions.Type must be one of %s, got %v", strings.Join(stickySessionTypes, ", "), settings["Type"])
+	}
+	ss := &godo.StickySessions{Type: sessionType}
+	cookieName, _ := settings["CookieName"].(string)
+	ttl, msg := intSetting(settings, "CookieTtlSeconds", "StickySessions.CookieTtlSeconds", 1, math.MaxInt32)
+	if msg != "" {
+		return nil, msg
+	}
+	if sessionType == "none" {
+		if cookieName != "" || ttl != 0 {
+			return nil, "StickySessions.CookieName and CookieTtlSeconds are only used with Type cookies"
+		}
+		return ss, ""
+	}
+	if cookieName == "" {
+		return nil, "StickySessions.CookieName is required when Type is cookies"
+	}
+	if ttl == 0 {
+		return nil, "StickySessions.CookieTtlSeconds is required when Type is cookies"
+	}
+	ss.CookieName = cookieName
+	ss.CookieTtlSeconds = ttl
+	return ss, ""
+}
+
+// firewallRuleError returns why a load balancer firewall rule would be
+// rejected, or an empty string if it is valid. Rules are "ip:<address>" or
+// "cidr:<block>", as built by godo.IPSourceFirewall and CIDRSourceFirewall.
+func firewallRuleError(rule string) string {
+	kind, source, ok := strings.Cut(rule, ":")
+	switch {
+	case ok && kind == "ip":
+		if net.ParseIP(source) == nil {
+			return fmt.Sprintf("%q is not a valid IP address", source)
+		}
+	case ok && kind == "cidr":
+		if _, _, err := net.ParseCIDR(source); err != nil {
+			return fmt.Sprintf("%q is not a valid CIDR block", source)
+		}
+	default:
+		return fmt.Sprintf("%q must start with ip: or cidr:, e.g. ip:203.0.113.5 or cidr:1.2.3.0/24", rule)
+	}
+	return ""
+}
+
+// parseFirewall parses the Firewall argument, an object with Allow and Deny
+// lists of firewall rules.
+func parseFirewall(raw any) (*godo.LBFirewall, string) {
+	settings, ok := raw.(map[string]any)
+	if !ok {
+		return nil, "Firewall must be an object with Allow and Deny lists"
+	}
+	firewall := &godo.LBFirewall{}
+	for _, list := range []struct {
+		key string
+		dst *[]string
+	}{
+		{"Allow", &firewall.Allow},
+		{"Deny", &firewall.Deny},
+	} {
+		rawRules, ok := settings[list.key]
+		if !ok || rawRules == nil {
+			continue
+		}
+		rules, ok := rawRules.([]any)
+		if !ok {
+			return nil, fmt.Sprintf("Firewall.%s must be a list of strings", list.key)
+		}
+		for i, r := range rules {
+			rule, ok := r.(string)
+			if !ok {
+				return nil, fmt.Sprintf("Firewall.%s[%d] must be a string", list.key, i)
+			}
+			if msg := firewallRuleError(rule); msg != "" {
+				return nil, fmt.Sprintf("Firewall.%s[%d]: %s", list.key, i, msg)
+			}
+			*list.dst = append(*list.dst, rule)
+		}
+	}
+	return firewall, ""
+}
+
+// parseLoadBalancerSettings sets the health check, sticky session, firewall
+// and connection settings shared by lb-create and lb-update on lbr.
+func parseLoadBalancerSettings(args map[string]any, lbr *godo.LoadBalancerRequest) *mcp.CallToolResult {
+	if raw, ok := args["HealthCheck"]; ok && raw != nil {
+		hc, msg := parseHealthCheck(raw)
+		if msg != "" {
+			return mcp.NewToolResultError(msg)
+		}
+		lbr.HealthCheck = hc
+	}
+	if raw, ok := args["StickySessions"]; ok && raw != nil {
+		ss, msg := parseStickySessions(raw)
+		if msg != "" {
+			return mcp.NewToolResultError(msg)
+		}
+		lbr.StickySessions = ss
+	}
+	if raw, ok := args["Firewall"]; ok && raw != nil {
+		firewall, msg := parseFirewall(raw)
+		if msg != "" {
+			return mcp.NewToolResultError(msg)
+		}
+		lbr.Firewall = firewall
+	}
+
+	for _, flag := range []struct {
+		key string
+		dst *bool
+	}{
+		{"RedirectHttpToHttps", &lbr.RedirectHttpToHttps},
+		{"EnableProxyProtocol", &lbr.EnableProxyProtocol},
+		{"EnableBackendKeepalive", &lbr.EnableBackendKeepalive},
+	} {
+		if raw, ok := args[flag.key]; ok && raw != nil {
+			v, ok := raw.(bool)
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("%s must be a boolean", flag.key))
+			}
+			*flag.dst = v
+		}
+	}
+	if raw, ok := args["DisableLetsEncryptDNSRecords"]; ok && raw != nil {
+		v, ok := raw.(bool)
+		if !ok {
+			return mcp.NewToolResultError("DisableLetsEncryptDNSRecords must be a boolean")
+		}
+		lbr.DisableLetsEncryptDNSRecords = &v
+	}
+
+	timeout, msg := intSetting(args, "HTTPIdleTimeoutSeconds", "HTTPIdleTimeoutSeconds", 30, 600)
+	if msg != "" {
+		return mcp.NewToolResultError(msg)
+	}
+	if timeout != 0 {
+		idle := uint64(timeout)
+		lbr.HTTPIdleTimeoutSeconds = &idle
+	}
+	return nil
+}
+
+// withLoadBalancerSettings registers the arguments read by
+// parseLoadBalancerSettings.
+func withLoadBalancerSettings() mcp.ToolOption {
+	return func(t *mcp.Tool) {
+		for _, opt := range []mcp.ToolOption{
+			mcp.WithObject("HealthCheck",
+				mcp.Description("Health check the load balancer runs against its Droplets; fields left out use the API defaults"),
+				mcp.Properties(map[string]any{
+					"Protocol":               map[string]any{"type": "string", "enum": healthCheckProtocols},
+					"Port":                   map[string]any{"type": "number", "description": "Droplet port to check (1-65535)"},
+					"Path":                   map[string]any{"type": "string", "description": "Path to request for http and https checks, e.g. /healthz"},
+					"CheckIntervalSeconds":   map[string]any{"type": "number", "description": "Seconds between checks (3-300)"},
+					"ResponseTimeoutSeconds": map[string]any{"type": "number", "description": "Seconds to wait for a response (3-300)"},
+					"HealthyThreshold":       map[string]any{"type": "number", "description": "Passing checks before a Droplet is healthy (2-10)"},
+					"UnhealthyThreshold":     map[string]any{"type": "number", "description": "Failing checks before a Droplet is unhealthy (2-10)"},
+					"ProxyProtocol":          map[string]any{"type": "boolean", "description": "Send health checks using the PROXY protocol"},
+				}),
+			),
+			mcp.WithObject("StickySessions",
+				mcp.Description("Send a client's requests to the same Droplet"),
+				mcp.Properties(map[string]any{
+					"Type":             map[string]any{"type": "string", "enum": stickySessionTypes},
+					"CookieName":       map[string]any{"type": "string", "description": "Cookie name, required when Type is cookies"},
+					"CookieTtlSeconds": map[string]any{"type": "number", "description": "Cookie lifetime in seconds, required when Type is cookies"},
+				}),
+			),
+			mcp.WithObject("Firewall",
+				mcp.Description("Sources allowed or denied access to the load balancer"),
+				mcp.Properties(map[string]any{
+					"Allow": map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": "Rules such as ip:203.0.113.5 or cidr:1.2.3.0/24"},
+					"Deny":  map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": "Rules such as ip:203.0.113.5 or cidr:1.2.3.0/24"},
+				}),
+			),
+			mcp.WithBoolean("RedirectHttpToHttps", mcp.Description("Redirect HTTP requests on port 80 to HTTPS on port 443")),
+			mcp.WithBoolean("EnableProxyProtocol", mcp.Description("Use the PROXY protocol to pass client information to the Droplets")),
+			mcp.WithBoolean("EnableBackendKeepalive", mcp.Description("Keep connections to the Droplets open between requests")),
+			mcp.WithNumber("HTTPIdleTimeoutSeconds", mcp.Description("Seconds an idle HTTP connection is kept open (30-600)")),
+			mcp.WithBoolean("DisableLetsEncryptDNSRecords", mcp.Description("Do not create DNS records for Let's Encrypt certificates")),
+		} {
+			opt(t)
+		}
+	}
+}
+
 func (l *LoadBalancersTool) createLoadBalancer(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	args := req.GetArguments()
 	name, ok := args["Name"].(string)
@@ -78,7 +424,17 @@ func (l *LoadBalancersTool) createLoadBalancer(ctx context.Context, req mcp.Call
 	}
 	// Optional arguments
 	lbType, _ := args["Type"].(string)
+	if lbType != "" {
+		if err := enums.LoadBalancerTypes.Validate("Type", lbType); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
 	network, _ := args["Network"].(string)
+	if network != "" {
+		if err := enums.LoadBalancerNetworkTypes.Validate("Network", network); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
 	sizeUnit, _ := args["SizeUnit"].(float64)
 	networkStack, _ := args["NetworkStack"].(string)
 	projectID, _ := args["ProjectID"].(string)
@@ -91,9 +447,12 @@ func (l *LoadBalancersTool) createLoadBalancer(ctx context.Context, req mcp.Call
 		NetworkStack: networkStack,
 		ProjectID:    projectID,
 	}
+	if errResult := parseLoadBalancerSettings(args, lbr); errResult != nil {
+		return errResult, nil
+	}
 
 	// Global load balancer arguments
-	if lbType == "GLOBAL" {
+	if lbType == godo.LoadBalancerTypeGlobal {
 		targetLoadBalancerIDs, ok := args["TargetLoadBalancerIDs"].([]string)
 		if ok && len(targetLoadBalancerIDs) > 0 {
 			lbr.TargetLoadBalancerIDs = targetLoadBalancerIDs
@@ -183,15 +542,20 @@ func (l *LoadBalancersTool) createLoadBalancer(ctx context.Context, req mcp.Call
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	lb, _, err := client.LoadBalancers.Create(ctx, lbr)
+	errResult, warning := l.checkRegions(ctx, client, lbr)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	lb, resp, err := client.LoadBalancers.Create(ctx, lbr)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, l.Logger(ctx), "api error", resp, err), nil
 	}
-	jsonLB, err := json.MarshalIndent(lb, "", "  ")
+	result, err := common.NewResourceResult(lb)
 	if err != nil {
 		return nil, fmt.Errorf("marshal error: %w", err)
 	}
-	return mcp.NewToolResultText(string(jsonLB)), nil
+	return withWarning(result, warning), nil
 }
 
 func (l *LoadBalancersTool) deleteLoadBalancer(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -202,9 +566,9 @@ func (l *LoadBalancersTool) deleteLoadBalancer(ctx context.Context, req mcp.Call
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	_, err = client.LoadBalancers.Delete(ctx, lbID)
+	resp, err := client.LoadBalancers.Delete(ctx, lbID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, l.Logger(ctx), "api error", resp, err), nil
 	}
 
 	return mcp.NewToolResultText("Load Balancer deleted successfully"), nil
@@ -218,9 +582,9 @@ func (l *LoadBalancersTool) deleteLoadBalancerCache(ctx context.Context, req mcp
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	_, err = client.LoadBalancers.PurgeCache(ctx, lbID)
+	resp, err := client.LoadBalancers.PurgeCache(ctx, lbID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, l.Logger(ctx), "api error", resp, err), nil
 	}
 
 	return mcp.NewToolResultText("Load Balancer cache deleted successfully"), nil
@@ -239,13 +603,51 @@ func (l *LoadBalancersTool) getLoadBalancer(ctx context.Context, req mcp.CallToo
 
 	lb, _, err := client.LoadBalancers.Get(ctx, lbID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.GetErrorResult(ctx, l.Logger(ctx), "api error", "load balancer", lbID, err), nil
 	}
-	jsonLB, err := json.MarshalIndent(lb, "", "  ")
+	result, err := common.NewStructuredResourceResult(lb)
 	if err != nil {
 		return nil, fmt.Errorf("marshal error: %w", err)
 	}
-	return mcp.NewToolResultText(string(jsonLB)), nil
+	return result, nil
+}
+
+// LoadBalancerFirewall is the result of lb-get-firewall. Unlike
+// godo.LBFirewall, empty rule lists are kept so "no rules" is explicit.
+type LoadBalancerFirewall struct {
+	ID    string   `json:"id"`
+	Name  string   `json:"name"`
+	Allow []string `json:"allow"`
+	Deny  []string `json:"deny"`
+}
+
+func (l *LoadBalancersTool) getFirewall(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	lbID, ok := req.GetArguments()["LoadBalancerID"].(string)
+	if !ok || lbID == "" {
+		return mcp.NewToolResultError("LoadBalancer ID is required"), nil
+	}
+
+	client, err := l.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	lb, _, err := client.LoadBalancers.Get(ctx, lbID)
+	if err != nil {
+		return common.GetErrorResult(ctx, l.Logger(ctx), "api error", "load balancer", lbID, err), nil
+	}
+	// a load balancer without rules has no firewall section at all.
+	result := LoadBalancerFirewall{ID: lb.ID, Name: lb.Name, Allow: []string{}, Deny: []string{}}
+	if lb.Firewall != nil {
+		result.Allow = append(result.Allow, lb.Firewall.Allow...)
+		result.Deny = append(result.Deny, lb.Firewall.Deny...)
+	}
+
+	jsonResult, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(string(jsonResult)), nil
 }
 
 func (l *LoadBalancersTool) listLoadBalancers(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -267,9 +669,17 @@ func (l *LoadBalancersTool) listLoadBalancers(ctx context.Context, req mcp.CallT
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	lbs, _, err := client.LoadBalancers.List(ctx, opt)
+	if common.IsAll(req.GetArguments()) {
+		lbs, truncated, err := common.FetchForAll(ctx, client.LoadBalancers.List)
+		if err != nil {
+			return common.APIErrorResult(ctx, l.Logger(ctx), "api error", nil, err), nil
+		}
+		return common.NewAllResult(lbs, truncated)
+	}
+
+	lbs, resp, err := client.LoadBalancers.List(ctx, opt)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, l.Logger(ctx), "api error", resp, err), nil
 	}
 	jsonLBs, err := json.MarshalIndent(lbs, "", "  ")
 	if err != nil {
@@ -278,6 +688,38 @@ func (l *LoadBalancersTool) listLoadBalancers(ctx context.Context, req mcp.CallT
 	return mcp.NewToolResultText(string(jsonLBs)), nil
 }
 
+// dropletDelta splits the requested droplet ids into those that need the API
+// call, because they are not yet attached (attach) or are still attached
+// (!attach), and those that are already in the requested state. The API
+// rejects adding an attached droplet, so callers only send changed.
+func dropletDelta(ids, attached []int, attach bool) (changed, skipped []int) {
+	changed, skipped = []int{}, []int{}
+	for _, id := range ids {
+		if slices.Contains(changed, id) || slices.Contains(skipped, id) {
+			continue
+		}
+		if slices.Contains(attached, id) == attach {
+			skipped = append(skipped, id)
+		} else {
+			changed = append(changed, id)
+		}
+	}
+	return changed, skipped
+}
+
+// dropletDeltaSummary formats a delta as e.g. "added: [1, 2], skipped: [3]".
+func dropletDeltaSummary(change string, changed, skipped []int) string {
+	return fmt.Sprintf("%s: %s, skipped: %s", change, formatIDs(changed), formatIDs(skipped))
+}
+
+func formatIDs(ids []int) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.Itoa(id)
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
 func (l *LoadBalancersTool) addDroplets(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	lbID, ok := req.GetArguments()["LoadBalancerID"].(string)
 	if !ok || lbID == "" {
@@ -299,12 +741,21 @@ func (l *LoadBalancersTool) addDroplets(ctx context.Context, req mcp.CallToolReq
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	_, err = client.LoadBalancers.AddDroplets(ctx, lbID, dIDs...)
+	lb, resp, err := client.LoadBalancers.Get(ctx, lbID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, l.Logger(ctx), "api error", resp, err), nil
+	}
+	added, skipped := dropletDelta(dIDs, lb.DropletIDs, true)
+	if len(added) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No droplets added: all are already attached (%s)", dropletDeltaSummary("added", added, skipped))), nil
 	}
 
-	return mcp.NewToolResultText("Droplets added successfully"), nil
+	resp, err = client.LoadBalancers.AddDroplets(ctx, lbID, added...)
+	if err != nil {
+		return common.APIErrorResult(ctx, l.Logger(ctx), "api error", resp, err), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Droplets added successfully (%s)", dropletDeltaSummary("added", added, skipped))), nil
 }
 
 func (l *LoadBalancersTool) removeDroplets(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -328,12 +779,21 @@ func (l *LoadBalancersTool) removeDroplets(ctx context.Context, req mcp.CallTool
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	_, err = client.LoadBalancers.RemoveDroplets(ctx, lbID, dIDs...)
+	lb, resp, err := client.LoadBalancers.Get(ctx, lbID)
+	if err != nil {
+		return common.APIErrorResult(ctx, l.Logger(ctx), "api error", resp, err), nil
+	}
+	removed, skipped := dropletDelta(dIDs, lb.DropletIDs, false)
+	if len(removed) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No droplets removed: all are already detached (%s)", dropletDeltaSummary("removed", removed, skipped))), nil
+	}
+
+	resp, err = client.LoadBalancers.RemoveDroplets(ctx, lbID, removed...)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, l.Logger(ctx), "api error", resp, err), nil
 	}
 
-	return mcp.NewToolResultText("Droplets removed successfully"), nil
+	return mcp.NewToolResultText(fmt.Sprintf("Droplets removed successfully (%s)", dropletDeltaSummary("removed", removed, skipped))), nil
 }
 
 func (l *LoadBalancersTool) updateLoadBalancer(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -356,9 +816,17 @@ func (l *LoadBalancersTool) updateLoadBalancer(ctx context.Context, req mcp.Call
 	if !ok || lbType == "" {
 		return mcp.NewToolResultError("Type is required"), nil
 	}
+	if err := enums.LoadBalancerTypes.Validate("Type", lbType); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	// Optional arguments
 	network, _ := args["Network"].(string)
+	if network != "" {
+		if err := enums.LoadBalancerNetworkTypes.Validate("Network", network); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
 	sizeUnit, _ := args["SizeUnit"].(float64)
 	networkStack, _ := args["NetworkStack"].(string)
 	projectID, _ := args["ProjectID"].(string)
@@ -371,8 +839,11 @@ func (l *LoadBalancersTool) updateLoadBalancer(ctx context.Context, req mcp.Call
 		NetworkStack: networkStack,
 		ProjectID:    projectID,
 	}
+	if errResult := parseLoadBalancerSettings(args, lbr); errResult != nil {
+		return errResult, nil
+	}
 
-	if lbType == "GLOBAL" {
+	if lbType == godo.LoadBalancerTypeGlobal {
 		targetLoadBalancerIDs, ok := args["TargetLoadBalancerIDs"].([]string)
 		if ok && len(targetLoadBalancerIDs) > 0 {
 			lbr.TargetLoadBalancerIDs = targetLoadBalancerIDs
@@ -457,15 +928,20 @@ func (l *LoadBalancersTool) updateLoadBalancer(ctx context.Context, req mcp.Call
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	lb, _, err := client.LoadBalancers.Update(ctx, lbID, lbr)
+	errResult, warning := l.checkRegions(ctx, client, lbr)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	lb, resp, err := client.LoadBalancers.Update(ctx, lbID, lbr)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, l.Logger(ctx), "api error", resp, err), nil
 	}
 	jsonLB, err := json.MarshalIndent(lb, "", "  ")
 	if err != nil {
 		return nil, fmt.Errorf("marshal error: %w", err)
 	}
-	return mcp.NewToolResultText(string(jsonLB)), nil
+	return withWarning(mcp.NewToolResultText(string(jsonLB)), warning), nil
 }
 
 func (l *LoadBalancersTool) addForwardingRules(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -492,9 +968,9 @@ func (l *LoadBalancersTool) addForwardingRules(ctx context.Context, req mcp.Call
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	_, err = client.LoadBalancers.AddForwardingRules(ctx, lbID, forwardingRules...)
+	resp, err := client.LoadBalancers.AddForwardingRules(ctx, lbID, forwardingRules...)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, l.Logger(ctx), "api error", resp, err), nil
 	}
 
 	return mcp.NewToolResultText("Forwarding rules added successfully"), nil
@@ -524,9 +1000,9 @@ func (l *LoadBalancersTool) removeForwardingRules(ctx context.Context, req mcp.C
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	_, err = client.LoadBalancers.RemoveForwardingRules(ctx, lbID, forwardingRules...)
+	resp, err := client.LoadBalancers.RemoveForwardingRules(ctx, lbID, forwardingRules...)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, l.Logger(ctx), "api error", resp, err), nil
 	}
 
 	return mcp.NewToolResultText("Forwarding rules removed successfully"), nil
@@ -537,23 +1013,25 @@ func (l *LoadBalancersTool) Tools() []server.ServerTool {
 		{
 			Handler: l.createLoadBalancer,
 			Tool: mcp.NewTool("lb-create",
+				common.WithCreatesResource(),
 				mcp.WithDescription("Create a new Load Balancer"),
 				mcp.WithString("Name", mcp.Required(), mcp.Description("Name of the load balancer")),
 				mcp.WithString("Region", mcp.Description("Region slug (e.g., nyc3)")),
 				mcp.WithArray("DropletIDs", mcp.Description("IDs of the Droplets assigned to the load balancer"), mcp.Items(map[string]any{"type": "string"})),
 				mcp.WithString("Tag", mcp.Description("Droplet tag corresponding to Droplets assigned to the load balancer")),
 				mcp.WithArray("ForwardingRules", mcp.Description("Forwarding rules for a load balancer"), mcp.Items(map[string]any{"type": "string"})),
-				mcp.WithString("Type", mcp.Description("Type of the load balancer (REGIONAL, REGIONAL_NETWORK, GLOBAL)")),
-				mcp.WithString("Network", mcp.Description("Network type of the load balancer (EXTERNAL, INTERNAL)")),
+				mcp.WithString("Type", mcp.Enum(enums.LoadBalancerTypes...), mcp.Description("Type of the load balancer (REGIONAL, REGIONAL_NETWORK, GLOBAL)")),
+				mcp.WithString("Network", mcp.Enum(enums.LoadBalancerNetworkTypes...), mcp.Description("Network type of the load balancer (EXTERNAL, INTERNAL)")),
 				mcp.WithNumber("SizeUnit", mcp.DefaultNumber(2), mcp.Description("Size of the load balancer in units appropriate to its type")),
 				mcp.WithString("NetworkStack", mcp.Description("Network stack of the load balancer (IPV4, DUALSTACK)")),
 				mcp.WithString("ProjectID", mcp.Description("Project ID to which the load balancer will be assigned")),
 				mcp.WithArray("TargetLoadBalancerIDs", mcp.Description("IDs of the target regional load balancers for a global load balancer"), mcp.Items(map[string]any{"type": "string"})),
 				mcp.WithObject("GLBSettings", mcp.Description("Forward configurations for a global load balancer")),
+				withLoadBalancerSettings(),
 			),
 		},
 		{
-			Handler: l.deleteLoadBalancer,
+			Handler: l.locks.Serialize(lbURN, l.deleteLoadBalancer),
 			Tool: mcp.NewTool("lb-delete",
 				mcp.WithDestructiveHintAnnotation(true),
 				mcp.WithDescription("Delete a Load Balancer by ID"),
@@ -572,6 +1050,23 @@ func (l *LoadBalancersTool) Tools() []server.ServerTool {
 			Handler: l.getLoadBalancer,
 			Tool: mcp.NewTool("lb-get",
 				mcp.WithDescription("Get a Load Balancer by ID"),
+				common.WithOutputSchema[godo.LoadBalancer](),
+				mcp.WithString("LoadBalancerID", mcp.Required(), mcp.Description("ID of the load balancer")),
+			),
+		},
+		{
+			Handler: l.getFirewall,
+			Tool: mcp.NewTool("lb-get-firewall",
+				common.WithHints(common.HintsRead),
+				mcp.WithDescription("Get only the firewall rules of a Load Balancer: the ip: and cidr: sources it allows and denies. Both lists are empty when it has no rules"),
+				mcp.WithString("LoadBalancerID", mcp.Required(), mcp.Description("ID of the load balancer")),
+			),
+		},
+		{
+			Handler: l.getHealth,
+			Tool: mcp.NewTool("lb-health",
+				common.WithHints(common.HintsRead),
+				mcp.WithDescription("Summarize the health of a Load Balancer: its status and the status of every Droplet behind it, with a one-line summary naming any that are not active. Use it to answer whether anything behind a load balancer is unhealthy"),
 				mcp.WithString("LoadBalancerID", mcp.Required(), mcp.Description("ID of the load balancer")),
 			),
 		},
@@ -581,26 +1076,27 @@ func (l *LoadBalancersTool) Tools() []server.ServerTool {
 				mcp.WithDescription("List Load Balancers with pagination"),
 				mcp.WithNumber("Page", mcp.DefaultNumber(1), mcp.Description("Page number")),
 				mcp.WithNumber("PerPage", mcp.DefaultNumber(20), mcp.Description("Items per page")),
+				common.WithAllArg(),
 			),
 		},
 		{
-			Handler: l.addDroplets,
+			Handler: l.locks.Serialize(lbURN, l.addDroplets),
 			Tool: mcp.NewTool("lb-add-droplets",
-				mcp.WithDescription("Add Droplets to a Load Balancer"),
+				mcp.WithDescription("Add Droplets to a Load Balancer. Droplets that are already attached are skipped and listed in the result"),
 				mcp.WithString("LoadBalancerID", mcp.Required(), mcp.Description("ID of the load balancer")),
 				mcp.WithArray("DropletIDs", mcp.Required(), mcp.Description("IDs of the droplets to add"), mcp.Items(map[string]any{"type": "string"})),
 			),
 		},
 		{
-			Handler: l.removeDroplets,
+			Handler: l.locks.Serialize(lbURN, l.removeDroplets),
 			Tool: mcp.NewTool("lb-remove-droplets",
-				mcp.WithDescription("Remove Droplets from a Load Balancer"),
+				mcp.WithDescription("Remove Droplets from a Load Balancer. Droplets that are not attached are skipped and listed in the result"),
 				mcp.WithString("LoadBalancerID", mcp.Required(), mcp.Description("ID of the load balancer")),
 				mcp.WithArray("DropletIDs", mcp.Required(), mcp.Description("IDs of the droplets to remove"), mcp.Items(map[string]any{"type": "string"})),
 			),
 		},
 		{
-			Handler: l.updateLoadBalancer,
+			Handler: l.locks.Serialize(lbURN, l.updateLoadBalancer),
 			Tool: mcp.NewTool("lb-update",
 				mcp.WithDescription("Update a Load Balancer"),
 				mcp.WithString("LoadBalancerID", mcp.Required(), mcp.Description("ID of the load balancer")),
@@ -609,17 +1105,18 @@ func (l *LoadBalancersTool) Tools() []server.ServerTool {
 				mcp.WithArray("DropletIDs", mcp.Description("IDs of the Droplets assigned to the load balancer"), mcp.Items(map[string]any{"type": "string"})),
 				mcp.WithString("Tag", mcp.Description("Droplet tag corresponding to Droplets assigned to the load balancer")),
 				mcp.WithArray("ForwardingRules", mcp.Description("Forwarding rules for a load balancer"), mcp.Items(map[string]any{"type": "string"})),
-				mcp.WithString("Type", mcp.Required(), mcp.Description("Type of the load balancer (REGIONAL, REGIONAL_NETWORK, GLOBAL)")),
-				mcp.WithString("Network", mcp.Description("Network type of the load balancer (EXTERNAL, INTERNAL)")),
+				mcp.WithString("Type", mcp.Required(), mcp.Enum(enums.LoadBalancerTypes...), mcp.Description("Type of the load balancer (REGIONAL, REGIONAL_NETWORK, GLOBAL)")),
+				mcp.WithString("Network", mcp.Enum(enums.LoadBalancerNetworkTypes...), mcp.Description("Network type of the load balancer (EXTERNAL, INTERNAL)")),
 				mcp.WithNumber("SizeUnit", mcp.DefaultNumber(2), mcp.Description("Size of the load balancer in units appropriate to its type")),
 				mcp.WithString("NetworkStack", mcp.Description("Network stack of the load balancer (IPV4, DUALSTACK)")),
 				mcp.WithString("ProjectID", mcp.Description("Project ID to which the load balancer will be assigned")),
 				mcp.WithArray("TargetLoadBalancerIDs", mcp.Description("IDs of the target regional load balancers for a global load balancer"), mcp.Items(map[string]any{"type": "string"})),
 				mcp.WithObject("GLBSettings", mcp.Description("Forward configurations for a global load balancer")),
+				withLoadBalancerSettings(),
 			),
 		},
 		{
-			Handler: l.addForwardingRules,
+			Handler: l.locks.Serialize(lbURN, l.addForwardingRules),
 			Tool: mcp.NewTool("lb-add-fwd-rules",
 				mcp.WithDescription("Add Forwarding Rules to a Load Balancer"),
 				mcp.WithString("LoadBalancerID", mcp.Required(), mcp.Description("ID of the load balancer")),
@@ -627,7 +1124,7 @@ func (l *LoadBalancersTool) Tools() []server.ServerTool {
 			),
 		},
 		{
-			Handler: l.removeForwardingRules,
+			Handler: l.locks.Serialize(lbURN, l.removeForwardingRules),
 			Tool: mcp.NewTool("lb-remove-fwd-rules",
 				mcp.WithDescription("Remove Forwarding Rules from a Load Balancer"),
 				mcp.WithString("LoadBalancerID", mcp.Required(), mcp.Description("ID of the load balancer")),
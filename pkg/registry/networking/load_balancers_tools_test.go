@@ -4,21 +4,34 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"mcp-digitalocean/pkg/registry/common"
 )
 
 func setupLoadBalancersToolWithMock(loadBalancers *MockLoadBalancersService) *LoadBalancersTool {
 	client := func(ctx context.Context) (*godo.Client, error) {
 		return &godo.Client{LoadBalancers: loadBalancers}, nil
 	}
-	return NewLoadBalancersTool(client)
+	tool := NewLoadBalancersTool(client)
+	tool.regions.list = func(ctx context.Context, client *godo.Client) ([]godo.Region, error) {
+		return testRegions, nil
+	}
+	return tool
 }
 
+// testRegions are the regions the load balancer tests may name.
+var testRegions = []godo.Region{{Slug: "nyc3"}, {Slug: "sfo2"}, {Slug: "dev1"}, {Slug: "dev2"}}
+
 func TestLoadBalancersTool_createLoadBalancer(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -222,6 +235,30 @@ func TestLoadBalancersTool_createLoadBalancer(t *testing.T) {
 			expectError: true,
 			expectText:  "Name is required",
 		},
+		{
+			name: "Unknown Type",
+			args: map[string]any{
+				"Region":          "nyc3",
+				"Name":            "example-lb",
+				"Type":            "regional",
+				"ForwardingRules": forwardingRulesArg,
+			},
+			mockSetup:   nil,
+			expectError: true,
+			expectText:  `Type must be one of REGIONAL, REGIONAL_NETWORK, GLOBAL, got "regional"`,
+		},
+		{
+			name: "Unknown Network",
+			args: map[string]any{
+				"Region":          "nyc3",
+				"Name":            "example-lb",
+				"Network":         "PUBLIC",
+				"ForwardingRules": forwardingRulesArg,
+			},
+			mockSetup:   nil,
+			expectError: true,
+			expectText:  `Network must be one of EXTERNAL, INTERNAL, got "PUBLIC"`,
+		},
 		{
 			name: "Both DropletIDs and Tag arguments provided",
 			args: map[string]any{
@@ -267,6 +304,314 @@ func TestLoadBalancersTool_createLoadBalancer(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "Successful create with health check and sticky sessions",
+			args: map[string]any{
+				"Region":          "nyc3",
+				"Name":            "example-lb",
+				"DropletIDs":      []any{float64(111), float64(222)},
+				"ForwardingRules": forwardingRulesArg,
+				"HealthCheck": map[string]any{
+					"Protocol":               "HTTP",
+					"Port":                   float64(8080),
+					"Path":                   "/healthz",
+					"CheckIntervalSeconds":   float64(10),
+					"ResponseTimeoutSeconds": float64(5),
+					"HealthyThreshold":       float64(3),
+					"UnhealthyThreshold":     float64(2),
+					"ProxyProtocol":          true,
+				},
+				"StickySessions": map[string]any{
+					"Type":             "cookies",
+					"CookieName":       "lb",
+					"CookieTtlSeconds": float64(300),
+				},
+				"RedirectHttpToHttps":          true,
+				"EnableProxyProtocol":          true,
+				"EnableBackendKeepalive":       true,
+				"HTTPIdleTimeoutSeconds":       float64(120),
+				"DisableLetsEncryptDNSRecords": true,
+			},
+			mockSetup: func(m *MockLoadBalancersService) {
+				m.EXPECT().
+					Create(gomock.Any(), &godo.LoadBalancerRequest{
+						Region:          "nyc3",
+						Name:            "example-lb",
+						DropletIDs:      []int{111, 222},
+						ForwardingRules: mockForwardingRules,
+						HealthCheck: &godo.HealthCheck{
+							Protocol:               "http",
+							Port:                   8080,
+							Path:                   "/healthz",
+							CheckIntervalSeconds:   10,
+							ResponseTimeoutSeconds: 5,
+							HealthyThreshold:       3,
+							UnhealthyThreshold:     2,
+							ProxyProtocol:          godo.PtrTo(true),
+						},
+						StickySessions: &godo.StickySessions{
+							Type:             "cookies",
+							CookieName:       "lb",
+							CookieTtlSeconds: 300,
+						},
+						RedirectHttpToHttps:          true,
+						EnableProxyProtocol:          true,
+						EnableBackendKeepalive:       true,
+						HTTPIdleTimeoutSeconds:       godo.PtrTo(uint64(120)),
+						DisableLetsEncryptDNSRecords: godo.PtrTo(true),
+					}).
+					Return(testLoadBalancerWithDropletIDs, nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name: "Invalid HealthCheck not an object",
+			args: map[string]any{
+				"Region":          "nyc3",
+				"Name":            "example-lb",
+				"ForwardingRules": forwardingRulesArg,
+				"HealthCheck":     "http",
+			},
+			expectError: true,
+			expectText:  "HealthCheck must be an object",
+		},
+		{
+			name: "Invalid unsupported health check protocol",
+			args: map[string]any{
+				"Region":          "nyc3",
+				"Name":            "example-lb",
+				"ForwardingRules": forwardingRulesArg,
+				"HealthCheck":     map[string]any{"Protocol": "udp"},
+			},
+			expectError: true,
+			expectText:  "HealthCheck.Protocol must be one of http, https, tcp, got udp",
+		},
+		{
+			name: "Invalid health check port out of range",
+			args: map[string]any{
+				"Region":          "nyc3",
+				"Name":            "example-lb",
+				"ForwardingRules": forwardingRulesArg,
+				"HealthCheck":     map[string]any{"Protocol": "tcp", "Port": float64(70000)},
+			},
+			expectError: true,
+			expectText:  "HealthCheck.Port must be between 1 and 65535, got 70000",
+		},
+		{
+			name: "Invalid fractional health check interval",
+			args: map[string]any{
+				"Region":          "nyc3",
+				"Name":            "example-lb",
+				"ForwardingRules": forwardingRulesArg,
+				"HealthCheck":     map[string]any{"CheckIntervalSeconds": float64(2.5)},
+			},
+			expectError: true,
+			expectText:  "HealthCheck.CheckIntervalSeconds must be a whole number",
+		},
+		{
+			name: "Invalid health check threshold out of range",
+			args: map[string]any{
+				"Region":          "nyc3",
+				"Name":            "example-lb",
+				"ForwardingRules": forwardingRulesArg,
+				"HealthCheck":     map[string]any{"UnhealthyThreshold": float64(1)},
+			},
+			expectError: true,
+			expectText:  "HealthCheck.UnhealthyThreshold must be between 2 and 10, got 1",
+		},
+		{
+			name: "Invalid health check path without slash",
+			args: map[string]any{
+				"Region":          "nyc3",
+				"Name":            "example-lb",
+				"ForwardingRules": forwardingRulesArg,
+				"HealthCheck":     map[string]any{"Path": "healthz"},
+			},
+			expectError: true,
+			expectText:  "HealthCheck.Path must be a string starting with /",
+		},
+		{
+			name: "Invalid health check path on tcp",
+			args: map[string]any{
+				"Region":          "nyc3",
+				"Name":            "example-lb",
+				"ForwardingRules": forwardingRulesArg,
+				"HealthCheck":     map[string]any{"Protocol": "tcp", "Path": "/healthz"},
+			},
+			expectError: true,
+			expectText:  "HealthCheck.Path is only used by http and https health checks",
+		},
+		{
+			name: "Invalid unknown sticky session type",
+			args: map[string]any{
+				"Region":          "nyc3",
+				"Name":            "example-lb",
+				"ForwardingRules": forwardingRulesArg,
+				"StickySessions":  map[string]any{"Type": "ip"},
+			},
+			expectError: true,
+			expectText:  "StickySessions.Type must be one of none, cookies, got ip",
+		},
+		{
+			name: "Invalid cookie sticky sessions without a name",
+			args: map[string]any{
+				"Region":          "nyc3",
+				"Name":            "example-lb",
+				"ForwardingRules": forwardingRulesArg,
+				"StickySessions":  map[string]any{"Type": "cookies", "CookieTtlSeconds": float64(60)},
+			},
+			expectError: true,
+			expectText:  "StickySessions.CookieName is required when Type is cookies",
+		},
+		{
+			name: "Invalid cookie sticky sessions without a ttl",
+			args: map[string]any{
+				"Region":          "nyc3",
+				"Name":            "example-lb",
+				"ForwardingRules": forwardingRulesArg,
+				"StickySessions":  map[string]any{"Type": "cookies", "CookieName": "lb"},
+			},
+			expectError: true,
+			expectText:  "StickySessions.CookieTtlSeconds is required when Type is cookies",
+		},
+		{
+			name: "Invalid cookie settings without cookies",
+			args: map[string]any{
+				"Region":          "nyc3",
+				"Name":            "example-lb",
+				"ForwardingRules": forwardingRulesArg,
+				"StickySessions":  map[string]any{"Type": "none", "CookieName": "lb"},
+			},
+			expectError: true,
+			expectText:  "only used with Type cookies",
+		},
+		{
+			name: "Invalid idle timeout out of range",
+			args: map[string]any{
+				"Region":                 "nyc3",
+				"Name":                   "example-lb",
+				"ForwardingRules":        forwardingRulesArg,
+				"HTTPIdleTimeoutSeconds": float64(5),
+			},
+			expectError: true,
+			expectText:  "HTTPIdleTimeoutSeconds must be between 30 and 600, got 5",
+		},
+		{
+			name: "Invalid non-boolean redirect",
+			args: map[string]any{
+				"Region":              "nyc3",
+				"Name":                "example-lb",
+				"ForwardingRules":     forwardingRulesArg,
+				"RedirectHttpToHttps": "yes",
+			},
+			expectError: true,
+			expectText:  "RedirectHttpToHttps must be a boolean",
+		},
+		{
+			name: "Successful create with firewall",
+			args: map[string]any{
+				"Region":          "nyc3",
+				"Name":            "example-lb",
+				"Type":            "REGIONAL_NETWORK",
+				"ForwardingRules": forwardingRulesArg,
+				"Firewall": map[string]any{
+					"Allow": []any{"cidr:1.2.3.0/24", "ip:2001:db8::1"},
+					"Deny":  []any{"ip:203.0.113.5"},
+				},
+			},
+			mockSetup: func(m *MockLoadBalancersService) {
+				m.EXPECT().
+					Create(gomock.Any(), &godo.LoadBalancerRequest{
+						Region:          "nyc3",
+						Name:            "example-lb",
+						Type:            "REGIONAL_NETWORK",
+						ForwardingRules: mockForwardingRules,
+						Firewall: &godo.LBFirewall{
+							Allow: []string{"cidr:1.2.3.0/24", "ip:2001:db8::1"},
+							Deny:  []string{"ip:203.0.113.5"},
+						},
+					}).
+					Return(testLoadBalancerWithDropletIDs, nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name: "Invalid firewall not an object",
+			args: map[string]any{
+				"Region":          "nyc3",
+				"Name":            "example-lb",
+				"ForwardingRules": forwardingRulesArg,
+				"Firewall":        []any{"ip:203.0.113.5"},
+			},
+			expectError: true,
+			expectText:  "Firewall must be an object with Allow and Deny lists",
+		},
+		{
+			name: "Invalid firewall list not a list",
+			args: map[string]any{
+				"Region":          "nyc3",
+				"Name":            "example-lb",
+				"ForwardingRules": forwardingRulesArg,
+				"Firewall":        map[string]any{"Allow": "ip:203.0.113.5"},
+			},
+			expectError: true,
+			expectText:  "Firewall.Allow must be a list of strings",
+		},
+		{
+			name: "Invalid firewall rule not a string",
+			args: map[string]any{
+				"Region":          "nyc3",
+				"Name":            "example-lb",
+				"ForwardingRules": forwardingRulesArg,
+				"Firewall":        map[string]any{"Deny": []any{float64(1)}},
+			},
+			expectError: true,
+			expectText:  "Firewall.Deny[0] must be a string",
+		},
+		{
+			name: "Invalid firewall rule without prefix",
+			args: map[string]any{
+				"Region":          "nyc3",
+				"Name":            "example-lb",
+				"ForwardingRules": forwardingRulesArg,
+				"Firewall":        map[string]any{"Allow": []any{"cidr:1.2.3.0/24", "203.0.113.5"}},
+			},
+			expectError: true,
+			expectText:  "Firewall.Allow[1]: \"203.0.113.5\" must start with ip: or cidr:",
+		},
+		{
+			name: "Invalid firewall rule with unknown prefix",
+			args: map[string]any{
+				"Region":          "nyc3",
+				"Name":            "example-lb",
+				"ForwardingRules": forwardingRulesArg,
+				"Firewall":        map[string]any{"Allow": []any{"tag:web"}},
+			},
+			expectError: true,
+			expectText:  "\"tag:web\" must start with ip: or cidr:",
+		},
+		{
+			name: "Invalid firewall ip rule with a CIDR",
+			args: map[string]any{
+				"Region":          "nyc3",
+				"Name":            "example-lb",
+				"ForwardingRules": forwardingRulesArg,
+				"Firewall":        map[string]any{"Deny": []any{"ip:1.2.3.0/24"}},
+			},
+			expectError: true,
+			expectText:  "Firewall.Deny[0]: \"1.2.3.0/24\" is not a valid IP address",
+		},
+		{
+			name: "Invalid firewall cidr rule without a mask",
+			args: map[string]any{
+				"Region":          "nyc3",
+				"Name":            "example-lb",
+				"ForwardingRules": forwardingRulesArg,
+				"Firewall":        map[string]any{"Allow": []any{"cidr:1.2.3.4"}},
+			},
+			expectError: true,
+			expectText:  "Firewall.Allow[0]: \"1.2.3.4\" is not a valid CIDR block",
+		},
 	}
 
 	for _, tc := range tests {
@@ -484,6 +829,103 @@ func TestLoadBalancersTool_getLoadBalancer(t *testing.T) {
 			var outLoadBalancer godo.LoadBalancer
 			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &outLoadBalancer))
 			require.Equal(t, testLoadBalancer.ID, outLoadBalancer.ID)
+			require.Equal(t, testLoadBalancer, resp.StructuredContent)
+			require.Len(t, resp.Content, 2)
+			require.JSONEq(t, `{"urn":"do:loadbalancer:12345","console_url":"https://cloud.digitalocean.com/networking/load_balancers/12345"}`, resp.Content[1].(mcp.TextContent).Text)
+		})
+	}
+}
+
+func TestLoadBalancersTool_getFirewall(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name         string
+		lbID         string
+		mockSetup    func(m *MockLoadBalancersService)
+		expectError  bool
+		expectResult LoadBalancerFirewall
+	}{
+		{
+			name: "Firewall rules",
+			lbID: "12345",
+			mockSetup: func(m *MockLoadBalancersService) {
+				m.EXPECT().
+					Get(gomock.Any(), "12345").
+					Return(&godo.LoadBalancer{
+						ID:         "12345",
+						Name:       "example-lb",
+						DropletIDs: []int{111},
+						Firewall:   &godo.LBFirewall{Allow: []string{"cidr:1.2.3.0/24"}, Deny: []string{"ip:203.0.113.5"}},
+					}, nil, nil).
+					Times(1)
+			},
+			expectResult: LoadBalancerFirewall{
+				ID:    "12345",
+				Name:  "example-lb",
+				Allow: []string{"cidr:1.2.3.0/24"},
+				Deny:  []string{"ip:203.0.113.5"},
+			},
+		},
+		{
+			name: "No firewall",
+			lbID: "12345",
+			mockSetup: func(m *MockLoadBalancersService) {
+				m.EXPECT().
+					Get(gomock.Any(), "12345").
+					Return(&godo.LoadBalancer{ID: "12345", Name: "example-lb"}, nil, nil).
+					Times(1)
+			},
+			expectResult: LoadBalancerFirewall{
+				ID:    "12345",
+				Name:  "example-lb",
+				Allow: []string{},
+				Deny:  []string{},
+			},
+		},
+		{
+			name: "API error",
+			lbID: "12345",
+			mockSetup: func(m *MockLoadBalancersService) {
+				m.EXPECT().
+					Get(gomock.Any(), "12345").
+					Return(nil, nil, errors.New("api error")).
+					Times(1)
+			},
+			expectError: true,
+		},
+		{
+			name:        "Missing load balancer ID argument",
+			expectError: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockLoadBalancers := NewMockLoadBalancersService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockLoadBalancers)
+			}
+			tool := setupLoadBalancersToolWithMock(mockLoadBalancers)
+			args := map[string]any{}
+			if tc.lbID != "" {
+				args["LoadBalancerID"] = tc.lbID
+			}
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
+			resp, err := tool.getFirewall(context.Background(), req)
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			if tc.expectError {
+				require.True(t, resp.IsError)
+				return
+			}
+			require.False(t, resp.IsError)
+			text := resp.Content[0].(mcp.TextContent).Text
+			var out LoadBalancerFirewall
+			require.NoError(t, json.Unmarshal([]byte(text), &out))
+			require.Equal(t, tc.expectResult, out)
+			// only the firewall section is returned, not the whole load balancer.
+			require.NotContains(t, text, "droplet_ids")
 		})
 	}
 }
@@ -578,6 +1020,58 @@ func TestLoadBalancersTool_listLoadBalancers(t *testing.T) {
 	}
 }
 
+func TestLoadBalancersTool_listLoadBalancersAll(t *testing.T) {
+	more := &godo.Response{Links: &godo.Links{Pages: &godo.Pages{Next: "https://api/v2/load_balancers?page=next", Last: "https://api/v2/load_balancers?page=last"}}}
+	page := func(n int) []godo.LoadBalancer {
+		lbs := make([]godo.LoadBalancer, n)
+		for i := range lbs {
+			lbs[i] = godo.LoadBalancer{ID: fmt.Sprintf("lb-%d", i)}
+		}
+		return lbs
+	}
+
+	tests := []struct {
+		name          string
+		mockSetup     func(m *MockLoadBalancersService)
+		wantFetched   int
+		wantTruncated bool
+	}{
+		{
+			name: "follows every page",
+			mockSetup: func(m *MockLoadBalancersService) {
+				m.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: common.MaxPerPage}).Return(page(200), more, nil)
+				m.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 2, PerPage: common.MaxPerPage}).Return(page(50), &godo.Response{Links: &godo.Links{}}, nil)
+			},
+			wantFetched: 250,
+		},
+		{
+			name: "stops at the cap",
+			mockSetup: func(m *MockLoadBalancersService) {
+				m.EXPECT().List(gomock.Any(), gomock.Any()).Return(page(200), more, nil).Times(common.MaxAllItems/common.MaxPerPage + 1)
+			},
+			wantFetched:   common.MaxAllItems,
+			wantTruncated: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockLoadBalancers := NewMockLoadBalancersService(ctrl)
+			tc.mockSetup(mockLoadBalancers)
+			tool := setupLoadBalancersToolWithMock(mockLoadBalancers)
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"All": true, "Page": float64(3)}}}
+			resp, err := tool.listLoadBalancers(context.Background(), req)
+			require.NoError(t, err)
+			require.False(t, resp.IsError)
+			var out common.AllResult[godo.LoadBalancer]
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+			require.Len(t, out.Items, tc.wantFetched)
+			require.Equal(t, tc.wantFetched, out.TotalFetched)
+			require.Equal(t, tc.wantTruncated, out.Truncated)
+		})
+	}
+}
+
 func TestLoadBalancersTool_addDroplets(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -595,18 +1089,66 @@ func TestLoadBalancersTool_addDroplets(t *testing.T) {
 			lbID:       "12345",
 			dropletIDs: []any{float64(111), float64(222)},
 			mockSetup: func(m *MockLoadBalancersService) {
+				m.EXPECT().
+					Get(gomock.Any(), "12345").
+					Return(&godo.LoadBalancer{ID: "12345", DropletIDs: []int{333}}, nil, nil).
+					Times(1)
 				m.EXPECT().
 					AddDroplets(gomock.Any(), "12345", []int{111, 222}).
 					Return(nil, nil).
 					Times(1)
 			},
-			expectText: "Droplets added successfully",
+			expectText: "Droplets added successfully (added: [111, 222], skipped: [])",
+		},
+		{
+			name:       "Partial add skips droplets already attached",
+			lbID:       "12345",
+			dropletIDs: []any{float64(111), float64(222), float64(111)},
+			mockSetup: func(m *MockLoadBalancersService) {
+				m.EXPECT().
+					Get(gomock.Any(), "12345").
+					Return(&godo.LoadBalancer{ID: "12345", DropletIDs: []int{222, 333}}, nil, nil).
+					Times(1)
+				m.EXPECT().
+					AddDroplets(gomock.Any(), "12345", []int{111}).
+					Return(nil, nil).
+					Times(1)
+			},
+			expectText: "Droplets added successfully (added: [111], skipped: [222])",
+		},
+		{
+			name:       "All droplets already attached",
+			lbID:       "12345",
+			dropletIDs: []any{float64(111), float64(222)},
+			mockSetup: func(m *MockLoadBalancersService) {
+				m.EXPECT().
+					Get(gomock.Any(), "12345").
+					Return(&godo.LoadBalancer{ID: "12345", DropletIDs: []int{111, 222, 333}}, nil, nil).
+					Times(1)
+			},
+			expectText: "No droplets added: all are already attached (added: [], skipped: [111, 222])",
+		},
+		{
+			name:       "API error fetching the load balancer",
+			lbID:       "12345",
+			dropletIDs: []any{float64(111), float64(222)},
+			mockSetup: func(m *MockLoadBalancersService) {
+				m.EXPECT().
+					Get(gomock.Any(), "12345").
+					Return(nil, nil, errors.New("api error")).
+					Times(1)
+			},
+			expectError: true,
 		},
 		{
 			name:       "API error",
 			lbID:       "12345",
 			dropletIDs: []any{float64(111), float64(222)},
 			mockSetup: func(m *MockLoadBalancersService) {
+				m.EXPECT().
+					Get(gomock.Any(), "12345").
+					Return(&godo.LoadBalancer{ID: "12345", DropletIDs: []int{333}}, nil, nil).
+					Times(1)
 				m.EXPECT().
 					AddDroplets(gomock.Any(), "12345", []int{111, 222}).
 					Return(nil, errors.New("api error")).
@@ -675,18 +1217,66 @@ func TestLoadBalancersTool_removeDroplets(t *testing.T) {
 			lbID:       "12345",
 			dropletIDs: []any{float64(111), float64(222)},
 			mockSetup: func(m *MockLoadBalancersService) {
+				m.EXPECT().
+					Get(gomock.Any(), "12345").
+					Return(&godo.LoadBalancer{ID: "12345", DropletIDs: []int{111, 222, 333}}, nil, nil).
+					Times(1)
 				m.EXPECT().
 					RemoveDroplets(gomock.Any(), "12345", []int{111, 222}).
 					Return(nil, nil).
 					Times(1)
 			},
-			expectText: "Droplets removed successfully",
+			expectText: "Droplets removed successfully (removed: [111, 222], skipped: [])",
+		},
+		{
+			name:       "Partial remove skips droplets already detached",
+			lbID:       "12345",
+			dropletIDs: []any{float64(111), float64(222), float64(111)},
+			mockSetup: func(m *MockLoadBalancersService) {
+				m.EXPECT().
+					Get(gomock.Any(), "12345").
+					Return(&godo.LoadBalancer{ID: "12345", DropletIDs: []int{111, 333}}, nil, nil).
+					Times(1)
+				m.EXPECT().
+					RemoveDroplets(gomock.Any(), "12345", []int{111}).
+					Return(nil, nil).
+					Times(1)
+			},
+			expectText: "Droplets removed successfully (removed: [111], skipped: [222])",
+		},
+		{
+			name:       "All droplets already detached",
+			lbID:       "12345",
+			dropletIDs: []any{float64(111), float64(222)},
+			mockSetup: func(m *MockLoadBalancersService) {
+				m.EXPECT().
+					Get(gomock.Any(), "12345").
+					Return(&godo.LoadBalancer{ID: "12345", DropletIDs: []int{333}}, nil, nil).
+					Times(1)
+			},
+			expectText: "No droplets removed: all are already detached (removed: [], skipped: [111, 222])",
+		},
+		{
+			name:       "API error fetching the load balancer",
+			lbID:       "12345",
+			dropletIDs: []any{float64(111), float64(222)},
+			mockSetup: func(m *MockLoadBalancersService) {
+				m.EXPECT().
+					Get(gomock.Any(), "12345").
+					Return(nil, nil, errors.New("api error")).
+					Times(1)
+			},
+			expectError: true,
 		},
 		{
 			name:       "API error",
 			lbID:       "12345",
 			dropletIDs: []any{float64(111), float64(222)},
 			mockSetup: func(m *MockLoadBalancersService) {
+				m.EXPECT().
+					Get(gomock.Any(), "12345").
+					Return(&godo.LoadBalancer{ID: "12345", DropletIDs: []int{111, 222, 333}}, nil, nil).
+					Times(1)
 				m.EXPECT().
 					RemoveDroplets(gomock.Any(), "12345", []int{111, 222}).
 					Return(nil, errors.New("api error")).
@@ -922,6 +1512,18 @@ func TestLoadBalancersTool_updateLoadBalancer(t *testing.T) {
 			expectError: true,
 			expectText:  "Load Balancer ID is required",
 		},
+		{
+			name: "Unknown Type",
+			args: map[string]any{
+				"LoadBalancerID": "12345",
+				"Name":           "example-lb-updated",
+				"Region":         "nyc3",
+				"Type":           "REGIONAL_HTTP",
+			},
+			mockSetup:   nil,
+			expectError: true,
+			expectText:  `Type must be one of REGIONAL, REGIONAL_NETWORK, GLOBAL, got "REGIONAL_HTTP"`,
+		},
 		{
 			name: "Missing Name argument",
 			args: map[string]any{
@@ -1034,6 +1636,45 @@ func TestLoadBalancersTool_updateLoadBalancer(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "Successful update with health check and sticky sessions",
+			args: map[string]any{
+				"LoadBalancerID": "12345",
+				"Name":           "example-lb-updated",
+				"Type":           "REGIONAL",
+				"Region":         "nyc3",
+				"HealthCheck": map[string]any{
+					"Protocol": "tcp",
+					"Port":     float64(22),
+				},
+				"StickySessions": map[string]any{"Type": "none"},
+			},
+			mockSetup: func(m *MockLoadBalancersService) {
+				m.EXPECT().
+					Update(gomock.Any(), "12345", &godo.LoadBalancerRequest{
+						Name:            "example-lb-updated",
+						Type:            "REGIONAL",
+						Region:          "nyc3",
+						ForwardingRules: []godo.ForwardingRule{},
+						HealthCheck:     &godo.HealthCheck{Protocol: "tcp", Port: 22},
+						StickySessions:  &godo.StickySessions{Type: "none"},
+					}).
+					Return(testLoadBalancer, nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name: "Invalid health check on update",
+			args: map[string]any{
+				"LoadBalancerID": "12345",
+				"Name":           "example-lb-updated",
+				"Type":           "REGIONAL",
+				"Region":         "nyc3",
+				"HealthCheck":    map[string]any{"ResponseTimeoutSeconds": float64(301)},
+			},
+			expectError: true,
+			expectText:  "HealthCheck.ResponseTimeoutSeconds must be between 3 and 300, got 301",
+		},
 	}
 
 	for _, tc := range tests {
@@ -1292,3 +1933,111 @@ func TestLoadBalancersTool_removeForwardingRules(t *testing.T) {
 		})
 	}
 }
+
+func TestParseForwardingRules(t *testing.T) {
+	rule := func(entry, target string, extra map[string]any) map[string]any {
+		r := map[string]any{"EntryProtocol": entry, "EntryPort": float64(443), "TargetProtocol": target, "TargetPort": float64(8080)}
+		for k, v := range extra {
+			r[k] = v
+		}
+		return r
+	}
+	cert := map[string]any{"CertificateID": "cert-1"}
+	passthrough := map[string]any{"TlsPassthrough": true}
+
+	tests := []struct {
+		name        string
+		rules       []any
+		expectedErr string
+	}{
+		{name: "http to http", rules: []any{rule("http", "http", nil)}},
+		{name: "http to http2", rules: []any{rule("http", "http2", nil)}},
+		{name: "http to tcp", rules: []any{rule("http", "tcp", nil)}, expectedErr: "ForwardingRules[0]: http entry requires an http, https or http2 target, got tcp"},
+		{name: "https with certificate", rules: []any{rule("https", "http", cert)}},
+		{name: "https with passthrough", rules: []any{rule("https", "https", passthrough)}},
+		{name: "https without certificate", rules: []any{rule("https", "http", nil)}, expectedErr: "https entry requires a CertificateID or TlsPassthrough"},
+		{name: "passthrough to http", rules: []any{rule("https", "http", passthrough)}, expectedErr: "TlsPassthrough requires an https target, got http"},
+		{name: "passthrough with certificate", rules: []any{rule("https", "https", map[string]any{"TlsPassthrough": true, "CertificateID": "cert-1"})}, expectedErr: "TlsPassthrough and CertificateID cannot be used together"},
+		{name: "passthrough on http", rules: []any{rule("http", "https", passthrough)}, expectedErr: "TlsPassthrough is only supported for https and http2 entry, got http"},
+		{name: "http2 with certificate", rules: []any{rule("http2", "http2", cert)}},
+		{name: "http3 to https", rules: []any{rule("http3", "https", cert)}},
+		{name: "http3 to http", rules: []any{rule("HTTP3", "HTTP", cert)}},
+		{name: "http3 to http2", rules: []any{rule("http3", "http2", cert)}, expectedErr: "http3 entry requires an http or https target, got http2"},
+		{name: "http3 without certificate", rules: []any{rule("http3", "https", nil)}, expectedErr: "http3 entry requires a CertificateID"},
+		{name: "http3 with passthrough", rules: []any{rule("http3", "https", passthrough)}, expectedErr: "http3 entry does not support TlsPassthrough"},
+		{name: "tcp to tcp", rules: []any{rule("tcp", "tcp", nil)}},
+		{name: "tcp to http", rules: []any{rule("tcp", "http", nil)}, expectedErr: "tcp entry requires a tcp target, got http"},
+		{name: "udp to udp", rules: []any{rule("udp", "udp", nil)}},
+		{name: "udp to tcp", rules: []any{rule("udp", "tcp", nil)}, expectedErr: "udp entry requires a udp target, got tcp"},
+		{name: "unknown entry protocol", rules: []any{rule("quic", "udp", nil)}, expectedErr: `EntryProtocol "quic" is not supported`},
+		{name: "unknown target protocol", rules: []any{rule("http", "grpc", nil)}, expectedErr: `TargetProtocol "grpc" is not supported`},
+		{name: "same port on tcp and udp", rules: []any{rule("tcp", "tcp", nil), rule("udp", "udp", nil)}},
+		{
+			name:        "duplicate entry port and protocol",
+			rules:       []any{rule("http", "http", nil), rule("tcp", "tcp", nil), rule("http", "http2", nil)},
+			expectedErr: "ForwardingRules[0] and ForwardingRules[2] both use entry port 443/http",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rules, errResult := parseForwardingRules(tc.rules)
+			if tc.expectedErr != "" {
+				require.NotNil(t, errResult)
+				require.True(t, errResult.IsError)
+				require.Contains(t, errResult.Content[0].(mcp.TextContent).Text, tc.expectedErr)
+				return
+			}
+			require.Nil(t, errResult)
+			require.Len(t, rules, len(tc.rules))
+		})
+	}
+}
+
+func TestLoadBalancersTool_updateSerialized(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockLBs := NewMockLoadBalancersService(ctrl)
+
+	var mu sync.Mutex
+	active, maxActive := 0, 0
+	slowUpdate := func(ctx context.Context, id string, req *godo.LoadBalancerRequest) (*godo.LoadBalancer, *godo.Response, error) {
+		mu.Lock()
+		active++
+		maxActive = max(maxActive, active)
+		mu.Unlock()
+		time.Sleep(50 * time.Millisecond)
+		mu.Lock()
+		active--
+		mu.Unlock()
+		return &godo.LoadBalancer{ID: id, Name: req.Name}, nil, nil
+	}
+	mockLBs.EXPECT().Update(gomock.Any(), "12345", gomock.Any()).DoAndReturn(slowUpdate).Times(2)
+
+	tool := setupLoadBalancersToolWithMock(mockLBs)
+	tool.locks = common.NewResourceLocks(time.Second)
+	var handler server.ToolHandlerFunc
+	for _, st := range tool.Tools() {
+		if st.Tool.Name == "lb-update" {
+			handler = st.Handler
+		}
+	}
+	require.NotNil(t, handler)
+
+	var wg sync.WaitGroup
+	for _, name := range []string{"lb-a", "lb-b"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+				"LoadBalancerID": "12345",
+				"Name":           name,
+				"Type":           "REGIONAL",
+				"Region":         "nyc3",
+			}}})
+			assert.NoError(t, err)
+			assert.False(t, resp.IsError)
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, 1, maxActive)
+}
@@ -0,0 +1,197 @@
+package networking
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"mcp-digitalocean/internal/testhelpers"
+	"mcp-digitalocean/pkg/registry/common"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func staticClient(client *godo.Client) func(ctx context.Context) (*godo.Client, error) {
+	return func(ctx context.Context) (*godo.Client, error) { return client, nil }
+}
+
+func TestGetToolsNotFound(t *testing.T) {
+	type handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	tests := []struct {
+		name     string
+		setup    func(ctrl *gomock.Controller, err error) handler
+		args     map[string]any
+		resource string
+		id       string
+	}{
+		{
+			name: "BYOIP prefix",
+			setup: func(ctrl *gomock.Controller, err error) handler {
+				m := NewMockBYOIPPrefixesService(ctrl)
+				m.EXPECT().Get(gomock.Any(), "missing").Return(nil, nil, err)
+				return NewBYOIPPrefixTool(staticClient(&godo.Client{BYOIPPrefixes: m})).getBYOIPPrefix
+			},
+			args:     map[string]any{"UUID": "missing"},
+			resource: "BYOIP prefix", id: "missing",
+		},
+		{
+			name: "BYOIP prefix resources",
+			setup: func(ctrl *gomock.Controller, err error) handler {
+				m := NewMockBYOIPPrefixesService(ctrl)
+				m.EXPECT().GetResources(gomock.Any(), "missing", gomock.Any()).Return(nil, nil, err)
+				return NewBYOIPPrefixTool(staticClient(&godo.Client{BYOIPPrefixes: m})).getByOIPPrefixResources
+			},
+			args:     map[string]any{"UUID": "missing"},
+			resource: "BYOIP prefix", id: "missing",
+		},
+		{
+			name: "certificate",
+			setup: func(ctrl *gomock.Controller, err error) handler {
+				m := NewMockCertificatesService(ctrl)
+				m.EXPECT().Get(gomock.Any(), "missing").Return(nil, nil, err)
+				return NewCertificateTool(staticClient(&godo.Client{Certificates: m})).getCertificate
+			},
+			args:     map[string]any{"ID": "missing"},
+			resource: "certificate", id: "missing",
+		},
+		{
+			name: "domain",
+			setup: func(ctrl *gomock.Controller, err error) handler {
+				m := NewMockDomainsService(ctrl)
+				m.EXPECT().Get(gomock.Any(), "missing.example").Return(nil, nil, err)
+				return NewDomainsTool(staticClient(&godo.Client{Domains: m})).getDomain
+			},
+			args:     map[string]any{"Name": "missing.example"},
+			resource: "domain", id: "missing.example",
+		},
+		{
+			name: "domain record",
+			setup: func(ctrl *gomock.Controller, err error) handler {
+				m := NewMockDomainsService(ctrl)
+				m.EXPECT().Record(gomock.Any(), "example.com", 42).Return(nil, nil, err)
+				return NewDomainsTool(staticClient(&godo.Client{Domains: m})).getDomainRecord
+			},
+			args:     map[string]any{"Domain": "example.com", "RecordID": float64(42)},
+			resource: "domain record", id: "example.com/42",
+		},
+		{
+			name: "firewall",
+			setup: func(ctrl *gomock.Controller, err error) handler {
+				m := NewMockFirewallsService(ctrl)
+				m.EXPECT().Get(gomock.Any(), "missing").Return(nil, nil, err)
+				return NewFirewallTool(staticClient(&godo.Client{Firewalls: m})).getFirewall
+			},
+			args:     map[string]any{"ID": "missing"},
+			resource: "firewall", id: "missing",
+		},
+		{
+			name: "load balancer",
+			setup: func(ctrl *gomock.Controller, err error) handler {
+				m := NewMockLoadBalancersService(ctrl)
+				m.EXPECT().Get(gomock.Any(), "missing").Return(nil, nil, err)
+				return NewLoadBalancersTool(staticClient(&godo.Client{LoadBalancers: m})).getLoadBalancer
+			},
+			args:     map[string]any{"LoadBalancerID": "missing"},
+			resource: "load balancer", id: "missing",
+		},
+		{
+			name: "load balancer firewall",
+			setup: func(ctrl *gomock.Controller, err error) handler {
+				m := NewMockLoadBalancersService(ctrl)
+				m.EXPECT().Get(gomock.Any(), "missing").Return(nil, nil, err)
+				return NewLoadBalancersTool(staticClient(&godo.Client{LoadBalancers: m})).getFirewall
+			},
+			args:     map[string]any{"LoadBalancerID": "missing"},
+			resource: "load balancer", id: "missing",
+		},
+		{
+			name: "load balancer health",
+			setup: func(ctrl *gomock.Controller, err error) handler {
+				m := NewMockLoadBalancersService(ctrl)
+				m.EXPECT().Get(gomock.Any(), "missing").Return(nil, nil, err)
+				return NewLoadBalancersTool(staticClient(&godo.Client{LoadBalancers: m})).getHealth
+			},
+			args:     map[string]any{"LoadBalancerID": "missing"},
+			resource: "load balancer", id: "missing",
+		},
+		{
+			name: "partner attachment",
+			setup: func(ctrl *gomock.Controller, err error) handler {
+				m := NewMockPartnerAttachmentService(ctrl)
+				m.EXPECT().Get(gomock.Any(), "missing").Return(nil, nil, err)
+				return NewPartnerAttachmentTool(staticClient(&godo.Client{PartnerAttachment: m})).getPartnerAttachment
+			},
+			args:     map[string]any{"ID": "missing"},
+			resource: "partner attachment", id: "missing",
+		},
+		{
+			name: "partner attachment service key",
+			setup: func(ctrl *gomock.Controller, err error) handler {
+				m := NewMockPartnerAttachmentService(ctrl)
+				m.EXPECT().GetServiceKey(gomock.Any(), "missing").Return(nil, nil, err)
+				return NewPartnerAttachmentTool(staticClient(&godo.Client{PartnerAttachment: m})).getServiceKey
+			},
+			args:     map[string]any{"ID": "missing"},
+			resource: "partner attachment", id: "missing",
+		},
+		{
+			name: "partner attachment BGP auth key",
+			setup: func(ctrl *gomock.Controller, err error) handler {
+				m := NewMockPartnerAttachmentService(ctrl)
+				m.EXPECT().GetBGPAuthKey(gomock.Any(), "missing").Return(nil, nil, err)
+				return NewPartnerAttachmentTool(staticClient(&godo.Client{PartnerAttachment: m})).getBGPConfig
+			},
+			args:     map[string]any{"ID": "missing"},
+			resource: "partner attachment", id: "missing",
+		},
+		{
+			name: "reserved IPv4",
+			setup: func(ctrl *gomock.Controller, err error) handler {
+				m := NewMockReservedIPsService(ctrl)
+				m.EXPECT().Get(gomock.Any(), "203.0.113.7").Return(nil, nil, err)
+				return NewReservedIPTool(staticClient(&godo.Client{ReservedIPs: m})).getReservedIP
+			},
+			args:     map[string]any{"IP": "203.0.113.7"},
+			resource: "reserved IP", id: "203.0.113.7",
+		},
+		{
+			name: "VPC",
+			setup: func(ctrl *gomock.Controller, err error) handler {
+				m := NewMockVPCsService(ctrl)
+				m.EXPECT().Get(gomock.Any(), "missing").Return(nil, nil, err)
+				return NewVPCTool(staticClient(&godo.Client{VPCs: m})).getVPC
+			},
+			args:     map[string]any{"ID": "missing"},
+			resource: "VPC", id: "missing",
+		},
+		{
+			name: "VPC peering",
+			setup: func(ctrl *gomock.Controller, err error) handler {
+				m := NewMockVPCsService(ctrl)
+				m.EXPECT().GetVPCPeering(gomock.Any(), "missing").Return(nil, nil, err)
+				return NewVPCPeeringTool(staticClient(&godo.Client{VPCs: m})).getVPCPeering
+			},
+			args:     map[string]any{"ID": "missing"},
+			resource: "VPC peering", id: "missing",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			get := tc.setup(ctrl, testhelpers.NotFoundError("/v2/"+tc.id))
+
+			resp, err := get(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			require.True(t, resp.IsError)
+			var out common.NotFound
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+			require.Equal(t, common.ErrorCodeNotFound, out.Code)
+			require.Equal(t, tc.resource, out.Resource)
+			require.Equal(t, tc.id, out.ID)
+		})
+	}
+}
@@ -8,9 +8,12 @@ import (
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"mcp-digitalocean/pkg/registry/common"
 )
 
 type PartnerAttachmentTool struct {
+	common.ToolLogger
+
 	client func(ctx context.Context) (*godo.Client, error)
 }
 
@@ -36,9 +39,9 @@ func (p *PartnerAttachmentTool) createPartnerAttachment(ctx context.Context, req
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	attachment, _, err := client.PartnerAttachment.Create(ctx, createRequest)
+	attachment, resp, err := client.PartnerAttachment.Create(ctx, createRequest)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, p.Logger(ctx), "api error", resp, err), nil
 	}
 
 	jsonAttachment, err := json.MarshalIndent(attachment, "", "  ")
@@ -63,7 +66,7 @@ func (p *PartnerAttachmentTool) getPartnerAttachment(ctx context.Context, req mc
 
 	attachment, _, err := client.PartnerAttachment.Get(ctx, id)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.GetErrorResult(ctx, p.Logger(ctx), "api error", "partner attachment", id, err), nil
 	}
 	jsonAttachment, err := json.MarshalIndent(attachment, "", "  ")
 	if err != nil {
@@ -88,9 +91,9 @@ func (p *PartnerAttachmentTool) listPartnerAttachments(ctx context.Context, req
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	attachments, _, err := client.PartnerAttachment.List(ctx, &godo.ListOptions{Page: page, PerPage: perPage})
+	attachments, resp, err := client.PartnerAttachment.List(ctx, &godo.ListOptions{Page: page, PerPage: perPage})
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, p.Logger(ctx), "api error", resp, err), nil
 	}
 	jsonAttachments, err := json.MarshalIndent(attachments, "", "  ")
 	if err != nil {
@@ -107,9 +110,9 @@ func (p *PartnerAttachmentTool) deletePartnerAttachment(ctx context.Context, req
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	_, err = client.PartnerAttachment.Delete(ctx, id)
+	resp, err := client.PartnerAttachment.Delete(ctx, id)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, p.Logger(ctx), "api error", resp, err), nil
 	}
 	return mcp.NewToolResultText("Partner attachment deleted successfully"), nil
 }
@@ -124,7 +127,7 @@ func (p *PartnerAttachmentTool) getServiceKey(ctx context.Context, req mcp.CallT
 
 	serviceKey, _, err := client.PartnerAttachment.GetServiceKey(ctx, id)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.GetErrorResult(ctx, p.Logger(ctx), "api error", "partner attachment", id, err), nil
 	}
 
 	jsonServiceKey, err := json.MarshalIndent(serviceKey, "", "  ")
@@ -145,7 +148,7 @@ func (p *PartnerAttachmentTool) getBGPConfig(ctx context.Context, req mcp.CallTo
 
 	bgpAuthKey, _, err := client.PartnerAttachment.GetBGPAuthKey(ctx, id)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.GetErrorResult(ctx, p.Logger(ctx), "api error", "partner attachment", id, err), nil
 	}
 
 	jsonBGPAuthKey, err := json.MarshalIndent(bgpAuthKey, "", "  ")
@@ -177,9 +180,9 @@ func (p *PartnerAttachmentTool) updatePartnerAttachment(ctx context.Context, req
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	attachment, _, err := client.PartnerAttachment.Update(ctx, id, updateRequest)
+	attachment, resp, err := client.PartnerAttachment.Update(ctx, id, updateRequest)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, p.Logger(ctx), "api error", resp, err), nil
 	}
 
 	jsonAttachment, err := json.MarshalIndent(attachment, "", "  ")
@@ -210,6 +213,7 @@ func (p *PartnerAttachmentTool) Tools() []server.ServerTool {
 		{
 			Handler: p.createPartnerAttachment,
 			Tool: mcp.NewTool("partner-attachment-create",
+				common.WithCreatesResource(),
 				mcp.WithDescription("Create a new partner attachment"),
 				mcp.WithString("Name", mcp.Required(), mcp.Description("Name of the partner attachment")),
 				mcp.WithString("Region", mcp.Required(), mcp.Description("Region for the partner attachment")),
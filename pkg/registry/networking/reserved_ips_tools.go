@@ -6,21 +6,29 @@ import (
 	"errors"
 	"fmt"
 	"net/netip"
+	"time"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"mcp-digitalocean/pkg/registry/common"
 )
 
 // ReservedIPTool provides tools for managing reserved IPs
 type ReservedIPTool struct {
-	client func(ctx context.Context) (*godo.Client, error)
+	common.ToolLogger
+
+	client       func(ctx context.Context) (*godo.Client, error)
+	pollInterval time.Duration
+	waitTimeout  time.Duration
 }
 
 // NewReservedIPTool creates a new ReservedIPTool
 func NewReservedIPTool(client func(ctx context.Context) (*godo.Client, error)) *ReservedIPTool {
 	return &ReservedIPTool{
-		client: client,
+		client:       client,
+		pollInterval: common.DefaultActionPollInterval,
+		waitTimeout:  common.DefaultActionWaitTimeout,
 	}
 }
 
@@ -50,7 +58,7 @@ func (t *ReservedIPTool) getReservedIP(ctx context.Context, req mcp.CallToolRequ
 		return mcp.NewToolResultError("unsupported IP address type"), nil
 	}
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.GetErrorResult(ctx, t.Logger(ctx), "api error", "reserved IP", ip, err), nil
 	}
 	jsonData, err := json.MarshalIndent(reservedIP, "", "  ")
 	if err != nil {
@@ -86,10 +94,10 @@ func (t *ReservedIPTool) listReservedIPs(ctx context.Context, req mcp.CallToolRe
 	case "ipv6":
 		ips, _, err = client.ReservedIPV6s.List(ctx, opts)
 	default:
-		return mcp.NewToolResultErrorFromErr("invalid IP type. Use 'ipv4' or 'ipv6'", errors.New("invalid IP type")), nil
+		return common.ErrorResult(ctx, t.Logger(ctx), "invalid IP type. Use 'ipv4' or 'ipv6'", errors.New("invalid IP type")), nil
 	}
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, t.Logger(ctx), "api error", nil, err), nil
 	}
 	jsonData, err := json.MarshalIndent(ips, "", "  ")
 	if err != nil {
@@ -117,11 +125,11 @@ func (t *ReservedIPTool) reserveIP(ctx context.Context, req mcp.CallToolRequest)
 	case "ipv6":
 		reservedIP, _, err = client.ReservedIPV6s.Create(ctx, &godo.ReservedIPV6CreateRequest{Region: region})
 	default:
-		return mcp.NewToolResultErrorFromErr("invalid IP type. Use 'ipv4' or 'ipv6'", errors.New("invalid IP type")), nil
+		return common.ErrorResult(ctx, t.Logger(ctx), "invalid IP type. Use 'ipv4' or 'ipv6'", errors.New("invalid IP type")), nil
 	}
 
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, t.Logger(ctx), "api error", nil, err), nil
 	}
 
 	jsonData, err := json.MarshalIndent(reservedIP, "", "  ")
@@ -150,55 +158,112 @@ func (t *ReservedIPTool) releaseIP(ctx context.Context, req mcp.CallToolRequest)
 	case "ipv6":
 		_, err = client.ReservedIPV6s.Delete(ctx, ip)
 	default:
-		return mcp.NewToolResultErrorFromErr("invalid IP type. Use 'ipv4' or 'ipv6'", errors.New("invalid IP type")), nil
+		return common.ErrorResult(ctx, t.Logger(ctx), "invalid IP type. Use 'ipv4' or 'ipv6'", errors.New("invalid IP type")), nil
 	}
 
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, t.Logger(ctx), "api error", nil, err), nil
 	}
 
 	return mcp.NewToolResultText("reserved IP released successfully"), nil
 }
 
-// assignIP assigns a reserved IP to a droplet
-func (t *ReservedIPTool) assignIP(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	ip := req.GetArguments()["IP"].(string)
-	dropletID := int(req.GetArguments()["DropletID"].(float64))
-	ipType := req.GetArguments()["Type"].(string) // "ipv4" or "ipv6"
+// reservedIPRegion returns the region slug of a reserved IPv4 or IPv6.
+func reservedIPRegion(ctx context.Context, client *godo.Client, ip, ipType string) (string, error) {
+	if ipType == "ipv6" {
+		reservedIP, _, err := client.ReservedIPV6s.Get(ctx, ip)
+		if err != nil {
+			return "", err
+		}
+		return reservedIP.RegionSlug, nil
+	}
 
-	var action *godo.Action
-	var err error
+	reservedIP, _, err := client.ReservedIPs.Get(ctx, ip)
+	if err != nil {
+		return "", err
+	}
+	if reservedIP.Region == nil {
+		return "", nil
+	}
+	return reservedIP.Region.Slug, nil
+}
+
+// actionResult waits for action to finish when the Wait argument is set, then
+// returns the action as JSON.
+func (t *ReservedIPTool) actionResult(ctx context.Context, req mcp.CallToolRequest, client *godo.Client, action *godo.Action) (*mcp.CallToolResult, error) {
+	if wait, _ := req.GetArguments()["Wait"].(bool); wait {
+		var err error
+		action, err = common.WaitForAction(ctx, func(ctx context.Context) (*godo.Action, *godo.Response, error) {
+			return client.Actions.Get(ctx, action.ID)
+		}, t.pollInterval, t.waitTimeout)
+		if err != nil {
+			return common.APIErrorResult(ctx, t.Logger(ctx), "failed waiting for action", nil, err), nil
+		}
+	}
+
+	jsonData, err := json.MarshalIndent(action, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+// assignIP assigns a reserved IP to a droplet in the same region
+func (t *ReservedIPTool) assignIP(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ip, ok := req.GetArguments()["IP"].(string)
+	if !ok || ip == "" {
+		return mcp.NewToolResultError("IP is required"), nil
+	}
+	dropletIDFloat, ok := req.GetArguments()["DropletID"].(float64)
+	if !ok || dropletIDFloat <= 0 {
+		return mcp.NewToolResultError("DropletID is required"), nil
+	}
+	dropletID := int(dropletIDFloat)
+	ipType, _ := req.GetArguments()["Type"].(string) // "ipv4" or "ipv6"
+	if ipType != "ipv4" && ipType != "ipv6" {
+		return common.ErrorResult(ctx, t.Logger(ctx), "invalid IP type. Use 'ipv4' or 'ipv6'", errors.New("invalid IP type")), nil
+	}
 
 	client, err := t.client(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	switch ipType {
-	case "ipv4":
-		action, _, err = client.ReservedIPActions.Assign(ctx, ip, dropletID)
-	case "ipv6":
-		action, _, err = client.ReservedIPV6Actions.Assign(ctx, ip, dropletID)
-	default:
-		return mcp.NewToolResultErrorFromErr("invalid IP type. Use 'ipv4' or 'ipv6'", errors.New("invalid IP type")), nil
+	// Reserved IPs can only be assigned within their region; check up front so
+	// the caller gets a targeted error rather than a generic API failure.
+	ipRegion, err := reservedIPRegion(ctx, client, ip, ipType)
+	if err != nil {
+		return common.APIErrorResult(ctx, t.Logger(ctx), "api error", nil, err), nil
 	}
-
+	droplet, resp, err := client.Droplets.Get(ctx, dropletID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, t.Logger(ctx), "api error", resp, err), nil
+	}
+	if droplet.Region != nil && ipRegion != "" && droplet.Region.Slug != ipRegion {
+		return mcp.NewToolResultError(fmt.Sprintf("reserved IP %s is in region %s but droplet %d is in region %s; a reserved IP can only be assigned to a droplet in the same region", ip, ipRegion, dropletID, droplet.Region.Slug)), nil
 	}
 
-	jsonData, err := json.MarshalIndent(action, "", "  ")
+	var action *godo.Action
+	if ipType == "ipv4" {
+		action, _, err = client.ReservedIPActions.Assign(ctx, ip, dropletID)
+	} else {
+		action, _, err = client.ReservedIPV6Actions.Assign(ctx, ip, dropletID)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("marshal error: %w", err)
+		return common.APIErrorResult(ctx, t.Logger(ctx), "api error", nil, err), nil
 	}
 
-	return mcp.NewToolResultText(string(jsonData)), nil
+	return t.actionResult(ctx, req, client, action)
 }
 
 // unassignIP unassigns a reserved IP from a droplet
 func (t *ReservedIPTool) unassignIP(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	ip := req.GetArguments()["IP"].(string)
-	ipType := req.GetArguments()["Type"].(string) // "ipv4" or "ipv6"
+	ip, ok := req.GetArguments()["IP"].(string)
+	if !ok || ip == "" {
+		return mcp.NewToolResultError("IP is required"), nil
+	}
+	ipType, _ := req.GetArguments()["Type"].(string) // "ipv4" or "ipv6"
 
 	var action *godo.Action
 	var err error
@@ -214,19 +279,14 @@ func (t *ReservedIPTool) unassignIP(ctx context.Context, req mcp.CallToolRequest
 	case "ipv6":
 		action, _, err = client.ReservedIPV6Actions.Unassign(ctx, ip)
 	default:
-		return mcp.NewToolResultErrorFromErr("invalid IP type. Use 'ipv4' or 'ipv6'", errors.New("invalid IP type")), nil
+		return common.ErrorResult(ctx, t.Logger(ctx), "invalid IP type. Use 'ipv4' or 'ipv6'", errors.New("invalid IP type")), nil
 	}
 
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, t.Logger(ctx), "api error", nil, err), nil
 	}
 
-	jsonData, err := json.MarshalIndent(action, "", "  ")
-	if err != nil {
-		return nil, fmt.Errorf("marshal error: %w", err)
-	}
-
-	return mcp.NewToolResultText(string(jsonData)), nil
+	return t.actionResult(ctx, req, client, action)
 }
 
 // Tools returns a list of tools for managing reserved IPs
@@ -251,6 +311,7 @@ func (t *ReservedIPTool) Tools() []server.ServerTool {
 		{
 			Handler: t.reserveIP,
 			Tool: mcp.NewTool("reserved-ip-reserve",
+				common.WithCreatesResource(),
 				mcp.WithDescription("Reserve a new IPv4 or IPv6"),
 				mcp.WithString("Region", mcp.Required(), mcp.Description("Region to reserve the IP in")),
 				mcp.WithString("Type", mcp.Required(), mcp.Description("Type of IP to reserve ('ipv4' or 'ipv6')")),
@@ -267,18 +328,20 @@ func (t *ReservedIPTool) Tools() []server.ServerTool {
 		{
 			Handler: t.assignIP,
 			Tool: mcp.NewTool("reserved-ip-assign",
-				mcp.WithDescription("Assign a reserved IP to a droplet"),
+				mcp.WithDescription("Assign a reserved IP to a droplet. The droplet must be in the same region as the reserved IP. Returns the assign action"),
 				mcp.WithString("IP", mcp.Required(), mcp.Description("The reserved IP to assign")),
 				mcp.WithNumber("DropletID", mcp.Required(), mcp.Description("The ID of the droplet to assign the IP to")),
 				mcp.WithString("Type", mcp.Required(), mcp.Description("Type of IP to assign ('ipv4' or 'ipv6')")),
+				mcp.WithBoolean("Wait", mcp.DefaultBool(false), mcp.Description("Wait for the action to complete before returning (default: false)")),
 			),
 		},
 		{
 			Handler: t.unassignIP,
 			Tool: mcp.NewTool("reserved-ip-unassign",
-				mcp.WithDescription("Unassign a reserved IP from a droplet"),
+				mcp.WithDescription("Unassign a reserved IP from a droplet. Returns the unassign action"),
 				mcp.WithString("IP", mcp.Required(), mcp.Description("The reserved IP to unassign")),
 				mcp.WithString("Type", mcp.Required(), mcp.Description("Type of IP to unassign ('ipv4' or 'ipv6')")),
+				mcp.WithBoolean("Wait", mcp.DefaultBool(false), mcp.Description("Wait for the action to complete before returning (default: false)")),
 			),
 		},
 	}
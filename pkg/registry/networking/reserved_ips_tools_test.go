@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net/netip"
 	"testing"
+	"time"
 
 	"reflect"
 
@@ -33,6 +34,16 @@ func setupReservedIPToolWithMocks(
 	return NewReservedIPTool(client)
 }
 
+// setupReservedIPToolWithClient returns a tool over client that polls actions
+// without delay.
+func setupReservedIPToolWithClient(client *godo.Client) *ReservedIPTool {
+	tool := NewReservedIPTool(func(ctx context.Context) (*godo.Client, error) {
+		return client, nil
+	})
+	tool.pollInterval = time.Millisecond
+	return tool
+}
+
 func TestReservedIPTool_getReservedIP(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -389,13 +400,16 @@ func TestReservedIPTool_assignIP_unassignIP(t *testing.T) {
 
 	// assignIP
 	t.Run("Assign IPv4 success", func(t *testing.T) {
+		mockIPv4 := NewMockReservedIPsService(ctrl)
 		mockIPv4Actions := NewMockReservedIPActionsService(ctrl)
-		mockIPv6Actions := NewMockReservedIPV6ActionsService(ctrl)
+		mockDroplets := NewMockDropletsService(ctrl)
+		mockIPv4.EXPECT().Get(gomock.Any(), "192.0.2.1").Return(&godo.ReservedIP{IP: "192.0.2.1", Region: &godo.Region{Slug: "nyc3"}}, nil, nil)
+		mockDroplets.EXPECT().Get(gomock.Any(), 42).Return(&godo.Droplet{ID: 42, Region: &godo.Region{Slug: "nyc3"}}, nil, nil)
 		mockIPv4Actions.EXPECT().
 			Assign(gomock.Any(), "192.0.2.1", 42).
 			Return(testAction, nil, nil).
 			Times(1)
-		tool := setupReservedIPToolWithMocks(nil, nil, mockIPv4Actions, mockIPv6Actions)
+		tool := setupReservedIPToolWithClient(&godo.Client{ReservedIPs: mockIPv4, ReservedIPActions: mockIPv4Actions, Droplets: mockDroplets})
 		args := map[string]any{"IP": "192.0.2.1", "DropletID": float64(42), "Type": "ipv4"}
 		req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 		resp, err := tool.assignIP(context.Background(), req)
@@ -408,13 +422,16 @@ func TestReservedIPTool_assignIP_unassignIP(t *testing.T) {
 	})
 
 	t.Run("Assign IPv6 error", func(t *testing.T) {
-		mockIPv4Actions := NewMockReservedIPActionsService(ctrl)
+		mockIPv6 := NewMockReservedIPV6sService(ctrl)
 		mockIPv6Actions := NewMockReservedIPV6ActionsService(ctrl)
+		mockDroplets := NewMockDropletsService(ctrl)
+		mockIPv6.EXPECT().Get(gomock.Any(), "2001:db8::1").Return(&godo.ReservedIPV6{IP: "2001:db8::1", RegionSlug: "nyc3"}, nil, nil)
+		mockDroplets.EXPECT().Get(gomock.Any(), 99).Return(&godo.Droplet{ID: 99, Region: &godo.Region{Slug: "nyc3"}}, nil, nil)
 		mockIPv6Actions.EXPECT().
 			Assign(gomock.Any(), "2001:db8::1", 99).
 			Return(nil, nil, errors.New("api error")).
 			Times(1)
-		tool := setupReservedIPToolWithMocks(nil, nil, mockIPv4Actions, mockIPv6Actions)
+		tool := setupReservedIPToolWithClient(&godo.Client{ReservedIPV6s: mockIPv6, ReservedIPV6Actions: mockIPv6Actions, Droplets: mockDroplets})
 		args := map[string]any{"IP": "2001:db8::1", "DropletID": float64(99), "Type": "ipv6"}
 		req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 		resp, err := tool.assignIP(context.Background(), req)
@@ -459,3 +476,121 @@ func TestReservedIPTool_assignIP_unassignIP(t *testing.T) {
 		require.True(t, resp.IsError)
 	})
 }
+
+func TestReservedIPTool_assignIP_regionCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     map[string]any
+		setup    func(*MockReservedIPsService, *MockReservedIPV6sService, *MockDropletsService)
+		wantText string
+	}{
+		{
+			name: "IPv4 in a different region",
+			args: map[string]any{"IP": "192.0.2.1", "DropletID": float64(42), "Type": "ipv4"},
+			setup: func(ipv4 *MockReservedIPsService, ipv6 *MockReservedIPV6sService, droplets *MockDropletsService) {
+				ipv4.EXPECT().Get(gomock.Any(), "192.0.2.1").Return(&godo.ReservedIP{IP: "192.0.2.1", Region: &godo.Region{Slug: "nyc3"}}, nil, nil)
+				droplets.EXPECT().Get(gomock.Any(), 42).Return(&godo.Droplet{ID: 42, Region: &godo.Region{Slug: "sfo3"}}, nil, nil)
+			},
+			wantText: "reserved IP 192.0.2.1 is in region nyc3 but droplet 42 is in region sfo3; a reserved IP can only be assigned to a droplet in the same region",
+		},
+		{
+			name: "IPv6 in a different region",
+			args: map[string]any{"IP": "2001:db8::1", "DropletID": float64(7), "Type": "ipv6"},
+			setup: func(ipv4 *MockReservedIPsService, ipv6 *MockReservedIPV6sService, droplets *MockDropletsService) {
+				ipv6.EXPECT().Get(gomock.Any(), "2001:db8::1").Return(&godo.ReservedIPV6{IP: "2001:db8::1", RegionSlug: "ams3"}, nil, nil)
+				droplets.EXPECT().Get(gomock.Any(), 7).Return(&godo.Droplet{ID: 7, Region: &godo.Region{Slug: "fra1"}}, nil, nil)
+			},
+			wantText: "reserved IP 2001:db8::1 is in region ams3 but droplet 7 is in region fra1; a reserved IP can only be assigned to a droplet in the same region",
+		},
+		{
+			name: "Droplet lookup fails",
+			args: map[string]any{"IP": "192.0.2.1", "DropletID": float64(42), "Type": "ipv4"},
+			setup: func(ipv4 *MockReservedIPsService, ipv6 *MockReservedIPV6sService, droplets *MockDropletsService) {
+				ipv4.EXPECT().Get(gomock.Any(), "192.0.2.1").Return(&godo.ReservedIP{IP: "192.0.2.1", Region: &godo.Region{Slug: "nyc3"}}, nil, nil)
+				droplets.EXPECT().Get(gomock.Any(), 42).Return(nil, nil, errors.New("droplet not found"))
+			},
+			wantText: "api error: droplet not found",
+		},
+		{
+			name:     "Missing DropletID",
+			args:     map[string]any{"IP": "192.0.2.1", "Type": "ipv4"},
+			wantText: "DropletID is required",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockIPv4 := NewMockReservedIPsService(ctrl)
+			mockIPv6 := NewMockReservedIPV6sService(ctrl)
+			mockDroplets := NewMockDropletsService(ctrl)
+			if tc.setup != nil {
+				tc.setup(mockIPv4, mockIPv6, mockDroplets)
+			}
+			// no action mocks: a failed region check must never call Assign.
+			tool := setupReservedIPToolWithClient(&godo.Client{ReservedIPs: mockIPv4, ReservedIPV6s: mockIPv6, Droplets: mockDroplets})
+
+			resp, err := tool.assignIP(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			require.True(t, resp.IsError)
+			require.Equal(t, tc.wantText, resp.Content[0].(mcp.TextContent).Text)
+		})
+	}
+}
+
+func TestReservedIPTool_wait(t *testing.T) {
+	t.Run("Assign waits until the action completes", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockIPv4 := NewMockReservedIPsService(ctrl)
+		mockIPv4Actions := NewMockReservedIPActionsService(ctrl)
+		mockDroplets := NewMockDropletsService(ctrl)
+		mockActions := NewMockActionsService(ctrl)
+		mockIPv4.EXPECT().Get(gomock.Any(), "192.0.2.1").Return(&godo.ReservedIP{IP: "192.0.2.1", Region: &godo.Region{Slug: "nyc3"}}, nil, nil)
+		mockDroplets.EXPECT().Get(gomock.Any(), 42).Return(&godo.Droplet{ID: 42, Region: &godo.Region{Slug: "nyc3"}}, nil, nil)
+		mockIPv4Actions.EXPECT().Assign(gomock.Any(), "192.0.2.1", 42).Return(&godo.Action{ID: 5, Status: godo.ActionInProgress}, nil, nil)
+		gomock.InOrder(
+			mockActions.EXPECT().Get(gomock.Any(), 5).Return(&godo.Action{ID: 5, Status: godo.ActionInProgress}, nil, nil).Times(2),
+			mockActions.EXPECT().Get(gomock.Any(), 5).Return(&godo.Action{ID: 5, Status: godo.ActionCompleted}, nil, nil),
+		)
+		tool := setupReservedIPToolWithClient(&godo.Client{ReservedIPs: mockIPv4, ReservedIPActions: mockIPv4Actions, Droplets: mockDroplets, Actions: mockActions})
+
+		args := map[string]any{"IP": "192.0.2.1", "DropletID": float64(42), "Type": "ipv4", "Wait": true}
+		resp, err := tool.assignIP(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		require.NoError(t, err)
+		require.False(t, resp.IsError)
+		var outAction godo.Action
+		require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &outAction))
+		require.Equal(t, godo.ActionCompleted, outAction.Status)
+	})
+
+	t.Run("Unassign reports an errored action", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockIPv6Actions := NewMockReservedIPV6ActionsService(ctrl)
+		mockActions := NewMockActionsService(ctrl)
+		mockIPv6Actions.EXPECT().Unassign(gomock.Any(), "2001:db8::1").Return(&godo.Action{ID: 6, Status: godo.ActionInProgress}, nil, nil)
+		mockActions.EXPECT().Get(gomock.Any(), 6).Return(&godo.Action{ID: 6, Type: "unassign_ip", Status: "errored"}, nil, nil)
+		tool := setupReservedIPToolWithClient(&godo.Client{ReservedIPV6Actions: mockIPv6Actions, Actions: mockActions})
+
+		args := map[string]any{"IP": "2001:db8::1", "Type": "ipv6", "Wait": true}
+		resp, err := tool.unassignIP(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		require.NoError(t, err)
+		require.True(t, resp.IsError)
+		require.Contains(t, resp.Content[0].(mcp.TextContent).Text, "action 6 (unassign_ip) errored")
+	})
+
+	t.Run("Wait times out", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockIPv4Actions := NewMockReservedIPActionsService(ctrl)
+		mockActions := NewMockActionsService(ctrl)
+		mockIPv4Actions.EXPECT().Unassign(gomock.Any(), "192.0.2.1").Return(&godo.Action{ID: 8, Status: godo.ActionInProgress}, nil, nil)
+		mockActions.EXPECT().Get(gomock.Any(), 8).Return(&godo.Action{ID: 8, Status: godo.ActionInProgress}, nil, nil).AnyTimes()
+		tool := setupReservedIPToolWithClient(&godo.Client{ReservedIPActions: mockIPv4Actions, Actions: mockActions})
+		tool.waitTimeout = 20 * time.Millisecond
+
+		args := map[string]any{"IP": "192.0.2.1", "Type": "ipv4", "Wait": true}
+		resp, err := tool.unassignIP(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		require.NoError(t, err)
+		require.True(t, resp.IsError)
+		require.Contains(t, resp.Content[0].(mcp.TextContent).Text, "timed out waiting for action 8")
+	})
+}
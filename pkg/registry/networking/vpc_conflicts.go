@@ -0,0 +1,55 @@
+package networking
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/digitalocean/godo"
+)
+
+// parseVPCSubnet parses the Subnet argument of vpc-create. It must be an IPv4
+// network address, such as 10.10.0.0/20 rather than 10.10.0.5/20.
+func parseVPCSubnet(subnet string) (netip.Prefix, error) {
+	prefix, err := netip.ParsePrefix(subnet)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("Subnet %q is not a CIDR block, e.g. 10.10.0.0/20", subnet)
+	}
+	if !prefix.Addr().Is4() {
+		return netip.Prefix{}, fmt.Errorf("Subnet %q must be an IPv4 CIDR block", subnet)
+	}
+	if masked := prefix.Masked(); masked != prefix {
+		return netip.Prefix{}, fmt.Errorf("Subnet %q has host bits set; did you mean %s?", subnet, masked)
+	}
+	return prefix, nil
+}
+
+// vpcRangeConflicts returns the VPCs in region whose IP range overlaps
+// subnet, including ranges nested in it or containing it. Adjacent ranges do
+// not overlap. VPCs whose range cannot be parsed are skipped.
+func vpcRangeConflicts(subnet netip.Prefix, region string, vpcs []*godo.VPC) []*godo.VPC {
+	var conflicts []*godo.VPC
+	for _, vpc := range vpcs {
+		if vpc.RegionSlug != region {
+			continue
+		}
+		existing, err := netip.ParsePrefix(vpc.IPRange)
+		if err != nil {
+			continue
+		}
+		if subnet.Overlaps(existing) {
+			conflicts = append(conflicts, vpc)
+		}
+	}
+	return conflicts
+}
+
+// vpcConflictMessage explains why subnet cannot be used in region.
+func vpcConflictMessage(subnet netip.Prefix, region string, conflicts []*godo.VPC) string {
+	names := make([]string, 0, len(conflicts))
+	for _, vpc := range conflicts {
+		names = append(names, fmt.Sprintf("%s (%s, %s)", vpc.Name, vpc.ID, vpc.IPRange))
+	}
+	return fmt.Sprintf("Subnet %s overlaps existing VPCs in %s: %s. Choose a range that does not overlap them, or omit Subnet to have DigitalOcean assign one",
+		subnet, region, strings.Join(names, "; "))
+}
@@ -0,0 +1,66 @@
+package networking
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVPCRangeConflicts(t *testing.T) {
+	vpcs := []*godo.VPC{
+		{ID: "vpc-1", RegionSlug: "nyc3", IPRange: "10.10.0.0/20"},
+		{ID: "vpc-2", RegionSlug: "nyc3", IPRange: "10.20.0.0/16"},
+		{ID: "vpc-3", RegionSlug: "ams3", IPRange: "10.30.0.0/20"},
+		{ID: "vpc-4", RegionSlug: "nyc3", IPRange: ""},
+	}
+	tests := []struct {
+		name   string
+		subnet string
+		region string
+		want   []string
+	}{
+		{name: "identical", subnet: "10.10.0.0/20", region: "nyc3", want: []string{"vpc-1"}},
+		{name: "partial overlap", subnet: "10.10.8.0/21", region: "nyc3", want: []string{"vpc-1"}},
+		{name: "nested inside existing", subnet: "10.20.4.0/24", region: "nyc3", want: []string{"vpc-2"}},
+		{name: "contains existing", subnet: "10.0.0.0/8", region: "nyc3", want: []string{"vpc-1", "vpc-2"}},
+		{name: "adjacent below", subnet: "10.9.240.0/20", region: "nyc3"},
+		{name: "adjacent above", subnet: "10.10.16.0/20", region: "nyc3"},
+		{name: "other region", subnet: "10.30.0.0/20", region: "nyc3"},
+		{name: "same range in its own region", subnet: "10.30.0.0/20", region: "ams3", want: []string{"vpc-3"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []string
+			for _, vpc := range vpcRangeConflicts(netip.MustParsePrefix(tc.subnet), tc.region, vpcs) {
+				got = append(got, vpc.ID)
+			}
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestParseVPCSubnet(t *testing.T) {
+	tests := []struct {
+		subnet  string
+		wantErr string
+	}{
+		{subnet: "10.10.0.0/20"},
+		{subnet: "172.16.0.0/12"},
+		{subnet: "10.10.0.1/20", wantErr: "did you mean 10.10.0.0/20?"},
+		{subnet: "10.10.0.0", wantErr: "is not a CIDR block"},
+		{subnet: "fd00::/64", wantErr: "must be an IPv4 CIDR block"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.subnet, func(t *testing.T) {
+			prefix, err := parseVPCSubnet(tc.subnet)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.subnet, prefix.String())
+		})
+	}
+}
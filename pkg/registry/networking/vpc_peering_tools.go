@@ -8,10 +8,14 @@ import (
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+
+	"mcp-digitalocean/pkg/registry/common"
 )
 
 // VPCPeeringTool represents a tool for managing VPC peering connections.
 type VPCPeeringTool struct {
+	common.ToolLogger
+
 	client func(ctx context.Context) (*godo.Client, error)
 }
 
@@ -35,7 +39,7 @@ func (t *VPCPeeringTool) getVPCPeering(ctx context.Context, req mcp.CallToolRequ
 
 	peering, _, err := client.VPCs.GetVPCPeering(ctx, id)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.GetErrorResult(ctx, t.Logger(ctx), "api error", "VPC peering", id, err), nil
 	}
 	jsonData, err := json.MarshalIndent(peering, "", "  ")
 	if err != nil {
@@ -59,9 +63,9 @@ func (t *VPCPeeringTool) listVPCPeerings(ctx context.Context, req mcp.CallToolRe
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	peerings, _, err := client.VPCs.ListVPCPeerings(ctx, &godo.ListOptions{Page: page, PerPage: perPage})
+	peerings, resp, err := client.VPCs.ListVPCPeerings(ctx, &godo.ListOptions{Page: page, PerPage: perPage})
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, t.Logger(ctx), "api error", resp, err), nil
 	}
 	jsonPeerings, err := json.MarshalIndent(peerings, "", "  ")
 	if err != nil {
@@ -83,12 +87,12 @@ func (t *VPCPeeringTool) createPeering(ctx context.Context, req mcp.CallToolRequ
 	}
 
 	// Create a new VPC peering connection
-	peering, _, err := client.VPCs.CreateVPCPeering(ctx, &godo.VPCPeeringCreateRequest{
+	peering, resp, err := client.VPCs.CreateVPCPeering(ctx, &godo.VPCPeeringCreateRequest{
 		Name:   peeringName,
 		VPCIDs: []string{vpc1, vpc2},
 	})
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, t.Logger(ctx), "api error", resp, err), nil
 	}
 
 	jsonData, err := json.MarshalIndent(peering, "", "  ")
@@ -110,9 +114,9 @@ func (t *VPCPeeringTool) deletePeering(ctx context.Context, req mcp.CallToolRequ
 	}
 
 	// Delete the VPC peering connection
-	_, err = client.VPCs.DeleteVPCPeering(ctx, peeringID)
+	resp, err := client.VPCs.DeleteVPCPeering(ctx, peeringID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, t.Logger(ctx), "api error", resp, err), nil
 	}
 
 	return mcp.NewToolResultText("VPC peering connection deleted"), nil
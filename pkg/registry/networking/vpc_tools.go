@@ -4,14 +4,19 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/netip"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+
+	"mcp-digitalocean/pkg/registry/common"
 )
 
 // VPCTool provides VPC management tools
 type VPCTool struct {
+	common.ToolLogger
+
 	client func(ctx context.Context) (*godo.Client, error)
 }
 
@@ -36,7 +41,7 @@ func (v *VPCTool) getVPC(ctx context.Context, req mcp.CallToolRequest) (*mcp.Cal
 
 	vpc, _, err := client.VPCs.Get(ctx, id)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.GetErrorResult(ctx, v.Logger(ctx), "api error", "VPC", id, err), nil
 	}
 	jsonVPC, err := json.MarshalIndent(vpc, "", "  ")
 	if err != nil {
@@ -61,9 +66,9 @@ func (v *VPCTool) listVPCs(ctx context.Context, req mcp.CallToolRequest) (*mcp.C
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	vpcs, _, err := client.VPCs.List(ctx, &godo.ListOptions{Page: page, PerPage: perPage})
+	vpcs, resp, err := client.VPCs.List(ctx, &godo.ListOptions{Page: page, PerPage: perPage})
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, v.Logger(ctx), "api error", resp, err), nil
 	}
 	jsonVPCs, err := json.MarshalIndent(vpcs, "", "  ")
 	if err != nil {
@@ -82,9 +87,14 @@ func (v *VPCTool) createVPC(ctx context.Context, req mcp.CallToolRequest) (*mcp.
 		RegionSlug: region,
 	}
 
-	// Add optional subnet parameter
-	if subnet, ok := req.GetArguments()["Subnet"].(string); ok && subnet != "" {
-		createRequest.IPRange = subnet
+	// Add optional subnet parameter. Without one, DigitalOcean assigns a range.
+	var subnet netip.Prefix
+	if arg, ok := req.GetArguments()["Subnet"].(string); ok && arg != "" {
+		var err error
+		if subnet, err = parseVPCSubnet(arg); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		createRequest.IPRange = subnet.String()
 	}
 
 	// Add optional description parameter
@@ -97,9 +107,21 @@ func (v *VPCTool) createVPC(ctx context.Context, req mcp.CallToolRequest) (*mcp.
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	vpc, _, err := client.VPCs.Create(ctx, createRequest)
+	// the API rejects an overlapping range without saying which VPC it
+	// overlaps, so check the region's VPCs first.
+	if subnet.IsValid() {
+		vpcs, err := common.FetchAll(ctx, common.MaxPerPage, client.VPCs.List)
+		if err != nil {
+			return common.APIErrorResult(ctx, v.Logger(ctx), "api error", nil, err), nil
+		}
+		if conflicts := vpcRangeConflicts(subnet, region, vpcs); len(conflicts) > 0 {
+			return mcp.NewToolResultError(vpcConflictMessage(subnet, region, conflicts)), nil
+		}
+	}
+
+	vpc, resp, err := client.VPCs.Create(ctx, createRequest)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, v.Logger(ctx), "api error", resp, err), nil
 	}
 
 	jsonVPC, err := json.MarshalIndent(vpc, "", "  ")
@@ -119,9 +141,9 @@ func (v *VPCTool) listVPCMembers(ctx context.Context, req mcp.CallToolRequest) (
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	members, _, err := client.VPCs.ListMembers(ctx, vpcID, nil, nil)
+	members, resp, err := client.VPCs.ListMembers(ctx, vpcID, nil, nil)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, v.Logger(ctx), "api error", resp, err), nil
 	}
 
 	jsonMembers, err := json.MarshalIndent(members, "", "  ")
@@ -141,9 +163,9 @@ func (v *VPCTool) deleteVPC(ctx context.Context, req mcp.CallToolRequest) (*mcp.
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	_, err = client.VPCs.Delete(ctx, vpcID)
+	resp, err := client.VPCs.Delete(ctx, vpcID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(ctx, v.Logger(ctx), "api error", resp, err), nil
 	}
 
 	return mcp.NewToolResultText("VPC deleted successfully"), nil
@@ -173,7 +195,7 @@ func (v *VPCTool) Tools() []server.ServerTool {
 				mcp.WithDescription("Create a new VPC"),
 				mcp.WithString("Name", mcp.Required(), mcp.Description("Name of the VPC")),
 				mcp.WithString("Region", mcp.Required(), mcp.Description("Region slug (e.g., nyc3)")),
-				mcp.WithString("Subnet", mcp.Description("Optional subnet CIDR block (e.g., 10.10.0.0/20)")),
+				mcp.WithString("Subnet", mcp.Description("Optional IPv4 CIDR block (e.g., 10.10.0.0/20). It must not overlap another VPC in the region; the tool checks this first and names the conflicting VPC. Omit it to have DigitalOcean assign a free range")),
 				mcp.WithString("Description", mcp.Description("Optional description for the VPC")),
 			),
 		},
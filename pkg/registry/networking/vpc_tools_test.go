@@ -1,11 +1,15 @@
 package networking
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
+	"log/slog"
 	"testing"
 
+	"mcp-digitalocean/pkg/registry/common"
+
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/require"
@@ -183,12 +187,71 @@ func TestVPCTool_createVPC(t *testing.T) {
 		Name:       "private-net",
 		RegionSlug: "nyc3",
 	}
+	// 10.10.0.0/20 is free in nyc3; the same range is taken in sfo3.
+	existingVPCs := []*godo.VPC{
+		{ID: "vpc-1", Name: "default-nyc3", RegionSlug: "nyc3", IPRange: "10.108.0.0/20"},
+		{ID: "vpc-2", Name: "apps", RegionSlug: "nyc3", IPRange: "10.10.16.0/20"},
+		{ID: "vpc-3", Name: "west", RegionSlug: "sfo3", IPRange: "10.10.0.0/20"},
+	}
 	tests := []struct {
 		name        string
 		args        map[string]any
 		mockSetup   func(*MockVPCsService)
 		expectError bool
+		errContains string
 	}{
+		{
+			name: "Subnet overlaps a VPC in the region",
+			args: map[string]any{
+				"Name":   "private-net",
+				"Region": "nyc3",
+				"Subnet": "10.10.0.0/16",
+			},
+			mockSetup: func(m *MockVPCsService) {
+				m.EXPECT().
+					List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: 200}).
+					Return(existingVPCs, &godo.Response{}, nil).
+					Times(1)
+			},
+			expectError: true,
+			errContains: "Subnet 10.10.0.0/16 overlaps existing VPCs in nyc3: apps (vpc-2, 10.10.16.0/20). Choose a range",
+		},
+		{
+			name: "Subnet with host bits",
+			args: map[string]any{
+				"Name":   "private-net",
+				"Region": "nyc3",
+				"Subnet": "10.10.0.5/20",
+			},
+			expectError: true,
+			errContains: "did you mean 10.10.0.0/20?",
+		},
+		{
+			name: "Subnet is not a CIDR block",
+			args: map[string]any{
+				"Name":   "private-net",
+				"Region": "nyc3",
+				"Subnet": "10.10.0.0",
+			},
+			expectError: true,
+			errContains: "is not a CIDR block",
+		},
+		{
+			name: "Listing VPCs fails",
+			args: map[string]any{
+				"Name":   "private-net",
+				"Region": "nyc3",
+				"Subnet": "10.10.0.0/20",
+			},
+			mockSetup: func(m *MockVPCsService) {
+				m.EXPECT().
+					List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: 200}).
+					Return(nil, nil, errors.New("list failed")).
+					Times(1)
+			},
+			expectError: true,
+			errContains: "list failed",
+		},
 		{
 			name: "Successful create",
 			args: map[string]any{
@@ -213,6 +276,10 @@ func TestVPCTool_createVPC(t *testing.T) {
 				"Subnet": "10.10.0.0/20",
 			},
 			mockSetup: func(m *MockVPCsService) {
+				m.EXPECT().
+					List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: 200}).
+					Return(existingVPCs, &godo.Response{}, nil).
+					Times(1)
 				m.EXPECT().
 					Create(gomock.Any(), &godo.VPCCreateRequest{
 						Name:       "private-net",
@@ -267,6 +334,10 @@ func TestVPCTool_createVPC(t *testing.T) {
 				"Description": "My private network with custom subnet",
 			},
 			mockSetup: func(m *MockVPCsService) {
+				m.EXPECT().
+					List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: 200}).
+					Return(existingVPCs, &godo.Response{}, nil).
+					Times(1)
 				m.EXPECT().
 					Create(gomock.Any(), &godo.VPCCreateRequest{
 						Name:        "private-net",
@@ -326,6 +397,9 @@ func TestVPCTool_createVPC(t *testing.T) {
 			if tc.expectError {
 				require.NotNil(t, resp)
 				require.True(t, resp.IsError)
+				if tc.errContains != "" {
+					require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.errContains)
+				}
 				return
 			}
 			require.NoError(t, err)
@@ -455,3 +529,43 @@ func TestVPCTool_deleteVPC(t *testing.T) {
 		})
 	}
 }
+
+func TestVPCTool_getVPCLogsAPIError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockVPCs := NewMockVPCsService(ctrl)
+	mockVPCs.EXPECT().Get(gomock.Any(), "vpc-404").Return(nil, nil, errors.New("vpc not found")).Times(2)
+	client := func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{VPCs: mockVPCs}, nil
+	}
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"ID": "vpc-404"}}}
+
+	t.Run("request logger", func(t *testing.T) {
+		var logs bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		ctx := common.ContextWithLogger(context.Background(), logger.With("tool", "vpc-get", "request_id", "0a1b2c3d"))
+
+		// the request logger wins over the tool's own logger.
+		tool := common.WithLogger(NewVPCTool(client), slog.New(slog.DiscardHandler))
+		result, err := tool.getVPC(ctx, req)
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal(logs.Bytes(), &entry))
+		require.Equal(t, "WARN", entry["level"])
+		require.Equal(t, "api error", entry["msg"])
+		require.Equal(t, "vpc not found", entry["error"])
+		require.Equal(t, "vpc-get", entry["tool"])
+		require.Equal(t, "0a1b2c3d", entry["request_id"])
+	})
+
+	t.Run("fallback logger", func(t *testing.T) {
+		var logs bytes.Buffer
+		tool := common.WithLogger(NewVPCTool(client), slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})))
+		result, err := tool.getVPC(context.Background(), req)
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		require.Contains(t, logs.String(), `"error":"vpc not found"`)
+	})
+}
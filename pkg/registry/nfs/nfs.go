@@ -7,6 +7,7 @@ import (
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"mcp-digitalocean/pkg/registry/common"
 )
 
 const (
@@ -274,6 +275,7 @@ func (n *NfsTool) Tools() []server.ServerTool {
 			Handler: n.createFileShare,
 			Tool: mcp.NewTool(
 				"nfs-file-share-create",
+				common.WithCreatesResource(),
 				mcp.WithDescription("Create a new file share"),
 				mcp.WithString("Name", mcp.Required(), mcp.Description("Name of the file share")),
 				mcp.WithNumber("SizeGibibytes", mcp.Required(), mcp.Description("Size of the file share in GiB")),
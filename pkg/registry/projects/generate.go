@@ -0,0 +1,3 @@
+package projects
+
+//go:generate mockgen -destination=./mocks.go -package projects github.com/digitalocean/godo ProjectsService
@@ -0,0 +1,369 @@
+package projects
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/digitalocean/godo"
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"mcp-digitalocean/pkg/registry/common"
+)
+
+const defaultProjectsPageSize = 20
+
+// projectEnvironments are the environments the API accepts for a project.
+var projectEnvironments = []string{"Development", "Staging", "Production"}
+
+// urnIDValidators check the ID part of a resource URN by its type. The types
+// are the ones a project can hold, named as in godo's URN methods; reserved
+// IPs keep the floatingip type.
+var urnIDValidators = map[string]func(id string) string{
+	"droplet":      positiveInt,
+	"floatingip":   ipAddress,
+	"loadbalancer": isUUID,
+	"kubernetes":   isUUID,
+	"dbaas":        isUUID,
+	"volume":       isUUID,
+	"app":          isUUID,
+	"domain":       domainName,
+	"space":        nonEmpty,
+}
+
+func positiveInt(id string) string {
+	if n, err := strconv.Atoi(id); err != nil || n <= 0 {
+		return "ID must be a positive number"
+	}
+	return ""
+}
+
+func ipAddress(id string) string {
+	if net.ParseIP(id) == nil {
+		return "ID must be an IP address"
+	}
+	return ""
+}
+
+func isUUID(id string) string {
+	if _, err := uuid.Parse(id); err != nil {
+		return "ID must be a UUID"
+	}
+	return ""
+}
+
+func domainName(id string) string {
+	if !strings.Contains(id, ".") {
+		return "ID must be a domain name"
+	}
+	return ""
+}
+
+func nonEmpty(id string) string {
+	if id == "" {
+		return "ID must not be empty"
+	}
+	return ""
+}
+
+// validateURN returns why urn is not a resource URN a project can hold, or ""
+// when it is one.
+func validateURN(urn string) string {
+	parts := strings.SplitN(urn, ":", 3)
+	if len(parts) != 3 || parts[0] != "do" {
+		return "expected do:<type>:<id>"
+	}
+	validate, ok := urnIDValidators[parts[1]]
+	if !ok {
+		return fmt.Sprintf("unsupported type %q", parts[1])
+	}
+	return validate(parts[2])
+}
+
+// ProjectsTool provides project management tools.
+type ProjectsTool struct {
+	common.ToolLogger
+
+	client func(ctx context.Context) (*godo.Client, error)
+}
+
+// NewProjectsTool creates a new ProjectsTool.
+func NewProjectsTool(client func(ctx context.Context) (*godo.Client, error)) *ProjectsTool {
+	return &ProjectsTool{client: client}
+}
+
+// listProjects lists projects with pagination.
+func (p *ProjectsTool) listProjects(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	opt, pageMeta := common.ListOptionsFromArgs(req.GetArguments(), defaultProjectsPageSize)
+
+	client, err := p.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	projects, resp, err := client.Projects.List(ctx, opt)
+	if err != nil {
+		return common.APIErrorResult(ctx, p.Logger(ctx), "api error", resp, err), nil
+	}
+
+	jsonData, err := json.MarshalIndent(projects, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return common.WithPageMeta(mcp.NewToolResultText(string(jsonData)), pageMeta)
+}
+
+// getProject gets a project by ID, or the default project for "default".
+func (p *ProjectsTool) getProject(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	id, _ := req.GetArguments()["ID"].(string)
+	if id == "" {
+		return mcp.NewToolResultError("ID is required"), nil
+	}
+
+	client, err := p.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	project, _, err := client.Projects.Get(ctx, id)
+	if err != nil {
+		return common.GetErrorResult(ctx, p.Logger(ctx), "api error", "project", id, err), nil
+	}
+	return projectResult(project)
+}
+
+// createProject creates a project.
+func (p *ProjectsTool) createProject(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	name, _ := args["Name"].(string)
+	if name == "" {
+		return mcp.NewToolResultError("Name is required"), nil
+	}
+	purpose, _ := args["Purpose"].(string)
+	if purpose == "" {
+		return mcp.NewToolResultError("Purpose is required"), nil
+	}
+	description, _ := args["Description"].(string)
+	environment, _ := args["Environment"].(string)
+	if message := validateEnvironment(environment); message != "" {
+		return mcp.NewToolResultError(message), nil
+	}
+
+	client, err := p.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	project, resp, err := client.Projects.Create(ctx, &godo.CreateProjectRequest{
+		Name:        name,
+		Description: description,
+		Purpose:     purpose,
+		Environment: environment,
+	})
+	if err != nil {
+		return common.APIErrorResult(ctx, p.Logger(ctx), "api error", resp, err), nil
+	}
+	return projectResult(project)
+}
+
+// updateProject changes the fields of a project that are set in the request.
+func (p *ProjectsTool) updateProject(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	id, _ := args["ID"].(string)
+	if id == "" {
+		return mcp.NewToolResultError("ID is required"), nil
+	}
+
+	// UpdateProjectRequest leaves out fields that are nil, so only the
+	// arguments given are changed.
+	update := &godo.UpdateProjectRequest{}
+	changed := false
+	for name, field := range map[string]*any{
+		"Name":        &update.Name,
+		"Description": &update.Description,
+		"Purpose":     &update.Purpose,
+		"Environment": &update.Environment,
+	} {
+		if v, ok := args[name].(string); ok {
+			*field = v
+			changed = true
+		}
+	}
+	if isDefault, ok := args["IsDefault"].(bool); ok {
+		update.IsDefault = isDefault
+		changed = true
+	}
+	if !changed {
+		return mcp.NewToolResultError("at least one of Name, Description, Purpose, Environment or IsDefault is required"), nil
+	}
+	if environment, ok := update.Environment.(string); ok {
+		if message := validateEnvironment(environment); message != "" {
+			return mcp.NewToolResultError(message), nil
+		}
+	}
+
+	client, err := p.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	project, resp, err := client.Projects.Update(ctx, id, update)
+	if err != nil {
+		return common.APIErrorResult(ctx, p.Logger(ctx), "api error", resp, err), nil
+	}
+	return projectResult(project)
+}
+
+// deleteProject deletes a project.
+func (p *ProjectsTool) deleteProject(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	id, _ := req.GetArguments()["ID"].(string)
+	if id == "" {
+		return mcp.NewToolResultError("ID is required"), nil
+	}
+
+	client, err := p.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	resp, err := client.Projects.Delete(ctx, id)
+	if err != nil {
+		return common.APIErrorResult(ctx, p.Logger(ctx), "api error", resp, err), nil
+	}
+	return mcp.NewToolResultText("Project deleted successfully"), nil
+}
+
+// assignResources moves resources into a project. Every URN is checked before
+// the API is called, so a typo does not leave the resources half assigned.
+func (p *ProjectsTool) assignResources(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	id, _ := args["ID"].(string)
+	if id == "" {
+		return mcp.NewToolResultError("ID is required"), nil
+	}
+	urnsArg, _ := args["Resources"].([]any)
+	if len(urnsArg) == 0 {
+		return mcp.NewToolResultError("Resources is required"), nil
+	}
+
+	urns := make([]any, 0, len(urnsArg))
+	var invalid []string
+	for _, v := range urnsArg {
+		urn, _ := v.(string)
+		if message := validateURN(urn); message != "" {
+			invalid = append(invalid, fmt.Sprintf("%q: %s", urn, message))
+			continue
+		}
+		urns = append(urns, urn)
+	}
+	if len(invalid) > 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("invalid resource URNs, nothing was assigned: %s. URNs look like do:droplet:123 or do:loadbalancer:<uuid>",
+			strings.Join(invalid, "; "))), nil
+	}
+
+	client, err := p.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	resources, resp, err := client.Projects.AssignResources(ctx, id, urns...)
+	if err != nil {
+		return common.APIErrorResult(ctx, p.Logger(ctx), "api error", resp, err), nil
+	}
+
+	jsonData, err := json.MarshalIndent(resources, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+// validateEnvironment returns why environment is not one the API accepts, or
+// "" when it is one or is empty.
+func validateEnvironment(environment string) string {
+	if environment == "" {
+		return ""
+	}
+	for _, e := range projectEnvironments {
+		if environment == e {
+			return ""
+		}
+	}
+	return fmt.Sprintf("Environment must be one of %s", strings.Join(projectEnvironments, ", "))
+}
+
+func projectResult(project *godo.Project) (*mcp.CallToolResult, error) {
+	jsonData, err := json.MarshalIndent(project, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+// Tools returns the project tools.
+func (p *ProjectsTool) Tools() []server.ServerTool {
+	return []server.ServerTool{
+		{
+			Handler: p.listProjects,
+			Tool: mcp.NewTool("project-list",
+				common.WithHints(common.HintsRead),
+				mcp.WithDescription("List projects. Use it to find the ProjectID that tools such as lb-create accept, or to move resources with project-assign-resources"),
+				mcp.WithNumber("Page", mcp.DefaultNumber(1), mcp.Description("Page number")),
+				mcp.WithNumber("PerPage", mcp.DefaultNumber(defaultProjectsPageSize), mcp.Description("Items per page")),
+			),
+		},
+		{
+			Handler: p.getProject,
+			Tool: mcp.NewTool("project-get",
+				common.WithHints(common.HintsRead),
+				mcp.WithDescription("Get a project by ID"),
+				mcp.WithString("ID", mcp.Required(), mcp.Description("ID of the project, or 'default' for the account's default project")),
+			),
+		},
+		{
+			Handler: p.createProject,
+			Tool: mcp.NewTool("project-create",
+				common.WithHints(common.HintsAction),
+				mcp.WithDescription("Create a project"),
+				mcp.WithString("Name", mcp.Required(), mcp.Description("Name of the project")),
+				mcp.WithString("Purpose", mcp.Required(), mcp.Description("Purpose of the project, e.g. 'Web Application'")),
+				mcp.WithString("Description", mcp.Description("Description of the project")),
+				mcp.WithString("Environment", mcp.Enum(projectEnvironments...), mcp.Description("Environment of the project's resources")),
+			),
+		},
+		{
+			Handler: p.updateProject,
+			Tool: mcp.NewTool("project-update",
+				common.WithHints(common.HintsToggle),
+				mcp.WithDescription("Update a project. Only the fields given are changed"),
+				mcp.WithString("ID", mcp.Required(), mcp.Description("ID of the project")),
+				mcp.WithString("Name", mcp.Description("New name")),
+				mcp.WithString("Purpose", mcp.Description("New purpose")),
+				mcp.WithString("Description", mcp.Description("New description")),
+				mcp.WithString("Environment", mcp.Enum(projectEnvironments...), mcp.Description("New environment")),
+				mcp.WithBoolean("IsDefault", mcp.Description("Make this the default project, which new resources are added to when no project is given")),
+			),
+		},
+		{
+			Handler: p.deleteProject,
+			Tool: mcp.NewTool("project-delete",
+				common.WithHints(common.HintsDelete),
+				mcp.WithDescription("Delete a project. The project must be empty and must not be the default project"),
+				mcp.WithString("ID", mcp.Required(), mcp.Description("ID of the project to delete")),
+			),
+		},
+		{
+			Handler: p.assignResources,
+			Tool: mcp.NewTool("project-assign-resources",
+				common.WithHints(common.HintsToggle),
+				mcp.WithDescription("Move resources into a project. Resources are given by URN, e.g. do:droplet:123, do:loadbalancer:<uuid>, do:kubernetes:<uuid>, do:dbaas:<uuid>, do:volume:<uuid>, do:app:<uuid>, do:floatingip:<ip>, do:domain:<name> or do:space:<name>. Invalid URNs are rejected before anything is assigned"),
+				mcp.WithString("ID", mcp.Required(), mcp.Description("ID of the project")),
+				mcp.WithArray("Resources", mcp.Required(), mcp.Description("URNs of the resources to assign"), mcp.Items(map[string]any{"type": "string"})),
+			),
+		},
+	}
+}
@@ -0,0 +1,207 @@
+package projects
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"mcp-digitalocean/pkg/registry/common"
+)
+
+const testProjectID = "4e1bfbc3-dc3e-41f2-a18f-1b4d7ba71679"
+
+func setupProjectsToolWithMocks(projects *MockProjectsService) *ProjectsTool {
+	client := func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{Projects: projects}, nil
+	}
+	return NewProjectsTool(client)
+}
+
+func TestProjectsTool_createProject(t *testing.T) {
+	testProject := &godo.Project{ID: testProjectID, Name: "web", Purpose: "Web Application", Environment: "Production"}
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(*MockProjectsService)
+		expectError string
+	}{
+		{
+			name: "Successful create",
+			args: map[string]any{"Name": "web", "Purpose": "Web Application", "Environment": "Production"},
+			mockSetup: func(m *MockProjectsService) {
+				m.EXPECT().
+					Create(gomock.Any(), &godo.CreateProjectRequest{Name: "web", Purpose: "Web Application", Environment: "Production"}).
+					Return(testProject, nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name:        "Missing purpose",
+			args:        map[string]any{"Name": "web"},
+			expectError: "Purpose is required",
+		},
+		{
+			name:        "Invalid environment",
+			args:        map[string]any{"Name": "web", "Purpose": "Web Application", "Environment": "prod"},
+			expectError: "Environment must be one of Development, Staging, Production",
+		},
+		{
+			name: "API error",
+			args: map[string]any{"Name": "web", "Purpose": "Web Application"},
+			mockSetup: func(m *MockProjectsService) {
+				m.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, nil, errors.New("api error")).Times(1)
+			},
+			expectError: "api error",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockProjects := NewMockProjectsService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockProjects)
+			}
+			tool := setupProjectsToolWithMocks(mockProjects)
+			resp, err := tool.createProject(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			text := resp.Content[0].(mcp.TextContent).Text
+			if tc.expectError != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, text, tc.expectError)
+				return
+			}
+			require.False(t, resp.IsError)
+			var out godo.Project
+			require.NoError(t, json.Unmarshal([]byte(text), &out))
+			require.Equal(t, *testProject, out)
+		})
+	}
+}
+
+func TestProjectsTool_updateProject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockProjects := NewMockProjectsService(ctrl)
+	mockProjects.EXPECT().
+		Update(gomock.Any(), testProjectID, &godo.UpdateProjectRequest{Name: "web-prod", IsDefault: true}).
+		Return(&godo.Project{ID: testProjectID, Name: "web-prod", IsDefault: true}, nil, nil).
+		Times(1)
+	tool := setupProjectsToolWithMocks(mockProjects)
+
+	resp, err := tool.updateProject(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"ID": testProjectID, "Name": "web-prod", "IsDefault": true,
+	}}})
+	require.NoError(t, err)
+	require.False(t, resp.IsError)
+
+	resp, err = tool.updateProject(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"ID": testProjectID}}})
+	require.NoError(t, err)
+	require.True(t, resp.IsError)
+	require.Contains(t, resp.Content[0].(mcp.TextContent).Text, "at least one of")
+}
+
+func TestProjectsTool_assignResources(t *testing.T) {
+	tests := []struct {
+		name        string
+		resources   []any
+		mockSetup   func(*MockProjectsService)
+		expectError string
+	}{
+		{
+			name: "Successful assign",
+			resources: []any{
+				"do:droplet:123",
+				"do:loadbalancer:a8bb8cfe-7c59-4b6a-8b0b-79bf1b9b2d25",
+				"do:floatingip:203.0.113.10",
+				"do:domain:example.com",
+			},
+			mockSetup: func(m *MockProjectsService) {
+				m.EXPECT().
+					AssignResources(gomock.Any(), testProjectID,
+						"do:droplet:123",
+						"do:loadbalancer:a8bb8cfe-7c59-4b6a-8b0b-79bf1b9b2d25",
+						"do:floatingip:203.0.113.10",
+						"do:domain:example.com",
+					).
+					Return([]godo.ProjectResource{
+						{URN: "do:droplet:123", Status: "assigned"},
+						{URN: "do:loadbalancer:a8bb8cfe-7c59-4b6a-8b0b-79bf1b9b2d25", Status: "assigned"},
+						{URN: "do:floatingip:203.0.113.10", Status: "assigned"},
+						{URN: "do:domain:example.com", Status: "assigned"},
+					}, nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name:      "Invalid URNs are listed and nothing is assigned",
+			resources: []any{"do:droplet:123", "do:droplet:web-1", "droplet:123", "do:loadbalancer:lb-1", "do:firewall:" + testProjectID},
+			expectError: `invalid resource URNs, nothing was assigned: "do:droplet:web-1": ID must be a positive number; ` +
+				`"droplet:123": expected do:<type>:<id>; "do:loadbalancer:lb-1": ID must be a UUID; ` +
+				`"do:firewall:` + testProjectID + `": unsupported type "firewall"`,
+		},
+		{
+			name:        "Missing resources",
+			expectError: "Resources is required",
+		},
+		{
+			name:      "API error",
+			resources: []any{"do:droplet:123"},
+			mockSetup: func(m *MockProjectsService) {
+				m.EXPECT().AssignResources(gomock.Any(), testProjectID, "do:droplet:123").Return(nil, nil, errors.New("api error")).Times(1)
+			},
+			expectError: "api error",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockProjects := NewMockProjectsService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockProjects)
+			}
+			tool := setupProjectsToolWithMocks(mockProjects)
+			resp, err := tool.assignResources(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+				"ID":        testProjectID,
+				"Resources": tc.resources,
+			}}})
+			require.NoError(t, err)
+			text := resp.Content[0].(mcp.TextContent).Text
+			if tc.expectError != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, text, tc.expectError)
+				return
+			}
+			require.False(t, resp.IsError)
+			var out []godo.ProjectResource
+			require.NoError(t, json.Unmarshal([]byte(text), &out))
+			require.Len(t, out, len(tc.resources))
+		})
+	}
+}
+
+func TestProjectsTool_getProjectNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockProjects := NewMockProjectsService(ctrl)
+	mockProjects.EXPECT().Get(gomock.Any(), testProjectID).Return(nil, nil, &godo.ErrorResponse{
+		Response: &http.Response{StatusCode: http.StatusNotFound, Request: &http.Request{Method: http.MethodGet, URL: &url.URL{Scheme: "https", Host: "api.digitalocean.com", Path: "/v2/projects/" + testProjectID}}},
+		Message:  "The resource you were accessing could not be found.",
+	}).Times(1)
+	tool := setupProjectsToolWithMocks(mockProjects)
+
+	resp, err := tool.getProject(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"ID": testProjectID}}})
+	require.NoError(t, err)
+	require.True(t, resp.IsError)
+	var out common.NotFound
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+	require.Equal(t, "project", out.Resource)
+	require.Equal(t, testProjectID, out.ID)
+}
@@ -0,0 +1,40 @@
+package registry
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// readVerbs are the name segments of tools that only read, such as get in
+// doks-get-cluster or list in lb-list.
+var readVerbs = []string{"get", "list", "describe", "search", "find", "check", "validate", "options", "status", "health", "diff", "wait", "guide"}
+
+// mutatingVerbs are the name segments of tools that change something. A name
+// with a read verb is still not read-only when it has one of these too, e.g.
+// db-cluster-get-and-reset. Words that are also nouns, such as snapshot in
+// volume-snapshot-get or action in functions-get-action, are left out; names
+// that use them as verbs have no read verb.
+var mutatingVerbs = []string{
+	"create", "update", "upsert", "edit", "delete", "remove", "add", "set", "attach", "detach", "assign", "unassign",
+	"reserve", "release", "resize", "rebuild", "restore", "reboot", "power", "shutdown", "rename", "reset",
+	"enable", "disable", "recycle", "upgrade", "start", "stop", "cancel", "flush", "invoke", "install",
+	"execute", "reassign", "switch", "change", "scale", "import", "upload", "convert", "transfer",
+}
+
+// isReadOnlyTool reports whether tool only reads, and so is registered in
+// read-only mode. mcp.NewTool annotates every tool as destructive unless it
+// says otherwise, so the annotations alone cannot tell an unannotated list
+// tool from a delete: a tool is read-only when it declares the read-only hint,
+// or when its name has a read verb and no mutating one.
+func isReadOnlyTool(tool mcp.Tool) bool {
+	if hint := tool.Annotations.ReadOnlyHint; hint != nil && *hint {
+		return true
+	}
+	segments := strings.Split(tool.Name, "-")
+	if slices.ContainsFunc(segments, func(s string) bool { return slices.Contains(mutatingVerbs, s) }) {
+		return false
+	}
+	return slices.ContainsFunc(segments, func(s string) bool { return slices.Contains(readVerbs, s) })
+}
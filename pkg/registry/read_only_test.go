@@ -0,0 +1,74 @@
+package registry
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/require"
+
+	"mcp-digitalocean/pkg/registry/common"
+)
+
+func TestIsReadOnlyTool(t *testing.T) {
+	tests := []struct {
+		tool mcp.Tool
+		want bool
+	}{
+		{tool: mcp.NewTool("lb-list"), want: true},
+		{tool: mcp.NewTool("doks-get-cluster"), want: true},
+		{tool: mcp.NewTool("volume-snapshot-get"), want: true},
+		{tool: mcp.NewTool("functions-get-action"), want: true},
+		{tool: mcp.NewTool("droplet-action", common.WithHints(common.HintsRead)), want: true},
+		{tool: mcp.NewTool("lb-delete"), want: false},
+		{tool: mcp.NewTool("doks-delete-cluster"), want: false},
+		{tool: mcp.NewTool("snapshot-droplet", common.WithHints(common.HintsAction)), want: false},
+		{tool: mcp.NewTool("droplets-run-action-by-tag"), want: false},
+		{tool: mcp.NewTool("db-cluster-get-and-reset"), want: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.tool.Name, func(t *testing.T) {
+			require.Equal(t, tc.want, isReadOnlyTool(tc.tool))
+		})
+	}
+}
+
+func TestRegister_readOnly(t *testing.T) {
+	destructive := []string{"lb-delete", "doks-delete-cluster", "droplet-delete", "resize-droplet", "rebuild-droplet", "droplet-create"}
+	tests := []struct {
+		name     string
+		readOnly bool
+	}{
+		{name: "all tools"},
+		{name: "read-only", readOnly: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := server.NewMCPServer("test", "0.0.0")
+			_, err := Register(slog.New(slog.NewTextHandler(io.Discard, nil)), s, func(ctx context.Context) (*godo.Client, error) {
+				return godo.NewFromToken("token"), nil
+			}, Options{ReadOnly: tc.readOnly})
+			require.NoError(t, err)
+
+			for _, name := range destructive {
+				if tc.readOnly {
+					require.Nil(t, s.GetTool(name), name)
+				} else {
+					require.NotNil(t, s.GetTool(name), name)
+				}
+			}
+			for _, name := range []string{"lb-list", "doks-get-cluster", "droplet-get"} {
+				require.NotNil(t, s.GetTool(name), name)
+			}
+			if tc.readOnly {
+				for name := range s.ListTools() {
+					require.NotRegexp(t, `(^|-)(create|update|delete)(-|$)`, name)
+				}
+			}
+		})
+	}
+}
@@ -1,11 +1,15 @@
 package registry
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
 
+	middleware "mcp-digitalocean/internal"
 	"mcp-digitalocean/pkg/registry/account"
 	"mcp-digitalocean/pkg/registry/apps"
 	"mcp-digitalocean/pkg/registry/common"
@@ -25,15 +29,35 @@ import (
 	"mcp-digitalocean/pkg/registry/marketplace"
 	"mcp-digitalocean/pkg/registry/networking"
 	"mcp-digitalocean/pkg/registry/nfs"
+	"mcp-digitalocean/pkg/registry/projects"
 	"mcp-digitalocean/pkg/registry/spaces"
+	"mcp-digitalocean/pkg/registry/tags"
 	"mcp-digitalocean/pkg/registry/volumes"
 
 	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
 type getClientFn func(ctx context.Context) (*godo.Client, error)
 
+// Options configures the registered tools.
+type Options struct {
+	// PreferredRegions are listed first, in this order, by placement-options.
+	PreferredRegions []string
+	// Filter limits the registered tools by name.
+	Filter ToolFilter
+	// DisableStructuredOutput removes output schemas from tools added by
+	// Reload, matching a server set up with middleware.DisableStructuredOutput.
+	DisableStructuredOutput bool
+	// FieldNaming selects the field names of droplet, load balancer and
+	// image text output; the zero value keeps godo's names.
+	FieldNaming common.FieldNaming
+	// ReadOnly registers only the tools that read, leaving out every tool
+	// that creates, changes or deletes something.
+	ReadOnly bool
+}
+
 // supportedServices is a set of services that we support in this MCP server.
 var supportedServices = map[string]struct{}{
 	"apps":                   {},
@@ -56,16 +80,18 @@ var supportedServices = map[string]struct{}{
 	"volumes":                {},
 	"functions":              {},
 	"nfs":                    {},
+	"projects":               {},
+	"tags":                   {},
 }
 
 // registerAppTools registers the app platform tools with the MCP server.
-func registerAppTools(s *server.MCPServer, getClient getClientFn) error {
+func registerAppTools(s *server.MCPServer, getClient getClientFn, logger *slog.Logger) error {
 	appTools, err := apps.NewAppPlatformTool(getClient)
 	if err != nil {
 		return fmt.Errorf("failed to create apps tool: %w", err)
 	}
 
-	s.AddTools(appTools.Tools()...)
+	s.AddTools(common.WithLogger(appTools, logger).Tools()...)
 
 	return nil
 }
@@ -73,32 +99,35 @@ func registerAppTools(s *server.MCPServer, getClient getClientFn) error {
 // registerCommonTools registers the common tools with the MCP server.
 func registerCommonTools(s *server.MCPServer, getClient getClientFn) error {
 	s.AddTools(common.NewRegionTools(getClient).Tools()...)
+	s.AddTools(common.NewStatusTools().Tools()...)
 
 	return nil
 }
 
 // registerDropletTools registers the droplet tools with the MCP server.
-func registerDropletTools(s *server.MCPServer, getClient getClientFn) error {
-	s.AddTools(droplet.NewDropletTool(getClient).Tools()...)
+func registerDropletTools(s *server.MCPServer, getClient getClientFn, logger *slog.Logger, opts Options) error {
+	s.AddTools(common.WithLogger(droplet.NewDropletTool(getClient), logger).Tools()...)
 	s.AddTools(droplet.NewDropletActionsTool(getClient).Tools()...)
-	s.AddTools(droplet.NewImageTool(getClient).Tools()...)
-	s.AddTools(droplet.NewImageActionsTool(getClient).Tools()...)
+	s.AddTools(common.WithLogger(droplet.NewImageTool(getClient), logger).Tools()...)
+	s.AddTools(common.WithLogger(droplet.NewImageActionsTool(getClient), logger).Tools()...)
 	s.AddTools(droplet.NewSizesTool(getClient).Tools()...)
+	s.AddTools(common.WithLogger(droplet.NewSnapshotsTool(getClient), logger).Tools()...)
+	s.AddTools(droplet.NewPlacementTool(getClient, opts.PreferredRegions).Tools()...)
 	return nil
 }
 
 // registerNetworkingTools registers the networking tools with the MCP server.
-func registerNetworkingTools(s *server.MCPServer, getClient getClientFn) error {
-	s.AddTools(networking.NewCertificateTool(getClient).Tools()...)
-	s.AddTools(networking.NewDomainsTool(getClient).Tools()...)
-	s.AddTools(networking.NewFirewallTool(getClient).Tools()...)
-	s.AddTools(networking.NewLoadBalancersTool(getClient).Tools()...)
-	s.AddTools(networking.NewReservedIPTool(getClient).Tools()...)
-	s.AddTools(networking.NewBYOIPPrefixTool(getClient).Tools()...)
+func registerNetworkingTools(s *server.MCPServer, getClient getClientFn, logger *slog.Logger) error {
+	s.AddTools(common.WithLogger(networking.NewCertificateTool(getClient), logger).Tools()...)
+	s.AddTools(common.WithLogger(networking.NewDomainsTool(getClient), logger).Tools()...)
+	s.AddTools(common.WithLogger(networking.NewFirewallTool(getClient), logger).Tools()...)
+	s.AddTools(common.WithLogger(networking.NewLoadBalancersTool(getClient), logger).Tools()...)
+	s.AddTools(common.WithLogger(networking.NewReservedIPTool(getClient), logger).Tools()...)
+	s.AddTools(common.WithLogger(networking.NewBYOIPPrefixTool(getClient), logger).Tools()...)
 	// Partner attachments doesn't have much users so this has been disabled
 	// s.AddTools(networking.NewPartnerAttachmentTool(c).Tools()...)
-	s.AddTools(networking.NewVPCTool(getClient).Tools()...)
-	s.AddTools(networking.NewVPCPeeringTool(getClient).Tools()...)
+	s.AddTools(common.WithLogger(networking.NewVPCTool(getClient), logger).Tools()...)
+	s.AddTools(common.WithLogger(networking.NewVPCPeeringTool(getClient), logger).Tools()...)
 	return nil
 }
 
@@ -173,11 +202,16 @@ func registerInsightsTools(s *server.MCPServer, getClient getClientFn) error {
 	s.AddTools(insights.NewUptimeTool(getClient).Tools()...)
 	s.AddTools(insights.NewUptimeCheckAlertTool(getClient).Tools()...)
 	s.AddTools(insights.NewAlertPolicyTool(getClient).Tools()...)
+	s.AddTools(insights.NewLoadBalancerMetricsTool(getClient).Tools()...)
+	s.AddTools(insights.NewDoksNodeMetricsTool(getClient).Tools()...)
 	return nil
 }
 
-func registerDOKSTools(s *server.MCPServer, getClient getClientFn) error {
-	s.AddTools(doks.NewDoksTool(getClient).Tools()...)
+func registerDOKSTools(s *server.MCPServer, getClient getClientFn, logger *slog.Logger) error {
+	if err := doks.ValidateSchemas(); err != nil {
+		return err
+	}
+	s.AddTools(common.WithLogger(doks.NewDoksTool(getClient), logger).Tools()...)
 
 	return nil
 }
@@ -209,23 +243,23 @@ func registerFunctionsTools(s *server.MCPServer, getClient getClientFn) error {
 	return nil
 }
 
-func registerDatabasesTools(s *server.MCPServer, getClient getClientFn) error {
-	s.AddTools(dbaas.NewClusterTool(getClient).Tools()...)
-	s.AddTools(dbaas.NewFirewallTool(getClient).Tools()...)
-	s.AddTools(dbaas.NewKafkaTool(getClient).Tools()...)
-	s.AddTools(dbaas.NewMongoTool(getClient).Tools()...)
-	s.AddTools(dbaas.NewMysqlTool(getClient).Tools()...)
-	s.AddTools(dbaas.NewOpenSearchTool(getClient).Tools()...)
-	s.AddTools(dbaas.NewPostgreSQLTool(getClient).Tools()...)
-	s.AddTools(dbaas.NewRedisTool(getClient).Tools()...)
-	s.AddTools(dbaas.NewUserTool(getClient).Tools()...)
+func registerDatabasesTools(s *server.MCPServer, getClient getClientFn, logger *slog.Logger) error {
+	s.AddTools(common.WithLogger(dbaas.NewClusterTool(getClient), logger).Tools()...)
+	s.AddTools(common.WithLogger(dbaas.NewFirewallTool(getClient), logger).Tools()...)
+	s.AddTools(common.WithLogger(dbaas.NewKafkaTool(getClient), logger).Tools()...)
+	s.AddTools(common.WithLogger(dbaas.NewMongoTool(getClient), logger).Tools()...)
+	s.AddTools(common.WithLogger(dbaas.NewMysqlTool(getClient), logger).Tools()...)
+	s.AddTools(common.WithLogger(dbaas.NewOpenSearchTool(getClient), logger).Tools()...)
+	s.AddTools(common.WithLogger(dbaas.NewPostgreSQLTool(getClient), logger).Tools()...)
+	s.AddTools(common.WithLogger(dbaas.NewRedisTool(getClient), logger).Tools()...)
+	s.AddTools(common.WithLogger(dbaas.NewUserTool(getClient), logger).Tools()...)
 
 	return nil
 }
 
-func registerVolumesTools(s *server.MCPServer, getClient getClientFn) error {
+func registerVolumesTools(s *server.MCPServer, getClient getClientFn, logger *slog.Logger) error {
 	s.AddTools(volumes.NewVolumeTool(getClient).Tools()...)
-	s.AddTools(volumes.NewVolumeActionsTool(getClient).Tools()...)
+	s.AddTools(common.WithLogger(volumes.NewVolumeActionsTool(getClient), logger).Tools()...)
 	return nil
 }
 
@@ -235,9 +269,145 @@ func registerNfsTools(s *server.MCPServer, getClient getClientFn) error {
 	return nil
 }
 
+func registerProjectsTools(s *server.MCPServer, getClient getClientFn, logger *slog.Logger) error {
+	s.AddTools(common.WithLogger(projects.NewProjectsTool(getClient), logger).Tools()...)
+	return nil
+}
+
+func registerTagsTools(s *server.MCPServer, getClient getClientFn, logger *slog.Logger) error {
+	s.AddTools(common.WithLogger(tags.NewTagsTool(getClient), logger).Tools()...)
+	return nil
+}
+
+// Registration summarizes what Register added to the server.
+type Registration struct {
+	// Services are the services whose tools were registered, sorted.
+	Services []string
+	// Tools is the number of tools registered on the server.
+	Tools int
+	// DestructiveTools is the number of tools annotated as destructive.
+	DestructiveTools int
+	// Added are the tools this call added or replaced, and Removed the tools
+	// it deleted, both sorted.
+	Added   []string
+	Removed []string
+
+	// tools and prompts are the names registered by this call, so a later
+	// Reload knows what it may remove.
+	tools   []string
+	prompts []string
+}
+
 // Register registers the set of tools for the specified services with the MCP server.
 // We either register a subset of tools of the services are specified, or we register all tools if no services are specified.
-func Register(logger *slog.Logger, s *server.MCPServer, getClient getClientFn, servicesToActivate ...string) error {
+// It returns a summary of what was registered.
+func Register(logger *slog.Logger, s *server.MCPServer, getClient getClientFn, opts Options, servicesToActivate ...string) (*Registration, error) {
+	return Reload(logger, s, nil, getClient, opts, servicesToActivate...)
+}
+
+// Reload makes the tools on s match the given services, opts.Filter and
+// opts.ReadOnly. Tools registered by prev that are no longer wanted are
+// deleted, new tools are added and tools whose definition changed are
+// replaced; unchanged tools are left in place, so their handlers keep their
+// state. prev is nil for the first registration. The server notifies connected clients when its tools change.
+func Reload(logger *slog.Logger, s *server.MCPServer, prev *Registration, getClient getClientFn, opts Options, servicesToActivate ...string) (*Registration, error) {
+	if err := opts.Filter.validate(); err != nil {
+		return nil, err
+	}
+	scratch := server.NewMCPServer("registry", "")
+	services, err := registerServices(logger, scratch, getClient, opts, servicesToActivate)
+	if err != nil {
+		return nil, err
+	}
+
+	registration := &Registration{Services: slices.Sorted(slices.Values(services))}
+	servicesChanged := prev != nil && !slices.Equal(prev.Services, registration.Services)
+	wanted := map[string]bool{}
+	var added []server.ServerTool
+	for name, tool := range scratch.ListTools() {
+		// an alias follows its canonical tool as well as its own name
+		if canonical := AliasOf(tool.Tool); !opts.Filter.allows(name) || canonical != "" && !opts.Filter.allows(canonical) {
+			continue
+		}
+		if opts.ReadOnly && !isReadOnlyTool(tool.Tool) {
+			continue
+		}
+		wanted[name] = true
+		registration.tools = append(registration.tools, name)
+		if opts.DisableStructuredOutput {
+			tool.Tool = middleware.WithoutOutputSchema(tool.Tool)
+		}
+		*tool = withFieldNaming(*tool, opts.FieldNaming)
+		current := s.GetTool(name)
+		if current == nil || !sameTool(current.Tool, tool.Tool) || servicesChanged && slices.Contains(serviceDependentTools, name) {
+			added = append(added, *tool)
+			registration.Added = append(registration.Added, name)
+		}
+	}
+	if prev != nil {
+		for _, name := range prev.tools {
+			if !wanted[name] {
+				registration.Removed = append(registration.Removed, name)
+			}
+		}
+	}
+	slices.Sort(registration.tools)
+	slices.Sort(registration.Added)
+	slices.Sort(registration.Removed)
+	if len(registration.Removed) > 0 {
+		s.DeleteTools(registration.Removed...)
+	}
+	if len(added) > 0 {
+		s.AddTools(added...)
+	}
+
+	// prompts are not filtered; they follow their service.
+	var prompts []server.ServerPrompt
+	current := s.ListPrompts()
+	for name, prompt := range scratch.ListPrompts() {
+		registration.prompts = append(registration.prompts, name)
+		if _, ok := current[name]; !ok {
+			prompts = append(prompts, *prompt)
+		}
+	}
+	if prev != nil {
+		var removedPrompts []string
+		for _, name := range prev.prompts {
+			if !slices.Contains(registration.prompts, name) {
+				removedPrompts = append(removedPrompts, name)
+			}
+		}
+		if len(removedPrompts) > 0 {
+			s.DeletePrompts(removedPrompts...)
+		}
+	}
+	if len(prompts) > 0 {
+		s.AddPrompts(prompts...)
+	}
+
+	for _, tool := range s.ListTools() {
+		registration.Tools++
+		if hint := tool.Tool.Annotations.DestructiveHint; hint != nil && *hint {
+			registration.DestructiveTools++
+		}
+	}
+	return registration, nil
+}
+
+// serviceDependentTools are tools whose handler, but not definition, depends
+// on the enabled services, so Reload replaces them whenever those change.
+var serviceDependentTools = []string{"describe-services"}
+
+// sameTool reports whether a and b would be listed to clients identically.
+func sameTool(a, b mcp.Tool) bool {
+	aJSON, aErr := json.Marshal(a)
+	bJSON, bErr := json.Marshal(b)
+	return aErr == nil && bErr == nil && bytes.Equal(aJSON, bJSON)
+}
+
+// registerServices registers the tools of the given services, or of all
+// supported services when none are given, and returns the services used.
+func registerServices(logger *slog.Logger, s *server.MCPServer, getClient getClientFn, opts Options, servicesToActivate []string) ([]string, error) {
 	if len(servicesToActivate) == 0 {
 		logger.Warn("no services specified, loading all supported services")
 		for k := range supportedServices {
@@ -249,96 +419,115 @@ func Register(logger *slog.Logger, s *server.MCPServer, getClient getClientFn, s
 		logger.Debug(fmt.Sprintf("Registering tool and resources for service: %s", svc))
 		switch svc {
 		case "apps":
-			if err := registerAppTools(s, getClient); err != nil {
-				return fmt.Errorf("failed to register app tools: %w", err)
+			if err := registerAppTools(s, getClient, logger); err != nil {
+				return nil, fmt.Errorf("failed to register app tools: %w", err)
 			}
 		case "networking":
-			if err := registerNetworkingTools(s, getClient); err != nil {
-				return fmt.Errorf("failed to register networking tools: %w", err)
+			if err := registerNetworkingTools(s, getClient, logger); err != nil {
+				return nil, fmt.Errorf("failed to register networking tools: %w", err)
 			}
 		case "droplets":
-			if err := registerDropletTools(s, getClient); err != nil {
-				return fmt.Errorf("failed to register droplets tool: %w", err)
+			if err := registerDropletTools(s, getClient, logger, opts); err != nil {
+				return nil, fmt.Errorf("failed to register droplets tool: %w", err)
 			}
 		case "accounts":
 			if err := registerAccountTools(s, getClient); err != nil {
-				return fmt.Errorf("failed to register account tools: %w", err)
+				return nil, fmt.Errorf("failed to register account tools: %w", err)
 			}
 		case "spaces":
 			if err := registerSpacesTools(s, getClient); err != nil {
-				return fmt.Errorf("failed to register spaces tools: %w", err)
+				return nil, fmt.Errorf("failed to register spaces tools: %w", err)
 			}
 		case "databases":
-			if err := registerDatabasesTools(s, getClient); err != nil {
-				return fmt.Errorf("failed to register databases tools: %w", err)
+			if err := registerDatabasesTools(s, getClient, logger); err != nil {
+				return nil, fmt.Errorf("failed to register databases tools: %w", err)
 			}
 		case "marketplace":
 			if err := registerMarketplaceTools(s, getClient); err != nil {
-				return fmt.Errorf("failed to register marketplace tools: %w", err)
+				return nil, fmt.Errorf("failed to register marketplace tools: %w", err)
 			}
 		case "dedicated-inference":
 			if err := registerDedicatedInferenceTools(s, getClient); err != nil {
-				return fmt.Errorf("failed to register dedicated-inference tools: %w", err)
+				return nil, fmt.Errorf("failed to register dedicated-inference tools: %w", err)
 			}
 		case "inference-modelcatalog":
 			if err := registerModelCatalogTools(s, getClient); err != nil {
-				return fmt.Errorf("failed to register inference-modelcatalog tools: %w", err)
+				return nil, fmt.Errorf("failed to register inference-modelcatalog tools: %w", err)
 			}
 		case "genai-evaluation":
 			if err := registerGenAIEvaluationTools(s, getClient); err != nil {
-				return fmt.Errorf("failed to register genai-evaluation tools: %w", err)
+				return nil, fmt.Errorf("failed to register genai-evaluation tools: %w", err)
 			}
 		case "genai-custom-models":
 			if err := registerGenAICustomModelsTools(s, getClient); err != nil {
-				return fmt.Errorf("failed to register genai-custom-models tools: %w", err)
+				return nil, fmt.Errorf("failed to register genai-custom-models tools: %w", err)
 			}
 		case "genai-batchinference":
 			if err := registerGenAIBatchInferenceTools(s, getClient); err != nil {
-				return fmt.Errorf("failed to register genai-batchinference tools: %w", err)
+				return nil, fmt.Errorf("failed to register genai-batchinference tools: %w", err)
 			}
 		case "genai-inferencerouter":
 			if err := registerGenAIInferenceRouterTools(s, getClient); err != nil {
-				return fmt.Errorf("failed to register genai-inferencerouter tools: %w", err)
+				return nil, fmt.Errorf("failed to register genai-inferencerouter tools: %w", err)
 			}
 		case "insights":
 			if err := registerInsightsTools(s, getClient); err != nil {
-				return fmt.Errorf("failed to register insights tools: %w", err)
+				return nil, fmt.Errorf("failed to register insights tools: %w", err)
 			}
 		case "doks":
-			if err := registerDOKSTools(s, getClient); err != nil {
-				return fmt.Errorf("failed to register DOKS tools: %w", err)
+			if err := registerDOKSTools(s, getClient, logger); err != nil {
+				return nil, fmt.Errorf("failed to register DOKS tools: %w", err)
 			}
 		case "docr":
 			if err := registerDOCRTools(s, getClient); err != nil {
-				return fmt.Errorf("failed to register DOCR tools: %w", err)
+				return nil, fmt.Errorf("failed to register DOCR tools: %w", err)
 			}
 		case "docs":
 			if err := registerDocsTools(s); err != nil {
-				return fmt.Errorf("failed to register docs tools: %w", err)
+				return nil, fmt.Errorf("failed to register docs tools: %w", err)
 			}
 		case "volumes":
-			if err := registerVolumesTools(s, getClient); err != nil {
-				return fmt.Errorf("failed to register volumes tools: %w", err)
+			if err := registerVolumesTools(s, getClient, logger); err != nil {
+				return nil, fmt.Errorf("failed to register volumes tools: %w", err)
 			}
 		case "functions":
 			if err := registerFunctionsTools(s, getClient); err != nil {
-				return fmt.Errorf("failed to register functions tools: %w", err)
+				return nil, fmt.Errorf("failed to register functions tools: %w", err)
 			}
 		case "nfs":
 			if err := registerNfsTools(s, getClient); err != nil {
-				return fmt.Errorf("failed to register nfs tools: %w", err)
+				return nil, fmt.Errorf("failed to register nfs tools: %w", err)
+			}
+		case "projects":
+			if err := registerProjectsTools(s, getClient, logger); err != nil {
+				return nil, fmt.Errorf("failed to register projects tools: %w", err)
+			}
+		case "tags":
+			if err := registerTagsTools(s, getClient, logger); err != nil {
+				return nil, fmt.Errorf("failed to register tags tools: %w", err)
 			}
 		default:
-			return fmt.Errorf("unsupported service: %s, supported service are: %v", svc, setToString(supportedServices))
+			return nil, fmt.Errorf("unsupported service: %s, supported service are: %v", svc, setToString(supportedServices))
 		}
 	}
 
 	// Common tools are always registered because they provide common functionality for all services such as region resources
 	if err := registerCommonTools(s, getClient); err != nil {
-		return fmt.Errorf("failed to register common tools: %w", err)
+		return nil, fmt.Errorf("failed to register common tools: %w", err)
 	}
 
-	return nil
+	// Composite tools are registered only when every service they call is enabled.
+	for _, group := range compositeGroups {
+		if missing := group.missingDependencies(servicesToActivate); len(missing) > 0 {
+			logger.Debug(fmt.Sprintf("Skipping composite tools %s, missing services: %v", group.name, missing))
+			continue
+		}
+		s.AddTools(group.tools(getClient)...)
+	}
+	aliases := registerAliases(s)
+	s.AddTools(describeServicesTool(describeServices(getClient, servicesToActivate, aliases)))
+
+	return servicesToActivate, nil
 }
 
 func setToString(set map[string]struct{}) string {
@@ -0,0 +1,141 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"testing"
+
+	"mcp-digitalocean/internal/testhelpers"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegister_compositeTools(t *testing.T) {
+	tests := []struct {
+		name            string
+		services        []string
+		expectedMissing []string
+	}{
+		{name: "no dependencies", services: []string{"accounts"}, expectedMissing: []string{"droplets", "networking", "volumes"}},
+		{name: "droplets", services: []string{"droplets"}, expectedMissing: []string{"networking", "volumes"}},
+		{name: "networking", services: []string{"networking"}, expectedMissing: []string{"droplets", "volumes"}},
+		{name: "volumes", services: []string{"volumes"}, expectedMissing: []string{"droplets", "networking"}},
+		{name: "droplets and networking", services: []string{"droplets", "networking"}, expectedMissing: []string{"volumes"}},
+		{name: "droplets and volumes", services: []string{"droplets", "volumes"}, expectedMissing: []string{"networking"}},
+		{name: "networking and volumes", services: []string{"networking", "volumes"}, expectedMissing: []string{"droplets"}},
+		{name: "all dependencies", services: []string{"volumes", "droplets", "networking"}},
+		{name: "all services"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := server.NewMCPServer("test", "0.0.0")
+			_, err := Register(slog.New(slog.NewTextHandler(io.Discard, nil)), s, func(ctx context.Context) (*godo.Client, error) {
+				return godo.NewFromToken("token"), nil
+			}, Options{}, tc.services...)
+			require.NoError(t, err)
+
+			if len(tc.expectedMissing) > 0 {
+				require.Nil(t, s.GetTool("resource-export"))
+			} else {
+				require.NotNil(t, s.GetTool("resource-export"))
+			}
+
+			describe := s.GetTool("describe-services")
+			require.NotNil(t, describe)
+			resp, err := describe.Handler(context.Background(), mcp.CallToolRequest{})
+			require.NoError(t, err)
+			var description ServicesDescription
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &description))
+
+			require.Len(t, description.Supported, len(supportedServices))
+			if tc.services != nil {
+				require.ElementsMatch(t, tc.services, description.Enabled)
+			}
+			require.Len(t, description.Composite, len(compositeGroups))
+			require.Equal(t, CompositeDescription{
+				Name:      "resource-export",
+				Tools:     []string{"resource-export"},
+				DependsOn: []string{"droplets", "networking", "volumes"},
+				Enabled:   len(tc.expectedMissing) == 0,
+				Missing:   tc.expectedMissing,
+			}, description.Composite[0])
+		})
+	}
+}
+
+func TestRegister_compositeGroups(t *testing.T) {
+	getClient := func(ctx context.Context) (*godo.Client, error) {
+		return godo.NewFromToken("token"), nil
+	}
+	for _, group := range compositeGroups {
+		t.Run(group.name, func(t *testing.T) {
+			for i := range group.dependsOn {
+				// without any one of its services, the group is not registered.
+				services := slices.Delete(slices.Clone(group.dependsOn), i, i+1)
+				s := server.NewMCPServer("test", "0.0.0")
+				_, err := Register(slog.New(slog.NewTextHandler(io.Discard, nil)), s, getClient, Options{}, services...)
+				require.NoError(t, err)
+				for _, tool := range group.tools(getClient) {
+					require.Nil(t, s.GetTool(tool.Tool.Name), "%s without %s", tool.Tool.Name, group.dependsOn[i])
+				}
+			}
+
+			s := server.NewMCPServer("test", "0.0.0")
+			_, err := Register(slog.New(slog.NewTextHandler(io.Discard, nil)), s, getClient, Options{}, group.dependsOn...)
+			require.NoError(t, err)
+			for _, tool := range group.tools(getClient) {
+				require.NotNil(t, s.GetTool(tool.Tool.Name), tool.Tool.Name)
+			}
+		})
+	}
+}
+
+func TestRegister_logger(t *testing.T) {
+	api := testhelpers.NewFakeAPI(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		testhelpers.WriteJSON(w, http.StatusInternalServerError, map[string]any{"id": "server_error", "message": "boom"})
+	}))
+	t.Cleanup(api.Close)
+	getClient := func(ctx context.Context) (*godo.Client, error) {
+		return godo.New(http.DefaultClient, godo.SetBaseURL(api.URL))
+	}
+
+	tests := []struct {
+		service string
+		tool    string
+		args    map[string]any
+	}{
+		{service: "apps", tool: "apps-get-info", args: map[string]any{"AppID": "app-1"}},
+		{service: "droplets", tool: "snapshot-get", args: map[string]any{"ID": "6372321"}},
+		{service: "projects", tool: "project-get", args: map[string]any{"ID": "project-1"}},
+		{service: "tags", tool: "tag-get", args: map[string]any{"Name": "web"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.tool, func(t *testing.T) {
+			// without the request logger middleware, handlers log through the
+			// logger given to Register.
+			var logs bytes.Buffer
+			s := server.NewMCPServer("test", "0.0.0")
+			_, err := Register(slog.New(slog.NewTextHandler(&logs, nil)), s, getClient, Options{}, tc.service)
+			require.NoError(t, err)
+
+			tool := s.GetTool(tc.tool)
+			require.NotNil(t, tool)
+			req := mcp.CallToolRequest{}
+			req.Params.Arguments = tc.args
+			result, err := tool.Handler(context.Background(), req)
+			require.NoError(t, err)
+			require.True(t, result.IsError)
+			require.Contains(t, logs.String(), "level=ERROR")
+			require.Contains(t, logs.String(), "boom")
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	middleware "mcp-digitalocean/internal"
+	"mcp-digitalocean/pkg/registry/common"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ReloadResult is the result of server-reload-tools.
+type ReloadResult struct {
+	Services []string `json:"services"`
+	Tools    int      `json:"tools"`
+	Added    []string `json:"added"`
+	Removed  []string `json:"removed"`
+}
+
+// Reloader re-reads the tool configuration file and updates the tools on a
+// running server to match, so filters can be changed without a restart.
+type Reloader struct {
+	logger     *slog.Logger
+	server     *server.MCPServer
+	getClient  getClientFn
+	opts       Options
+	configFile string
+	// services are used when the configuration file names none.
+	services []string
+
+	// Quotas, when set, are given the quota lines of the reloaded file.
+	Quotas *middleware.CreateQuota
+
+	mu      sync.Mutex
+	current *Registration
+}
+
+// NewReloader creates a Reloader for the tools currently registered on s.
+// configFile may be empty, in which case reloading is refused.
+func NewReloader(logger *slog.Logger, s *server.MCPServer, getClient getClientFn, opts Options, configFile string, services []string, current *Registration) *Reloader {
+	return &Reloader{
+		logger:     logger,
+		server:     s,
+		getClient:  getClient,
+		opts:       opts,
+		configFile: configFile,
+		services:   services,
+		current:    current,
+	}
+}
+
+// reload applies the configuration file. If the file cannot be read or is
+// invalid, the current tools are kept.
+func (r *Reloader) reload(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if r.configFile == "" {
+		return mcp.NewToolResultError("no --tools-config-file is set, so there is no configuration to reload"), nil
+	}
+	cfg, err := LoadToolConfig(r.configFile)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("failed to load the tool configuration; the current tools are unchanged", err), nil
+	}
+	services := r.services
+	if len(cfg.Services) > 0 {
+		services = cfg.Services
+	}
+	opts := r.opts
+	opts.Filter = cfg.Filter
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	registration, err := Reload(r.logger, r.server, r.current, r.getClient, opts, services...)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("failed to reload tools; the current tools are unchanged", err), nil
+	}
+	r.current = registration
+	if r.Quotas != nil {
+		r.Quotas.SetQuotas(cfg.Quotas)
+	}
+	r.logger.InfoContext(ctx, "tools reloaded", "added", len(registration.Added), "removed", len(registration.Removed), "tools", registration.Tools, "quota_tokens", len(cfg.Quotas))
+
+	result := ReloadResult{
+		Services: registration.Services,
+		Tools:    registration.Tools,
+		Added:    append([]string{}, registration.Added...),
+		Removed:  append([]string{}, registration.Removed...),
+	}
+	resultJSON, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// Tools returns the server-reload-tools admin tool.
+func (r *Reloader) Tools() []server.ServerTool {
+	return []server.ServerTool{
+		{
+			Handler: r.reload,
+			Tool: mcp.NewTool("server-reload-tools",
+				mcp.WithDescription("Re-read the server's tool configuration file (services, allow and deny patterns and creation quotas) and add or remove tools and apply the quotas to match, without a restart. Connected clients are notified that the tool list changed. Returns the tools added and removed."),
+				common.WithHints(common.HintsAction),
+			),
+		},
+	}
+}
@@ -0,0 +1,188 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	middleware "mcp-digitalocean/internal"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSession struct {
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (f *fakeSession) Initialize()       {}
+func (f *fakeSession) Initialized() bool { return true }
+func (f *fakeSession) SessionID() string { return "session-1" }
+func (f *fakeSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return f.notifications
+}
+
+// drainNotifications returns the methods of the notifications sent so far.
+func drainNotifications(session *fakeSession) []string {
+	var methods []string
+	for {
+		select {
+		case n := <-session.notifications:
+			methods = append(methods, n.Method)
+		default:
+			return methods
+		}
+	}
+}
+
+func testClient(ctx context.Context) (*godo.Client, error) {
+	return godo.NewFromToken("token"), nil
+}
+
+// setupReloader registers services on a new server with a connected session
+// and returns a Reloader for the config file, which starts out empty.
+func setupReloader(t *testing.T, services ...string) (*server.MCPServer, *Reloader, *fakeSession, string) {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	s := server.NewMCPServer("test", "0.0.0")
+	registration, err := Register(logger, s, testClient, Options{}, services...)
+	require.NoError(t, err)
+
+	session := &fakeSession{notifications: make(chan mcp.JSONRPCNotification, 10)}
+	require.NoError(t, s.RegisterSession(context.Background(), session))
+
+	configFile := filepath.Join(t.TempDir(), "tools.conf")
+	require.NoError(t, os.WriteFile(configFile, nil, 0o600))
+	return s, NewReloader(logger, s, testClient, Options{}, configFile, services, registration), session, configFile
+}
+
+func callReload(t *testing.T, r *Reloader) (*mcp.CallToolResult, ReloadResult) {
+	t.Helper()
+	resp, err := r.reload(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	var result ReloadResult
+	if !resp.IsError {
+		require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &result))
+	}
+	return resp, result
+}
+
+func TestReloader_addService(t *testing.T) {
+	s, reloader, session, configFile := setupReloader(t, "accounts")
+	require.Nil(t, s.GetTool("volume-create"))
+	before := s.GetTool("balance-get")
+	require.NotNil(t, before)
+
+	require.NoError(t, os.WriteFile(configFile, []byte("services=accounts,volumes\n"), 0o600))
+	resp, result := callReload(t, reloader)
+	require.False(t, resp.IsError)
+
+	require.NotNil(t, s.GetTool("volume-create"))
+	require.Contains(t, result.Added, "volume-create")
+	require.Contains(t, result.Added, "describe-services", "describe-services reports the enabled services")
+	require.NotContains(t, result.Added, "balance-get")
+	require.Empty(t, result.Removed)
+	require.Equal(t, []string{"accounts", "volumes"}, result.Services)
+	require.Equal(t, len(s.ListTools()), result.Tools)
+	// unchanged tools keep their handler, and with it any state it holds.
+	require.Equal(t, reflect.ValueOf(before.Handler).Pointer(), reflect.ValueOf(s.GetTool("balance-get").Handler).Pointer())
+	require.Contains(t, drainNotifications(session), "notifications/tools/list_changed")
+
+	// a second reload with the same configuration changes nothing.
+	resp, result = callReload(t, reloader)
+	require.False(t, resp.IsError)
+	require.Empty(t, result.Added)
+	require.Empty(t, result.Removed)
+	require.Empty(t, drainNotifications(session))
+}
+
+func TestReloader_denyPattern(t *testing.T) {
+	s, reloader, session, configFile := setupReloader(t, "volumes")
+	require.NotNil(t, s.GetTool("volume-delete"))
+	adminTool := reloader.Tools()[0]
+	s.AddTools(adminTool)
+	drainNotifications(session)
+
+	require.NoError(t, os.WriteFile(configFile, []byte("deny=*-delete*\n"), 0o600))
+	resp, result := callReload(t, reloader)
+	require.False(t, resp.IsError)
+
+	require.Nil(t, s.GetTool("volume-delete"))
+	require.Contains(t, result.Removed, "volume-delete")
+	require.NotNil(t, s.GetTool("volume-create"))
+	require.NotNil(t, s.GetTool("server-reload-tools"), "tools added outside the registry are left alone")
+	require.Equal(t, []string{"notifications/tools/list_changed"}, drainNotifications(session))
+
+	// removing the pattern brings the tool back.
+	require.NoError(t, os.WriteFile(configFile, nil, 0o600))
+	resp, result = callReload(t, reloader)
+	require.False(t, resp.IsError)
+	require.NotNil(t, s.GetTool("volume-delete"))
+	require.Contains(t, result.Added, "volume-delete")
+}
+
+func TestReload_disableStructuredOutput(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	s := server.NewMCPServer("test", "0.0.0")
+	opts := Options{DisableStructuredOutput: true}
+	registration, err := Register(logger, s, testClient, opts, "droplets")
+	require.NoError(t, err)
+	middleware.DisableStructuredOutput(s)
+
+	registration, err = Reload(logger, s, registration, testClient, opts, "droplets", "volumes")
+	require.NoError(t, err)
+	require.NotContains(t, registration.Added, "droplet-get", "tools whose output schema was removed are unchanged")
+	for name, tool := range s.ListTools() {
+		require.Nil(t, tool.Tool.RawOutputSchema, name)
+		require.Empty(t, tool.Tool.OutputSchema.Type, name)
+	}
+}
+
+func TestReloader_quotas(t *testing.T) {
+	_, reloader, _, configFile := setupReloader(t, "volumes")
+	quota := middleware.NewCreateQuota(nil, func(toolName string) bool { return true })
+	reloader.Quotas = quota
+
+	require.NoError(t, os.WriteFile(configFile, []byte("quota=default: volume-create=1\n"), 0o600))
+	resp, _ := callReload(t, reloader)
+	require.False(t, resp.IsError)
+	require.Equal(t, middleware.Quotas{middleware.QuotaDefault: {{Tools: "volume-create", Limit: 1}}}, quota.Quotas)
+
+	// an invalid file keeps the current quotas too.
+	require.NoError(t, os.WriteFile(configFile, []byte("quota=default: volume-create\n"), 0o600))
+	resp, _ = callReload(t, reloader)
+	require.True(t, resp.IsError)
+	require.Equal(t, middleware.Quotas{middleware.QuotaDefault: {{Tools: "volume-create", Limit: 1}}}, quota.Quotas)
+
+	require.NoError(t, os.WriteFile(configFile, nil, 0o600))
+	resp, _ = callReload(t, reloader)
+	require.False(t, resp.IsError)
+	require.Empty(t, quota.Quotas)
+}
+
+func TestReloader_invalidConfigKeepsTools(t *testing.T) {
+	s, reloader, session, configFile := setupReloader(t, "volumes")
+	toolCount := len(s.ListTools())
+
+	require.NoError(t, os.WriteFile(configFile, []byte("services=volumes,servers\n"), 0o600))
+	resp, _ := callReload(t, reloader)
+	require.True(t, resp.IsError)
+	require.Contains(t, resp.Content[0].(mcp.TextContent).Text, "the current tools are unchanged")
+	require.Len(t, s.ListTools(), toolCount)
+	require.Empty(t, drainNotifications(session))
+}
+
+func TestReloader_noConfigFile(t *testing.T) {
+	s := server.NewMCPServer("test", "0.0.0")
+	reloader := NewReloader(slog.New(slog.NewTextHandler(io.Discard, nil)), s, testClient, Options{}, "", nil, nil)
+	resp, _ := callReload(t, reloader)
+	require.True(t, resp.IsError)
+	require.Contains(t, resp.Content[0].(mcp.TextContent).Text, "no --tools-config-file is set")
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"mcp-digitalocean/pkg/registry/common"
 )
 
 // CDNTool provides CDN management tools
@@ -74,14 +76,34 @@ func (c *CDNTool) listCDNs(ctx context.Context, req mcp.CallToolRequest) (*mcp.C
 	return mcp.NewToolResultText(string(jsonCDNs)), nil
 }
 
-// createCDN creates a new CDN
-func (c *CDNTool) createCDN(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	origin := req.GetArguments()["Origin"].(string)
-	ttl := uint32(req.GetArguments()["TTL"].(float64))
+// spacesOriginSuffix is the host suffix every Spaces bucket origin ends with.
+const spacesOriginSuffix = ".digitaloceanspaces.com"
 
-	createRequest := &godo.CDNCreateRequest{
-		Origin: origin,
-		TTL:    ttl,
+// createCDN creates a new CDN, optionally served from a custom domain
+func (c *CDNTool) createCDN(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	origin, _ := args["Origin"].(string)
+	if origin == "" {
+		return mcp.NewToolResultError("Origin is required"), nil
+	}
+	if !strings.HasSuffix(strings.ToLower(origin), spacesOriginSuffix) {
+		return mcp.NewToolResultError(fmt.Sprintf("Origin must be a Spaces origin hostname ending in %s, such as my-bucket.nyc3%s, got %q", spacesOriginSuffix, spacesOriginSuffix, origin)), nil
+	}
+	ttl, ok := args["TTL"].(float64)
+	if !ok || ttl <= 0 {
+		return mcp.NewToolResultError("TTL must be a positive number of seconds"), nil
+	}
+	customDomain, _ := args["CustomDomain"].(string)
+	certificateID, _ := args["CertificateID"].(string)
+	certificateName, _ := args["CertificateName"].(string)
+	if certificateID != "" && certificateName != "" {
+		return mcp.NewToolResultError("only one of CertificateID or CertificateName may be set"), nil
+	}
+	if customDomain == "" && (certificateID != "" || certificateName != "") {
+		return mcp.NewToolResultError("CustomDomain is required when a certificate is provided"), nil
+	}
+	if customDomain != "" && certificateID == "" && certificateName == "" {
+		return mcp.NewToolResultError("CertificateID or CertificateName is required when CustomDomain is set"), nil
 	}
 
 	client, err := c.client(ctx)
@@ -89,6 +111,24 @@ func (c *CDNTool) createCDN(ctx context.Context, req mcp.CallToolRequest) (*mcp.
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
+	if certificateName != "" {
+		certs, err := common.FetchAll(ctx, common.MaxPerPage, client.Certificates.List)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("api error", err), nil
+		}
+		certificateID, err = resolveCertificateID(certificateName, certs)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+
+	createRequest := &godo.CDNCreateRequest{
+		Origin:        origin,
+		TTL:           uint32(ttl),
+		CustomDomain:  customDomain,
+		CertificateID: certificateID,
+	}
+
 	cdn, _, err := client.CDNs.Create(ctx, createRequest)
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("api error", err), nil
@@ -102,9 +142,30 @@ func (c *CDNTool) createCDN(ctx context.Context, req mcp.CallToolRequest) (*mcp.
 	return mcp.NewToolResultText(string(jsonCDN)), nil
 }
 
+// resolveCertificateID returns the ID of the one certificate named name.
+func resolveCertificateID(name string, certs []godo.Certificate) (string, error) {
+	var ids []string
+	for _, cert := range certs {
+		if cert.Name == name {
+			ids = append(ids, cert.ID)
+		}
+	}
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no certificate named %q", name)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("%d certificates are named %q (%s); pass CertificateID instead", len(ids), name, strings.Join(ids, ", "))
+	}
+}
+
 // deleteCDN deletes a CDN
 func (c *CDNTool) deleteCDN(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	cdnID := req.GetArguments()["ID"].(string)
+	cdnID, ok := req.GetArguments()["ID"].(string)
+	if !ok || cdnID == "" {
+		return mcp.NewToolResultError("CDN ID is required"), nil
+	}
 
 	client, err := c.client(ctx)
 	if err != nil {
@@ -169,9 +230,12 @@ func (c *CDNTool) Tools() []server.ServerTool {
 		{
 			Handler: c.createCDN,
 			Tool: mcp.NewTool("spaces-cdn-create",
-				mcp.WithDescription("Create a new CDN"),
-				mcp.WithString("Origin", mcp.Required(), mcp.Description("Origin URL for the CDN")),
-				mcp.WithNumber("TTL", mcp.Required(), mcp.Description("Time-to-live for the CDN cache")),
+				mcp.WithDescription("Create a new CDN endpoint for a Spaces bucket, optionally served from a custom domain"),
+				mcp.WithString("Origin", mcp.Required(), mcp.Description("Spaces origin hostname, such as my-bucket.nyc3.digitaloceanspaces.com")),
+				mcp.WithNumber("TTL", mcp.Required(), mcp.Description("Time-to-live for the CDN cache in seconds")),
+				mcp.WithString("CustomDomain", mcp.Description("Fully qualified custom subdomain to serve the CDN from. Requires CertificateID or CertificateName")),
+				mcp.WithString("CertificateID", mcp.Description("ID of the certificate for CustomDomain")),
+				mcp.WithString("CertificateName", mcp.Description("Name of the certificate for CustomDomain, resolved to its ID. Use instead of CertificateID")),
 			),
 		},
 		{
@@ -181,68 +181,189 @@ func TestCDNTool_createCDN(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	const origin = "assets.nyc3.digitaloceanspaces.com"
 	testCDN := &godo.CDN{
 		ID:     "cdn-123",
-		Origin: "origin.example.com",
+		Origin: origin,
 		TTL:    3600,
 	}
+	certs := []godo.Certificate{
+		{ID: "cert-1", Name: "cdn-cert"},
+		{ID: "cert-2", Name: "shared"},
+		{ID: "cert-3", Name: "shared"},
+	}
 	tests := []struct {
 		name        string
 		args        map[string]any
-		mockSetup   func(*MockCDNService)
-		expectError bool
+		mockSetup   func(*MockCDNService, *MockCertificatesService)
+		expectError string
 	}{
 		{
 			name: "Successful create",
 			args: map[string]any{
-				"Origin": "origin.example.com",
+				"Origin": origin,
 				"TTL":    float64(3600),
 			},
-			mockSetup: func(m *MockCDNService) {
+			mockSetup: func(m *MockCDNService, _ *MockCertificatesService) {
 				m.EXPECT().
 					Create(gomock.Any(), &godo.CDNCreateRequest{
-						Origin: "origin.example.com",
+						Origin: origin,
 						TTL:    3600,
 					}).
 					Return(testCDN, nil, nil).
 					Times(1)
 			},
 		},
+		{
+			name: "Custom domain with certificate ID",
+			args: map[string]any{
+				"Origin":        origin,
+				"TTL":           float64(3600),
+				"CustomDomain":  "static.example.com",
+				"CertificateID": "cert-1",
+			},
+			mockSetup: func(m *MockCDNService, _ *MockCertificatesService) {
+				m.EXPECT().
+					Create(gomock.Any(), &godo.CDNCreateRequest{
+						Origin:        origin,
+						TTL:           3600,
+						CustomDomain:  "static.example.com",
+						CertificateID: "cert-1",
+					}).
+					Return(testCDN, nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name: "Custom domain with certificate name",
+			args: map[string]any{
+				"Origin":          origin,
+				"TTL":             float64(3600),
+				"CustomDomain":    "static.example.com",
+				"CertificateName": "cdn-cert",
+			},
+			mockSetup: func(m *MockCDNService, c *MockCertificatesService) {
+				c.EXPECT().
+					List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: 200}).
+					Return(certs, nil, nil).
+					Times(1)
+				m.EXPECT().
+					Create(gomock.Any(), &godo.CDNCreateRequest{
+						Origin:        origin,
+						TTL:           3600,
+						CustomDomain:  "static.example.com",
+						CertificateID: "cert-1",
+					}).
+					Return(testCDN, nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name: "Unknown certificate name",
+			args: map[string]any{
+				"Origin":          origin,
+				"TTL":             float64(3600),
+				"CustomDomain":    "static.example.com",
+				"CertificateName": "missing",
+			},
+			mockSetup: func(_ *MockCDNService, c *MockCertificatesService) {
+				c.EXPECT().List(gomock.Any(), gomock.Any()).Return(certs, nil, nil).Times(1)
+			},
+			expectError: `no certificate named "missing"`,
+		},
+		{
+			name: "Ambiguous certificate name",
+			args: map[string]any{
+				"Origin":          origin,
+				"TTL":             float64(3600),
+				"CustomDomain":    "static.example.com",
+				"CertificateName": "shared",
+			},
+			mockSetup: func(_ *MockCDNService, c *MockCertificatesService) {
+				c.EXPECT().List(gomock.Any(), gomock.Any()).Return(certs, nil, nil).Times(1)
+			},
+			expectError: `2 certificates are named "shared" (cert-2, cert-3); pass CertificateID instead`,
+		},
+		{
+			name: "Certificate without custom domain",
+			args: map[string]any{
+				"Origin":        origin,
+				"TTL":           float64(3600),
+				"CertificateID": "cert-1",
+			},
+			expectError: "CustomDomain is required when a certificate is provided",
+		},
+		{
+			name: "Custom domain without certificate",
+			args: map[string]any{
+				"Origin":       origin,
+				"TTL":          float64(3600),
+				"CustomDomain": "static.example.com",
+			},
+			expectError: "CertificateID or CertificateName is required when CustomDomain is set",
+		},
+		{
+			name: "Both certificate ID and name",
+			args: map[string]any{
+				"Origin":          origin,
+				"TTL":             float64(3600),
+				"CustomDomain":    "static.example.com",
+				"CertificateID":   "cert-1",
+				"CertificateName": "cdn-cert",
+			},
+			expectError: "only one of CertificateID or CertificateName may be set",
+		},
+		{
+			name: "Non-Spaces origin",
+			args: map[string]any{
+				"Origin": "origin.example.com",
+				"TTL":    float64(3600),
+			},
+			expectError: "Origin must be a Spaces origin hostname",
+		},
+		{
+			name:        "Missing TTL",
+			args:        map[string]any{"Origin": origin},
+			expectError: "TTL must be a positive number of seconds",
+		},
 		{
 			name: "API error",
 			args: map[string]any{
-				"Origin": "fail.example.com",
+				"Origin": origin,
 				"TTL":    float64(1800),
 			},
-			mockSetup: func(m *MockCDNService) {
+			mockSetup: func(m *MockCDNService, _ *MockCertificatesService) {
 				m.EXPECT().
 					Create(gomock.Any(), &godo.CDNCreateRequest{
-						Origin: "fail.example.com",
+						Origin: origin,
 						TTL:    1800,
 					}).
 					Return(nil, nil, errors.New("api error")).
 					Times(1)
 			},
-			expectError: true,
+			expectError: "api error",
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			mockCDN := NewMockCDNService(ctrl)
+			mockCerts := NewMockCertificatesService(ctrl)
 			if tc.mockSetup != nil {
-				tc.mockSetup(mockCDN)
+				tc.mockSetup(mockCDN, mockCerts)
 			}
-			tool := setupCDNToolWithMock(mockCDN)
+			tool := NewCDNTool(func(ctx context.Context) (*godo.Client, error) {
+				return &godo.Client{CDNs: mockCDN, Certificates: mockCerts}, nil
+			})
 			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
 			resp, err := tool.createCDN(context.Background(), req)
-			if tc.expectError {
-				require.NotNil(t, resp)
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			if tc.expectError != "" {
 				require.True(t, resp.IsError)
+				require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.expectError)
 				return
 			}
-			require.NoError(t, err)
-			require.NotNil(t, resp)
 			require.False(t, resp.IsError)
 			var outCDN godo.CDN
 			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &outCDN))
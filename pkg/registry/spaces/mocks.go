@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/digitalocean/godo (interfaces: SpacesKeysService,CDNService)
+// Source: github.com/digitalocean/godo (interfaces: SpacesKeysService,CDNService,CertificatesService)
 //
 // Generated by this command:
 //
-//	mockgen -destination=./mocks.go -package spaces github.com/digitalocean/godo SpacesKeysService,CDNService
+//	mockgen -destination=./mocks.go -package spaces github.com/digitalocean/godo SpacesKeysService,CDNService,CertificatesService
 //
 
 // Package spaces is a generated GoMock package.
@@ -253,3 +253,106 @@ func (mr *MockCDNServiceMockRecorder) UpdateTTL(arg0, arg1, arg2 any) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTTL", reflect.TypeOf((*MockCDNService)(nil).UpdateTTL), arg0, arg1, arg2)
 }
+
+// MockCertificatesService is a mock of CertificatesService interface.
+type MockCertificatesService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCertificatesServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockCertificatesServiceMockRecorder is the mock recorder for MockCertificatesService.
+type MockCertificatesServiceMockRecorder struct {
+	mock *MockCertificatesService
+}
+
+// NewMockCertificatesService creates a new mock instance.
+func NewMockCertificatesService(ctrl *gomock.Controller) *MockCertificatesService {
+	mock := &MockCertificatesService{ctrl: ctrl}
+	mock.recorder = &MockCertificatesServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCertificatesService) EXPECT() *MockCertificatesServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCertificatesService) Create(arg0 context.Context, arg1 *godo.CertificateRequest) (*godo.Certificate, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*godo.Certificate)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCertificatesServiceMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCertificatesService)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockCertificatesService) Delete(arg0 context.Context, arg1 string) (*godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(*godo.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCertificatesServiceMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCertificatesService)(nil).Delete), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockCertificatesService) Get(arg0 context.Context, arg1 string) (*godo.Certificate, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*godo.Certificate)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCertificatesServiceMockRecorder) Get(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCertificatesService)(nil).Get), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockCertificatesService) List(arg0 context.Context, arg1 *godo.ListOptions) ([]godo.Certificate, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].([]godo.Certificate)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockCertificatesServiceMockRecorder) List(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCertificatesService)(nil).List), arg0, arg1)
+}
+
+// ListByName mocks base method.
+func (m *MockCertificatesService) ListByName(arg0 context.Context, arg1 string, arg2 *godo.ListOptions) ([]godo.Certificate, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByName", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]godo.Certificate)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListByName indicates an expected call of ListByName.
+func (mr *MockCertificatesServiceMockRecorder) ListByName(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByName", reflect.TypeOf((*MockCertificatesService)(nil).ListByName), arg0, arg1, arg2)
+}
@@ -0,0 +1,3 @@
+package tags
+
+//go:generate mockgen -destination=./mocks.go -package tags github.com/digitalocean/godo TagsService
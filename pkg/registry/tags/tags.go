@@ -0,0 +1,284 @@
+package tags
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"mcp-digitalocean/pkg/registry/common"
+)
+
+const defaultTagsPageSize = 20
+
+// tagNamePattern is what the API accepts as a tag name.
+var tagNamePattern = regexp.MustCompile(`^[A-Za-z0-9:_-]{1,255}$`)
+
+// resourceTypes are the resource types that can be tagged.
+var resourceTypes = []string{
+	string(godo.DropletResourceType),
+	string(godo.ImageResourceType),
+	string(godo.VolumeResourceType),
+	string(godo.LoadBalancerResourceType),
+	string(godo.VolumeSnapshotResourceType),
+	string(godo.DatabaseResourceType),
+}
+
+// TagsTool provides tag management tools.
+type TagsTool struct {
+	common.ToolLogger
+
+	client func(ctx context.Context) (*godo.Client, error)
+}
+
+// NewTagsTool creates a new TagsTool.
+func NewTagsTool(client func(ctx context.Context) (*godo.Client, error)) *TagsTool {
+	return &TagsTool{client: client}
+}
+
+// listTags lists tags with pagination.
+func (t *TagsTool) listTags(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	opt, pageMeta := common.ListOptionsFromArgs(req.GetArguments(), defaultTagsPageSize)
+
+	client, err := t.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	tags, resp, err := client.Tags.List(ctx, opt)
+	if err != nil {
+		return common.APIErrorResult(ctx, t.Logger(ctx), "api error", resp, err), nil
+	}
+
+	jsonData, err := json.MarshalIndent(tags, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return common.WithPageMeta(mcp.NewToolResultText(string(jsonData)), pageMeta)
+}
+
+// getTag gets a tag and the counts of the resources carrying it.
+func (t *TagsTool) getTag(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, _ := req.GetArguments()["Name"].(string)
+	if name == "" {
+		return mcp.NewToolResultError("Name is required"), nil
+	}
+
+	client, err := t.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	tag, _, err := client.Tags.Get(ctx, name)
+	if err != nil {
+		return common.GetErrorResult(ctx, t.Logger(ctx), "api error", "tag", name, err), nil
+	}
+
+	jsonData, err := json.MarshalIndent(tag, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+// createTag creates a tag. Creating a tag that exists returns it unchanged.
+func (t *TagsTool) createTag(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, _ := req.GetArguments()["Name"].(string)
+	if !tagNamePattern.MatchString(name) {
+		return mcp.NewToolResultError("Name must be 1-255 letters, numbers, colons, dashes and underscores"), nil
+	}
+
+	client, err := t.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	tag, resp, err := client.Tags.Create(ctx, &godo.TagCreateRequest{Name: name})
+	if err != nil {
+		return common.APIErrorResult(ctx, t.Logger(ctx), "api error", resp, err), nil
+	}
+
+	jsonData, err := json.MarshalIndent(tag, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+// deleteTag deletes a tag, removing it from every resource carrying it.
+func (t *TagsTool) deleteTag(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, _ := req.GetArguments()["Name"].(string)
+	if name == "" {
+		return mcp.NewToolResultError("Name is required"), nil
+	}
+
+	client, err := t.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	resp, err := client.Tags.Delete(ctx, name)
+	if err != nil {
+		return common.APIErrorResult(ctx, t.Logger(ctx), "api error", resp, err), nil
+	}
+	return mcp.NewToolResultText("Tag deleted successfully"), nil
+}
+
+// tagResources attaches a tag to resources.
+func (t *TagsTool) tagResources(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, resources, errResult := tagRequest(req)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	client, err := t.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	resp, err := client.Tags.TagResources(ctx, name, &godo.TagResourcesRequest{Resources: resources})
+	if err != nil {
+		return common.APIErrorResult(ctx, t.Logger(ctx), "api error", resp, err), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Tagged %d resources with %s", len(resources), name)), nil
+}
+
+// untagResources removes a tag from resources.
+func (t *TagsTool) untagResources(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, resources, errResult := tagRequest(req)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	client, err := t.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	resp, err := client.Tags.UntagResources(ctx, name, &godo.UntagResourcesRequest{Resources: resources})
+	if err != nil {
+		return common.APIErrorResult(ctx, t.Logger(ctx), "api error", resp, err), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Removed %s from %d resources", name, len(resources))), nil
+}
+
+// tagRequest reads the Name and Resources arguments of tag-resources and
+// untag-resources. Every resource is checked, and all invalid entries are
+// reported together, before the API is called.
+func tagRequest(req mcp.CallToolRequest) (string, []godo.Resource, *mcp.CallToolResult) {
+	args := req.GetArguments()
+	name, _ := args["Name"].(string)
+	if name == "" {
+		return "", nil, mcp.NewToolResultError("Name is required")
+	}
+	resourcesArg, _ := args["Resources"].([]any)
+	if len(resourcesArg) == 0 {
+		return "", nil, mcp.NewToolResultError("Resources must list at least one resource")
+	}
+
+	resources := make([]godo.Resource, 0, len(resourcesArg))
+	var invalid []string
+	for i, v := range resourcesArg {
+		r, _ := v.(map[string]any)
+		id := resourceID(r["resource_id"])
+		resourceType, _ := r["resource_type"].(string)
+		switch {
+		case id == "":
+			invalid = append(invalid, fmt.Sprintf("resource %d: resource_id is required", i))
+		case !slices.Contains(resourceTypes, resourceType):
+			invalid = append(invalid, fmt.Sprintf("resource %d: unknown resource_type %q", i, resourceType))
+		default:
+			resources = append(resources, godo.Resource{ID: id, Type: godo.ResourceType(resourceType)})
+		}
+	}
+	if len(invalid) > 0 {
+		return "", nil, mcp.NewToolResultError(fmt.Sprintf("invalid resources, nothing was changed: %s. resource_type must be one of %s",
+			strings.Join(invalid, "; "), strings.Join(resourceTypes, ", ")))
+	}
+	return name, resources, nil
+}
+
+// resourceID returns a resource_id given as a string, or as a number for a
+// droplet or image ID.
+func resourceID(v any) string {
+	switch id := v.(type) {
+	case string:
+		return id
+	case float64:
+		return strconv.FormatInt(int64(id), 10)
+	}
+	return ""
+}
+
+// resourcesSchema is the schema of the Resources argument.
+var resourcesSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"resource_id":   map[string]any{"type": "string", "description": "ID of the resource, e.g. a droplet ID or a volume UUID"},
+		"resource_type": map[string]any{"type": "string", "enum": resourceTypes},
+	},
+	"required": []string{"resource_id", "resource_type"},
+}
+
+// Tools returns the tag tools.
+func (t *TagsTool) Tools() []server.ServerTool {
+	return []server.ServerTool{
+		{
+			Handler: t.listTags,
+			Tool: mcp.NewTool("tag-list",
+				common.WithHints(common.HintsRead),
+				mcp.WithDescription("List tags with the counts of the resources carrying each"),
+				mcp.WithNumber("Page", mcp.DefaultNumber(1), mcp.Description("Page number")),
+				mcp.WithNumber("PerPage", mcp.DefaultNumber(defaultTagsPageSize), mcp.Description("Items per page")),
+			),
+		},
+		{
+			Handler: t.getTag,
+			Tool: mcp.NewTool("tag-get",
+				common.WithHints(common.HintsRead),
+				mcp.WithDescription("Get a tag with the counts of the resources carrying it"),
+				mcp.WithString("Name", mcp.Required(), mcp.Description("Name of the tag")),
+			),
+		},
+		{
+			Handler: t.createTag,
+			Tool: mcp.NewTool("tag-create",
+				common.WithHints(common.HintsToggle),
+				mcp.WithDescription("Create a tag. Tag droplets with tag-resources to use tools that act on droplets by tag"),
+				mcp.WithString("Name", mcp.Required(), mcp.Description("Name of the tag: letters, numbers, colons, dashes and underscores")),
+			),
+		},
+		{
+			Handler: t.deleteTag,
+			Tool: mcp.NewTool("tag-delete",
+				common.WithHints(common.HintsDelete),
+				mcp.WithDescription("Delete a tag and remove it from every resource carrying it. The resources themselves are kept"),
+				mcp.WithString("Name", mcp.Required(), mcp.Description("Name of the tag to delete")),
+			),
+		},
+		{
+			Handler: t.tagResources,
+			Tool: mcp.NewTool("tag-resources",
+				common.WithHints(common.HintsToggle),
+				mcp.WithDescription("Attach a tag to resources. The tag must exist; create it with tag-create"),
+				mcp.WithString("Name", mcp.Required(), mcp.Description("Name of the tag")),
+				mcp.WithArray("Resources", mcp.Required(), mcp.Description("Resources to tag, e.g. [{\"resource_id\": \"123\", \"resource_type\": \"droplet\"}]"), mcp.Items(resourcesSchema)),
+			),
+		},
+		{
+			Handler: t.untagResources,
+			Tool: mcp.NewTool("untag-resources",
+				common.WithHints(common.HintsToggle),
+				mcp.WithDescription("Remove a tag from resources"),
+				mcp.WithString("Name", mcp.Required(), mcp.Description("Name of the tag")),
+				mcp.WithArray("Resources", mcp.Required(), mcp.Description("Resources to untag, e.g. [{\"resource_id\": \"123\", \"resource_type\": \"droplet\"}]"), mcp.Items(resourcesSchema)),
+			),
+		},
+	}
+}
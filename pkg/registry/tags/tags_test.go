@@ -0,0 +1,141 @@
+package tags
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func setupTagsToolWithMocks(tags *MockTagsService) *TagsTool {
+	client := func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{Tags: tags}, nil
+	}
+	return NewTagsTool(client)
+}
+
+func TestTagsTool_createTag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockTags := NewMockTagsService(ctrl)
+	mockTags.EXPECT().Create(gomock.Any(), &godo.TagCreateRequest{Name: "env:prod"}).Return(&godo.Tag{Name: "env:prod"}, nil, nil).Times(1)
+	tool := setupTagsToolWithMocks(mockTags)
+
+	resp, err := tool.createTag(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"Name": "env:prod"}}})
+	require.NoError(t, err)
+	require.False(t, resp.IsError)
+	var out godo.Tag
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+	require.Equal(t, "env:prod", out.Name)
+
+	resp, err = tool.createTag(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"Name": "env prod"}}})
+	require.NoError(t, err)
+	require.True(t, resp.IsError)
+}
+
+func TestTagsTool_tagResources(t *testing.T) {
+	droplet := map[string]any{"resource_id": "123", "resource_type": "droplet"}
+	tests := []struct {
+		name        string
+		untag       bool
+		resources   []any
+		mockSetup   func(*MockTagsService)
+		expectText  string
+		expectError string
+	}{
+		{
+			name: "Attach",
+			resources: []any{
+				droplet,
+				map[string]any{"resource_id": float64(456), "resource_type": "droplet"},
+				map[string]any{"resource_id": "0c9a3b6e-6a1f-11ed-9d2b-0a58ac14d11e", "resource_type": "volume"},
+			},
+			mockSetup: func(m *MockTagsService) {
+				m.EXPECT().TagResources(gomock.Any(), "web", &godo.TagResourcesRequest{Resources: []godo.Resource{
+					{ID: "123", Type: godo.DropletResourceType},
+					{ID: "456", Type: godo.DropletResourceType},
+					{ID: "0c9a3b6e-6a1f-11ed-9d2b-0a58ac14d11e", Type: godo.VolumeResourceType},
+				}}).Return(nil, nil).Times(1)
+			},
+			expectText: "Tagged 3 resources with web",
+		},
+		{
+			name:      "Detach",
+			untag:     true,
+			resources: []any{droplet},
+			mockSetup: func(m *MockTagsService) {
+				m.EXPECT().UntagResources(gomock.Any(), "web", &godo.UntagResourcesRequest{Resources: []godo.Resource{
+					{ID: "123", Type: godo.DropletResourceType},
+				}}).Return(nil, nil).Times(1)
+			},
+			expectText: "Removed web from 1 resources",
+		},
+		{
+			name:        "Empty resources",
+			resources:   []any{},
+			expectError: "Resources must list at least one resource",
+		},
+		{
+			name: "Invalid resources are listed and nothing is changed",
+			resources: []any{
+				droplet,
+				map[string]any{"resource_id": "1", "resource_type": "kubernetes"},
+				map[string]any{"resource_type": "droplet"},
+			},
+			expectError: `invalid resources, nothing was changed: resource 1: unknown resource_type "kubernetes"; resource 2: resource_id is required`,
+		},
+		{
+			name:      "API error is propagated",
+			untag:     true,
+			resources: []any{droplet},
+			mockSetup: func(m *MockTagsService) {
+				m.EXPECT().UntagResources(gomock.Any(), "web", gomock.Any()).Return(nil, errors.New("tag not found")).Times(1)
+			},
+			expectError: "tag not found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockTags := NewMockTagsService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockTags)
+			}
+			tool := setupTagsToolWithMocks(mockTags)
+			handler := tool.tagResources
+			if tc.untag {
+				handler = tool.untagResources
+			}
+			resp, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+				"Name":      "web",
+				"Resources": tc.resources,
+			}}})
+			require.NoError(t, err)
+			text := resp.Content[0].(mcp.TextContent).Text
+			if tc.expectError != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, text, tc.expectError)
+				return
+			}
+			require.False(t, resp.IsError)
+			require.Equal(t, tc.expectText, text)
+		})
+	}
+}
+
+func TestTagsTool_deleteTag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockTags := NewMockTagsService(ctrl)
+	mockTags.EXPECT().Delete(gomock.Any(), "web").Return(nil, errors.New("forbidden")).Times(1)
+	tool := setupTagsToolWithMocks(mockTags)
+
+	resp, err := tool.deleteTag(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"Name": "web"}}})
+	require.NoError(t, err)
+	require.True(t, resp.IsError)
+	require.Contains(t, resp.Content[0].(mcp.TextContent).Text, "forbidden")
+}
@@ -0,0 +1,137 @@
+package volumes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"mcp-digitalocean/pkg/registry/common"
+)
+
+// dropletStatusOff is the status of a droplet that is shut down.
+const dropletStatusOff = "off"
+
+// Status values of a detachStep.
+const (
+	stepCompleted = "completed"
+	stepSkipped   = "skipped"
+	stepFailed    = "failed"
+)
+
+// detachStep is one step of volume-detach-safe and the action it ran, if any.
+type detachStep struct {
+	Step   string       `json:"step"`
+	Status string       `json:"status"`
+	Action *godo.Action `json:"action,omitempty"`
+	Detail string       `json:"detail,omitempty"`
+}
+
+// detachReport is the result of volume-detach-safe. Error is set when a step
+// failed and the steps after it were not run.
+type detachReport struct {
+	VolumeID  string       `json:"volume_id"`
+	DropletID int          `json:"droplet_id,omitempty"`
+	Steps     []detachStep `json:"steps"`
+	Warning   string       `json:"warning,omitempty"`
+	Error     string       `json:"error,omitempty"`
+}
+
+// detachSafe detaches a volume from the droplet it is attached to, shutting the
+// droplet down first when ShutdownFirst is set, since detaching a mounted
+// volume from a running droplet can corrupt its filesystem. Each step waits for
+// its action to finish; a failed step ends the sequence.
+func (v *VolumeActionsTool) detachSafe(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	volumeID, ok := args["VolumeID"].(string)
+	if !ok || volumeID == "" {
+		return mcp.NewToolResultError("Volume ID is required"), nil
+	}
+	shutdownFirst, _ := args["ShutdownFirst"].(bool)
+
+	client, err := v.client(ctx)
+	if err != nil {
+		return common.ErrorResult(ctx, v.Logger(ctx), "Error getting DigitalOcean client", err), nil
+	}
+
+	volume, _, err := client.Storage.GetVolume(ctx, volumeID)
+	if err != nil {
+		return common.GetErrorResult(ctx, v.Logger(ctx), "api error", "volume", volumeID, err), nil
+	}
+	if len(volume.DropletIDs) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("volume %s is not attached to a droplet; nothing was done", volumeID)), nil
+	}
+	// a volume is attached to at most one droplet.
+	dropletID := volume.DropletIDs[0]
+	report := &detachReport{VolumeID: volumeID, DropletID: dropletID}
+
+	droplet, _, err := client.Droplets.Get(ctx, dropletID)
+	if err != nil {
+		return report.fail("check droplet", fmt.Errorf("failed to get droplet %d: %w", dropletID, err))
+	}
+
+	switch {
+	case droplet.Status == dropletStatusOff:
+		report.Steps = append(report.Steps, detachStep{Step: "shutdown", Status: stepSkipped, Detail: "the droplet is already off"})
+	case shutdownFirst:
+		action, err := v.shutdown(ctx, client, dropletID)
+		if err != nil {
+			report.Steps = append(report.Steps, detachStep{Step: "shutdown", Status: stepFailed, Action: action})
+			return report.fail("", fmt.Errorf("failed to shut down droplet %d, the volume is still attached: %w", dropletID, err))
+		}
+		report.Steps = append(report.Steps, detachStep{Step: "shutdown", Status: stepCompleted, Action: action})
+	default:
+		report.Warning = fmt.Sprintf("droplet %d is %s; unmount the volume before detaching it, or pass ShutdownFirst: true", dropletID, droplet.Status)
+	}
+
+	action, err := v.detach(ctx, client, volumeID, dropletID)
+	if err != nil {
+		report.Steps = append(report.Steps, detachStep{Step: "detach", Status: stepFailed, Action: action})
+		return report.fail("", fmt.Errorf("failed to detach volume %s from droplet %d: %w", volumeID, dropletID, err))
+	}
+	report.Steps = append(report.Steps, detachStep{Step: "detach", Status: stepCompleted, Action: action})
+
+	jsonReport, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return common.ErrorResult(ctx, v.Logger(ctx), "marshal error", err), nil
+	}
+	return mcp.NewToolResultText(string(jsonReport)), nil
+}
+
+// fail returns the report as an error result. A non-empty step is added as
+// failed first.
+func (r *detachReport) fail(step string, err error) (*mcp.CallToolResult, error) {
+	if step != "" {
+		r.Steps = append(r.Steps, detachStep{Step: step, Status: stepFailed})
+	}
+	r.Error = err.Error()
+	jsonReport, marshalErr := json.MarshalIndent(r, "", "  ")
+	if marshalErr != nil {
+		return mcp.NewToolResultErrorFromErr("marshal error", marshalErr), nil
+	}
+	return mcp.NewToolResultError(string(jsonReport)), nil
+}
+
+// shutdown shuts the droplet down and waits for the action to finish.
+func (v *VolumeActionsTool) shutdown(ctx context.Context, client *godo.Client, dropletID int) (*godo.Action, error) {
+	action, _, err := client.DropletActions.Shutdown(ctx, dropletID)
+	if err != nil {
+		return nil, err
+	}
+	return common.WaitForAction(ctx, func(ctx context.Context) (*godo.Action, *godo.Response, error) {
+		return client.DropletActions.Get(ctx, dropletID, action.ID)
+	}, v.pollInterval, v.waitTimeout)
+}
+
+// detach detaches the volume from the droplet and waits for the action to
+// finish.
+func (v *VolumeActionsTool) detach(ctx context.Context, client *godo.Client, volumeID string, dropletID int) (*godo.Action, error) {
+	action, _, err := client.StorageActions.DetachByDropletID(ctx, volumeID, dropletID)
+	if err != nil {
+		return nil, err
+	}
+	return common.WaitForAction(ctx, func(ctx context.Context) (*godo.Action, *godo.Response, error) {
+		return client.StorageActions.Get(ctx, volumeID, action.ID)
+	}, v.pollInterval, v.waitTimeout)
+}
@@ -0,0 +1,163 @@
+package volumes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type detachSafeMocks struct {
+	storage        *MockStorageService
+	storageActions *MockStorageActionsService
+	droplets       *MockDropletsService
+	dropletActions *MockDropletActionsService
+}
+
+func setupDetachSafeWithMocks(m detachSafeMocks) *VolumeActionsTool {
+	client := func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{
+			Storage:        m.storage,
+			StorageActions: m.storageActions,
+			Droplets:       m.droplets,
+			DropletActions: m.dropletActions,
+		}, nil
+	}
+	tool := NewVolumeActionsTool(client)
+	tool.pollInterval = time.Millisecond
+	tool.waitTimeout = time.Second
+	return tool
+}
+
+func TestVolumeActionsTool_detachSafe(t *testing.T) {
+	attached := &godo.Volume{ID: "vol-1", DropletIDs: []int{42}}
+	tests := []struct {
+		name          string
+		args          map[string]any
+		mockSetup     func(m detachSafeMocks)
+		expectError   string
+		expectSteps   []string
+		expectWarning bool
+	}{
+		{
+			name: "Shutdown then detach",
+			args: map[string]any{"VolumeID": "vol-1", "ShutdownFirst": true},
+			mockSetup: func(m detachSafeMocks) {
+				m.storage.EXPECT().GetVolume(gomock.Any(), "vol-1").Return(attached, nil, nil)
+				m.droplets.EXPECT().Get(gomock.Any(), 42).Return(&godo.Droplet{ID: 42, Status: "active"}, nil, nil)
+				gomock.InOrder(
+					m.dropletActions.EXPECT().Shutdown(gomock.Any(), 42).Return(&godo.Action{ID: 1, Status: "in-progress"}, nil, nil),
+					m.dropletActions.EXPECT().Get(gomock.Any(), 42, 1).Return(&godo.Action{ID: 1, Status: "in-progress"}, nil, nil),
+					m.dropletActions.EXPECT().Get(gomock.Any(), 42, 1).Return(&godo.Action{ID: 1, Status: "completed"}, nil, nil),
+					m.storageActions.EXPECT().DetachByDropletID(gomock.Any(), "vol-1", 42).Return(&godo.Action{ID: 2, Status: "in-progress"}, nil, nil),
+					m.storageActions.EXPECT().Get(gomock.Any(), "vol-1", 2).Return(&godo.Action{ID: 2, Status: "completed"}, nil, nil),
+				)
+			},
+			expectSteps: []string{"shutdown:completed", "detach:completed"},
+		},
+		{
+			name: "Droplet already off skips the shutdown",
+			args: map[string]any{"VolumeID": "vol-1", "ShutdownFirst": true},
+			mockSetup: func(m detachSafeMocks) {
+				m.storage.EXPECT().GetVolume(gomock.Any(), "vol-1").Return(attached, nil, nil)
+				m.droplets.EXPECT().Get(gomock.Any(), 42).Return(&godo.Droplet{ID: 42, Status: "off"}, nil, nil)
+				m.storageActions.EXPECT().DetachByDropletID(gomock.Any(), "vol-1", 42).Return(&godo.Action{ID: 2, Status: "completed"}, nil, nil)
+				m.storageActions.EXPECT().Get(gomock.Any(), "vol-1", 2).Return(&godo.Action{ID: 2, Status: "completed"}, nil, nil)
+			},
+			expectSteps: []string{"shutdown:skipped", "detach:completed"},
+		},
+		{
+			name: "Running droplet without ShutdownFirst warns",
+			args: map[string]any{"VolumeID": "vol-1"},
+			mockSetup: func(m detachSafeMocks) {
+				m.storage.EXPECT().GetVolume(gomock.Any(), "vol-1").Return(attached, nil, nil)
+				m.droplets.EXPECT().Get(gomock.Any(), 42).Return(&godo.Droplet{ID: 42, Status: "active"}, nil, nil)
+				m.storageActions.EXPECT().DetachByDropletID(gomock.Any(), "vol-1", 42).Return(&godo.Action{ID: 2, Status: "completed"}, nil, nil)
+				m.storageActions.EXPECT().Get(gomock.Any(), "vol-1", 2).Return(&godo.Action{ID: 2, Status: "completed"}, nil, nil)
+			},
+			expectSteps:   []string{"detach:completed"},
+			expectWarning: true,
+		},
+		{
+			name: "Shutdown failure stops before detaching",
+			args: map[string]any{"VolumeID": "vol-1", "ShutdownFirst": true},
+			mockSetup: func(m detachSafeMocks) {
+				m.storage.EXPECT().GetVolume(gomock.Any(), "vol-1").Return(attached, nil, nil)
+				m.droplets.EXPECT().Get(gomock.Any(), 42).Return(&godo.Droplet{ID: 42, Status: "active"}, nil, nil)
+				m.dropletActions.EXPECT().Shutdown(gomock.Any(), 42).Return(&godo.Action{ID: 1, Status: "in-progress"}, nil, nil)
+				m.dropletActions.EXPECT().Get(gomock.Any(), 42, 1).Return(&godo.Action{ID: 1, Status: "errored"}, nil, nil)
+			},
+			expectError: "failed to shut down droplet 42, the volume is still attached",
+			expectSteps: []string{"shutdown:failed"},
+		},
+		{
+			name: "Detach failure is reported after the shutdown",
+			args: map[string]any{"VolumeID": "vol-1", "ShutdownFirst": true},
+			mockSetup: func(m detachSafeMocks) {
+				m.storage.EXPECT().GetVolume(gomock.Any(), "vol-1").Return(attached, nil, nil)
+				m.droplets.EXPECT().Get(gomock.Any(), 42).Return(&godo.Droplet{ID: 42, Status: "active"}, nil, nil)
+				m.dropletActions.EXPECT().Shutdown(gomock.Any(), 42).Return(&godo.Action{ID: 1, Status: "completed"}, nil, nil)
+				m.dropletActions.EXPECT().Get(gomock.Any(), 42, 1).Return(&godo.Action{ID: 1, Status: "completed"}, nil, nil)
+				m.storageActions.EXPECT().DetachByDropletID(gomock.Any(), "vol-1", 42).Return(nil, nil, errors.New("volume is busy"))
+			},
+			expectError: "volume is busy",
+			expectSteps: []string{"shutdown:completed", "detach:failed"},
+		},
+		{
+			name: "Unattached volume does nothing",
+			args: map[string]any{"VolumeID": "vol-1", "ShutdownFirst": true},
+			mockSetup: func(m detachSafeMocks) {
+				m.storage.EXPECT().GetVolume(gomock.Any(), "vol-1").Return(&godo.Volume{ID: "vol-1"}, nil, nil)
+			},
+			expectError: "volume vol-1 is not attached to a droplet; nothing was done",
+		},
+		{
+			name:        "Missing VolumeID",
+			args:        map[string]any{},
+			expectError: "Volume ID is required",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			m := detachSafeMocks{
+				storage:        NewMockStorageService(ctrl),
+				storageActions: NewMockStorageActionsService(ctrl),
+				droplets:       NewMockDropletsService(ctrl),
+				dropletActions: NewMockDropletActionsService(ctrl),
+			}
+			if tc.mockSetup != nil {
+				tc.mockSetup(m)
+			}
+			tool := setupDetachSafeWithMocks(m)
+
+			resp, err := tool.detachSafe(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			text := resp.Content[0].(mcp.TextContent).Text
+			require.Equal(t, tc.expectError != "", resp.IsError, text)
+			if tc.expectError != "" {
+				require.Contains(t, text, tc.expectError)
+			}
+			if tc.expectSteps == nil {
+				return
+			}
+
+			var report detachReport
+			require.NoError(t, json.Unmarshal([]byte(text), &report))
+			require.Equal(t, 42, report.DropletID)
+			var steps []string
+			for _, s := range report.Steps {
+				steps = append(steps, s.Step+":"+s.Status)
+			}
+			require.Equal(t, tc.expectSteps, steps)
+			require.Equal(t, tc.expectWarning, report.Warning != "")
+		})
+	}
+}
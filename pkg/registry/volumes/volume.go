@@ -3,25 +3,61 @@ package volumes
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"time"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"mcp-digitalocean/pkg/registry/common"
 )
 
 type VolumeTool struct {
-	client func(ctx context.Context) (*godo.Client, error)
+	client       func(ctx context.Context) (*godo.Client, error)
+	pollInterval time.Duration
+	waitTimeout  time.Duration
 }
 
 const (
 	defaultVolumeListPage    = 1
 	defaultVolumeListPerPage = 50
-	maxVolumeListPerPage     = 200
 )
 
 // NewVolumeTool creates a new VolumeTool instance
 func NewVolumeTool(client func(ctx context.Context) (*godo.Client, error)) *VolumeTool {
-	return &VolumeTool{client: client}
+	return &VolumeTool{
+		client:       client,
+		pollInterval: common.DefaultActionPollInterval,
+		waitTimeout:  common.DefaultActionWaitTimeout,
+	}
+}
+
+// createdVolume is the result of volume-create: the volume, and the attach
+// action when AttachToDropletID was given. Warning is set when the volume was
+// created but could not be attached.
+type createdVolume struct {
+	*godo.Volume
+	AttachAction *godo.Action `json:"attach_action,omitempty"`
+	MountHint    string       `json:"mount_hint,omitempty"`
+	Warning      string       `json:"warning,omitempty"`
+}
+
+// mountHint returns the commands that mount a formatted volume on the droplet
+// it is attached to. DigitalOcean exposes volumes by name under
+// /dev/disk/by-id.
+func mountHint(name string) string {
+	return fmt.Sprintf("mkdir -p /mnt/%[1]s && mount -o discard,defaults /dev/disk/by-id/scsi-0DO_Volume_%[1]s /mnt/%[1]s", name)
+}
+
+// attach attaches the volume to the droplet and waits for the action to finish.
+func (vt *VolumeTool) attach(ctx context.Context, client *godo.Client, volumeID string, dropletID int) (*godo.Action, error) {
+	action, _, err := client.StorageActions.Attach(ctx, volumeID, dropletID)
+	if err != nil {
+		return nil, err
+	}
+	return common.WaitForAction(ctx, func(ctx context.Context) (*godo.Action, *godo.Response, error) {
+		return client.StorageActions.Get(ctx, volumeID, action.ID)
+	}, vt.pollInterval, vt.waitTimeout)
 }
 
 func (vt *VolumeTool) createVolume(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -46,6 +82,7 @@ func (vt *VolumeTool) createVolume(ctx context.Context, req mcp.CallToolRequest)
 	filesystemType, _ := args["FilesystemType"].(string)
 	filesystemLabel, _ := args["FilesystemLabel"].(string)
 	tagsArg, _ := args["Tags"].([]any)
+	attachTo, _ := args["AttachToDropletID"].(float64)
 
 	var tags []string
 	for _, t := range tagsArg {
@@ -75,7 +112,21 @@ func (vt *VolumeTool) createVolume(ctx context.Context, req mcp.CallToolRequest)
 		return mcp.NewToolResultErrorFromErr("api error", err), nil
 	}
 
-	jsonVolume, err := json.MarshalIndent(volume, "", "  ")
+	result := createdVolume{Volume: volume}
+	if filesystemType != "" {
+		result.MountHint = mountHint(volume.Name)
+	}
+	if attachTo >= 1 {
+		// the volume exists either way, so a failed attach is reported
+		// alongside it rather than as an error.
+		action, err := vt.attach(ctx, client, volume.ID, int(attachTo))
+		result.AttachAction = action
+		if err != nil {
+			result.Warning = fmt.Sprintf("volume created, but attaching it to droplet %d failed: %v", int(attachTo), err)
+		}
+	}
+
+	jsonVolume, err := json.MarshalIndent(result, "", "  ")
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("marshal error", err), nil
 	}
@@ -88,25 +139,12 @@ func (vt *VolumeTool) listVolumes(ctx context.Context, req mcp.CallToolRequest)
 	name, _ := args["Name"].(string)
 	region, _ := args["Region"].(string)
 
-	page, ok := args["Page"].(float64)
-	if !ok || page < 1 {
-		page = defaultVolumeListPage
-	}
-	perPage, ok := args["PerPage"].(float64)
-	if !ok || perPage < 1 {
-		perPage = defaultVolumeListPerPage
-	}
-	if perPage > maxVolumeListPerPage {
-		perPage = maxVolumeListPerPage
-	}
+	opt, pageMeta := common.ListOptionsFromArgs(args, defaultVolumeListPerPage)
 
 	listRequest := &godo.ListVolumeParams{
-		Name:   name,
-		Region: region,
-		ListOptions: &godo.ListOptions{
-			Page:    int(page),
-			PerPage: int(perPage),
-		},
+		Name:        name,
+		Region:      region,
+		ListOptions: opt,
 	}
 
 	client, err := vt.client(ctx)
@@ -139,7 +177,7 @@ func (vt *VolumeTool) listVolumes(ctx context.Context, req mcp.CallToolRequest)
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("marshal error", err), nil
 	}
-	return mcp.NewToolResultText(string(jsonVolumes)), nil
+	return common.WithPageMeta(mcp.NewToolResultText(string(jsonVolumes)), pageMeta)
 }
 
 func (vt *VolumeTool) getVolumeByID(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -236,29 +274,14 @@ func (vt *VolumeTool) listSnapshots(ctx context.Context, req mcp.CallToolRequest
 		return mcp.NewToolResultError("Volume ID is required"), nil
 	}
 
-	page, ok := args["Page"].(float64)
-	if !ok || page < 1 {
-		page = defaultVolumeListPage
-	}
-	perPage, ok := args["PerPage"].(float64)
-	if !ok || perPage < 1 {
-		perPage = defaultVolumeListPerPage
-	}
-	if perPage > maxVolumeListPerPage {
-		perPage = maxVolumeListPerPage
-	}
+	opt, pageMeta := common.ListOptionsFromArgs(args, defaultVolumeListPerPage)
 
 	client, err := vt.client(ctx)
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("Error getting DigitalOcean client", err), nil
 	}
 
-	options := &godo.ListOptions{
-		Page:    int(page),
-		PerPage: int(perPage),
-	}
-
-	snapshots, _, err := client.Storage.ListSnapshots(ctx, volumeID, options)
+	snapshots, _, err := client.Storage.ListSnapshots(ctx, volumeID, opt)
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("api error", err), nil
 	}
@@ -282,7 +305,7 @@ func (vt *VolumeTool) listSnapshots(ctx context.Context, req mcp.CallToolRequest
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("marshal error", err), nil
 	}
-	return mcp.NewToolResultText(string(jsonSnapshots)), nil
+	return common.WithPageMeta(mcp.NewToolResultText(string(jsonSnapshots)), pageMeta)
 }
 
 func (vt *VolumeTool) getSnapshotByID(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -335,6 +358,7 @@ func (vt *VolumeTool) Tools() []server.ServerTool {
 			Handler: vt.createVolume,
 			Tool: mcp.NewTool(
 				"volume-create",
+				common.WithCreatesResource(),
 				mcp.WithDescription("Create a new block storage volume"),
 				mcp.WithString("Name", mcp.Required(), mcp.Description("The name of the volume")),
 				mcp.WithNumber("SizeGigaBytes", mcp.Required(), mcp.Description("The size of the volume in GB")),
@@ -344,6 +368,7 @@ func (vt *VolumeTool) Tools() []server.ServerTool {
 				mcp.WithString("FilesystemType", mcp.Description("The filesystem type for the volume, e.g. ext4 or xfs (optional)")),
 				mcp.WithString("FilesystemLabel", mcp.Description("The filesystem label for the volume (optional)")),
 				mcp.WithArray("Tags", mcp.Description("Tags to apply"), mcp.Items(map[string]any{"type": "string"})),
+				mcp.WithNumber("AttachToDropletID", mcp.Description("ID of a droplet in the same region to attach the volume to once it is created. The call waits for the attach to finish (optional)")),
 			),
 		},
 		{
@@ -405,7 +430,7 @@ func (vt *VolumeTool) Tools() []server.ServerTool {
 			Handler: vt.deleteSnapshot,
 			Tool: mcp.NewTool(
 				"volume-snapshot-delete",
-				mcp.WithDescription("Delete a snapshot by ID"),
+				mcp.WithDescription("Delete a snapshot by ID. The API does not record which volumes were created from a snapshot, so references cannot be checked; volumes created from it are independent copies and keep working after it is deleted"),
 				mcp.WithString("ID", mcp.Required(), mcp.Description("The ID of the snapshot to delete")),
 			),
 		},
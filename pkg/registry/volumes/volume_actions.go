@@ -3,19 +3,29 @@ package volumes
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"mcp-digitalocean/pkg/registry/common"
 )
 
 type VolumeActionsTool struct {
-	client func(ctx context.Context) (*godo.Client, error)
+	common.ToolLogger
+
+	client       func(ctx context.Context) (*godo.Client, error)
+	pollInterval time.Duration
+	waitTimeout  time.Duration
 }
 
 // NewVolumeActionsTool creates a new VolumeActionsTool instance
 func NewVolumeActionsTool(client func(ctx context.Context) (*godo.Client, error)) *VolumeActionsTool {
-	return &VolumeActionsTool{client: client}
+	return &VolumeActionsTool{
+		client:       client,
+		pollInterval: common.DefaultActionPollInterval,
+		waitTimeout:  common.DefaultActionWaitTimeout,
+	}
 }
 
 func (v *VolumeActionsTool) attachVolume(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -31,17 +41,17 @@ func (v *VolumeActionsTool) attachVolume(ctx context.Context, req mcp.CallToolRe
 
 	client, err := v.client(ctx)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("Error getting DigitalOcean client", err), nil
+		return common.ErrorResult(ctx, v.Logger(ctx), "Error getting DigitalOcean client", err), nil
 	}
 
 	action, _, err := client.StorageActions.Attach(ctx, volumeID, int(dropletID))
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, v.Logger(ctx), "api error", err), nil
 	}
 
 	jsonAction, err := json.MarshalIndent(action, "", "  ")
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("marshal error", err), nil
+		return common.ErrorResult(ctx, v.Logger(ctx), "marshal error", err), nil
 	}
 	return mcp.NewToolResultText(string(jsonAction)), nil
 }
@@ -59,17 +69,17 @@ func (v *VolumeActionsTool) detachVolume(ctx context.Context, req mcp.CallToolRe
 
 	client, err := v.client(ctx)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("Error getting DigitalOcean client", err), nil
+		return common.ErrorResult(ctx, v.Logger(ctx), "Error getting DigitalOcean client", err), nil
 	}
 
 	action, _, err := client.StorageActions.DetachByDropletID(ctx, volumeID, int(dropletID))
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, v.Logger(ctx), "api error", err), nil
 	}
 
 	jsonAction, err := json.MarshalIndent(action, "", "  ")
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("marshal error", err), nil
+		return common.ErrorResult(ctx, v.Logger(ctx), "marshal error", err), nil
 	}
 	return mcp.NewToolResultText(string(jsonAction)), nil
 }
@@ -87,16 +97,16 @@ func (v *VolumeActionsTool) getVolumeAction(ctx context.Context, req mcp.CallToo
 
 	client, err := v.client(ctx)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("Error getting DigitalOcean client", err), nil
+		return common.ErrorResult(ctx, v.Logger(ctx), "Error getting DigitalOcean client", err), nil
 	}
 
 	action, _, err := client.StorageActions.Get(ctx, volumeID, int(actionID))
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, v.Logger(ctx), "api error", err), nil
 	}
 	jsonAction, err := json.MarshalIndent(action, "", "  ")
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("marshal error", err), nil
+		return common.ErrorResult(ctx, v.Logger(ctx), "marshal error", err), nil
 	}
 	return mcp.NewToolResultText(string(jsonAction)), nil
 }
@@ -108,37 +118,22 @@ func (v *VolumeActionsTool) listVolumeActions(ctx context.Context, req mcp.CallT
 		return mcp.NewToolResultError("Volume ID is required"), nil
 	}
 
-	page, ok := args["Page"].(float64)
-	if !ok || page < 1 {
-		page = defaultVolumeListPage
-	}
-	perPage, ok := args["PerPage"].(float64)
-	if !ok || perPage < 1 {
-		perPage = defaultVolumeListPerPage
-	}
-	if perPage > maxVolumeListPerPage {
-		perPage = maxVolumeListPerPage
-	}
+	opt, pageMeta := common.ListOptionsFromArgs(args, defaultVolumeListPerPage)
 
 	client, err := v.client(ctx)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("Error getting DigitalOcean client", err), nil
+		return common.ErrorResult(ctx, v.Logger(ctx), "Error getting DigitalOcean client", err), nil
 	}
 
-	options := &godo.ListOptions{
-		Page:    int(page),
-		PerPage: int(perPage),
-	}
-
-	actions, _, err := client.StorageActions.List(ctx, volumeID, options)
+	actions, _, err := client.StorageActions.List(ctx, volumeID, opt)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, v.Logger(ctx), "api error", err), nil
 	}
 	jsonActions, err := json.MarshalIndent(actions, "", "  ")
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("marshal error", err), nil
+		return common.ErrorResult(ctx, v.Logger(ctx), "marshal error", err), nil
 	}
-	return mcp.NewToolResultText(string(jsonActions)), nil
+	return common.WithPageMeta(mcp.NewToolResultText(string(jsonActions)), pageMeta)
 }
 
 func (v *VolumeActionsTool) resizeVolume(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -157,22 +152,22 @@ func (v *VolumeActionsTool) resizeVolume(ctx context.Context, req mcp.CallToolRe
 	}
 	client, err := v.client(ctx)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("Error getting DigitalOcean client", err), nil
+		return common.ErrorResult(ctx, v.Logger(ctx), "Error getting DigitalOcean client", err), nil
 	}
 
 	action, _, err := client.StorageActions.Resize(ctx, volumeID, int(sizeGigaBytes), region)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.ErrorResult(ctx, v.Logger(ctx), "api error", err), nil
 	}
 
 	jsonAction, err := json.MarshalIndent(action, "", "  ")
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("marshal error", err), nil
+		return common.ErrorResult(ctx, v.Logger(ctx), "marshal error", err), nil
 	}
 	return mcp.NewToolResultText(string(jsonAction)), nil
 }
 
-// Tools returns MCP server tools for volume lifecycle actions (attach, detach, resize, and action list/get).
+// Tools returns MCP server tools for volume lifecycle actions (attach, detach, safe detach, resize, and action list/get).
 func (v *VolumeActionsTool) Tools() []server.ServerTool {
 	return []server.ServerTool{
 		{
@@ -191,6 +186,14 @@ func (v *VolumeActionsTool) Tools() []server.ServerTool {
 				mcp.WithNumber("DropletID", mcp.Required(), mcp.Description("The ID of the droplet to detach the volume from")),
 			),
 		},
+		{
+			Handler: v.detachSafe,
+			Tool: mcp.NewTool("volume-detach-safe",
+				mcp.WithDescription("Detach a volume from the droplet it is attached to, optionally shutting the droplet down first and waiting for it to be off. Reports the action of each step; a failed step stops the sequence and the report lists the steps completed before it"),
+				mcp.WithString("VolumeID", mcp.Required(), mcp.Description("The ID of the volume to detach")),
+				mcp.WithBoolean("ShutdownFirst", mcp.DefaultBool(false), mcp.Description("Shut the droplet down before detaching, so a mounted volume is not detached from a running droplet. The droplet is left off")),
+			),
+		},
 		{
 			Handler: v.getVolumeAction,
 			Tool: mcp.NewTool("volume-action-get",
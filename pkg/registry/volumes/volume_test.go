@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -702,3 +703,98 @@ func TestVolumeTool_getSnapshotByID(t *testing.T) {
 		})
 	}
 }
+
+func TestVolumeTool_createVolumeAttach(t *testing.T) {
+	testVolume := &godo.Volume{ID: "vol-1", Name: "data", SizeGigaBytes: 10, Region: &godo.Region{Slug: "nyc1"}}
+	createRequest := &godo.VolumeCreateRequest{Name: "data", SizeGigaBytes: 10, Region: "nyc1", FilesystemType: "ext4"}
+
+	tests := []struct {
+		name            string
+		args            map[string]any
+		mockSetup       func(*MockStorageService, *MockStorageActionsService)
+		expectedAction  *godo.Action
+		expectedHint    string
+		expectedWarning string
+	}{
+		{
+			name: "Attach and wait",
+			args: map[string]any{"Name": "data", "SizeGigaBytes": float64(10), "Region": "nyc1", "FilesystemType": "ext4", "AttachToDropletID": float64(42)},
+			mockSetup: func(s *MockStorageService, a *MockStorageActionsService) {
+				s.EXPECT().CreateVolume(gomock.Any(), createRequest).Return(testVolume, nil, nil)
+				a.EXPECT().Attach(gomock.Any(), "vol-1", 42).Return(&godo.Action{ID: 7, Type: "attach_volume", Status: "in-progress"}, nil, nil)
+				gomock.InOrder(
+					a.EXPECT().Get(gomock.Any(), "vol-1", 7).Return(&godo.Action{ID: 7, Type: "attach_volume", Status: "in-progress"}, nil, nil),
+					a.EXPECT().Get(gomock.Any(), "vol-1", 7).Return(&godo.Action{ID: 7, Type: "attach_volume", Status: "completed"}, nil, nil),
+				)
+			},
+			expectedAction: &godo.Action{ID: 7, Type: "attach_volume", Status: "completed"},
+			expectedHint:   "mkdir -p /mnt/data && mount -o discard,defaults /dev/disk/by-id/scsi-0DO_Volume_data /mnt/data",
+		},
+		{
+			name: "Attach fails after create",
+			args: map[string]any{"Name": "data", "SizeGigaBytes": float64(10), "Region": "nyc1", "FilesystemType": "ext4", "AttachToDropletID": float64(42)},
+			mockSetup: func(s *MockStorageService, a *MockStorageActionsService) {
+				s.EXPECT().CreateVolume(gomock.Any(), createRequest).Return(testVolume, nil, nil)
+				a.EXPECT().Attach(gomock.Any(), "vol-1", 42).Return(nil, nil, errors.New("droplet is in another region"))
+			},
+			expectedHint:    "mkdir -p /mnt/data && mount -o discard,defaults /dev/disk/by-id/scsi-0DO_Volume_data /mnt/data",
+			expectedWarning: "volume created, but attaching it to droplet 42 failed: droplet is in another region",
+		},
+		{
+			name: "Attach action errors",
+			args: map[string]any{"Name": "data", "SizeGigaBytes": float64(10), "Region": "nyc1", "FilesystemType": "ext4", "AttachToDropletID": float64(42)},
+			mockSetup: func(s *MockStorageService, a *MockStorageActionsService) {
+				s.EXPECT().CreateVolume(gomock.Any(), createRequest).Return(testVolume, nil, nil)
+				a.EXPECT().Attach(gomock.Any(), "vol-1", 42).Return(&godo.Action{ID: 7, Type: "attach_volume", Status: "in-progress"}, nil, nil)
+				a.EXPECT().Get(gomock.Any(), "vol-1", 7).Return(&godo.Action{ID: 7, Type: "attach_volume", Status: "errored"}, nil, nil)
+			},
+			expectedAction:  &godo.Action{ID: 7, Type: "attach_volume", Status: "errored"},
+			expectedHint:    "mkdir -p /mnt/data && mount -o discard,defaults /dev/disk/by-id/scsi-0DO_Volume_data /mnt/data",
+			expectedWarning: "volume created, but attaching it to droplet 42 failed: action 7 (attach_volume) errored",
+		},
+		{
+			name: "No filesystem, no attach",
+			args: map[string]any{"Name": "data", "SizeGigaBytes": float64(10), "Region": "nyc1"},
+			mockSetup: func(s *MockStorageService, a *MockStorageActionsService) {
+				s.EXPECT().CreateVolume(gomock.Any(), &godo.VolumeCreateRequest{Name: "data", SizeGigaBytes: 10, Region: "nyc1"}).Return(testVolume, nil, nil)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStorage := NewMockStorageService(ctrl)
+			mockActions := NewMockStorageActionsService(ctrl)
+			tc.mockSetup(mockStorage, mockActions)
+			tool := NewVolumeTool(func(ctx context.Context) (*godo.Client, error) {
+				return &godo.Client{Storage: mockStorage, StorageActions: mockActions}, nil
+			})
+			tool.pollInterval = time.Millisecond
+
+			resp, err := tool.createVolume(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			require.False(t, resp.IsError)
+
+			var out struct {
+				ID           string       `json:"id"`
+				Name         string       `json:"name"`
+				AttachAction *godo.Action `json:"attach_action"`
+				MountHint    string       `json:"mount_hint"`
+				Warning      string       `json:"warning"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+			require.Equal(t, "vol-1", out.ID)
+			require.Equal(t, "data", out.Name)
+			require.Equal(t, tc.expectedAction, out.AttachAction)
+			require.Equal(t, tc.expectedHint, out.MountHint)
+			require.Equal(t, tc.expectedWarning, out.Warning)
+		})
+	}
+}
+
+func TestMountHint(t *testing.T) {
+	require.Equal(t,
+		"mkdir -p /mnt/pg-data-01 && mount -o discard,defaults /dev/disk/by-id/scsi-0DO_Volume_pg-data-01 /mnt/pg-data-01",
+		mountHint("pg-data-01"))
+}
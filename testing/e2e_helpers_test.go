@@ -7,12 +7,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"mcp-digitalocean/internal/testhelpers"
+	"mcp-digitalocean/pkg/registry/dbaas"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/client"
@@ -21,7 +23,7 @@ import (
 )
 
 const (
-	dbaasClusterStatusOnline = "online"
+	dbaasClusterStatusOnline = dbaas.ClusterStatusOnline
 
 	// Configuration Defaults
 	defaultDropletSize             = "s-1vcpu-1gb"
@@ -307,13 +309,18 @@ func DeleteResource(t *testing.T, resourceType string, id any) {
 	ctx, c := getTestClient(t)
 
 	toolName := fmt.Sprintf("%s-delete", resourceType)
-	if resourceType == "snapshot" {
-		toolName = "image-delete"
-	}
-
 	args := map[string]any{
 		"ID": id,
 	}
+	if resourceType == "snapshot" {
+		// snapshot-delete takes droplet and volume snapshot IDs as strings.
+		// FormatFloat keeps a float64 ID from JSON out of exponent form.
+		if v, ok := id.(float64); ok {
+			args["ID"] = strconv.FormatFloat(v, 'f', -1, 64)
+		} else {
+			args["ID"] = fmt.Sprint(id)
+		}
+	}
 
 	resp, err := c.CallTool(ctx, mcp.CallToolRequest{
 		Params: mcp.CallToolParams{
@@ -0,0 +1,61 @@
+//go:build integration
+
+package testing
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMCPServer_ClientLogging verifies that once a client sets a logging level,
+// tool errors logged by the server reach it as notifications/message.
+func TestMCPServer_ClientLogging(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	c := initializeClient(ctx, t)
+	defer c.Close()
+
+	var (
+		mu       sync.Mutex
+		messages []mcp.JSONRPCNotification
+	)
+	c.OnNotification(func(notification mcp.JSONRPCNotification) {
+		if notification.Method != string(mcp.MethodNotificationMessage) {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		messages = append(messages, notification)
+	})
+
+	err := c.SetLevel(ctx, mcp.SetLevelRequest{Params: mcp.SetLevelParams{Level: mcp.LoggingLevelWarning}})
+	require.NoError(t, err)
+
+	// an empty ID fails validation, which the tool logging middleware logs at ERROR.
+	result, err := c.CallTool(ctx, mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Name: "spaces-cdn-get", Arguments: map[string]any{"ID": ""}},
+	})
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+
+	pollCondition(t, 10*time.Second, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(messages) > 0
+	}, "expected a log notification after the tool error")
+
+	mu.Lock()
+	defer mu.Unlock()
+	notification := messages[0]
+	require.Equal(t, string(mcp.LoggingLevelError), notification.Params.AdditionalFields["level"])
+	data, ok := notification.Params.AdditionalFields["data"].(map[string]any)
+	require.True(t, ok, "notification data should be a log entry")
+	require.Equal(t, "tool call result", data["message"])
+	require.Equal(t, "spaces-cdn-get", data["tool"])
+}